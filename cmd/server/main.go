@@ -7,15 +7,20 @@
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	chimiddleware "github.com/go-chi/chi/middleware"
 	flag "github.com/spf13/pflag"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/build/server"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/config"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
+	msgconfig "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/config"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/secrets"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/frontend"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/workers"
 )
 
 const (
@@ -53,6 +58,55 @@ func initializeLocalSecretManager(ctx context.Context) secrets.SecretManager {
 	return sm
 }
 
+// startWorkers runs the background workers inside this process, which, paired with the
+// in-memory message queue provider, makes for a single-binary deployment.
+func startWorkers(ctx context.Context, logger logging.Logger, cfg *config.InstanceConfig) error {
+	// the server has already run any migrations by this point.
+	wcfg := *cfg
+	wcfg.Database.RunMigrations = false
+
+	dataManager, err := config.ProvideDatabaseClient(ctx, logger, &wcfg)
+	if err != nil {
+		return err
+	}
+
+	pcfg := cfg.Events
+	pcfg.RedisStreamsConfig.ConsumerGroup = workers.ConsumerGroup
+
+	consumerProvider, err := msgconfig.ProvideConsumerProvider(logger, &pcfg)
+	if err != nil {
+		return err
+	}
+
+	publisherProvider, err := msgconfig.ProvidePublisherProvider(logger, &pcfg)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+	}
+
+	indexManagerProvider, err := config.ProvideSearchIndexManagerProvider(cfg, dataManager)
+	if err != nil {
+		return err
+	}
+
+	indexPath := config.ProvideSearchIndexPath(cfg)
+
+	emailer, err := email.ProvideEmailer(logger, &cfg.Email)
+	if err != nil {
+		return err
+	}
+
+	emailRenderer, err := email.ProvideRenderer(&cfg.Email, frontend.ProvideLocalizer())
+	if err != nil {
+		return err
+	}
+
+	return workers.StartWorkers(ctx, logger, client, dataManager, consumerProvider, publisherProvider, indexPath, indexManagerProvider, emailer, emailRenderer)
+}
+
 func main() {
 	flag.Parse()
 
@@ -110,6 +164,12 @@ func main() {
 		logger.Fatal(fmt.Errorf("initializing HTTP server: %w", err))
 	}
 
+	if cfg.Server.RunWorkers {
+		if err = startWorkers(ctx, logger, cfg); err != nil {
+			logger.Fatal(fmt.Errorf("starting workers: %w", err))
+		}
+	}
+
 	initSpan.End()
 	cancel()
 
@@ -13,6 +13,7 @@
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/config"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
 	dbconfig "gitlab.com/verygoodsoftwarenotvirus/todo/internal/database/config"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	msgconfig "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/config"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
@@ -55,10 +56,16 @@
 	// search index paths.
 	localElasticsearchLocation = "http://elasticsearch:9200"
 
+	// email settings.
+	emailFromAddress = "todo@localhost"
+	emailFromName    = "Todo"
+	localBaseURL     = "http://localhost:8888"
+
 	// message provider topics
 	preWritesTopicName   = "pre_writes"
 	preUpdatesTopicName  = "pre_updates"
 	preArchivesTopicName = "pre_archives"
+	dataChangesTopicName = "data_changes"
 
 	pasetoSecretSize      = 32
 	maxAttempts           = 50
@@ -92,6 +99,12 @@
 		SecureOnly: false,
 	}
 
+	localWebAuthnConfig = authservice.WebAuthnConfig{
+		RelyingPartyID:          defaultCookieDomain,
+		RelyingPartyDisplayName: authservice.DefaultWebAuthnRelyingPartyDisplayName,
+		RelyingPartyOrigin:      localBaseURL,
+	}
+
 	localTracingConfig = tracing.Config{
 		Provider:                  "jaeger",
 		SpanCollectionProbability: 1,
@@ -226,9 +239,16 @@ func localDevelopmentConfig(ctx context.Context, filePath string) error {
 		Search: search.Config{
 			Provider: search.ElasticsearchProvider,
 		},
+		Email: email.Config{
+			Provider:    email.ProviderLog,
+			FromAddress: emailFromAddress,
+			FromName:    emailFromName,
+			BaseURL:     localBaseURL,
+		},
 		Services: config.ServicesConfigurations{
 			Accounts: accounts.Config{
-				PreWritesTopicName: preWritesTopicName,
+				PreWritesTopicName:   preWritesTopicName,
+				DataChangesTopicName: dataChangesTopicName,
 			},
 			Auth: authservice.Config{
 				PASETO: authservice.PASETOConfig{
@@ -237,15 +257,19 @@ func localDevelopmentConfig(ctx context.Context, filePath string) error {
 					LocalModeKey: examplePASETOKey,
 				},
 				Cookies:               localCookies,
+				WebAuthn:              localWebAuthnConfig,
 				Debug:                 true,
 				EnableUserSignup:      true,
 				MinimumUsernameLength: 4,
 				MinimumPasswordLength: 8,
+				DataChangesTopicName:  dataChangesTopicName,
 			},
 			Frontend: buildLocalFrontendServiceConfig(),
 			Webhooks: webhooksservice.Config{
 				PreWritesTopicName:   preWritesTopicName,
+				PreUpdatesTopicName:  preUpdatesTopicName,
 				PreArchivesTopicName: preArchivesTopicName,
+				DataChangesTopicName: dataChangesTopicName,
 				Debug:                true,
 				Enabled:              false,
 			},
@@ -311,9 +335,16 @@ func frontendTestsConfig(ctx context.Context, filePath string) error {
 		Search: search.Config{
 			Provider: search.ElasticsearchProvider,
 		},
+		Email: email.Config{
+			Provider:    email.ProviderMemory,
+			FromAddress: emailFromAddress,
+			FromName:    emailFromName,
+			BaseURL:     localBaseURL,
+		},
 		Services: config.ServicesConfigurations{
 			Accounts: accounts.Config{
-				PreWritesTopicName: preWritesTopicName,
+				PreWritesTopicName:   preWritesTopicName,
+				DataChangesTopicName: dataChangesTopicName,
 			},
 			Auth: authservice.Config{
 				PASETO: authservice.PASETOConfig{
@@ -322,15 +353,19 @@ func frontendTestsConfig(ctx context.Context, filePath string) error {
 					LocalModeKey: examplePASETOKey,
 				},
 				Cookies:               localCookies,
+				WebAuthn:              localWebAuthnConfig,
 				Debug:                 true,
 				EnableUserSignup:      true,
 				MinimumUsernameLength: 4,
 				MinimumPasswordLength: 8,
+				DataChangesTopicName:  dataChangesTopicName,
 			},
 			Frontend: buildLocalFrontendServiceConfig(),
 			Webhooks: webhooksservice.Config{
 				PreWritesTopicName:   preWritesTopicName,
+				PreUpdatesTopicName:  preUpdatesTopicName,
 				PreArchivesTopicName: preArchivesTopicName,
+				DataChangesTopicName: dataChangesTopicName,
 				Debug:                true,
 				Enabled:              false,
 			},
@@ -414,9 +449,16 @@ func buildIntegrationTestForDBImplementation(dbVendor, dbDetails string) configF
 			Search: search.Config{
 				Provider: search.ElasticsearchProvider,
 			},
+			Email: email.Config{
+				Provider:    email.ProviderMemory,
+				FromAddress: emailFromAddress,
+				FromName:    emailFromName,
+				BaseURL:     localBaseURL,
+			},
 			Services: config.ServicesConfigurations{
 				Accounts: accounts.Config{
-					PreWritesTopicName: preWritesTopicName,
+					PreWritesTopicName:   preWritesTopicName,
+					DataChangesTopicName: dataChangesTopicName,
 				},
 				Auth: authservice.Config{
 					PASETO: authservice.PASETOConfig{
@@ -431,15 +473,19 @@ func buildIntegrationTestForDBImplementation(dbVendor, dbDetails string) configF
 						Lifetime:   authservice.DefaultCookieLifetime,
 						SecureOnly: false,
 					},
+					WebAuthn:              localWebAuthnConfig,
 					Debug:                 false,
 					EnableUserSignup:      true,
 					MinimumUsernameLength: 4,
 					MinimumPasswordLength: 8,
+					DataChangesTopicName:  dataChangesTopicName,
 				},
 				Frontend: buildLocalFrontendServiceConfig(),
 				Webhooks: webhooksservice.Config{
 					PreWritesTopicName:   preWritesTopicName,
+					PreUpdatesTopicName:  preUpdatesTopicName,
 					PreArchivesTopicName: preArchivesTopicName,
+					DataChangesTopicName: dataChangesTopicName,
 					Debug:                true,
 					Enabled:              false,
 				},
@@ -131,7 +131,7 @@ func main() {
 
 					createdAccount, accountCreationError := userClient.CreateAccount(ctx, fakes.BuildFakeAccountCreationInput())
 					if accountCreationError != nil {
-						quitter.ComplainAndQuit(fmt.Errorf("creating account %s: %w", j, accountCreationError))
+						quitter.ComplainAndQuit(fmt.Errorf("creating account %d: %w", j, accountCreationError))
 					}
 
 					iterationLogger.WithValue(keys.AccountIDKey, createdAccount.ID).Debug("created account")
@@ -146,7 +146,7 @@ func main() {
 
 					code, codeErr := totp.GenerateCode(strings.ToUpper(createdUser.TwoFactorSecret), time.Now().UTC())
 					if codeErr != nil {
-						quitter.ComplainAndQuit(fmt.Errorf("creating API Client %s: %w", j, codeErr))
+						quitter.ComplainAndQuit(fmt.Errorf("creating API Client %d: %w", j, codeErr))
 					}
 
 					fakeInput := fakes.BuildFakeAPIClientCreationInput()
@@ -160,7 +160,7 @@ func main() {
 						Name: fakeInput.Name,
 					})
 					if apiClientCreationErr != nil {
-						quitter.ComplainAndQuit(fmt.Errorf("API Client webhook %s: %w", j, apiClientCreationErr))
+						quitter.ComplainAndQuit(fmt.Errorf("API Client webhook %d: %w", j, apiClientCreationErr))
 					}
 
 					iterationLogger.WithValue(keys.APIClientDatabaseIDKey, createdAPIClient.ID).Debug("created API Client")
@@ -173,12 +173,12 @@ func main() {
 				for j := 0; j < int(dataCount); j++ {
 					iterationLogger := userLogger.WithValue("creating", "webhooks").WithValue("iteration", j)
 
-					createdWebhookID, webhookCreationErr := userClient.CreateWebhook(ctx, fakes.BuildFakeWebhookCreationInput())
+					createdWebhook, webhookCreationErr := userClient.CreateWebhook(ctx, fakes.BuildFakeWebhookCreationInput())
 					if webhookCreationErr != nil {
-						quitter.ComplainAndQuit(fmt.Errorf("creating webhook %s: %w", j, webhookCreationErr))
+						quitter.ComplainAndQuit(fmt.Errorf("creating webhook %d: %w", j, webhookCreationErr))
 					}
 
-					iterationLogger.WithValue(keys.WebhookIDKey, createdWebhookID).Debug("created webhook")
+					iterationLogger.WithValue(keys.WebhookIDKey, createdWebhook.ID).Debug("created webhook")
 				}
 				wg.Done()
 			}(wg)
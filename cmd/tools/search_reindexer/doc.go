@@ -0,0 +1,5 @@
+/*
+Command search_reindexer rebuilds the items search index from the database, and reports any
+items that were missing from, or orphaned in, the index beforehand
+*/
+package main
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/config"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/reindex"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/secrets"
+)
+
+const (
+	configFilepathEnvVar = "CONFIGURATION_FILEPATH"
+	configStoreEnvVarKey = "TODO_SEARCH_REINDEXER_LOCAL_CONFIG_STORE_KEY"
+)
+
+var (
+	configFilepath string
+	debug          bool
+)
+
+func init() {
+	flag.StringVarP(&configFilepath, "config", "c", os.Getenv(configFilepathEnvVar), "where the instance configuration is stored")
+	flag.BoolVarP(&debug, "debug", "z", false, "whether debug mode is enabled")
+}
+
+func initializeLocalSecretManager(ctx context.Context, envVarKey string) secrets.SecretManager {
+	logger := logging.NewNoopLogger()
+
+	cfg := &secrets.Config{
+		Provider: secrets.ProviderLocal,
+		Key:      os.Getenv(envVarKey),
+	}
+
+	k, err := secrets.ProvideSecretKeeper(ctx, cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	sm, err := secrets.ProvideSecretManager(logger, k)
+	if err != nil {
+		panic(err)
+	}
+
+	return sm
+}
+
+func main() {
+	flag.Parse()
+
+	ctx := context.Background()
+	logger := logging.ProvideLogger(logging.Config{Provider: logging.ProviderZerolog})
+
+	if debug {
+		logger.SetLevel(logging.DebugLevel)
+	}
+
+	if configFilepath == "" {
+		log.Fatal("no config provided")
+	}
+
+	configBytes, err := os.ReadFile(configFilepath)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	sm := initializeLocalSecretManager(ctx, configStoreEnvVarKey)
+
+	var cfg *config.InstanceConfig
+	if err = sm.Decrypt(ctx, string(configBytes), &cfg); err != nil || cfg == nil {
+		logger.Fatal(err)
+	}
+
+	cfg.Database.RunMigrations = false
+
+	dataManager, err := config.ProvideDatabaseClient(ctx, logger, cfg)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	indexManagerProvider, err := config.ProvideSearchIndexManagerProvider(cfg, dataManager)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	reindexer := reindex.ProvideReindexer(logger, dataManager, indexManagerProvider, config.ProvideSearchIndexPath(cfg))
+
+	report, err := reindexer.ReindexItems(ctx)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "\t")
+
+	if err = encoder.Encode(report); err != nil {
+		logger.Fatal(err)
+	}
+}
@@ -19,8 +19,10 @@ func buildBasicEditorTemplate(cfg *basicEditorTemplateConfig) string {
 }
 
 type basicEditorTemplateConfig struct {
-	SubmissionURL string
-	Fields        []formField
+	SubmissionURL    string
+	Fields           []formField
+	ShowItemPresence bool
+	IncludeVersion   bool
 }
 
 var editorConfigs = map[string]*basicEditorTemplateConfig{
@@ -82,6 +84,8 @@ type basicEditorTemplateConfig struct {
 		},
 	},
 	"internal/services/frontend/templates/partials/generated/editors/item_editor.gotpl": {
+		ShowItemPresence: true,
+		IncludeVersion:   true,
 		Fields: []formField{
 			{
 				LabelName:       "name",
@@ -9,22 +9,13 @@
 	"syscall"
 	"time"
 
-	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/elasticsearch"
-
-	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/workers"
-
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/config"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
 	msgconfig "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/config"
-	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/consumers"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/secrets"
-)
-
-const (
-	preWritesTopicName   = "pre_writes"
-	dataChangesTopicName = "data_changes"
-	preUpdatesTopicName  = "pre_updates"
-	preArchivesTopicName = "pre_archives"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/frontend"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/workers"
 )
 
 func initializeLocalSecretManager(ctx context.Context, envVarKey string) secrets.SecretManager {
@@ -54,10 +45,6 @@ func initializeLocalSecretManager(ctx context.Context, envVarKey string) secrets
 )
 
 func main() {
-	const (
-		addr = "worker_queue:6379"
-	)
-
 	ctx := context.Background()
 
 	logger := logging.ProvideLogger(logging.Config{
@@ -103,90 +90,44 @@ func main() {
 		logger.Fatal(err)
 	}
 
-	pcfg := &msgconfig.Config{
-		Provider: msgconfig.ProviderRedis,
-		RedisConfig: msgconfig.RedisConfig{
-			QueueAddress: addr,
-		},
-	}
-
-	consumerProvider := consumers.ProvideRedisConsumerProvider(logger, addr)
+	pcfg := cfg.Events
+	pcfg.RedisStreamsConfig.ConsumerGroup = workers.ConsumerGroup
 
-	publisherProvider, err := msgconfig.ProvidePublisherProvider(logger, pcfg)
+	consumerProvider, err := msgconfig.ProvideConsumerProvider(logger, &pcfg)
 	if err != nil {
 		logger.Fatal(err)
 	}
 
-	// post-writes worker
-
-	postWritesWorker := workers.ProvideDataChangesWorker(logger)
-	postWritesConsumer, err := consumerProvider.ProviderConsumer(ctx, dataChangesTopicName, postWritesWorker.HandleMessage)
+	publisherProvider, err := msgconfig.ProvidePublisherProvider(logger, &pcfg)
 	if err != nil {
 		logger.Fatal(err)
 	}
 
-	go postWritesConsumer.Consume(nil, nil)
-
-	// pre-writes worker
-
 	client := &http.Client{
 		Timeout: 5 * time.Second,
 	}
 
-	postWritesPublisher, err := publisherProvider.ProviderPublisher(dataChangesTopicName)
+	indexManagerProvider, err := config.ProvideSearchIndexManagerProvider(cfg, dataManager)
 	if err != nil {
 		logger.Fatal(err)
 	}
 
-	preWritesWorker, err := workers.ProvidePreWritesWorker(ctx, logger, client, dataManager, postWritesPublisher, "http://elasticsearch:9200", elasticsearch.NewIndexManager)
-	if err != nil {
-		logger.Fatal(err)
-	}
+	indexPath := config.ProvideSearchIndexPath(cfg)
 
-	preWritesConsumer, err := consumerProvider.ProviderConsumer(ctx, preWritesTopicName, preWritesWorker.HandleMessage)
+	emailer, err := email.ProvideEmailer(logger, &cfg.Email)
 	if err != nil {
 		logger.Fatal(err)
 	}
 
-	go preWritesConsumer.Consume(nil, nil)
-	// pre-updates worker
-
-	postUpdatesPublisher, err := publisherProvider.ProviderPublisher(dataChangesTopicName)
+	emailRenderer, err := email.ProvideRenderer(&cfg.Email, frontend.ProvideLocalizer())
 	if err != nil {
 		logger.Fatal(err)
 	}
 
-	preUpdatesWorker, err := workers.ProvidePreUpdatesWorker(ctx, logger, client, dataManager, postUpdatesPublisher, "http://elasticsearch:9200", elasticsearch.NewIndexManager)
-	if err != nil {
+	if err = workers.StartWorkers(ctx, logger, client, dataManager, consumerProvider, publisherProvider, indexPath, indexManagerProvider, emailer, emailRenderer); err != nil {
 		logger.Fatal(err)
 	}
 
-	preUpdatesConsumer, err := consumerProvider.ProviderConsumer(ctx, preUpdatesTopicName, preUpdatesWorker.HandleMessage)
-	if err != nil {
-		logger.Fatal(err)
-	}
-
-	go preUpdatesConsumer.Consume(nil, nil)
-
-	// pre-archives worker
-
-	postArchivesPublisher, err := publisherProvider.ProviderPublisher(dataChangesTopicName)
-	if err != nil {
-		logger.Fatal(err)
-	}
-
-	preArchivesWorker, err := workers.ProvidePreArchivesWorker(ctx, logger, client, dataManager, postArchivesPublisher, "http://elasticsearch:9200", elasticsearch.NewIndexManager)
-	if err != nil {
-		logger.Fatal(err)
-	}
-
-	preArchivesConsumer, err := consumerProvider.ProviderConsumer(ctx, preArchivesTopicName, preArchivesWorker.HandleMessage)
-	if err != nil {
-		logger.Fatal(err)
-	}
-
-	go preArchivesConsumer.Consume(nil, nil)
-
 	logger.Info("working...")
 
 	// wait for signal to exit
@@ -24,10 +24,13 @@ require (
 	github.com/brianvoe/gofakeit/v5 v5.11.2
 	github.com/carolynvs/magex v0.5.0 // indirect
 	github.com/cenkalti/backoff v2.2.1+incompatible // indirect
+	github.com/coreos/go-oidc v2.2.1+incompatible
 	github.com/cznic/ql v1.2.0 // indirect
+	github.com/duo-labs/webauthn v0.0.0-20210727191636-9f1b88ef44cc
 	github.com/elastic/go-elasticsearch/v8 v8.0.0-20211001143748-fd99a833e74f // indirect
 	github.com/emicklei/hazana v1.9.6 // indirect
 	github.com/felixge/httpsnoop v1.0.2 // indirect
+	github.com/fxamacker/cbor/v2 v2.2.0
 	github.com/go-chi/chi v1.5.4
 	github.com/go-chi/cors v1.2.0
 	github.com/go-ozzo/ozzo-validation/v4 v4.3.0
@@ -66,6 +69,7 @@ require (
 	github.com/olahol/melody v0.0.0-20180227134253-7bd65910e5ab // indirect
 	github.com/olivere/elastic/v7 v7.0.29
 	github.com/pelletier/go-toml v1.9.0 // indirect
+	github.com/pquerna/cachecontrol v0.0.0-20180517163645-1555304b9b35 // indirect
 	github.com/pquerna/otp v1.3.0
 	github.com/prometheus/common v0.23.0 // indirect
 	github.com/rs/zerolog v1.21.0
@@ -107,6 +111,7 @@ require (
 	gopkg.in/DATA-DOG/go-sqlmock.v1 v1.3.0 // indirect
 	gopkg.in/ini.v1 v1.62.0 // indirect
 	gopkg.in/mikespook/gorbac.v2 v2.1.0
+	gopkg.in/square/go-jose.v2 v2.6.0
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
 )
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"context"
+
+	chimiddleware "github.com/go-chi/chi/middleware"
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// RequestIDFromContext returns the ID of the request a context belongs to, if there is one.
+func RequestIDFromContext(ctx context.Context) string {
+	return chimiddleware.GetReqID(ctx)
+}
+
+// Record writes an audit log entry, filling in its ID and the current request ID if they're missing.
+// The operation being audited has already happened by the time we get here, so failures are logged
+// rather than returned.
+func Record(ctx context.Context, logger logging.Logger, dataManager types.AuditLogEntryDataManager, input *types.AuditLogEntryCreationInput) {
+	if dataManager == nil || input == nil {
+		return
+	}
+
+	logger = logging.EnsureLogger(logger).WithValue(keys.AuditLogEntryEventTypeKey, input.EventType)
+
+	if input.ID == "" {
+		input.ID = ksuid.New().String()
+	}
+
+	if input.RequestID == "" {
+		input.RequestID = RequestIDFromContext(ctx)
+	}
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		logger.Error(err, "validating audit log entry")
+		return
+	}
+
+	if err := dataManager.CreateAuditLogEntry(ctx, input); err != nil {
+		logger.Error(err, "recording audit log entry")
+	}
+}
@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	chimiddleware "github.com/go-chi/chi/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+func TestRequestIDFromContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), chimiddleware.RequestIDKey, t.Name())
+
+		assert.Equal(t, t.Name(), RequestIDFromContext(ctx))
+	})
+
+	T.Run("without request ID", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, RequestIDFromContext(context.Background()))
+	})
+}
+
+func TestRecord(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), chimiddleware.RequestIDKey, t.Name())
+		exampleInput := fakes.BuildFakeAuditLogEntryCreationInput()
+		exampleInput.ID = ""
+		exampleInput.RequestID = ""
+
+		dataManager := &mocktypes.AuditLogEntryDataManager{}
+		dataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.ID != "" && input.RequestID == t.Name()
+			}),
+		).Return(nil)
+
+		Record(ctx, logging.NewNoopLogger(), dataManager, exampleInput)
+
+		mock.AssertExpectationsForObjects(t, dataManager)
+	})
+
+	T.Run("preserves provided request ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), chimiddleware.RequestIDKey, t.Name())
+		exampleInput := fakes.BuildFakeAuditLogEntryCreationInput()
+		expectedRequestID := exampleInput.RequestID
+
+		dataManager := &mocktypes.AuditLogEntryDataManager{}
+		dataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.RequestID == expectedRequestID
+			}),
+		).Return(nil)
+
+		Record(ctx, logging.NewNoopLogger(), dataManager, exampleInput)
+
+		mock.AssertExpectationsForObjects(t, dataManager)
+	})
+
+	T.Run("with nil data manager", func(t *testing.T) {
+		t.Parallel()
+
+		Record(context.Background(), logging.NewNoopLogger(), nil, fakes.BuildFakeAuditLogEntryCreationInput())
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		dataManager := &mocktypes.AuditLogEntryDataManager{}
+
+		Record(context.Background(), logging.NewNoopLogger(), dataManager, &types.AuditLogEntryCreationInput{})
+
+		mock.AssertExpectationsForObjects(t, dataManager)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		exampleInput := fakes.BuildFakeAuditLogEntryCreationInput()
+
+		dataManager := &mocktypes.AuditLogEntryDataManager{}
+		dataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			exampleInput,
+		).Return(errors.New("blah"))
+
+		Record(ctx, logging.NewNoopLogger(), dataManager, exampleInput)
+
+		mock.AssertExpectationsForObjects(t, dataManager)
+	})
+}
@@ -0,0 +1,4 @@
+/*
+Package audit provides helpers for recording who changed what in the audit log
+*/
+package audit
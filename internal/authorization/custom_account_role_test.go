@@ -0,0 +1,79 @@
+package authorization
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBuiltInAccountRole(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, IsBuiltInAccountRole(AccountAdminRole.String()))
+		assert.True(t, IsBuiltInAccountRole(AccountMemberRole.String()))
+		assert.False(t, IsBuiltInAccountRole("viewer"))
+		assert.False(t, IsBuiltInAccountRole(""))
+	})
+}
+
+func TestNewCustomAccountRolePermissionChecker(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with only custom permissions", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewCustomAccountRolePermissionChecker(nil, []string{ReadItemsPermission.ID(), SearchItemsPermission.ID()})
+
+		assert.True(t, r.HasPermission(ReadItemsPermission))
+		assert.True(t, r.HasPermission(SearchItemsPermission))
+		assert.False(t, r.HasPermission(CreateItemsPermission))
+		assert.False(t, r.CanUpdateAccounts())
+		assert.False(t, r.CanCreateWebhooks())
+	})
+
+	T.Run("with built-in roles", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewCustomAccountRolePermissionChecker(
+			[]string{AccountMemberRole.String()},
+			[]string{CreateWebhooksPermission.ID(), ReadWebhooksPermission.ID()},
+		)
+
+		assert.True(t, r.HasPermission(CreateItemsPermission))
+		assert.True(t, r.CanCreateWebhooks())
+		assert.True(t, r.CanSeeWebhooks())
+		assert.False(t, r.CanArchiveWebhooks())
+		assert.False(t, r.CanUpdateAccounts())
+	})
+
+	T.Run("with nothing", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewCustomAccountRolePermissionChecker(nil, nil)
+
+		assert.False(t, r.HasPermission(ReadItemsPermission))
+		assert.False(t, r.CanDeleteAPIClients())
+	})
+
+	T.Run("survives gob encoding", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			b   bytes.Buffer
+			out AccountRolePermissionsChecker
+		)
+
+		var in AccountRolePermissionsChecker = NewCustomAccountRolePermissionChecker(nil, []string{ReadItemsPermission.ID()})
+		require.NoError(t, gob.NewEncoder(&b).Encode(&in))
+		require.NoError(t, gob.NewDecoder(&b).Decode(&out))
+
+		assert.True(t, out.HasPermission(ReadItemsPermission))
+		assert.False(t, out.HasPermission(CreateItemsPermission))
+	})
+}
@@ -18,6 +18,14 @@
 	ReadUserPermission Permission = "read.user"
 	// SearchUserPermission is a service admin permission.
 	SearchUserPermission Permission = "search.user"
+	// ReindexSearchPermission is a service admin permission.
+	ReindexSearchPermission Permission = "reindex.search"
+	// ReadAllAuditLogEntriesPermission is a service admin permission.
+	ReadAllAuditLogEntriesPermission Permission = "read.all_audit_log_entries"
+	// RevokeUserSessionsPermission is a service admin permission.
+	RevokeUserSessionsPermission Permission = "revoke.user_sessions"
+	// ReadWebsocketPresencePermission is a service admin permission.
+	ReadWebsocketPresencePermission Permission = "read.websocket_presence"
 
 	// UpdateAccountPermission is an account admin permission.
 	UpdateAccountPermission Permission = "update.account"
@@ -45,6 +53,16 @@
 	ReadAPIClientsPermission Permission = "read.api_clients"
 	// ArchiveAPIClientsPermission is an account admin permission.
 	ArchiveAPIClientsPermission Permission = "archive.api_clients"
+	// CreateAccountRolesPermission is an account admin permission.
+	CreateAccountRolesPermission Permission = "create.account_roles"
+	// ReadAccountRolesPermission is an account admin permission.
+	ReadAccountRolesPermission Permission = "read.account_roles"
+	// UpdateAccountRolesPermission is an account admin permission.
+	UpdateAccountRolesPermission Permission = "update.account_roles"
+	// ArchiveAccountRolesPermission is an account admin permission.
+	ArchiveAccountRolesPermission Permission = "archive.account_roles"
+	// ReadAuditLogEntriesPermission is an account admin permission.
+	ReadAuditLogEntriesPermission Permission = "read.audit_log_entries"
 
 	// CreateItemsPermission is an account user permission.
 	CreateItemsPermission Permission = "create.items"
@@ -71,10 +89,14 @@ func (p Permission) Match(perm gorbac.Permission) bool {
 var (
 	// service admin permissions.
 	serviceAdminPermissions = map[string]gorbac.Permission{
-		CycleCookieSecretPermission.ID(): CycleCookieSecretPermission,
-		UpdateUserStatusPermission.ID():  UpdateUserStatusPermission,
-		ReadUserPermission.ID():          ReadUserPermission,
-		SearchUserPermission.ID():        SearchUserPermission,
+		CycleCookieSecretPermission.ID():      CycleCookieSecretPermission,
+		UpdateUserStatusPermission.ID():       UpdateUserStatusPermission,
+		ReadUserPermission.ID():               ReadUserPermission,
+		SearchUserPermission.ID():             SearchUserPermission,
+		ReindexSearchPermission.ID():          ReindexSearchPermission,
+		ReadAllAuditLogEntriesPermission.ID(): ReadAllAuditLogEntriesPermission,
+		RevokeUserSessionsPermission.ID():     RevokeUserSessionsPermission,
+		ReadWebsocketPresencePermission.ID():  ReadWebsocketPresencePermission,
 	}
 
 	// account admin permissions.
@@ -92,6 +114,11 @@ func (p Permission) Match(perm gorbac.Permission) bool {
 		CreateAPIClientsPermission.ID():                  CreateAPIClientsPermission,
 		ReadAPIClientsPermission.ID():                    ReadAPIClientsPermission,
 		ArchiveAPIClientsPermission.ID():                 ArchiveAPIClientsPermission,
+		CreateAccountRolesPermission.ID():                CreateAccountRolesPermission,
+		ReadAccountRolesPermission.ID():                  ReadAccountRolesPermission,
+		UpdateAccountRolesPermission.ID():                UpdateAccountRolesPermission,
+		ArchiveAccountRolesPermission.ID():               ArchiveAccountRolesPermission,
+		ReadAuditLogEntriesPermission.ID():               ReadAuditLogEntriesPermission,
 	}
 
 	// account member permissions.
@@ -120,3 +147,17 @@ func init() {
 		must(accountMember.Assign(perm))
 	}
 }
+
+// IsServicePermission returns whether a permission is a service admin permission.
+func IsServicePermission(p string) bool {
+	_, ok := serviceAdminPermissions[p]
+	return ok
+}
+
+// IsAccountPermission returns whether a permission is an account admin or account member permission.
+func IsAccountPermission(p string) bool {
+	_, isAdminPermission := accountAdminPermissions[p]
+	_, isMemberPermission := accountMemberPermissions[p]
+
+	return isAdminPermission || isMemberPermission
+}
@@ -0,0 +1,156 @@
+package authorization
+
+import (
+	"encoding/gob"
+)
+
+// scopedPermissionCollection is a fixed set of permissions, rather than a set of roles.
+// It is what remains when a set of roles is narrowed to a scope, as with API clients.
+type scopedPermissionCollection struct {
+	Permissions []string
+	Admin       bool
+}
+
+func init() {
+	gob.Register(scopedPermissionCollection{})
+}
+
+// intersectPermissions returns the permissions in a scope that a checker grants.
+func intersectPermissions(hasPermission func(Permission) bool, scope ...string) []string {
+	granted := []string{}
+
+	for _, p := range scope {
+		if hasPermission(Permission(p)) {
+			granted = append(granted, p)
+		}
+	}
+
+	return granted
+}
+
+// NewScopedAccountRolePermissionChecker returns a checker that grants only the permissions
+// in the provided scope that the provided checker would have granted too.
+func NewScopedAccountRolePermissionChecker(checker AccountRolePermissionsChecker, scope ...string) AccountRolePermissionsChecker {
+	x := &scopedPermissionCollection{}
+
+	if checker != nil {
+		x.Permissions = intersectPermissions(checker.HasPermission, scope...)
+	}
+
+	return x
+}
+
+// NewScopedServiceRolePermissionChecker returns a checker that grants only the permissions
+// in the provided scope that the provided checker would have granted too. It is only
+// considered a service admin if it retains at least one service admin permission.
+func NewScopedServiceRolePermissionChecker(checker ServiceRolePermissionChecker, scope ...string) ServiceRolePermissionChecker {
+	x := &scopedPermissionCollection{}
+
+	if checker != nil {
+		x.Permissions = intersectPermissions(checker.HasPermission, scope...)
+		x.Admin = checker.IsServiceAdmin() && len(x.Permissions) > 0
+	}
+
+	return x
+}
+
+// HasPermission returns whether a permission is in the scope.
+func (r scopedPermissionCollection) HasPermission(p Permission) bool {
+	for _, x := range r.Permissions {
+		if x == p.ID() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AsAccountRolePermissionChecker returns the scope as an account role permission checker.
+func (r scopedPermissionCollection) AsAccountRolePermissionChecker() AccountRolePermissionsChecker {
+	return &scopedPermissionCollection{Permissions: r.Permissions}
+}
+
+// IsServiceAdmin returns whether the scope retains service admin permissions.
+func (r scopedPermissionCollection) IsServiceAdmin() bool {
+	return r.Admin
+}
+
+// CanCycleCookieSecrets returns whether the scope allows cycling cookie secrets or not.
+func (r scopedPermissionCollection) CanCycleCookieSecrets() bool {
+	return r.HasPermission(CycleCookieSecretPermission)
+}
+
+// CanUpdateUserReputations returns whether the scope allows updating user reputations or not.
+func (r scopedPermissionCollection) CanUpdateUserReputations() bool {
+	return r.HasPermission(UpdateUserStatusPermission)
+}
+
+// CanSeeUserData returns whether the scope allows viewing users or not.
+func (r scopedPermissionCollection) CanSeeUserData() bool {
+	return r.HasPermission(ReadUserPermission)
+}
+
+// CanSearchUsers returns whether the scope allows searching for users or not.
+func (r scopedPermissionCollection) CanSearchUsers() bool {
+	return r.HasPermission(SearchUserPermission)
+}
+
+// CanUpdateAccounts returns whether the scope allows updating accounts or not.
+func (r scopedPermissionCollection) CanUpdateAccounts() bool {
+	return r.HasPermission(UpdateAccountPermission)
+}
+
+// CanDeleteAccounts returns whether the scope allows deleting accounts or not.
+func (r scopedPermissionCollection) CanDeleteAccounts() bool {
+	return r.HasPermission(ArchiveAccountPermission)
+}
+
+// CanAddMemberToAccounts returns whether the scope allows adding members to accounts or not.
+func (r scopedPermissionCollection) CanAddMemberToAccounts() bool {
+	return r.HasPermission(AddMemberAccountPermission)
+}
+
+// CanRemoveMemberFromAccounts returns whether the scope allows removing members from accounts or not.
+func (r scopedPermissionCollection) CanRemoveMemberFromAccounts() bool {
+	return r.HasPermission(RemoveMemberAccountPermission)
+}
+
+// CanTransferAccountToNewOwner returns whether the scope allows transferring an account to a new owner or not.
+func (r scopedPermissionCollection) CanTransferAccountToNewOwner() bool {
+	return r.HasPermission(TransferAccountPermission)
+}
+
+// CanCreateWebhooks returns whether the scope allows creating webhooks or not.
+func (r scopedPermissionCollection) CanCreateWebhooks() bool {
+	return r.HasPermission(CreateWebhooksPermission)
+}
+
+// CanSeeWebhooks returns whether the scope allows viewing webhooks or not.
+func (r scopedPermissionCollection) CanSeeWebhooks() bool {
+	return r.HasPermission(ReadWebhooksPermission)
+}
+
+// CanUpdateWebhooks returns whether the scope allows updating webhooks or not.
+func (r scopedPermissionCollection) CanUpdateWebhooks() bool {
+	return r.HasPermission(UpdateWebhooksPermission)
+}
+
+// CanArchiveWebhooks returns whether the scope allows deleting webhooks or not.
+func (r scopedPermissionCollection) CanArchiveWebhooks() bool {
+	return r.HasPermission(ArchiveWebhooksPermission)
+}
+
+// CanCreateAPIClients returns whether the scope allows creating API clients or not.
+func (r scopedPermissionCollection) CanCreateAPIClients() bool {
+	return r.HasPermission(CreateAPIClientsPermission)
+}
+
+// CanSeeAPIClients returns whether the scope allows viewing API clients or not.
+func (r scopedPermissionCollection) CanSeeAPIClients() bool {
+	return r.HasPermission(ReadAPIClientsPermission)
+}
+
+// CanDeleteAPIClients returns whether the scope allows deleting API clients or not.
+func (r scopedPermissionCollection) CanDeleteAPIClients() bool {
+	return r.HasPermission(ArchiveAPIClientsPermission)
+}
@@ -0,0 +1,134 @@
+package authorization
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewScopedAccountRolePermissionChecker(T *testing.T) {
+	T.Parallel()
+
+	T.Run("narrows account admin", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewScopedAccountRolePermissionChecker(
+			NewAccountRolePermissionChecker(AccountAdminRole.String()),
+			ReadItemsPermission.ID(),
+			ReadWebhooksPermission.ID(),
+		)
+
+		assert.True(t, r.HasPermission(ReadItemsPermission))
+		assert.True(t, r.CanSeeWebhooks())
+		assert.False(t, r.HasPermission(CreateItemsPermission))
+		assert.False(t, r.CanCreateWebhooks())
+		assert.False(t, r.CanUpdateAccounts())
+	})
+
+	T.Run("does not exceed the underlying roles", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewScopedAccountRolePermissionChecker(
+			NewAccountRolePermissionChecker(AccountMemberRole.String()),
+			ReadItemsPermission.ID(),
+			CreateWebhooksPermission.ID(),
+		)
+
+		assert.True(t, r.HasPermission(ReadItemsPermission))
+		assert.False(t, r.CanCreateWebhooks())
+	})
+
+	T.Run("with nil checker", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewScopedAccountRolePermissionChecker(nil, ReadItemsPermission.ID())
+
+		assert.False(t, r.HasPermission(ReadItemsPermission))
+	})
+}
+
+func TestNewScopedServiceRolePermissionChecker(T *testing.T) {
+	T.Parallel()
+
+	T.Run("narrows service admin", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewScopedServiceRolePermissionChecker(
+			NewServiceRolePermissionChecker(ServiceAdminRole.String()),
+			ReadUserPermission.ID(),
+		)
+
+		assert.True(t, r.IsServiceAdmin())
+		assert.True(t, r.CanSeeUserData())
+		assert.False(t, r.CanSearchUsers())
+		assert.False(t, r.CanCycleCookieSecrets())
+		assert.False(t, r.CanUpdateUserReputations())
+		assert.False(t, r.AsAccountRolePermissionChecker().CanUpdateAccounts())
+	})
+
+	T.Run("without admin permissions in scope", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewScopedServiceRolePermissionChecker(NewServiceRolePermissionChecker(ServiceAdminRole.String()))
+
+		assert.False(t, r.IsServiceAdmin())
+		assert.False(t, r.CanSeeUserData())
+	})
+
+	T.Run("does not exceed the underlying roles", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewScopedServiceRolePermissionChecker(
+			NewServiceRolePermissionChecker(ServiceUserRole.String()),
+			ReadUserPermission.ID(),
+		)
+
+		assert.False(t, r.IsServiceAdmin())
+		assert.False(t, r.CanSeeUserData())
+	})
+
+	T.Run("survives gob encoding", func(t *testing.T) {
+		t.Parallel()
+
+		var checker ServiceRolePermissionChecker = NewScopedServiceRolePermissionChecker(
+			NewServiceRolePermissionChecker(ServiceAdminRole.String()),
+			ReadUserPermission.ID(),
+		)
+
+		var b bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&b).Encode(&checker))
+
+		var decoded ServiceRolePermissionChecker
+		require.NoError(t, gob.NewDecoder(&b).Decode(&decoded))
+
+		assert.True(t, decoded.IsServiceAdmin())
+		assert.True(t, decoded.CanSeeUserData())
+		assert.False(t, decoded.CanSearchUsers())
+	})
+}
+
+func TestIsServicePermission(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, IsServicePermission(ReadUserPermission.ID()))
+		assert.False(t, IsServicePermission(ReadItemsPermission.ID()))
+	})
+}
+
+func TestIsAccountPermission(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, IsAccountPermission(ReadItemsPermission.ID()))
+		assert.True(t, IsAccountPermission(ReadWebhooksPermission.ID()))
+		assert.False(t, IsAccountPermission(ReadUserPermission.ID()))
+	})
+}
@@ -12,20 +12,24 @@
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/config"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
 	dbconfig "gitlab.com/verygoodsoftwarenotvirus/todo/internal/database/config"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	msgconfig "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/config"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing/chi"
-	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/elasticsearch"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/reindex"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/server"
+	accountrolesservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/accountroles"
 	accountsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/accounts"
 	adminservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/admin"
 	apiclientsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/apiclients"
+	auditlogservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/auditlog"
 	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
 	frontendservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/frontend"
 	itemsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/items"
+	notificationsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/notifications"
 	usersservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/users"
 	webhooksservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/webhooks"
 	websocketsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/websockets"
@@ -41,10 +45,10 @@ func Build(
 	cfg *config.InstanceConfig,
 ) (*server.HTTPServer, error) {
 	wire.Build(
-		elasticsearch.Providers,
 		config.Providers,
 		database.Providers,
 		dbconfig.Providers,
+		email.Providers,
 		encoding.Providers,
 		msgconfig.Providers,
 		server.Providers,
@@ -54,16 +58,20 @@ func Build(
 		observability.Providers,
 		storage.Providers,
 		chi.Providers,
+		reindex.Providers,
 		authentication.Providers,
 		authservice.Providers,
 		usersservice.Providers,
 		accountsservice.Providers,
+		accountrolesservice.Providers,
 		apiclientsservice.Providers,
+		auditlogservice.Providers,
 		webhooksservice.Providers,
 		websocketsservice.Providers,
 		adminservice.Providers,
 		frontendservice.Providers,
 		itemsservice.Providers,
+		notificationsservice.Providers,
 	)
 
 	return nil, nil
@@ -12,19 +12,23 @@
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/config"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
 	config2 "gitlab.com/verygoodsoftwarenotvirus/todo/internal/database/config"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	config3 "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/config"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing/chi"
-	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/elasticsearch"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/reindex"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/server"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/accountroles"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/accounts"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/admin"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/apiclients"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/auditlog"
 	authentication2 "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/frontend"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/items"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/notifications"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/users"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/webhooks"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/websockets"
@@ -62,7 +66,17 @@ func Build(ctx context.Context, logger logging.Logger, cfg *config.InstanceConfi
 	encodingConfig := cfg.Encoding
 	contentType := encoding.ProvideContentType(encodingConfig)
 	serverEncoderDecoder := encoding.ProvideServerEncoderDecoder(logger, contentType)
-	authService, err := authentication2.ProvideService(logger, authenticationConfig, authenticator, userDataManager, apiClientDataManager, accountUserMembershipDataManager, sessionManager, serverEncoderDecoder)
+	userSessionDataManager := database.ProvideUserSessionDataManager(dataManager)
+	webAuthnCredentialDataManager := database.ProvideWebAuthnCredentialDataManager(dataManager)
+	oidcIdentityDataManager := database.ProvideOIDCIdentityDataManager(dataManager)
+	auditLogEntryDataManager := database.ProvideAuditLogEntryDataManager(dataManager)
+	routeParamManager := chi.NewRouteParamManager()
+	configConfig := &cfg.Events
+	publisherProvider, err := config3.ProvidePublisherProvider(logger, configConfig)
+	if err != nil {
+		return nil, err
+	}
+	authService, err := authentication2.ProvideService(logger, authenticationConfig, authenticator, userDataManager, apiClientDataManager, accountUserMembershipDataManager, userSessionDataManager, webAuthnCredentialDataManager, oidcIdentityDataManager, auditLogEntryDataManager, sessionManager, serverEncoderDecoder, routeParamManager, publisherProvider)
 	if err != nil {
 		return nil, err
 	}
@@ -74,54 +88,75 @@ func Build(ctx context.Context, logger logging.Logger, cfg *config.InstanceConfi
 	imageUploadProcessor := images.NewImageUploadProcessor(logger)
 	uploadsConfig := &cfg.Uploads
 	storageConfig := &uploadsConfig.Storage
-	routeParamManager := chi.NewRouteParamManager()
 	uploader, err := storage.NewUploadManager(ctx, logger, storageConfig, routeParamManager)
 	if err != nil {
 		return nil, err
 	}
 	uploadManager := uploads.ProvideUploadManager(uploader)
-	userDataService := users.ProvideUsersService(authenticationConfig, logger, userDataManager, accountDataManager, authenticator, serverEncoderDecoder, unitCounterProvider, imageUploadProcessor, uploadManager, routeParamManager)
-	accountsConfig := servicesConfigurations.Accounts
-	configConfig := &cfg.Events
-	publisherProvider, err := config3.ProvidePublisherProvider(logger, configConfig)
+	passwordResetTokenDataManager := database.ProvidePasswordResetTokenDataManager(dataManager)
+	totpRecoveryCodeDataManager := database.ProvideTOTPRecoveryCodeDataManager(dataManager)
+	emailConfig := &cfg.Email
+	emailer, err := email.ProvideEmailer(logger, emailConfig)
 	if err != nil {
 		return nil, err
 	}
-	accountDataService, err := accounts.ProvideService(logger, accountsConfig, accountDataManager, accountUserMembershipDataManager, serverEncoderDecoder, unitCounterProvider, routeParamManager, publisherProvider)
+	localizer := frontend.ProvideLocalizer()
+	renderer, err := email.ProvideRenderer(emailConfig, localizer)
 	if err != nil {
 		return nil, err
 	}
+	userDataService := users.ProvideUsersService(authenticationConfig, logger, userDataManager, accountDataManager, auditLogEntryDataManager, passwordResetTokenDataManager, totpRecoveryCodeDataManager, emailer, renderer, authenticator, serverEncoderDecoder, unitCounterProvider, imageUploadProcessor, uploadManager, routeParamManager)
+	accountsConfig := servicesConfigurations.Accounts
+	accountRoleDataManager := database.ProvideAccountRoleDataManager(dataManager)
+	accountInvitationDataManager := database.ProvideAccountInvitationDataManager(dataManager)
+	accountDataService, err := accounts.ProvideService(logger, accountsConfig, accountDataManager, accountUserMembershipDataManager, accountRoleDataManager, accountInvitationDataManager, userDataManager, auditLogEntryDataManager, emailer, renderer, serverEncoderDecoder, unitCounterProvider, routeParamManager, publisherProvider)
+	if err != nil {
+		return nil, err
+	}
+	accountRoleDataService := accountroles.ProvideService(logger, accountRoleDataManager, auditLogEntryDataManager, serverEncoderDecoder, routeParamManager)
+	auditLogEntryDataService := auditlog.ProvideService(logger, auditLogEntryDataManager, serverEncoderDecoder, routeParamManager)
 	apiclientsConfig := apiclients.ProvideConfig(authenticationConfig)
-	apiClientDataService := apiclients.ProvideAPIClientsService(logger, apiClientDataManager, userDataManager, authenticator, serverEncoderDecoder, unitCounterProvider, routeParamManager, apiclientsConfig)
+	apiClientDataService := apiclients.ProvideAPIClientsService(logger, apiClientDataManager, userDataManager, auditLogEntryDataManager, authenticator, serverEncoderDecoder, unitCounterProvider, routeParamManager, apiclientsConfig)
 	consumerProvider, err := config3.ProvideConsumerProvider(logger, configConfig)
 	if err != nil {
 		return nil, err
 	}
-	websocketDataService, err := websockets.ProvideService(ctx, authenticationConfig, logger, serverEncoderDecoder, consumerProvider)
+	websocketDataService, err := websockets.ProvideService(ctx, authenticationConfig, logger, serverEncoderDecoder, consumerProvider, unitCounterProvider, publisherProvider, routeParamManager)
 	if err != nil {
 		return nil, err
 	}
 	itemsConfig := &servicesConfigurations.Items
 	itemDataManager := database.ProvideItemDataManager(dataManager)
-	indexManagerProvider := elasticsearch.ProvideIndexManagerProvider()
+	indexManagerProvider, err := config.ProvideSearchIndexManagerProvider(cfg, dataManager)
+	if err != nil {
+		return nil, err
+	}
 	itemDataService, err := items.ProvideService(ctx, logger, itemsConfig, itemDataManager, serverEncoderDecoder, indexManagerProvider, routeParamManager, publisherProvider)
 	if err != nil {
 		return nil, err
 	}
 	webhooksConfig := &servicesConfigurations.Webhooks
 	webhookDataManager := database.ProvideWebhookDataManager(dataManager)
-	webhookDataService, err := webhooks.ProvideWebhooksService(logger, webhooksConfig, webhookDataManager, serverEncoderDecoder, routeParamManager, publisherProvider)
+	webhookDataService, err := webhooks.ProvideWebhooksService(logger, webhooksConfig, webhookDataManager, auditLogEntryDataManager, serverEncoderDecoder, routeParamManager, publisherProvider)
 	if err != nil {
 		return nil, err
 	}
 	adminUserDataManager := database.ProvideAdminUserDataManager(dataManager)
-	adminService := admin.ProvideService(logger, authenticationConfig, authenticator, adminUserDataManager, sessionManager, serverEncoderDecoder, routeParamManager)
+	indexPath := config.ProvideSearchIndexPath(cfg)
+	reindexer := reindex.ProvideReindexer(logger, itemDataManager, indexManagerProvider, indexPath)
+	adminService, err := admin.ProvideService(logger, authenticationConfig, authenticator, adminUserDataManager, auditLogEntryDataManager, userSessionDataManager, sessionManager, serverEncoderDecoder, routeParamManager, reindexer, publisherProvider)
+	if err != nil {
+		return nil, err
+	}
+	notificationDataManager := database.ProvideNotificationDataManager(dataManager)
+	notificationDataService := notifications.ProvideService(logger, notificationDataManager, serverEncoderDecoder, routeParamManager)
 	frontendConfig := &servicesConfigurations.Frontend
 	frontendAuthService := frontend.ProvideAuthService(authService)
 	usersService := frontend.ProvideUsersService(userDataService)
-	service := frontend.ProvideService(frontendConfig, logger, frontendAuthService, usersService, dataManager, routeParamManager)
+	accountsService := frontend.ProvideAccountsService(accountDataService)
+	service := frontend.ProvideService(frontendConfig, logger, frontendAuthService, usersService, accountsService, dataManager, routeParamManager)
 	router := chi.NewRouter(logger)
-	httpServer, err := server.ProvideHTTPServer(ctx, serverConfig, instrumentationHandler, authService, userDataService, accountDataService, apiClientDataService, websocketDataService, itemDataService, webhookDataService, adminService, service, logger, serverEncoderDecoder, router)
+	httpServer, err := server.ProvideHTTPServer(ctx, serverConfig, instrumentationHandler, authService, userDataService, accountDataService, accountRoleDataService, auditLogEntryDataService, apiClientDataService, websocketDataService, itemDataService, webhookDataService, adminService, notificationDataService, service, logger, serverEncoderDecoder, router)
 	if err != nil {
 		return nil, err
 	}
@@ -13,12 +13,16 @@
 	dbconfig "gitlab.com/verygoodsoftwarenotvirus/todo/internal/database/config"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database/queriers/mysql"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database/queriers/postgres"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	msgconfig "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/config"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/dbsearch"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/elasticsearch"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/embedded"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/server"
 	accountsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/accounts"
 	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
@@ -41,6 +45,8 @@
 var (
 	errNilConfig               = errors.New("nil config provided")
 	errInvalidDatabaseProvider = errors.New("invalid database provider")
+	errInvalidSearchProvider   = errors.New("invalid search provider")
+	errLogEmailerInProduction  = errors.New("the log email provider may not be used in production")
 )
 
 type (
@@ -63,6 +69,7 @@
 		_             struct{}
 		Events        msgconfig.Config       `json:"events" mapstructure:"events" toml:"events,omitempty"`
 		Search        search.Config          `json:"search" mapstructure:"search" toml:"search,omitempty"`
+		Email         email.Config           `json:"email" mapstructure:"email" toml:"email,omitempty"`
 		Encoding      encoding.Config        `json:"encoding" mapstructure:"encoding" toml:"encoding,omitempty"`
 		Uploads       uploads.Config         `json:"uploads" mapstructure:"uploads" toml:"uploads,omitempty"`
 		Observability observability.Config   `json:"observability" mapstructure:"observability" toml:"observability,omitempty"`
@@ -92,6 +99,14 @@ func (cfg *InstanceConfig) ValidateWithContext(ctx context.Context) error {
 		return fmt.Errorf("error validating Search portion of config: %w", err)
 	}
 
+	if err := cfg.Email.ValidateWithContext(ctx); err != nil {
+		return fmt.Errorf("error validating Email portion of config: %w", err)
+	}
+
+	if cfg.Meta.RunMode == ProductionRunMode && cfg.Email.Provider == email.ProviderLog {
+		return fmt.Errorf("error validating Email portion of config: %w", errLogEmailerInProduction)
+	}
+
 	if err := cfg.Uploads.ValidateWithContext(ctx); err != nil {
 		return fmt.Errorf("error validating Uploads portion of config: %w", err)
 	}
@@ -161,3 +176,26 @@ func ProvideDatabaseClient(ctx context.Context, logger logging.Logger, cfg *Inst
 		return nil, fmt.Errorf("%w: %q", errInvalidDatabaseProvider, cfg.Database.Provider)
 	}
 }
+
+// ProvideSearchIndexManagerProvider provides a search index manager provider dependent on the configuration.
+func ProvideSearchIndexManagerProvider(cfg *InstanceConfig, dataManager database.DataManager) (search.IndexManagerProvider, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Search.Provider)) {
+	case search.ElasticsearchProvider:
+		return elasticsearch.ProvideIndexManagerProvider(), nil
+	case search.EmbeddedProvider:
+		return embedded.ProvideIndexManagerProvider(), nil
+	case search.DatabaseProvider:
+		return dbsearch.ProvideIndexManagerProvider(dataManager), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errInvalidSearchProvider, cfg.Search.Provider)
+	}
+}
+
+// ProvideSearchIndexPath provides the location of the configured search index.
+func ProvideSearchIndexPath(cfg *InstanceConfig) search.IndexPath {
+	return search.IndexPath(cfg.Services.Items.SearchIndexPath)
+}
@@ -17,6 +17,7 @@
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/server"
 	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
 	itemsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/items"
@@ -129,3 +130,63 @@ func TestServerConfig_ProvideDatabaseClient(T *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestServerConfig_ProvideSearchIndexManagerProvider(T *testing.T) {
+	T.Parallel()
+
+	T.Run("supported providers", func(t *testing.T) {
+		t.Parallel()
+
+		for _, provider := range []string{search.ElasticsearchProvider, search.EmbeddedProvider, search.DatabaseProvider} {
+			cfg := &InstanceConfig{
+				Search: search.Config{
+					Provider: provider,
+				},
+			}
+
+			x, err := ProvideSearchIndexManagerProvider(cfg, &database.MockDatabase{})
+			assert.NotNil(t, x)
+			assert.NoError(t, err)
+		}
+	})
+
+	T.Run("with nil config", func(t *testing.T) {
+		t.Parallel()
+
+		x, err := ProvideSearchIndexManagerProvider(nil, &database.MockDatabase{})
+		assert.Nil(t, x)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid provider", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &InstanceConfig{
+			Search: search.Config{
+				Provider: "provider",
+			},
+		}
+
+		x, err := ProvideSearchIndexManagerProvider(cfg, &database.MockDatabase{})
+		assert.Nil(t, x)
+		assert.Error(t, err)
+	})
+}
+
+func TestProvideSearchIndexPath(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &InstanceConfig{
+			Services: ServicesConfigurations{
+				Items: itemsservice.Config{
+					SearchIndexPath: t.Name(),
+				},
+			},
+		}
+
+		assert.Equal(t, search.IndexPath(t.Name()), ProvideSearchIndexPath(cfg))
+	})
+}
@@ -8,6 +8,8 @@
 	// Providers represents this package's offering to the dependency injector.
 	Providers = wire.NewSet(
 		ProvideDatabaseClient,
+		ProvideSearchIndexManagerProvider,
+		ProvideSearchIndexPath,
 		wire.FieldsOf(
 			new(*InstanceConfig),
 			"Database",
@@ -16,6 +18,7 @@
 			"Encoding",
 			"Uploads",
 			"Search",
+			"Email",
 			"Events",
 			"Server",
 			"Services",
@@ -15,6 +15,9 @@
 var (
 	// ErrDatabaseNotReady indicates the given database is not ready.
 	ErrDatabaseNotReady = errors.New("database is not ready yet")
+
+	// ErrVersionConflict indicates a record was changed by someone else since the version being written was read.
+	ErrVersionConflict = errors.New("record was changed since it was read")
 )
 
 type (
@@ -57,5 +60,14 @@
 		types.APIClientDataManager
 		types.WebhookDataManager
 		types.ItemDataManager
+		types.NotificationDataManager
+		types.AccountRoleDataManager
+		types.AuditLogEntryDataManager
+		types.PasswordResetTokenDataManager
+		types.TOTPRecoveryCodeDataManager
+		types.AccountInvitationDataManager
+		types.UserSessionDataManager
+		types.WebAuthnCredentialDataManager
+		types.OIDCIdentityDataManager
 	}
 )
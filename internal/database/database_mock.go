@@ -23,6 +23,15 @@ func BuildMockDatabase() *MockDatabase {
 		AdminUserDataManager:             &mocktypes.AdminUserDataManager{},
 		APIClientDataManager:             &mocktypes.APIClientDataManager{},
 		WebhookDataManager:               &mocktypes.WebhookDataManager{},
+		NotificationDataManager:          &mocktypes.NotificationDataManager{},
+		AccountRoleDataManager:           &mocktypes.AccountRoleDataManager{},
+		AuditLogEntryDataManager:         &mocktypes.AuditLogEntryDataManager{},
+		PasswordResetTokenDataManager:    &mocktypes.PasswordResetTokenDataManager{},
+		TOTPRecoveryCodeDataManager:      &mocktypes.TOTPRecoveryCodeDataManager{},
+		AccountInvitationDataManager:     &mocktypes.AccountInvitationDataManager{},
+		UserSessionDataManager:           &mocktypes.UserSessionDataManager{},
+		WebAuthnCredentialDataManager:    &mocktypes.WebAuthnCredentialDataManager{},
+		OIDCIdentityDataManager:          &mocktypes.OIDCIdentityDataManager{},
 	}
 }
 
@@ -36,6 +45,15 @@ type MockDatabase struct {
 	*mocktypes.APIClientDataManager
 	*mocktypes.WebhookDataManager
 	*mocktypes.AccountDataManager
+	*mocktypes.NotificationDataManager
+	*mocktypes.AccountRoleDataManager
+	*mocktypes.AuditLogEntryDataManager
+	*mocktypes.PasswordResetTokenDataManager
+	*mocktypes.TOTPRecoveryCodeDataManager
+	*mocktypes.AccountInvitationDataManager
+	*mocktypes.UserSessionDataManager
+	*mocktypes.WebAuthnCredentialDataManager
+	*mocktypes.OIDCIdentityDataManager
 	mock.Mock
 }
 
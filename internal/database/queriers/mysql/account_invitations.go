@@ -0,0 +1,364 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	accountInvitationsTableName = "account_invitations"
+
+	// accountInvitationsTableRolesSeparator is what the account invitations table uses to separate roles.
+	accountInvitationsTableRolesSeparator = commaSeparator
+
+	// accountInvitationsTableDestinationColumn is the column that identifies which account an invitation is for.
+	accountInvitationsTableDestinationColumn = "destination_account"
+)
+
+var (
+	_ types.AccountInvitationDataManager = (*SQLQuerier)(nil)
+
+	// accountInvitationsTableColumns are the columns for the account invitations table.
+	accountInvitationsTableColumns = []string{
+		"account_invitations.id",
+		"account_invitations.from_user",
+		"account_invitations.to_user",
+		"account_invitations.to_email",
+		"account_invitations.destination_account",
+		"account_invitations.note",
+		"account_invitations.status",
+		"account_invitations.account_roles",
+		"account_invitations.expires_at",
+		"account_invitations.created_on",
+		"account_invitations.last_updated_on",
+		"account_invitations.archived_on",
+	}
+)
+
+// scanAccountInvitation takes a database Scanner (i.e. *sql.Row) and scans the result into an account invitation struct.
+func (q *SQLQuerier) scanAccountInvitation(ctx context.Context, scan database.Scanner, includeCounts bool) (x *types.AccountInvitation, filteredCount, totalCount uint64, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue("include_counts", includeCounts)
+	x = &types.AccountInvitation{}
+
+	var rawAccountRoles string
+
+	targetVars := []interface{}{
+		&x.ID,
+		&x.FromUser,
+		&x.ToUser,
+		&x.ToEmail,
+		&x.DestinationAccount,
+		&x.Note,
+		&x.Status,
+		&rawAccountRoles,
+		&x.ExpiresAt,
+		&x.CreatedOn,
+		&x.LastUpdatedOn,
+		&x.ArchivedOn,
+	}
+
+	if includeCounts {
+		targetVars = append(targetVars, &filteredCount, &totalCount)
+	}
+
+	if err = scan.Scan(targetVars...); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "scanning account invitation")
+	}
+
+	if roles := strings.Split(rawAccountRoles, accountInvitationsTableRolesSeparator); len(roles) >= 1 && roles[0] != "" {
+		x.AccountRoles = roles
+	}
+
+	return x, filteredCount, totalCount, nil
+}
+
+// scanAccountInvitations takes some database rows and turns them into a slice of account invitations.
+func (q *SQLQuerier) scanAccountInvitations(ctx context.Context, rows database.ResultIterator, includeCounts bool) (invitations []*types.AccountInvitation, filteredCount, totalCount uint64, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue("include_counts", includeCounts)
+
+	for rows.Next() {
+		x, fc, tc, scanErr := q.scanAccountInvitation(ctx, rows, includeCounts)
+		if scanErr != nil {
+			return nil, 0, 0, scanErr
+		}
+
+		if includeCounts {
+			if filteredCount == 0 {
+				filteredCount = fc
+			}
+
+			if totalCount == 0 {
+				totalCount = tc
+			}
+		}
+
+		invitations = append(invitations, x)
+	}
+
+	if err = q.checkRowsForErrorAndClose(ctx, rows); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "handling rows")
+	}
+
+	return invitations, filteredCount, totalCount, nil
+}
+
+const getAccountInvitationQuery = `
+	SELECT account_invitations.id, account_invitations.from_user, account_invitations.to_user, account_invitations.to_email, account_invitations.destination_account, account_invitations.note, account_invitations.status, account_invitations.account_roles, account_invitations.expires_at, account_invitations.created_on, account_invitations.last_updated_on, account_invitations.archived_on FROM account_invitations WHERE account_invitations.archived_on IS NULL AND account_invitations.id = ?
+`
+
+// GetAccountInvitation fetches an account invitation from the database.
+func (q *SQLQuerier) GetAccountInvitation(ctx context.Context, accountInvitationID string) (*types.AccountInvitation, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountInvitationID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+	logger := q.logger.WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+
+	args := []interface{}{accountInvitationID}
+
+	row := q.getOneRow(ctx, q.db, "account invitation", getAccountInvitationQuery, args)
+
+	invitation, _, _, err := q.scanAccountInvitation(ctx, row, false)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning account invitation")
+	}
+
+	return invitation, nil
+}
+
+// GetPendingAccountInvitationsForAccount fetches a list of the invitations an account has sent that are still awaiting a response.
+func (q *SQLQuerier) GetPendingAccountInvitationsForAccount(ctx context.Context, accountID string, filter *types.QueryFilter) (*types.AccountInvitationList, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := q.logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	x := &types.AccountInvitationList{}
+	if filter != nil {
+		x.Page, x.Limit = filter.Page, filter.Limit
+	}
+
+	query, args := q.buildListQuery(
+		ctx,
+		accountInvitationsTableName,
+		nil,
+		squirrel.Eq{"account_invitations.status": types.PendingAccountInvitationStatus},
+		accountInvitationsTableDestinationColumn,
+		accountInvitationsTableColumns,
+		accountID,
+		false,
+		filter,
+	)
+
+	rows, err := q.performReadQuery(ctx, q.db, "account invitations", query, args)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "fetching account invitations from database")
+	}
+
+	if x.AccountInvitations, x.FilteredCount, x.TotalCount, err = q.scanAccountInvitations(ctx, rows, true); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning account invitations")
+	}
+
+	return x, nil
+}
+
+const getPendingAccountInvitationsForUserQuery = `
+	SELECT account_invitations.id, account_invitations.from_user, account_invitations.to_user, account_invitations.to_email, account_invitations.destination_account, account_invitations.note, account_invitations.status, account_invitations.account_roles, account_invitations.expires_at, account_invitations.created_on, account_invitations.last_updated_on, account_invitations.archived_on FROM account_invitations WHERE account_invitations.archived_on IS NULL AND account_invitations.status = 'pending' AND account_invitations.expires_at > UNIX_TIMESTAMP() AND account_invitations.to_user = ? ORDER BY account_invitations.created_on
+`
+
+// GetPendingAccountInvitationsForUser fetches the unexpired invitations awaiting a response from a given user.
+// Invitations sent by email only show up here once they've been claimed.
+func (q *SQLQuerier) GetPendingAccountInvitationsForUser(ctx context.Context, userID string) (*types.AccountInvitationList, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	tracing.AttachUserIDToSpan(span, userID)
+	logger := q.logger.WithValue(keys.UserIDKey, userID)
+
+	args := []interface{}{
+		userID,
+	}
+
+	rows, err := q.performReadQuery(ctx, q.db, "account invitations for user", getPendingAccountInvitationsForUserQuery, args)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "fetching account invitations from database")
+	}
+
+	x := &types.AccountInvitationList{}
+	if x.AccountInvitations, _, _, err = q.scanAccountInvitations(ctx, rows, false); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning account invitations")
+	}
+
+	x.FilteredCount = uint64(len(x.AccountInvitations))
+	x.TotalCount = x.FilteredCount
+
+	return x, nil
+}
+
+const accountInvitationCreationQuery = `
+	INSERT INTO account_invitations (id,from_user,to_user,to_email,token_hash,destination_account,note,status,account_roles,expires_at,created_on) VALUES (?,?,?,?,?,?,?,?,?,?,UNIX_TIMESTAMP())
+`
+
+// CreateAccountInvitation creates an account invitation in the database.
+func (q *SQLQuerier) CreateAccountInvitation(ctx context.Context, input *types.AccountInvitationDatabaseCreationInput) (*types.AccountInvitation, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	tracing.AttachAccountInvitationIDToSpan(span, input.ID)
+	tracing.AttachAccountIDToSpan(span, input.DestinationAccount)
+	logger := q.logger.WithValue(keys.AccountInvitationIDKey, input.ID).WithValue(keys.AccountIDKey, input.DestinationAccount)
+
+	toEmail := strings.ToLower(input.ToEmail)
+
+	args := []interface{}{
+		input.ID,
+		input.FromUser,
+		input.ToUser,
+		toEmail,
+		input.TokenHash,
+		input.DestinationAccount,
+		input.Note,
+		types.PendingAccountInvitationStatus,
+		strings.Join(input.AccountRoles, accountInvitationsTableRolesSeparator),
+		input.ExpiresAt,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "account invitation creation", accountInvitationCreationQuery, args); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "creating account invitation")
+	}
+
+	x := &types.AccountInvitation{
+		ID:                 input.ID,
+		FromUser:           input.FromUser,
+		ToUser:             input.ToUser,
+		ToEmail:            toEmail,
+		DestinationAccount: input.DestinationAccount,
+		Note:               input.Note,
+		Status:             types.PendingAccountInvitationStatus,
+		AccountRoles:       input.AccountRoles,
+		ExpiresAt:          input.ExpiresAt,
+		CreatedOn:          q.currentTime(),
+	}
+
+	logger.Info("account invitation created")
+
+	return x, nil
+}
+
+const getUnclaimedAccountInvitationQuery = `
+	SELECT account_invitations.id, account_invitations.from_user, account_invitations.to_user, account_invitations.to_email, account_invitations.destination_account, account_invitations.note, account_invitations.status, account_invitations.account_roles, account_invitations.expires_at, account_invitations.created_on, account_invitations.last_updated_on, account_invitations.archived_on FROM account_invitations WHERE account_invitations.archived_on IS NULL AND account_invitations.status = 'pending' AND account_invitations.to_user IS NULL AND account_invitations.expires_at > UNIX_TIMESTAMP() AND account_invitations.token_hash <> '' AND account_invitations.token_hash = ?
+`
+
+const claimAccountInvitationQuery = `
+	UPDATE account_invitations SET to_user = ?, token_hash = '', last_updated_on = UNIX_TIMESTAMP() WHERE archived_on IS NULL AND status = 'pending' AND to_user IS NULL AND id = ?
+`
+
+// ClaimAccountInvitation addresses the unexpired invitation a token was emailed for to a given user. Tokens can only
+// be used once, so claiming an invitation that was already claimed yields sql.ErrNoRows.
+func (q *SQLQuerier) ClaimAccountInvitation(ctx context.Context, tokenHash, userID string) (*types.AccountInvitation, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if tokenHash == "" {
+		return nil, ErrEmptyInputProvided
+	}
+
+	if userID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	tracing.AttachUserIDToSpan(span, userID)
+	logger := q.logger.WithValue(keys.UserIDKey, userID)
+
+	row := q.getOneRow(ctx, q.db, "account invitation", getUnclaimedAccountInvitationQuery, []interface{}{tokenHash})
+
+	invitation, _, _, err := q.scanAccountInvitation(ctx, row, false)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning account invitation")
+	}
+
+	tracing.AttachAccountInvitationIDToSpan(span, invitation.ID)
+	logger = logger.WithValue(keys.AccountInvitationIDKey, invitation.ID)
+
+	args := []interface{}{
+		userID,
+		invitation.ID,
+	}
+
+	if err = q.performWriteQuery(ctx, q.db, "account invitation claim", claimAccountInvitationQuery, args); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "claiming account invitation")
+	}
+
+	invitation.ToUser = &userID
+
+	logger.Info("account invitation claimed")
+
+	return invitation, nil
+}
+
+const setAccountInvitationStatusQuery = `
+	UPDATE account_invitations SET status = ?, last_updated_on = UNIX_TIMESTAMP() WHERE archived_on IS NULL AND status = 'pending' AND id = ?
+`
+
+// SetAccountInvitationStatus settles a pending account invitation. Invitations can only be settled once, so
+// attempting to change the status of an invitation that isn't pending yields sql.ErrNoRows.
+func (q *SQLQuerier) SetAccountInvitationStatus(ctx context.Context, accountInvitationID string, status types.AccountInvitationStatus) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountInvitationID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	if status == "" {
+		return ErrEmptyInputProvided
+	}
+
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+	logger := q.logger.WithValue(keys.AccountInvitationIDKey, accountInvitationID).WithValue("status", status)
+
+	args := []interface{}{
+		status,
+		accountInvitationID,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "account invitation status update", setAccountInvitationStatusQuery, args); err != nil {
+		return observability.PrepareError(err, logger, span, "updating account invitation status")
+	}
+
+	logger.Info("account invitation status updated")
+
+	return nil
+}
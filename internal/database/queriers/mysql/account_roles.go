@@ -0,0 +1,453 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	// accountRolesTablePermissionsSeparator is what the account roles table uses to separate permissions.
+	accountRolesTablePermissionsSeparator = commaSeparator
+
+	// accountRoleCacheTTL is how long the permissions of a custom role are trusted before they're fetched again.
+	accountRoleCacheTTL = time.Minute
+)
+
+var (
+	_ types.AccountRoleDataManager = (*SQLQuerier)(nil)
+
+	// accountRolesTableColumns are the columns for the account roles table.
+	accountRolesTableColumns = []string{
+		"account_roles.id",
+		"account_roles.name",
+		"account_roles.description",
+		"account_roles.permissions",
+		"account_roles.created_on",
+		"account_roles.last_updated_on",
+		"account_roles.archived_on",
+		"account_roles.belongs_to_account",
+	}
+)
+
+type (
+	// cachedAccountRole is what we remember about a custom role between session builds.
+	cachedAccountRole struct {
+		fetchedOn        time.Time
+		belongsToAccount string
+		permissions      []string
+	}
+
+	// accountRoleCache keeps custom roles in memory, so that building a session doesn't query for them every time.
+	accountRoleCache struct {
+		roles   map[string]*cachedAccountRole
+		rolesMu sync.RWMutex
+	}
+)
+
+// get returns a cached role, provided it hasn't gone stale.
+func (c *accountRoleCache) get(accountRoleID string) (*cachedAccountRole, bool) {
+	c.rolesMu.RLock()
+	defer c.rolesMu.RUnlock()
+
+	x, ok := c.roles[accountRoleID]
+	if !ok || time.Since(x.fetchedOn) > accountRoleCacheTTL {
+		return nil, false
+	}
+
+	return x, true
+}
+
+// set caches a role. Roles that couldn't be found are cached without permissions.
+func (c *accountRoleCache) set(accountRoleID string, x *cachedAccountRole) {
+	c.rolesMu.Lock()
+	defer c.rolesMu.Unlock()
+
+	if c.roles == nil {
+		c.roles = map[string]*cachedAccountRole{}
+	}
+
+	x.fetchedOn = time.Now()
+	c.roles[accountRoleID] = x
+}
+
+// evict forgets a role.
+func (c *accountRoleCache) evict(accountRoleID string) {
+	c.rolesMu.Lock()
+	defer c.rolesMu.Unlock()
+
+	delete(c.roles, accountRoleID)
+}
+
+// scanAccountRole takes a database Scanner (i.e. *sql.Row) and scans the result into an account role struct.
+func (q *SQLQuerier) scanAccountRole(ctx context.Context, scan database.Scanner, includeCounts bool) (x *types.AccountRole, filteredCount, totalCount uint64, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue("include_counts", includeCounts)
+	x = &types.AccountRole{}
+
+	var rawPermissions string
+
+	targetVars := []interface{}{
+		&x.ID,
+		&x.Name,
+		&x.Description,
+		&rawPermissions,
+		&x.CreatedOn,
+		&x.LastUpdatedOn,
+		&x.ArchivedOn,
+		&x.BelongsToAccount,
+	}
+
+	if includeCounts {
+		targetVars = append(targetVars, &filteredCount, &totalCount)
+	}
+
+	if err = scan.Scan(targetVars...); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "scanning account role")
+	}
+
+	if permissions := strings.Split(rawPermissions, accountRolesTablePermissionsSeparator); len(permissions) >= 1 && permissions[0] != "" {
+		x.Permissions = permissions
+	}
+
+	return x, filteredCount, totalCount, nil
+}
+
+// scanAccountRoles takes some database rows and turns them into a slice of account roles.
+func (q *SQLQuerier) scanAccountRoles(ctx context.Context, rows database.ResultIterator, includeCounts bool) (accountRoles []*types.AccountRole, filteredCount, totalCount uint64, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue("include_counts", includeCounts)
+
+	for rows.Next() {
+		x, fc, tc, scanErr := q.scanAccountRole(ctx, rows, includeCounts)
+		if scanErr != nil {
+			return nil, 0, 0, scanErr
+		}
+
+		if includeCounts {
+			if filteredCount == 0 {
+				filteredCount = fc
+			}
+
+			if totalCount == 0 {
+				totalCount = tc
+			}
+		}
+
+		accountRoles = append(accountRoles, x)
+	}
+
+	if err = q.checkRowsForErrorAndClose(ctx, rows); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "handling rows")
+	}
+
+	return accountRoles, filteredCount, totalCount, nil
+}
+
+const getAccountRoleQuery = `
+	SELECT account_roles.id, account_roles.name, account_roles.description, account_roles.permissions, account_roles.created_on, account_roles.last_updated_on, account_roles.archived_on, account_roles.belongs_to_account FROM account_roles WHERE account_roles.archived_on IS NULL AND account_roles.belongs_to_account = ? AND account_roles.id = ?
+`
+
+// GetAccountRole fetches an account role from the database.
+func (q *SQLQuerier) GetAccountRole(ctx context.Context, accountRoleID, accountID string) (*types.AccountRole, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountRoleID == "" || accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	tracing.AttachAccountRoleIDToSpan(span, accountRoleID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	logger := q.logger.WithValues(map[string]interface{}{
+		keys.AccountRoleIDKey: accountRoleID,
+		keys.AccountIDKey:     accountID,
+	})
+
+	args := []interface{}{
+		accountID,
+		accountRoleID,
+	}
+
+	row := q.getOneRow(ctx, q.db, "account role", getAccountRoleQuery, args)
+
+	accountRole, _, _, err := q.scanAccountRole(ctx, row, false)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning account role")
+	}
+
+	return accountRole, nil
+}
+
+// GetAccountRoles fetches a list of an account's roles from the database that meet a particular filter.
+func (q *SQLQuerier) GetAccountRoles(ctx context.Context, accountID string, filter *types.QueryFilter) (*types.AccountRoleList, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := q.logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	x := &types.AccountRoleList{}
+	if filter != nil {
+		x.Page, x.Limit = filter.Page, filter.Limit
+	}
+
+	query, args := q.buildListQuery(
+		ctx,
+		"account_roles",
+		nil,
+		nil,
+		accountOwnershipColumn,
+		accountRolesTableColumns,
+		accountID,
+		false,
+		filter,
+	)
+
+	rows, err := q.performReadQuery(ctx, q.db, "account roles", query, args)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "fetching account roles from database")
+	}
+
+	if x.AccountRoles, x.FilteredCount, x.TotalCount, err = q.scanAccountRoles(ctx, rows, true); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning account roles")
+	}
+
+	return x, nil
+}
+
+const accountRoleCreationQuery = `
+	INSERT INTO account_roles (id,name,description,permissions,belongs_to_account,created_on) VALUES (?,?,?,?,?,UNIX_TIMESTAMP())
+`
+
+// CreateAccountRole creates an account role in the database.
+func (q *SQLQuerier) CreateAccountRole(ctx context.Context, input *types.AccountRoleCreationInput) (*types.AccountRole, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	logger := q.logger.WithValue(keys.AccountRoleIDKey, input.ID).WithValue(keys.AccountIDKey, input.BelongsToAccount)
+
+	args := []interface{}{
+		input.ID,
+		input.Name,
+		input.Description,
+		strings.Join(input.Permissions, accountRolesTablePermissionsSeparator),
+		input.BelongsToAccount,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "account role creation", accountRoleCreationQuery, args); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "creating account role")
+	}
+
+	x := &types.AccountRole{
+		ID:               input.ID,
+		Name:             input.Name,
+		Description:      input.Description,
+		Permissions:      input.Permissions,
+		BelongsToAccount: input.BelongsToAccount,
+		CreatedOn:        q.currentTime(),
+	}
+
+	tracing.AttachAccountRoleIDToSpan(span, x.ID)
+	logger.Info("account role created")
+
+	return x, nil
+}
+
+const updateAccountRoleQuery = `
+	UPDATE account_roles SET name = ?, description = ?, permissions = ?, last_updated_on = UNIX_TIMESTAMP() WHERE archived_on IS NULL AND belongs_to_account = ? AND id = ?
+`
+
+// UpdateAccountRole updates a particular account role. Note that UpdateAccountRole expects the provided input to have a valid ID.
+func (q *SQLQuerier) UpdateAccountRole(ctx context.Context, updated *types.AccountRole) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if updated == nil {
+		return ErrNilInputProvided
+	}
+
+	logger := q.logger.WithValue(keys.AccountRoleIDKey, updated.ID)
+	tracing.AttachAccountRoleIDToSpan(span, updated.ID)
+	tracing.AttachAccountIDToSpan(span, updated.BelongsToAccount)
+
+	args := []interface{}{
+		updated.Name,
+		updated.Description,
+		strings.Join(updated.Permissions, accountRolesTablePermissionsSeparator),
+		updated.BelongsToAccount,
+		updated.ID,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "account role update", updateAccountRoleQuery, args); err != nil {
+		return observability.PrepareError(err, logger, span, "updating account role")
+	}
+
+	q.accountRoleCache.evict(updated.ID)
+
+	logger.Info("account role updated")
+
+	return nil
+}
+
+const archiveAccountRoleQuery = `
+	UPDATE account_roles SET last_updated_on = UNIX_TIMESTAMP(), archived_on = UNIX_TIMESTAMP() WHERE archived_on IS NULL AND belongs_to_account = ? AND id = ?
+`
+
+// ArchiveAccountRole archives an account role from the database.
+func (q *SQLQuerier) ArchiveAccountRole(ctx context.Context, accountRoleID, accountID string) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountRoleID == "" || accountID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	tracing.AttachAccountRoleIDToSpan(span, accountRoleID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	logger := q.logger.WithValues(map[string]interface{}{
+		keys.AccountRoleIDKey: accountRoleID,
+		keys.AccountIDKey:     accountID,
+	})
+
+	args := []interface{}{
+		accountID,
+		accountRoleID,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "account role archive", archiveAccountRoleQuery, args); err != nil {
+		return observability.PrepareError(err, logger, span, "archiving account role")
+	}
+
+	q.accountRoleCache.evict(accountRoleID)
+
+	logger.Info("account role archived")
+
+	return nil
+}
+
+// buildGetAccountRolesWithIDsQuery builds a query to fetch the unarchived account roles within a given set of IDs.
+func (q *SQLQuerier) buildGetAccountRolesWithIDsQuery(ctx context.Context, ids []string) (query string, args []interface{}) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	builder := q.sqlBuilder.
+		Select(accountRolesTableColumns...).
+		From("account_roles").
+		Where(squirrel.Eq{
+			"account_roles.id":          ids,
+			"account_roles.archived_on": nil,
+		})
+
+	return q.buildQuery(span, builder)
+}
+
+// buildAccountRolePermissionCheckers turns the roles a user has in each of their accounts into permission checkers,
+// resolving any custom roles among them from the cache, or the database when the cache can't help.
+func (q *SQLQuerier) buildAccountRolePermissionCheckers(ctx context.Context, accountRolesMap map[string][]string) (map[string]authorization.AccountRolePermissionsChecker, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger
+
+	customRoles := map[string]*cachedAccountRole{}
+	uncachedRoleIDs := []string{}
+
+	for _, roles := range accountRolesMap {
+		for _, role := range roles {
+			if authorization.IsBuiltInAccountRole(role) {
+				continue
+			}
+
+			if x, ok := q.accountRoleCache.get(role); ok {
+				customRoles[role] = x
+			} else {
+				uncachedRoleIDs = append(uncachedRoleIDs, role)
+			}
+		}
+	}
+
+	if len(uncachedRoleIDs) > 0 {
+		logger = logger.WithValue("uncached_role_count", len(uncachedRoleIDs))
+		query, args := q.buildGetAccountRolesWithIDsQuery(ctx, uncachedRoleIDs)
+
+		rows, err := q.performReadQuery(ctx, q.db, "account roles with IDs", query, args)
+		if err != nil {
+			return nil, observability.PrepareError(err, logger, span, "fetching account roles from database")
+		}
+
+		accountRoles, _, _, err := q.scanAccountRoles(ctx, rows, false)
+		if err != nil {
+			return nil, observability.PrepareError(err, logger, span, "scanning account roles")
+		}
+
+		// roles that have been archived (or never existed) are remembered too, as granting nothing.
+		for _, id := range uncachedRoleIDs {
+			customRoles[id] = &cachedAccountRole{}
+		}
+
+		for _, accountRole := range accountRoles {
+			customRoles[accountRole.ID] = &cachedAccountRole{
+				belongsToAccount: accountRole.BelongsToAccount,
+				permissions:      accountRole.Permissions,
+			}
+		}
+
+		for _, id := range uncachedRoleIDs {
+			q.accountRoleCache.set(id, customRoles[id])
+		}
+	}
+
+	checkers := map[string]authorization.AccountRolePermissionsChecker{}
+	for accountID, roles := range accountRolesMap {
+		builtInRoles := []string{}
+		permissions := []string{}
+		hasCustomRoles := false
+
+		for _, role := range roles {
+			if authorization.IsBuiltInAccountRole(role) {
+				builtInRoles = append(builtInRoles, role)
+				continue
+			}
+
+			hasCustomRoles = true
+			// a role from another account must never grant anything here.
+			if x, ok := customRoles[role]; ok && x.belongsToAccount == accountID {
+				permissions = append(permissions, x.permissions...)
+			}
+		}
+
+		if hasCustomRoles {
+			checkers[accountID] = authorization.NewCustomAccountRolePermissionChecker(builtInRoles, permissions)
+		} else {
+			checkers[accountID] = authorization.NewAccountRolePermissionChecker(roles...)
+		}
+	}
+
+	return checkers, nil
+}
@@ -147,14 +147,15 @@ func (q *SQLQuerier) BuildSessionContextDataForUser(ctx context.Context, userID
 		return nil, observability.PrepareError(err, logger, span, "scanning user's memberships from database")
 	}
 
-	actualAccountRolesMap := map[string]authorization.AccountRolePermissionsChecker{}
-	for accountID, roles := range accountRolesMap {
-		actualAccountRolesMap[accountID] = authorization.NewAccountRolePermissionChecker(roles...)
+	actualAccountRolesMap, err := q.buildAccountRolePermissionCheckers(ctx, accountRolesMap)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "resolving user's account roles")
 	}
 
 	sessionCtxData := &types.SessionContextData{
 		Requester: types.RequesterInfo{
 			UserID:                user.ID,
+			Username:              user.Username,
 			Reputation:            user.ServiceAccountStatus,
 			ReputationExplanation: user.ReputationExplanation,
 			ServicePermissions:    authorization.NewServiceRolePermissionChecker(user.ServiceRoles...),
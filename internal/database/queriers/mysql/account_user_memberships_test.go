@@ -136,6 +136,7 @@ func TestQuerier_BuildSessionContextDataForUser(T *testing.T) {
 		expected := &types.SessionContextData{
 			Requester: types.RequesterInfo{
 				UserID:                exampleUser.ID,
+				Username:              exampleUser.Username,
 				Reputation:            exampleUser.ServiceAccountStatus,
 				ReputationExplanation: exampleUser.ReputationExplanation,
 				ServicePermissions:    authorization.NewServiceRolePermissionChecker(exampleUser.ServiceRoles...),
@@ -3,6 +3,7 @@
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -39,6 +40,7 @@
 		"accounts.last_updated_on",
 		"accounts.archived_on",
 		"accounts.belongs_to_user",
+		"accounts.version",
 	}
 )
 
@@ -68,6 +70,7 @@ func (q *SQLQuerier) scanAccount(ctx context.Context, scan database.Scanner, inc
 		&account.LastUpdatedOn,
 		&account.ArchivedOn,
 		&account.BelongsToUser,
+		&account.Version,
 		&membership.ID,
 		&membership.BelongsToUser,
 		&membership.BelongsToAccount,
@@ -153,6 +156,7 @@ func (q *SQLQuerier) scanAccounts(ctx context.Context, rows database.ResultItera
 		accounts.last_updated_on, 
 		accounts.archived_on, 
 		accounts.belongs_to_user, 
+		accounts.version, 
 		account_user_memberships.id, 
 		account_user_memberships.belongs_to_user, 
 		account_user_memberships.belongs_to_account, 
@@ -245,6 +249,7 @@ func (q *SQLQuerier) GetAllAccountsCount(ctx context.Context) (uint64, error) {
 		"accounts.last_updated_on",
 		"accounts.archived_on",
 		"accounts.belongs_to_user",
+		"accounts.version",
 		// accountsUserMembershipTableColumns,
 		"account_user_memberships.id",
 		"account_user_memberships.belongs_to_user",
@@ -423,6 +428,7 @@ func (q *SQLQuerier) CreateAccount(ctx context.Context, input *types.AccountCrea
 		ContactEmail:  input.ContactEmail,
 		ContactPhone:  input.ContactPhone,
 		CreatedOn:     q.currentTime(),
+		Version:       1,
 	}
 
 	addInput := &types.AddUserToAccountInput{
@@ -454,11 +460,14 @@ func (q *SQLQuerier) CreateAccount(ctx context.Context, input *types.AccountCrea
 	return account, nil
 }
 
+const accountExistenceQuery = "SELECT EXISTS ( SELECT accounts.id FROM accounts WHERE accounts.archived_on IS NULL AND accounts.belongs_to_user = ? AND accounts.id = ? )"
+
 const updateAccountQuery = `
-	UPDATE accounts SET name = ?, contact_email = ?, contact_phone = ?, last_updated_on = UNIX_TIMESTAMP() WHERE archived_on IS NULL AND belongs_to_user = ? AND id = ?
+	UPDATE accounts SET name = ?, contact_email = ?, contact_phone = ?, last_updated_on = UNIX_TIMESTAMP(), version = version + 1 WHERE archived_on IS NULL AND belongs_to_user = ? AND id = ? AND version = ?
 `
 
-// UpdateAccount updates a particular account. Note that UpdateAccount expects the provided input to have a valid ID.
+// UpdateAccount updates a particular account. Note that UpdateAccount expects the provided input to have a valid ID, and
+// the version it was read at. If the account has changed since then, nothing is written and ErrVersionConflict is returned.
 func (q *SQLQuerier) UpdateAccount(ctx context.Context, updated *types.Account) error {
 	ctx, span := q.tracer.StartSpan(ctx)
 	defer span.End()
@@ -476,12 +485,23 @@ func (q *SQLQuerier) UpdateAccount(ctx context.Context, updated *types.Account)
 		updated.ContactPhone,
 		updated.BelongsToUser,
 		updated.ID,
+		updated.Version,
 	}
 
 	if err := q.performWriteQuery(ctx, q.db, "account update", updateAccountQuery, args); err != nil {
+		// no rows were updated, so either the account is gone, or someone else got to it first.
+		if errors.Is(err, sql.ErrNoRows) {
+			existenceArgs := []interface{}{updated.BelongsToUser, updated.ID}
+			if exists, existenceErr := q.performBooleanQuery(ctx, q.db, accountExistenceQuery, existenceArgs); existenceErr == nil && exists {
+				err = database.ErrVersionConflict
+			}
+		}
+
 		return observability.PrepareError(err, logger, span, "updating account")
 	}
 
+	updated.Version++
+
 	logger.Info("account updated")
 
 	return nil
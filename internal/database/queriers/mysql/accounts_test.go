@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"strings"
@@ -40,6 +41,7 @@ func buildMockRowsFromAccounts(includeCounts bool, filteredCount uint64, account
 				x.LastUpdatedOn,
 				x.ArchivedOn,
 				x.BelongsToUser,
+				x.Version,
 				y.ID,
 				y.BelongsToUser,
 				y.BelongsToAccount,
@@ -738,6 +740,7 @@ func TestQuerier_UpdateAccount(T *testing.T) {
 			exampleAccount.ContactPhone,
 			exampleAccount.BelongsToUser,
 			exampleAccount.ID,
+			exampleAccount.Version,
 		}
 
 		db.ExpectExec(formatQueryForSQLMock(updateAccountQuery)).
@@ -745,6 +748,7 @@ func TestQuerier_UpdateAccount(T *testing.T) {
 			WillReturnResult(newArbitraryDatabaseResult(exampleAccount.ID))
 
 		assert.NoError(t, c.UpdateAccount(ctx, exampleAccount))
+		assert.Equal(t, uint64(2), exampleAccount.Version)
 
 		mock.AssertExpectationsForObjects(t, db)
 	})
@@ -778,6 +782,7 @@ func TestQuerier_UpdateAccount(T *testing.T) {
 			exampleAccount.ContactPhone,
 			exampleAccount.BelongsToUser,
 			exampleAccount.ID,
+			exampleAccount.Version,
 		}
 
 		db.ExpectExec(formatQueryForSQLMock(updateAccountQuery)).
@@ -788,6 +793,83 @@ func TestQuerier_UpdateAccount(T *testing.T) {
 
 		mock.AssertExpectationsForObjects(t, db)
 	})
+
+	T.Run("with version conflict", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleAccount := fakes.BuildFakeAccount()
+		exampleAccount.BelongsToUser = exampleUserID
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleAccount.Name,
+			exampleAccount.ContactEmail,
+			exampleAccount.ContactPhone,
+			exampleAccount.BelongsToUser,
+			exampleAccount.ID,
+			exampleAccount.Version,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(updateAccountQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		existenceArgs := []interface{}{
+			exampleAccount.BelongsToUser,
+			exampleAccount.ID,
+		}
+
+		db.ExpectQuery(formatQueryForSQLMock(accountExistenceQuery)).
+			WithArgs(interfaceToDriverValue(existenceArgs)...).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		err := c.UpdateAccount(ctx, exampleAccount)
+		assert.True(t, errors.Is(err, database.ErrVersionConflict))
+		assert.Equal(t, uint64(1), exampleAccount.Version)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with nonexistent account", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleAccount := fakes.BuildFakeAccount()
+		exampleAccount.BelongsToUser = exampleUserID
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleAccount.Name,
+			exampleAccount.ContactEmail,
+			exampleAccount.ContactPhone,
+			exampleAccount.BelongsToUser,
+			exampleAccount.ID,
+			exampleAccount.Version,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(updateAccountQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		existenceArgs := []interface{}{
+			exampleAccount.BelongsToUser,
+			exampleAccount.ID,
+		}
+
+		db.ExpectQuery(formatQueryForSQLMock(accountExistenceQuery)).
+			WithArgs(interfaceToDriverValue(existenceArgs)...).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		err := c.UpdateAccount(ctx, exampleAccount)
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
 }
 
 func TestQuerier_ArchiveAccount(T *testing.T) {
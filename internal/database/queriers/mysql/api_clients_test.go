@@ -5,12 +5,14 @@
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
@@ -31,6 +33,9 @@ func buildMockRowsFromAPIClients(includeCounts bool, filteredCount uint64, clien
 			c.Name,
 			c.ClientID,
 			c.ClientSecret,
+			strings.Join(c.Permissions, apiClientsTablePermissionsSeparator),
+			strings.Join(c.AdminPermissions, apiClientsTablePermissionsSeparator),
+			strings.Join(c.Accounts, apiClientsTableAccountsSeparator),
 			c.CreatedOn,
 			c.LastUpdatedOn,
 			c.ArchivedOn,
@@ -104,6 +109,30 @@ func TestQuerier_GetAPIClientByClientID(T *testing.T) {
 		mock.AssertExpectationsForObjects(t, db)
 	})
 
+	T.Run("with scopes", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAPIClient := fakes.BuildFakeAPIClient()
+		exampleAPIClient.Permissions = []string{authorization.ReadItemsPermission.ID(), authorization.SearchItemsPermission.ID()}
+		exampleAPIClient.AdminPermissions = []string{authorization.ReadUserPermission.ID()}
+		exampleAPIClient.Accounts = []string{fakes.BuildFakeID()}
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleAPIClient.ClientID}
+
+		db.ExpectQuery(formatQueryForSQLMock(getAPIClientByClientIDQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromAPIClients(false, 0, exampleAPIClient))
+
+		actual, err := c.GetAPIClientByClientID(ctx, exampleAPIClient.ClientID)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleAPIClient, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
 	T.Run("with empty client ID", func(t *testing.T) {
 		t.Parallel()
 
@@ -492,6 +521,9 @@ func TestQuerier_CreateAPIClient(T *testing.T) {
 			exampleInput.Name,
 			exampleInput.ClientID,
 			exampleInput.ClientSecret,
+			strings.Join(exampleInput.Permissions, apiClientsTablePermissionsSeparator),
+			strings.Join(exampleInput.AdminPermissions, apiClientsTablePermissionsSeparator),
+			strings.Join(exampleInput.Accounts, apiClientsTableAccountsSeparator),
 			exampleInput.BelongsToUser,
 		}
 
@@ -550,6 +582,9 @@ func TestQuerier_CreateAPIClient(T *testing.T) {
 			exampleInput.Name,
 			exampleInput.ClientID,
 			exampleInput.ClientSecret,
+			strings.Join(exampleInput.Permissions, apiClientsTablePermissionsSeparator),
+			strings.Join(exampleInput.AdminPermissions, apiClientsTablePermissionsSeparator),
+			strings.Join(exampleInput.Accounts, apiClientsTableAccountsSeparator),
 			exampleInput.BelongsToUser,
 		}
 
@@ -0,0 +1,330 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func buildMockRowsFromAuditLogEntries(t *testing.T, includeCounts bool, filteredCount uint64, entries ...*types.AuditLogEntry) *sqlmock.Rows {
+	t.Helper()
+
+	columns := auditLogEntriesTableColumns
+
+	if includeCounts {
+		columns = append(columns, "filtered_count", "total_count")
+	}
+
+	exampleRows := sqlmock.NewRows(columns)
+
+	for _, x := range entries {
+		rawChanges, err := json.Marshal(x.Changes)
+		require.NoError(t, err)
+
+		rowValues := []driver.Value{
+			x.ID,
+			x.EventType,
+			x.ActorUserID,
+			x.BelongsToAccount,
+			x.ResourceType,
+			x.ResourceID,
+			x.RequestID,
+			string(rawChanges),
+			x.CreatedOn,
+		}
+
+		if includeCounts {
+			rowValues = append(rowValues, filteredCount, len(entries))
+		}
+
+		exampleRows.AddRow(rowValues...)
+	}
+
+	return exampleRows
+}
+
+func TestQuerier_buildGetAuditLogEntriesQuery(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		filter := types.DefaultAuditLogEntryQueryFilter()
+		filter.EventType = types.ItemUpdateEvent
+		filter.SortBy = types.SortDescending
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		expectedQuery := "SELECT audit_log_entries.id, audit_log_entries.event_type, audit_log_entries.actor_user_id, audit_log_entries.belongs_to_account, audit_log_entries.resource_type, audit_log_entries.resource_id, audit_log_entries.request_id, audit_log_entries.changes, audit_log_entries.created_on, (SELECT COUNT(audit_log_entries.id) FROM audit_log_entries WHERE audit_log_entries.belongs_to_account = ?) as total_count, (SELECT COUNT(audit_log_entries.id) FROM audit_log_entries WHERE audit_log_entries.belongs_to_account = ? AND audit_log_entries.event_type = ?) as filtered_count FROM audit_log_entries WHERE audit_log_entries.belongs_to_account = ? AND audit_log_entries.event_type = ? ORDER BY audit_log_entries.created_on DESC LIMIT 20"
+		expectedArgs := []interface{}{
+			exampleAccountID,
+			exampleAccountID,
+			types.ItemUpdateEvent,
+			exampleAccountID,
+			types.ItemUpdateEvent,
+		}
+
+		actualQuery, actualArgs := c.buildGetAuditLogEntriesQuery(ctx, exampleAccountID, filter)
+
+		assert.Equal(t, expectedQuery, actualQuery)
+		assert.Equal(t, expectedArgs, actualArgs)
+	})
+
+	T.Run("for all accounts", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		expectedQuery := "SELECT audit_log_entries.id, audit_log_entries.event_type, audit_log_entries.actor_user_id, audit_log_entries.belongs_to_account, audit_log_entries.resource_type, audit_log_entries.resource_id, audit_log_entries.request_id, audit_log_entries.changes, audit_log_entries.created_on, (SELECT COUNT(audit_log_entries.id) FROM audit_log_entries) as total_count, (SELECT COUNT(audit_log_entries.id) FROM audit_log_entries) as filtered_count FROM audit_log_entries ORDER BY audit_log_entries.created_on ASC LIMIT 20"
+
+		actualQuery, actualArgs := c.buildGetAuditLogEntriesQuery(ctx, "", nil)
+
+		assert.Equal(t, expectedQuery, actualQuery)
+		assert.Empty(t, actualArgs)
+	})
+}
+
+func TestQuerier_GetAuditLogEntries(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultAuditLogEntryQueryFilter()
+		exampleAuditLogEntryList := fakes.BuildFakeAuditLogEntryList()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildGetAuditLogEntriesQuery(ctx, "", filter)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromAuditLogEntries(t, true, exampleAuditLogEntryList.FilteredCount, exampleAuditLogEntryList.Entries...))
+
+		actual, err := c.GetAuditLogEntries(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleAuditLogEntryList, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultAuditLogEntryQueryFilter()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildGetAuditLogEntriesQuery(ctx, "", filter)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetAuditLogEntries(ctx, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with erroneous response from database", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultAuditLogEntryQueryFilter()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildGetAuditLogEntriesQuery(ctx, "", filter)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildErroneousMockRow())
+
+		actual, err := c.GetAuditLogEntries(ctx, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_GetAuditLogEntriesForAccount(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultAuditLogEntryQueryFilter()
+		exampleAccountID := fakes.BuildFakeID()
+		exampleAuditLogEntryList := fakes.BuildFakeAuditLogEntryList()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildGetAuditLogEntriesQuery(ctx, exampleAccountID, filter)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromAuditLogEntries(t, true, exampleAuditLogEntryList.FilteredCount, exampleAuditLogEntryList.Entries...))
+
+		actual, err := c.GetAuditLogEntriesForAccount(ctx, exampleAccountID, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleAuditLogEntryList, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid account ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetAuditLogEntriesForAccount(ctx, "", types.DefaultAuditLogEntryQueryFilter())
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultAuditLogEntryQueryFilter()
+		exampleAccountID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildGetAuditLogEntriesQuery(ctx, exampleAccountID, filter)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetAuditLogEntriesForAccount(ctx, exampleAccountID, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_CreateAuditLogEntry(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleInput := fakes.BuildFakeAuditLogEntryCreationInput()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		rawChanges, err := json.Marshal(exampleInput.Changes)
+		require.NoError(t, err)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.EventType,
+			exampleInput.ActorUserID,
+			exampleInput.BelongsToAccount,
+			exampleInput.ResourceType,
+			exampleInput.ResourceID,
+			exampleInput.RequestID,
+			string(rawChanges),
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(auditLogEntryCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleInput.ID))
+
+		assert.NoError(t, c.CreateAuditLogEntry(ctx, exampleInput))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("without changes", func(t *testing.T) {
+		t.Parallel()
+
+		exampleInput := fakes.BuildFakeAuditLogEntryCreationInput()
+		exampleInput.Changes = nil
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.EventType,
+			exampleInput.ActorUserID,
+			exampleInput.BelongsToAccount,
+			exampleInput.ResourceType,
+			exampleInput.ResourceID,
+			exampleInput.RequestID,
+			"[]",
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(auditLogEntryCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleInput.ID))
+
+		assert.NoError(t, c.CreateAuditLogEntry(ctx, exampleInput))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.CreateAuditLogEntry(ctx, nil))
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		exampleInput := fakes.BuildFakeAuditLogEntryCreationInput()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		rawChanges, err := json.Marshal(exampleInput.Changes)
+		require.NoError(t, err)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.EventType,
+			exampleInput.ActorUserID,
+			exampleInput.BelongsToAccount,
+			exampleInput.ResourceType,
+			exampleInput.ResourceID,
+			exampleInput.RequestID,
+			string(rawChanges),
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(auditLogEntryCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.CreateAuditLogEntry(ctx, exampleInput))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
@@ -5,7 +5,6 @@
 	"database/sql"
 	"database/sql/driver"
 	"errors"
-	"fmt"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -35,6 +34,7 @@ func buildMockRowsFromItems(includeCounts bool, filteredCount uint64, items ...*
 			x.LastUpdatedOn,
 			x.ArchivedOn,
 			x.BelongsToAccount,
+			x.Version,
 		}
 
 		if includeCounts {
@@ -458,6 +458,137 @@ func TestQuerier_GetItems(T *testing.T) {
 	})
 }
 
+func TestQuerier_GetItemsForAdmin(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+		exampleItemList := fakes.BuildFakeItemList()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"items",
+			nil,
+			nil,
+			accountOwnershipColumn,
+			itemsTableColumns,
+			"",
+			true,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromItems(true, exampleItemList.FilteredCount, exampleItemList.Items...))
+
+		actual, err := c.GetItemsForAdmin(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleItemList, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with nil filter", func(t *testing.T) {
+		t.Parallel()
+
+		filter := (*types.QueryFilter)(nil)
+		exampleItemList := fakes.BuildFakeItemList()
+		exampleItemList.Page, exampleItemList.Limit = 0, 0
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"items",
+			nil,
+			nil,
+			accountOwnershipColumn,
+			itemsTableColumns,
+			"",
+			true,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromItems(true, exampleItemList.FilteredCount, exampleItemList.Items...))
+
+		actual, err := c.GetItemsForAdmin(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleItemList, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"items",
+			nil,
+			nil,
+			accountOwnershipColumn,
+			itemsTableColumns,
+			"",
+			true,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetItemsForAdmin(ctx, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with erroneous response from database", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"items",
+			nil,
+			nil,
+			accountOwnershipColumn,
+			itemsTableColumns,
+			"",
+			true,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildErroneousMockRow())
+
+		actual, err := c.GetItemsForAdmin(ctx, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
 func TestQuerier_GetItemsWithIDs(T *testing.T) {
 	T.Parallel()
 
@@ -467,22 +598,20 @@ func TestQuerier_GetItemsWithIDs(T *testing.T) {
 		exampleAccountID := fakes.BuildFakeID()
 		exampleItemList := fakes.BuildFakeItemList()
 
-		exampleArgs := []interface{}{exampleAccountID}
 		var exampleIDs []string
 		for _, x := range exampleItemList.Items {
-			exampleArgs = append(exampleArgs, x.ID)
 			exampleIDs = append(exampleIDs, x.ID)
 		}
 
 		ctx := context.Background()
 		c, db := buildTestClient(t)
 
-		query := fmt.Sprintf(getItemsWithIDsQuery, joinIDs(exampleIDs))
+		query, exampleArgs := c.buildGetItemsWithIDsQuery(ctx, exampleAccountID, defaultLimit, exampleIDs)
 		db.ExpectQuery(formatQueryForSQLMock(query)).
 			WithArgs(interfaceToDriverValue(exampleArgs)...).
 			WillReturnRows(buildMockRowsFromItems(false, 0, exampleItemList.Items...))
 
-		actual, err := c.GetItemsWithIDs(ctx, exampleAccountID, 0, exampleIDs)
+		actual, err := c.GetItemsWithIDs(ctx, exampleAccountID, defaultLimit, exampleIDs)
 		assert.NoError(t, err)
 		assert.Equal(t, exampleItemList.Items, actual)
 
@@ -519,17 +648,15 @@ func TestQuerier_GetItemsWithIDs(T *testing.T) {
 		exampleAccountID := fakes.BuildFakeID()
 		exampleItemList := fakes.BuildFakeItemList()
 
-		exampleArgs := []interface{}{exampleAccountID}
 		var exampleIDs []string
 		for _, x := range exampleItemList.Items {
-			exampleArgs = append(exampleArgs, x.ID)
 			exampleIDs = append(exampleIDs, x.ID)
 		}
 
 		ctx := context.Background()
 		c, db := buildTestClient(t)
 
-		query := fmt.Sprintf(getItemsWithIDsQuery, joinIDs(exampleIDs))
+		query, exampleArgs := c.buildGetItemsWithIDsQuery(ctx, exampleAccountID, defaultLimit, exampleIDs)
 		db.ExpectQuery(formatQueryForSQLMock(query)).
 			WithArgs(interfaceToDriverValue(exampleArgs)...).
 			WillReturnError(errors.New("blah"))
@@ -547,17 +674,15 @@ func TestQuerier_GetItemsWithIDs(T *testing.T) {
 		exampleAccountID := fakes.BuildFakeID()
 		exampleItemList := fakes.BuildFakeItemList()
 
-		exampleArgs := []interface{}{exampleAccountID}
 		var exampleIDs []string
 		for _, x := range exampleItemList.Items {
-			exampleArgs = append(exampleArgs, x.ID)
 			exampleIDs = append(exampleIDs, x.ID)
 		}
 
 		ctx := context.Background()
 		c, db := buildTestClient(t)
 
-		query := fmt.Sprintf(getItemsWithIDsQuery, joinIDs(exampleIDs))
+		query, exampleArgs := c.buildGetItemsWithIDsQuery(ctx, exampleAccountID, defaultLimit, exampleIDs)
 		db.ExpectQuery(formatQueryForSQLMock(query)).
 			WithArgs(interfaceToDriverValue(exampleArgs)...).
 			WillReturnRows(buildErroneousMockRow())
@@ -570,6 +695,171 @@ func TestQuerier_GetItemsWithIDs(T *testing.T) {
 	})
 }
 
+func TestQuerier_SearchItemIDs(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		exampleQuery := "example"
+		exampleItemList := fakes.BuildFakeItemList()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		exampleIDs := []string{}
+		exampleRows := sqlmock.NewRows([]string{"items.id"})
+		for _, x := range exampleItemList.Items {
+			exampleIDs = append(exampleIDs, x.ID)
+			exampleRows.AddRow(x.ID)
+		}
+
+		args := []interface{}{exampleAccountID, exampleQuery, exampleQuery, types.MaxLimit}
+
+		db.ExpectQuery(formatQueryForSQLMock(searchItemIDsQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(exampleRows)
+
+		actual, err := c.SearchItemIDs(ctx, exampleQuery, exampleAccountID)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleIDs, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with empty query", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.SearchItemIDs(ctx, "", fakes.BuildFakeID())
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with invalid account ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.SearchItemIDs(ctx, "example", "")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		exampleQuery := "example"
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleAccountID, exampleQuery, exampleQuery, types.MaxLimit}
+
+		db.ExpectQuery(formatQueryForSQLMock(searchItemIDsQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.SearchItemIDs(ctx, exampleQuery, exampleAccountID)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with erroneous response from database", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		exampleQuery := "example"
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleAccountID, exampleQuery, exampleQuery, types.MaxLimit}
+
+		db.ExpectQuery(formatQueryForSQLMock(searchItemIDsQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildErroneousMockRow())
+
+		actual, err := c.SearchItemIDs(ctx, exampleQuery, exampleAccountID)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_SearchItemIDsForAdmin(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleQuery := "example"
+		exampleItemList := fakes.BuildFakeItemList()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		exampleIDs := []string{}
+		exampleRows := sqlmock.NewRows([]string{"items.id"})
+		for _, x := range exampleItemList.Items {
+			exampleIDs = append(exampleIDs, x.ID)
+			exampleRows.AddRow(x.ID)
+		}
+
+		args := []interface{}{exampleQuery, exampleQuery, types.MaxLimit}
+
+		db.ExpectQuery(formatQueryForSQLMock(searchItemIDsForAdminQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(exampleRows)
+
+		actual, err := c.SearchItemIDsForAdmin(ctx, exampleQuery)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleIDs, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with empty query", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.SearchItemIDsForAdmin(ctx, "")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleQuery := "example"
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleQuery, exampleQuery, types.MaxLimit}
+
+		db.ExpectQuery(formatQueryForSQLMock(searchItemIDsForAdminQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.SearchItemIDsForAdmin(ctx, exampleQuery)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
 func TestQuerier_CreateItem(T *testing.T) {
 	T.Parallel()
 
@@ -666,6 +956,7 @@ func TestQuerier_UpdateItem(T *testing.T) {
 			exampleItem.Details,
 			exampleItem.BelongsToAccount,
 			exampleItem.ID,
+			exampleItem.Version,
 		}
 
 		db.ExpectExec(formatQueryForSQLMock(updateItemQuery)).
@@ -673,6 +964,7 @@ func TestQuerier_UpdateItem(T *testing.T) {
 			WillReturnResult(newArbitraryDatabaseResult(exampleItem.ID))
 
 		assert.NoError(t, c.UpdateItem(ctx, exampleItem))
+		assert.Equal(t, uint64(2), exampleItem.Version)
 
 		mock.AssertExpectationsForObjects(t, db)
 	})
@@ -710,6 +1002,7 @@ func TestQuerier_UpdateItem(T *testing.T) {
 			exampleItem.Details,
 			exampleItem.BelongsToAccount,
 			exampleItem.ID,
+			exampleItem.Version,
 		}
 
 		db.ExpectExec(formatQueryForSQLMock(updateItemQuery)).
@@ -720,6 +1013,77 @@ func TestQuerier_UpdateItem(T *testing.T) {
 
 		mock.AssertExpectationsForObjects(t, db)
 	})
+
+	T.Run("with version conflict", func(t *testing.T) {
+		t.Parallel()
+
+		exampleItem := fakes.BuildFakeItem()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleItem.Name,
+			exampleItem.Details,
+			exampleItem.BelongsToAccount,
+			exampleItem.ID,
+			exampleItem.Version,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(updateItemQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		existenceArgs := []interface{}{
+			exampleItem.BelongsToAccount,
+			exampleItem.ID,
+		}
+
+		db.ExpectQuery(formatQueryForSQLMock(itemExistenceQuery)).
+			WithArgs(interfaceToDriverValue(existenceArgs)...).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		err := c.UpdateItem(ctx, exampleItem)
+		assert.True(t, errors.Is(err, database.ErrVersionConflict))
+		assert.Equal(t, uint64(1), exampleItem.Version)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with nonexistent item", func(t *testing.T) {
+		t.Parallel()
+
+		exampleItem := fakes.BuildFakeItem()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleItem.Name,
+			exampleItem.Details,
+			exampleItem.BelongsToAccount,
+			exampleItem.ID,
+			exampleItem.Version,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(updateItemQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		existenceArgs := []interface{}{
+			exampleItem.BelongsToAccount,
+			exampleItem.ID,
+		}
+
+		db.ExpectQuery(formatQueryForSQLMock(itemExistenceQuery)).
+			WithArgs(interfaceToDriverValue(existenceArgs)...).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		err := c.UpdateItem(ctx, exampleItem)
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
 }
 
 func TestQuerier_ArchiveItem(T *testing.T) {
@@ -24,7 +24,7 @@
 )
 
 const testUserExistenceQuery = `
-	SELECT users.id, users.username, users.avatar_src, users.hashed_password, users.requires_password_change, users.password_last_changed_on, users.two_factor_secret, users.two_factor_secret_verified_on, users.service_roles, users.reputation, users.reputation_explanation, users.created_on, users.last_updated_on, users.archived_on FROM users WHERE users.archived_on IS NULL AND users.username = ? AND users.two_factor_secret_verified_on IS NOT NULL
+	SELECT users.id, users.username, users.email_address, users.avatar_src, users.hashed_password, users.requires_password_change, users.password_last_changed_on, users.two_factor_secret, users.two_factor_secret_verified_on, users.service_roles, users.reputation, users.reputation_explanation, users.created_on, users.last_updated_on, users.archived_on FROM users WHERE users.archived_on IS NULL AND users.username = ? AND users.two_factor_secret_verified_on IS NOT NULL
 `
 
 const testUserCreationQuery = `
@@ -248,6 +248,244 @@ func (q *SQLQuerier) Migrate(ctx context.Context, maxAttempts uint8, testUserCon
 				");",
 			}, "\n"),
 		},
+		{
+			Version:     0.10,
+			Description: "create webhook delivery attempts table",
+			Script: strings.Join([]string{
+				"CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (",
+				"    `id` CHAR(27) NOT NULL,",
+				"    `request_body` LONGTEXT NOT NULL,",
+				"    `response_status` INTEGER UNSIGNED NOT NULL DEFAULT 0,",
+				"    `latency_in_milliseconds` BIGINT UNSIGNED NOT NULL DEFAULT 0,",
+				"    `error_text` LONGTEXT NOT NULL,",
+				"    `attempt_number` INTEGER UNSIGNED NOT NULL,",
+				"    `created_on` BIGINT UNSIGNED NOT NULL,",
+				"    `last_updated_on` BIGINT UNSIGNED DEFAULT NULL,",
+				"    `archived_on` BIGINT UNSIGNED DEFAULT NULL,",
+				"    `belongs_to_webhook` CHAR(27) NOT NULL,",
+				"    PRIMARY KEY (`id`),",
+				"    FOREIGN KEY (`belongs_to_webhook`) REFERENCES webhooks(`id`) ON DELETE CASCADE",
+				");",
+			}, "\n"),
+		},
+		{
+			Version:     0.11,
+			Description: "add webhook signing secrets",
+			Script: strings.Join([]string{
+				"ALTER TABLE webhooks",
+				"    ADD COLUMN `signing_secret` VARCHAR(256) NOT NULL DEFAULT '',",
+				"    ADD COLUMN `previous_signing_secret` VARCHAR(256) NOT NULL DEFAULT '',",
+				"    ADD COLUMN `previous_signing_secret_expires_on` BIGINT UNSIGNED DEFAULT NULL;",
+			}, "\n"),
+		},
+		{
+			Version:     0.12,
+			Description: "add items full-text search index",
+			Script:      "CREATE FULLTEXT INDEX items_search_idx ON items (`name`, `details`);",
+		},
+		{
+			Version:     0.13,
+			Description: "add notifications archival",
+			Script:      "ALTER TABLE notifications ADD COLUMN `archived_on` BIGINT UNSIGNED DEFAULT NULL;",
+		},
+		{
+			Version:     0.14,
+			Description: "add notifications user index",
+			Script:      "CREATE INDEX notifications_belongs_to_user_idx ON notifications (`belongs_to_user`);",
+		},
+		{
+			Version:     0.15,
+			Description: "add API client scopes",
+			Script: strings.Join([]string{
+				"ALTER TABLE api_clients",
+				"    ADD COLUMN `permissions` VARCHAR(1024) NOT NULL DEFAULT '',",
+				"    ADD COLUMN `admin_permissions` VARCHAR(1024) NOT NULL DEFAULT '',",
+				"    ADD COLUMN `accounts` VARCHAR(4096) NOT NULL DEFAULT '';",
+			}, "\n"),
+		},
+		{
+			Version:     0.16,
+			Description: "create account roles table",
+			Script: strings.Join([]string{
+				"CREATE TABLE IF NOT EXISTS account_roles (",
+				"    `id` CHAR(27) NOT NULL,",
+				"    `name` VARCHAR(128) NOT NULL,",
+				"    `description` LONGTEXT NOT NULL,",
+				"    `permissions` VARCHAR(1024) NOT NULL,",
+				"    `created_on` BIGINT UNSIGNED NOT NULL,",
+				"    `last_updated_on` BIGINT UNSIGNED DEFAULT NULL,",
+				"    `archived_on` BIGINT UNSIGNED DEFAULT NULL,",
+				"    `belongs_to_account` CHAR(27) NOT NULL,",
+				"    PRIMARY KEY (`id`),",
+				"    FOREIGN KEY (`belongs_to_account`) REFERENCES accounts(`id`) ON DELETE CASCADE",
+				");",
+			}, "\n"),
+		},
+		{
+			Version:     0.17,
+			Description: "create audit log table",
+			Script: strings.Join([]string{
+				"CREATE TABLE IF NOT EXISTS audit_log_entries (",
+				"    `id` CHAR(27) NOT NULL,",
+				"    `event_type` VARCHAR(128) NOT NULL,",
+				"    `actor_user_id` VARCHAR(64) NOT NULL DEFAULT '',",
+				"    `belongs_to_account` VARCHAR(64) NOT NULL DEFAULT '',",
+				"    `resource_type` VARCHAR(64) NOT NULL,",
+				"    `resource_id` VARCHAR(64) NOT NULL DEFAULT '',",
+				"    `request_id` VARCHAR(128) NOT NULL DEFAULT '',",
+				"    `changes` LONGTEXT NOT NULL,",
+				"    `created_on` BIGINT UNSIGNED NOT NULL,",
+				"    PRIMARY KEY (`id`),",
+				"    INDEX audit_log_entries_belongs_to_account_idx (`belongs_to_account`),",
+				"    INDEX audit_log_entries_created_on_idx (`created_on`)",
+				");",
+			}, "\n"),
+		},
+		{
+			Version:     0.18,
+			Description: "add user email addresses",
+			Script:      "ALTER TABLE users ADD COLUMN `email_address` VARCHAR(256) NOT NULL DEFAULT '';",
+		},
+		{
+			Version:     0.19,
+			Description: "create password reset tokens table",
+			Script: strings.Join([]string{
+				"CREATE TABLE IF NOT EXISTS password_reset_tokens (",
+				"    `id` CHAR(27) NOT NULL,",
+				"    `hashed_token` VARCHAR(128) NOT NULL,",
+				"    `belongs_to_user` CHAR(27) NOT NULL,",
+				"    `expires_at` BIGINT UNSIGNED NOT NULL,",
+				"    `redeemed_on` BIGINT UNSIGNED DEFAULT NULL,",
+				"    `created_on` BIGINT UNSIGNED NOT NULL,",
+				"    PRIMARY KEY (`id`),",
+				"    UNIQUE (`hashed_token`),",
+				"    FOREIGN KEY (`belongs_to_user`) REFERENCES users(`id`) ON DELETE CASCADE",
+				");",
+			}, "\n"),
+		},
+		{
+			Version:     0.20,
+			Description: "create TOTP recovery codes table",
+			Script: strings.Join([]string{
+				"CREATE TABLE IF NOT EXISTS totp_recovery_codes (",
+				"    `id` CHAR(27) NOT NULL,",
+				"    `hashed_code` VARCHAR(128) NOT NULL,",
+				"    `belongs_to_user` CHAR(27) NOT NULL,",
+				"    `redeemed_on` BIGINT UNSIGNED DEFAULT NULL,",
+				"    `created_on` BIGINT UNSIGNED NOT NULL,",
+				"    PRIMARY KEY (`id`),",
+				"    INDEX totp_recovery_codes_belongs_to_user_idx (`belongs_to_user`),",
+				"    FOREIGN KEY (`belongs_to_user`) REFERENCES users(`id`) ON DELETE CASCADE",
+				");",
+			}, "\n"),
+		},
+		{
+			Version:     0.21,
+			Description: "create account invitations table",
+			Script: strings.Join([]string{
+				"CREATE TABLE IF NOT EXISTS account_invitations (",
+				"    `id` CHAR(27) NOT NULL,",
+				"    `from_user` CHAR(27) NOT NULL,",
+				"    `to_user` CHAR(27) DEFAULT NULL,",
+				"    `to_email` VARCHAR(256) NOT NULL DEFAULT '',",
+				"    `destination_account` CHAR(27) NOT NULL,",
+				"    `note` LONGTEXT NOT NULL,",
+				"    `status` VARCHAR(32) NOT NULL DEFAULT 'pending',",
+				"    `account_roles` VARCHAR(1024) NOT NULL,",
+				"    `expires_at` BIGINT UNSIGNED NOT NULL,",
+				"    `created_on` BIGINT UNSIGNED NOT NULL,",
+				"    `last_updated_on` BIGINT UNSIGNED DEFAULT NULL,",
+				"    `archived_on` BIGINT UNSIGNED DEFAULT NULL,",
+				"    PRIMARY KEY (`id`),",
+				"    INDEX account_invitations_to_email_idx (`to_email`),",
+				"    FOREIGN KEY (`from_user`) REFERENCES users(`id`) ON DELETE CASCADE,",
+				"    FOREIGN KEY (`to_user`) REFERENCES users(`id`) ON DELETE CASCADE,",
+				"    FOREIGN KEY (`destination_account`) REFERENCES accounts(`id`) ON DELETE CASCADE",
+				");",
+			}, "\n"),
+		},
+		{
+			Version:     0.22,
+			Description: "create user sessions table",
+			Script: strings.Join([]string{
+				"CREATE TABLE IF NOT EXISTS user_sessions (",
+				"    `id` CHAR(27) NOT NULL,",
+				"    `session_token` CHAR(43) NOT NULL,",
+				"    `belongs_to_user` CHAR(27) NOT NULL,",
+				"    `active_account` VARCHAR(64) NOT NULL DEFAULT '',",
+				"    `ip_address` VARCHAR(64) NOT NULL DEFAULT '',",
+				"    `user_agent` VARCHAR(512) NOT NULL DEFAULT '',",
+				"    `browser` VARCHAR(128) NOT NULL DEFAULT '',",
+				"    `browser_version` VARCHAR(128) NOT NULL DEFAULT '',",
+				"    `operating_system` VARCHAR(128) NOT NULL DEFAULT '',",
+				"    `platform` VARCHAR(128) NOT NULL DEFAULT '',",
+				"    `mobile` BOOLEAN NOT NULL DEFAULT false,",
+				"    `created_on` BIGINT UNSIGNED NOT NULL,",
+				"    `last_seen_on` BIGINT UNSIGNED NOT NULL,",
+				"    `archived_on` BIGINT UNSIGNED DEFAULT NULL,",
+				"    PRIMARY KEY (`id`),",
+				"    UNIQUE (`session_token`),",
+				"    INDEX user_sessions_belongs_to_user_idx (`belongs_to_user`),",
+				"    FOREIGN KEY (`belongs_to_user`) REFERENCES users(`id`) ON DELETE CASCADE",
+				");",
+			}, "\n"),
+		},
+		{
+			Version:     0.23,
+			Description: "create WebAuthn credentials table",
+			Script: strings.Join([]string{
+				"CREATE TABLE IF NOT EXISTS webauthn_credentials (",
+				"    `id` CHAR(27) NOT NULL,",
+				"    `name` VARCHAR(128) NOT NULL,",
+				"    `credential_id` VARBINARY(1023) NOT NULL,",
+				"    `public_key` BLOB NOT NULL,",
+				"    `attestation_type` VARCHAR(64) NOT NULL DEFAULT '',",
+				"    `aaguid` VARBINARY(16) NOT NULL DEFAULT '',",
+				"    `sign_count` BIGINT UNSIGNED NOT NULL DEFAULT 0,",
+				"    `belongs_to_user` CHAR(27) NOT NULL,",
+				"    `last_used_on` BIGINT UNSIGNED DEFAULT NULL,",
+				"    `created_on` BIGINT UNSIGNED NOT NULL,",
+				"    `archived_on` BIGINT UNSIGNED DEFAULT NULL,",
+				"    PRIMARY KEY (`id`),",
+				"    UNIQUE (`credential_id`),",
+				"    INDEX webauthn_credentials_belongs_to_user_idx (`belongs_to_user`),",
+				"    FOREIGN KEY (`belongs_to_user`) REFERENCES users(`id`) ON DELETE CASCADE",
+				");",
+			}, "\n"),
+		},
+		{
+			Version:     0.24,
+			Description: "create OIDC identities table",
+			Script: strings.Join([]string{
+				"CREATE TABLE IF NOT EXISTS oidc_identities (",
+				"    `id` CHAR(27) NOT NULL,",
+				"    `issuer` VARCHAR(255) NOT NULL,",
+				"    `subject` VARCHAR(255) NOT NULL,",
+				"    `belongs_to_user` CHAR(27) NOT NULL,",
+				"    `created_on` BIGINT UNSIGNED NOT NULL,",
+				"    `archived_on` BIGINT UNSIGNED DEFAULT NULL,",
+				"    PRIMARY KEY (`id`),",
+				"    UNIQUE (`issuer`, `subject`),",
+				"    INDEX oidc_identities_belongs_to_user_idx (`belongs_to_user`),",
+				"    FOREIGN KEY (`belongs_to_user`) REFERENCES users(`id`) ON DELETE CASCADE",
+				");",
+			}, "\n"),
+		},
+		{
+			Version:     0.25,
+			Description: "add item versions",
+			Script:      "ALTER TABLE items ADD COLUMN `version` BIGINT UNSIGNED NOT NULL DEFAULT 1;",
+		},
+		{
+			Version:     0.26,
+			Description: "add account versions",
+			Script:      "ALTER TABLE accounts ADD COLUMN `version` BIGINT UNSIGNED NOT NULL DEFAULT 1;",
+		},
+		{
+			Version:     0.27,
+			Description: "add account invitation tokens",
+			Script:      "ALTER TABLE account_invitations ADD COLUMN `token_hash` VARCHAR(128) NOT NULL DEFAULT '', ADD INDEX account_invitations_token_hash_idx (`token_hash`);",
+		},
 	}
 )
 
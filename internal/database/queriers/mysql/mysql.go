@@ -33,13 +33,14 @@
 
 // SQLQuerier is the primary database querying client. All tracing/logging/query execution happens here. Query building generally happens elsewhere.
 type SQLQuerier struct {
-	config      *dbconfig.Config
-	db          *sql.DB
-	timeFunc    func() uint64
-	sqlBuilder  squirrel.StatementBuilderType
-	logger      logging.Logger
-	tracer      tracing.Tracer
-	migrateOnce sync.Once
+	config           *dbconfig.Config
+	db               *sql.DB
+	timeFunc         func() uint64
+	sqlBuilder       squirrel.StatementBuilderType
+	logger           logging.Logger
+	tracer           tracing.Tracer
+	accountRoleCache accountRoleCache
+	migrateOnce      sync.Once
 }
 
 var instrumentedDriverRegistration sync.Once
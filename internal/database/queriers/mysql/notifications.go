@@ -0,0 +1,275 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var (
+	_ types.NotificationDataManager = (*SQLQuerier)(nil)
+
+	// notificationsTableColumns are the columns for the notifications table.
+	notificationsTableColumns = []string{
+		"notifications.id",
+		"notifications.title",
+		"notifications.description",
+		"notifications.created_on",
+		"notifications.last_updated_on",
+		"notifications.seen_on",
+		"notifications.archived_on",
+		"notifications.belongs_to_account",
+		"notifications.belongs_to_user",
+	}
+)
+
+// scanNotification takes a database Scanner (i.e. *sql.Row) and scans the result into a notification struct.
+func (q *SQLQuerier) scanNotification(ctx context.Context, scan database.Scanner, includeCounts bool) (x *types.Notification, filteredCount, totalCount uint64, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue("include_counts", includeCounts)
+
+	x = &types.Notification{}
+
+	targetVars := []interface{}{
+		&x.ID,
+		&x.Title,
+		&x.Description,
+		&x.CreatedOn,
+		&x.LastUpdatedOn,
+		&x.SeenOn,
+		&x.ArchivedOn,
+		&x.BelongsToAccount,
+		&x.BelongsToUser,
+	}
+
+	if includeCounts {
+		targetVars = append(targetVars, &filteredCount, &totalCount)
+	}
+
+	if err = scan.Scan(targetVars...); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "")
+	}
+
+	return x, filteredCount, totalCount, nil
+}
+
+// scanNotifications takes some database rows and turns them into a slice of notifications.
+func (q *SQLQuerier) scanNotifications(ctx context.Context, rows database.ResultIterator, includeCounts bool) (notifications []*types.Notification, filteredCount, totalCount uint64, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue("include_counts", includeCounts)
+
+	for rows.Next() {
+		x, fc, tc, scanErr := q.scanNotification(ctx, rows, includeCounts)
+		if scanErr != nil {
+			return nil, 0, 0, scanErr
+		}
+
+		if includeCounts {
+			if filteredCount == 0 {
+				filteredCount = fc
+			}
+
+			if totalCount == 0 {
+				totalCount = tc
+			}
+		}
+
+		notifications = append(notifications, x)
+	}
+
+	if err = q.checkRowsForErrorAndClose(ctx, rows); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "handling rows")
+	}
+
+	return notifications, filteredCount, totalCount, nil
+}
+
+// GetNotifications fetches a list of a user's notifications from the database that meet a particular filter.
+func (q *SQLQuerier) GetNotifications(ctx context.Context, userID string, filter *types.QueryFilter) (x *types.NotificationList, err error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger
+
+	if userID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+	logger = logger.WithValue(keys.UserIDKey, userID)
+	tracing.AttachUserIDToSpan(span, userID)
+
+	x = &types.NotificationList{}
+	logger = filter.AttachToLogger(logger)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	if filter != nil {
+		x.Page, x.Limit = filter.Page, filter.Limit
+	}
+
+	query, args := q.buildListQuery(
+		ctx,
+		"notifications",
+		nil,
+		nil,
+		userOwnershipColumn,
+		notificationsTableColumns,
+		userID,
+		false,
+		filter,
+	)
+
+	rows, err := q.performReadQuery(ctx, q.db, "notifications", query, args)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "executing notifications list retrieval query")
+	}
+
+	if x.Notifications, x.FilteredCount, x.TotalCount, err = q.scanNotifications(ctx, rows, true); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning notifications")
+	}
+
+	return x, nil
+}
+
+const getUnreadNotificationCountQuery = `
+	SELECT COUNT(notifications.id) FROM notifications WHERE notifications.archived_on IS NULL AND notifications.seen_on IS NULL AND notifications.belongs_to_user = ?
+`
+
+// GetUnreadNotificationCount fetches the count of a user's notifications they have yet to see.
+func (q *SQLQuerier) GetUnreadNotificationCount(ctx context.Context, userID string) (uint64, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger
+
+	if userID == "" {
+		return 0, ErrInvalidIDProvided
+	}
+	logger = logger.WithValue(keys.UserIDKey, userID)
+	tracing.AttachUserIDToSpan(span, userID)
+
+	var count uint64
+	if err := q.getOneRow(ctx, q.db, "unread notification count", getUnreadNotificationCountQuery, []interface{}{userID}).Scan(&count); err != nil {
+		return 0, observability.PrepareError(err, logger, span, "querying for count of unread notifications")
+	}
+
+	return count, nil
+}
+
+const notificationCreationQuery = `
+	INSERT INTO notifications (id,title,description,belongs_to_account,belongs_to_user,created_on) VALUES (?,?,?,?,?,UNIX_TIMESTAMP())
+`
+
+// CreateNotification creates a notification in the database.
+func (q *SQLQuerier) CreateNotification(ctx context.Context, input *types.NotificationDatabaseCreationInput) (*types.Notification, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	logger := q.logger.WithValue(keys.NotificationIDKey, input.ID).WithValue(keys.UserIDKey, input.BelongsToUser)
+
+	args := []interface{}{
+		input.ID,
+		input.Title,
+		input.Description,
+		input.BelongsToAccount,
+		input.BelongsToUser,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "notification creation", notificationCreationQuery, args); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "creating notification")
+	}
+
+	x := &types.Notification{
+		ID:               input.ID,
+		Title:            input.Title,
+		Description:      input.Description,
+		BelongsToAccount: input.BelongsToAccount,
+		BelongsToUser:    input.BelongsToUser,
+		CreatedOn:        q.currentTime(),
+	}
+
+	tracing.AttachNotificationIDToSpan(span, x.ID)
+	logger.Info("notification created")
+
+	return x, nil
+}
+
+const markNotificationAsSeenQuery = `
+	UPDATE notifications SET last_updated_on = UNIX_TIMESTAMP(), seen_on = UNIX_TIMESTAMP() WHERE archived_on IS NULL AND seen_on IS NULL AND belongs_to_user = ? AND id = ?
+`
+
+// MarkNotificationAsSeen marks one of a user's notifications as seen.
+func (q *SQLQuerier) MarkNotificationAsSeen(ctx context.Context, notificationID, userID string) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger
+
+	if notificationID == "" {
+		return ErrInvalidIDProvided
+	}
+	logger = logger.WithValue(keys.NotificationIDKey, notificationID)
+	tracing.AttachNotificationIDToSpan(span, notificationID)
+
+	if userID == "" {
+		return ErrInvalidIDProvided
+	}
+	logger = logger.WithValue(keys.UserIDKey, userID)
+	tracing.AttachUserIDToSpan(span, userID)
+
+	args := []interface{}{
+		userID,
+		notificationID,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "notification seen", markNotificationAsSeenQuery, args); err != nil {
+		return observability.PrepareError(err, logger, span, "marking notification as seen")
+	}
+
+	logger.Debug("notification marked as seen")
+
+	return nil
+}
+
+const markAllNotificationsAsSeenQuery = `
+	UPDATE notifications SET last_updated_on = UNIX_TIMESTAMP(), seen_on = UNIX_TIMESTAMP() WHERE archived_on IS NULL AND seen_on IS NULL AND belongs_to_user = ?
+`
+
+// MarkAllNotificationsAsSeen marks every one of a user's unseen notifications as seen.
+func (q *SQLQuerier) MarkAllNotificationsAsSeen(ctx context.Context, userID string) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger
+
+	if userID == "" {
+		return ErrInvalidIDProvided
+	}
+	logger = logger.WithValue(keys.UserIDKey, userID)
+	tracing.AttachUserIDToSpan(span, userID)
+
+	args := []interface{}{
+		userID,
+	}
+
+	// having nothing left to mark as seen is not an error.
+	if err := q.performWriteQuery(ctx, q.db, "all notifications seen", markAllNotificationsAsSeenQuery, args); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return observability.PrepareError(err, logger, span, "marking all notifications as seen")
+	}
+
+	logger.Debug("all notifications marked as seen")
+
+	return nil
+}
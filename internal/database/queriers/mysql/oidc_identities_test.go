@@ -0,0 +1,176 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func buildMockRowsFromOIDCIdentities(identities ...*types.OIDCIdentity) *sqlmock.Rows {
+	exampleRows := sqlmock.NewRows(oidcIdentitiesTableColumns)
+
+	for _, x := range identities {
+		rowValues := []driver.Value{
+			x.ID,
+			x.Issuer,
+			x.Subject,
+			x.BelongsToUser,
+			x.CreatedOn,
+			x.ArchivedOn,
+		}
+
+		exampleRows.AddRow(rowValues...)
+	}
+
+	return exampleRows
+}
+
+func TestQuerier_GetOIDCIdentity(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectQuery(formatQueryForSQLMock(getOIDCIdentityQuery)).
+			WithArgs(exampleIdentity.Issuer, exampleIdentity.Subject).
+			WillReturnRows(buildMockRowsFromOIDCIdentities(exampleIdentity))
+
+		actual, err := c.GetOIDCIdentity(ctx, exampleIdentity.Issuer, exampleIdentity.Subject)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleIdentity, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetOIDCIdentity(ctx, "", "subject")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		actual, err = c.GetOIDCIdentity(ctx, "issuer", "")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("respects sql.ErrNoRows", func(t *testing.T) {
+		t.Parallel()
+
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectQuery(formatQueryForSQLMock(getOIDCIdentityQuery)).
+			WithArgs(exampleIdentity.Issuer, exampleIdentity.Subject).
+			WillReturnError(sql.ErrNoRows)
+
+		actual, err := c.GetOIDCIdentity(ctx, exampleIdentity.Issuer, exampleIdentity.Subject)
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectQuery(formatQueryForSQLMock(getOIDCIdentityQuery)).
+			WithArgs(exampleIdentity.Issuer, exampleIdentity.Subject).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetOIDCIdentity(ctx, exampleIdentity.Issuer, exampleIdentity.Subject)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_CreateOIDCIdentity(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+		exampleInput := fakes.BuildFakeOIDCIdentityDatabaseCreationInputFromOIDCIdentity(exampleIdentity)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.Issuer,
+			exampleInput.Subject,
+			exampleInput.BelongsToUser,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(oidcIdentityCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleIdentity.ID))
+
+		c.timeFunc = func() uint64 {
+			return exampleIdentity.CreatedOn
+		}
+
+		actual, err := c.CreateOIDCIdentity(ctx, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleIdentity, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.CreateOIDCIdentity(ctx, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+		exampleInput := fakes.BuildFakeOIDCIdentityDatabaseCreationInputFromOIDCIdentity(exampleIdentity)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(oidcIdentityCreationQuery)).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.CreateOIDCIdentity(ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
@@ -0,0 +1,215 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func buildMockRowsFromPasswordResetTokens(tokens ...*types.PasswordResetToken) *sqlmock.Rows {
+	exampleRows := sqlmock.NewRows(passwordResetTokensTableColumns)
+
+	for _, x := range tokens {
+		rowValues := []driver.Value{
+			x.ID,
+			x.HashedToken,
+			x.BelongsToUser,
+			x.ExpiresAt,
+			x.RedeemedOn,
+			x.CreatedOn,
+		}
+
+		exampleRows.AddRow(rowValues...)
+	}
+
+	return exampleRows
+}
+
+func TestQuerier_GetPasswordResetTokenByToken(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleToken := fakes.BuildFakePasswordResetToken()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleToken.HashedToken}
+
+		db.ExpectQuery(formatQueryForSQLMock(getPasswordResetTokenByTokenQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromPasswordResetTokens(exampleToken))
+
+		actual, err := c.GetPasswordResetTokenByToken(ctx, exampleToken.HashedToken)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleToken, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with empty token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetPasswordResetTokenByToken(ctx, "")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleToken := fakes.BuildFakePasswordResetToken()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleToken.HashedToken}
+
+		db.ExpectQuery(formatQueryForSQLMock(getPasswordResetTokenByTokenQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetPasswordResetTokenByToken(ctx, exampleToken.HashedToken)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_CreatePasswordResetToken(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleToken := fakes.BuildFakePasswordResetToken()
+		exampleInput := fakes.BuildFakePasswordResetTokenDatabaseCreationInputFromPasswordResetToken(exampleToken)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.HashedToken,
+			exampleInput.BelongsToUser,
+			exampleInput.ExpiresAt,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(passwordResetTokenCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleToken.ID))
+
+		c.timeFunc = func() uint64 {
+			return exampleToken.CreatedOn
+		}
+
+		actual, err := c.CreatePasswordResetToken(ctx, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleToken, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.CreatePasswordResetToken(ctx, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		exampleToken := fakes.BuildFakePasswordResetToken()
+		exampleInput := fakes.BuildFakePasswordResetTokenDatabaseCreationInputFromPasswordResetToken(exampleToken)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.HashedToken,
+			exampleInput.BelongsToUser,
+			exampleInput.ExpiresAt,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(passwordResetTokenCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.CreatePasswordResetToken(ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_RedeemPasswordResetToken(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleToken := fakes.BuildFakePasswordResetToken()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleToken.ID}
+
+		db.ExpectExec(formatQueryForSQLMock(redeemPasswordResetTokenQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleToken.ID))
+
+		assert.NoError(t, c.RedeemPasswordResetToken(ctx, exampleToken.ID))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.RedeemPasswordResetToken(ctx, ""))
+	})
+
+	T.Run("with already redeemed token", func(t *testing.T) {
+		t.Parallel()
+
+		exampleToken := fakes.BuildFakePasswordResetToken()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleToken.ID}
+
+		db.ExpectExec(formatQueryForSQLMock(redeemPasswordResetTokenQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.Error(t, c.RedeemPasswordResetToken(ctx, exampleToken.ID))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
@@ -0,0 +1,222 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func buildTOTPRecoveryCodeCreationInputs() []*types.TOTPRecoveryCodeDatabaseCreationInput {
+	var inputs []*types.TOTPRecoveryCodeDatabaseCreationInput
+	for _, code := range fakes.BuildFakeTOTPRecoveryCodesResponse().RecoveryCodes {
+		inputs = append(inputs, &types.TOTPRecoveryCodeDatabaseCreationInput{
+			ID:         fakes.BuildFakeID(),
+			HashedCode: code,
+		})
+	}
+
+	return inputs
+}
+
+func TestQuerier_CreateTOTPRecoveryCodes(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleInput := buildTOTPRecoveryCodeCreationInputs()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteTOTPRecoveryCodesQuery)).
+			WithArgs(exampleUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		for _, code := range exampleInput {
+			db.ExpectExec(formatQueryForSQLMock(totpRecoveryCodeCreationQuery)).
+				WithArgs(code.ID, code.HashedCode, exampleUserID).
+				WillReturnResult(newArbitraryDatabaseResult(code.ID))
+		}
+
+		db.ExpectCommit()
+
+		assert.NoError(t, c.CreateTOTPRecoveryCodes(ctx, exampleUserID, exampleInput))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.CreateTOTPRecoveryCodes(ctx, "", buildTOTPRecoveryCodeCreationInputs()))
+	})
+
+	T.Run("with empty input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.CreateTOTPRecoveryCodes(ctx, fakes.BuildFakeID(), nil))
+	})
+
+	T.Run("with error beginning transaction", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin().WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.CreateTOTPRecoveryCodes(ctx, fakes.BuildFakeID(), buildTOTPRecoveryCodeCreationInputs()))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error removing previous codes", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteTOTPRecoveryCodesQuery)).
+			WithArgs(exampleUserID).
+			WillReturnError(errors.New("blah"))
+
+		db.ExpectRollback()
+
+		assert.Error(t, c.CreateTOTPRecoveryCodes(ctx, exampleUserID, buildTOTPRecoveryCodeCreationInputs()))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error creating code", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleInput := buildTOTPRecoveryCodeCreationInputs()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteTOTPRecoveryCodesQuery)).
+			WithArgs(exampleUserID).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserID))
+
+		db.ExpectExec(formatQueryForSQLMock(totpRecoveryCodeCreationQuery)).
+			WithArgs(exampleInput[0].ID, exampleInput[0].HashedCode, exampleUserID).
+			WillReturnError(errors.New("blah"))
+
+		db.ExpectRollback()
+
+		assert.Error(t, c.CreateTOTPRecoveryCodes(ctx, exampleUserID, exampleInput))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error committing transaction", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleInput := buildTOTPRecoveryCodeCreationInputs()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteTOTPRecoveryCodesQuery)).
+			WithArgs(exampleUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		for _, code := range exampleInput {
+			db.ExpectExec(formatQueryForSQLMock(totpRecoveryCodeCreationQuery)).
+				WithArgs(code.ID, code.HashedCode, exampleUserID).
+				WillReturnResult(newArbitraryDatabaseResult(code.ID))
+		}
+
+		db.ExpectCommit().WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.CreateTOTPRecoveryCodes(ctx, exampleUserID, exampleInput))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_RedeemTOTPRecoveryCode(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleHashedCode := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(redeemTOTPRecoveryCodeQuery)).
+			WithArgs(exampleUserID, exampleHashedCode).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserID))
+
+		assert.NoError(t, c.RedeemTOTPRecoveryCode(ctx, exampleUserID, exampleHashedCode))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.RedeemTOTPRecoveryCode(ctx, "", fakes.BuildFakeID()))
+	})
+
+	T.Run("with empty code", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.RedeemTOTPRecoveryCode(ctx, fakes.BuildFakeID(), ""))
+	})
+
+	T.Run("with unknown code", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleHashedCode := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(redeemTOTPRecoveryCodeQuery)).
+			WithArgs(exampleUserID, exampleHashedCode).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.Error(t, c.RedeemTOTPRecoveryCode(ctx, exampleUserID, exampleHashedCode))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
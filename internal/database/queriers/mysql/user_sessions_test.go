@@ -0,0 +1,731 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func buildMockRowsFromUserSessions(includeCounts bool, filteredCount uint64, sessions ...*types.UserSession) *sqlmock.Rows {
+	columns := userSessionsTableColumns
+
+	if includeCounts {
+		columns = append(columns, "filtered_count", "total_count")
+	}
+
+	exampleRows := sqlmock.NewRows(columns)
+
+	for _, x := range sessions {
+		rowValues := []driver.Value{
+			x.ID,
+			x.Token,
+			x.BelongsToUser,
+			x.ActiveAccount,
+			x.IPAddress,
+			x.UserAgent,
+			x.Browser,
+			x.BrowserVersion,
+			x.OperatingSystem,
+			x.Platform,
+			x.Mobile,
+			x.CreatedOn,
+			x.LastSeenOn,
+			x.ArchivedOn,
+		}
+
+		if includeCounts {
+			rowValues = append(rowValues, filteredCount, len(sessions))
+		}
+
+		exampleRows.AddRow(rowValues...)
+	}
+
+	return exampleRows
+}
+
+func TestQuerier_GetUserSessionsForUser(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+		exampleUserID := fakes.BuildFakeID()
+		exampleUserSessionList := fakes.BuildFakeUserSessionList()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			userSessionsTableName,
+			[]string{userSessionsTableSessionsJoin},
+			nil,
+			userOwnershipColumn,
+			userSessionsTableColumns,
+			exampleUserID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromUserSessions(true, exampleUserSessionList.FilteredCount, exampleUserSessionList.UserSessions...))
+
+		actual, err := c.GetUserSessionsForUser(ctx, exampleUserID, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleUserSessionList, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetUserSessionsForUser(ctx, "", types.DefaultQueryFilter())
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			userSessionsTableName,
+			[]string{userSessionsTableSessionsJoin},
+			nil,
+			userOwnershipColumn,
+			userSessionsTableColumns,
+			exampleUserID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetUserSessionsForUser(ctx, exampleUserID, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with erroneous response", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			userSessionsTableName,
+			[]string{userSessionsTableSessionsJoin},
+			nil,
+			userOwnershipColumn,
+			userSessionsTableColumns,
+			exampleUserID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildErroneousMockRow())
+
+		actual, err := c.GetUserSessionsForUser(ctx, exampleUserID, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_CreateUserSession(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+		exampleInput := fakes.BuildFakeUserSessionDatabaseCreationInputFromUserSession(exampleUserSession)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.Token,
+			exampleInput.BelongsToUser,
+			exampleInput.ActiveAccount,
+			exampleInput.IPAddress,
+			exampleInput.UserAgent,
+			exampleInput.Browser,
+			exampleInput.BrowserVersion,
+			exampleInput.OperatingSystem,
+			exampleInput.Platform,
+			exampleInput.Mobile,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(userSessionCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserSession.ID))
+
+		c.timeFunc = func() uint64 {
+			return exampleUserSession.CreatedOn
+		}
+
+		actual, err := c.CreateUserSession(ctx, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleUserSession, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.CreateUserSession(ctx, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+		exampleInput := fakes.BuildFakeUserSessionDatabaseCreationInputFromUserSession(exampleUserSession)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(userSessionCreationQuery)).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.CreateUserSession(ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_UpdateUserSessionToken(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionForUserSessionQuery)).
+			WithArgs(exampleUserSession.ID).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserSession.ID))
+
+		db.ExpectExec(formatQueryForSQLMock(updateUserSessionTokenQuery)).
+			WithArgs(exampleUserSession.Token, exampleUserSession.ActiveAccount, exampleUserSession.ID).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserSession.ID))
+
+		db.ExpectCommit()
+
+		assert.NoError(t, c.UpdateUserSessionToken(ctx, exampleUserSession.ID, exampleUserSession.Token, exampleUserSession.ActiveAccount))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with previous session already expired", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionForUserSessionQuery)).
+			WithArgs(exampleUserSession.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		db.ExpectExec(formatQueryForSQLMock(updateUserSessionTokenQuery)).
+			WithArgs(exampleUserSession.Token, exampleUserSession.ActiveAccount, exampleUserSession.ID).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserSession.ID))
+
+		db.ExpectCommit()
+
+		assert.NoError(t, c.UpdateUserSessionToken(ctx, exampleUserSession.ID, exampleUserSession.Token, exampleUserSession.ActiveAccount))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid user session ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.UpdateUserSessionToken(ctx, "", t.Name(), t.Name()))
+	})
+
+	T.Run("with empty token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.UpdateUserSessionToken(ctx, fakes.BuildFakeID(), "", t.Name()))
+	})
+
+	T.Run("with error beginning transaction", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin().WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.UpdateUserSessionToken(ctx, exampleUserSession.ID, exampleUserSession.Token, exampleUserSession.ActiveAccount))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error removing previous session", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionForUserSessionQuery)).
+			WithArgs(exampleUserSession.ID).
+			WillReturnError(errors.New("blah"))
+
+		db.ExpectRollback()
+
+		assert.Error(t, c.UpdateUserSessionToken(ctx, exampleUserSession.ID, exampleUserSession.Token, exampleUserSession.ActiveAccount))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error updating user session", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionForUserSessionQuery)).
+			WithArgs(exampleUserSession.ID).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserSession.ID))
+
+		db.ExpectExec(formatQueryForSQLMock(updateUserSessionTokenQuery)).
+			WithArgs(exampleUserSession.Token, exampleUserSession.ActiveAccount, exampleUserSession.ID).
+			WillReturnError(errors.New("blah"))
+
+		db.ExpectRollback()
+
+		assert.Error(t, c.UpdateUserSessionToken(ctx, exampleUserSession.ID, exampleUserSession.Token, exampleUserSession.ActiveAccount))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error committing transaction", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionForUserSessionQuery)).
+			WithArgs(exampleUserSession.ID).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserSession.ID))
+
+		db.ExpectExec(formatQueryForSQLMock(updateUserSessionTokenQuery)).
+			WithArgs(exampleUserSession.Token, exampleUserSession.ActiveAccount, exampleUserSession.ID).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserSession.ID))
+
+		db.ExpectCommit().WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.UpdateUserSessionToken(ctx, exampleUserSession.ID, exampleUserSession.Token, exampleUserSession.ActiveAccount))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_MarkUserSessionAsSeen(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSessionID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(markUserSessionAsSeenQuery)).
+			WithArgs(exampleUserSessionID, userSessionSeenThresholdSeconds).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserSessionID))
+
+		assert.NoError(t, c.MarkUserSessionAsSeen(ctx, exampleUserSessionID))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with recently seen session", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSessionID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(markUserSessionAsSeenQuery)).
+			WithArgs(exampleUserSessionID, userSessionSeenThresholdSeconds).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.NoError(t, c.MarkUserSessionAsSeen(ctx, exampleUserSessionID))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid user session ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.MarkUserSessionAsSeen(ctx, ""))
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSessionID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(markUserSessionAsSeenQuery)).
+			WithArgs(exampleUserSessionID, userSessionSeenThresholdSeconds).
+			WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.MarkUserSessionAsSeen(ctx, exampleUserSessionID))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_RevokeUserSession(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionForUserSessionBelongingToUserQuery)).
+			WithArgs(exampleUserSession.ID, exampleUserSession.BelongsToUser).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserSession.ID))
+
+		db.ExpectExec(formatQueryForSQLMock(archiveUserSessionQuery)).
+			WithArgs(exampleUserSession.ID, exampleUserSession.BelongsToUser).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserSession.ID))
+
+		db.ExpectCommit()
+
+		assert.NoError(t, c.RevokeUserSession(ctx, exampleUserSession.ID, exampleUserSession.BelongsToUser))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid IDs", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.RevokeUserSession(ctx, "", fakes.BuildFakeID()))
+		assert.Error(t, c.RevokeUserSession(ctx, fakes.BuildFakeID(), ""))
+	})
+
+	T.Run("with error beginning transaction", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin().WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.RevokeUserSession(ctx, exampleUserSession.ID, exampleUserSession.BelongsToUser))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error removing session", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionForUserSessionBelongingToUserQuery)).
+			WithArgs(exampleUserSession.ID, exampleUserSession.BelongsToUser).
+			WillReturnError(errors.New("blah"))
+
+		db.ExpectRollback()
+
+		assert.Error(t, c.RevokeUserSession(ctx, exampleUserSession.ID, exampleUserSession.BelongsToUser))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with nonexistent user session", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionForUserSessionBelongingToUserQuery)).
+			WithArgs(exampleUserSession.ID, exampleUserSession.BelongsToUser).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		db.ExpectExec(formatQueryForSQLMock(archiveUserSessionQuery)).
+			WithArgs(exampleUserSession.ID, exampleUserSession.BelongsToUser).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		db.ExpectRollback()
+
+		assert.Error(t, c.RevokeUserSession(ctx, exampleUserSession.ID, exampleUserSession.BelongsToUser))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error committing transaction", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionForUserSessionBelongingToUserQuery)).
+			WithArgs(exampleUserSession.ID, exampleUserSession.BelongsToUser).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserSession.ID))
+
+		db.ExpectExec(formatQueryForSQLMock(archiveUserSessionQuery)).
+			WithArgs(exampleUserSession.ID, exampleUserSession.BelongsToUser).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserSession.ID))
+
+		db.ExpectCommit().WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.RevokeUserSession(ctx, exampleUserSession.ID, exampleUserSession.BelongsToUser))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_RevokeUserSessionsForUser(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleUserSessionID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionsForUserQuery)).
+			WithArgs(exampleUserID, exampleUserSessionID).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserID))
+
+		db.ExpectExec(formatQueryForSQLMock(archiveUserSessionsForUserQuery)).
+			WithArgs(exampleUserID, exampleUserSessionID).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserID))
+
+		db.ExpectCommit()
+
+		assert.NoError(t, c.RevokeUserSessionsForUser(ctx, exampleUserID, exampleUserSessionID))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("without any sessions", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionsForUserQuery)).
+			WithArgs(exampleUserID, "").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		db.ExpectExec(formatQueryForSQLMock(archiveUserSessionsForUserQuery)).
+			WithArgs(exampleUserID, "").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		db.ExpectCommit()
+
+		assert.NoError(t, c.RevokeUserSessionsForUser(ctx, exampleUserID, ""))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.RevokeUserSessionsForUser(ctx, "", ""))
+	})
+
+	T.Run("with error beginning transaction", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin().WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.RevokeUserSessionsForUser(ctx, fakes.BuildFakeID(), ""))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error removing sessions", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionsForUserQuery)).
+			WithArgs(exampleUserID, "").
+			WillReturnError(errors.New("blah"))
+
+		db.ExpectRollback()
+
+		assert.Error(t, c.RevokeUserSessionsForUser(ctx, exampleUserID, ""))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error archiving user sessions", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionsForUserQuery)).
+			WithArgs(exampleUserID, "").
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserID))
+
+		db.ExpectExec(formatQueryForSQLMock(archiveUserSessionsForUserQuery)).
+			WithArgs(exampleUserID, "").
+			WillReturnError(errors.New("blah"))
+
+		db.ExpectRollback()
+
+		assert.Error(t, c.RevokeUserSessionsForUser(ctx, exampleUserID, ""))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error committing transaction", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectBegin()
+
+		db.ExpectExec(formatQueryForSQLMock(deleteSessionsForUserQuery)).
+			WithArgs(exampleUserID, "").
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserID))
+
+		db.ExpectExec(formatQueryForSQLMock(archiveUserSessionsForUserQuery)).
+			WithArgs(exampleUserID, "").
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserID))
+
+		db.ExpectCommit().WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.RevokeUserSessionsForUser(ctx, exampleUserID, ""))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
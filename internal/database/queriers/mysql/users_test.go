@@ -32,6 +32,7 @@ func buildMockRowsFromUsers(includeCounts bool, filteredCount uint64, users ...*
 		rowValues := []driver.Value{
 			user.ID,
 			user.Username,
+			user.EmailAddress,
 			user.AvatarSrc,
 			user.HashedPassword,
 			user.RequiresPasswordChange,
@@ -1057,6 +1058,7 @@ func TestQuerier_CreateUser(T *testing.T) {
 		userCreationArgs := []interface{}{
 			exampleUserCreationInput.ID,
 			exampleUserCreationInput.Username,
+			exampleUserCreationInput.EmailAddress,
 			exampleUserCreationInput.HashedPassword,
 			exampleUserCreationInput.TwoFactorSecret,
 			"",
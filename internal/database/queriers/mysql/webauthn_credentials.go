@@ -0,0 +1,226 @@
+package mysql
+
+import (
+	"context"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var (
+	_ types.WebAuthnCredentialDataManager = (*SQLQuerier)(nil)
+
+	// webAuthnCredentialsTableColumns are the columns for the WebAuthn credentials table.
+	webAuthnCredentialsTableColumns = []string{
+		"webauthn_credentials.id",
+		"webauthn_credentials.name",
+		"webauthn_credentials.credential_id",
+		"webauthn_credentials.public_key",
+		"webauthn_credentials.attestation_type",
+		"webauthn_credentials.aaguid",
+		"webauthn_credentials.sign_count",
+		"webauthn_credentials.belongs_to_user",
+		"webauthn_credentials.last_used_on",
+		"webauthn_credentials.created_on",
+		"webauthn_credentials.archived_on",
+	}
+)
+
+// scanWebAuthnCredential takes a database Scanner (i.e. *sql.Row) and scans the result into a WebAuthn credential struct.
+func (q *SQLQuerier) scanWebAuthnCredential(ctx context.Context, scan database.Scanner) (*types.WebAuthnCredential, error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	x := &types.WebAuthnCredential{}
+
+	targetVars := []interface{}{
+		&x.ID,
+		&x.Name,
+		&x.CredentialID,
+		&x.PublicKey,
+		&x.AttestationType,
+		&x.AAGUID,
+		&x.SignCount,
+		&x.BelongsToUser,
+		&x.LastUsedOn,
+		&x.CreatedOn,
+		&x.ArchivedOn,
+	}
+
+	if err := scan.Scan(targetVars...); err != nil {
+		return nil, observability.PrepareError(err, q.logger, span, "scanning WebAuthn credential")
+	}
+
+	return x, nil
+}
+
+// scanWebAuthnCredentials takes some database rows and turns them into a slice of WebAuthn credentials.
+func (q *SQLQuerier) scanWebAuthnCredentials(ctx context.Context, rows database.ResultIterator) ([]*types.WebAuthnCredential, error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	credentials := []*types.WebAuthnCredential{}
+
+	for rows.Next() {
+		x, scanErr := q.scanWebAuthnCredential(ctx, rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+
+		credentials = append(credentials, x)
+	}
+
+	if err := q.checkRowsForErrorAndClose(ctx, rows); err != nil {
+		return nil, observability.PrepareError(err, q.logger, span, "handling rows")
+	}
+
+	return credentials, nil
+}
+
+const getWebAuthnCredentialsForUserQuery = `
+	SELECT webauthn_credentials.id, webauthn_credentials.name, webauthn_credentials.credential_id, webauthn_credentials.public_key, webauthn_credentials.attestation_type, webauthn_credentials.aaguid, webauthn_credentials.sign_count, webauthn_credentials.belongs_to_user, webauthn_credentials.last_used_on, webauthn_credentials.created_on, webauthn_credentials.archived_on FROM webauthn_credentials WHERE webauthn_credentials.archived_on IS NULL AND webauthn_credentials.belongs_to_user = ? ORDER BY webauthn_credentials.created_on
+`
+
+// GetWebAuthnCredentialsForUser fetches every WebAuthn credential a user has registered.
+func (q *SQLQuerier) GetWebAuthnCredentialsForUser(ctx context.Context, userID string) (*types.WebAuthnCredentialList, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	tracing.AttachUserIDToSpan(span, userID)
+	logger := q.logger.WithValue(keys.UserIDKey, userID)
+
+	args := []interface{}{
+		userID,
+	}
+
+	rows, err := q.performReadQuery(ctx, q.db, "WebAuthn credentials for user", getWebAuthnCredentialsForUserQuery, args)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "fetching WebAuthn credentials from database")
+	}
+
+	x := &types.WebAuthnCredentialList{}
+	if x.WebAuthnCredentials, err = q.scanWebAuthnCredentials(ctx, rows); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning WebAuthn credentials")
+	}
+
+	x.FilteredCount = uint64(len(x.WebAuthnCredentials))
+	x.TotalCount = x.FilteredCount
+
+	return x, nil
+}
+
+const webAuthnCredentialCreationQuery = `
+	INSERT INTO webauthn_credentials (id,name,credential_id,public_key,attestation_type,aaguid,sign_count,belongs_to_user,created_on) VALUES (?,?,?,?,?,?,?,?,UNIX_TIMESTAMP())
+`
+
+// CreateWebAuthnCredential records a newly registered WebAuthn credential in the database.
+func (q *SQLQuerier) CreateWebAuthnCredential(ctx context.Context, input *types.WebAuthnCredentialDatabaseCreationInput) (*types.WebAuthnCredential, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	tracing.AttachWebAuthnCredentialIDToSpan(span, input.ID)
+	tracing.AttachUserIDToSpan(span, input.BelongsToUser)
+	logger := q.logger.WithValue(keys.WebAuthnCredentialIDKey, input.ID).WithValue(keys.UserIDKey, input.BelongsToUser)
+
+	args := []interface{}{
+		input.ID,
+		input.Name,
+		input.CredentialID,
+		input.PublicKey,
+		input.AttestationType,
+		input.AAGUID,
+		input.SignCount,
+		input.BelongsToUser,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "WebAuthn credential creation", webAuthnCredentialCreationQuery, args); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "creating WebAuthn credential")
+	}
+
+	x := &types.WebAuthnCredential{
+		ID:              input.ID,
+		Name:            input.Name,
+		BelongsToUser:   input.BelongsToUser,
+		AttestationType: input.AttestationType,
+		CredentialID:    input.CredentialID,
+		PublicKey:       input.PublicKey,
+		AAGUID:          input.AAGUID,
+		SignCount:       input.SignCount,
+		CreatedOn:       q.currentTime(),
+	}
+
+	logger.Info("WebAuthn credential created")
+
+	return x, nil
+}
+
+const markWebAuthnCredentialAsUsedQuery = `
+	UPDATE webauthn_credentials SET sign_count = ?, last_used_on = UNIX_TIMESTAMP() WHERE archived_on IS NULL AND id = ?
+`
+
+// MarkWebAuthnCredentialAsUsed records that a WebAuthn credential was just used to log in, along with the
+// signature counter the authenticator reported.
+func (q *SQLQuerier) MarkWebAuthnCredentialAsUsed(ctx context.Context, webAuthnCredentialID string, signCount uint32) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webAuthnCredentialID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	tracing.AttachWebAuthnCredentialIDToSpan(span, webAuthnCredentialID)
+	logger := q.logger.WithValue(keys.WebAuthnCredentialIDKey, webAuthnCredentialID)
+
+	args := []interface{}{
+		signCount,
+		webAuthnCredentialID,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "WebAuthn credential usage", markWebAuthnCredentialAsUsedQuery, args); err != nil {
+		return observability.PrepareError(err, logger, span, "marking WebAuthn credential as used")
+	}
+
+	return nil
+}
+
+const archiveWebAuthnCredentialQuery = `
+	UPDATE webauthn_credentials SET archived_on = UNIX_TIMESTAMP() WHERE archived_on IS NULL AND id = ? AND belongs_to_user = ?
+`
+
+// ArchiveWebAuthnCredential archives a WebAuthn credential, so that it can no longer be used to log in.
+func (q *SQLQuerier) ArchiveWebAuthnCredential(ctx context.Context, webAuthnCredentialID, userID string) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webAuthnCredentialID == "" || userID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	tracing.AttachWebAuthnCredentialIDToSpan(span, webAuthnCredentialID)
+	tracing.AttachUserIDToSpan(span, userID)
+	logger := q.logger.WithValue(keys.WebAuthnCredentialIDKey, webAuthnCredentialID).WithValue(keys.UserIDKey, userID)
+
+	args := []interface{}{
+		webAuthnCredentialID,
+		userID,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "WebAuthn credential archive", archiveWebAuthnCredentialQuery, args); err != nil {
+		return observability.PrepareError(err, logger, span, "archiving WebAuthn credential")
+	}
+
+	logger.Info("WebAuthn credential archived")
+
+	return nil
+}
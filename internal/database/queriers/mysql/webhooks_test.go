@@ -36,6 +36,9 @@ func buildMockRowsFromWebhooks(includeCounts bool, filteredCount uint64, webhook
 			strings.Join(w.Events, webhooksTableEventsSeparator),
 			strings.Join(w.DataTypes, webhooksTableDataTypesSeparator),
 			strings.Join(w.Topics, webhooksTableTopicsSeparator),
+			w.SigningSecret,
+			w.PreviousSigningSecret,
+			w.PreviousSigningSecretExpiresOn,
 			w.CreatedOn,
 			w.LastUpdatedOn,
 			w.ArchivedOn,
@@ -67,6 +70,9 @@ func buildErroneousMockRowsFromWebhooks(includeCounts bool, filteredCount uint64
 			strings.Join(w.Events, webhooksTableEventsSeparator),
 			strings.Join(w.DataTypes, webhooksTableDataTypesSeparator),
 			strings.Join(w.Topics, webhooksTableTopicsSeparator),
+			w.SigningSecret,
+			w.PreviousSigningSecret,
+			w.PreviousSigningSecretExpiresOn,
 			w.ID,
 			w.Name,
 			w.ContentType,
@@ -517,6 +523,7 @@ func TestQuerier_CreateWebhook(T *testing.T) {
 			strings.Join(exampleInput.Events, webhooksTableEventsSeparator),
 			strings.Join(exampleInput.DataTypes, webhooksTableDataTypesSeparator),
 			strings.Join(exampleInput.Topics, webhooksTableTopicsSeparator),
+			exampleInput.SigningSecret,
 			exampleInput.BelongsToAccount,
 		}
 
@@ -564,6 +571,7 @@ func TestQuerier_CreateWebhook(T *testing.T) {
 			strings.Join(exampleInput.Events, webhooksTableEventsSeparator),
 			strings.Join(exampleInput.DataTypes, webhooksTableDataTypesSeparator),
 			strings.Join(exampleInput.Topics, webhooksTableTopicsSeparator),
+			exampleInput.SigningSecret,
 			exampleInput.BelongsToAccount,
 		}
 
@@ -583,6 +591,77 @@ func TestQuerier_CreateWebhook(T *testing.T) {
 	})
 }
 
+func TestQuerier_UpdateWebhook(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleWebhook.Name,
+			exampleWebhook.ContentType,
+			exampleWebhook.URL,
+			exampleWebhook.Method,
+			strings.Join(exampleWebhook.Events, webhooksTableEventsSeparator),
+			strings.Join(exampleWebhook.DataTypes, webhooksTableDataTypesSeparator),
+			strings.Join(exampleWebhook.Topics, webhooksTableTopicsSeparator),
+			exampleWebhook.BelongsToAccount,
+			exampleWebhook.ID,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(updateWebhookQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleWebhook.ID))
+
+		assert.NoError(t, c.UpdateWebhook(ctx, exampleWebhook))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.UpdateWebhook(ctx, nil))
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleWebhook.Name,
+			exampleWebhook.ContentType,
+			exampleWebhook.URL,
+			exampleWebhook.Method,
+			strings.Join(exampleWebhook.Events, webhooksTableEventsSeparator),
+			strings.Join(exampleWebhook.DataTypes, webhooksTableDataTypesSeparator),
+			strings.Join(exampleWebhook.Topics, webhooksTableTopicsSeparator),
+			exampleWebhook.BelongsToAccount,
+			exampleWebhook.ID,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(updateWebhookQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.UpdateWebhook(ctx, exampleWebhook))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
 func TestQuerier_ArchiveWebhook(T *testing.T) {
 	T.Parallel()
 
@@ -649,3 +728,80 @@ func TestQuerier_ArchiveWebhook(T *testing.T) {
 		mock.AssertExpectationsForObjects(t, db)
 	})
 }
+
+func TestQuerier_RotateWebhookSigningSecret(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		exampleWebhookID := fakes.BuildFakeID()
+		exampleSecret := fakes.BuildFakeWebhook().SigningSecret
+		exampleExpiry := uint64(123456789)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleExpiry, exampleSecret, exampleAccountID, exampleWebhookID}
+
+		db.ExpectExec(formatQueryForSQLMock(rotateWebhookSigningSecretQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleWebhookID))
+
+		actual := c.RotateWebhookSigningSecret(ctx, exampleWebhookID, exampleAccountID, exampleSecret, exampleExpiry)
+		assert.NoError(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid webhook ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.RotateWebhookSigningSecret(ctx, "", fakes.BuildFakeID(), "blah", 123))
+	})
+
+	T.Run("with invalid account ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.RotateWebhookSigningSecret(ctx, fakes.BuildFakeID(), "", "blah", 123))
+	})
+
+	T.Run("with empty secret", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.RotateWebhookSigningSecret(ctx, fakes.BuildFakeID(), fakes.BuildFakeID(), "", 123))
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		exampleWebhookID := fakes.BuildFakeID()
+		exampleSecret := fakes.BuildFakeWebhook().SigningSecret
+		exampleExpiry := uint64(123456789)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleExpiry, exampleSecret, exampleAccountID, exampleWebhookID}
+
+		db.ExpectExec(formatQueryForSQLMock(rotateWebhookSigningSecretQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual := c.RotateWebhookSigningSecret(ctx, exampleWebhookID, exampleAccountID, exampleSecret, exampleExpiry)
+		assert.Error(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
@@ -0,0 +1,518 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func buildMockRowsFromAccountInvitations(includeCounts bool, filteredCount uint64, invitations ...*types.AccountInvitation) *sqlmock.Rows {
+	columns := accountInvitationsTableColumns
+
+	if includeCounts {
+		columns = append(columns, "filtered_count", "total_count")
+	}
+
+	exampleRows := sqlmock.NewRows(columns)
+
+	for _, x := range invitations {
+		var toUser driver.Value
+		if x.ToUser != nil {
+			toUser = *x.ToUser
+		}
+
+		rowValues := []driver.Value{
+			x.ID,
+			x.FromUser,
+			toUser,
+			x.ToEmail,
+			x.DestinationAccount,
+			x.Note,
+			string(x.Status),
+			strings.Join(x.AccountRoles, accountInvitationsTableRolesSeparator),
+			x.ExpiresAt,
+			x.CreatedOn,
+			x.LastUpdatedOn,
+			x.ArchivedOn,
+		}
+
+		if includeCounts {
+			rowValues = append(rowValues, filteredCount, len(invitations))
+		}
+
+		exampleRows.AddRow(rowValues...)
+	}
+
+	return exampleRows
+}
+
+func TestQuerier_GetAccountInvitation(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleInvitation := fakes.BuildFakeAccountInvitation()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleInvitation.ID}
+
+		db.ExpectQuery(formatQueryForSQLMock(getAccountInvitationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromAccountInvitations(false, 0, exampleInvitation))
+
+		actual, err := c.GetAccountInvitation(ctx, exampleInvitation.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleInvitation, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetAccountInvitation(ctx, "")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleInvitation := fakes.BuildFakeAccountInvitation()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleInvitation.ID}
+
+		db.ExpectQuery(formatQueryForSQLMock(getAccountInvitationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetAccountInvitation(ctx, exampleInvitation.ID)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_GetPendingAccountInvitationsForAccount(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+		exampleAccountID := fakes.BuildFakeID()
+		exampleInvitationList := fakes.BuildFakeAccountInvitationList()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			accountInvitationsTableName,
+			nil,
+			squirrel.Eq{"account_invitations.status": types.PendingAccountInvitationStatus},
+			accountInvitationsTableDestinationColumn,
+			accountInvitationsTableColumns,
+			exampleAccountID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromAccountInvitations(true, exampleInvitationList.FilteredCount, exampleInvitationList.AccountInvitations...))
+
+		actual, err := c.GetPendingAccountInvitationsForAccount(ctx, exampleAccountID, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleInvitationList, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid account ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetPendingAccountInvitationsForAccount(ctx, "", types.DefaultQueryFilter())
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+		exampleAccountID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			accountInvitationsTableName,
+			nil,
+			squirrel.Eq{"account_invitations.status": types.PendingAccountInvitationStatus},
+			accountInvitationsTableDestinationColumn,
+			accountInvitationsTableColumns,
+			exampleAccountID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetPendingAccountInvitationsForAccount(ctx, exampleAccountID, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_GetPendingAccountInvitationsForUser(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUser := fakes.BuildFakeUser()
+		exampleInvitationList := fakes.BuildFakeAccountInvitationList()
+		exampleInvitationList.Page, exampleInvitationList.Limit = 0, 0
+		exampleInvitationList.FilteredCount = uint64(len(exampleInvitationList.AccountInvitations))
+		exampleInvitationList.TotalCount = exampleInvitationList.FilteredCount
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleUser.ID,
+		}
+
+		db.ExpectQuery(formatQueryForSQLMock(getPendingAccountInvitationsForUserQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromAccountInvitations(false, 0, exampleInvitationList.AccountInvitations...))
+
+		actual, err := c.GetPendingAccountInvitationsForUser(ctx, exampleUser.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleInvitationList, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetPendingAccountInvitationsForUser(ctx, "")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUser := fakes.BuildFakeUser()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleUser.ID,
+		}
+
+		db.ExpectQuery(formatQueryForSQLMock(getPendingAccountInvitationsForUserQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetPendingAccountInvitationsForUser(ctx, exampleUser.ID)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_CreateAccountInvitation(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleInvitation := fakes.BuildFakeAccountInvitation()
+		exampleInput := fakes.BuildFakeAccountInvitationDatabaseCreationInputFromAccountInvitation(exampleInvitation)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.FromUser,
+			exampleInput.ToUser,
+			exampleInput.ToEmail,
+			exampleInput.TokenHash,
+			exampleInput.DestinationAccount,
+			exampleInput.Note,
+			types.PendingAccountInvitationStatus,
+			strings.Join(exampleInput.AccountRoles, accountInvitationsTableRolesSeparator),
+			exampleInput.ExpiresAt,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(accountInvitationCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleInvitation.ID))
+
+		c.timeFunc = func() uint64 {
+			return exampleInvitation.CreatedOn
+		}
+
+		actual, err := c.CreateAccountInvitation(ctx, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleInvitation, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.CreateAccountInvitation(ctx, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		exampleInvitation := fakes.BuildFakeAccountInvitation()
+		exampleInput := fakes.BuildFakeAccountInvitationDatabaseCreationInputFromAccountInvitation(exampleInvitation)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.FromUser,
+			exampleInput.ToUser,
+			exampleInput.ToEmail,
+			exampleInput.TokenHash,
+			exampleInput.DestinationAccount,
+			exampleInput.Note,
+			types.PendingAccountInvitationStatus,
+			strings.Join(exampleInput.AccountRoles, accountInvitationsTableRolesSeparator),
+			exampleInput.ExpiresAt,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(accountInvitationCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.CreateAccountInvitation(ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_ClaimAccountInvitation(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleTokenHash := t.Name()
+		exampleInvitation := fakes.BuildFakeAccountInvitation()
+		exampleInvitation.ToUser = nil
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectQuery(formatQueryForSQLMock(getUnclaimedAccountInvitationQuery)).
+			WithArgs(interfaceToDriverValue([]interface{}{exampleTokenHash})...).
+			WillReturnRows(buildMockRowsFromAccountInvitations(false, 0, exampleInvitation))
+
+		args := []interface{}{
+			exampleUserID,
+			exampleInvitation.ID,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(claimAccountInvitationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleInvitation.ID))
+
+		actual, err := c.ClaimAccountInvitation(ctx, exampleTokenHash, exampleUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleInvitation.ID, actual.ID)
+		assert.Equal(t, &exampleUserID, actual.ToUser)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with empty token hash", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.ClaimAccountInvitation(ctx, "", fakes.BuildFakeID())
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.ClaimAccountInvitation(ctx, t.Name(), "")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error fetching invitation", func(t *testing.T) {
+		t.Parallel()
+
+		exampleTokenHash := t.Name()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectQuery(formatQueryForSQLMock(getUnclaimedAccountInvitationQuery)).
+			WithArgs(interfaceToDriverValue([]interface{}{exampleTokenHash})...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.ClaimAccountInvitation(ctx, exampleTokenHash, fakes.BuildFakeID())
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with already claimed invitation", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleTokenHash := t.Name()
+		exampleInvitation := fakes.BuildFakeAccountInvitation()
+		exampleInvitation.ToUser = nil
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectQuery(formatQueryForSQLMock(getUnclaimedAccountInvitationQuery)).
+			WithArgs(interfaceToDriverValue([]interface{}{exampleTokenHash})...).
+			WillReturnRows(buildMockRowsFromAccountInvitations(false, 0, exampleInvitation))
+
+		args := []interface{}{
+			exampleUserID,
+			exampleInvitation.ID,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(claimAccountInvitationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		actual, err := c.ClaimAccountInvitation(ctx, exampleTokenHash, exampleUserID)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_SetAccountInvitationStatus(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleInvitation := fakes.BuildFakeAccountInvitation()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			types.AcceptedAccountInvitationStatus,
+			exampleInvitation.ID,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(setAccountInvitationStatusQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleInvitation.ID))
+
+		assert.NoError(t, c.SetAccountInvitationStatus(ctx, exampleInvitation.ID, types.AcceptedAccountInvitationStatus))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.SetAccountInvitationStatus(ctx, "", types.AcceptedAccountInvitationStatus))
+	})
+
+	T.Run("with empty status", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.SetAccountInvitationStatus(ctx, fakes.BuildFakeID(), ""))
+	})
+
+	T.Run("with already settled invitation", func(t *testing.T) {
+		t.Parallel()
+
+		exampleInvitation := fakes.BuildFakeAccountInvitation()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			types.AcceptedAccountInvitationStatus,
+			exampleInvitation.ID,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(setAccountInvitationStatusQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.Error(t, c.SetAccountInvitationStatus(ctx, exampleInvitation.ID, types.AcceptedAccountInvitationStatus))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
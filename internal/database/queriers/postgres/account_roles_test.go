@@ -0,0 +1,550 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func buildMockRowsFromAccountRoles(includeCounts bool, filteredCount uint64, accountRoles ...*types.AccountRole) *sqlmock.Rows {
+	columns := accountRolesTableColumns
+
+	if includeCounts {
+		columns = append(columns, "filtered_count", "total_count")
+	}
+
+	exampleRows := sqlmock.NewRows(columns)
+
+	for _, x := range accountRoles {
+		rowValues := []driver.Value{
+			x.ID,
+			x.Name,
+			x.Description,
+			strings.Join(x.Permissions, accountRolesTablePermissionsSeparator),
+			x.CreatedOn,
+			x.LastUpdatedOn,
+			x.ArchivedOn,
+			x.BelongsToAccount,
+		}
+
+		if includeCounts {
+			rowValues = append(rowValues, filteredCount, len(accountRoles))
+		}
+
+		exampleRows.AddRow(rowValues...)
+	}
+
+	return exampleRows
+}
+
+func TestQuerier_GetAccountRole(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleAccountRole.BelongsToAccount,
+			exampleAccountRole.ID,
+		}
+
+		db.ExpectQuery(formatQueryForSQLMock(getAccountRoleQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromAccountRoles(false, 0, exampleAccountRole))
+
+		actual, err := c.GetAccountRole(ctx, exampleAccountRole.ID, exampleAccountRole.BelongsToAccount)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleAccountRole, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid account role ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetAccountRole(ctx, "", fakes.BuildFakeID())
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with invalid account ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetAccountRole(ctx, fakes.BuildFakeID(), "")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleAccountRole.BelongsToAccount,
+			exampleAccountRole.ID,
+		}
+
+		db.ExpectQuery(formatQueryForSQLMock(getAccountRoleQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetAccountRole(ctx, exampleAccountRole.ID, exampleAccountRole.BelongsToAccount)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_GetAccountRoles(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+		exampleAccountID := fakes.BuildFakeID()
+		exampleAccountRoleList := fakes.BuildFakeAccountRoleList()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"account_roles",
+			nil,
+			nil,
+			accountOwnershipColumn,
+			accountRolesTableColumns,
+			exampleAccountID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromAccountRoles(true, exampleAccountRoleList.FilteredCount, exampleAccountRoleList.AccountRoles...))
+
+		actual, err := c.GetAccountRoles(ctx, exampleAccountID, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleAccountRoleList, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid account ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetAccountRoles(ctx, "", types.DefaultQueryFilter())
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+		exampleAccountID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"account_roles",
+			nil,
+			nil,
+			accountOwnershipColumn,
+			accountRolesTableColumns,
+			exampleAccountID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetAccountRoles(ctx, exampleAccountID, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with erroneous response from database", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+		exampleAccountID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"account_roles",
+			nil,
+			nil,
+			accountOwnershipColumn,
+			accountRolesTableColumns,
+			exampleAccountID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildErroneousMockRow())
+
+		actual, err := c.GetAccountRoles(ctx, exampleAccountID, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_CreateAccountRole(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+		exampleInput := fakes.BuildFakeAccountRoleCreationInputFromAccountRole(exampleAccountRole)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.Name,
+			exampleInput.Description,
+			strings.Join(exampleInput.Permissions, accountRolesTablePermissionsSeparator),
+			exampleInput.BelongsToAccount,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(accountRoleCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleAccountRole.ID))
+
+		c.timeFunc = func() uint64 {
+			return exampleAccountRole.CreatedOn
+		}
+
+		actual, err := c.CreateAccountRole(ctx, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleAccountRole, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.CreateAccountRole(ctx, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New(t.Name())
+		exampleInput := fakes.BuildFakeAccountRoleCreationInput()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.Name,
+			exampleInput.Description,
+			strings.Join(exampleInput.Permissions, accountRolesTablePermissionsSeparator),
+			exampleInput.BelongsToAccount,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(accountRoleCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(expectedErr)
+
+		actual, err := c.CreateAccountRole(ctx, exampleInput)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_UpdateAccountRole(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+		c.accountRoleCache.set(exampleAccountRole.ID, &cachedAccountRole{belongsToAccount: exampleAccountRole.BelongsToAccount})
+
+		args := []interface{}{
+			exampleAccountRole.Name,
+			exampleAccountRole.Description,
+			strings.Join(exampleAccountRole.Permissions, accountRolesTablePermissionsSeparator),
+			exampleAccountRole.BelongsToAccount,
+			exampleAccountRole.ID,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(updateAccountRoleQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleAccountRole.ID))
+
+		assert.NoError(t, c.UpdateAccountRole(ctx, exampleAccountRole))
+
+		_, cached := c.accountRoleCache.get(exampleAccountRole.ID)
+		assert.False(t, cached)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.UpdateAccountRole(ctx, nil))
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleAccountRole.Name,
+			exampleAccountRole.Description,
+			strings.Join(exampleAccountRole.Permissions, accountRolesTablePermissionsSeparator),
+			exampleAccountRole.BelongsToAccount,
+			exampleAccountRole.ID,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(updateAccountRoleQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.UpdateAccountRole(ctx, exampleAccountRole))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_ArchiveAccountRole(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+		c.accountRoleCache.set(exampleAccountRole.ID, &cachedAccountRole{belongsToAccount: exampleAccountRole.BelongsToAccount})
+
+		args := []interface{}{
+			exampleAccountRole.BelongsToAccount,
+			exampleAccountRole.ID,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(archiveAccountRoleQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleAccountRole.ID))
+
+		assert.NoError(t, c.ArchiveAccountRole(ctx, exampleAccountRole.ID, exampleAccountRole.BelongsToAccount))
+
+		_, cached := c.accountRoleCache.get(exampleAccountRole.ID)
+		assert.False(t, cached)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid account role ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.ArchiveAccountRole(ctx, "", fakes.BuildFakeID()))
+	})
+
+	T.Run("with invalid account ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.ArchiveAccountRole(ctx, fakes.BuildFakeID(), ""))
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleAccountRole.BelongsToAccount,
+			exampleAccountRole.ID,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(archiveAccountRoleQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.ArchiveAccountRole(ctx, exampleAccountRole.ID, exampleAccountRole.BelongsToAccount))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_buildAccountRolePermissionCheckers(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with only built-in roles", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		actual, err := c.buildAccountRolePermissionCheckers(ctx, map[string][]string{
+			exampleAccountID: {authorization.AccountMemberRole.String()},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, authorization.NewAccountRolePermissionChecker(authorization.AccountMemberRole.String()), actual[exampleAccountID])
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with custom role", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+		exampleAccountRole.Permissions = []string{authorization.ReadItemsPermission.ID()}
+		accountRolesMap := map[string][]string{
+			exampleAccountRole.BelongsToAccount: {exampleAccountRole.ID},
+		}
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildGetAccountRolesWithIDsQuery(ctx, []string{exampleAccountRole.ID})
+
+		// only expected once, as the second build should be served by the cache.
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromAccountRoles(false, 0, exampleAccountRole))
+
+		for i := 0; i < 2; i++ {
+			actual, err := c.buildAccountRolePermissionCheckers(ctx, accountRolesMap)
+			require.NoError(t, err)
+
+			checker := actual[exampleAccountRole.BelongsToAccount]
+			assert.True(t, checker.HasPermission(authorization.ReadItemsPermission))
+			assert.False(t, checker.HasPermission(authorization.CreateItemsPermission))
+		}
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with custom role from another account", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildGetAccountRolesWithIDsQuery(ctx, []string{exampleAccountRole.ID})
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromAccountRoles(false, 0, exampleAccountRole))
+
+		actual, err := c.buildAccountRolePermissionCheckers(ctx, map[string][]string{
+			exampleAccountID: {exampleAccountRole.ID},
+		})
+		assert.NoError(t, err)
+
+		for _, p := range exampleAccountRole.Permissions {
+			assert.False(t, actual[exampleAccountID].HasPermission(authorization.Permission(p)))
+		}
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildGetAccountRolesWithIDsQuery(ctx, []string{exampleAccountRole.ID})
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.buildAccountRolePermissionCheckers(ctx, map[string][]string{
+			exampleAccountRole.BelongsToAccount: {exampleAccountRole.ID},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
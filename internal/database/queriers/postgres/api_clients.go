@@ -4,6 +4,7 @@
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
@@ -12,6 +13,11 @@
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
+const (
+	apiClientsTablePermissionsSeparator = commaSeparator
+	apiClientsTableAccountsSeparator    = commaSeparator
+)
+
 var (
 	_ types.APIClientDataManager = (*SQLQuerier)(nil)
 
@@ -20,6 +26,9 @@
 		"api_clients.name",
 		"api_clients.client_id",
 		"api_clients.secret_key",
+		"api_clients.permissions",
+		"api_clients.admin_permissions",
+		"api_clients.accounts",
 		"api_clients.created_on",
 		"api_clients.last_updated_on",
 		"api_clients.archived_on",
@@ -36,11 +45,16 @@ func (q *SQLQuerier) scanAPIClient(ctx context.Context, scan database.Scanner, i
 
 	client = &types.APIClient{}
 
+	var rawPermissions, rawAdminPermissions, rawAccounts string
+
 	targetVars := []interface{}{
 		&client.ID,
 		&client.Name,
 		&client.ClientID,
 		&client.ClientSecret,
+		&rawPermissions,
+		&rawAdminPermissions,
+		&rawAccounts,
 		&client.CreatedOn,
 		&client.LastUpdatedOn,
 		&client.ArchivedOn,
@@ -55,6 +69,18 @@ func (q *SQLQuerier) scanAPIClient(ctx context.Context, scan database.Scanner, i
 		return nil, 0, 0, observability.PrepareError(err, logger, span, "scanning API client database result")
 	}
 
+	if permissions := strings.Split(rawPermissions, apiClientsTablePermissionsSeparator); len(permissions) >= 1 && permissions[0] != "" {
+		client.Permissions = permissions
+	}
+
+	if adminPermissions := strings.Split(rawAdminPermissions, apiClientsTablePermissionsSeparator); len(adminPermissions) >= 1 && adminPermissions[0] != "" {
+		client.AdminPermissions = adminPermissions
+	}
+
+	if accounts := strings.Split(rawAccounts, apiClientsTableAccountsSeparator); len(accounts) >= 1 && accounts[0] != "" {
+		client.Accounts = accounts
+	}
+
 	return client, filteredCount, totalCount, nil
 }
 
@@ -97,6 +123,9 @@ func (q *SQLQuerier) scanAPIClients(ctx context.Context, rows database.ResultIte
 		api_clients.name,
 		api_clients.client_id,
 		api_clients.secret_key,
+		api_clients.permissions,
+		api_clients.admin_permissions,
+		api_clients.accounts,
 		api_clients.created_on,
 		api_clients.last_updated_on,
 		api_clients.archived_on,
@@ -140,6 +169,9 @@ func (q *SQLQuerier) GetAPIClientByClientID(ctx context.Context, clientID string
 		api_clients.name,
 		api_clients.client_id,
 		api_clients.secret_key,
+		api_clients.permissions,
+		api_clients.admin_permissions,
+		api_clients.accounts,
 		api_clients.created_on,
 		api_clients.last_updated_on,
 		api_clients.archived_on,
@@ -249,7 +281,7 @@ func (q *SQLQuerier) GetAPIClients(ctx context.Context, userID string, filter *t
 }
 
 const createAPIClientQuery = `
-	INSERT INTO api_clients (id,name,client_id,secret_key,belongs_to_user) VALUES ($1,$2,$3,$4,$5)
+	INSERT INTO api_clients (id,name,client_id,secret_key,permissions,admin_permissions,accounts,belongs_to_user) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
 `
 
 // CreateAPIClient creates an API client.
@@ -271,6 +303,9 @@ func (q *SQLQuerier) CreateAPIClient(ctx context.Context, input *types.APIClient
 		input.Name,
 		input.ClientID,
 		input.ClientSecret,
+		strings.Join(input.Permissions, apiClientsTablePermissionsSeparator),
+		strings.Join(input.AdminPermissions, apiClientsTablePermissionsSeparator),
+		strings.Join(input.Accounts, apiClientsTableAccountsSeparator),
 		input.BelongsToUser,
 	}
 
@@ -281,12 +316,15 @@ func (q *SQLQuerier) CreateAPIClient(ctx context.Context, input *types.APIClient
 	tracing.AttachAPIClientDatabaseIDToSpan(span, input.ID)
 
 	client := &types.APIClient{
-		ID:            input.ID,
-		Name:          input.Name,
-		ClientID:      input.ClientID,
-		ClientSecret:  input.ClientSecret,
-		BelongsToUser: input.BelongsToUser,
-		CreatedOn:     q.currentTime(),
+		ID:               input.ID,
+		Name:             input.Name,
+		ClientID:         input.ClientID,
+		ClientSecret:     input.ClientSecret,
+		BelongsToUser:    input.BelongsToUser,
+		Permissions:      input.Permissions,
+		AdminPermissions: input.AdminPermissions,
+		Accounts:         input.Accounts,
+		CreatedOn:        q.currentTime(),
 	}
 
 	logger.Info("API client created")
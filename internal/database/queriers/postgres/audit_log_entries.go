@@ -0,0 +1,281 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	auditLogEntriesTableName = "audit_log_entries"
+)
+
+var (
+	_ types.AuditLogEntryDataManager = (*SQLQuerier)(nil)
+
+	// auditLogEntriesTableColumns are the columns for the audit log entries table.
+	auditLogEntriesTableColumns = []string{
+		"audit_log_entries.id",
+		"audit_log_entries.event_type",
+		"audit_log_entries.actor_user_id",
+		"audit_log_entries.belongs_to_account",
+		"audit_log_entries.resource_type",
+		"audit_log_entries.resource_id",
+		"audit_log_entries.request_id",
+		"audit_log_entries.changes",
+		"audit_log_entries.created_on",
+	}
+)
+
+// scanAuditLogEntry takes a database Scanner (i.e. *sql.Row) and scans the result into an audit log entry struct.
+func (q *SQLQuerier) scanAuditLogEntry(ctx context.Context, scan database.Scanner, includeCounts bool) (x *types.AuditLogEntry, filteredCount, totalCount uint64, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue("include_counts", includeCounts)
+	x = &types.AuditLogEntry{}
+
+	var rawChanges string
+
+	targetVars := []interface{}{
+		&x.ID,
+		&x.EventType,
+		&x.ActorUserID,
+		&x.BelongsToAccount,
+		&x.ResourceType,
+		&x.ResourceID,
+		&x.RequestID,
+		&rawChanges,
+		&x.CreatedOn,
+	}
+
+	if includeCounts {
+		targetVars = append(targetVars, &filteredCount, &totalCount)
+	}
+
+	if err = scan.Scan(targetVars...); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "scanning audit log entry")
+	}
+
+	if err = json.Unmarshal([]byte(rawChanges), &x.Changes); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "parsing audit log entry changes")
+	}
+
+	return x, filteredCount, totalCount, nil
+}
+
+// scanAuditLogEntries takes some database rows and turns them into a slice of audit log entries.
+func (q *SQLQuerier) scanAuditLogEntries(ctx context.Context, rows database.ResultIterator, includeCounts bool) (entries []*types.AuditLogEntry, filteredCount, totalCount uint64, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue("include_counts", includeCounts)
+
+	for rows.Next() {
+		x, fc, tc, scanErr := q.scanAuditLogEntry(ctx, rows, includeCounts)
+		if scanErr != nil {
+			return nil, 0, 0, scanErr
+		}
+
+		if includeCounts {
+			if filteredCount == 0 {
+				filteredCount = fc
+			}
+
+			if totalCount == 0 {
+				totalCount = tc
+			}
+		}
+
+		entries = append(entries, x)
+	}
+
+	if err = q.checkRowsForErrorAndClose(ctx, rows); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "handling rows")
+	}
+
+	return entries, filteredCount, totalCount, nil
+}
+
+// buildGetAuditLogEntriesQuery builds a query for fetching audit log entries that meet a given filter. Entries are
+// never archived, so unlike buildListQuery this doesn't concern itself with archived_on.
+func (q *SQLQuerier) buildGetAuditLogEntriesQuery(ctx context.Context, accountID string, filter *types.AuditLogEntryQueryFilter) (query string, args []interface{}) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if filter == nil {
+		filter = types.DefaultAuditLogEntryQueryFilter()
+	}
+
+	tracing.AttachQueryFilterToSpan(span, &filter.QueryFilter)
+
+	where := squirrel.Eq{}
+	if accountID != "" {
+		where[fmt.Sprintf("%s.%s", auditLogEntriesTableName, accountOwnershipColumn)] = accountID
+	}
+
+	filteredWhere := squirrel.Eq{}
+	for k, v := range where {
+		filteredWhere[k] = v
+	}
+
+	if filter.EventType != "" {
+		filteredWhere[fmt.Sprintf("%s.event_type", auditLogEntriesTableName)] = filter.EventType
+	}
+
+	if filter.ActorUserID != "" {
+		filteredWhere[fmt.Sprintf("%s.actor_user_id", auditLogEntriesTableName)] = filter.ActorUserID
+	}
+
+	if filter.ResourceType != "" {
+		filteredWhere[fmt.Sprintf("%s.resource_type", auditLogEntriesTableName)] = filter.ResourceType
+	}
+
+	if filter.ResourceID != "" {
+		filteredWhere[fmt.Sprintf("%s.resource_id", auditLogEntriesTableName)] = filter.ResourceID
+	}
+
+	totalCountQueryBuilder := q.sqlBuilder.
+		PlaceholderFormat(squirrel.Question).
+		Select(fmt.Sprintf(columnCountQueryTemplate, auditLogEntriesTableName)).
+		From(auditLogEntriesTableName)
+
+	filteredCountQueryBuilder := q.sqlBuilder.
+		PlaceholderFormat(squirrel.Question).
+		Select(fmt.Sprintf(columnCountQueryTemplate, auditLogEntriesTableName)).
+		From(auditLogEntriesTableName)
+
+	if len(where) > 0 {
+		totalCountQueryBuilder = totalCountQueryBuilder.Where(where)
+	}
+
+	if len(filteredWhere) > 0 {
+		filteredCountQueryBuilder = filteredCountQueryBuilder.Where(filteredWhere)
+	}
+
+	totalCountQuery, totalCountQueryArgs := q.buildQuery(span, totalCountQueryBuilder)
+	filteredCountQuery, filteredCountQueryArgs := q.buildQuery(span, applyFilterToSubCountQueryBuilder(&filter.QueryFilter, auditLogEntriesTableName, filteredCountQueryBuilder))
+
+	order := "ASC"
+	if filter.SortBy == types.SortDescending {
+		order = "DESC"
+	}
+
+	builder := q.sqlBuilder.
+		Select(append(
+			auditLogEntriesTableColumns,
+			fmt.Sprintf("(%s) as total_count", totalCountQuery),
+			fmt.Sprintf("(%s) as filtered_count", filteredCountQuery),
+		)...).
+		From(auditLogEntriesTableName).
+		OrderBy(fmt.Sprintf("%s.created_on %s", auditLogEntriesTableName, order))
+
+	if len(filteredWhere) > 0 {
+		builder = builder.Where(filteredWhere)
+	}
+
+	query, selectArgs := q.buildQuery(span, applyFilterToQueryBuilder(&filter.QueryFilter, auditLogEntriesTableName, builder))
+
+	return query, append(append(totalCountQueryArgs, filteredCountQueryArgs...), selectArgs...)
+}
+
+// getAuditLogEntries fetches a list of audit log entries, optionally limited to a given account.
+func (q *SQLQuerier) getAuditLogEntries(ctx context.Context, accountID string, filter *types.AuditLogEntryQueryFilter) (*types.AuditLogEntryList, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := filter.AttachToLogger(q.logger)
+
+	x := &types.AuditLogEntryList{}
+	if filter != nil {
+		x.Page, x.Limit = filter.Page, filter.Limit
+	}
+
+	query, args := q.buildGetAuditLogEntriesQuery(ctx, accountID, filter)
+
+	rows, err := q.performReadQuery(ctx, q.db, "audit log entries", query, args)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "fetching audit log entries from database")
+	}
+
+	if x.Entries, x.FilteredCount, x.TotalCount, err = q.scanAuditLogEntries(ctx, rows, true); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning audit log entries")
+	}
+
+	return x, nil
+}
+
+// GetAuditLogEntries fetches a list of audit log entries for every account from the database that meet a particular filter.
+func (q *SQLQuerier) GetAuditLogEntries(ctx context.Context, filter *types.AuditLogEntryQueryFilter) (*types.AuditLogEntryList, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	return q.getAuditLogEntries(ctx, "", filter)
+}
+
+// GetAuditLogEntriesForAccount fetches a list of an account's audit log entries from the database that meet a particular filter.
+func (q *SQLQuerier) GetAuditLogEntriesForAccount(ctx context.Context, accountID string, filter *types.AuditLogEntryQueryFilter) (*types.AuditLogEntryList, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	return q.getAuditLogEntries(ctx, accountID, filter)
+}
+
+const auditLogEntryCreationQuery = `
+	INSERT INTO audit_log_entries (id,event_type,actor_user_id,belongs_to_account,resource_type,resource_id,request_id,changes) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+`
+
+// CreateAuditLogEntry records an audit log entry in the database.
+func (q *SQLQuerier) CreateAuditLogEntry(ctx context.Context, input *types.AuditLogEntryCreationInput) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return ErrNilInputProvided
+	}
+
+	logger := q.logger.WithValue(keys.AuditLogEntryEventTypeKey, input.EventType).WithValue(keys.AccountIDKey, input.BelongsToAccount)
+
+	changes := input.Changes
+	if changes == nil {
+		changes = []*types.FieldChangeSummary{}
+	}
+
+	rawChanges, err := json.Marshal(changes)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "encoding audit log entry changes")
+	}
+
+	args := []interface{}{
+		input.ID,
+		input.EventType,
+		input.ActorUserID,
+		input.BelongsToAccount,
+		input.ResourceType,
+		input.ResourceID,
+		input.RequestID,
+		string(rawChanges),
+	}
+
+	if err = q.performWriteQuery(ctx, q.db, "audit log entry creation", auditLogEntryCreationQuery, args); err != nil {
+		return observability.PrepareError(err, logger, span, "creating audit log entry")
+	}
+
+	logger.Debug("audit log entry created")
+
+	return nil
+}
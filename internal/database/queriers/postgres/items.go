@@ -2,7 +2,10 @@
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
@@ -23,6 +26,7 @@
 		"items.last_updated_on",
 		"items.archived_on",
 		"items.belongs_to_account",
+		"items.version",
 	}
 )
 
@@ -43,6 +47,7 @@ func (q *SQLQuerier) scanItem(ctx context.Context, scan database.Scanner, includ
 		&x.LastUpdatedOn,
 		&x.ArchivedOn,
 		&x.BelongsToAccount,
+		&x.Version,
 	}
 
 	if includeCounts {
@@ -89,6 +94,28 @@ func (q *SQLQuerier) scanItems(ctx context.Context, rows database.ResultIterator
 	return items, filteredCount, totalCount, nil
 }
 
+// scanItemIDs takes some database rows of item IDs and turns them into a slice of IDs.
+func (q *SQLQuerier) scanItemIDs(ctx context.Context, rows database.ResultIterator) (ids []string, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	ids = []string{}
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, observability.PrepareError(err, q.logger, span, "scanning item ID")
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err = q.checkRowsForErrorAndClose(ctx, rows); err != nil {
+		return nil, observability.PrepareError(err, q.logger, span, "handling rows")
+	}
+
+	return ids, nil
+}
+
 const itemExistenceQuery = "SELECT EXISTS ( SELECT items.id FROM items WHERE items.archived_on IS NULL AND items.belongs_to_account = $1 AND items.id = $2 )"
 
 // ItemExists fetches whether an item exists from the database.
@@ -131,7 +158,8 @@ func (q *SQLQuerier) ItemExists(ctx context.Context, itemID, accountID string) (
 	items.created_on,
 	items.last_updated_on,
 	items.archived_on,
-	items.belongs_to_account
+	items.belongs_to_account,
+	items.version
 FROM items
 WHERE items.archived_on IS NULL
 AND items.belongs_to_account = $1
@@ -234,26 +262,60 @@ func (q *SQLQuerier) GetItems(ctx context.Context, accountID string, filter *typ
 	return x, nil
 }
 
-const getItemsWithIDsQuery = `
-SELECT 
-	items.id,
-	items.name,
-	items.details,
-	items.created_on,
-	items.last_updated_on,
-	items.archived_on,
-	items.belongs_to_account FROM (SELECT items.id,
-	items.name,
-	items.details,
-	items.created_on,
-	items.last_updated_on,
-	items.archived_on,
-	items.belongs_to_account
-FROM items JOIN unnest('{%s}'::text[]) WITH ORDINALITY t(id, ord) USING (id) ORDER BY t.ord LIMIT 20) AS items 
-WHERE items.archived_on IS NULL 
-AND items.belongs_to_account = $1 
-AND items.id IN ($2,$3,$4)
-`
+// GetItemsForAdmin fetches a list of items belonging to any account, archived or not, from the database that meet a particular filter.
+func (q *SQLQuerier) GetItemsForAdmin(ctx context.Context, filter *types.QueryFilter) (x *types.ItemList, err error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := filter.AttachToLogger(q.logger)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	x = &types.ItemList{}
+	if filter != nil {
+		x.Page, x.Limit = filter.Page, filter.Limit
+	}
+
+	query, args := q.buildListQuery(
+		ctx,
+		"items",
+		nil,
+		nil,
+		accountOwnershipColumn,
+		itemsTableColumns,
+		"",
+		true,
+		filter,
+	)
+
+	rows, err := q.performReadQuery(ctx, q.db, "items for admin", query, args)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "executing items list retrieval query for admin")
+	}
+
+	if x.Items, x.FilteredCount, x.TotalCount, err = q.scanItems(ctx, rows, true); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning items")
+	}
+
+	return x, nil
+}
+
+// buildGetItemsWithIDsQuery builds a query to fetch the unarchived items with the given IDs that belong to an account.
+func (q *SQLQuerier) buildGetItemsWithIDsQuery(ctx context.Context, accountID string, limit uint8, ids []string) (query string, args []interface{}) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	builder := q.sqlBuilder.
+		Select(itemsTableColumns...).
+		From("items").
+		Where(squirrel.Eq{
+			"items.id":                 ids,
+			"items.archived_on":        nil,
+			"items.belongs_to_account": accountID,
+		}).
+		Limit(uint64(limit))
+
+	return q.buildQuery(span, builder)
+}
 
 // GetItemsWithIDs fetches items from the database within a given set of IDs.
 func (q *SQLQuerier) GetItemsWithIDs(ctx context.Context, accountID string, limit uint8, ids []string) ([]*types.Item, error) {
@@ -268,6 +330,10 @@ func (q *SQLQuerier) GetItemsWithIDs(ctx context.Context, accountID string, limi
 	logger = logger.WithValue(keys.AccountIDKey, accountID)
 	tracing.AttachAccountIDToSpan(span, accountID)
 
+	if ids == nil {
+		return nil, ErrNilInputProvided
+	}
+
 	if limit == 0 {
 		limit = uint8(types.DefaultLimit)
 	}
@@ -277,12 +343,7 @@ func (q *SQLQuerier) GetItemsWithIDs(ctx context.Context, accountID string, limi
 		"id_count": len(ids),
 	})
 
-	query := fmt.Sprintf(getItemsWithIDsQuery, joinIDs(ids))
-
-	args := []interface{}{accountID}
-	for _, id := range ids {
-		args = append(args, id)
-	}
+	query, args := q.buildGetItemsWithIDsQuery(ctx, accountID, limit, ids)
 
 	rows, err := q.performReadQuery(ctx, q.db, "items with IDs", query, args)
 	if err != nil {
@@ -297,6 +358,90 @@ func (q *SQLQuerier) GetItemsWithIDs(ctx context.Context, accountID string, limi
 	return items, nil
 }
 
+const searchItemIDsQuery = `
+SELECT items.id FROM items
+WHERE items.archived_on IS NULL
+AND items.belongs_to_account = $1
+AND to_tsvector('english', items.name || ' ' || items.details) @@ plainto_tsquery('english', $2)
+ORDER BY ts_rank(to_tsvector('english', items.name || ' ' || items.details), plainto_tsquery('english', $2)) DESC
+LIMIT $3
+`
+
+// SearchItemIDs fetches the IDs of an account's items that match a full-text search query, most relevant first.
+func (q *SQLQuerier) SearchItemIDs(ctx context.Context, query, accountID string) ([]string, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue(keys.SearchQueryKey, query)
+	tracing.AttachSearchQueryToSpan(span, query)
+
+	if query == "" {
+		return nil, ErrEmptyInputProvided
+	}
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+	logger = logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	args := []interface{}{
+		accountID,
+		query,
+		types.MaxLimit,
+	}
+
+	rows, err := q.performReadQuery(ctx, q.db, "item search", searchItemIDsQuery, args)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "searching items")
+	}
+
+	ids, err := q.scanItemIDs(ctx, rows)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning item IDs")
+	}
+
+	return ids, nil
+}
+
+const searchItemIDsForAdminQuery = `
+SELECT items.id FROM items
+WHERE items.archived_on IS NULL
+AND to_tsvector('english', items.name || ' ' || items.details) @@ plainto_tsquery('english', $1)
+ORDER BY ts_rank(to_tsvector('english', items.name || ' ' || items.details), plainto_tsquery('english', $1)) DESC
+LIMIT $2
+`
+
+// SearchItemIDsForAdmin fetches the IDs of any account's items that match a full-text search query, most relevant first.
+func (q *SQLQuerier) SearchItemIDsForAdmin(ctx context.Context, query string) ([]string, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue(keys.SearchQueryKey, query)
+	tracing.AttachSearchQueryToSpan(span, query)
+
+	if query == "" {
+		return nil, ErrEmptyInputProvided
+	}
+
+	args := []interface{}{
+		query,
+		types.MaxLimit,
+	}
+
+	rows, err := q.performReadQuery(ctx, q.db, "item search for admin", searchItemIDsForAdminQuery, args)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "searching items")
+	}
+
+	ids, err := q.scanItemIDs(ctx, rows)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning item IDs")
+	}
+
+	return ids, nil
+}
+
 const itemCreationQuery = `
 	INSERT INTO items (id,name,details,belongs_to_account) VALUES ($1,$2,$3,$4)
 `
@@ -330,6 +475,7 @@ func (q *SQLQuerier) CreateItem(ctx context.Context, input *types.ItemDatabaseCr
 		Details:          input.Details,
 		BelongsToAccount: input.BelongsToAccount,
 		CreatedOn:        q.currentTime(),
+		Version:          1,
 	}
 
 	tracing.AttachItemIDToSpan(span, x.ID)
@@ -339,10 +485,11 @@ func (q *SQLQuerier) CreateItem(ctx context.Context, input *types.ItemDatabaseCr
 }
 
 const updateItemQuery = `
-	UPDATE items SET name = $1, details = $2, last_updated_on = extract(epoch FROM NOW()) WHERE archived_on IS NULL AND belongs_to_account = $3 AND id = $4
+	UPDATE items SET name = $1, details = $2, last_updated_on = extract(epoch FROM NOW()), version = version + 1 WHERE archived_on IS NULL AND belongs_to_account = $3 AND id = $4 AND version = $5
 `
 
-// UpdateItem updates a particular item. Note that UpdateItem expects the provided input to have a valid ID.
+// UpdateItem updates a particular item. Note that UpdateItem expects the provided input to have a valid ID, and the
+// version it was read at. If the item has changed since then, nothing is written and ErrVersionConflict is returned.
 func (q *SQLQuerier) UpdateItem(ctx context.Context, updated *types.Item) error {
 	ctx, span := q.tracer.StartSpan(ctx)
 	defer span.End()
@@ -360,12 +507,22 @@ func (q *SQLQuerier) UpdateItem(ctx context.Context, updated *types.Item) error
 		updated.Details,
 		updated.BelongsToAccount,
 		updated.ID,
+		updated.Version,
 	}
 
 	if err := q.performWriteQuery(ctx, q.db, "item update", updateItemQuery, args); err != nil {
+		// no rows were updated, so either the item is gone, or someone else got to it first.
+		if errors.Is(err, sql.ErrNoRows) {
+			if exists, existenceErr := q.ItemExists(ctx, updated.ID, updated.BelongsToAccount); existenceErr == nil && exists {
+				err = database.ErrVersionConflict
+			}
+		}
+
 		return observability.PrepareError(err, logger, span, "updating item")
 	}
 
+	updated.Version++
+
 	logger.Info("item updated")
 
 	return nil
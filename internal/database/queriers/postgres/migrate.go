@@ -22,7 +22,7 @@
 )
 
 const testUserExistenceQuery = `
-	SELECT users.id, users.username, users.avatar_src, users.hashed_password, users.requires_password_change, users.password_last_changed_on, users.two_factor_secret, users.two_factor_secret_verified_on, users.service_roles, users.reputation, users.reputation_explanation, users.created_on, users.last_updated_on, users.archived_on FROM users WHERE users.archived_on IS NULL AND users.username = $1 AND users.two_factor_secret_verified_on IS NOT NULL
+	SELECT users.id, users.username, users.email_address, users.avatar_src, users.hashed_password, users.requires_password_change, users.password_last_changed_on, users.two_factor_secret, users.two_factor_secret_verified_on, users.service_roles, users.reputation, users.reputation_explanation, users.created_on, users.last_updated_on, users.archived_on FROM users WHERE users.archived_on IS NULL AND users.username = $1 AND users.two_factor_secret_verified_on IS NOT NULL
 `
 
 const testUserCreationQuery = `
@@ -89,6 +89,48 @@ func (q *SQLQuerier) Migrate(ctx context.Context, maxAttempts uint8, testUserCon
 	//go:embed migrations/00002_items.sql
 	itemsMigration string
 
+	//go:embed migrations/00003_webhook_delivery_attempts.sql
+	webhookDeliveryAttemptsMigration string
+
+	//go:embed migrations/00004_webhook_signing_secrets.sql
+	webhookSigningSecretsMigration string
+
+	//go:embed migrations/00005_items_search.sql
+	itemsSearchMigration string
+
+	//go:embed migrations/00006_notifications.sql
+	notificationsMigration string
+
+	//go:embed migrations/00007_api_client_scopes.sql
+	apiClientScopesMigration string
+
+	//go:embed migrations/00008_account_roles.sql
+	accountRolesMigration string
+
+	//go:embed migrations/00009_audit_log.sql
+	auditLogMigration string
+
+	//go:embed migrations/00010_account_recovery.sql
+	accountRecoveryMigration string
+
+	//go:embed migrations/00011_account_invitations.sql
+	accountInvitationsMigration string
+
+	//go:embed migrations/00012_user_sessions.sql
+	userSessionsMigration string
+
+	//go:embed migrations/00013_webauthn_credentials.sql
+	webAuthnCredentialsMigration string
+
+	//go:embed migrations/00014_oidc_identities.sql
+	oidcIdentitiesMigration string
+
+	//go:embed migrations/00015_record_versions.sql
+	recordVersionsMigration string
+
+	//go:embed migrations/00016_account_invitation_tokens.sql
+	accountInvitationTokensMigration string
+
 	migrations = []darwin.Migration{
 		{
 			Version:     0.01,
@@ -100,6 +142,76 @@ func (q *SQLQuerier) Migrate(ctx context.Context, maxAttempts uint8, testUserCon
 			Description: "create items table",
 			Script:      itemsMigration,
 		},
+		{
+			Version:     0.03,
+			Description: "create webhook delivery attempts table",
+			Script:      webhookDeliveryAttemptsMigration,
+		},
+		{
+			Version:     0.04,
+			Description: "add webhook signing secrets",
+			Script:      webhookSigningSecretsMigration,
+		},
+		{
+			Version:     0.05,
+			Description: "add items full-text search index",
+			Script:      itemsSearchMigration,
+		},
+		{
+			Version:     0.06,
+			Description: "add notifications archival and user index",
+			Script:      notificationsMigration,
+		},
+		{
+			Version:     0.07,
+			Description: "add API client scopes",
+			Script:      apiClientScopesMigration,
+		},
+		{
+			Version:     0.08,
+			Description: "create account roles table",
+			Script:      accountRolesMigration,
+		},
+		{
+			Version:     0.09,
+			Description: "create audit log table",
+			Script:      auditLogMigration,
+		},
+		{
+			Version:     0.10,
+			Description: "add user email addresses, password reset tokens, and TOTP recovery codes",
+			Script:      accountRecoveryMigration,
+		},
+		{
+			Version:     0.11,
+			Description: "create account invitations table",
+			Script:      accountInvitationsMigration,
+		},
+		{
+			Version:     0.12,
+			Description: "create user sessions table",
+			Script:      userSessionsMigration,
+		},
+		{
+			Version:     0.13,
+			Description: "create WebAuthn credentials table",
+			Script:      webAuthnCredentialsMigration,
+		},
+		{
+			Version:     0.14,
+			Description: "create OIDC identities table",
+			Script:      oidcIdentitiesMigration,
+		},
+		{
+			Version:     0.15,
+			Description: "add item and account versions",
+			Script:      recordVersionsMigration,
+		},
+		{
+			Version:     0.16,
+			Description: "add account invitation tokens",
+			Script:      accountInvitationTokensMigration,
+		},
 	}
 )
 
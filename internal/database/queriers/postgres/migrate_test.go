@@ -23,6 +23,7 @@ func TestQuerier_Migrate(T *testing.T) {
 		exampleCreationTime := fakes.BuildFakeTime()
 
 		exampleUser := fakes.BuildFakeUser()
+		exampleUser.EmailAddress = ""
 		exampleUser.TwoFactorSecretVerifiedOn = nil
 		exampleUser.CreatedOn = exampleCreationTime
 
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id CHAR(27) NOT NULL PRIMARY KEY,
+    request_body TEXT NOT NULL DEFAULT '',
+    response_status INTEGER NOT NULL DEFAULT 0,
+    latency_in_milliseconds BIGINT NOT NULL DEFAULT 0,
+    error_text TEXT NOT NULL DEFAULT '',
+    attempt_number INTEGER NOT NULL,
+    created_on BIGINT NOT NULL DEFAULT extract(epoch FROM NOW()),
+    last_updated_on BIGINT DEFAULT NULL,
+    archived_on BIGINT DEFAULT NULL,
+    belongs_to_webhook CHAR(27) NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhook_delivery_attempts_belongs_to_webhook_idx ON webhook_delivery_attempts (belongs_to_webhook);
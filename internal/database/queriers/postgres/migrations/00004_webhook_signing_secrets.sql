@@ -0,0 +1,3 @@
+ALTER TABLE webhooks ADD COLUMN signing_secret TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN previous_signing_secret TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN previous_signing_secret_expires_on BIGINT DEFAULT NULL;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS items_search_idx ON items USING GIN (to_tsvector('english', name || ' ' || details));
@@ -0,0 +1,3 @@
+ALTER TABLE notifications ADD COLUMN archived_on BIGINT DEFAULT NULL;
+
+CREATE INDEX notifications_belongs_to_user_idx ON notifications (belongs_to_user);
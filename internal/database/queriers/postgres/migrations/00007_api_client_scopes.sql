@@ -0,0 +1,7 @@
+ALTER TABLE api_clients ALTER COLUMN permissions DROP DEFAULT;
+ALTER TABLE api_clients ALTER COLUMN permissions TYPE TEXT USING '';
+ALTER TABLE api_clients ALTER COLUMN permissions SET DEFAULT '';
+ALTER TABLE api_clients ALTER COLUMN admin_permissions DROP DEFAULT;
+ALTER TABLE api_clients ALTER COLUMN admin_permissions TYPE TEXT USING '';
+ALTER TABLE api_clients ALTER COLUMN admin_permissions SET DEFAULT '';
+ALTER TABLE api_clients ADD COLUMN accounts TEXT NOT NULL DEFAULT '';
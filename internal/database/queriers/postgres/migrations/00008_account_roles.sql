@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS account_roles (
+    id CHAR(27) NOT NULL PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    permissions TEXT NOT NULL,
+    created_on BIGINT NOT NULL DEFAULT extract(epoch FROM NOW()),
+    last_updated_on BIGINT DEFAULT NULL,
+    archived_on BIGINT DEFAULT NULL,
+    belongs_to_account CHAR(27) NOT NULL REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+CREATE INDEX account_roles_belongs_to_account_idx ON account_roles (belongs_to_account);
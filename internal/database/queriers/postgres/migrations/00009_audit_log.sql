@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS audit_log_entries (
+    id CHAR(27) NOT NULL PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    actor_user_id TEXT NOT NULL DEFAULT '',
+    belongs_to_account TEXT NOT NULL DEFAULT '',
+    resource_type TEXT NOT NULL,
+    resource_id TEXT NOT NULL DEFAULT '',
+    request_id TEXT NOT NULL DEFAULT '',
+    changes TEXT NOT NULL DEFAULT '[]',
+    created_on BIGINT NOT NULL DEFAULT extract(epoch FROM NOW())
+);
+
+CREATE INDEX audit_log_entries_belongs_to_account_idx ON audit_log_entries (belongs_to_account);
+CREATE INDEX audit_log_entries_created_on_idx ON audit_log_entries (created_on);
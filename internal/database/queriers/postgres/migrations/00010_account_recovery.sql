@@ -0,0 +1,21 @@
+ALTER TABLE users ADD COLUMN email_address TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id CHAR(27) NOT NULL PRIMARY KEY,
+    hashed_token TEXT NOT NULL,
+    belongs_to_user CHAR(27) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at BIGINT NOT NULL,
+    redeemed_on BIGINT DEFAULT NULL,
+    created_on BIGINT NOT NULL DEFAULT extract(epoch FROM NOW()),
+    UNIQUE("hashed_token")
+);
+
+CREATE TABLE IF NOT EXISTS totp_recovery_codes (
+    id CHAR(27) NOT NULL PRIMARY KEY,
+    hashed_code TEXT NOT NULL,
+    belongs_to_user CHAR(27) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    redeemed_on BIGINT DEFAULT NULL,
+    created_on BIGINT NOT NULL DEFAULT extract(epoch FROM NOW())
+);
+
+CREATE INDEX totp_recovery_codes_belongs_to_user_idx ON totp_recovery_codes (belongs_to_user);
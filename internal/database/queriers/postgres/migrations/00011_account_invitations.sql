@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS account_invitations (
+    id CHAR(27) NOT NULL PRIMARY KEY,
+    from_user CHAR(27) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_user CHAR(27) DEFAULT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_email TEXT NOT NULL DEFAULT '',
+    destination_account CHAR(27) NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    note TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending',
+    account_roles TEXT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    created_on BIGINT NOT NULL DEFAULT extract(epoch FROM NOW()),
+    last_updated_on BIGINT DEFAULT NULL,
+    archived_on BIGINT DEFAULT NULL
+);
+
+CREATE INDEX account_invitations_destination_account_idx ON account_invitations (destination_account);
+CREATE INDEX account_invitations_to_user_idx ON account_invitations (to_user);
+CREATE INDEX account_invitations_to_email_idx ON account_invitations (to_email);
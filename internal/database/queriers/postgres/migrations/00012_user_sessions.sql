@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id CHAR(27) NOT NULL PRIMARY KEY,
+    session_token TEXT NOT NULL,
+    belongs_to_user CHAR(27) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    active_account TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    browser TEXT NOT NULL DEFAULT '',
+    browser_version TEXT NOT NULL DEFAULT '',
+    operating_system TEXT NOT NULL DEFAULT '',
+    platform TEXT NOT NULL DEFAULT '',
+    mobile BOOLEAN NOT NULL DEFAULT 'false',
+    created_on BIGINT NOT NULL DEFAULT extract(epoch FROM NOW()),
+    last_seen_on BIGINT NOT NULL DEFAULT extract(epoch FROM NOW()),
+    archived_on BIGINT DEFAULT NULL,
+    UNIQUE(session_token)
+);
+
+CREATE INDEX user_sessions_belongs_to_user_idx ON user_sessions (belongs_to_user);
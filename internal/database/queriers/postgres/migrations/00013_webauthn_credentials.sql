@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS webauthn_credentials (
+    id CHAR(27) NOT NULL PRIMARY KEY,
+    name TEXT NOT NULL,
+    credential_id BYTEA NOT NULL,
+    public_key BYTEA NOT NULL,
+    attestation_type TEXT NOT NULL DEFAULT '',
+    aaguid BYTEA NOT NULL DEFAULT '',
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    belongs_to_user CHAR(27) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_used_on BIGINT DEFAULT NULL,
+    created_on BIGINT NOT NULL DEFAULT extract(epoch FROM NOW()),
+    archived_on BIGINT DEFAULT NULL,
+    UNIQUE(credential_id)
+);
+
+CREATE INDEX webauthn_credentials_belongs_to_user_idx ON webauthn_credentials (belongs_to_user);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS oidc_identities (
+    id CHAR(27) NOT NULL PRIMARY KEY,
+    issuer TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    belongs_to_user CHAR(27) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_on BIGINT NOT NULL DEFAULT extract(epoch FROM NOW()),
+    archived_on BIGINT DEFAULT NULL,
+    UNIQUE(issuer, subject)
+);
+
+CREATE INDEX oidc_identities_belongs_to_user_idx ON oidc_identities (belongs_to_user);
@@ -0,0 +1,2 @@
+ALTER TABLE items ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE accounts ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+ALTER TABLE account_invitations ADD COLUMN token_hash TEXT NOT NULL DEFAULT '';
+CREATE INDEX account_invitations_token_hash_idx ON account_invitations (token_hash);
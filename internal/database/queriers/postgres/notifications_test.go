@@ -0,0 +1,421 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func buildMockRowsFromNotifications(includeCounts bool, filteredCount uint64, notifications ...*types.Notification) *sqlmock.Rows {
+	columns := notificationsTableColumns
+
+	if includeCounts {
+		columns = append(columns, "filtered_count", "total_count")
+	}
+
+	exampleRows := sqlmock.NewRows(columns)
+
+	for _, x := range notifications {
+		rowValues := []driver.Value{
+			x.ID,
+			x.Title,
+			x.Description,
+			x.CreatedOn,
+			x.LastUpdatedOn,
+			x.SeenOn,
+			x.ArchivedOn,
+			x.BelongsToAccount,
+			x.BelongsToUser,
+		}
+
+		if includeCounts {
+			rowValues = append(rowValues, filteredCount, len(notifications))
+		}
+
+		exampleRows.AddRow(rowValues...)
+	}
+
+	return exampleRows
+}
+
+func TestQuerier_GetNotifications(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+		exampleUserID := fakes.BuildFakeID()
+		exampleNotificationList := fakes.BuildFakeNotificationList()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"notifications",
+			nil,
+			nil,
+			userOwnershipColumn,
+			notificationsTableColumns,
+			exampleUserID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromNotifications(true, exampleNotificationList.FilteredCount, exampleNotificationList.Notifications...))
+
+		actual, err := c.GetNotifications(ctx, exampleUserID, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleNotificationList, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetNotifications(ctx, "", types.DefaultQueryFilter())
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"notifications",
+			nil,
+			nil,
+			userOwnershipColumn,
+			notificationsTableColumns,
+			exampleUserID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetNotifications(ctx, exampleUserID, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with erroneous response from database", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"notifications",
+			nil,
+			nil,
+			userOwnershipColumn,
+			notificationsTableColumns,
+			exampleUserID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildErroneousMockRow())
+
+		actual, err := c.GetNotifications(ctx, exampleUserID, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_GetUnreadNotificationCount(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleCount := uint64(123)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectQuery(formatQueryForSQLMock(getUnreadNotificationCountQuery)).
+			WithArgs(exampleUserID).
+			WillReturnRows(newCountDBRowResponse(exampleCount))
+
+		actual, err := c.GetUnreadNotificationCount(ctx, exampleUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleCount, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetUnreadNotificationCount(ctx, "")
+		assert.Error(t, err)
+		assert.Zero(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectQuery(formatQueryForSQLMock(getUnreadNotificationCountQuery)).
+			WithArgs(exampleUserID).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetUnreadNotificationCount(ctx, exampleUserID)
+		assert.Error(t, err)
+		assert.Zero(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_CreateNotification(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleNotification := fakes.BuildFakeNotification()
+		exampleInput := fakes.BuildFakeNotificationDatabaseCreationInputFromNotification(exampleNotification)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.Title,
+			exampleInput.Description,
+			exampleInput.BelongsToAccount,
+			exampleInput.BelongsToUser,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(notificationCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleNotification.ID))
+
+		c.timeFunc = func() uint64 {
+			return exampleNotification.CreatedOn
+		}
+
+		actual, err := c.CreateNotification(ctx, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleNotification, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.CreateNotification(ctx, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New(t.Name())
+		exampleInput := fakes.BuildFakeNotificationDatabaseCreationInput()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.Title,
+			exampleInput.Description,
+			exampleInput.BelongsToAccount,
+			exampleInput.BelongsToUser,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(notificationCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(expectedErr)
+
+		actual, err := c.CreateNotification(ctx, exampleInput)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, expectedErr))
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_MarkNotificationAsSeen(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleNotificationID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleUserID,
+			exampleNotificationID,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(markNotificationAsSeenQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleNotificationID))
+
+		assert.NoError(t, c.MarkNotificationAsSeen(ctx, exampleNotificationID, exampleUserID))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid notification ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.MarkNotificationAsSeen(ctx, "", fakes.BuildFakeID()))
+	})
+
+	T.Run("with invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.MarkNotificationAsSeen(ctx, fakes.BuildFakeID(), ""))
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleNotificationID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleUserID,
+			exampleNotificationID,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(markNotificationAsSeenQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.MarkNotificationAsSeen(ctx, exampleNotificationID, exampleUserID))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_MarkAllNotificationsAsSeen(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(markAllNotificationsAsSeenQuery)).
+			WithArgs(exampleUserID).
+			WillReturnResult(newArbitraryDatabaseResult(exampleUserID))
+
+		assert.NoError(t, c.MarkAllNotificationsAsSeen(ctx, exampleUserID))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with nothing to mark", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(markAllNotificationsAsSeenQuery)).
+			WithArgs(exampleUserID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.NoError(t, c.MarkAllNotificationsAsSeen(ctx, exampleUserID))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.MarkAllNotificationsAsSeen(ctx, ""))
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(markAllNotificationsAsSeenQuery)).
+			WithArgs(exampleUserID).
+			WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.MarkAllNotificationsAsSeen(ctx, exampleUserID))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
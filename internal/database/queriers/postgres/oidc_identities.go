@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var (
+	_ types.OIDCIdentityDataManager = (*SQLQuerier)(nil)
+
+	// oidcIdentitiesTableColumns are the columns for the OIDC identities table.
+	oidcIdentitiesTableColumns = []string{
+		"oidc_identities.id",
+		"oidc_identities.issuer",
+		"oidc_identities.subject",
+		"oidc_identities.belongs_to_user",
+		"oidc_identities.created_on",
+		"oidc_identities.archived_on",
+	}
+)
+
+// scanOIDCIdentity takes a database Scanner (i.e. *sql.Row) and scans the result into an OIDC identity struct.
+func (q *SQLQuerier) scanOIDCIdentity(ctx context.Context, scan database.Scanner) (*types.OIDCIdentity, error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	x := &types.OIDCIdentity{}
+
+	targetVars := []interface{}{
+		&x.ID,
+		&x.Issuer,
+		&x.Subject,
+		&x.BelongsToUser,
+		&x.CreatedOn,
+		&x.ArchivedOn,
+	}
+
+	if err := scan.Scan(targetVars...); err != nil {
+		return nil, observability.PrepareError(err, q.logger, span, "scanning OIDC identity")
+	}
+
+	return x, nil
+}
+
+const getOIDCIdentityQuery = `
+	SELECT oidc_identities.id, oidc_identities.issuer, oidc_identities.subject, oidc_identities.belongs_to_user, oidc_identities.created_on, oidc_identities.archived_on FROM oidc_identities WHERE oidc_identities.archived_on IS NULL AND oidc_identities.issuer = $1 AND oidc_identities.subject = $2
+`
+
+// GetOIDCIdentity fetches the identity linking a given identity provider subject to a user.
+func (q *SQLQuerier) GetOIDCIdentity(ctx context.Context, issuer, subject string) (*types.OIDCIdentity, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if issuer == "" || subject == "" {
+		return nil, ErrEmptyInputProvided
+	}
+
+	args := []interface{}{
+		issuer,
+		subject,
+	}
+
+	row := q.getOneRow(ctx, q.db, "OIDC identity", getOIDCIdentityQuery, args)
+
+	identity, err := q.scanOIDCIdentity(ctx, row)
+	if err != nil {
+		return nil, observability.PrepareError(err, q.logger, span, "scanning OIDC identity")
+	}
+
+	return identity, nil
+}
+
+const oidcIdentityCreationQuery = `
+	INSERT INTO oidc_identities (id,issuer,subject,belongs_to_user) VALUES ($1,$2,$3,$4)
+`
+
+// CreateOIDCIdentity links an identity provider subject to a user in the database.
+func (q *SQLQuerier) CreateOIDCIdentity(ctx context.Context, input *types.OIDCIdentityDatabaseCreationInput) (*types.OIDCIdentity, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	tracing.AttachUserIDToSpan(span, input.BelongsToUser)
+	logger := q.logger.WithValue(keys.OIDCIdentityIDKey, input.ID).WithValue(keys.UserIDKey, input.BelongsToUser)
+
+	args := []interface{}{
+		input.ID,
+		input.Issuer,
+		input.Subject,
+		input.BelongsToUser,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "OIDC identity creation", oidcIdentityCreationQuery, args); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "creating OIDC identity")
+	}
+
+	x := &types.OIDCIdentity{
+		ID:            input.ID,
+		Issuer:        input.Issuer,
+		Subject:       input.Subject,
+		BelongsToUser: input.BelongsToUser,
+		CreatedOn:     q.currentTime(),
+	}
+
+	logger.Info("OIDC identity created")
+
+	return x, nil
+}
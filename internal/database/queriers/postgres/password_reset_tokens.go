@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var (
+	_ types.PasswordResetTokenDataManager = (*SQLQuerier)(nil)
+
+	// passwordResetTokensTableColumns are the columns for the password reset tokens table.
+	passwordResetTokensTableColumns = []string{
+		"password_reset_tokens.id",
+		"password_reset_tokens.hashed_token",
+		"password_reset_tokens.belongs_to_user",
+		"password_reset_tokens.expires_at",
+		"password_reset_tokens.redeemed_on",
+		"password_reset_tokens.created_on",
+	}
+)
+
+// scanPasswordResetToken takes a database Scanner (i.e. *sql.Row) and scans the result into a password reset token struct.
+func (q *SQLQuerier) scanPasswordResetToken(ctx context.Context, scan database.Scanner) (x *types.PasswordResetToken, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	x = &types.PasswordResetToken{}
+
+	targetVars := []interface{}{
+		&x.ID,
+		&x.HashedToken,
+		&x.BelongsToUser,
+		&x.ExpiresAt,
+		&x.RedeemedOn,
+		&x.CreatedOn,
+	}
+
+	if err = scan.Scan(targetVars...); err != nil {
+		return nil, observability.PrepareError(err, q.logger, span, "scanning password reset token")
+	}
+
+	return x, nil
+}
+
+const getPasswordResetTokenByTokenQuery = `
+	SELECT password_reset_tokens.id, password_reset_tokens.hashed_token, password_reset_tokens.belongs_to_user, password_reset_tokens.expires_at, password_reset_tokens.redeemed_on, password_reset_tokens.created_on FROM password_reset_tokens WHERE password_reset_tokens.redeemed_on IS NULL AND password_reset_tokens.expires_at > extract(epoch FROM NOW()) AND password_reset_tokens.hashed_token = $1
+`
+
+// GetPasswordResetTokenByToken fetches an unredeemed, unexpired password reset token from the database.
+func (q *SQLQuerier) GetPasswordResetTokenByToken(ctx context.Context, hashedToken string) (*types.PasswordResetToken, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if hashedToken == "" {
+		return nil, ErrEmptyInputProvided
+	}
+
+	args := []interface{}{hashedToken}
+
+	row := q.getOneRow(ctx, q.db, "password reset token", getPasswordResetTokenByTokenQuery, args)
+
+	token, err := q.scanPasswordResetToken(ctx, row)
+	if err != nil {
+		return nil, observability.PrepareError(err, q.logger, span, "scanning password reset token")
+	}
+
+	return token, nil
+}
+
+const passwordResetTokenCreationQuery = `
+	INSERT INTO password_reset_tokens (id,hashed_token,belongs_to_user,expires_at) VALUES ($1,$2,$3,$4)
+`
+
+// CreatePasswordResetToken creates a password reset token in the database.
+func (q *SQLQuerier) CreatePasswordResetToken(ctx context.Context, input *types.PasswordResetTokenDatabaseCreationInput) (*types.PasswordResetToken, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	tracing.AttachUserIDToSpan(span, input.BelongsToUser)
+	logger := q.logger.WithValue(keys.PasswordResetTokenIDKey, input.ID).WithValue(keys.UserIDKey, input.BelongsToUser)
+
+	args := []interface{}{
+		input.ID,
+		input.HashedToken,
+		input.BelongsToUser,
+		input.ExpiresAt,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "password reset token creation", passwordResetTokenCreationQuery, args); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "creating password reset token")
+	}
+
+	x := &types.PasswordResetToken{
+		ID:            input.ID,
+		HashedToken:   input.HashedToken,
+		BelongsToUser: input.BelongsToUser,
+		ExpiresAt:     input.ExpiresAt,
+		CreatedOn:     q.currentTime(),
+	}
+
+	logger.Info("password reset token created")
+
+	return x, nil
+}
+
+const redeemPasswordResetTokenQuery = `
+	UPDATE password_reset_tokens SET redeemed_on = extract(epoch FROM NOW()) WHERE redeemed_on IS NULL AND id = $1
+`
+
+// RedeemPasswordResetToken marks a password reset token as redeemed, so that it can't be used again.
+func (q *SQLQuerier) RedeemPasswordResetToken(ctx context.Context, passwordResetTokenID string) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if passwordResetTokenID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	logger := q.logger.WithValue(keys.PasswordResetTokenIDKey, passwordResetTokenID)
+
+	args := []interface{}{passwordResetTokenID}
+
+	if err := q.performWriteQuery(ctx, q.db, "password reset token redemption", redeemPasswordResetTokenQuery, args); err != nil {
+		return observability.PrepareError(err, logger, span, "redeeming password reset token")
+	}
+
+	logger.Info("password reset token redeemed")
+
+	return nil
+}
@@ -3,7 +3,6 @@
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/Masterminds/squirrel"
 
@@ -21,10 +20,6 @@
 	accountOwnershipColumn   = "belongs_to_account"
 )
 
-func joinIDs(ids []string) string {
-	return strings.Join(ids, commaSeparator)
-}
-
 // logQueryBuildingError logs errs that may occur during query construction. Such errors should be few and far between,
 // as the generally only occur with type discrepancies or other misuses of SQL. An alert should be set up for any log
 // entries with the given name, and those alerts should be investigated quickly.
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var _ types.TOTPRecoveryCodeDataManager = (*SQLQuerier)(nil)
+
+const deleteTOTPRecoveryCodesQuery = `
+	DELETE FROM totp_recovery_codes WHERE belongs_to_user = $1
+`
+
+const totpRecoveryCodeCreationQuery = `
+	INSERT INTO totp_recovery_codes (id,hashed_code,belongs_to_user) VALUES ($1,$2,$3)
+`
+
+// CreateTOTPRecoveryCodes replaces a user's TOTP recovery codes with a new set.
+func (q *SQLQuerier) CreateTOTPRecoveryCodes(ctx context.Context, userID string, input []*types.TOTPRecoveryCodeDatabaseCreationInput) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	if len(input) == 0 {
+		return ErrEmptyInputProvided
+	}
+
+	tracing.AttachUserIDToSpan(span, userID)
+	logger := q.logger.WithValue(keys.UserIDKey, userID)
+
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "beginning transaction")
+	}
+
+	// a user who has never verified a secret won't have any codes to remove.
+	if err = q.performWriteQuery(ctx, tx, "TOTP recovery codes removal", deleteTOTPRecoveryCodesQuery, []interface{}{userID}); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		q.rollbackTransaction(ctx, tx)
+		return observability.PrepareError(err, logger, span, "removing previous TOTP recovery codes")
+	}
+
+	for _, code := range input {
+		args := []interface{}{
+			code.ID,
+			code.HashedCode,
+			userID,
+		}
+
+		if err = q.performWriteQuery(ctx, tx, "TOTP recovery code creation", totpRecoveryCodeCreationQuery, args); err != nil {
+			q.rollbackTransaction(ctx, tx)
+			return observability.PrepareError(err, logger, span, "creating TOTP recovery code")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return observability.PrepareError(err, logger, span, "committing transaction")
+	}
+
+	logger.Info("TOTP recovery codes created")
+
+	return nil
+}
+
+const redeemTOTPRecoveryCodeQuery = `
+	UPDATE totp_recovery_codes SET redeemed_on = extract(epoch FROM NOW()) WHERE redeemed_on IS NULL AND belongs_to_user = $1 AND hashed_code = $2
+`
+
+// RedeemTOTPRecoveryCode marks one of a user's TOTP recovery codes as redeemed. If the code isn't valid, sql.ErrNoRows is returned.
+func (q *SQLQuerier) RedeemTOTPRecoveryCode(ctx context.Context, userID, hashedCode string) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	if hashedCode == "" {
+		return ErrEmptyInputProvided
+	}
+
+	tracing.AttachUserIDToSpan(span, userID)
+	logger := q.logger.WithValue(keys.UserIDKey, userID)
+
+	args := []interface{}{
+		userID,
+		hashedCode,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "TOTP recovery code redemption", redeemTOTPRecoveryCodeQuery, args); err != nil {
+		return observability.PrepareError(err, logger, span, "redeeming TOTP recovery code")
+	}
+
+	logger.Info("TOTP recovery code redeemed")
+
+	return nil
+}
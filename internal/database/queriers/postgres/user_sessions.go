@@ -0,0 +1,398 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	userSessionsTableName = "user_sessions"
+
+	// userSessionsTableSessionsJoin restricts user sessions to those the session store still knows about.
+	userSessionsTableSessionsJoin = "sessions ON sessions.token = user_sessions.session_token"
+
+	// userSessionSeenThresholdSeconds is how often we bother recording that a session was seen.
+	userSessionSeenThresholdSeconds = 60
+)
+
+var (
+	_ types.UserSessionDataManager = (*SQLQuerier)(nil)
+
+	// userSessionsTableColumns are the columns for the user sessions table.
+	userSessionsTableColumns = []string{
+		"user_sessions.id",
+		"user_sessions.session_token",
+		"user_sessions.belongs_to_user",
+		"user_sessions.active_account",
+		"user_sessions.ip_address",
+		"user_sessions.user_agent",
+		"user_sessions.browser",
+		"user_sessions.browser_version",
+		"user_sessions.operating_system",
+		"user_sessions.platform",
+		"user_sessions.mobile",
+		"user_sessions.created_on",
+		"user_sessions.last_seen_on",
+		"user_sessions.archived_on",
+	}
+)
+
+// scanUserSession takes a database Scanner (i.e. *sql.Row) and scans the result into a user session struct.
+func (q *SQLQuerier) scanUserSession(ctx context.Context, scan database.Scanner, includeCounts bool) (x *types.UserSession, filteredCount, totalCount uint64, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue("include_counts", includeCounts)
+	x = &types.UserSession{}
+
+	targetVars := []interface{}{
+		&x.ID,
+		&x.Token,
+		&x.BelongsToUser,
+		&x.ActiveAccount,
+		&x.IPAddress,
+		&x.UserAgent,
+		&x.Browser,
+		&x.BrowserVersion,
+		&x.OperatingSystem,
+		&x.Platform,
+		&x.Mobile,
+		&x.CreatedOn,
+		&x.LastSeenOn,
+		&x.ArchivedOn,
+	}
+
+	if includeCounts {
+		targetVars = append(targetVars, &filteredCount, &totalCount)
+	}
+
+	if err = scan.Scan(targetVars...); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "scanning user session")
+	}
+
+	return x, filteredCount, totalCount, nil
+}
+
+// scanUserSessions takes some database rows and turns them into a slice of user sessions.
+func (q *SQLQuerier) scanUserSessions(ctx context.Context, rows database.ResultIterator, includeCounts bool) (sessions []*types.UserSession, filteredCount, totalCount uint64, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue("include_counts", includeCounts)
+
+	for rows.Next() {
+		x, fc, tc, scanErr := q.scanUserSession(ctx, rows, includeCounts)
+		if scanErr != nil {
+			return nil, 0, 0, scanErr
+		}
+
+		if includeCounts {
+			if filteredCount == 0 {
+				filteredCount = fc
+			}
+
+			if totalCount == 0 {
+				totalCount = tc
+			}
+		}
+
+		sessions = append(sessions, x)
+	}
+
+	if err = q.checkRowsForErrorAndClose(ctx, rows); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "handling rows")
+	}
+
+	return sessions, filteredCount, totalCount, nil
+}
+
+// GetUserSessionsForUser fetches a list of a user's live sessions from the database.
+func (q *SQLQuerier) GetUserSessionsForUser(ctx context.Context, userID string, filter *types.QueryFilter) (*types.UserSessionList, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := q.logger.WithValue(keys.UserIDKey, userID)
+	tracing.AttachUserIDToSpan(span, userID)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	x := &types.UserSessionList{}
+	if filter != nil {
+		x.Page, x.Limit = filter.Page, filter.Limit
+	}
+
+	query, args := q.buildListQuery(
+		ctx,
+		userSessionsTableName,
+		[]string{userSessionsTableSessionsJoin},
+		nil,
+		userOwnershipColumn,
+		userSessionsTableColumns,
+		userID,
+		false,
+		filter,
+	)
+
+	rows, err := q.performReadQuery(ctx, q.db, "user sessions", query, args)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "fetching user sessions from database")
+	}
+
+	if x.UserSessions, x.FilteredCount, x.TotalCount, err = q.scanUserSessions(ctx, rows, true); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning user sessions")
+	}
+
+	return x, nil
+}
+
+const userSessionCreationQuery = `
+	INSERT INTO user_sessions (id,session_token,belongs_to_user,active_account,ip_address,user_agent,browser,browser_version,operating_system,platform,mobile) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
+`
+
+// CreateUserSession records a user session in the database.
+func (q *SQLQuerier) CreateUserSession(ctx context.Context, input *types.UserSessionDatabaseCreationInput) (*types.UserSession, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	tracing.AttachUserSessionIDToSpan(span, input.ID)
+	tracing.AttachUserIDToSpan(span, input.BelongsToUser)
+	logger := q.logger.WithValue(keys.UserSessionIDKey, input.ID).WithValue(keys.UserIDKey, input.BelongsToUser)
+
+	args := []interface{}{
+		input.ID,
+		input.Token,
+		input.BelongsToUser,
+		input.ActiveAccount,
+		input.IPAddress,
+		input.UserAgent,
+		input.Browser,
+		input.BrowserVersion,
+		input.OperatingSystem,
+		input.Platform,
+		input.Mobile,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "user session creation", userSessionCreationQuery, args); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "creating user session")
+	}
+
+	now := q.currentTime()
+
+	x := &types.UserSession{
+		ID:              input.ID,
+		Token:           input.Token,
+		BelongsToUser:   input.BelongsToUser,
+		ActiveAccount:   input.ActiveAccount,
+		IPAddress:       input.IPAddress,
+		UserAgent:       input.UserAgent,
+		Browser:         input.Browser,
+		BrowserVersion:  input.BrowserVersion,
+		OperatingSystem: input.OperatingSystem,
+		Platform:        input.Platform,
+		Mobile:          input.Mobile,
+		CreatedOn:       now,
+		LastSeenOn:      now,
+	}
+
+	logger.Info("user session created")
+
+	return x, nil
+}
+
+const deleteSessionForUserSessionQuery = `
+	DELETE FROM sessions WHERE token IN (SELECT session_token FROM user_sessions WHERE archived_on IS NULL AND id = $1)
+`
+
+const updateUserSessionTokenQuery = `
+	UPDATE user_sessions SET session_token = $1, active_account = $2, last_seen_on = extract(epoch FROM NOW()) WHERE archived_on IS NULL AND id = $3
+`
+
+// UpdateUserSessionToken points a user session at a freshly issued session token, and removes the one it replaces
+// from the session store so that it can't be used again.
+func (q *SQLQuerier) UpdateUserSessionToken(ctx context.Context, userSessionID, token, activeAccountID string) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userSessionID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	if token == "" {
+		return ErrEmptyInputProvided
+	}
+
+	tracing.AttachUserSessionIDToSpan(span, userSessionID)
+	logger := q.logger.WithValue(keys.UserSessionIDKey, userSessionID)
+
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "beginning transaction")
+	}
+
+	// the previous token may have already expired out of the session store.
+	if err = q.performWriteQuery(ctx, tx, "previous session removal", deleteSessionForUserSessionQuery, []interface{}{userSessionID}); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		q.rollbackTransaction(ctx, tx)
+		return observability.PrepareError(err, logger, span, "removing previous session")
+	}
+
+	args := []interface{}{
+		token,
+		activeAccountID,
+		userSessionID,
+	}
+
+	if err = q.performWriteQuery(ctx, tx, "user session token update", updateUserSessionTokenQuery, args); err != nil {
+		q.rollbackTransaction(ctx, tx)
+		return observability.PrepareError(err, logger, span, "updating user session token")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return observability.PrepareError(err, logger, span, "committing transaction")
+	}
+
+	logger.Debug("user session token updated")
+
+	return nil
+}
+
+const markUserSessionAsSeenQuery = `
+	UPDATE user_sessions SET last_seen_on = extract(epoch FROM NOW()) WHERE archived_on IS NULL AND id = $1 AND last_seen_on < extract(epoch FROM NOW()) - $2
+`
+
+// MarkUserSessionAsSeen records that a user session was just used. To keep writes down, sessions seen within the
+// last minute are left alone.
+func (q *SQLQuerier) MarkUserSessionAsSeen(ctx context.Context, userSessionID string) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userSessionID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	tracing.AttachUserSessionIDToSpan(span, userSessionID)
+	logger := q.logger.WithValue(keys.UserSessionIDKey, userSessionID)
+
+	args := []interface{}{
+		userSessionID,
+		userSessionSeenThresholdSeconds,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "user session sighting", markUserSessionAsSeenQuery, args); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return observability.PrepareError(err, logger, span, "marking user session as seen")
+	}
+
+	return nil
+}
+
+const deleteSessionForUserSessionBelongingToUserQuery = `
+	DELETE FROM sessions WHERE token IN (SELECT session_token FROM user_sessions WHERE archived_on IS NULL AND id = $1 AND belongs_to_user = $2)
+`
+
+const archiveUserSessionQuery = `
+	UPDATE user_sessions SET archived_on = extract(epoch FROM NOW()) WHERE archived_on IS NULL AND id = $1 AND belongs_to_user = $2
+`
+
+// RevokeUserSession ends a user session, removing it from the session store and archiving its metadata.
+func (q *SQLQuerier) RevokeUserSession(ctx context.Context, userSessionID, userID string) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userSessionID == "" || userID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	tracing.AttachUserSessionIDToSpan(span, userSessionID)
+	tracing.AttachUserIDToSpan(span, userID)
+	logger := q.logger.WithValue(keys.UserSessionIDKey, userSessionID).WithValue(keys.UserIDKey, userID)
+
+	args := []interface{}{
+		userSessionID,
+		userID,
+	}
+
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "beginning transaction")
+	}
+
+	if err = q.performWriteQuery(ctx, tx, "session removal", deleteSessionForUserSessionBelongingToUserQuery, args); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		q.rollbackTransaction(ctx, tx)
+		return observability.PrepareError(err, logger, span, "removing session")
+	}
+
+	if err = q.performWriteQuery(ctx, tx, "user session archive", archiveUserSessionQuery, args); err != nil {
+		q.rollbackTransaction(ctx, tx)
+		return observability.PrepareError(err, logger, span, "archiving user session")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return observability.PrepareError(err, logger, span, "committing transaction")
+	}
+
+	logger.Info("user session revoked")
+
+	return nil
+}
+
+const deleteSessionsForUserQuery = `
+	DELETE FROM sessions WHERE token IN (SELECT session_token FROM user_sessions WHERE archived_on IS NULL AND belongs_to_user = $1 AND id <> $2)
+`
+
+const archiveUserSessionsForUserQuery = `
+	UPDATE user_sessions SET archived_on = extract(epoch FROM NOW()) WHERE archived_on IS NULL AND belongs_to_user = $1 AND id <> $2
+`
+
+// RevokeUserSessionsForUser ends every session a user has, save for the one provided (if any).
+func (q *SQLQuerier) RevokeUserSessionsForUser(ctx context.Context, userID, exceptUserSessionID string) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	tracing.AttachUserIDToSpan(span, userID)
+	logger := q.logger.WithValue(keys.UserIDKey, userID).WithValue("except_user_session_id", exceptUserSessionID)
+
+	args := []interface{}{
+		userID,
+		exceptUserSessionID,
+	}
+
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "beginning transaction")
+	}
+
+	if err = q.performWriteQuery(ctx, tx, "sessions removal", deleteSessionsForUserQuery, args); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		q.rollbackTransaction(ctx, tx)
+		return observability.PrepareError(err, logger, span, "removing sessions")
+	}
+
+	if err = q.performWriteQuery(ctx, tx, "user sessions archive", archiveUserSessionsForUserQuery, args); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		q.rollbackTransaction(ctx, tx)
+		return observability.PrepareError(err, logger, span, "archiving user sessions")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return observability.PrepareError(err, logger, span, "committing transaction")
+	}
+
+	logger.Info("user sessions revoked")
+
+	return nil
+}
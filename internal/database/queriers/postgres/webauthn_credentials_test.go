@@ -0,0 +1,295 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func buildMockRowsFromWebAuthnCredentials(credentials ...*types.WebAuthnCredential) *sqlmock.Rows {
+	exampleRows := sqlmock.NewRows(webAuthnCredentialsTableColumns)
+
+	for _, x := range credentials {
+		rowValues := []driver.Value{
+			x.ID,
+			x.Name,
+			x.CredentialID,
+			x.PublicKey,
+			x.AttestationType,
+			x.AAGUID,
+			x.SignCount,
+			x.BelongsToUser,
+			x.LastUsedOn,
+			x.CreatedOn,
+			x.ArchivedOn,
+		}
+
+		exampleRows.AddRow(rowValues...)
+	}
+
+	return exampleRows
+}
+
+func TestQuerier_GetWebAuthnCredentialsForUser(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+		exampleCredentialList := fakes.BuildFakeWebAuthnCredentialList()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectQuery(formatQueryForSQLMock(getWebAuthnCredentialsForUserQuery)).
+			WithArgs(exampleUserID).
+			WillReturnRows(buildMockRowsFromWebAuthnCredentials(exampleCredentialList.WebAuthnCredentials...))
+
+		actual, err := c.GetWebAuthnCredentialsForUser(ctx, exampleUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleCredentialList, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetWebAuthnCredentialsForUser(ctx, "")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectQuery(formatQueryForSQLMock(getWebAuthnCredentialsForUserQuery)).
+			WithArgs(exampleUserID).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetWebAuthnCredentialsForUser(ctx, exampleUserID)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with erroneous response", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUserID := fakes.BuildFakeID()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectQuery(formatQueryForSQLMock(getWebAuthnCredentialsForUserQuery)).
+			WithArgs(exampleUserID).
+			WillReturnRows(buildErroneousMockRow())
+
+		actual, err := c.GetWebAuthnCredentialsForUser(ctx, exampleUserID)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_CreateWebAuthnCredential(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+		exampleInput := fakes.BuildFakeWebAuthnCredentialDatabaseCreationInputFromWebAuthnCredential(exampleCredential)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.Name,
+			exampleInput.CredentialID,
+			exampleInput.PublicKey,
+			exampleInput.AttestationType,
+			exampleInput.AAGUID,
+			exampleInput.SignCount,
+			exampleInput.BelongsToUser,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(webAuthnCredentialCreationQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleCredential.ID))
+
+		c.timeFunc = func() uint64 {
+			return exampleCredential.CreatedOn
+		}
+
+		actual, err := c.CreateWebAuthnCredential(ctx, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleCredential, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.CreateWebAuthnCredential(ctx, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+		exampleInput := fakes.BuildFakeWebAuthnCredentialDatabaseCreationInputFromWebAuthnCredential(exampleCredential)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(webAuthnCredentialCreationQuery)).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.CreateWebAuthnCredential(ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_MarkWebAuthnCredentialAsUsed(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(markWebAuthnCredentialAsUsedQuery)).
+			WithArgs(exampleCredential.SignCount, exampleCredential.ID).
+			WillReturnResult(newArbitraryDatabaseResult(exampleCredential.ID))
+
+		assert.NoError(t, c.MarkWebAuthnCredentialAsUsed(ctx, exampleCredential.ID, exampleCredential.SignCount))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.MarkWebAuthnCredentialAsUsed(ctx, "", 0))
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(markWebAuthnCredentialAsUsedQuery)).
+			WithArgs(exampleCredential.SignCount, exampleCredential.ID).
+			WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.MarkWebAuthnCredentialAsUsed(ctx, exampleCredential.ID, exampleCredential.SignCount))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_ArchiveWebAuthnCredential(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(archiveWebAuthnCredentialQuery)).
+			WithArgs(exampleCredential.ID, exampleCredential.BelongsToUser).
+			WillReturnResult(newArbitraryDatabaseResult(exampleCredential.ID))
+
+		assert.NoError(t, c.ArchiveWebAuthnCredential(ctx, exampleCredential.ID, exampleCredential.BelongsToUser))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid IDs", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		assert.Error(t, c.ArchiveWebAuthnCredential(ctx, "", fakes.BuildFakeID()))
+		assert.Error(t, c.ArchiveWebAuthnCredential(ctx, fakes.BuildFakeID(), ""))
+	})
+
+	T.Run("with nonexistent credential", func(t *testing.T) {
+		t.Parallel()
+
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(archiveWebAuthnCredentialQuery)).
+			WithArgs(exampleCredential.ID, exampleCredential.BelongsToUser).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := c.ArchiveWebAuthnCredential(ctx, exampleCredential.ID, exampleCredential.BelongsToUser)
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		db.ExpectExec(formatQueryForSQLMock(archiveWebAuthnCredentialQuery)).
+			WithArgs(exampleCredential.ID, exampleCredential.BelongsToUser).
+			WillReturnError(errors.New("blah"))
+
+		assert.Error(t, c.ArchiveWebAuthnCredential(ctx, exampleCredential.ID, exampleCredential.BelongsToUser))
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
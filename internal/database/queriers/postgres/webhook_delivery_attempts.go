@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"context"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	webhookOwnershipColumn = "belongs_to_webhook"
+)
+
+var (
+	// webhookDeliveryAttemptsTableColumns are the columns for the webhook_delivery_attempts table.
+	webhookDeliveryAttemptsTableColumns = []string{
+		"webhook_delivery_attempts.id",
+		"webhook_delivery_attempts.request_body",
+		"webhook_delivery_attempts.response_status",
+		"webhook_delivery_attempts.latency_in_milliseconds",
+		"webhook_delivery_attempts.error_text",
+		"webhook_delivery_attempts.attempt_number",
+		"webhook_delivery_attempts.created_on",
+		"webhook_delivery_attempts.belongs_to_webhook",
+	}
+)
+
+// scanWebhookDeliveryAttempt is a consistent way to turn a *sql.Row into a webhook delivery attempt struct.
+func (q *SQLQuerier) scanWebhookDeliveryAttempt(ctx context.Context, scan database.Scanner, includeCounts bool) (attempt *types.WebhookDeliveryAttempt, filteredCount, totalCount uint64, err error) {
+	_, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue("include_counts", includeCounts)
+	attempt = &types.WebhookDeliveryAttempt{}
+
+	targetVars := []interface{}{
+		&attempt.ID,
+		&attempt.RequestBody,
+		&attempt.ResponseStatus,
+		&attempt.LatencyInMilliseconds,
+		&attempt.ErrorText,
+		&attempt.AttemptNumber,
+		&attempt.CreatedOn,
+		&attempt.BelongsToWebhook,
+	}
+
+	if includeCounts {
+		targetVars = append(targetVars, &filteredCount, &totalCount)
+	}
+
+	if err = scan.Scan(targetVars...); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "scanning webhook delivery attempt")
+	}
+
+	return attempt, filteredCount, totalCount, nil
+}
+
+// scanWebhookDeliveryAttempts provides a consistent way to turn sql rows into a slice of webhook delivery attempts.
+func (q *SQLQuerier) scanWebhookDeliveryAttempts(ctx context.Context, rows database.ResultIterator, includeCounts bool) (attempts []*types.WebhookDeliveryAttempt, filteredCount, totalCount uint64, err error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := q.logger.WithValue("include_counts", includeCounts)
+
+	for rows.Next() {
+		attempt, fc, tc, scanErr := q.scanWebhookDeliveryAttempt(ctx, rows, includeCounts)
+		if scanErr != nil {
+			return nil, 0, 0, scanErr
+		}
+
+		if includeCounts {
+			if filteredCount == 0 {
+				filteredCount = fc
+			}
+
+			if totalCount == 0 {
+				totalCount = tc
+			}
+		}
+
+		attempts = append(attempts, attempt)
+	}
+
+	if err = q.checkRowsForErrorAndClose(ctx, rows); err != nil {
+		return nil, 0, 0, observability.PrepareError(err, logger, span, "handling rows")
+	}
+
+	return attempts, filteredCount, totalCount, nil
+}
+
+const getWebhookDeliveryAttemptQuery = `
+	SELECT webhook_delivery_attempts.id, webhook_delivery_attempts.request_body, webhook_delivery_attempts.response_status, webhook_delivery_attempts.latency_in_milliseconds, webhook_delivery_attempts.error_text, webhook_delivery_attempts.attempt_number, webhook_delivery_attempts.created_on, webhook_delivery_attempts.belongs_to_webhook FROM webhook_delivery_attempts WHERE webhook_delivery_attempts.archived_on IS NULL AND webhook_delivery_attempts.belongs_to_webhook = $1 AND webhook_delivery_attempts.id = $2
+`
+
+// GetWebhookDeliveryAttempt fetches a webhook delivery attempt from the database.
+func (q *SQLQuerier) GetWebhookDeliveryAttempt(ctx context.Context, attemptID, webhookID string) (*types.WebhookDeliveryAttempt, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if attemptID == "" || webhookID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	tracing.AttachWebhookDeliveryAttemptIDToSpan(span, attemptID)
+	tracing.AttachWebhookIDToSpan(span, webhookID)
+
+	logger := q.logger.WithValues(map[string]interface{}{
+		keys.WebhookDeliveryAttemptIDKey: attemptID,
+		keys.WebhookIDKey:                webhookID,
+	})
+
+	args := []interface{}{
+		webhookID,
+		attemptID,
+	}
+
+	row := q.getOneRow(ctx, q.db, "webhook delivery attempt", getWebhookDeliveryAttemptQuery, args)
+
+	attempt, _, _, err := q.scanWebhookDeliveryAttempt(ctx, row, false)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning webhook delivery attempt")
+	}
+
+	return attempt, nil
+}
+
+// GetWebhookDeliveryAttempts fetches a list of webhook delivery attempts from the database that meet a particular filter.
+func (q *SQLQuerier) GetWebhookDeliveryAttempts(ctx context.Context, webhookID string, filter *types.QueryFilter) (*types.WebhookDeliveryAttemptList, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webhookID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := q.logger.WithValue(keys.WebhookIDKey, webhookID)
+	tracing.AttachWebhookIDToSpan(span, webhookID)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	x := &types.WebhookDeliveryAttemptList{}
+	if filter != nil {
+		x.Page, x.Limit = filter.Page, filter.Limit
+	}
+
+	query, args := q.buildListQuery(
+		ctx,
+		"webhook_delivery_attempts",
+		nil,
+		nil,
+		webhookOwnershipColumn,
+		webhookDeliveryAttemptsTableColumns,
+		webhookID,
+		false,
+		filter,
+	)
+
+	rows, err := q.performReadQuery(ctx, q.db, "webhook delivery attempts", query, args)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "fetching webhook delivery attempts from database")
+	}
+
+	if x.Attempts, x.FilteredCount, x.TotalCount, err = q.scanWebhookDeliveryAttempts(ctx, rows, true); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "scanning database response")
+	}
+
+	return x, nil
+}
+
+const createWebhookDeliveryAttemptQuery = `
+	INSERT INTO webhook_delivery_attempts (id,request_body,response_status,latency_in_milliseconds,error_text,attempt_number,belongs_to_webhook) VALUES ($1,$2,$3,$4,$5,$6,$7)
+`
+
+// CreateWebhookDeliveryAttempt records a webhook delivery attempt in the database.
+func (q *SQLQuerier) CreateWebhookDeliveryAttempt(ctx context.Context, input *types.WebhookDeliveryAttemptDatabaseCreationInput) (*types.WebhookDeliveryAttempt, error) {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	tracing.AttachWebhookIDToSpan(span, input.BelongsToWebhook)
+	logger := q.logger.WithValue(keys.WebhookIDKey, input.BelongsToWebhook)
+
+	args := []interface{}{
+		input.ID,
+		input.RequestBody,
+		input.ResponseStatus,
+		input.LatencyInMilliseconds,
+		input.ErrorText,
+		input.AttemptNumber,
+		input.BelongsToWebhook,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "webhook delivery attempt creation", createWebhookDeliveryAttemptQuery, args); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "creating webhook delivery attempt")
+	}
+
+	x := &types.WebhookDeliveryAttempt{
+		ID:                    input.ID,
+		RequestBody:           input.RequestBody,
+		ResponseStatus:        input.ResponseStatus,
+		LatencyInMilliseconds: input.LatencyInMilliseconds,
+		ErrorText:             input.ErrorText,
+		AttemptNumber:         input.AttemptNumber,
+		BelongsToWebhook:      input.BelongsToWebhook,
+		CreatedOn:             q.currentTime(),
+	}
+
+	tracing.AttachWebhookDeliveryAttemptIDToSpan(span, x.ID)
+	logger.WithValue(keys.WebhookDeliveryAttemptIDKey, x.ID).Debug("webhook delivery attempt created")
+
+	return x, nil
+}
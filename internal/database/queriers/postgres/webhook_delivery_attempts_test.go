@@ -0,0 +1,342 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func buildMockRowsFromWebhookDeliveryAttempts(includeCounts bool, filteredCount uint64, attempts ...*types.WebhookDeliveryAttempt) *sqlmock.Rows {
+	columns := webhookDeliveryAttemptsTableColumns
+
+	if includeCounts {
+		columns = append(columns, "filtered_count", "total_count")
+	}
+
+	exampleRows := sqlmock.NewRows(columns)
+
+	for _, x := range attempts {
+		rowValues := []driver.Value{
+			x.ID,
+			x.RequestBody,
+			x.ResponseStatus,
+			x.LatencyInMilliseconds,
+			x.ErrorText,
+			x.AttemptNumber,
+			x.CreatedOn,
+			x.BelongsToWebhook,
+		}
+
+		if includeCounts {
+			rowValues = append(rowValues, filteredCount, len(attempts))
+		}
+
+		exampleRows.AddRow(rowValues...)
+	}
+
+	return exampleRows
+}
+
+func TestQuerier_ScanWebhookDeliveryAttempts(T *testing.T) {
+	T.Parallel()
+
+	T.Run("surfaces row errs", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		q, _ := buildTestClient(t)
+		mockRows := &database.MockResultIterator{}
+
+		mockRows.On("Next").Return(false)
+		mockRows.On("Err").Return(errors.New("blah"))
+
+		_, _, _, err := q.scanWebhookDeliveryAttempts(ctx, mockRows, false)
+		assert.Error(t, err)
+	})
+
+	T.Run("logs row closing errs", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		q, _ := buildTestClient(t)
+		mockRows := &database.MockResultIterator{}
+
+		mockRows.On("Next").Return(false)
+		mockRows.On("Err").Return(nil)
+		mockRows.On("Close").Return(errors.New("blah"))
+
+		_, _, _, err := q.scanWebhookDeliveryAttempts(ctx, mockRows, false)
+		assert.Error(t, err)
+	})
+}
+
+func TestQuerier_GetWebhookDeliveryAttempt(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAttempt := fakes.BuildFakeWebhookDeliveryAttempt()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleAttempt.BelongsToWebhook, exampleAttempt.ID}
+
+		db.ExpectQuery(formatQueryForSQLMock(getWebhookDeliveryAttemptQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromWebhookDeliveryAttempts(false, 0, exampleAttempt))
+
+		actual, err := c.GetWebhookDeliveryAttempt(ctx, exampleAttempt.ID, exampleAttempt.BelongsToWebhook)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleAttempt, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid attempt ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetWebhookDeliveryAttempt(ctx, "", fakes.BuildFakeID())
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with invalid webhook ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetWebhookDeliveryAttempt(ctx, fakes.BuildFakeID(), "")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAttempt := fakes.BuildFakeWebhookDeliveryAttempt()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{exampleAttempt.BelongsToWebhook, exampleAttempt.ID}
+
+		db.ExpectQuery(formatQueryForSQLMock(getWebhookDeliveryAttemptQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetWebhookDeliveryAttempt(ctx, exampleAttempt.ID, exampleAttempt.BelongsToWebhook)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_GetWebhookDeliveryAttempts(T *testing.T) {
+	T.Parallel()
+
+	exampleWebhookID := fakes.BuildFakeID()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleList := fakes.BuildFakeWebhookDeliveryAttemptList()
+		filter := types.DefaultQueryFilter()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"webhook_delivery_attempts",
+			nil,
+			nil,
+			webhookOwnershipColumn,
+			webhookDeliveryAttemptsTableColumns,
+			exampleWebhookID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildMockRowsFromWebhookDeliveryAttempts(
+				true,
+				exampleList.FilteredCount,
+				exampleList.Attempts...,
+			))
+
+		actual, err := c.GetWebhookDeliveryAttempts(ctx, exampleWebhookID, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleList, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid webhook ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.GetWebhookDeliveryAttempts(ctx, "", types.DefaultQueryFilter())
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing query", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"webhook_delivery_attempts",
+			nil,
+			nil,
+			webhookOwnershipColumn,
+			webhookDeliveryAttemptsTableColumns,
+			exampleWebhookID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.GetWebhookDeliveryAttempts(ctx, exampleWebhookID, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with erroneous database response", func(t *testing.T) {
+		t.Parallel()
+
+		filter := types.DefaultQueryFilter()
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		query, args := c.buildListQuery(
+			ctx,
+			"webhook_delivery_attempts",
+			nil,
+			nil,
+			webhookOwnershipColumn,
+			webhookDeliveryAttemptsTableColumns,
+			exampleWebhookID,
+			false,
+			filter,
+		)
+
+		db.ExpectQuery(formatQueryForSQLMock(query)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnRows(buildErroneousMockRow())
+
+		actual, err := c.GetWebhookDeliveryAttempts(ctx, exampleWebhookID, filter)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
+
+func TestQuerier_CreateWebhookDeliveryAttempt(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAttempt := fakes.BuildFakeWebhookDeliveryAttempt()
+		exampleInput := fakes.BuildFakeWebhookDeliveryAttemptDatabaseCreationInputFromWebhookDeliveryAttempt(exampleAttempt)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.RequestBody,
+			exampleInput.ResponseStatus,
+			exampleInput.LatencyInMilliseconds,
+			exampleInput.ErrorText,
+			exampleInput.AttemptNumber,
+			exampleInput.BelongsToWebhook,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(createWebhookDeliveryAttemptQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnResult(newArbitraryDatabaseResult(exampleAttempt.ID))
+
+		c.timeFunc = func() uint64 {
+			return exampleAttempt.CreatedOn
+		}
+
+		actual, err := c.CreateWebhookDeliveryAttempt(ctx, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleAttempt, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildTestClient(t)
+
+		actual, err := c.CreateWebhookDeliveryAttempt(ctx, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error executing creation query", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAttempt := fakes.BuildFakeWebhookDeliveryAttempt()
+		exampleInput := fakes.BuildFakeWebhookDeliveryAttemptDatabaseCreationInputFromWebhookDeliveryAttempt(exampleAttempt)
+
+		ctx := context.Background()
+		c, db := buildTestClient(t)
+
+		args := []interface{}{
+			exampleInput.ID,
+			exampleInput.RequestBody,
+			exampleInput.ResponseStatus,
+			exampleInput.LatencyInMilliseconds,
+			exampleInput.ErrorText,
+			exampleInput.AttemptNumber,
+			exampleInput.BelongsToWebhook,
+		}
+
+		db.ExpectExec(formatQueryForSQLMock(createWebhookDeliveryAttemptQuery)).
+			WithArgs(interfaceToDriverValue(args)...).
+			WillReturnError(errors.New("blah"))
+
+		actual, err := c.CreateWebhookDeliveryAttempt(ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, db)
+	})
+}
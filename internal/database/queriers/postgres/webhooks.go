@@ -33,6 +33,9 @@
 		"webhooks.events",
 		"webhooks.data_types",
 		"webhooks.topics",
+		"webhooks.signing_secret",
+		"webhooks.previous_signing_secret",
+		"webhooks.previous_signing_secret_expires_on",
 		"webhooks.created_on",
 		"webhooks.last_updated_on",
 		"webhooks.archived_on",
@@ -63,6 +66,9 @@ func (q *SQLQuerier) scanWebhook(ctx context.Context, scan database.Scanner, inc
 		&eventsStr,
 		&dataTypesStr,
 		&topicsStr,
+		&webhook.SigningSecret,
+		&webhook.PreviousSigningSecret,
+		&webhook.PreviousSigningSecretExpiresOn,
 		&webhook.CreatedOn,
 		&webhook.LastUpdatedOn,
 		&webhook.ArchivedOn,
@@ -164,7 +170,7 @@ func (q *SQLQuerier) WebhookExists(ctx context.Context, webhookID, accountID str
 }
 
 const getWebhookQuery = `
-	SELECT webhooks.id, webhooks.name, webhooks.content_type, webhooks.url, webhooks.method, webhooks.events, webhooks.data_types, webhooks.topics, webhooks.created_on, webhooks.last_updated_on, webhooks.archived_on, webhooks.belongs_to_account FROM webhooks WHERE webhooks.archived_on IS NULL AND webhooks.belongs_to_account = $1 AND webhooks.id = $2
+	SELECT webhooks.id, webhooks.name, webhooks.content_type, webhooks.url, webhooks.method, webhooks.events, webhooks.data_types, webhooks.topics, webhooks.signing_secret, webhooks.previous_signing_secret, webhooks.previous_signing_secret_expires_on, webhooks.created_on, webhooks.last_updated_on, webhooks.archived_on, webhooks.belongs_to_account FROM webhooks WHERE webhooks.archived_on IS NULL AND webhooks.belongs_to_account = $1 AND webhooks.id = $2
 `
 
 // GetWebhook fetches a webhook from the database.
@@ -261,7 +267,7 @@ func (q *SQLQuerier) GetWebhooks(ctx context.Context, accountID string, filter *
 }
 
 const createWebhookQuery = `
-	INSERT INTO webhooks (id,name,content_type,url,method,events,data_types,topics,belongs_to_account) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+	INSERT INTO webhooks (id,name,content_type,url,method,events,data_types,topics,signing_secret,belongs_to_account) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
 `
 
 // CreateWebhook creates a webhook in a database.
@@ -285,6 +291,7 @@ func (q *SQLQuerier) CreateWebhook(ctx context.Context, input *types.WebhookData
 		strings.Join(input.Events, webhooksTableEventsSeparator),
 		strings.Join(input.DataTypes, webhooksTableDataTypesSeparator),
 		strings.Join(input.Topics, webhooksTableTopicsSeparator),
+		input.SigningSecret,
 		input.BelongsToAccount,
 	}
 
@@ -302,6 +309,7 @@ func (q *SQLQuerier) CreateWebhook(ctx context.Context, input *types.WebhookData
 		DataTypes:        input.DataTypes,
 		Topics:           input.Topics,
 		BelongsToAccount: input.BelongsToAccount,
+		SigningSecret:    input.SigningSecret,
 		CreatedOn:        q.currentTime(),
 	}
 
@@ -313,6 +321,55 @@ func (q *SQLQuerier) CreateWebhook(ctx context.Context, input *types.WebhookData
 	return x, nil
 }
 
+const updateWebhookQuery = `
+UPDATE webhooks SET
+	name = $1,
+	content_type = $2,
+	url = $3,
+	method = $4,
+	events = $5,
+	data_types = $6,
+	topics = $7,
+	last_updated_on = extract(epoch FROM NOW())
+WHERE archived_on IS NULL
+AND belongs_to_account = $8
+AND id = $9
+`
+
+// UpdateWebhook updates a particular webhook. Note that UpdateWebhook expects the provided input to have a valid ID.
+func (q *SQLQuerier) UpdateWebhook(ctx context.Context, updated *types.Webhook) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if updated == nil {
+		return ErrNilInputProvided
+	}
+
+	logger := q.logger.WithValue(keys.WebhookIDKey, updated.ID)
+	tracing.AttachWebhookIDToSpan(span, updated.ID)
+	tracing.AttachAccountIDToSpan(span, updated.BelongsToAccount)
+
+	args := []interface{}{
+		updated.Name,
+		updated.ContentType,
+		updated.URL,
+		updated.Method,
+		strings.Join(updated.Events, webhooksTableEventsSeparator),
+		strings.Join(updated.DataTypes, webhooksTableDataTypesSeparator),
+		strings.Join(updated.Topics, webhooksTableTopicsSeparator),
+		updated.BelongsToAccount,
+		updated.ID,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "webhook update", updateWebhookQuery, args); err != nil {
+		return observability.PrepareError(err, logger, span, "updating webhook")
+	}
+
+	logger.Info("webhook updated")
+
+	return nil
+}
+
 const archiveWebhookQuery = `
 UPDATE webhooks SET
 	last_updated_on = extract(epoch FROM NOW()), 
@@ -349,3 +406,51 @@ func (q *SQLQuerier) ArchiveWebhook(ctx context.Context, webhookID, accountID st
 
 	return nil
 }
+
+const rotateWebhookSigningSecretQuery = `
+UPDATE webhooks SET
+	previous_signing_secret = signing_secret,
+	previous_signing_secret_expires_on = $1,
+	signing_secret = $2,
+	last_updated_on = extract(epoch FROM NOW())
+WHERE archived_on IS NULL
+AND belongs_to_account = $3
+AND id = $4
+`
+
+// RotateWebhookSigningSecret replaces a webhook's signing secret, keeping the old one around until a given time.
+func (q *SQLQuerier) RotateWebhookSigningSecret(ctx context.Context, webhookID, accountID, newSecret string, previousSecretExpiresOn uint64) error {
+	ctx, span := q.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webhookID == "" || accountID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	if newSecret == "" {
+		return ErrEmptyInputProvided
+	}
+
+	tracing.AttachWebhookIDToSpan(span, webhookID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	logger := q.logger.WithValues(map[string]interface{}{
+		keys.WebhookIDKey: webhookID,
+		keys.AccountIDKey: accountID,
+	})
+
+	args := []interface{}{
+		previousSecretExpiresOn,
+		newSecret,
+		accountID,
+		webhookID,
+	}
+
+	if err := q.performWriteQuery(ctx, q.db, "webhook signing secret rotation", rotateWebhookSigningSecretQuery, args); err != nil {
+		return observability.PrepareError(err, logger, span, "rotating webhook signing secret")
+	}
+
+	logger.Info("webhook signing secret rotated")
+
+	return nil
+}
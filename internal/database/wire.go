@@ -16,6 +16,15 @@
 		ProvideAccountUserMembershipDataManager,
 		ProvideAPIClientDataManager,
 		ProvideWebhookDataManager,
+		ProvideNotificationDataManager,
+		ProvideAccountRoleDataManager,
+		ProvideAuditLogEntryDataManager,
+		ProvidePasswordResetTokenDataManager,
+		ProvideTOTPRecoveryCodeDataManager,
+		ProvideAccountInvitationDataManager,
+		ProvideUserSessionDataManager,
+		ProvideWebAuthnCredentialDataManager,
+		ProvideOIDCIdentityDataManager,
 	)
 )
 
@@ -53,3 +62,48 @@ func ProvideAPIClientDataManager(db DataManager) types.APIClientDataManager {
 func ProvideWebhookDataManager(db DataManager) types.WebhookDataManager {
 	return db
 }
+
+// ProvideNotificationDataManager is an arbitrary function for dependency injection's sake.
+func ProvideNotificationDataManager(db DataManager) types.NotificationDataManager {
+	return db
+}
+
+// ProvideAccountRoleDataManager is an arbitrary function for dependency injection's sake.
+func ProvideAccountRoleDataManager(db DataManager) types.AccountRoleDataManager {
+	return db
+}
+
+// ProvideAuditLogEntryDataManager is an arbitrary function for dependency injection's sake.
+func ProvideAuditLogEntryDataManager(db DataManager) types.AuditLogEntryDataManager {
+	return db
+}
+
+// ProvidePasswordResetTokenDataManager is an arbitrary function for dependency injection's sake.
+func ProvidePasswordResetTokenDataManager(db DataManager) types.PasswordResetTokenDataManager {
+	return db
+}
+
+// ProvideTOTPRecoveryCodeDataManager is an arbitrary function for dependency injection's sake.
+func ProvideTOTPRecoveryCodeDataManager(db DataManager) types.TOTPRecoveryCodeDataManager {
+	return db
+}
+
+// ProvideAccountInvitationDataManager is an arbitrary function for dependency injection's sake.
+func ProvideAccountInvitationDataManager(db DataManager) types.AccountInvitationDataManager {
+	return db
+}
+
+// ProvideUserSessionDataManager is an arbitrary function for dependency injection's sake.
+func ProvideUserSessionDataManager(db DataManager) types.UserSessionDataManager {
+	return db
+}
+
+// ProvideWebAuthnCredentialDataManager is an arbitrary function for dependency injection's sake.
+func ProvideWebAuthnCredentialDataManager(db DataManager) types.WebAuthnCredentialDataManager {
+	return db
+}
+
+// ProvideOIDCIdentityDataManager is an arbitrary function for dependency injection's sake.
+func ProvideOIDCIdentityDataManager(db DataManager) types.OIDCIdentityDataManager {
+	return db
+}
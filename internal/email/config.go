@@ -0,0 +1,101 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+)
+
+const (
+	// ProviderSMTP represents sending email through an SMTP server.
+	ProviderSMTP = "smtp"
+	// ProviderLog represents "sending" email by logging it, and optionally writing it to a directory.
+	ProviderLog = "log"
+	// ProviderMemory represents "sending" email by holding onto it in memory, which is only useful for tests.
+	ProviderMemory = "memory"
+)
+
+var (
+	errInvalidProvider     = errors.New("invalid email provider")
+	errInvalidEmailAddress = errors.New("invalid email address")
+)
+
+type (
+	// SMTPConfig configures an SMTP-backed Emailer.
+	SMTPConfig struct {
+		_ struct{}
+
+		Host     string `json:"host" mapstructure:"host" toml:"host,omitempty"`
+		Username string `json:"username" mapstructure:"username" toml:"username,omitempty"`
+		Password string `json:"password" mapstructure:"password" toml:"password,omitempty"`
+		Port     uint16 `json:"port" mapstructure:"port" toml:"port,omitempty"`
+	}
+
+	// Config configures how we send email.
+	Config struct {
+		_ struct{}
+
+		SMTP            *SMTPConfig `json:"smtp" mapstructure:"smtp" toml:"smtp,omitempty"`
+		Provider        string      `json:"provider" mapstructure:"provider" toml:"provider,omitempty"`
+		FromAddress     string      `json:"from_address" mapstructure:"from_address" toml:"from_address,omitempty"`
+		FromName        string      `json:"from_name" mapstructure:"from_name" toml:"from_name,omitempty"`
+		BaseURL         string      `json:"base_url" mapstructure:"base_url" toml:"base_url,omitempty"`
+		OutputDirectory string      `json:"output_directory" mapstructure:"output_directory" toml:"output_directory,omitempty"`
+	}
+)
+
+var emailAddressRule = validation.By(func(value interface{}) error {
+	if s, ok := value.(string); ok && s != "" {
+		if _, err := mail.ParseAddress(s); err != nil {
+			return errInvalidEmailAddress
+		}
+	}
+
+	return nil
+})
+
+var _ validation.ValidatableWithContext = (*SMTPConfig)(nil)
+
+// ValidateWithContext validates a SMTPConfig struct.
+func (cfg *SMTPConfig) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, cfg,
+		validation.Field(&cfg.Host, validation.Required),
+		validation.Field(&cfg.Port, validation.Required),
+	)
+}
+
+var _ validation.ValidatableWithContext = (*Config)(nil)
+
+// ValidateWithContext validates a Config struct.
+func (cfg *Config) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, cfg,
+		validation.Field(&cfg.Provider, validation.Required, validation.In(ProviderSMTP, ProviderLog, ProviderMemory)),
+		validation.Field(&cfg.FromAddress, emailAddressRule),
+		validation.Field(&cfg.SMTP, validation.When(cfg.Provider == ProviderSMTP, validation.Required)),
+	)
+}
+
+// ProvideEmailer provides an Emailer dependent on the configuration.
+func ProvideEmailer(logger logging.Logger, cfg *Config) (Emailer, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Provider)) {
+	case ProviderSMTP:
+		e, err := NewSMTPEmailer(logger, cfg.SMTP)
+		if err != nil {
+			return nil, err
+		}
+
+		return e, nil
+	case ProviderMemory:
+		return NewInMemoryEmailer(), nil
+	case ProviderLog:
+		return NewLogEmailer(logger, cfg.OutputDirectory), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errInvalidProvider, cfg.Provider)
+	}
+}
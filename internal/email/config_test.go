@@ -0,0 +1,176 @@
+package email
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+)
+
+func TestConfig_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		cfg := &Config{
+			Provider:    ProviderLog,
+			FromAddress: "todo@example.com",
+		}
+
+		assert.NoError(t, cfg.ValidateWithContext(ctx))
+	})
+
+	T.Run("with smtp provider", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		cfg := &Config{
+			Provider:    ProviderSMTP,
+			FromAddress: "todo@example.com",
+			SMTP: &SMTPConfig{
+				Host: "localhost",
+				Port: 25,
+			},
+		}
+
+		assert.NoError(t, cfg.ValidateWithContext(ctx))
+	})
+
+	T.Run("with smtp provider and no smtp config", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		cfg := &Config{
+			Provider: ProviderSMTP,
+		}
+
+		assert.Error(t, cfg.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid smtp config", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		cfg := &Config{
+			Provider: ProviderSMTP,
+			SMTP:     &SMTPConfig{},
+		}
+
+		assert.Error(t, cfg.ValidateWithContext(ctx))
+	})
+
+	T.Run("without provider", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		cfg := &Config{
+			FromAddress: "todo@example.com",
+		}
+
+		assert.Error(t, cfg.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid provider", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		cfg := &Config{
+			Provider: t.Name(),
+		}
+
+		assert.Error(t, cfg.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid from address", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		cfg := &Config{
+			Provider:    ProviderMemory,
+			FromAddress: t.Name(),
+		}
+
+		assert.Error(t, cfg.ValidateWithContext(ctx))
+	})
+}
+
+func TestProvideEmailer(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with smtp provider", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &Config{
+			Provider: ProviderSMTP,
+			SMTP: &SMTPConfig{
+				Host: "localhost",
+				Port: 25,
+			},
+		}
+
+		actual, err := ProvideEmailer(logging.NewNoopLogger(), cfg)
+		assert.NoError(t, err)
+		assert.IsType(t, &SMTPEmailer{}, actual)
+	})
+
+	T.Run("with smtp provider and no smtp config", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &Config{
+			Provider: ProviderSMTP,
+		}
+
+		actual, err := ProvideEmailer(logging.NewNoopLogger(), cfg)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with log provider", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &Config{
+			Provider: ProviderLog,
+		}
+
+		actual, err := ProvideEmailer(logging.NewNoopLogger(), cfg)
+		assert.NoError(t, err)
+		assert.IsType(t, &LogEmailer{}, actual)
+	})
+
+	T.Run("with no provider", func(t *testing.T) {
+		t.Parallel()
+
+		actual, err := ProvideEmailer(logging.NewNoopLogger(), &Config{})
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with memory provider", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &Config{
+			Provider: ProviderMemory,
+		}
+
+		actual, err := ProvideEmailer(logging.NewNoopLogger(), cfg)
+		require.NoError(t, err)
+		assert.IsType(t, &InMemoryEmailer{}, actual)
+	})
+
+	T.Run("with invalid provider", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &Config{
+			Provider: t.Name(),
+		}
+
+		actual, err := ProvideEmailer(logging.NewNoopLogger(), cfg)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
@@ -0,0 +1,4 @@
+/*
+Package email provides an interface for sending outbound email, along with the messages we send
+*/
+package email
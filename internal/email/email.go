@@ -0,0 +1,30 @@
+package email
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrMissingRecipient is returned when asked to send an email to nobody.
+	ErrMissingRecipient = errors.New("no recipient address provided")
+)
+
+type (
+	// OutboundMessageDetails represents an email we intend to send.
+	OutboundMessageDetails struct {
+		_ struct{}
+
+		ToAddress   string `json:"toAddress"`
+		ToName      string `json:"toName"`
+		FromAddress string `json:"fromAddress"`
+		FromName    string `json:"fromName"`
+		Subject     string `json:"subject"`
+		HTMLContent string `json:"htmlContent"`
+	}
+
+	// Emailer represents a service that can send outbound email.
+	Emailer interface {
+		SendEmail(ctx context.Context, details *OutboundMessageDetails) error
+	}
+)
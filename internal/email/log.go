@@ -0,0 +1,63 @@
+package email
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+)
+
+const (
+	logName = "log_emailer"
+)
+
+var _ Emailer = (*LogEmailer)(nil)
+
+type (
+	// LogEmailer "sends" email by logging who it was for, and writing it to a directory if one is configured. Message
+	// content is never logged, since it may contain tokens that grant access to someone's account.
+	LogEmailer struct {
+		logger          logging.Logger
+		tracer          tracing.Tracer
+		outputDirectory string
+	}
+)
+
+// NewLogEmailer builds a new LogEmailer.
+func NewLogEmailer(logger logging.Logger, outputDirectory string) *LogEmailer {
+	return &LogEmailer{
+		logger:          logging.EnsureLogger(logger).WithName(logName),
+		tracer:          tracing.NewTracer(logName),
+		outputDirectory: outputDirectory,
+	}
+}
+
+// SendEmail logs an email's recipient and subject, and writes it to the output directory if one is configured.
+func (e *LogEmailer) SendEmail(ctx context.Context, details *OutboundMessageDetails) error {
+	_, span := e.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if details == nil || details.ToAddress == "" {
+		return ErrMissingRecipient
+	}
+
+	logger := e.logger.WithValue("to_address", details.ToAddress).WithValue("subject", details.Subject)
+
+	if e.outputDirectory != "" {
+		outputPath := filepath.Join(e.outputDirectory, ksuid.New().String()+".eml")
+		if err := os.WriteFile(outputPath, buildMessage(details), 0600); err != nil {
+			return observability.PrepareError(err, logger, span, "writing email to file")
+		}
+
+		logger = logger.WithValue("output_path", outputPath)
+	}
+
+	logger.Info("email sent")
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package email
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+)
+
+func TestLogEmailer_SendEmail(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		e := NewLogEmailer(logging.NewNoopLogger(), "")
+
+		assert.NoError(t, e.SendEmail(ctx, buildTestOutboundMessageDetails()))
+	})
+
+	T.Run("with output directory", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		dir := t.TempDir()
+		e := NewLogEmailer(logging.NewNoopLogger(), dir)
+
+		require.NoError(t, e.SendEmail(ctx, buildTestOutboundMessageDetails()))
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		contents, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), "<p>hello</p>")
+	})
+
+	T.Run("with invalid output directory", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		e := NewLogEmailer(logging.NewNoopLogger(), filepath.Join(t.TempDir(), "nonexistent"))
+
+		assert.Error(t, e.SendEmail(ctx, buildTestOutboundMessageDetails()))
+	})
+
+	T.Run("without recipient", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		e := NewLogEmailer(logging.NewNoopLogger(), "")
+
+		assert.ErrorIs(t, e.SendEmail(ctx, nil), ErrMissingRecipient)
+	})
+}
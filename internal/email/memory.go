@@ -0,0 +1,63 @@
+package email
+
+import (
+	"context"
+	"sync"
+)
+
+var _ Emailer = (*InMemoryEmailer)(nil)
+
+type (
+	// InMemoryEmailer "sends" email by holding onto it, so tests can inspect what was sent.
+	InMemoryEmailer struct {
+		sent    []*OutboundMessageDetails
+		sentHat sync.RWMutex
+	}
+)
+
+// NewInMemoryEmailer builds a new InMemoryEmailer.
+func NewInMemoryEmailer() *InMemoryEmailer {
+	return &InMemoryEmailer{
+		sent: []*OutboundMessageDetails{},
+	}
+}
+
+// SendEmail records an email.
+func (e *InMemoryEmailer) SendEmail(_ context.Context, details *OutboundMessageDetails) error {
+	if details == nil || details.ToAddress == "" {
+		return ErrMissingRecipient
+	}
+
+	e.sentHat.Lock()
+	defer e.sentHat.Unlock()
+
+	e.sent = append(e.sent, details)
+
+	return nil
+}
+
+// SentMessages returns every email sent so far.
+func (e *InMemoryEmailer) SentMessages() []*OutboundMessageDetails {
+	e.sentHat.RLock()
+	defer e.sentHat.RUnlock()
+
+	out := make([]*OutboundMessageDetails, len(e.sent))
+	copy(out, e.sent)
+
+	return out
+}
+
+// SentTo returns every email sent so far to a given address.
+func (e *InMemoryEmailer) SentTo(address string) []*OutboundMessageDetails {
+	e.sentHat.RLock()
+	defer e.sentHat.RUnlock()
+
+	out := []*OutboundMessageDetails{}
+	for _, details := range e.sent {
+		if details.ToAddress == address {
+			out = append(out, details)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,35 @@
+package email
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryEmailer_SendEmail(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		e := NewInMemoryEmailer()
+		details := buildTestOutboundMessageDetails()
+
+		assert.NoError(t, e.SendEmail(ctx, details))
+		assert.Equal(t, []*OutboundMessageDetails{details}, e.SentMessages())
+		assert.Equal(t, []*OutboundMessageDetails{details}, e.SentTo(details.ToAddress))
+		assert.Empty(t, e.SentTo(t.Name()))
+	})
+
+	T.Run("without recipient", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		e := NewInMemoryEmailer()
+
+		assert.ErrorIs(t, e.SendEmail(ctx, &OutboundMessageDetails{}), ErrMissingRecipient)
+		assert.Empty(t, e.SentMessages())
+	})
+}
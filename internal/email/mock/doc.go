@@ -0,0 +1,4 @@
+/*
+Package mockemail provides an interface-compatible emailer mock
+*/
+package mockemail
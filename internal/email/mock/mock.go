@@ -0,0 +1,21 @@
+package mockemail
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
+)
+
+var _ email.Emailer = (*Emailer)(nil)
+
+// Emailer is a mock Emailer.
+type Emailer struct {
+	mock.Mock
+}
+
+// SendEmail implements our interface.
+func (m *Emailer) SendEmail(ctx context.Context, details *email.OutboundMessageDetails) error {
+	return m.Called(ctx, details).Error(0)
+}
@@ -0,0 +1,96 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+)
+
+const (
+	smtpName = "smtp_emailer"
+)
+
+var (
+	errNilSMTPConfig = errors.New("nil SMTP config provided")
+)
+
+var _ Emailer = (*SMTPEmailer)(nil)
+
+type (
+	// SMTPEmailer sends email through an SMTP server.
+	SMTPEmailer struct {
+		logger   logging.Logger
+		tracer   tracing.Tracer
+		auth     smtp.Auth
+		sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+		address  string
+	}
+)
+
+// NewSMTPEmailer builds a new SMTPEmailer.
+func NewSMTPEmailer(logger logging.Logger, cfg *SMTPConfig) (*SMTPEmailer, error) {
+	if cfg == nil {
+		return nil, errNilSMTPConfig
+	}
+
+	e := &SMTPEmailer{
+		logger:   logging.EnsureLogger(logger).WithName(smtpName),
+		tracer:   tracing.NewTracer(smtpName),
+		sendMail: smtp.SendMail,
+		address:  net.JoinHostPort(cfg.Host, strconv.Itoa(int(cfg.Port))),
+	}
+
+	if cfg.Username != "" {
+		e.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return e, nil
+}
+
+// buildMessage renders an OutboundMessageDetails as an RFC 5322 message.
+func buildMessage(details *OutboundMessageDetails) []byte {
+	from := mail.Address{Name: details.FromName, Address: details.FromAddress}
+	to := mail.Address{Name: details.ToName, Address: details.ToAddress}
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "From: %s\r\n", from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", to.String())
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", details.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/html; charset=\"UTF-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(details.HTMLContent)
+
+	return b.Bytes()
+}
+
+// SendEmail sends an email.
+func (e *SMTPEmailer) SendEmail(ctx context.Context, details *OutboundMessageDetails) error {
+	_, span := e.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if details == nil || details.ToAddress == "" {
+		return ErrMissingRecipient
+	}
+
+	logger := e.logger.WithValue("to_address", details.ToAddress)
+
+	if err := e.sendMail(e.address, e.auth, details.FromAddress, []string{details.ToAddress}, buildMessage(details)); err != nil {
+		return observability.PrepareError(err, logger, span, "sending email")
+	}
+
+	logger.Debug("email sent")
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+)
+
+func buildTestSMTPEmailer(t *testing.T) *SMTPEmailer {
+	t.Helper()
+
+	e, err := NewSMTPEmailer(logging.NewNoopLogger(), &SMTPConfig{
+		Host:     "localhost",
+		Port:     25,
+		Username: "username",
+		Password: "password",
+	})
+	require.NoError(t, err)
+
+	return e
+}
+
+func buildTestOutboundMessageDetails() *OutboundMessageDetails {
+	return &OutboundMessageDetails{
+		ToAddress:   "recipient@example.com",
+		ToName:      "Recipient",
+		FromAddress: "sender@example.com",
+		FromName:    "Sender",
+		Subject:     "things & stuff",
+		HTMLContent: "<p>hello</p>",
+	}
+}
+
+func TestNewSMTPEmailer(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		e := buildTestSMTPEmailer(t)
+
+		assert.NotNil(t, e.auth)
+		assert.Equal(t, "localhost:25", e.address)
+	})
+
+	T.Run("with nil config", func(t *testing.T) {
+		t.Parallel()
+
+		e, err := NewSMTPEmailer(logging.NewNoopLogger(), nil)
+		assert.Error(t, err)
+		assert.Nil(t, e)
+	})
+}
+
+func TestSMTPEmailer_SendEmail(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		e := buildTestSMTPEmailer(t)
+		details := buildTestOutboundMessageDetails()
+
+		var sentTo []string
+		var sentMessage []byte
+		e.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			assert.Equal(t, e.address, addr)
+			assert.Equal(t, details.FromAddress, from)
+			sentTo, sentMessage = to, msg
+			return nil
+		}
+
+		assert.NoError(t, e.SendEmail(ctx, details))
+		assert.Equal(t, []string{details.ToAddress}, sentTo)
+		assert.Contains(t, string(sentMessage), "Content-Type: text/html")
+		assert.Contains(t, string(sentMessage), details.HTMLContent)
+	})
+
+	T.Run("without recipient", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		e := buildTestSMTPEmailer(t)
+
+		assert.ErrorIs(t, e.SendEmail(ctx, &OutboundMessageDetails{}), ErrMissingRecipient)
+	})
+
+	T.Run("with error sending", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		e := buildTestSMTPEmailer(t)
+		e.sendMail = func(string, smtp.Auth, string, []string, []byte) error {
+			return errors.New("blah")
+		}
+
+		assert.Error(t, e.SendEmail(ctx, buildTestOutboundMessageDetails()))
+	})
+}
@@ -0,0 +1,183 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/url"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	passwordResetTemplateName      = "password_reset"
+	accountInviteTemplateName      = "account_invite"
+	securityAlertTemplateName      = "security_alert"
+	notificationDigestTemplateName = "notification_digest"
+
+	passwordResetPath = "/reset_password"
+	invitationsPath   = "/invitations"
+	dashboardPath     = "/dashboard"
+)
+
+var (
+	errNilLocalizer = errors.New("nil localizer provided")
+	// ErrUnknownSecurityAlert is returned when we're asked to build a security alert for an event we don't alert on.
+	ErrUnknownSecurityAlert = errors.New("unknown security alert")
+
+	// securityAlertMessageIDs maps the audit log event types we alert on to the translation that describes them.
+	securityAlertMessageIDs = map[string]string{
+		types.UserPasswordChangeEvent:          "email.securityAlert.passwordChanged",
+		types.UserPasswordResetEvent:           "email.securityAlert.passwordReset",
+		types.UserTwoFactorSecretChangeEvent:   "email.securityAlert.twoFactorSecretChanged",
+		types.UserTwoFactorSecretRecoveryEvent: "email.securityAlert.twoFactorSecretRecovered",
+		types.AccountOwnershipTransferEvent:    "email.securityAlert.accountOwnershipTransferred",
+	}
+)
+
+//go:embed templates/*.gotpl
+var templatesDir embed.FS
+
+type (
+	// Renderer renders the emails we send.
+	Renderer struct {
+		localizer   *i18n.Localizer
+		templates   *template.Template
+		fromAddress string
+		fromName    string
+		baseURL     string
+	}
+)
+
+// ProvideRenderer builds a new Renderer.
+func ProvideRenderer(cfg *Config, localizer *i18n.Localizer) (*Renderer, error) {
+	if localizer == nil {
+		return nil, errNilLocalizer
+	}
+
+	r := &Renderer{
+		localizer:   localizer,
+		fromAddress: cfg.FromAddress,
+		fromName:    cfg.FromName,
+		baseURL:     strings.TrimSuffix(cfg.BaseURL, "/"),
+	}
+
+	tmpl, err := template.New("").Funcs(template.FuncMap{"translate": r.translate}).ParseFS(templatesDir, "templates/*.gotpl")
+	if err != nil {
+		return nil, fmt.Errorf("parsing email templates: %w", err)
+	}
+
+	r.templates = tmpl
+
+	return r, nil
+}
+
+func (r *Renderer) translate(messageID string) string {
+	return r.localizer.MustLocalize(&i18n.LocalizeConfig{
+		MessageID:      messageID,
+		DefaultMessage: nil,
+		TemplateData:   nil,
+		Funcs:          nil,
+	})
+}
+
+func (r *Renderer) buildLink(path string, query url.Values) string {
+	link := r.baseURL + path
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+
+	return link
+}
+
+// render executes a template and wraps it up as a message.
+func (r *Renderer) render(templateName, toAddress, toName, subjectMessageID string, data map[string]interface{}) (*OutboundMessageDetails, error) {
+	if toAddress == "" {
+		return nil, ErrMissingRecipient
+	}
+
+	details := &OutboundMessageDetails{
+		ToAddress:   toAddress,
+		ToName:      toName,
+		FromAddress: r.fromAddress,
+		FromName:    r.fromName,
+		Subject:     r.translate(subjectMessageID),
+	}
+
+	data["Subject"] = details.Subject
+	data["ToName"] = toName
+
+	var b bytes.Buffer
+	if err := r.templates.ExecuteTemplate(&b, templateName, data); err != nil {
+		return nil, fmt.Errorf("rendering %s email: %w", templateName, err)
+	}
+
+	details.HTMLContent = b.String()
+
+	return details, nil
+}
+
+// BuildPasswordResetEmail builds the email that carries a password reset token.
+func (r *Renderer) BuildPasswordResetEmail(user *types.User, token string) (*OutboundMessageDetails, error) {
+	if user == nil {
+		return nil, ErrMissingRecipient
+	}
+
+	data := map[string]interface{}{
+		"Link": r.buildLink(passwordResetPath, url.Values{"token": []string{token}}),
+	}
+
+	return r.render(passwordResetTemplateName, user.EmailAddress, user.Username, "email.passwordReset.subject", data)
+}
+
+// BuildAccountInviteEmail builds the email that tells someone they've been invited to join an account. Invitations
+// sent to an email address come with a token, which the link carries so the invitation can be claimed.
+func (r *Renderer) BuildAccountInviteEmail(toAddress, accountName, inviterUsername, note, token string) (*OutboundMessageDetails, error) {
+	var query url.Values
+	if token != "" {
+		query = url.Values{"token": []string{token}}
+	}
+
+	data := map[string]interface{}{
+		"AccountName":     accountName,
+		"InviterUsername": inviterUsername,
+		"Note":            note,
+		"Link":            r.buildLink(invitationsPath, query),
+	}
+
+	return r.render(accountInviteTemplateName, toAddress, "", "email.accountInvite.subject", data)
+}
+
+// BuildSecurityAlertEmail builds the email that tells someone a sensitive change was made to their user or account.
+// The eventType is one of the audit log event types, e.g. types.UserPasswordChangeEvent.
+func (r *Renderer) BuildSecurityAlertEmail(toAddress, toName, eventType string) (*OutboundMessageDetails, error) {
+	messageID, ok := securityAlertMessageIDs[eventType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSecurityAlert, eventType)
+	}
+
+	data := map[string]interface{}{
+		"AlertMessageID": messageID,
+	}
+
+	return r.render(securityAlertTemplateName, toAddress, toName, "email.securityAlert.subject", data)
+}
+
+// BuildNotificationDigestEmail builds the email that summarizes a batch of new notifications for a user.
+func (r *Renderer) BuildNotificationDigestEmail(user *types.User, notifications []*types.Notification) (*OutboundMessageDetails, error) {
+	if user == nil {
+		return nil, ErrMissingRecipient
+	}
+
+	data := map[string]interface{}{
+		"Notifications": notifications,
+		"Link":          r.buildLink(dashboardPath, nil),
+	}
+
+	return r.render(notificationDigestTemplateName, user.EmailAddress, user.Username, "email.notificationDigest.subject", data)
+}
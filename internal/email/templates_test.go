@@ -0,0 +1,176 @@
+package email
+
+import (
+	"html/template"
+	"os"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+// the frontend owns our translations, so we read them from there.
+const testTranslationsFile = "../services/frontend/translations/en.toml"
+
+func buildTestRenderer(t *testing.T) *Renderer {
+	t.Helper()
+
+	translations, err := os.ReadFile(testTranslationsFile)
+	require.NoError(t, err)
+
+	bundle := i18n.NewBundle(language.English)
+	bundle.RegisterUnmarshalFunc("toml", toml.Unmarshal)
+	bundle.MustParseMessageFileBytes(translations, "en.toml")
+
+	r, err := ProvideRenderer(&Config{
+		FromAddress: "todo@example.com",
+		FromName:    "Todo",
+		BaseURL:     "https://todo.example.com/",
+	}, i18n.NewLocalizer(bundle, "en"))
+	require.NoError(t, err)
+
+	return r
+}
+
+func TestProvideRenderer(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NotNil(t, buildTestRenderer(t))
+	})
+
+	T.Run("with nil localizer", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := ProvideRenderer(&Config{}, nil)
+		assert.Error(t, err)
+		assert.Nil(t, r)
+	})
+}
+
+func TestRenderer_BuildPasswordResetEmail(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		r := buildTestRenderer(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleUser.EmailAddress = "user@example.com"
+
+		actual, err := r.BuildPasswordResetEmail(exampleUser, "token")
+		require.NoError(t, err)
+
+		assert.Equal(t, exampleUser.EmailAddress, actual.ToAddress)
+		assert.Equal(t, "todo@example.com", actual.FromAddress)
+		assert.Equal(t, "Reset your password", actual.Subject)
+		assert.Contains(t, actual.HTMLContent, "https://todo.example.com/reset_password?token=token")
+	})
+
+	T.Run("without email address", func(t *testing.T) {
+		t.Parallel()
+
+		r := buildTestRenderer(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleUser.EmailAddress = ""
+
+		actual, err := r.BuildPasswordResetEmail(exampleUser, "token")
+		assert.ErrorIs(t, err, ErrMissingRecipient)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestRenderer_BuildAccountInviteEmail(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		r := buildTestRenderer(t)
+
+		actual, err := r.BuildAccountInviteEmail("invitee@example.com", "Example Account", "inviter", "come on in", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, "invitee@example.com", actual.ToAddress)
+		assert.Contains(t, actual.HTMLContent, "Example Account")
+		assert.Contains(t, actual.HTMLContent, "come on in")
+		assert.Contains(t, actual.HTMLContent, "https://todo.example.com/invitations")
+		assert.NotContains(t, actual.HTMLContent, "token=")
+	})
+
+	T.Run("with token", func(t *testing.T) {
+		t.Parallel()
+
+		r := buildTestRenderer(t)
+
+		actual, err := r.BuildAccountInviteEmail("invitee@example.com", "Example Account", "inviter", "", "abc123")
+		require.NoError(t, err)
+
+		assert.Contains(t, actual.HTMLContent, "https://todo.example.com/invitations?token=abc123")
+	})
+}
+
+func TestRenderer_BuildSecurityAlertEmail(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		r := buildTestRenderer(t)
+
+		for eventType := range securityAlertMessageIDs {
+			actual, err := r.BuildSecurityAlertEmail("user@example.com", "user", eventType)
+			require.NoError(t, err)
+			assert.Equal(t, "Security alert for your account", actual.Subject)
+		}
+	})
+
+	T.Run("with unknown event type", func(t *testing.T) {
+		t.Parallel()
+
+		r := buildTestRenderer(t)
+
+		actual, err := r.BuildSecurityAlertEmail("user@example.com", "user", types.ItemCreationEvent)
+		assert.ErrorIs(t, err, ErrUnknownSecurityAlert)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestRenderer_BuildNotificationDigestEmail(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		r := buildTestRenderer(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleUser.EmailAddress = "user@example.com"
+		exampleNotifications := fakes.BuildFakeNotificationList().Notifications
+
+		actual, err := r.BuildNotificationDigestEmail(exampleUser, exampleNotifications)
+		require.NoError(t, err)
+
+		assert.Equal(t, exampleUser.EmailAddress, actual.ToAddress)
+		for _, n := range exampleNotifications {
+			assert.Contains(t, actual.HTMLContent, template.HTMLEscapeString(n.Title))
+		}
+	})
+
+	T.Run("with nil user", func(t *testing.T) {
+		t.Parallel()
+
+		r := buildTestRenderer(t)
+
+		actual, err := r.BuildNotificationDigestEmail(nil, nil)
+		assert.ErrorIs(t, err, ErrMissingRecipient)
+		assert.Nil(t, actual)
+	})
+}
@@ -0,0 +1,13 @@
+package email
+
+import (
+	"github.com/google/wire"
+)
+
+var (
+	// Providers represents this package's offering to the dependency manager.
+	Providers = wire.NewSet(
+		ProvideEmailer,
+		ProvideRenderer,
+	)
+)
@@ -0,0 +1,45 @@
+package encoding
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ETagHeaderKey is the HTTP standard header name for entity tags.
+	ETagHeaderKey = "ETag"
+	// IfMatchHeaderKey is the HTTP standard header name for conditional requests on entity tags.
+	IfMatchHeaderKey = "If-Match"
+
+	ifMatchWildcard = "*"
+)
+
+// ETagForVersion returns the entity tag for a given version of a record.
+func ETagForVersion(version uint64) string {
+	return strconv.Quote(strconv.FormatUint(version, 10))
+}
+
+// IfMatchProvided reports whether a request has an If-Match header at all. Updates that require one should answer
+// its absence with 428 Precondition Required.
+func IfMatchProvided(req *http.Request) bool {
+	return strings.TrimSpace(req.Header.Get(IfMatchHeaderKey)) != ""
+}
+
+// IfMatchAllows reports whether a request's If-Match header permits changing a record at a given version. A missing
+// header permits nothing. Weak entity tags never match, since If-Match requires a strong comparison.
+func IfMatchAllows(req *http.Request, version uint64) bool {
+	header := strings.TrimSpace(req.Header.Get(IfMatchHeaderKey))
+	if header == ifMatchWildcard {
+		return true
+	}
+
+	etag := ETagForVersion(version)
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimSpace(candidate) == etag {
+			return true
+		}
+	}
+
+	return false
+}
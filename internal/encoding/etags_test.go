@@ -0,0 +1,84 @@
+package encoding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETagForVersion(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, `"123"`, ETagForVersion(123))
+	})
+}
+
+func buildIfMatchRequest(ifMatch string) *http.Request {
+	req := httptest.NewRequest(http.MethodPut, "/", nil)
+	if ifMatch != "" {
+		req.Header.Set(IfMatchHeaderKey, ifMatch)
+	}
+
+	return req
+}
+
+func TestIfMatchProvided(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with header", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, IfMatchProvided(buildIfMatchRequest(ETagForVersion(1))))
+	})
+
+	T.Run("without header", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, IfMatchProvided(buildIfMatchRequest("")))
+	})
+}
+
+func TestIfMatchAllows(T *testing.T) {
+	T.Parallel()
+
+	T.Run("without header", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, IfMatchAllows(buildIfMatchRequest(""), 1))
+	})
+
+	T.Run("with matching entity tag", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, IfMatchAllows(buildIfMatchRequest(ETagForVersion(3)), 3))
+	})
+
+	T.Run("with matching entity tag in list", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, IfMatchAllows(buildIfMatchRequest(`"1", "3"`), 3))
+	})
+
+	T.Run("with wildcard", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, IfMatchAllows(buildIfMatchRequest("*"), 3))
+	})
+
+	T.Run("with stale entity tag", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, IfMatchAllows(buildIfMatchRequest(ETagForVersion(2)), 3))
+	})
+
+	T.Run("with weak entity tag", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, IfMatchAllows(buildIfMatchRequest(`W/"3"`), 3))
+	})
+}
@@ -3,6 +3,7 @@
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/consumers"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers"
@@ -12,8 +13,15 @@
 const (
 	// ProviderRedis is used to refer to redis.
 	ProviderRedis = "redis"
+	// ProviderRedisStreams is used to refer to redis streams, which offer durable, at-least-once delivery.
+	ProviderRedisStreams = "redis_streams"
+	// ProviderMemory is used to refer to the in-process queue, which only reaches consumers in the same binary.
+	ProviderMemory = "memory"
 )
 
+// inMemoryBroker connects in-memory publishers to in-memory consumers within this process.
+var inMemoryBroker = consumers.NewInMemoryBroker(consumers.DefaultInMemoryBufferSize)
+
 type (
 	// Provider is used to indicate what messaging provider we'll use.
 	Provider string
@@ -26,12 +34,23 @@
 		QueueAddress MessageQueueAddress `json:"message_queue_address" mapstructure:"message_queue_address" toml:"message_queue_address,omitempty"`
 	}
 
+	// RedisStreamsConfig configures a Redis Streams-backed consumer and publisher.
+	RedisStreamsConfig struct {
+		QueueAddress    MessageQueueAddress `json:"message_queue_address" mapstructure:"message_queue_address" toml:"message_queue_address,omitempty"`
+		ConsumerGroup   string              `json:"consumer_group" mapstructure:"consumer_group" toml:"consumer_group,omitempty"`
+		DeadLetterTopic string              `json:"dead_letter_topic" mapstructure:"dead_letter_topic" toml:"dead_letter_topic,omitempty"`
+		RetryDelay      time.Duration       `json:"retry_delay" mapstructure:"retry_delay" toml:"retry_delay,omitempty"`
+		MaxStreamLength int64               `json:"max_stream_length" mapstructure:"max_stream_length" toml:"max_stream_length,omitempty"`
+		MaxRetries      uint8               `json:"max_retries" mapstructure:"max_retries" toml:"max_retries,omitempty"`
+	}
+
 	// Config is used to indicate how the messaging provider should be configured.
 	Config struct {
 		_ struct{}
 
-		Provider    Provider    `json:"provider" mapstructure:"provider" toml:"provider,omitempty"`
-		RedisConfig RedisConfig `json:"redis" mapstructure:"redis" toml:"redis,omitempty"`
+		Provider           Provider           `json:"provider" mapstructure:"provider" toml:"provider,omitempty"`
+		RedisConfig        RedisConfig        `json:"redis" mapstructure:"redis" toml:"redis,omitempty"`
+		RedisStreamsConfig RedisStreamsConfig `json:"redis_streams" mapstructure:"redis_streams" toml:"redis_streams,omitempty"`
 	}
 )
 
@@ -44,6 +63,16 @@ func ProvideConsumerProvider(logger logging.Logger, c *Config) (consumers.Consum
 	switch cleanString(string(c.Provider)) {
 	case ProviderRedis:
 		return consumers.ProvideRedisConsumerProvider(logger, string(c.RedisConfig.QueueAddress)), nil
+	case ProviderRedisStreams:
+		return consumers.ProvideRedisStreamsConsumerProvider(logger, &consumers.RedisStreamsConfig{
+			QueueAddress:    string(c.RedisStreamsConfig.QueueAddress),
+			ConsumerGroup:   c.RedisStreamsConfig.ConsumerGroup,
+			DeadLetterTopic: c.RedisStreamsConfig.DeadLetterTopic,
+			RetryDelay:      c.RedisStreamsConfig.RetryDelay,
+			MaxRetries:      c.RedisStreamsConfig.MaxRetries,
+		}), nil
+	case ProviderMemory:
+		return consumers.ProvideInMemoryConsumerProvider(logger, inMemoryBroker), nil
 	default:
 		return nil, fmt.Errorf("invalid provider: %q", c.Provider)
 	}
@@ -54,6 +83,10 @@ func ProvidePublisherProvider(logger logging.Logger, c *Config) (publishers.Publ
 	switch cleanString(string(c.Provider)) {
 	case ProviderRedis:
 		return publishers.ProvideRedisPublisherProvider(logger, string(c.RedisConfig.QueueAddress)), nil
+	case ProviderRedisStreams:
+		return publishers.ProvideRedisStreamsPublisherProvider(logger, string(c.RedisStreamsConfig.QueueAddress), c.RedisStreamsConfig.MaxStreamLength), nil
+	case ProviderMemory:
+		return publishers.ProvideInMemoryPublisherProvider(logger, inMemoryBroker), nil
 	default:
 		return nil, fmt.Errorf("invalid provider: %q", c.Provider)
 	}
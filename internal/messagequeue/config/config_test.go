@@ -34,6 +34,32 @@ func TestProvideConsumerProvider(T *testing.T) {
 		assert.NotNil(t, provider)
 	})
 
+	T.Run("with redis streams", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewZerologLogger()
+		cfg := &Config{
+			Provider: ProviderRedisStreams,
+		}
+
+		provider, err := ProvideConsumerProvider(logger, cfg)
+		assert.NoError(t, err)
+		assert.NotNil(t, provider)
+	})
+
+	T.Run("with memory", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewZerologLogger()
+		cfg := &Config{
+			Provider: ProviderMemory,
+		}
+
+		provider, err := ProvideConsumerProvider(logger, cfg)
+		assert.NoError(t, err)
+		assert.NotNil(t, provider)
+	})
+
 	T.Run("with invalid provider", func(t *testing.T) {
 		t.Parallel()
 
@@ -62,6 +88,32 @@ func TestProvidePublisherProvider(T *testing.T) {
 		assert.NotNil(t, provider)
 	})
 
+	T.Run("with redis streams", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewZerologLogger()
+		cfg := &Config{
+			Provider: ProviderRedisStreams,
+		}
+
+		provider, err := ProvidePublisherProvider(logger, cfg)
+		assert.NoError(t, err)
+		assert.NotNil(t, provider)
+	})
+
+	T.Run("with memory", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewZerologLogger()
+		cfg := &Config{
+			Provider: ProviderMemory,
+		}
+
+		provider, err := ProvidePublisherProvider(logger, cfg)
+		assert.NoError(t, err)
+		assert.NotNil(t, provider)
+	})
+
 	T.Run("with invalid provider", func(t *testing.T) {
 		t.Parallel()
 
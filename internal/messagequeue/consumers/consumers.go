@@ -19,5 +19,9 @@
 	// ConsumerProvider is a function that provides a Consumer for a given topic.
 	ConsumerProvider interface {
 		ProviderConsumer(ctx context.Context, topic string, handlerFunc func(context.Context, []byte) error) (Consumer, error)
+		// ProvideBroadcastConsumer provides a Consumer that sees every message published to a topic after it starts
+		// consuming, no matter how many other processes consume the same topic. Unlike ProviderConsumer's, these
+		// consumers don't share work, so they're for keeping per-process state (like open websockets) up to date.
+		ProvideBroadcastConsumer(ctx context.Context, topic string, handlerFunc func(context.Context, []byte) error) (Consumer, error)
 	}
 )
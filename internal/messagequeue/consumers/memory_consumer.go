@@ -0,0 +1,133 @@
+package consumers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+)
+
+const (
+	// DefaultInMemoryBufferSize is how many messages an in-memory consumer holds before publishers block.
+	DefaultInMemoryBufferSize = 1024
+)
+
+type (
+	// InMemoryBroker routes published messages to the in-memory consumers registered in the same process.
+	InMemoryBroker struct {
+		subscriptions    map[string][]chan []byte
+		bufferSize       int
+		subscriptionsHat sync.RWMutex
+	}
+
+	inMemoryConsumer struct {
+		tracer      tracing.Tracer
+		logger      logging.Logger
+		handlerFunc func(context.Context, []byte) error
+		messages    <-chan []byte
+		topic       string
+	}
+)
+
+// NewInMemoryBroker builds a new InMemoryBroker.
+func NewInMemoryBroker(bufferSize int) *InMemoryBroker {
+	if bufferSize <= 0 {
+		bufferSize = DefaultInMemoryBufferSize
+	}
+
+	return &InMemoryBroker{
+		subscriptions: map[string][]chan []byte{},
+		bufferSize:    bufferSize,
+	}
+}
+
+func (b *InMemoryBroker) subscribe(topic string) <-chan []byte {
+	b.subscriptionsHat.Lock()
+	defer b.subscriptionsHat.Unlock()
+
+	c := make(chan []byte, b.bufferSize)
+	b.subscriptions[topic] = append(b.subscriptions[topic], c)
+
+	return c
+}
+
+// Route delivers a message to every consumer subscribed to a topic. Like Redis pub/sub, messages
+// published to a topic nobody consumes are dropped. Route blocks while a consumer's buffer is full.
+func (b *InMemoryBroker) Route(ctx context.Context, topic string, payload []byte) error {
+	b.subscriptionsHat.RLock()
+	subscribers := b.subscriptions[topic]
+	b.subscriptionsHat.RUnlock()
+
+	for _, c := range subscribers {
+		select {
+		case c <- payload:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func provideInMemoryConsumer(logger logging.Logger, broker *InMemoryBroker, topic string, handlerFunc func(context.Context, []byte) error) *inMemoryConsumer {
+	return &inMemoryConsumer{
+		topic:       topic,
+		handlerFunc: handlerFunc,
+		messages:    broker.subscribe(topic),
+		logger:      logging.EnsureLogger(logger),
+		tracer:      tracing.NewTracer(fmt.Sprintf("%s_consumer", topic)),
+	}
+}
+
+// Consume reads messages and applies the handler to their payloads.
+// Writes errors to the error chan if it isn't nil.
+func (c *inMemoryConsumer) Consume(stopChan chan bool, errors chan error) {
+	if stopChan == nil {
+		stopChan = make(chan bool, 1)
+	}
+
+	for {
+		select {
+		case msg := <-c.messages:
+			ctx, span := c.tracer.StartSpan(context.Background())
+			if err := c.handlerFunc(ctx, msg); err != nil {
+				c.logger.Error(err, "handling message")
+				if errors != nil {
+					errors <- err
+				}
+			}
+			span.End()
+		case <-stopChan:
+			return
+		}
+	}
+}
+
+type inMemoryConsumerProvider struct {
+	logger logging.Logger
+	broker *InMemoryBroker
+}
+
+// ProvideInMemoryConsumerProvider returns a ConsumerProvider whose consumers receive messages routed by a given broker.
+// Consumers aren't cached by topic, as several components in one process may each need to see every message on a topic.
+func ProvideInMemoryConsumerProvider(logger logging.Logger, broker *InMemoryBroker) ConsumerProvider {
+	return &inMemoryConsumerProvider{
+		logger: logging.EnsureLogger(logger),
+		broker: broker,
+	}
+}
+
+// ProviderConsumer returns a Consumer for a given topic.
+func (p *inMemoryConsumerProvider) ProviderConsumer(_ context.Context, topic string, handlerFunc func(context.Context, []byte) error) (Consumer, error) {
+	logger := logging.EnsureLogger(p.logger).WithValue("topic", topic)
+
+	return provideInMemoryConsumer(logger, p.broker, topic, handlerFunc), nil
+}
+
+// ProvideBroadcastConsumer returns a Consumer for a given topic. The in-memory broker already delivers every
+// message to every consumer, so these are no different from any other.
+func (p *inMemoryConsumerProvider) ProvideBroadcastConsumer(ctx context.Context, topic string, handlerFunc func(context.Context, []byte) error) (Consumer, error) {
+	return p.ProviderConsumer(ctx, topic, handlerFunc)
+}
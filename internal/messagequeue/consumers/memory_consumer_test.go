@@ -0,0 +1,181 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+)
+
+func TestNewInMemoryBroker(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		actual := NewInMemoryBroker(1)
+		assert.NotNil(t, actual)
+		assert.Equal(t, 1, actual.bufferSize)
+	})
+
+	T.Run("with default buffer size", func(t *testing.T) {
+		t.Parallel()
+
+		actual := NewInMemoryBroker(0)
+		assert.NotNil(t, actual)
+		assert.Equal(t, DefaultInMemoryBufferSize, actual.bufferSize)
+	})
+}
+
+func TestInMemoryBroker_Route(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		broker := NewInMemoryBroker(1)
+		first, second := broker.subscribe(t.Name()), broker.subscribe(t.Name())
+		exampleInput := []byte(t.Name())
+
+		assert.NoError(t, broker.Route(ctx, t.Name(), exampleInput))
+
+		assert.Equal(t, exampleInput, <-first)
+		assert.Equal(t, exampleInput, <-second)
+	})
+
+	T.Run("without subscribers", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		broker := NewInMemoryBroker(1)
+
+		assert.NoError(t, broker.Route(ctx, t.Name(), []byte(t.Name())))
+	})
+
+	T.Run("with full buffer and canceled context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		broker := NewInMemoryBroker(1)
+		broker.subscribe(t.Name())
+
+		require.NoError(t, broker.Route(ctx, t.Name(), []byte(t.Name())))
+
+		cancel()
+		assert.Error(t, broker.Route(ctx, t.Name(), []byte(t.Name())))
+	})
+}
+
+func Test_inMemoryConsumer_Consume(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		broker := NewInMemoryBroker(1)
+		exampleInput := []byte(t.Name())
+
+		received := make(chan []byte, 1)
+		hf := func(_ context.Context, payload []byte) error {
+			received <- payload
+			return nil
+		}
+
+		actual := provideInMemoryConsumer(logger, broker, t.Name(), hf)
+		require.NotNil(t, actual)
+
+		stopChan := make(chan bool)
+		go actual.Consume(stopChan, nil)
+
+		require.NoError(t, broker.Route(ctx, t.Name(), exampleInput))
+		assert.Equal(t, exampleInput, <-received)
+
+		stopChan <- true
+	})
+
+	T.Run("with error handling message", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		broker := NewInMemoryBroker(1)
+
+		hf := func(context.Context, []byte) error {
+			return errors.New("blah")
+		}
+
+		actual := provideInMemoryConsumer(logger, broker, t.Name(), hf)
+		require.NotNil(t, actual)
+
+		stopChan := make(chan bool)
+		errorsChan := make(chan error)
+		go actual.Consume(stopChan, errorsChan)
+
+		require.NoError(t, broker.Route(ctx, t.Name(), []byte(t.Name())))
+		assert.Error(t, <-errorsChan)
+
+		stopChan <- true
+	})
+}
+
+func TestProvideInMemoryConsumerProvider(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+
+		actual := ProvideInMemoryConsumerProvider(logger, NewInMemoryBroker(0))
+		assert.NotNil(t, actual)
+	})
+}
+
+func Test_inMemoryConsumerProvider_ProviderConsumer(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		broker := NewInMemoryBroker(1)
+		provider := ProvideInMemoryConsumerProvider(logger, broker)
+
+		first, err := provider.ProviderConsumer(ctx, t.Name(), nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, first)
+
+		second, err := provider.ProviderConsumer(ctx, t.Name(), nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, second)
+
+		assert.Len(t, broker.subscriptions[t.Name()], 2)
+	})
+}
+
+func Test_inMemoryConsumerProvider_ProvideBroadcastConsumer(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		broker := NewInMemoryBroker(1)
+		provider := ProvideInMemoryConsumerProvider(logger, broker)
+
+		actual, err := provider.ProvideBroadcastConsumer(ctx, t.Name(), nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, actual)
+
+		assert.Len(t, broker.subscriptions[t.Name()], 1)
+	})
+}
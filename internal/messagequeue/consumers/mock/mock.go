@@ -22,6 +22,13 @@ func (m *ConsumerProvider) ProviderConsumer(ctx context.Context, topic string, h
 	return args.Get(0).(consumers.Consumer), args.Error(1)
 }
 
+// ProvideBroadcastConsumer implements the interface.
+func (m *ConsumerProvider) ProvideBroadcastConsumer(ctx context.Context, topic string, handlerFunc func(context.Context, []byte) error) (consumers.Consumer, error) {
+	args := m.Called(ctx, topic, handlerFunc)
+
+	return args.Get(0).(consumers.Consumer), args.Error(1)
+}
+
 // Consumer is a mock consumers.Consumer.
 type Consumer struct {
 	mock.Mock
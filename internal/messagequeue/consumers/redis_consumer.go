@@ -108,3 +108,11 @@ func (p *consumerProvider) ProviderConsumer(ctx context.Context, topic string, h
 
 	return c, nil
 }
+
+// ProvideBroadcastConsumer returns a Consumer for a given topic. Redis pub/sub already delivers every message to
+// every subscriber, but broadcast consumers aren't cached, since each one needs its own subscription.
+func (p *consumerProvider) ProvideBroadcastConsumer(ctx context.Context, topic string, handlerFunc func(context.Context, []byte) error) (Consumer, error) {
+	logger := logging.EnsureLogger(p.logger).WithValue("topic", topic)
+
+	return provideRedisConsumer(ctx, logger, p.redisClient, topic, handlerFunc), nil
+}
@@ -189,3 +189,27 @@ func Test_consumerProvider_ProviderConsumer(T *testing.T) {
 		assert.NotNil(t, actual)
 	})
 }
+
+func Test_consumerProvider_ProvideBroadcastConsumer(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+		exampleAddress := t.Name()
+
+		conPro := ProvideRedisConsumerProvider(logger, exampleAddress)
+		require.NotNil(t, conPro)
+
+		ctx := context.Background()
+
+		first, err := conPro.ProvideBroadcastConsumer(ctx, t.Name(), nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, first)
+
+		second, err := conPro.ProvideBroadcastConsumer(ctx, t.Name(), nil)
+		assert.NoError(t, err)
+		assert.NotSame(t, first, second)
+	})
+}
@@ -0,0 +1,447 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+)
+
+const (
+	// RedisStreamsPayloadKey is the stream entry field message payloads are stored under.
+	RedisStreamsPayloadKey = "payload"
+	// RedisStreamsTopicKey is the stream entry field a dead-lettered message's original topic is stored under.
+	RedisStreamsTopicKey = "topic"
+	// RedisStreamsMessageIDKey is the stream entry field a dead-lettered message's original ID is stored under.
+	RedisStreamsMessageIDKey = "message_id"
+	// RedisStreamsErrorKey is the stream entry field the last error for a dead-lettered message is stored under.
+	RedisStreamsErrorKey = "error"
+
+	// DefaultRedisStreamsConsumerGroup is the consumer group used when none is configured.
+	DefaultRedisStreamsConsumerGroup = "todo"
+	// DefaultRedisStreamsDeadLetterTopic is the topic poison messages are moved to when none is configured.
+	DefaultRedisStreamsDeadLetterTopic = "dead_letters"
+	// DefaultRedisStreamsMaxRetries is how many times a message is retried when no limit is configured.
+	DefaultRedisStreamsMaxRetries = 5
+	// DefaultRedisStreamsRetryDelay is how long a failed message waits before being retried when no delay is configured.
+	DefaultRedisStreamsRetryDelay = 10 * time.Second
+
+	redisStreamsBatchSize    = 10
+	redisStreamsBlockTimeout = time.Second
+	redisStreamsErrorBackoff = time.Second
+)
+
+type (
+	streamsClient interface {
+		XGroupCreateMkStream(ctx context.Context, stream, group, start string) *redis.StatusCmd
+		XReadGroup(ctx context.Context, a *redis.XReadGroupArgs) *redis.XStreamSliceCmd
+		XRead(ctx context.Context, a *redis.XReadArgs) *redis.XStreamSliceCmd
+		XAck(ctx context.Context, stream, group string, ids ...string) *redis.IntCmd
+		XPendingExt(ctx context.Context, a *redis.XPendingExtArgs) *redis.XPendingExtCmd
+		XClaim(ctx context.Context, a *redis.XClaimArgs) *redis.XMessageSliceCmd
+		XAdd(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd
+	}
+
+	// RedisStreamsConfig configures a Redis Streams-backed consumer.
+	RedisStreamsConfig struct {
+		QueueAddress    string
+		ConsumerGroup   string
+		DeadLetterTopic string
+		RetryDelay      time.Duration
+		MaxRetries      uint8
+	}
+
+	redisStreamsConsumer struct {
+		tracer          tracing.Tracer
+		logger          logging.Logger
+		client          streamsClient
+		handlerFunc     func(context.Context, []byte) error
+		topic           string
+		group           string
+		name            string
+		deadLetterTopic string
+		retryDelay      time.Duration
+		maxRetries      uint8
+	}
+
+	// redisStreamsBroadcastConsumer reads a stream without a consumer group, so that every instance of it sees
+	// every message. Messages are neither acknowledged nor retried.
+	redisStreamsBroadcastConsumer struct {
+		tracer      tracing.Tracer
+		logger      logging.Logger
+		client      streamsClient
+		handlerFunc func(context.Context, []byte) error
+		topic       string
+		lastID      string
+	}
+)
+
+func provideRedisStreamsConsumer(logger logging.Logger, client streamsClient, cfg *RedisStreamsConfig, topic string, handlerFunc func(context.Context, []byte) error) *redisStreamsConsumer {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "consumer"
+	}
+
+	return &redisStreamsConsumer{
+		topic:           topic,
+		handlerFunc:     handlerFunc,
+		client:          client,
+		group:           cfg.ConsumerGroup,
+		name:            fmt.Sprintf("%s-%s", hostname, ksuid.New().String()),
+		deadLetterTopic: cfg.DeadLetterTopic,
+		retryDelay:      cfg.RetryDelay,
+		maxRetries:      cfg.MaxRetries,
+		logger:          logging.EnsureLogger(logger),
+		tracer:          tracing.NewTracer(fmt.Sprintf("%s_consumer", topic)),
+	}
+}
+
+// Consume reads messages and applies the handler to their payloads.
+// Messages are only acknowledged once handled successfully, so they survive consumer restarts.
+// Writes errors to the error chan if it isn't nil.
+func (r *redisStreamsConsumer) Consume(stopChan chan bool, errs chan error) {
+	if stopChan == nil {
+		stopChan = make(chan bool, 1)
+	}
+
+	ctx := context.Background()
+
+	for err := r.ensureConsumerGroup(ctx); err != nil; err = r.ensureConsumerGroup(ctx) {
+		r.logger.Error(err, "creating consumer group")
+
+		if waitOrStop(stopChan, redisStreamsErrorBackoff) {
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		default:
+		}
+
+		if err := r.retryPendingMessages(ctx, errs); err != nil {
+			r.logger.Error(err, "retrying pending messages")
+		}
+
+		if err := r.readNewMessages(ctx, errs); err != nil {
+			r.logger.Error(err, "reading new messages")
+
+			if waitOrStop(stopChan, redisStreamsErrorBackoff) {
+				return
+			}
+		}
+	}
+}
+
+// waitOrStop waits for a given duration, and reports whether we were told to stop in the meantime.
+func waitOrStop(stopChan chan bool, d time.Duration) bool {
+	select {
+	case <-stopChan:
+		return true
+	case <-time.After(d):
+		return false
+	}
+}
+
+// ensureConsumerGroup creates our consumer group (and the stream, if need be), tolerating one already existing.
+func (r *redisStreamsConsumer) ensureConsumerGroup(ctx context.Context) error {
+	err := r.client.XGroupCreateMkStream(ctx, r.topic, r.group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	return nil
+}
+
+// readNewMessages reads messages that have never been delivered to our consumer group, and handles them.
+func (r *redisStreamsConsumer) readNewMessages(ctx context.Context, errs chan error) error {
+	streams, err := r.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    r.group,
+		Consumer: r.name,
+		Streams:  []string{r.topic, ">"},
+		Count:    redisStreamsBatchSize,
+		Block:    redisStreamsBlockTimeout,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			r.handleMessage(ctx, msg, errs)
+		}
+	}
+
+	return nil
+}
+
+// retryPendingMessages claims messages that were delivered but never acknowledged, and either retries or dead-letters them.
+func (r *redisStreamsConsumer) retryPendingMessages(ctx context.Context, errs chan error) error {
+	pending, err := r.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: r.topic,
+		Group:  r.group,
+		Start:  "-",
+		End:    "+",
+		Count:  redisStreamsBatchSize,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// we filter by idle time here rather than in the query because XPENDING's IDLE option requires redis 6.2.
+	deliveryCounts := map[string]int64{}
+	ids := []string{}
+	for _, p := range pending {
+		if p.Idle >= r.retryDelay {
+			deliveryCounts[p.ID] = p.RetryCount
+			ids = append(ids, p.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	claimed, err := r.client.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   r.topic,
+		Group:    r.group,
+		Consumer: r.name,
+		MinIdle:  r.retryDelay,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	r.handleClaimedMessages(ctx, claimed, deliveryCounts, errs)
+
+	return nil
+}
+
+// handleClaimedMessages retries claimed messages, unless they've been delivered too many times already.
+func (r *redisStreamsConsumer) handleClaimedMessages(ctx context.Context, claimed []redis.XMessage, deliveryCounts map[string]int64, errs chan error) {
+	for _, msg := range claimed {
+		if deliveryCounts[msg.ID] > int64(r.maxRetries) {
+			if err := r.deadLetter(ctx, msg); err != nil {
+				r.logger.WithValue("message_id", msg.ID).Error(err, "dead-lettering message")
+			}
+
+			continue
+		}
+
+		r.handleMessage(ctx, msg, errs)
+	}
+}
+
+// handleMessage applies the handler to a message's payload, and acknowledges it if that succeeds.
+func (r *redisStreamsConsumer) handleMessage(ctx context.Context, msg redis.XMessage, errs chan error) {
+	ctx, span := r.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := r.logger.WithValue("message_id", msg.ID)
+
+	payload, _ := msg.Values[RedisStreamsPayloadKey].(string)
+
+	if err := r.handlerFunc(ctx, []byte(payload)); err != nil {
+		observability.AcknowledgeError(err, logger, span, "handling message")
+		if errs != nil {
+			errs <- err
+		}
+
+		// leave the message pending, so that it is retried later.
+		return
+	}
+
+	if err := r.client.XAck(ctx, r.topic, r.group, msg.ID).Err(); err != nil {
+		observability.AcknowledgeError(err, logger, span, "acknowledging message")
+	}
+}
+
+// deadLetter moves a message that has failed too many times to the dead-letter topic.
+func (r *redisStreamsConsumer) deadLetter(ctx context.Context, msg redis.XMessage) error {
+	ctx, span := r.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := r.logger.WithValue("message_id", msg.ID).WithValue("dead_letter_topic", r.deadLetterTopic)
+
+	err := r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: r.deadLetterTopic,
+		Values: map[string]interface{}{
+			RedisStreamsPayloadKey:   msg.Values[RedisStreamsPayloadKey],
+			RedisStreamsTopicKey:     r.topic,
+			RedisStreamsMessageIDKey: msg.ID,
+			RedisStreamsErrorKey:     fmt.Sprintf("exceeded %d retries", r.maxRetries),
+		},
+	}).Err()
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "publishing dead letter")
+	}
+
+	if err = r.client.XAck(ctx, r.topic, r.group, msg.ID).Err(); err != nil {
+		return observability.PrepareError(err, logger, span, "acknowledging dead-lettered message")
+	}
+
+	logger.Info("message dead-lettered")
+
+	return nil
+}
+
+func provideRedisStreamsBroadcastConsumer(logger logging.Logger, client streamsClient, topic string, handlerFunc func(context.Context, []byte) error) *redisStreamsBroadcastConsumer {
+	return &redisStreamsBroadcastConsumer{
+		topic:       topic,
+		handlerFunc: handlerFunc,
+		client:      client,
+		// only messages added after we start reading are of interest.
+		lastID: "$",
+		logger: logging.EnsureLogger(logger),
+		tracer: tracing.NewTracer(fmt.Sprintf("%s_broadcast_consumer", topic)),
+	}
+}
+
+// Consume reads messages and applies the handler to their payloads.
+// Writes errors to the error chan if it isn't nil.
+func (r *redisStreamsBroadcastConsumer) Consume(stopChan chan bool, errs chan error) {
+	if stopChan == nil {
+		stopChan = make(chan bool, 1)
+	}
+
+	ctx := context.Background()
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		default:
+		}
+
+		if err := r.readNewMessages(ctx, errs); err != nil {
+			r.logger.Error(err, "reading new messages")
+
+			if waitOrStop(stopChan, redisStreamsErrorBackoff) {
+				return
+			}
+		}
+	}
+}
+
+// readNewMessages reads messages added since the last one we read, and handles them.
+func (r *redisStreamsBroadcastConsumer) readNewMessages(ctx context.Context, errs chan error) error {
+	streams, err := r.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{r.topic, r.lastID},
+		Count:   redisStreamsBatchSize,
+		Block:   redisStreamsBlockTimeout,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			r.handleMessage(ctx, msg, errs)
+			r.lastID = msg.ID
+		}
+	}
+
+	return nil
+}
+
+// handleMessage applies the handler to a message's payload.
+func (r *redisStreamsBroadcastConsumer) handleMessage(ctx context.Context, msg redis.XMessage, errs chan error) {
+	ctx, span := r.tracer.StartSpan(ctx)
+	defer span.End()
+
+	payload, _ := msg.Values[RedisStreamsPayloadKey].(string)
+
+	if err := r.handlerFunc(ctx, []byte(payload)); err != nil {
+		observability.AcknowledgeError(err, r.logger.WithValue("message_id", msg.ID), span, "handling message")
+		if errs != nil {
+			errs <- err
+		}
+	}
+}
+
+type redisStreamsConsumerProvider struct {
+	logger           logging.Logger
+	consumerCache    map[string]Consumer
+	redisClient      *redis.Client
+	config           *RedisStreamsConfig
+	consumerCacheHat sync.RWMutex
+}
+
+// ProvideRedisStreamsConsumerProvider returns a ConsumerProvider backed by Redis Streams.
+func ProvideRedisStreamsConsumerProvider(logger logging.Logger, cfg *RedisStreamsConfig) ConsumerProvider {
+	x := &RedisStreamsConfig{}
+	if cfg != nil {
+		*x = *cfg
+	}
+
+	if x.ConsumerGroup == "" {
+		x.ConsumerGroup = DefaultRedisStreamsConsumerGroup
+	}
+
+	if x.DeadLetterTopic == "" {
+		x.DeadLetterTopic = DefaultRedisStreamsDeadLetterTopic
+	}
+
+	if x.MaxRetries == 0 {
+		x.MaxRetries = DefaultRedisStreamsMaxRetries
+	}
+
+	if x.RetryDelay == 0 {
+		x.RetryDelay = DefaultRedisStreamsRetryDelay
+	}
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     x.QueueAddress,
+		Password: "", // no password set
+		DB:       0,  // use default DB
+	})
+
+	return &redisStreamsConsumerProvider{
+		logger:        logging.EnsureLogger(logger),
+		redisClient:   redisClient,
+		config:        x,
+		consumerCache: map[string]Consumer{},
+	}
+}
+
+// ProviderConsumer returns a Consumer for a given topic.
+func (p *redisStreamsConsumerProvider) ProviderConsumer(_ context.Context, topic string, handlerFunc func(context.Context, []byte) error) (Consumer, error) {
+	logger := logging.EnsureLogger(p.logger).WithValue("topic", topic)
+
+	p.consumerCacheHat.Lock()
+	defer p.consumerCacheHat.Unlock()
+	if cachedConsumer, ok := p.consumerCache[topic]; ok {
+		return cachedConsumer, nil
+	}
+
+	c := provideRedisStreamsConsumer(logger, p.redisClient, p.config, topic, handlerFunc)
+	p.consumerCache[topic] = c
+
+	return c, nil
+}
+
+// ProvideBroadcastConsumer returns a Consumer that reads a given topic outside of any consumer group.
+func (p *redisStreamsConsumerProvider) ProvideBroadcastConsumer(_ context.Context, topic string, handlerFunc func(context.Context, []byte) error) (Consumer, error) {
+	logger := logging.EnsureLogger(p.logger).WithValue("topic", topic)
+
+	return provideRedisStreamsBroadcastConsumer(logger, p.redisClient, topic, handlerFunc), nil
+}
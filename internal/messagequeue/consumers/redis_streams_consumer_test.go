@@ -0,0 +1,513 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+type mockStreamsClient struct {
+	mock.Mock
+}
+
+func (m *mockStreamsClient) XGroupCreateMkStream(ctx context.Context, stream, group, start string) *redis.StatusCmd {
+	return m.Called(ctx, stream, group, start).Get(0).(*redis.StatusCmd)
+}
+
+func (m *mockStreamsClient) XReadGroup(ctx context.Context, a *redis.XReadGroupArgs) *redis.XStreamSliceCmd {
+	return m.Called(ctx, a).Get(0).(*redis.XStreamSliceCmd)
+}
+
+func (m *mockStreamsClient) XRead(ctx context.Context, a *redis.XReadArgs) *redis.XStreamSliceCmd {
+	return m.Called(ctx, a).Get(0).(*redis.XStreamSliceCmd)
+}
+
+func (m *mockStreamsClient) XAck(ctx context.Context, stream, group string, ids ...string) *redis.IntCmd {
+	return m.Called(ctx, stream, group, ids).Get(0).(*redis.IntCmd)
+}
+
+func (m *mockStreamsClient) XPendingExt(ctx context.Context, a *redis.XPendingExtArgs) *redis.XPendingExtCmd {
+	return m.Called(ctx, a).Get(0).(*redis.XPendingExtCmd)
+}
+
+func (m *mockStreamsClient) XClaim(ctx context.Context, a *redis.XClaimArgs) *redis.XMessageSliceCmd {
+	return m.Called(ctx, a).Get(0).(*redis.XMessageSliceCmd)
+}
+
+func (m *mockStreamsClient) XAdd(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd {
+	return m.Called(ctx, a).Get(0).(*redis.StringCmd)
+}
+
+func buildTestRedisStreamsConsumer(t *testing.T, client streamsClient, handlerFunc func(context.Context, []byte) error) *redisStreamsConsumer {
+	t.Helper()
+
+	cfg := &RedisStreamsConfig{
+		ConsumerGroup:   DefaultRedisStreamsConsumerGroup,
+		DeadLetterTopic: DefaultRedisStreamsDeadLetterTopic,
+		MaxRetries:      2,
+		RetryDelay:      time.Second,
+	}
+
+	c := provideRedisStreamsConsumer(logging.NewNoopLogger(), client, cfg, t.Name(), handlerFunc)
+	require.NotNil(t, c)
+
+	return c
+}
+
+func buildPendingMessage(id, payload string) redis.XMessage {
+	return redis.XMessage{
+		ID:     id,
+		Values: map[string]interface{}{RedisStreamsPayloadKey: payload},
+	}
+}
+
+func Test_redisStreamsConsumer_ensureConsumerGroup(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+		c := buildTestRedisStreamsConsumer(t, client, nil)
+
+		client.On("XGroupCreateMkStream", testutils.ContextMatcher, c.topic, c.group, "0").Return(redis.NewStatusResult("OK", nil))
+
+		assert.NoError(t, c.ensureConsumerGroup(ctx))
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+
+	T.Run("with existing group", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+		c := buildTestRedisStreamsConsumer(t, client, nil)
+
+		client.On("XGroupCreateMkStream", testutils.ContextMatcher, c.topic, c.group, "0").Return(redis.NewStatusResult("", errors.New("BUSYGROUP Consumer Group name already exists")))
+
+		assert.NoError(t, c.ensureConsumerGroup(ctx))
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+
+	T.Run("with error creating group", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+		c := buildTestRedisStreamsConsumer(t, client, nil)
+
+		client.On("XGroupCreateMkStream", testutils.ContextMatcher, c.topic, c.group, "0").Return(redis.NewStatusResult("", errors.New("blah")))
+
+		assert.Error(t, c.ensureConsumerGroup(ctx))
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+}
+
+func Test_redisStreamsConsumer_readNewMessages(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+
+		var handled []string
+		hf := func(_ context.Context, b []byte) error {
+			handled = append(handled, string(b))
+			return nil
+		}
+		c := buildTestRedisStreamsConsumer(t, client, hf)
+
+		exampleMessage := buildPendingMessage("1-0", t.Name())
+		client.On("XReadGroup", testutils.ContextMatcher, mock.IsType(&redis.XReadGroupArgs{})).Return(redis.NewXStreamSliceCmdResult([]redis.XStream{{Stream: c.topic, Messages: []redis.XMessage{exampleMessage}}}, nil))
+		client.On("XAck", testutils.ContextMatcher, c.topic, c.group, []string{exampleMessage.ID}).Return(redis.NewIntResult(1, nil))
+
+		assert.NoError(t, c.readNewMessages(ctx, nil))
+		assert.Equal(t, []string{t.Name()}, handled)
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+
+	T.Run("with no new messages", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+		c := buildTestRedisStreamsConsumer(t, client, nil)
+
+		client.On("XReadGroup", testutils.ContextMatcher, mock.IsType(&redis.XReadGroupArgs{})).Return(redis.NewXStreamSliceCmdResult(nil, redis.Nil))
+
+		assert.NoError(t, c.readNewMessages(ctx, nil))
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+
+	T.Run("with error reading messages", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+		c := buildTestRedisStreamsConsumer(t, client, nil)
+
+		client.On("XReadGroup", testutils.ContextMatcher, mock.IsType(&redis.XReadGroupArgs{})).Return(redis.NewXStreamSliceCmdResult(nil, errors.New("blah")))
+
+		assert.Error(t, c.readNewMessages(ctx, nil))
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+
+	T.Run("with error handling message", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+
+		anticipatedError := errors.New("blah")
+		hf := func(context.Context, []byte) error {
+			return anticipatedError
+		}
+		c := buildTestRedisStreamsConsumer(t, client, hf)
+
+		exampleMessage := buildPendingMessage("1-0", t.Name())
+		client.On("XReadGroup", testutils.ContextMatcher, mock.IsType(&redis.XReadGroupArgs{})).Return(redis.NewXStreamSliceCmdResult([]redis.XStream{{Stream: c.topic, Messages: []redis.XMessage{exampleMessage}}}, nil))
+
+		errorsChan := make(chan error, 1)
+		assert.NoError(t, c.readNewMessages(ctx, errorsChan))
+		assert.Equal(t, anticipatedError, <-errorsChan)
+
+		// the message should not have been acknowledged, so that it gets retried.
+		mock.AssertExpectationsForObjects(t, client)
+		client.AssertNotCalled(t, "XAck", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func Test_redisStreamsConsumer_retryPendingMessages(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with error fetching pending messages", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+		c := buildTestRedisStreamsConsumer(t, client, nil)
+
+		cmd := redis.NewXPendingExtCmd(ctx)
+		cmd.SetErr(errors.New("blah"))
+		client.On("XPendingExt", testutils.ContextMatcher, mock.IsType(&redis.XPendingExtArgs{})).Return(cmd)
+
+		assert.Error(t, c.retryPendingMessages(ctx, nil))
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+
+	T.Run("with nothing pending", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+		c := buildTestRedisStreamsConsumer(t, client, nil)
+
+		client.On("XPendingExt", testutils.ContextMatcher, mock.IsType(&redis.XPendingExtArgs{})).Return(redis.NewXPendingExtCmd(ctx))
+
+		assert.NoError(t, c.retryPendingMessages(ctx, nil))
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+}
+
+func Test_redisStreamsConsumer_handleClaimedMessages(T *testing.T) {
+	T.Parallel()
+
+	T.Run("retries messages under the retry limit", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+
+		var handled []string
+		hf := func(_ context.Context, b []byte) error {
+			handled = append(handled, string(b))
+			return nil
+		}
+		c := buildTestRedisStreamsConsumer(t, client, hf)
+
+		exampleMessage := buildPendingMessage("1-0", t.Name())
+		client.On("XAck", testutils.ContextMatcher, c.topic, c.group, []string{exampleMessage.ID}).Return(redis.NewIntResult(1, nil))
+
+		c.handleClaimedMessages(ctx, []redis.XMessage{exampleMessage}, map[string]int64{exampleMessage.ID: int64(c.maxRetries)}, nil)
+		assert.Equal(t, []string{t.Name()}, handled)
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+
+	T.Run("dead-letters messages over the retry limit", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+
+		hf := func(context.Context, []byte) error {
+			t.Fatal("handler should not be called for dead-lettered messages")
+			return nil
+		}
+		c := buildTestRedisStreamsConsumer(t, client, hf)
+
+		exampleMessage := buildPendingMessage("1-0", t.Name())
+		client.On(
+			"XAdd",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(a *redis.XAddArgs) bool {
+				values, ok := a.Values.(map[string]interface{})
+				return ok && a.Stream == c.deadLetterTopic && values[RedisStreamsTopicKey] == c.topic && values[RedisStreamsPayloadKey] == t.Name()
+			}),
+		).Return(redis.NewStringResult("2-0", nil))
+		client.On("XAck", testutils.ContextMatcher, c.topic, c.group, []string{exampleMessage.ID}).Return(redis.NewIntResult(1, nil))
+
+		c.handleClaimedMessages(ctx, []redis.XMessage{exampleMessage}, map[string]int64{exampleMessage.ID: int64(c.maxRetries) + 1}, nil)
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+
+	T.Run("with error dead-lettering message", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+		c := buildTestRedisStreamsConsumer(t, client, nil)
+
+		exampleMessage := buildPendingMessage("1-0", t.Name())
+		client.On("XAdd", testutils.ContextMatcher, mock.IsType(&redis.XAddArgs{})).Return(redis.NewStringResult("", errors.New("blah")))
+
+		c.handleClaimedMessages(ctx, []redis.XMessage{exampleMessage}, map[string]int64{exampleMessage.ID: int64(c.maxRetries) + 1}, nil)
+
+		// the message must stay pending if we couldn't dead-letter it.
+		mock.AssertExpectationsForObjects(t, client)
+		client.AssertNotCalled(t, "XAck", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func Test_redisStreamsConsumer_Consume(T *testing.T) {
+	T.Parallel()
+
+	T.Run("stops when asked", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockStreamsClient{}
+		c := buildTestRedisStreamsConsumer(t, client, nil)
+
+		client.On("XGroupCreateMkStream", testutils.ContextMatcher, c.topic, c.group, "0").Return(redis.NewStatusResult("OK", nil))
+		client.On("XPendingExt", testutils.ContextMatcher, mock.IsType(&redis.XPendingExtArgs{})).Return(redis.NewXPendingExtCmd(context.Background()))
+		client.On("XReadGroup", testutils.ContextMatcher, mock.IsType(&redis.XReadGroupArgs{})).Return(redis.NewXStreamSliceCmdResult(nil, redis.Nil))
+
+		stopChan := make(chan bool)
+		done := make(chan struct{})
+
+		go func() {
+			c.Consume(stopChan, nil)
+			close(done)
+		}()
+
+		stopChan <- true
+		<-done
+
+		client.AssertCalled(t, "XGroupCreateMkStream", testutils.ContextMatcher, c.topic, c.group, "0")
+	})
+}
+
+func Test_redisStreamsBroadcastConsumer_readNewMessages(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+
+		var handled []string
+		hf := func(_ context.Context, b []byte) error {
+			handled = append(handled, string(b))
+			return nil
+		}
+		c := provideRedisStreamsBroadcastConsumer(logging.NewNoopLogger(), client, t.Name(), hf)
+
+		exampleMessage := buildPendingMessage("1-0", t.Name())
+		client.On("XRead", testutils.ContextMatcher, &redis.XReadArgs{
+			Streams: []string{c.topic, "$"},
+			Count:   redisStreamsBatchSize,
+			Block:   redisStreamsBlockTimeout,
+		}).Return(redis.NewXStreamSliceCmdResult([]redis.XStream{{Stream: c.topic, Messages: []redis.XMessage{exampleMessage}}}, nil))
+
+		assert.NoError(t, c.readNewMessages(ctx, nil))
+		assert.Equal(t, []string{t.Name()}, handled)
+		assert.Equal(t, exampleMessage.ID, c.lastID)
+
+		mock.AssertExpectationsForObjects(t, client)
+		client.AssertNotCalled(t, "XAck", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	T.Run("with no new messages", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+		c := provideRedisStreamsBroadcastConsumer(logging.NewNoopLogger(), client, t.Name(), nil)
+
+		client.On("XRead", testutils.ContextMatcher, mock.IsType(&redis.XReadArgs{})).Return(redis.NewXStreamSliceCmdResult(nil, redis.Nil))
+
+		assert.NoError(t, c.readNewMessages(ctx, nil))
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+
+	T.Run("with error reading messages", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+		c := provideRedisStreamsBroadcastConsumer(logging.NewNoopLogger(), client, t.Name(), nil)
+
+		client.On("XRead", testutils.ContextMatcher, mock.IsType(&redis.XReadArgs{})).Return(redis.NewXStreamSliceCmdResult(nil, errors.New("blah")))
+
+		assert.Error(t, c.readNewMessages(ctx, nil))
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+
+	T.Run("with error handling message", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := &mockStreamsClient{}
+
+		anticipatedError := errors.New("blah")
+		hf := func(context.Context, []byte) error {
+			return anticipatedError
+		}
+		c := provideRedisStreamsBroadcastConsumer(logging.NewNoopLogger(), client, t.Name(), hf)
+
+		exampleMessage := buildPendingMessage("1-0", t.Name())
+		client.On("XRead", testutils.ContextMatcher, mock.IsType(&redis.XReadArgs{})).Return(redis.NewXStreamSliceCmdResult([]redis.XStream{{Stream: c.topic, Messages: []redis.XMessage{exampleMessage}}}, nil))
+
+		errorsChan := make(chan error, 1)
+		assert.NoError(t, c.readNewMessages(ctx, errorsChan))
+		assert.Equal(t, anticipatedError, <-errorsChan)
+
+		// broadcast consumers don't retry, so the message is behind us either way.
+		assert.Equal(t, exampleMessage.ID, c.lastID)
+
+		mock.AssertExpectationsForObjects(t, client)
+	})
+}
+
+func Test_redisStreamsBroadcastConsumer_Consume(T *testing.T) {
+	T.Parallel()
+
+	T.Run("stops when asked", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockStreamsClient{}
+		c := provideRedisStreamsBroadcastConsumer(logging.NewNoopLogger(), client, t.Name(), nil)
+
+		client.On("XRead", testutils.ContextMatcher, mock.IsType(&redis.XReadArgs{})).Return(redis.NewXStreamSliceCmdResult(nil, redis.Nil))
+
+		stopChan := make(chan bool)
+		done := make(chan struct{})
+
+		go func() {
+			c.Consume(stopChan, nil)
+			close(done)
+		}()
+
+		stopChan <- true
+		<-done
+	})
+}
+
+func TestProvideRedisStreamsConsumerProvider(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+
+		actual := ProvideRedisStreamsConsumerProvider(logger, &RedisStreamsConfig{QueueAddress: t.Name()})
+		assert.NotNil(t, actual)
+	})
+
+	T.Run("with nil config", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+
+		actual := ProvideRedisStreamsConsumerProvider(logger, nil)
+		require.NotNil(t, actual)
+
+		p, ok := actual.(*redisStreamsConsumerProvider)
+		require.True(t, ok)
+		assert.Equal(t, DefaultRedisStreamsConsumerGroup, p.config.ConsumerGroup)
+		assert.Equal(t, DefaultRedisStreamsDeadLetterTopic, p.config.DeadLetterTopic)
+		assert.Equal(t, uint8(DefaultRedisStreamsMaxRetries), p.config.MaxRetries)
+		assert.Equal(t, DefaultRedisStreamsRetryDelay, p.config.RetryDelay)
+	})
+}
+
+func Test_redisStreamsConsumerProvider_ProviderConsumer(T *testing.T) {
+	T.Parallel()
+
+	T.Run("hitting cache", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+
+		conPro := ProvideRedisStreamsConsumerProvider(logger, &RedisStreamsConfig{QueueAddress: t.Name()})
+		require.NotNil(t, conPro)
+
+		ctx := context.Background()
+
+		first, err := conPro.ProviderConsumer(ctx, t.Name(), nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, first)
+
+		second, err := conPro.ProviderConsumer(ctx, t.Name(), nil)
+		assert.NoError(t, err)
+		assert.Same(t, first, second)
+	})
+}
+
+func Test_redisStreamsConsumerProvider_ProvideBroadcastConsumer(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+
+		conPro := ProvideRedisStreamsConsumerProvider(logger, &RedisStreamsConfig{QueueAddress: t.Name()})
+		require.NotNil(t, conPro)
+
+		ctx := context.Background()
+
+		first, err := conPro.ProvideBroadcastConsumer(ctx, t.Name(), nil)
+		assert.NoError(t, err)
+		assert.IsType(t, &redisStreamsBroadcastConsumer{}, first)
+
+		second, err := conPro.ProvideBroadcastConsumer(ctx, t.Name(), nil)
+		assert.NoError(t, err)
+		assert.NotSame(t, first, second)
+	})
+}
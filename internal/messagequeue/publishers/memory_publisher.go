@@ -0,0 +1,90 @@
+package publishers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+)
+
+type (
+	// MessageRouter hands published messages to whatever is consuming a topic in the same process.
+	MessageRouter interface {
+		Route(ctx context.Context, topic string, payload []byte) error
+	}
+
+	inMemoryPublisher struct {
+		tracer  tracing.Tracer
+		encoder encoding.ClientEncoder
+		logger  logging.Logger
+		router  MessageRouter
+		topic   string
+	}
+)
+
+// Publish routes a message to the topic's in-process consumers.
+func (p *inMemoryPublisher) Publish(ctx context.Context, data interface{}) error {
+	ctx, span := p.tracer.StartSpan(ctx)
+	defer span.End()
+
+	p.logger.Debug("publishing message")
+
+	var b bytes.Buffer
+	if err := p.encoder.Encode(ctx, &b, data); err != nil {
+		return observability.PrepareError(err, p.logger, span, "encoding topic message")
+	}
+
+	if err := p.router.Route(ctx, p.topic, b.Bytes()); err != nil {
+		return observability.PrepareError(err, p.logger, span, "publishing message")
+	}
+
+	return nil
+}
+
+// provideInMemoryPublisher provides an in-process Publisher.
+func provideInMemoryPublisher(logger logging.Logger, router MessageRouter, topic string) *inMemoryPublisher {
+	return &inMemoryPublisher{
+		router:  router,
+		topic:   topic,
+		encoder: encoding.ProvideClientEncoder(logger, encoding.ContentTypeJSON),
+		logger:  logging.EnsureLogger(logger),
+		tracer:  tracing.NewTracer(fmt.Sprintf("%s_publisher", topic)),
+	}
+}
+
+type inMemoryPublisherProvider struct {
+	logger            logging.Logger
+	publisherCache    map[string]Publisher
+	router            MessageRouter
+	publisherCacheHat sync.RWMutex
+}
+
+// ProvideInMemoryPublisherProvider returns a PublisherProvider whose publishers hand messages to a given router.
+func ProvideInMemoryPublisherProvider(logger logging.Logger, router MessageRouter) PublisherProvider {
+	return &inMemoryPublisherProvider{
+		logger:         logging.EnsureLogger(logger),
+		router:         router,
+		publisherCache: map[string]Publisher{},
+	}
+}
+
+// ProviderPublisher returns a Publisher for a given topic.
+func (p *inMemoryPublisherProvider) ProviderPublisher(topic string) (Publisher, error) {
+	logger := logging.EnsureLogger(p.logger).WithValue("topic", topic)
+
+	p.publisherCacheHat.Lock()
+	defer p.publisherCacheHat.Unlock()
+	if cachedPub, ok := p.publisherCache[topic]; ok {
+		return cachedPub, nil
+	}
+
+	pub := provideInMemoryPublisher(logger, p.router, topic)
+	p.publisherCache[topic] = pub
+
+	return pub, nil
+}
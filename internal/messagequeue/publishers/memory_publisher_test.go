@@ -0,0 +1,143 @@
+package publishers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+type mockMessageRouter struct {
+	mock.Mock
+}
+
+func (m *mockMessageRouter) Route(ctx context.Context, topic string, payload []byte) error {
+	return m.Called(ctx, topic, payload).Error(0)
+}
+
+func Test_inMemoryPublisher_Publish(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+		mmr := &mockMessageRouter{}
+		actual := provideInMemoryPublisher(logger, mmr, t.Name())
+
+		ctx := context.Background()
+		inputData := &struct {
+			Name string `json:"name"`
+		}{
+			Name: t.Name(),
+		}
+
+		mmr.On(
+			"Route",
+			testutils.ContextMatcher,
+			actual.topic,
+			[]byte(fmt.Sprintf(`{"name":%q}%s`, t.Name(), string(byte(10)))),
+		).Return(nil)
+
+		assert.NoError(t, actual.Publish(ctx, inputData))
+
+		mock.AssertExpectationsForObjects(t, mmr)
+	})
+
+	T.Run("with error encoding value", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+		actual := provideInMemoryPublisher(logger, &mockMessageRouter{}, t.Name())
+
+		ctx := context.Background()
+		inputData := &struct {
+			Name json.Number `json:"name"`
+		}{
+			Name: json.Number(t.Name()),
+		}
+
+		assert.Error(t, actual.Publish(ctx, inputData))
+	})
+
+	T.Run("with error routing message", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+		mmr := &mockMessageRouter{}
+		actual := provideInMemoryPublisher(logger, mmr, t.Name())
+
+		ctx := context.Background()
+		inputData := &struct {
+			Name string `json:"name"`
+		}{
+			Name: t.Name(),
+		}
+
+		mmr.On(
+			"Route",
+			testutils.ContextMatcher,
+			actual.topic,
+			mock.IsType([]byte{}),
+		).Return(errors.New("blah"))
+
+		assert.Error(t, actual.Publish(ctx, inputData))
+
+		mock.AssertExpectationsForObjects(t, mmr)
+	})
+}
+
+func TestProvideInMemoryPublisherProvider(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+
+		actual := ProvideInMemoryPublisherProvider(logger, &mockMessageRouter{})
+		assert.NotNil(t, actual)
+	})
+}
+
+func Test_inMemoryPublisherProvider_ProviderPublisher(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+
+		provider := ProvideInMemoryPublisherProvider(logger, &mockMessageRouter{})
+		require.NotNil(t, provider)
+
+		actual, err := provider.ProviderPublisher(t.Name())
+		assert.NotNil(t, actual)
+		assert.NoError(t, err)
+	})
+
+	T.Run("with cache hit", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+
+		provider := ProvideInMemoryPublisherProvider(logger, &mockMessageRouter{})
+		require.NotNil(t, provider)
+
+		first, err := provider.ProviderPublisher(t.Name())
+		assert.NotNil(t, first)
+		assert.NoError(t, err)
+
+		second, err := provider.ProviderPublisher(t.Name())
+		assert.NoError(t, err)
+		assert.Same(t, first, second)
+	})
+}
@@ -0,0 +1,123 @@
+package publishers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+)
+
+const (
+	// redisStreamsPayloadKey is the stream entry field message payloads are stored under.
+	// this must match the key consumers read from.
+	redisStreamsPayloadKey = "payload"
+
+	// DefaultRedisStreamsMaxLength is roughly how many messages a stream retains when no limit is configured.
+	DefaultRedisStreamsMaxLength = 10000
+)
+
+type (
+	streamAdder interface {
+		XAdd(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd
+	}
+
+	redisStreamsPublisher struct {
+		tracer    tracing.Tracer
+		encoder   encoding.ClientEncoder
+		logger    logging.Logger
+		publisher streamAdder
+		topic     string
+		maxLength int64
+	}
+)
+
+// Publish appends a message to the topic's stream, where it remains until consumers acknowledge it.
+func (r *redisStreamsPublisher) Publish(ctx context.Context, data interface{}) error {
+	ctx, span := r.tracer.StartSpan(ctx)
+	defer span.End()
+
+	r.logger.Debug("publishing message")
+
+	var b bytes.Buffer
+	if err := r.encoder.Encode(ctx, &b, data); err != nil {
+		return observability.PrepareError(err, r.logger, span, "encoding topic message")
+	}
+
+	args := &redis.XAddArgs{
+		Stream: r.topic,
+		MaxLen: r.maxLength,
+		Approx: true,
+		Values: map[string]interface{}{
+			redisStreamsPayloadKey: b.String(),
+		},
+	}
+
+	if err := r.publisher.XAdd(ctx, args).Err(); err != nil {
+		return observability.PrepareError(err, r.logger, span, "publishing message")
+	}
+
+	return nil
+}
+
+// provideRedisStreamsPublisher provides a Redis Streams-backed Publisher.
+func provideRedisStreamsPublisher(logger logging.Logger, redisClient streamAdder, topic string, maxLength int64) *redisStreamsPublisher {
+	return &redisStreamsPublisher{
+		publisher: redisClient,
+		topic:     topic,
+		maxLength: maxLength,
+		encoder:   encoding.ProvideClientEncoder(logger, encoding.ContentTypeJSON),
+		logger:    logging.EnsureLogger(logger),
+		tracer:    tracing.NewTracer(fmt.Sprintf("%s_publisher", topic)),
+	}
+}
+
+type redisStreamsPublisherProvider struct {
+	logger            logging.Logger
+	publisherCache    map[string]Publisher
+	redisClient       *redis.Client
+	maxLength         int64
+	publisherCacheHat sync.RWMutex
+}
+
+// ProvideRedisStreamsPublisherProvider returns a PublisherProvider backed by Redis Streams for a given address.
+func ProvideRedisStreamsPublisherProvider(logger logging.Logger, address string, maxLength int64) PublisherProvider {
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     address,
+		Password: "", // no password set
+		DB:       0,  // use default DB
+	})
+
+	if maxLength == 0 {
+		maxLength = DefaultRedisStreamsMaxLength
+	}
+
+	return &redisStreamsPublisherProvider{
+		logger:         logging.EnsureLogger(logger),
+		redisClient:    redisClient,
+		maxLength:      maxLength,
+		publisherCache: map[string]Publisher{},
+	}
+}
+
+// ProviderPublisher returns a Publisher for a given topic.
+func (p *redisStreamsPublisherProvider) ProviderPublisher(topic string) (Publisher, error) {
+	logger := logging.EnsureLogger(p.logger).WithValue("topic", topic)
+
+	p.publisherCacheHat.Lock()
+	defer p.publisherCacheHat.Unlock()
+	if cachedPub, ok := p.publisherCache[topic]; ok {
+		return cachedPub, nil
+	}
+
+	pub := provideRedisStreamsPublisher(logger, p.redisClient, topic, p.maxLength)
+	p.publisherCache[topic] = pub
+
+	return pub, nil
+}
@@ -0,0 +1,149 @@
+package publishers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+type mockStreamAdder struct {
+	mock.Mock
+}
+
+func (m *mockStreamAdder) XAdd(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd {
+	return m.Called(ctx, a).Get(0).(*redis.StringCmd)
+}
+
+func Test_redisStreamsPublisher_Publish(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+		msa := &mockStreamAdder{}
+		actual := provideRedisStreamsPublisher(logger, msa, t.Name(), DefaultRedisStreamsMaxLength)
+
+		ctx := context.Background()
+		inputData := &struct {
+			Name string `json:"name"`
+		}{
+			Name: t.Name(),
+		}
+
+		msa.On(
+			"XAdd",
+			testutils.ContextMatcher,
+			&redis.XAddArgs{
+				Stream: actual.topic,
+				MaxLen: DefaultRedisStreamsMaxLength,
+				Approx: true,
+				Values: map[string]interface{}{
+					redisStreamsPayloadKey: fmt.Sprintf(`{"name":%q}%s`, t.Name(), string(byte(10))),
+				},
+			},
+		).Return(redis.NewStringResult("1-0", nil))
+
+		assert.NoError(t, actual.Publish(ctx, inputData))
+
+		mock.AssertExpectationsForObjects(t, msa)
+	})
+
+	T.Run("with error encoding value", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+		actual := provideRedisStreamsPublisher(logger, &mockStreamAdder{}, t.Name(), DefaultRedisStreamsMaxLength)
+
+		ctx := context.Background()
+		inputData := &struct {
+			Name json.Number `json:"name"`
+		}{
+			Name: json.Number(t.Name()),
+		}
+
+		assert.Error(t, actual.Publish(ctx, inputData))
+	})
+
+	T.Run("with error adding to stream", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+		msa := &mockStreamAdder{}
+		actual := provideRedisStreamsPublisher(logger, msa, t.Name(), DefaultRedisStreamsMaxLength)
+
+		ctx := context.Background()
+		inputData := &struct {
+			Name string `json:"name"`
+		}{
+			Name: t.Name(),
+		}
+
+		msa.On(
+			"XAdd",
+			testutils.ContextMatcher,
+			mock.IsType(&redis.XAddArgs{}),
+		).Return(redis.NewStringResult("", errors.New("blah")))
+
+		assert.Error(t, actual.Publish(ctx, inputData))
+
+		mock.AssertExpectationsForObjects(t, msa)
+	})
+}
+
+func TestProvideRedisStreamsPublisherProvider(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+
+		actual := ProvideRedisStreamsPublisherProvider(logger, t.Name(), 0)
+		assert.NotNil(t, actual)
+	})
+}
+
+func Test_redisStreamsPublisherProvider_ProviderPublisher(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+
+		provider := ProvideRedisStreamsPublisherProvider(logger, t.Name(), 0)
+		require.NotNil(t, provider)
+
+		actual, err := provider.ProviderPublisher(t.Name())
+		assert.NotNil(t, actual)
+		assert.NoError(t, err)
+	})
+
+	T.Run("with cache hit", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+
+		provider := ProvideRedisStreamsPublisherProvider(logger, t.Name(), 0)
+		require.NotNil(t, provider)
+
+		first, err := provider.ProviderPublisher(t.Name())
+		assert.NotNil(t, first)
+		assert.NoError(t, err)
+
+		second, err := provider.ProviderPublisher(t.Name())
+		assert.NoError(t, err)
+		assert.Same(t, first, second)
+	})
+}
@@ -43,6 +43,24 @@
 	APIClientDatabaseIDKey = "api_client.id"
 	// WebhookIDKey is the standard key for referring to a webhook's ID.
 	WebhookIDKey = "webhook.id"
+	// WebhookDeliveryAttemptIDKey is the standard key for referring to a webhook delivery attempt's ID.
+	WebhookDeliveryAttemptIDKey = "webhook_delivery_attempt.id"
+	// NotificationIDKey is the standard key for referring to a notification's ID.
+	NotificationIDKey = "notification.id"
+	// AccountRoleIDKey is the standard key for referring to an account role's ID.
+	AccountRoleIDKey = "account_role.id"
+	// AccountInvitationIDKey is the standard key for referring to an account invitation's ID.
+	AccountInvitationIDKey = "account_invitation.id"
+	// UserSessionIDKey is the standard key for referring to a user session's ID.
+	UserSessionIDKey = "user_session.id"
+	// WebAuthnCredentialIDKey is the standard key for referring to a WebAuthn credential's ID.
+	WebAuthnCredentialIDKey = "webauthn_credential.id"
+	// OIDCIdentityIDKey is the standard key for referring to an OIDC identity's ID.
+	OIDCIdentityIDKey = "oidc_identity.id"
+	// AuditLogEntryEventTypeKey is the standard key for referring to an audit log entry's event type.
+	AuditLogEntryEventTypeKey = "audit_log_entry.event_type"
+	// PasswordResetTokenIDKey is the standard key for referring to a password reset token's ID.
+	PasswordResetTokenIDKey = "password_reset_token.id"
 	// URLKey is the standard key for referring to a url.
 	URLKey = "url"
 	// RequestHeadersKey is the standard key for referring to an http.Request's Headers.
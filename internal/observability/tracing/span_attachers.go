@@ -115,6 +115,36 @@ func AttachWebhookIDToSpan(span trace.Span, webhookID string) {
 	attachStringToSpan(span, keys.WebhookIDKey, webhookID)
 }
 
+// AttachWebhookDeliveryAttemptIDToSpan provides a consistent way to attach a webhook delivery attempt's ID to a span.
+func AttachWebhookDeliveryAttemptIDToSpan(span trace.Span, attemptID string) {
+	attachStringToSpan(span, keys.WebhookDeliveryAttemptIDKey, attemptID)
+}
+
+// AttachNotificationIDToSpan provides a consistent way to attach a notification's ID to a span.
+func AttachNotificationIDToSpan(span trace.Span, notificationID string) {
+	attachStringToSpan(span, keys.NotificationIDKey, notificationID)
+}
+
+// AttachAccountRoleIDToSpan provides a consistent way to attach an account role's ID to a span.
+func AttachAccountRoleIDToSpan(span trace.Span, accountRoleID string) {
+	attachStringToSpan(span, keys.AccountRoleIDKey, accountRoleID)
+}
+
+// AttachAccountInvitationIDToSpan provides a consistent way to attach an account invitation's ID to a span.
+func AttachAccountInvitationIDToSpan(span trace.Span, accountInvitationID string) {
+	attachStringToSpan(span, keys.AccountInvitationIDKey, accountInvitationID)
+}
+
+// AttachUserSessionIDToSpan provides a consistent way to attach a user session's ID to a span.
+func AttachUserSessionIDToSpan(span trace.Span, userSessionID string) {
+	attachStringToSpan(span, keys.UserSessionIDKey, userSessionID)
+}
+
+// AttachWebAuthnCredentialIDToSpan provides a consistent way to attach a WebAuthn credential's ID to a span.
+func AttachWebAuthnCredentialIDToSpan(span trace.Span, webAuthnCredentialID string) {
+	attachStringToSpan(span, keys.WebAuthnCredentialIDKey, webAuthnCredentialID)
+}
+
 // AttachURLToSpan attaches a given URI to a span.
 func AttachURLToSpan(span trace.Span, u *url.URL) {
 	attachStringToSpan(span, keys.RequestURIKey, u.String())
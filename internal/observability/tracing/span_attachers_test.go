@@ -215,6 +215,78 @@ func TestAttachWebhookIDToSpan(T *testing.T) {
 	})
 }
 
+func TestAttachWebhookDeliveryAttemptIDToSpan(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		_, span := StartSpan(context.Background())
+
+		AttachWebhookDeliveryAttemptIDToSpan(span, "123")
+	})
+}
+
+func TestAttachNotificationIDToSpan(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		_, span := StartSpan(context.Background())
+
+		AttachNotificationIDToSpan(span, "123")
+	})
+}
+
+func TestAttachAccountRoleIDToSpan(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		_, span := StartSpan(context.Background())
+
+		AttachAccountRoleIDToSpan(span, "123")
+	})
+}
+
+func TestAttachAccountInvitationIDToSpan(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		_, span := StartSpan(context.Background())
+
+		AttachAccountInvitationIDToSpan(span, "123")
+	})
+}
+
+func TestAttachUserSessionIDToSpan(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		_, span := StartSpan(context.Background())
+
+		AttachUserSessionIDToSpan(span, "123")
+	})
+}
+
+func TestAttachWebAuthnCredentialIDToSpan(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		_, span := StartSpan(context.Background())
+
+		AttachWebAuthnCredentialIDToSpan(span, "123")
+	})
+}
+
 func TestAttachURLToSpan(T *testing.T) {
 	T.Parallel()
 
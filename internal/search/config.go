@@ -9,6 +9,10 @@
 const (
 	// ElasticsearchProvider represents the elasticsearch search index provider.
 	ElasticsearchProvider = "elasticsearch"
+	// EmbeddedProvider represents the embedded search index provider, which runs in-process.
+	EmbeddedProvider = "embedded"
+	// DatabaseProvider represents the search index provider backed by the primary database's full-text search.
+	DatabaseProvider = "database"
 )
 
 // Config contains settings regarding search indices.
@@ -23,6 +27,6 @@ type Config struct {
 // ValidateWithContext validates a Config struct.
 func (cfg *Config) ValidateWithContext(ctx context.Context) error {
 	return validation.ValidateStructWithContext(ctx, cfg,
-		validation.Field(&cfg.Provider, validation.In(ElasticsearchProvider)),
+		validation.Field(&cfg.Provider, validation.In(ElasticsearchProvider, EmbeddedProvider, DatabaseProvider)),
 	)
 }
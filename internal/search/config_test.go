@@ -20,4 +20,36 @@ func TestConfig_ValidateWithContext(T *testing.T) {
 
 		assert.NoError(t, cfg.ValidateWithContext(ctx))
 	})
+	T.Run("with embedded provider", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		cfg := &Config{
+			Provider: EmbeddedProvider,
+		}
+
+		assert.NoError(t, cfg.ValidateWithContext(ctx))
+	})
+
+	T.Run("with database provider", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		cfg := &Config{
+			Provider: DatabaseProvider,
+		}
+
+		assert.NoError(t, cfg.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid provider", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		cfg := &Config{
+			Provider: t.Name(),
+		}
+
+		assert.Error(t, cfg.ValidateWithContext(ctx))
+	})
 }
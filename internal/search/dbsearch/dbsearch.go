@@ -0,0 +1,133 @@
+package dbsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	itemsIndexName = "items"
+)
+
+var (
+	_ search.IndexManager = (*indexManager)(nil)
+
+	// ErrEmptyQueryProvided indicates an empty query was provided as input.
+	ErrEmptyQueryProvided = errors.New("empty search query provided")
+
+	// ErrUnsupportedIndex indicates an index was requested for data the database can't search.
+	ErrUnsupportedIndex = errors.New("unsupported index")
+)
+
+type indexManager struct {
+	logger          logging.Logger
+	tracer          tracing.Tracer
+	itemDataManager types.ItemDataManager
+}
+
+// ProvideIndexManagerProvider provides an IndexManagerProvider whose indices query the database directly.
+func ProvideIndexManagerProvider(itemDataManager types.ItemDataManager) search.IndexManagerProvider {
+	return func(_ context.Context, logger logging.Logger, _ *http.Client, _ search.IndexPath, name search.IndexName, _ ...string) (search.IndexManager, error) {
+		if name != itemsIndexName {
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedIndex, name)
+		}
+
+		return &indexManager{
+			logger:          logging.EnsureLogger(logger).WithName("search"),
+			tracer:          tracing.NewTracer("search"),
+			itemDataManager: itemDataManager,
+		}, nil
+	}
+}
+
+// Index implements our IndexManager interface. Items are searchable as soon as they're written
+// to the database, so there's nothing to do here.
+func (sm *indexManager) Index(ctx context.Context, id string, _ interface{}) error {
+	_, span := sm.tracer.StartSpan(ctx)
+	defer span.End()
+
+	sm.logger.WithValue("id", id).Debug("database index is maintained by the write path")
+
+	return nil
+}
+
+// buildResults windows ids, which the database returns in relevance order, into search results.
+// The database doesn't report how relevant each match is, so results carry no score.
+func buildResults(ids []string, filter *types.QueryFilter) []*search.Result {
+	offset, limit := search.ResultWindow(filter)
+	if offset > len(ids) {
+		offset = len(ids)
+	}
+	if offset+limit < len(ids) {
+		ids = ids[offset : offset+limit]
+	} else {
+		ids = ids[offset:]
+	}
+
+	results := []*search.Result{}
+	for _, id := range ids {
+		results = append(results, &search.Result{ID: id})
+	}
+
+	return results
+}
+
+// Search implements our IndexManager interface.
+func (sm *indexManager) Search(ctx context.Context, query, accountID string, filter *types.QueryFilter) ([]*search.Result, error) {
+	ctx, span := sm.tracer.StartSpan(ctx)
+	defer span.End()
+
+	tracing.AttachSearchQueryToSpan(span, query)
+	logger := sm.logger.WithValue(keys.SearchQueryKey, query)
+
+	if query == "" {
+		return nil, ErrEmptyQueryProvided
+	}
+
+	ids, err := sm.itemDataManager.SearchItemIDs(ctx, query, accountID)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "searching database")
+	}
+
+	return buildResults(ids, filter), nil
+}
+
+// SearchForAdmin implements our IndexManager interface.
+func (sm *indexManager) SearchForAdmin(ctx context.Context, query string, filter *types.QueryFilter) ([]*search.Result, error) {
+	ctx, span := sm.tracer.StartSpan(ctx)
+	defer span.End()
+
+	tracing.AttachSearchQueryToSpan(span, query)
+	logger := sm.logger.WithValue(keys.SearchQueryKey, query)
+
+	if query == "" {
+		return nil, ErrEmptyQueryProvided
+	}
+
+	ids, err := sm.itemDataManager.SearchItemIDsForAdmin(ctx, query)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "searching database")
+	}
+
+	return buildResults(ids, filter), nil
+}
+
+// Delete implements our IndexManager interface. Archived items are excluded from search
+// results by the database, so there's nothing to do here.
+func (sm *indexManager) Delete(ctx context.Context, id string) error {
+	_, span := sm.tracer.StartSpan(ctx)
+	defer span.End()
+
+	sm.logger.WithValue("id", id).Debug("database index is maintained by the write path")
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package dbsearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+func buildTestIndexManager(t *testing.T, itemDataManager *mocktypes.ItemDataManager) search.IndexManager {
+	t.Helper()
+
+	ctx := context.Background()
+	logger := logging.NewNoopLogger()
+
+	im, err := ProvideIndexManagerProvider(itemDataManager)(ctx, logger, &http.Client{}, "", itemsIndexName, "name", "details")
+	require.NoError(t, err)
+	require.NotNil(t, im)
+
+	return im
+}
+
+func TestProvideIndexManagerProvider(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		buildTestIndexManager(t, &mocktypes.ItemDataManager{})
+	})
+
+	T.Run("with unsupported index", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+
+		im, err := ProvideIndexManagerProvider(&mocktypes.ItemDataManager{})(ctx, logger, &http.Client{}, "", search.IndexName(t.Name()))
+		assert.ErrorIs(t, err, ErrUnsupportedIndex)
+		assert.Nil(t, im)
+	})
+}
+
+func Test_indexManager_Index(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		itemDataManager := &mocktypes.ItemDataManager{}
+		im := buildTestIndexManager(t, itemDataManager)
+
+		assert.NoError(t, im.Index(ctx, t.Name(), struct{}{}))
+
+		mock.AssertExpectationsForObjects(t, itemDataManager)
+	})
+}
+
+func Test_indexManager_Search(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		exampleQuery := "example"
+		exampleAccountID := "account"
+		expected := []*search.Result{{ID: "a"}, {ID: "b"}}
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("SearchItemIDs", testutils.ContextMatcher, exampleQuery, exampleAccountID).Return([]string{"a", "b"}, nil)
+
+		im := buildTestIndexManager(t, itemDataManager)
+
+		actual, err := im.Search(ctx, exampleQuery, exampleAccountID, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager)
+	})
+
+	T.Run("with pagination", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		exampleQuery := "example"
+		exampleAccountID := "account"
+		expected := []*search.Result{{ID: "c"}}
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("SearchItemIDs", testutils.ContextMatcher, exampleQuery, exampleAccountID).Return([]string{"a", "b", "c"}, nil)
+
+		im := buildTestIndexManager(t, itemDataManager)
+
+		actual, err := im.Search(ctx, exampleQuery, exampleAccountID, &types.QueryFilter{Page: 2, Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager)
+	})
+
+	T.Run("with empty query", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		itemDataManager := &mocktypes.ItemDataManager{}
+		im := buildTestIndexManager(t, itemDataManager)
+
+		actual, err := im.Search(ctx, "", "account", nil)
+		assert.ErrorIs(t, err, ErrEmptyQueryProvided)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager)
+	})
+
+	T.Run("with error searching database", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		exampleQuery := "example"
+		exampleAccountID := "account"
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("SearchItemIDs", testutils.ContextMatcher, exampleQuery, exampleAccountID).Return([]string(nil), errors.New("blah"))
+
+		im := buildTestIndexManager(t, itemDataManager)
+
+		actual, err := im.Search(ctx, exampleQuery, exampleAccountID, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager)
+	})
+}
+
+func Test_indexManager_SearchForAdmin(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		exampleQuery := "example"
+		expected := []*search.Result{{ID: "a"}, {ID: "b"}}
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("SearchItemIDsForAdmin", testutils.ContextMatcher, exampleQuery).Return([]string{"a", "b"}, nil)
+
+		im := buildTestIndexManager(t, itemDataManager)
+
+		actual, err := im.SearchForAdmin(ctx, exampleQuery, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager)
+	})
+
+	T.Run("with empty query", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		itemDataManager := &mocktypes.ItemDataManager{}
+		im := buildTestIndexManager(t, itemDataManager)
+
+		actual, err := im.SearchForAdmin(ctx, "", nil)
+		assert.ErrorIs(t, err, ErrEmptyQueryProvided)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager)
+	})
+
+	T.Run("with error searching database", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		exampleQuery := "example"
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("SearchItemIDsForAdmin", testutils.ContextMatcher, exampleQuery).Return([]string(nil), errors.New("blah"))
+
+		im := buildTestIndexManager(t, itemDataManager)
+
+		actual, err := im.SearchForAdmin(ctx, exampleQuery, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager)
+	})
+}
+
+func Test_indexManager_Delete(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		itemDataManager := &mocktypes.ItemDataManager{}
+		im := buildTestIndexManager(t, itemDataManager)
+
+		assert.NoError(t, im.Delete(ctx, t.Name()))
+
+		mock.AssertExpectationsForObjects(t, itemDataManager)
+	})
+}
@@ -0,0 +1,4 @@
+/*
+Package dbsearch provides a search index backed by the full-text search capabilities of the primary database
+*/
+package dbsearch
@@ -4,7 +4,9 @@
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
+	"sort"
 
 	"github.com/olivere/elastic/v7"
 
@@ -13,9 +15,14 @@
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
-var _ search.IndexManager = (*indexManager)(nil)
+var (
+	_ search.IndexManager = (*indexManager)(nil)
+	_ search.IDLister     = (*indexManager)(nil)
+	_ search.AliasSwapper = (*indexManager)(nil)
+)
 
 type (
 	esClient interface {
@@ -24,6 +31,10 @@
 		Search(indices ...string) *elastic.SearchService
 		Index() *elastic.IndexService
 		DeleteByQuery(indices ...string) *elastic.DeleteByQueryService
+		Scroll(indices ...string) *elastic.ScrollService
+		Aliases() *elastic.AliasesService
+		Alias() *elastic.AliasService
+		DeleteIndex(indices ...string) *elastic.IndicesDeleteService
 	}
 
 	indexManager struct {
@@ -76,19 +87,46 @@ func NewIndexManager(
 
 const (
 	itemsIndexName = "items"
+
+	// accountIDField is the field of indexed values that search results are scoped by.
+	accountIDField = "belongsToAccount"
+	// idField is the field of indexed values that identifies them.
+	idField = "id"
+
+	// scrollSize is how many documents are fetched at a time when listing an index's contents.
+	scrollSize = 1000
 )
 
+// mapping describes how the index's fields are stored. Identifiers are keywords so that
+// they're matched exactly rather than analyzed like free text.
+func (sm *indexManager) mapping() map[string]interface{} {
+	properties := map[string]interface{}{
+		idField:        map[string]interface{}{"type": "keyword"},
+		accountIDField: map[string]interface{}{"type": "keyword"},
+	}
+
+	for _, field := range sm.searchFields {
+		properties[field] = map[string]interface{}{"type": "text"}
+	}
+
+	return map[string]interface{}{
+		"mappings": map[string]interface{}{
+			"properties": properties,
+		},
+	}
+}
+
 func (sm *indexManager) ensureIndices(ctx context.Context) error {
 	_, span := sm.tracer.StartSpan(ctx)
 	defer span.End()
 
-	indexExists, err := sm.esclient.IndexExists(itemsIndexName).Do(ctx)
+	indexExists, err := sm.esclient.IndexExists(sm.indexName).Do(ctx)
 	if err != nil {
 		return err
 	}
 
 	if !indexExists {
-		_, err = sm.esclient.CreateIndex(itemsIndexName).Do(ctx)
+		_, err = sm.esclient.CreateIndex(sm.indexName).BodyJson(sm.mapping()).Do(ctx)
 		if err != nil {
 			return err
 		}
@@ -126,7 +164,8 @@ func (sm *indexManager) search(
 	ctx context.Context,
 	query,
 	accountID string,
-) (ids []string, err error) {
+	filter *types.QueryFilter,
+) (results []*search.Result, err error) {
 	_, span := sm.tracer.StartSpan(ctx)
 	defer span.End()
 
@@ -137,41 +176,60 @@ func (sm *indexManager) search(
 		return nil, ErrEmptyQueryProvided
 	}
 
-	baseQuery := elastic.NewMultiMatchQuery(query, sm.searchFields...)
+	offset, limit := search.ResultWindow(filter)
+
+	q := elastic.NewBoolQuery().Must(elastic.NewMultiMatchQuery(query, sm.searchFields...).Fuzziness("AUTO"))
+	if accountID != "" {
+		// filter clauses must match, but don't contribute to scoring.
+		q = q.Filter(elastic.NewTermQuery(accountIDField, accountID))
+	}
 
-	var q elastic.Query
-	if accountID == "" {
-		q = baseQuery
-	} else {
-		accountIDMatchQuery := elastic.NewMatchQuery("accountID", accountID)
-		q = elastic.NewBoolQuery().Should(accountIDMatchQuery).Should(baseQuery)
+	highlight := elastic.NewHighlight()
+	for _, field := range sm.searchFields {
+		highlight = highlight.Field(field)
 	}
 
-	results, err := sm.esclient.Search().Index(sm.indexName).Query(q).Do(ctx)
+	searchResults, err := sm.esclient.Search().
+		Index(sm.indexName).
+		Query(q).
+		Highlight(highlight).
+		From(offset).
+		Size(limit).
+		Do(ctx)
 	if err != nil {
 		return nil, observability.PrepareError(err, logger, span, "querying elasticsearch")
 	}
 
-	returnedItems := []string{}
-	for _, hit := range results.Hits.Hits {
+	results = []*search.Result{}
+	for _, hit := range searchResults.Hits.Hits {
 		var i *idContainer
 		if unmarshalErr := json.Unmarshal(hit.Source, &i); unmarshalErr != nil {
-			return nil, observability.PrepareError(err, logger, span, "unmarshalling search result")
+			return nil, observability.PrepareError(unmarshalErr, logger, span, "unmarshalling search result")
 		}
-		returnedItems = append(returnedItems, i.ID)
+
+		result := &search.Result{
+			ID:         i.ID,
+			Highlights: hit.Highlight,
+		}
+
+		if hit.Score != nil {
+			result.Score = *hit.Score
+		}
+
+		results = append(results, result)
 	}
 
-	return returnedItems, nil
+	return results, nil
 }
 
 // Search implements our IndexManager interface.
-func (sm *indexManager) Search(ctx context.Context, query, accountID string) (ids []string, err error) {
-	return sm.search(ctx, query, accountID)
+func (sm *indexManager) Search(ctx context.Context, query, accountID string, filter *types.QueryFilter) (results []*search.Result, err error) {
+	return sm.search(ctx, query, accountID, filter)
 }
 
 // SearchForAdmin implements our IndexManager interface.
-func (sm *indexManager) SearchForAdmin(ctx context.Context, query string) (ids []string, err error) {
-	return sm.search(ctx, query, "")
+func (sm *indexManager) SearchForAdmin(ctx context.Context, query string, filter *types.QueryFilter) (results []*search.Result, err error) {
+	return sm.search(ctx, query, "", filter)
 }
 
 // Delete implements our IndexManager interface.
@@ -181,7 +239,7 @@ func (sm *indexManager) Delete(ctx context.Context, id string) error {
 
 	logger := sm.logger.WithValue("id", id)
 
-	q := elastic.NewTermQuery("id", id)
+	q := elastic.NewTermQuery(idField, id)
 	if _, err := sm.esclient.DeleteByQuery(sm.indexName).Query(q).Do(ctx); err != nil {
 		return observability.PrepareError(err, logger, span, "deleting from elasticsearch")
 	}
@@ -190,3 +248,78 @@ func (sm *indexManager) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// IndexedIDs implements our IDLister interface.
+func (sm *indexManager) IndexedIDs(ctx context.Context) ([]string, error) {
+	_, span := sm.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := sm.logger
+
+	scroll := sm.esclient.Scroll(sm.indexName).Size(scrollSize).FetchSource(false)
+	defer func() {
+		if err := scroll.Clear(ctx); err != nil {
+			observability.AcknowledgeError(err, logger, span, "clearing scroll")
+		}
+	}()
+
+	ids := []string{}
+	for {
+		res, err := scroll.Do(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, observability.PrepareError(err, logger, span, "scrolling through elasticsearch")
+		}
+
+		for _, hit := range res.Hits.Hits {
+			ids = append(ids, hit.Id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// SwapAlias implements our AliasSwapper interface. This index's name becomes an alias of the target
+// index, and whatever the name previously referred to is deleted.
+func (sm *indexManager) SwapAlias(ctx context.Context, target search.IndexName) error {
+	_, span := sm.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := sm.logger.WithValue("target", target)
+
+	current, err := sm.esclient.Aliases().Index(sm.indexName).Do(ctx)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "fetching current aliases")
+	}
+
+	actions := []elastic.AliasAction{elastic.NewAliasAddAction(sm.indexName).Index(string(target))}
+	staleIndices := []string{}
+
+	for index := range current.Indices {
+		if index == sm.indexName {
+			// the name refers to a concrete index, which has to go before the alias can take its name.
+			actions = append(actions, elastic.NewAliasRemoveIndexAction(index))
+		} else if index != string(target) {
+			actions = append(actions, elastic.NewAliasRemoveAction(sm.indexName).Index(index))
+			staleIndices = append(staleIndices, index)
+		}
+	}
+
+	// alias actions are applied atomically, so searches never see a missing index.
+	if _, err = sm.esclient.Alias().Action(actions...).Do(ctx); err != nil {
+		return observability.PrepareError(err, logger, span, "updating aliases")
+	}
+
+	if len(staleIndices) > 0 {
+		if _, err = sm.esclient.DeleteIndex(staleIndices...).Do(ctx); err != nil {
+			return observability.PrepareError(err, logger, span, "deleting stale indices")
+		}
+	}
+
+	logger.Debug("swapped alias")
+
+	return nil
+}
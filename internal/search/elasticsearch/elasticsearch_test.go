@@ -16,6 +16,8 @@
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
 type mockESClient struct {
@@ -42,6 +44,22 @@ func (m *mockESClient) DeleteByQuery(indices ...string) *elastic.DeleteByQuerySe
 	return m.Called(indices).Get(0).(*elastic.DeleteByQueryService)
 }
 
+func (m *mockESClient) Scroll(indices ...string) *elastic.ScrollService {
+	return m.Called(indices).Get(0).(*elastic.ScrollService)
+}
+
+func (m *mockESClient) Aliases() *elastic.AliasesService {
+	return m.Called().Get(0).(*elastic.AliasesService)
+}
+
+func (m *mockESClient) Alias() *elastic.AliasService {
+	return m.Called().Get(0).(*elastic.AliasService)
+}
+
+func (m *mockESClient) DeleteIndex(indices ...string) *elastic.IndicesDeleteService {
+	return m.Called(indices).Get(0).(*elastic.IndicesDeleteService)
+}
+
 func TestNewIndexManager(T *testing.T) {
 	T.Parallel()
 
@@ -80,9 +98,10 @@ func(res http.ResponseWriter, req *http.Request) {
 		esc.On("IndexExists", []string{itemsIndexName}).Return(indicesExistsService)
 
 		im := &indexManager{
-			esclient: esc,
-			tracer:   tracing.NewTracer(t.Name()),
-			logger:   logger,
+			esclient:  esc,
+			indexName: itemsIndexName,
+			tracer:    tracing.NewTracer(t.Name()),
+			logger:    logger,
 		}
 		assert.NoError(t, im.ensureIndices(ctx))
 
@@ -124,12 +143,68 @@ func(res http.ResponseWriter, req *http.Request) {
 		esc.On("CreateIndex", itemsIndexName).Return(indicesCreateService)
 
 		im := &indexManager{
-			esclient: esc,
-			tracer:   tracing.NewTracer(t.Name()),
-			logger:   logger,
+			esclient:  esc,
+			indexName: itemsIndexName,
+			tracer:    tracing.NewTracer(t.Name()),
+			logger:    logger,
+		}
+		assert.NoError(t, im.ensureIndices(ctx))
+
+		mock.AssertExpectationsForObjects(t, esc)
+	})
+
+	T.Run("creates index with mapping", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewZerologLogger()
+
+		var requestBody map[string]interface{}
+		ts := httptest.NewTLSServer(http.HandlerFunc(
+			func(res http.ResponseWriter, req *http.Request) {
+				if req.Method == http.MethodHead {
+					res.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&requestBody))
+
+				_, err := res.Write([]byte(`{}`))
+				require.NoError(t, err)
+			},
+		))
+
+		client, err := elastic.NewSimpleClient(
+			elastic.SetHttpClient(ts.Client()),
+			elastic.SetURL(ts.URL),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, client)
+
+		esc := &mockESClient{}
+		esc.On("IndexExists", []string{itemsIndexName}).Return(elastic.NewIndicesExistsService(client).Index([]string{itemsIndexName}))
+		esc.On("CreateIndex", itemsIndexName).Return(elastic.NewIndicesCreateService(client).Index(itemsIndexName))
+
+		im := &indexManager{
+			esclient:     esc,
+			indexName:    itemsIndexName,
+			searchFields: []string{"name"},
+			tracer:       tracing.NewTracer(t.Name()),
+			logger:       logger,
 		}
 		assert.NoError(t, im.ensureIndices(ctx))
 
+		expected := map[string]interface{}{
+			"mappings": map[string]interface{}{
+				"properties": map[string]interface{}{
+					idField:        map[string]interface{}{"type": "keyword"},
+					accountIDField: map[string]interface{}{"type": "keyword"},
+					"name":         map[string]interface{}{"type": "text"},
+				},
+			},
+		}
+		assert.Equal(t, expected, requestBody)
+
 		mock.AssertExpectationsForObjects(t, esc)
 	})
 
@@ -158,9 +233,10 @@ func(res http.ResponseWriter, req *http.Request) {
 		esc.On("IndexExists", []string{itemsIndexName}).Return(indicesExistsService)
 
 		im := &indexManager{
-			esclient: esc,
-			tracer:   tracing.NewTracer(t.Name()),
-			logger:   logger,
+			esclient:  esc,
+			indexName: itemsIndexName,
+			tracer:    tracing.NewTracer(t.Name()),
+			logger:    logger,
 		}
 		assert.Error(t, im.ensureIndices(ctx))
 
@@ -199,9 +275,10 @@ func(res http.ResponseWriter, req *http.Request) {
 		esc.On("CreateIndex", itemsIndexName).Return(indicesCreateService)
 
 		im := &indexManager{
-			esclient: esc,
-			tracer:   tracing.NewTracer(t.Name()),
-			logger:   logger,
+			esclient:  esc,
+			indexName: itemsIndexName,
+			tracer:    tracing.NewTracer(t.Name()),
+			logger:    logger,
 		}
 		assert.Error(t, im.ensureIndices(ctx))
 
@@ -335,13 +412,96 @@ func(res http.ResponseWriter, req *http.Request) {
 			logger:    logger,
 		}
 
-		results, err := im.search(ctx, t.Name(), t.Name())
+		results, err := im.search(ctx, t.Name(), t.Name(), nil)
 		assert.NotNil(t, results)
 		assert.NoError(t, err)
 
 		mock.AssertExpectationsForObjects(t, esc)
 	})
 
+	T.Run("scopes results to account with a filter clause", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewZerologLogger()
+		exampleAccountID := "account"
+		exampleScore := 1.5
+		exampleHighlights := map[string][]string{"name": {"<em>example</em>"}}
+
+		var requestBody map[string]interface{}
+		ts := httptest.NewTLSServer(http.HandlerFunc(
+			func(res http.ResponseWriter, req *http.Request) {
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&requestBody))
+
+				results := &elastic.SearchResult{
+					Hits: &elastic.SearchHits{
+						Hits: []*elastic.SearchHit{
+							{
+								Source:    []byte(fmt.Sprintf(`{"id": %q}`, t.Name())),
+								Score:     &exampleScore,
+								Highlight: exampleHighlights,
+							},
+						},
+					},
+				}
+				output, err := json.Marshal(results)
+				require.NoError(t, err)
+
+				_, err = res.Write(output)
+				require.NoError(t, err)
+			},
+		))
+
+		client, err := elastic.NewSimpleClient(
+			elastic.SetHttpClient(ts.Client()),
+			elastic.SetURL(ts.URL),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, client)
+
+		esc := &mockESClient{}
+		esc.On("Search", []string(nil)).Return(elastic.NewSearchService(client))
+
+		im := &indexManager{
+			esclient:     esc,
+			indexName:    t.Name(),
+			searchFields: []string{"name", "details"},
+			tracer:       tracing.NewTracer(t.Name()),
+			logger:       logger,
+		}
+
+		filter := &types.QueryFilter{Page: 2, Limit: 5}
+
+		results, err := im.search(ctx, "example", exampleAccountID, filter)
+		assert.NoError(t, err)
+
+		expected := []*search.Result{
+			{
+				ID:         t.Name(),
+				Score:      exampleScore,
+				Highlights: exampleHighlights,
+			},
+		}
+		assert.Equal(t, expected, results)
+
+		assert.Equal(t, float64(5), requestBody["from"])
+		assert.Equal(t, float64(5), requestBody["size"])
+
+		boolQuery := requestBody["query"].(map[string]interface{})["bool"].(map[string]interface{})
+		assert.Nil(t, boolQuery["should"])
+		assert.Equal(
+			t,
+			map[string]interface{}{"term": map[string]interface{}{accountIDField: exampleAccountID}},
+			boolQuery["filter"],
+		)
+
+		multiMatch := boolQuery["must"].(map[string]interface{})["multi_match"].(map[string]interface{})
+		assert.Equal(t, "AUTO", multiMatch["fuzziness"])
+		assert.ElementsMatch(t, []interface{}{"name", "details"}, multiMatch["fields"])
+
+		mock.AssertExpectationsForObjects(t, esc)
+	})
+
 	T.Run("standard for admin", func(t *testing.T) {
 		t.Parallel()
 
@@ -388,15 +548,15 @@ func(res http.ResponseWriter, req *http.Request) {
 			logger:    logger,
 		}
 
-		results, err := im.search(ctx, t.Name(), "")
+		results, err := im.search(ctx, t.Name(), "", nil)
 		assert.NotNil(t, results)
 		assert.NoError(t, err)
 
-		results, err = im.Search(ctx, t.Name(), "")
+		results, err = im.Search(ctx, t.Name(), "", nil)
 		assert.NotNil(t, results)
 		assert.NoError(t, err)
 
-		results, err = im.SearchForAdmin(ctx, t.Name())
+		results, err = im.SearchForAdmin(ctx, t.Name(), nil)
 		assert.NotNil(t, results)
 		assert.NoError(t, err)
 
@@ -415,7 +575,7 @@ func(res http.ResponseWriter, req *http.Request) {
 			logger:    logger,
 		}
 
-		results, err := im.search(ctx, "", t.Name())
+		results, err := im.search(ctx, "", t.Name(), nil)
 		assert.Nil(t, results)
 		assert.Error(t, err)
 	})
@@ -451,7 +611,7 @@ func(res http.ResponseWriter, req *http.Request) {
 			logger:    logger,
 		}
 
-		results, err := im.search(ctx, t.Name(), t.Name())
+		results, err := im.search(ctx, t.Name(), t.Name(), nil)
 		assert.Nil(t, results)
 		assert.Error(t, err)
 
@@ -510,7 +670,7 @@ func(res http.ResponseWriter, req *http.Request) {
 			logger:    logger,
 		}
 
-		results, err := im.search(ctx, t.Name(), t.Name())
+		results, err := im.search(ctx, t.Name(), t.Name(), nil)
 		assert.Nil(t, results)
 		assert.Error(t, err)
 
@@ -567,7 +727,7 @@ func(res http.ResponseWriter, req *http.Request) {
 			logger:    logger,
 		}
 
-		results, err := im.Search(ctx, t.Name(), t.Name())
+		results, err := im.Search(ctx, t.Name(), t.Name(), nil)
 		assert.NotNil(t, results)
 		assert.NoError(t, err)
 
@@ -624,7 +784,7 @@ func(res http.ResponseWriter, req *http.Request) {
 			logger:    logger,
 		}
 
-		results, err := im.SearchForAdmin(ctx, t.Name())
+		results, err := im.SearchForAdmin(ctx, t.Name(), nil)
 		assert.NotNil(t, results)
 		assert.NoError(t, err)
 
@@ -708,3 +868,253 @@ func(res http.ResponseWriter, req *http.Request) {
 		mock.AssertExpectationsForObjects(t, esc)
 	})
 }
+
+func Test_indexManager_IndexedIDs(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewZerologLogger()
+
+		var scrolled bool
+		ts := httptest.NewTLSServer(http.HandlerFunc(
+			func(res http.ResponseWriter, req *http.Request) {
+				if req.Method == http.MethodDelete {
+					_, err := res.Write([]byte(`{"succeeded": true}`))
+					require.NoError(t, err)
+					return
+				}
+
+				hits := []*elastic.SearchHit{}
+				if !scrolled {
+					hits = append(hits, &elastic.SearchHit{Id: "b"}, &elastic.SearchHit{Id: "a"})
+					scrolled = true
+				}
+
+				output, err := json.Marshal(&elastic.SearchResult{
+					ScrollId: t.Name(),
+					Hits:     &elastic.SearchHits{Hits: hits},
+				})
+				require.NoError(t, err)
+
+				_, err = res.Write(output)
+				require.NoError(t, err)
+			},
+		))
+
+		client, err := elastic.NewSimpleClient(
+			elastic.SetHttpClient(ts.Client()),
+			elastic.SetURL(ts.URL),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, client)
+
+		esc := &mockESClient{}
+		esc.On("Scroll", []string{itemsIndexName}).Return(elastic.NewScrollService(client).Index(itemsIndexName))
+
+		im := &indexManager{
+			esclient:  esc,
+			indexName: itemsIndexName,
+			tracer:    tracing.NewTracer(t.Name()),
+			logger:    logger,
+		}
+
+		ids, err := im.IndexedIDs(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, ids)
+
+		mock.AssertExpectationsForObjects(t, esc)
+	})
+
+	T.Run("with error scrolling", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewZerologLogger()
+
+		ts := httptest.NewTLSServer(http.HandlerFunc(
+			func(res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusInternalServerError)
+			},
+		))
+
+		client, err := elastic.NewSimpleClient(
+			elastic.SetHttpClient(ts.Client()),
+			elastic.SetURL(ts.URL),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, client)
+
+		esc := &mockESClient{}
+		esc.On("Scroll", []string{itemsIndexName}).Return(elastic.NewScrollService(client).Index(itemsIndexName))
+
+		im := &indexManager{
+			esclient:  esc,
+			indexName: itemsIndexName,
+			tracer:    tracing.NewTracer(t.Name()),
+			logger:    logger,
+		}
+
+		ids, err := im.IndexedIDs(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, ids)
+
+		mock.AssertExpectationsForObjects(t, esc)
+	})
+}
+
+func Test_indexManager_SwapAlias(T *testing.T) {
+	T.Parallel()
+
+	T.Run("replacing a concrete index", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewZerologLogger()
+		exampleTarget := search.IndexName("items_123")
+
+		var aliasActions map[string]interface{}
+		ts := httptest.NewTLSServer(http.HandlerFunc(
+			func(res http.ResponseWriter, req *http.Request) {
+				switch req.Method {
+				case http.MethodGet:
+					_, err := res.Write([]byte(fmt.Sprintf(`{%q: {"aliases": {}}}`, itemsIndexName)))
+					require.NoError(t, err)
+				case http.MethodPost:
+					require.NoError(t, json.NewDecoder(req.Body).Decode(&aliasActions))
+					_, err := res.Write([]byte(`{"acknowledged": true}`))
+					require.NoError(t, err)
+				}
+			},
+		))
+
+		client, err := elastic.NewSimpleClient(
+			elastic.SetHttpClient(ts.Client()),
+			elastic.SetURL(ts.URL),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, client)
+
+		esc := &mockESClient{}
+		esc.On("Aliases").Return(elastic.NewAliasesService(client))
+		esc.On("Alias").Return(elastic.NewAliasService(client))
+
+		im := &indexManager{
+			esclient:  esc,
+			indexName: itemsIndexName,
+			tracer:    tracing.NewTracer(t.Name()),
+			logger:    logger,
+		}
+
+		assert.NoError(t, im.SwapAlias(ctx, exampleTarget))
+
+		expected := map[string]interface{}{
+			"actions": []interface{}{
+				map[string]interface{}{"add": map[string]interface{}{"alias": itemsIndexName, "index": string(exampleTarget)}},
+				map[string]interface{}{"remove_index": map[string]interface{}{"index": itemsIndexName}},
+			},
+		}
+		assert.Equal(t, expected, aliasActions)
+
+		mock.AssertExpectationsForObjects(t, esc)
+	})
+
+	T.Run("replacing a previously aliased index", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewZerologLogger()
+		exampleTarget := search.IndexName("items_456")
+		staleIndex := "items_123"
+
+		var (
+			aliasActions   map[string]interface{}
+			deletedIndices string
+		)
+		ts := httptest.NewTLSServer(http.HandlerFunc(
+			func(res http.ResponseWriter, req *http.Request) {
+				switch req.Method {
+				case http.MethodGet:
+					_, err := res.Write([]byte(fmt.Sprintf(`{%q: {"aliases": {%q: {}}}}`, staleIndex, itemsIndexName)))
+					require.NoError(t, err)
+				case http.MethodPost:
+					require.NoError(t, json.NewDecoder(req.Body).Decode(&aliasActions))
+					_, err := res.Write([]byte(`{"acknowledged": true}`))
+					require.NoError(t, err)
+				case http.MethodDelete:
+					deletedIndices = strings.TrimPrefix(req.URL.Path, "/")
+					_, err := res.Write([]byte(`{"acknowledged": true}`))
+					require.NoError(t, err)
+				}
+			},
+		))
+
+		client, err := elastic.NewSimpleClient(
+			elastic.SetHttpClient(ts.Client()),
+			elastic.SetURL(ts.URL),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, client)
+
+		esc := &mockESClient{}
+		esc.On("Aliases").Return(elastic.NewAliasesService(client))
+		esc.On("Alias").Return(elastic.NewAliasService(client))
+		esc.On("DeleteIndex", []string{staleIndex}).Return(elastic.NewIndicesDeleteService(client).Index([]string{staleIndex}))
+
+		im := &indexManager{
+			esclient:  esc,
+			indexName: itemsIndexName,
+			tracer:    tracing.NewTracer(t.Name()),
+			logger:    logger,
+		}
+
+		assert.NoError(t, im.SwapAlias(ctx, exampleTarget))
+
+		expected := map[string]interface{}{
+			"actions": []interface{}{
+				map[string]interface{}{"add": map[string]interface{}{"alias": itemsIndexName, "index": string(exampleTarget)}},
+				map[string]interface{}{"remove": map[string]interface{}{"alias": itemsIndexName, "index": staleIndex}},
+			},
+		}
+		assert.Equal(t, expected, aliasActions)
+		assert.Equal(t, staleIndex, deletedIndices)
+
+		mock.AssertExpectationsForObjects(t, esc)
+	})
+
+	T.Run("with error fetching aliases", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewZerologLogger()
+
+		ts := httptest.NewTLSServer(http.HandlerFunc(
+			func(res http.ResponseWriter, req *http.Request) {
+				res.WriteHeader(http.StatusInternalServerError)
+			},
+		))
+
+		client, err := elastic.NewSimpleClient(
+			elastic.SetHttpClient(ts.Client()),
+			elastic.SetURL(ts.URL),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, client)
+
+		esc := &mockESClient{}
+		esc.On("Aliases").Return(elastic.NewAliasesService(client))
+
+		im := &indexManager{
+			esclient:  esc,
+			indexName: itemsIndexName,
+			tracer:    tracing.NewTracer(t.Name()),
+			logger:    logger,
+		}
+
+		assert.Error(t, im.SwapAlias(ctx, "items_123"))
+
+		mock.AssertExpectationsForObjects(t, esc)
+	})
+}
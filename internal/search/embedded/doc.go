@@ -0,0 +1,6 @@
+/*
+Package embedded provides a full-text search index that runs inside the process, for deployments without elasticsearch.
+Indices are either held in memory or persisted to a directory, and are best suited to small installations where a single
+process writes to them.
+*/
+package embedded
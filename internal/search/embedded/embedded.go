@@ -0,0 +1,402 @@
+package embedded
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	// accountIDField is the field of indexed values that search results are scoped by.
+	accountIDField = "belongsToAccount"
+
+	indexFileExtension = ".json"
+)
+
+var (
+	_ search.IndexManager = (*indexManager)(nil)
+	_ search.IDLister     = (*indexManager)(nil)
+	_ search.AliasSwapper = (*indexManager)(nil)
+
+	// ErrEmptyQueryProvided indicates an empty query was provided as input.
+	ErrEmptyQueryProvided = errors.New("empty search query provided")
+
+	// ErrIndexNotFound indicates an index was referenced that hasn't been opened.
+	ErrIndexNotFound = errors.New("index not found")
+
+	// indices holds every index opened in this process, so that the API server and
+	// any workers running alongside it read and write the same documents.
+	indices    = map[string]*indexManager{}
+	indicesHat sync.Mutex
+)
+
+type (
+	// document is a single indexed value.
+	document struct {
+		AccountID string          `json:"accountID"`
+		Terms     map[string]uint `json:"terms"`
+	}
+
+	indexManager struct {
+		logger       logging.Logger
+		tracer       tracing.Tracer
+		documents    map[string]*document
+		loadedAt     time.Time
+		filepath     string
+		path         search.IndexPath
+		searchFields []string
+		documentsHat sync.Mutex
+	}
+)
+
+// NewIndexManager opens an embedded full-text index. An empty path keeps the index in memory only,
+// otherwise the path is treated as a directory the index is persisted to. Opening the same path and
+// name twice in one process returns the same index.
+func NewIndexManager(
+	_ context.Context,
+	logger logging.Logger,
+	_ *http.Client,
+	path search.IndexPath,
+	name search.IndexName,
+	fields ...string,
+) (search.IndexManager, error) {
+	indicesHat.Lock()
+	defer indicesHat.Unlock()
+
+	key := fmt.Sprintf("%s:%s", path, name)
+	if im, ok := indices[key]; ok {
+		return im, nil
+	}
+
+	im := &indexManager{
+		logger:       logging.EnsureLogger(logger).WithName("search").WithValue("index", name),
+		tracer:       tracing.NewTracer("search"),
+		documents:    map[string]*document{},
+		path:         path,
+		searchFields: fields,
+	}
+
+	if path != "" {
+		if err := os.MkdirAll(string(path), 0700); err != nil {
+			return nil, fmt.Errorf("creating index directory: %w", err)
+		}
+
+		im.filepath = filepath.Join(string(path), string(name)+indexFileExtension)
+		if err := im.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	indices[key] = im
+
+	return im, nil
+}
+
+// tokenize splits text into lowercase terms.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// load reads the index from disk if it has changed since we last read it, which lets
+// an API server pick up documents indexed by workers running in a separate process.
+// It must be called with the documents lock held.
+func (sm *indexManager) load() error {
+	if sm.filepath == "" {
+		return nil
+	}
+
+	info, err := os.Stat(sm.filepath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("checking index file: %w", err)
+	}
+
+	if !info.ModTime().After(sm.loadedAt) {
+		return nil
+	}
+
+	b, err := os.ReadFile(sm.filepath)
+	if err != nil {
+		return fmt.Errorf("reading index file: %w", err)
+	}
+
+	documents := map[string]*document{}
+	if err = json.Unmarshal(b, &documents); err != nil {
+		return fmt.Errorf("decoding index file: %w", err)
+	}
+
+	sm.documents = documents
+	sm.loadedAt = info.ModTime()
+
+	return nil
+}
+
+// save writes the index to disk. It must be called with the documents lock held.
+func (sm *indexManager) save() error {
+	if sm.filepath == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(sm.documents)
+	if err != nil {
+		return fmt.Errorf("encoding index: %w", err)
+	}
+
+	// write to a temporary file and rename it, so readers never see a partially written index.
+	tmp := sm.filepath + ".tmp"
+	if err = os.WriteFile(tmp, b, 0600); err != nil {
+		return fmt.Errorf("writing index file: %w", err)
+	}
+
+	if err = os.Rename(tmp, sm.filepath); err != nil {
+		return fmt.Errorf("replacing index file: %w", err)
+	}
+
+	if info, statErr := os.Stat(sm.filepath); statErr == nil {
+		sm.loadedAt = info.ModTime()
+	}
+
+	return nil
+}
+
+// Index implements our IndexManager interface.
+func (sm *indexManager) Index(ctx context.Context, id string, value interface{}) error {
+	_, span := sm.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := sm.logger.WithValue("id", id)
+	logger.Debug("adding to index")
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "encoding value")
+	}
+
+	var fields map[string]interface{}
+	if err = json.Unmarshal(b, &fields); err != nil {
+		return observability.PrepareError(err, logger, span, "decoding value")
+	}
+
+	doc := &document{Terms: map[string]uint{}}
+	if accountID, ok := fields[accountIDField].(string); ok {
+		doc.AccountID = accountID
+	}
+
+	for _, field := range sm.searchFields {
+		if text, ok := fields[field].(string); ok {
+			for _, term := range tokenize(text) {
+				doc.Terms[term]++
+			}
+		}
+	}
+
+	sm.documentsHat.Lock()
+	defer sm.documentsHat.Unlock()
+
+	if err = sm.load(); err != nil {
+		return observability.PrepareError(err, logger, span, "loading index")
+	}
+
+	sm.documents[id] = doc
+
+	if err = sm.save(); err != nil {
+		return observability.PrepareError(err, logger, span, "saving index")
+	}
+
+	return nil
+}
+
+type searchResult struct {
+	id    string
+	score uint
+}
+
+// search executes search queries.
+func (sm *indexManager) search(ctx context.Context, query, accountID string, filter *types.QueryFilter) ([]*search.Result, error) {
+	_, span := sm.tracer.StartSpan(ctx)
+	defer span.End()
+
+	tracing.AttachSearchQueryToSpan(span, query)
+	logger := sm.logger.WithValue(keys.SearchQueryKey, query)
+
+	queryTerms := tokenize(query)
+	if len(queryTerms) == 0 {
+		return nil, ErrEmptyQueryProvided
+	}
+
+	// loading may replace our documents, so searches hold the lock too.
+	sm.documentsHat.Lock()
+	defer sm.documentsHat.Unlock()
+
+	if err := sm.load(); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "loading index")
+	}
+
+	matches := []searchResult{}
+	for id, doc := range sm.documents {
+		if accountID != "" && doc.AccountID != accountID {
+			continue
+		}
+
+		// query terms match whole terms or, so that results appear while someone is still typing, term prefixes.
+		var score uint
+		for _, queryTerm := range queryTerms {
+			for term, count := range doc.Terms {
+				if strings.HasPrefix(term, queryTerm) {
+					score += count
+				}
+			}
+		}
+
+		if score > 0 {
+			matches = append(matches, searchResult{id: id, score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].id < matches[j].id
+	})
+
+	offset, limit := search.ResultWindow(filter)
+	if offset > len(matches) {
+		offset = len(matches)
+	}
+	if offset+limit < len(matches) {
+		matches = matches[offset : offset+limit]
+	} else {
+		matches = matches[offset:]
+	}
+
+	results := []*search.Result{}
+	for _, match := range matches {
+		results = append(results, &search.Result{ID: match.id, Score: float64(match.score)})
+	}
+
+	return results, nil
+}
+
+// Search implements our IndexManager interface.
+func (sm *indexManager) Search(ctx context.Context, query, accountID string, filter *types.QueryFilter) ([]*search.Result, error) {
+	return sm.search(ctx, query, accountID, filter)
+}
+
+// SearchForAdmin implements our IndexManager interface.
+func (sm *indexManager) SearchForAdmin(ctx context.Context, query string, filter *types.QueryFilter) ([]*search.Result, error) {
+	return sm.search(ctx, query, "", filter)
+}
+
+// Delete implements our IndexManager interface.
+func (sm *indexManager) Delete(ctx context.Context, id string) error {
+	_, span := sm.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := sm.logger.WithValue("id", id)
+
+	sm.documentsHat.Lock()
+	defer sm.documentsHat.Unlock()
+
+	if err := sm.load(); err != nil {
+		return observability.PrepareError(err, logger, span, "loading index")
+	}
+
+	delete(sm.documents, id)
+
+	if err := sm.save(); err != nil {
+		return observability.PrepareError(err, logger, span, "saving index")
+	}
+
+	logger.Debug("removed from index")
+
+	return nil
+}
+
+// IndexedIDs implements our IDLister interface.
+func (sm *indexManager) IndexedIDs(ctx context.Context) ([]string, error) {
+	_, span := sm.tracer.StartSpan(ctx)
+	defer span.End()
+
+	sm.documentsHat.Lock()
+	defer sm.documentsHat.Unlock()
+
+	if err := sm.load(); err != nil {
+		return nil, observability.PrepareError(err, sm.logger, span, "loading index")
+	}
+
+	ids := []string{}
+	for id := range sm.documents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// SwapAlias implements our AliasSwapper interface. Embedded indices have no aliases, so the
+// target index's documents replace this index's, and the target index is removed.
+func (sm *indexManager) SwapAlias(ctx context.Context, target search.IndexName) error {
+	_, span := sm.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := sm.logger.WithValue("target", target)
+
+	indicesHat.Lock()
+	defer indicesHat.Unlock()
+
+	targetKey := fmt.Sprintf("%s:%s", sm.path, target)
+	fresh, ok := indices[targetKey]
+	if !ok {
+		return observability.PrepareError(ErrIndexNotFound, logger, span, "finding target index")
+	} else if fresh == sm {
+		return nil
+	}
+
+	fresh.documentsHat.Lock()
+	defer fresh.documentsHat.Unlock()
+
+	if err := fresh.load(); err != nil {
+		return observability.PrepareError(err, logger, span, "loading target index")
+	}
+
+	sm.documentsHat.Lock()
+	defer sm.documentsHat.Unlock()
+
+	sm.documents = fresh.documents
+	if err := sm.save(); err != nil {
+		return observability.PrepareError(err, logger, span, "saving index")
+	}
+
+	if fresh.filepath != "" {
+		if err := os.Remove(fresh.filepath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return observability.PrepareError(err, logger, span, "removing target index file")
+		}
+	}
+
+	fresh.documents = map[string]*document{}
+	delete(indices, targetKey)
+
+	logger.Debug("swapped index contents")
+
+	return nil
+}
@@ -0,0 +1,358 @@
+package embedded
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+type exampleValue struct {
+	Name             string `json:"name"`
+	Details          string `json:"details"`
+	BelongsToAccount string `json:"belongsToAccount"`
+}
+
+func resultIDs(results []*search.Result) []string {
+	ids := []string{}
+	for _, result := range results {
+		ids = append(ids, result.ID)
+	}
+
+	return ids
+}
+
+func buildTestIndexManager(t *testing.T, path search.IndexPath) *indexManager {
+	t.Helper()
+
+	ctx := context.Background()
+	logger := logging.NewNoopLogger()
+
+	name := search.IndexName(strings.ReplaceAll(t.Name(), "/", "_"))
+
+	im, err := NewIndexManager(ctx, logger, &http.Client{}, path, name, "name", "details")
+	require.NoError(t, err)
+	require.NotNil(t, im)
+
+	return im.(*indexManager)
+}
+
+func TestNewIndexManager(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		im := buildTestIndexManager(t, "")
+		assert.Empty(t, im.filepath)
+	})
+
+	T.Run("returns the same index for the same path and name", func(t *testing.T) {
+		t.Parallel()
+
+		first := buildTestIndexManager(t, "")
+		second := buildTestIndexManager(t, "")
+
+		assert.Same(t, first, second)
+	})
+
+	T.Run("with path", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		im := buildTestIndexManager(t, search.IndexPath(dir))
+
+		assert.Equal(t, filepath.Join(dir, strings.ReplaceAll(t.Name(), "/", "_")+indexFileExtension), im.filepath)
+	})
+
+	T.Run("with invalid index file", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		dir := t.TempDir()
+		name := "invalid"
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+indexFileExtension), []byte("not json"), 0600))
+
+		im, err := NewIndexManager(ctx, logger, &http.Client{}, search.IndexPath(dir), search.IndexName(name), "name")
+		assert.Error(t, err)
+		assert.Nil(t, im)
+	})
+}
+
+func Test_tokenize(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		expected := []string{"buy", "more", "eggs", "12"}
+		actual := tokenize("Buy more EGGS! (12)")
+
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func Test_indexManager_Index(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		value := &exampleValue{Name: "eggs eggs", Details: "from the store", BelongsToAccount: "account"}
+		require.NoError(t, im.Index(ctx, "id", value))
+
+		expected := &document{
+			AccountID: "account",
+			Terms: map[string]uint{
+				"eggs":  2,
+				"from":  1,
+				"the":   1,
+				"store": 1,
+			},
+		}
+
+		assert.Equal(t, expected, im.documents["id"])
+	})
+
+	T.Run("with unencodable value", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		assert.Error(t, im.Index(ctx, "id", math.Inf(1)))
+	})
+
+	T.Run("with value that isn't an object", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		assert.Error(t, im.Index(ctx, "id", t.Name()))
+	})
+
+	T.Run("persists to disk", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		dir := t.TempDir()
+		im := buildTestIndexManager(t, search.IndexPath(dir))
+
+		require.NoError(t, im.Index(ctx, "id", &exampleValue{Name: "eggs", BelongsToAccount: "account"}))
+		assert.FileExists(t, im.filepath)
+
+		// a fresh index, as another process would open, sees what was written.
+		reopened := &indexManager{
+			logger:    im.logger,
+			tracer:    im.tracer,
+			documents: map[string]*document{},
+			filepath:  im.filepath,
+		}
+
+		results, err := reopened.Search(ctx, "eggs", "account", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"id"}, resultIDs(results))
+	})
+}
+
+func Test_indexManager_Search(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		require.NoError(t, im.Index(ctx, "a", &exampleValue{Name: "eggs", BelongsToAccount: "account"}))
+		require.NoError(t, im.Index(ctx, "b", &exampleValue{Name: "eggs", Details: "more eggs", BelongsToAccount: "account"}))
+		require.NoError(t, im.Index(ctx, "c", &exampleValue{Name: "milk", BelongsToAccount: "account"}))
+
+		results, err := im.Search(ctx, "egg", "account", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b", "a"}, resultIDs(results))
+	})
+
+	T.Run("scoped to account", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		require.NoError(t, im.Index(ctx, "a", &exampleValue{Name: "eggs", BelongsToAccount: "account"}))
+		require.NoError(t, im.Index(ctx, "b", &exampleValue{Name: "eggs", BelongsToAccount: "other_account"}))
+
+		results, err := im.Search(ctx, "eggs", "account", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a"}, resultIDs(results))
+	})
+
+	T.Run("with scores", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		require.NoError(t, im.Index(ctx, "a", &exampleValue{Name: "eggs", Details: "more eggs", BelongsToAccount: "account"}))
+
+		results, err := im.Search(ctx, "eggs", "account", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []*search.Result{{ID: "a", Score: 2}}, results)
+	})
+
+	T.Run("with pagination", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		require.NoError(t, im.Index(ctx, "a", &exampleValue{Name: "eggs", BelongsToAccount: "account"}))
+		require.NoError(t, im.Index(ctx, "b", &exampleValue{Name: "eggs", BelongsToAccount: "account"}))
+		require.NoError(t, im.Index(ctx, "c", &exampleValue{Name: "eggs", BelongsToAccount: "account"}))
+
+		results, err := im.Search(ctx, "eggs", "account", &types.QueryFilter{Page: 2, Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c"}, resultIDs(results))
+
+		results, err = im.Search(ctx, "eggs", "account", &types.QueryFilter{Page: 3, Limit: 2})
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	T.Run("without results", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		results, err := im.Search(ctx, "eggs", "account", nil)
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	T.Run("with empty query", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		results, err := im.Search(ctx, " ! ", "account", nil)
+		assert.ErrorIs(t, err, ErrEmptyQueryProvided)
+		assert.Nil(t, results)
+	})
+}
+
+func Test_indexManager_SearchForAdmin(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		require.NoError(t, im.Index(ctx, "a", &exampleValue{Name: "eggs", BelongsToAccount: "account"}))
+		require.NoError(t, im.Index(ctx, "b", &exampleValue{Name: "eggs", BelongsToAccount: "other_account"}))
+
+		results, err := im.SearchForAdmin(ctx, "eggs", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, resultIDs(results))
+	})
+}
+
+func Test_indexManager_Delete(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, search.IndexPath(t.TempDir()))
+
+		require.NoError(t, im.Index(ctx, "a", &exampleValue{Name: "eggs", BelongsToAccount: "account"}))
+		require.NoError(t, im.Delete(ctx, "a"))
+
+		results, err := im.Search(ctx, "eggs", "account", nil)
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	T.Run("with nonexistent document", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		assert.NoError(t, im.Delete(ctx, "a"))
+	})
+}
+
+func Test_indexManager_IndexedIDs(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		require.NoError(t, im.Index(ctx, "b", &exampleValue{Name: "eggs", BelongsToAccount: "account"}))
+		require.NoError(t, im.Index(ctx, "a", &exampleValue{Name: "milk", BelongsToAccount: "other_account"}))
+
+		ids, err := im.IndexedIDs(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, ids)
+	})
+}
+
+func Test_indexManager_SwapAlias(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		dir := t.TempDir()
+		im := buildTestIndexManager(t, search.IndexPath(dir))
+
+		require.NoError(t, im.Index(ctx, "stale", &exampleValue{Name: "eggs", BelongsToAccount: "account"}))
+
+		fresh, err := NewIndexManager(ctx, logger, &http.Client{}, search.IndexPath(dir), "fresh", "name", "details")
+		require.NoError(t, err)
+		require.NoError(t, fresh.Index(ctx, "current", &exampleValue{Name: "eggs", BelongsToAccount: "account"}))
+
+		require.NoError(t, im.SwapAlias(ctx, "fresh"))
+
+		results, err := im.Search(ctx, "eggs", "account", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"current"}, resultIDs(results))
+
+		assert.NoFileExists(t, filepath.Join(dir, "fresh"+indexFileExtension))
+	})
+
+	T.Run("with nonexistent target", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		im := buildTestIndexManager(t, "")
+
+		assert.ErrorIs(t, im.SwapAlias(ctx, "nonexistent"), ErrIndexNotFound)
+	})
+}
@@ -6,6 +6,7 @@
 	"github.com/stretchr/testify/mock"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
 var _ search.IndexManager = (*IndexManager)(nil)
@@ -22,15 +23,15 @@ func (m *IndexManager) Index(ctx context.Context, id string, value interface{})
 }
 
 // Search implements our interface.
-func (m *IndexManager) Search(ctx context.Context, query, accountID string) (ids []string, err error) {
-	args := m.Called(ctx, query, accountID)
-	return args.Get(0).([]string), args.Error(1)
+func (m *IndexManager) Search(ctx context.Context, query, accountID string, filter *types.QueryFilter) (results []*search.Result, err error) {
+	args := m.Called(ctx, query, accountID, filter)
+	return args.Get(0).([]*search.Result), args.Error(1)
 }
 
 // SearchForAdmin implements our interface.
-func (m *IndexManager) SearchForAdmin(ctx context.Context, query string) (ids []string, err error) {
-	args := m.Called(ctx, query)
-	return args.Get(0).([]string), args.Error(1)
+func (m *IndexManager) SearchForAdmin(ctx context.Context, query string, filter *types.QueryFilter) (results []*search.Result, err error) {
+	args := m.Called(ctx, query, filter)
+	return args.Get(0).([]*search.Result), args.Error(1)
 }
 
 // Delete implements our interface.
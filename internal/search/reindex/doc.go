@@ -0,0 +1,4 @@
+/*
+Package reindex rebuilds search indices from the database, and reports where they had drifted apart
+*/
+package reindex
@@ -0,0 +1,4 @@
+/*
+Package mockreindex provides an interface-compatible reindexer mock
+*/
+package mockreindex
@@ -0,0 +1,23 @@
+package mockreindex
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/reindex"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var _ reindex.Reindexer = (*Reindexer)(nil)
+
+// Reindexer is a mock Reindexer.
+type Reindexer struct {
+	mock.Mock
+}
+
+// ReindexItems implements our interface.
+func (m *Reindexer) ReindexItems(ctx context.Context) (*types.SearchReindexReport, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*types.SearchReindexReport), args.Error(1)
+}
@@ -0,0 +1,172 @@
+package reindex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	serviceName = "search_reindexer"
+
+	itemsIndexName search.IndexName = "items"
+)
+
+var (
+	// itemsSearchFields are the item fields that are searchable.
+	itemsSearchFields = []string{"name", "details"}
+)
+
+type (
+	// Reindexer rebuilds search indices from the database.
+	Reindexer interface {
+		ReindexItems(ctx context.Context) (*types.SearchReindexReport, error)
+	}
+
+	reindexer struct {
+		logger          logging.Logger
+		tracer          tracing.Tracer
+		client          *http.Client
+		itemDataManager types.ItemDataManager
+		indexProvider   search.IndexManagerProvider
+		indexPath       search.IndexPath
+	}
+)
+
+// ProvideReindexer builds a new Reindexer.
+func ProvideReindexer(
+	logger logging.Logger,
+	itemDataManager types.ItemDataManager,
+	indexProvider search.IndexManagerProvider,
+	indexPath search.IndexPath,
+) Reindexer {
+	return &reindexer{
+		logger:          logging.EnsureLogger(logger).WithName(serviceName),
+		tracer:          tracing.NewTracer(serviceName),
+		client:          &http.Client{Transport: tracing.BuildTracedHTTPTransport(time.Second)},
+		itemDataManager: itemDataManager,
+		indexProvider:   indexProvider,
+		indexPath:       indexPath,
+	}
+}
+
+// ReindexItems indexes every item in the database. Indices that support it are rebuilt from scratch and
+// swapped in once complete; others are reindexed in place. Where the index can list its contents, the
+// report compares them with the database, and items left over in an index reindexed in place are removed.
+func (r *reindexer) ReindexItems(ctx context.Context) (*types.SearchReindexReport, error) {
+	ctx, span := r.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := r.logger.WithValue("index", itemsIndexName)
+
+	live, err := r.indexProvider(ctx, logger, r.client, r.indexPath, itemsIndexName, itemsSearchFields...)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "opening search index")
+	}
+
+	report := &types.SearchReindexReport{
+		IndexName:        string(itemsIndexName),
+		MissingFromIndex: []string{},
+		OrphanedInIndex:  []string{},
+	}
+
+	// note what the index held beforehand, so we can tell what had drifted.
+	indexedIDs := map[string]bool{}
+	lister, canList := live.(search.IDLister)
+	if canList {
+		ids, listErr := lister.IndexedIDs(ctx)
+		if listErr != nil {
+			return nil, observability.PrepareError(listErr, logger, span, "listing indexed IDs")
+		}
+
+		for _, id := range ids {
+			indexedIDs[id] = true
+		}
+		report.Compared = true
+	}
+
+	target := live
+	swapper, canSwap := live.(search.AliasSwapper)
+	freshIndexName := search.IndexName(fmt.Sprintf("%s_%d", itemsIndexName, time.Now().UnixNano()))
+
+	if canSwap {
+		if target, err = r.indexProvider(ctx, logger, r.client, r.indexPath, freshIndexName, itemsSearchFields...); err != nil {
+			return nil, observability.PrepareError(err, logger, span, "creating fresh search index")
+		}
+		report.IndexName = string(freshIndexName)
+	}
+
+	databaseIDs := map[string]bool{}
+	filter := &types.QueryFilter{Page: 1, Limit: types.MaxLimit}
+
+	for {
+		items, getErr := r.itemDataManager.GetItemsForAdmin(ctx, filter)
+		if getErr != nil {
+			return nil, observability.PrepareError(getErr, logger, span, "fetching items")
+		}
+
+		for _, item := range items.Items {
+			// archived items are removed from the index, so they shouldn't be put back.
+			if item.ArchivedOn != nil {
+				continue
+			}
+
+			if err = target.Index(ctx, item.ID, item); err != nil {
+				return nil, observability.PrepareError(err, logger, span, "indexing item")
+			}
+
+			databaseIDs[item.ID] = true
+			report.IndexedCount++
+		}
+
+		if len(items.Items) < int(filter.Limit) {
+			break
+		}
+		filter.Page++
+	}
+
+	if canList {
+		for id := range databaseIDs {
+			if !indexedIDs[id] {
+				report.MissingFromIndex = append(report.MissingFromIndex, id)
+			}
+		}
+
+		for id := range indexedIDs {
+			if !databaseIDs[id] {
+				report.OrphanedInIndex = append(report.OrphanedInIndex, id)
+			}
+		}
+
+		sort.Strings(report.MissingFromIndex)
+		sort.Strings(report.OrphanedInIndex)
+	}
+
+	if canSwap {
+		if err = swapper.SwapAlias(ctx, freshIndexName); err != nil {
+			return nil, observability.PrepareError(err, logger, span, "swapping in fresh search index")
+		}
+		report.Swapped = true
+	} else {
+		for _, id := range report.OrphanedInIndex {
+			if err = live.Delete(ctx, id); err != nil {
+				return nil, observability.PrepareError(err, logger, span, "removing orphaned item from index")
+			}
+		}
+	}
+
+	logger.WithValue("indexed_count", report.IndexedCount).
+		WithValue("missing_count", len(report.MissingFromIndex)).
+		WithValue("orphaned_count", len(report.OrphanedInIndex)).
+		Info("reindexed items")
+
+	return report, nil
+}
@@ -0,0 +1,308 @@
+package reindex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
+	mocksearch "gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/mock"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+type listingIndexManager struct {
+	mocksearch.IndexManager
+}
+
+func (m *listingIndexManager) IndexedIDs(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type swappingIndexManager struct {
+	listingIndexManager
+}
+
+func (m *swappingIndexManager) SwapAlias(ctx context.Context, target search.IndexName) error {
+	return m.Called(ctx, target).Error(0)
+}
+
+var freshIndexNameMatcher = mock.MatchedBy(func(name search.IndexName) bool {
+	return name != itemsIndexName
+})
+
+func buildTestProvider(live, fresh search.IndexManager) search.IndexManagerProvider {
+	return func(_ context.Context, _ logging.Logger, _ *http.Client, _ search.IndexPath, name search.IndexName, _ ...string) (search.IndexManager, error) {
+		if name == itemsIndexName {
+			return live, nil
+		}
+		return fresh, nil
+	}
+}
+
+func buildTestReindexer(itemDataManager types.ItemDataManager, provider search.IndexManagerProvider) *reindexer {
+	return ProvideReindexer(logging.NewNoopLogger(), itemDataManager, provider, "").(*reindexer)
+}
+
+func TestProvideReindexer(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		r := ProvideReindexer(logging.NewNoopLogger(), &mocktypes.ItemDataManager{}, buildTestProvider(nil, nil), "")
+		assert.NotNil(t, r)
+	})
+}
+
+func Test_reindexer_ReindexItems(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with index that can be swapped", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		indexedItem, missingItem, archivedItem := fakes.BuildFakeItem(), fakes.BuildFakeItem(), fakes.BuildFakeItem()
+		archivedItem.ArchivedOn = func(x uint64) *uint64 { return &x }(1)
+		orphanedID := "orphaned"
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("GetItemsForAdmin", testutils.ContextMatcher, mock.IsType(&types.QueryFilter{})).
+			Return(&types.ItemList{Items: []*types.Item{indexedItem, missingItem, archivedItem}}, nil)
+
+		live := &swappingIndexManager{}
+		live.On("IndexedIDs", testutils.ContextMatcher).Return([]string{indexedItem.ID, orphanedID}, nil)
+		live.On("SwapAlias", testutils.ContextMatcher, freshIndexNameMatcher).Return(nil)
+
+		fresh := &mocksearch.IndexManager{}
+		fresh.On("Index", testutils.ContextMatcher, indexedItem.ID, indexedItem).Return(nil)
+		fresh.On("Index", testutils.ContextMatcher, missingItem.ID, missingItem).Return(nil)
+
+		r := buildTestReindexer(itemDataManager, buildTestProvider(live, fresh))
+
+		report, err := r.ReindexItems(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{missingItem.ID}, report.MissingFromIndex)
+		assert.Equal(t, []string{orphanedID}, report.OrphanedInIndex)
+		assert.Equal(t, uint64(2), report.IndexedCount)
+		assert.NotEqual(t, string(itemsIndexName), report.IndexName)
+		assert.True(t, report.Compared)
+		assert.True(t, report.Swapped)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager, live, fresh)
+	})
+
+	T.Run("with index reindexed in place", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		exampleItem := fakes.BuildFakeItem()
+		orphanedID := "orphaned"
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("GetItemsForAdmin", testutils.ContextMatcher, mock.IsType(&types.QueryFilter{})).
+			Return(&types.ItemList{Items: []*types.Item{exampleItem}}, nil)
+
+		live := &listingIndexManager{}
+		live.On("IndexedIDs", testutils.ContextMatcher).Return([]string{exampleItem.ID, orphanedID}, nil)
+		live.On("Index", testutils.ContextMatcher, exampleItem.ID, exampleItem).Return(nil)
+		live.On("Delete", testutils.ContextMatcher, orphanedID).Return(nil)
+
+		r := buildTestReindexer(itemDataManager, buildTestProvider(live, nil))
+
+		report, err := r.ReindexItems(ctx)
+		require.NoError(t, err)
+
+		assert.Empty(t, report.MissingFromIndex)
+		assert.Equal(t, []string{orphanedID}, report.OrphanedInIndex)
+		assert.Equal(t, string(itemsIndexName), report.IndexName)
+		assert.True(t, report.Compared)
+		assert.False(t, report.Swapped)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager, live)
+	})
+
+	T.Run("with index that can't list its contents", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		exampleItem := fakes.BuildFakeItem()
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("GetItemsForAdmin", testutils.ContextMatcher, mock.IsType(&types.QueryFilter{})).
+			Return(&types.ItemList{Items: []*types.Item{exampleItem}}, nil)
+
+		live := &mocksearch.IndexManager{}
+		live.On("Index", testutils.ContextMatcher, exampleItem.ID, exampleItem).Return(nil)
+
+		r := buildTestReindexer(itemDataManager, buildTestProvider(live, nil))
+
+		report, err := r.ReindexItems(ctx)
+		require.NoError(t, err)
+
+		assert.Empty(t, report.MissingFromIndex)
+		assert.Empty(t, report.OrphanedInIndex)
+		assert.Equal(t, uint64(1), report.IndexedCount)
+		assert.False(t, report.Compared)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager, live)
+	})
+
+	T.Run("pages through every item", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		firstPage := &types.ItemList{}
+		for i := 0; i < types.MaxLimit; i++ {
+			firstPage.Items = append(firstPage.Items, fakes.BuildFakeItem())
+		}
+		secondPage := &types.ItemList{Items: []*types.Item{fakes.BuildFakeItem()}}
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("GetItemsForAdmin", testutils.ContextMatcher, mock.MatchedBy(func(filter *types.QueryFilter) bool {
+			return filter.Page == 1
+		})).Return(firstPage, nil).Once()
+		itemDataManager.On("GetItemsForAdmin", testutils.ContextMatcher, mock.MatchedBy(func(filter *types.QueryFilter) bool {
+			return filter.Page == 2
+		})).Return(secondPage, nil).Once()
+
+		live := &mocksearch.IndexManager{}
+		live.On("Index", testutils.ContextMatcher, mock.AnythingOfType("string"), mock.IsType(&types.Item{})).Return(nil)
+
+		r := buildTestReindexer(itemDataManager, buildTestProvider(live, nil))
+
+		report, err := r.ReindexItems(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(types.MaxLimit+1), report.IndexedCount)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager, live)
+	})
+
+	T.Run("with error opening index", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		provider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
+			return nil, errors.New("blah")
+		}
+
+		r := buildTestReindexer(&mocktypes.ItemDataManager{}, provider)
+
+		report, err := r.ReindexItems(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, report)
+	})
+
+	T.Run("with error listing indexed IDs", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		live := &listingIndexManager{}
+		live.On("IndexedIDs", testutils.ContextMatcher).Return([]string(nil), errors.New("blah"))
+
+		r := buildTestReindexer(&mocktypes.ItemDataManager{}, buildTestProvider(live, nil))
+
+		report, err := r.ReindexItems(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, report)
+
+		mock.AssertExpectationsForObjects(t, live)
+	})
+
+	T.Run("with error fetching items", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("GetItemsForAdmin", testutils.ContextMatcher, mock.IsType(&types.QueryFilter{})).
+			Return((*types.ItemList)(nil), errors.New("blah"))
+
+		r := buildTestReindexer(itemDataManager, buildTestProvider(&mocksearch.IndexManager{}, nil))
+
+		report, err := r.ReindexItems(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, report)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager)
+	})
+
+	T.Run("with error indexing item", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		exampleItem := fakes.BuildFakeItem()
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("GetItemsForAdmin", testutils.ContextMatcher, mock.IsType(&types.QueryFilter{})).
+			Return(&types.ItemList{Items: []*types.Item{exampleItem}}, nil)
+
+		live := &mocksearch.IndexManager{}
+		live.On("Index", testutils.ContextMatcher, exampleItem.ID, exampleItem).Return(errors.New("blah"))
+
+		r := buildTestReindexer(itemDataManager, buildTestProvider(live, nil))
+
+		report, err := r.ReindexItems(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, report)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager, live)
+	})
+
+	T.Run("with error swapping index", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("GetItemsForAdmin", testutils.ContextMatcher, mock.IsType(&types.QueryFilter{})).
+			Return(&types.ItemList{}, nil)
+
+		live := &swappingIndexManager{}
+		live.On("IndexedIDs", testutils.ContextMatcher).Return([]string{}, nil)
+		live.On("SwapAlias", testutils.ContextMatcher, freshIndexNameMatcher).Return(errors.New("blah"))
+
+		r := buildTestReindexer(itemDataManager, buildTestProvider(live, &mocksearch.IndexManager{}))
+
+		report, err := r.ReindexItems(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, report)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager, live)
+	})
+
+	T.Run("with error removing orphaned item", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		orphanedID := "orphaned"
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On("GetItemsForAdmin", testutils.ContextMatcher, mock.IsType(&types.QueryFilter{})).
+			Return(&types.ItemList{}, nil)
+
+		live := &listingIndexManager{}
+		live.On("IndexedIDs", testutils.ContextMatcher).Return([]string{orphanedID}, nil)
+		live.On("Delete", testutils.ContextMatcher, orphanedID).Return(errors.New("blah"))
+
+		r := buildTestReindexer(itemDataManager, buildTestProvider(live, nil))
+
+		report, err := r.ReindexItems(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, report)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager, live)
+	})
+}
@@ -5,6 +5,7 @@
 	"net/http"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
 type (
@@ -14,14 +15,50 @@
 	// IndexName is a type alias for dependency injection's sake.
 	IndexName string
 
+	// Result is a single search hit.
+	Result struct {
+		Highlights map[string][]string
+		ID         string
+		Score      float64
+	}
+
 	// IndexManager is our wrapper interface for a text search index.
 	IndexManager interface {
 		Index(ctx context.Context, id string, value interface{}) error
-		Search(ctx context.Context, query, accountID string) (ids []string, err error)
-		SearchForAdmin(ctx context.Context, query string) (ids []string, err error)
+		Search(ctx context.Context, query, accountID string, filter *types.QueryFilter) (results []*Result, err error)
+		SearchForAdmin(ctx context.Context, query string, filter *types.QueryFilter) (results []*Result, err error)
 		Delete(ctx context.Context, id string) (err error)
 	}
 
+	// IDLister is implemented by indices that can list the IDs of everything they hold.
+	IDLister interface {
+		IndexedIDs(ctx context.Context) ([]string, error)
+	}
+
+	// AliasSwapper is implemented by indices that can serve another, freshly built index under
+	// their own name, discarding what they previously held.
+	AliasSwapper interface {
+		SwapAlias(ctx context.Context, target IndexName) error
+	}
+
 	// IndexManagerProvider is a function that provides an IndexManager for a given index.
 	IndexManagerProvider func(context.Context, logging.Logger, *http.Client, IndexPath, IndexName, ...string) (IndexManager, error)
 )
+
+// ResultWindow determines the offset and number of results a query filter asks for.
+func ResultWindow(filter *types.QueryFilter) (offset, limit int) {
+	if filter == nil {
+		filter = types.DefaultQueryFilter()
+	}
+
+	limit = int(filter.Limit)
+	if limit == 0 {
+		limit = types.DefaultLimit
+	}
+
+	if filter.Page > 1 {
+		offset = int(filter.Page-1) * limit
+	}
+
+	return offset, limit
+}
@@ -0,0 +1,39 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+func TestResultWindow(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		filter := &types.QueryFilter{Page: 3, Limit: 10}
+
+		offset, limit := ResultWindow(filter)
+		assert.Equal(t, 20, offset)
+		assert.Equal(t, 10, limit)
+	})
+
+	T.Run("with nil filter", func(t *testing.T) {
+		t.Parallel()
+
+		offset, limit := ResultWindow(nil)
+		assert.Equal(t, 0, offset)
+		assert.Equal(t, types.DefaultLimit, limit)
+	})
+
+	T.Run("with zero values", func(t *testing.T) {
+		t.Parallel()
+
+		offset, limit := ResultWindow(&types.QueryFilter{})
+		assert.Equal(t, 0, offset)
+		assert.Equal(t, types.DefaultLimit, limit)
+	})
+}
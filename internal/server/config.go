@@ -15,6 +15,8 @@
 		StartupDeadline time.Duration `json:"startup_deadline" mapstructure:"startup_deadline" toml:"startup_deadline,omitempty"`
 		HTTPPort        uint16        `json:"http_port" mapstructure:"http_port" toml:"http_port,omitempty"`
 		Debug           bool          `json:"debug" mapstructure:"debug" toml:"debug,omitempty"`
+		// RunWorkers has the server host the background workers itself, rather than relying on a separate workers process.
+		RunWorkers bool `json:"run_workers" mapstructure:"run_workers" toml:"run_workers,omitempty"`
 	}
 )
 
@@ -9,9 +9,13 @@
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing"
+	accountrolesservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/accountroles"
 	accountsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/accounts"
+	adminservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/admin"
 	apiclientsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/apiclients"
+	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
 	itemsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/items"
+	notificationsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/notifications"
 	usersservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/users"
 	webhooksservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/webhooks"
 )
@@ -52,12 +56,18 @@ func (s *HTTPServer) setupRouter(ctx context.Context, router routing.Router, met
 
 	authenticatedRouter := router.WithMiddleware(s.authService.UserAttributionMiddleware)
 	authenticatedRouter.Get("/auth/status", s.authService.StatusHandler)
+	router.Get("/auth/oidc/login", s.authService.BeginOIDCLoginHandler)
+	router.Get("/auth/oidc/callback", s.authService.OIDCCallbackHandler)
 
 	router.Route("/users", func(userRouter routing.Router) {
 		userRouter.Post("/login", s.authService.BeginSessionHandler)
+		userRouter.Post("/webauthn/login/begin", s.authService.BeginWebAuthnLoginHandler)
 		userRouter.WithMiddleware(s.authService.UserAttributionMiddleware, s.authService.CookieRequirementMiddleware).Post("/logout", s.authService.EndSessionHandler)
 		userRouter.Post(root, s.usersService.CreateHandler)
 		userRouter.Post("/totp_secret/verify", s.usersService.TOTPSecretVerificationHandler)
+		userRouter.Post("/totp_secret/recover", s.usersService.TOTPRecoveryHandler)
+		userRouter.Post("/password/reset", s.usersService.RequestPasswordResetHandler)
+		userRouter.Post("/password/reset/redeem", s.usersService.RedeemPasswordResetHandler)
 
 		// need credentials beyond this point
 		authedRouter := userRouter.WithMiddleware(s.authService.UserAttributionMiddleware, s.authService.AuthorizationMiddleware)
@@ -77,6 +87,18 @@ func (s *HTTPServer) setupRouter(ctx context.Context, router routing.Router, met
 			adminRouter.
 				WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.UpdateUserStatusPermission)).
 				Post("/users/status", s.adminService.UserReputationChangeHandler)
+			adminRouter.
+				WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.RevokeUserSessionsPermission)).
+				Delete("/users"+buildURLVarChunk(adminservice.UserIDURIParamKey, "")+"/sessions", s.adminService.RevokeUserSessionsHandler)
+			adminRouter.
+				WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.ReindexSearchPermission)).
+				Post("/search/reindex", s.adminService.SearchReindexHandler)
+			adminRouter.
+				WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.ReadAllAuditLogEntriesPermission)).
+				Get("/audit_log", s.auditLogService.ListHandler)
+			adminRouter.
+				WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.ReadWebsocketPresencePermission)).
+				Get("/websockets/presence", s.websocketsService.PresenceReportHandler)
 		})
 
 		// Users
@@ -90,6 +112,19 @@ func (s *HTTPServer) setupRouter(ctx context.Context, router routing.Router, met
 			usersRouter.Post("/avatar/upload", s.usersService.AvatarUploadHandler)
 			usersRouter.Get("/self", s.usersService.SelfHandler)
 
+			usersRouter.Route("/sessions", func(sessionsRouter routing.Router) {
+				sessionsRouter.Get(root, s.authService.ListUserSessionsHandler)
+				sessionsRouter.Delete(root, s.authService.RevokeOtherUserSessionsHandler)
+				sessionsRouter.Delete(buildURLVarChunk(authservice.UserSessionIDURIParamKey, ""), s.authService.RevokeUserSessionHandler)
+			})
+
+			usersRouter.Route("/webauthn", func(webAuthnRouter routing.Router) {
+				webAuthnRouter.Get("/credentials", s.authService.ListWebAuthnCredentialsHandler)
+				webAuthnRouter.Delete("/credentials"+buildURLVarChunk(authservice.WebAuthnCredentialIDURIParamKey, ""), s.authService.ArchiveWebAuthnCredentialHandler)
+				webAuthnRouter.Post("/registration/begin", s.authService.BeginWebAuthnRegistrationHandler)
+				webAuthnRouter.Post("/registration/finish", s.authService.FinishWebAuthnRegistrationHandler)
+			})
+
 			singleUserRoute := buildURLVarChunk(usersservice.UserIDURIParamKey, "")
 			usersRouter.Route(singleUserRoute, func(singleUserRouter routing.Router) {
 				singleUserRouter.
@@ -118,13 +153,62 @@ func (s *HTTPServer) setupRouter(ctx context.Context, router routing.Router, met
 				singleAccountRouter.
 					WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.RemoveMemberAccountPermission)).
 					Delete("/members"+singleUserRoute, s.accountsService.RemoveMemberHandler)
-				singleAccountRouter.
-					WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.AddMemberAccountPermission)).
-					Post("/member", s.accountsService.AddMemberHandler)
 				singleAccountRouter.
 					WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.ModifyMemberPermissionsForAccountPermission)).
 					Patch("/members"+singleUserRoute+"/permissions", s.accountsService.ModifyMemberPermissionsHandler)
 				singleAccountRouter.Post("/transfer", s.accountsService.TransferAccountOwnershipHandler)
+
+				singleAccountRouter.
+					WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.ReadAuditLogEntriesPermission)).
+					Get("/audit_log", s.auditLogService.ListForAccountHandler)
+
+				singleAccountRouter.Route("/invitations", func(accountInvitationsRouter routing.Router) {
+					accountInvitationsRouter.
+						WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.AddMemberAccountPermission)).
+						Get(root, s.accountsService.ListPendingInvitationsHandler)
+					accountInvitationsRouter.
+						WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.AddMemberAccountPermission)).
+						Post(root, s.accountsService.InviteMemberHandler)
+
+					singleAccountInvitationRoute := buildURLVarChunk(accountsservice.AccountInvitationIDURIParamKey, "")
+					accountInvitationsRouter.
+						WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.AddMemberAccountPermission)).
+						Delete(singleAccountInvitationRoute, s.accountsService.RevokeInvitationHandler)
+				})
+
+				singleAccountRouter.Route("/roles", func(accountRolesRouter routing.Router) {
+					accountRolesRouter.
+						WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.ReadAccountRolesPermission)).
+						Get(root, s.accountRolesService.ListHandler)
+					accountRolesRouter.
+						WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.CreateAccountRolesPermission)).
+						Post(root, s.accountRolesService.CreateHandler)
+
+					singleAccountRoleRoute := buildURLVarChunk(accountrolesservice.AccountRoleIDURIParamKey, "")
+					accountRolesRouter.Route(singleAccountRoleRoute, func(singleAccountRoleRouter routing.Router) {
+						singleAccountRoleRouter.
+							WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.ReadAccountRolesPermission)).
+							Get(root, s.accountRolesService.ReadHandler)
+						singleAccountRoleRouter.
+							WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.UpdateAccountRolesPermission)).
+							Put(root, s.accountRolesService.UpdateHandler)
+						singleAccountRoleRouter.
+							WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.ArchiveAccountRolesPermission)).
+							Delete(root, s.accountRolesService.ArchiveHandler)
+					})
+				})
+			})
+		})
+
+		// Account Invitations
+		v1Router.Route("/account_invitations", func(accountInvitationsRouter routing.Router) {
+			accountInvitationsRouter.Get(root, s.accountsService.ListReceivedInvitationsHandler)
+			accountInvitationsRouter.Post("/claim", s.accountsService.ClaimInvitationHandler)
+
+			singleAccountInvitationRoute := buildURLVarChunk(accountsservice.AccountInvitationIDURIParamKey, "")
+			accountInvitationsRouter.Route(singleAccountInvitationRoute, func(singleAccountInvitationRouter routing.Router) {
+				singleAccountInvitationRouter.Post("/accept", s.accountsService.AcceptInvitationHandler)
+				singleAccountInvitationRouter.Post("/reject", s.accountsService.RejectInvitationHandler)
 			})
 		})
 
@@ -151,6 +235,16 @@ func (s *HTTPServer) setupRouter(ctx context.Context, router routing.Router, met
 		// Notifications
 		v1Router.Route("/websockets", func(notificationsRouter routing.Router) {
 			notificationsRouter.Get("/data_changes", s.websocketsService.SubscribeHandler)
+			notificationsRouter.Get("/data_changes/events", s.websocketsService.EventStreamHandler)
+		})
+
+		v1Router.Route("/notifications", func(notificationsRouter routing.Router) {
+			notificationsRouter.Get(root, s.notificationsService.ListHandler)
+			notificationsRouter.Get("/unread_count", s.notificationsService.UnreadCountHandler)
+			notificationsRouter.Post("/seen", s.notificationsService.MarkAllAsSeenHandler)
+
+			singleNotificationRoute := buildURLVarChunk(notificationsservice.NotificationIDURIParamKey, "")
+			notificationsRouter.Post(singleNotificationRoute+"/seen", s.notificationsService.MarkAsSeenHandler)
 		})
 
 		// Webhooks
@@ -166,9 +260,22 @@ func (s *HTTPServer) setupRouter(ctx context.Context, router routing.Router, met
 				singleWebhookRouter.
 					WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.ReadWebhooksPermission)).
 					Get(root, s.webhooksService.ReadHandler)
+				singleWebhookRouter.
+					WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.UpdateWebhooksPermission)).
+					Put(root, s.webhooksService.UpdateHandler)
 				singleWebhookRouter.
 					WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.ArchiveWebhooksPermission)).
 					Delete(root, s.webhooksService.ArchiveHandler)
+				singleWebhookRouter.
+					WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.UpdateWebhooksPermission)).
+					Post("/rotate_secret", s.webhooksService.RotateSecretHandler)
+				singleWebhookRouter.
+					WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.ReadWebhooksPermission)).
+					Get("/deliveries", s.webhooksService.ListDeliveryAttemptsHandler)
+				singleDeliveryAttemptRoute := buildURLVarChunk(webhooksservice.WebhookDeliveryAttemptIDURIParamKey, "")
+				singleWebhookRouter.
+					WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.UpdateWebhooksPermission)).
+					Post("/deliveries"+singleDeliveryAttemptRoute+"/redeliver", s.webhooksService.RedeliverHandler)
 			})
 		})
 
@@ -197,6 +304,9 @@ func (s *HTTPServer) setupRouter(ctx context.Context, router routing.Router, met
 				singleItemRouter.
 					WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.UpdateItemsPermission)).
 					Put(root, s.itemsService.UpdateHandler)
+				singleItemRouter.
+					WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.ReadItemsPermission)).
+					Get("/viewers", s.websocketsService.ItemPresenceHandler)
 			})
 		})
 	})
@@ -30,21 +30,24 @@
 type (
 	// HTTPServer is our API http server.
 	HTTPServer struct {
-		authService       types.AuthService
-		accountsService   types.AccountDataService
-		frontendService   frontend.Service
-		usersService      types.UserDataService
-		adminService      types.AdminService
-		apiClientsService types.APIClientDataService
-		webhooksService   types.WebhookDataService
-		itemsService      types.ItemDataService
-		websocketsService types.WebsocketDataService
-		encoder           encoding.ServerEncoderDecoder
-		logger            logging.Logger
-		router            routing.Router
-		tracer            tracing.Tracer
-		httpServer        *http.Server
-		panicker          panicking.Panicker
+		authService          types.AuthService
+		accountsService      types.AccountDataService
+		accountRolesService  types.AccountRoleDataService
+		auditLogService      types.AuditLogEntryDataService
+		frontendService      frontend.Service
+		usersService         types.UserDataService
+		adminService         types.AdminService
+		apiClientsService    types.APIClientDataService
+		webhooksService      types.WebhookDataService
+		itemsService         types.ItemDataService
+		websocketsService    types.WebsocketDataService
+		notificationsService types.NotificationDataService
+		encoder              encoding.ServerEncoderDecoder
+		logger               logging.Logger
+		router               routing.Router
+		tracer               tracing.Tracer
+		httpServer           *http.Server
+		panicker             panicking.Panicker
 	}
 )
 
@@ -56,11 +59,14 @@ func ProvideHTTPServer(
 	authService types.AuthService,
 	usersService types.UserDataService,
 	accountsService types.AccountDataService,
+	accountRolesService types.AccountRoleDataService,
+	auditLogService types.AuditLogEntryDataService,
 	apiClientsService types.APIClientDataService,
 	websocketsService types.WebsocketDataService,
 	itemsService types.ItemDataService,
 	webhooksService types.WebhookDataService,
 	adminService types.AdminService,
+	notificationsService types.NotificationDataService,
 	frontendService frontend.Service,
 	logger logging.Logger,
 	encoder encoding.ServerEncoderDecoder,
@@ -75,15 +81,18 @@ func ProvideHTTPServer(
 		httpServer: provideHTTPServer(serverSettings.HTTPPort),
 
 		// services,
-		adminService:      adminService,
-		webhooksService:   webhooksService,
-		frontendService:   frontendService,
-		usersService:      usersService,
-		accountsService:   accountsService,
-		authService:       authService,
-		websocketsService: websocketsService,
-		itemsService:      itemsService,
-		apiClientsService: apiClientsService,
+		adminService:         adminService,
+		webhooksService:      webhooksService,
+		frontendService:      frontendService,
+		usersService:         usersService,
+		accountsService:      accountsService,
+		accountRolesService:  accountRolesService,
+		auditLogService:      auditLogService,
+		authService:          authService,
+		websocketsService:    websocketsService,
+		itemsService:         itemsService,
+		apiClientsService:    apiClientsService,
+		notificationsService: notificationsService,
 	}
 
 	srv.setupRouter(ctx, router, metricsHandler)
@@ -0,0 +1,4 @@
+/*
+Package accountroles provides a series of HTTP handlers for managing the custom roles an account defines for its members.
+*/
+package accountroles
@@ -0,0 +1,333 @@
+package accountroles
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/audit"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	// AccountIDURIParamKey is a standard string that we'll use to refer to account IDs with.
+	AccountIDURIParamKey = "accountID"
+	// AccountRoleIDURIParamKey is a standard string that we'll use to refer to account role IDs with.
+	AccountRoleIDURIParamKey = "accountRoleID"
+)
+
+// authorizedForAccount returns whether the requester holds a given permission in the account a request is about,
+// which is not necessarily the account they currently have active.
+func authorizedForAccount(sessionCtxData *types.SessionContextData, accountID string, perm authorization.Permission) bool {
+	if sessionCtxData.Requester.ServicePermissions != nil && sessionCtxData.Requester.ServicePermissions.IsServiceAdmin() {
+		return true
+	}
+
+	checker, ok := sessionCtxData.AccountPermissions[accountID]
+
+	return ok && checker.HasPermission(perm)
+}
+
+// ListHandler is our list route.
+func (s *service) ListHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	filter := types.ExtractQueryFilter(req)
+	logger := filter.AttachToLogger(s.logger.WithRequest(req))
+
+	tracing.AttachRequestToSpan(span, req)
+	tracing.AttachFilterToSpan(span, filter.Page, filter.Limit, string(filter.SortBy))
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	// determine account ID.
+	accountID := s.accountIDFetcher(req)
+	logger = logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	if !authorizedForAccount(sessionCtxData, accountID, authorization.ReadAccountRolesPermission) {
+		logger.Debug("requester not authorized to read account roles")
+		s.encoderDecoder.EncodeUnauthorizedResponse(ctx, res)
+		return
+	}
+
+	accountRoles, err := s.accountRoleDataManager.GetAccountRoles(ctx, accountID, filter)
+	if errors.Is(err, sql.ErrNoRows) {
+		// in the event no rows exist, return an empty list.
+		accountRoles = &types.AccountRoleList{AccountRoles: []*types.AccountRole{}}
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving account roles")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// encode our response and peace.
+	s.encoderDecoder.RespondWithData(ctx, res, accountRoles)
+}
+
+// CreateHandler is our account role creation route.
+func (s *service) CreateHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	input := new(types.AccountRoleCreationInput)
+	if err = s.encoderDecoder.DecodeRequest(ctx, req, input); err != nil {
+		observability.AcknowledgeError(err, logger, span, "decoding request body")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid request content", http.StatusBadRequest)
+		return
+	}
+
+	if err = input.ValidateWithContext(ctx); err != nil {
+		logger.WithValue(keys.ValidationErrorKey, err).Debug("provided input was invalid")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// determine account ID.
+	accountID := s.accountIDFetcher(req)
+	logger = logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	if !authorizedForAccount(sessionCtxData, accountID, authorization.CreateAccountRolesPermission) {
+		logger.Debug("requester not authorized to create account roles")
+		s.encoderDecoder.EncodeUnauthorizedResponse(ctx, res)
+		return
+	}
+
+	input.ID = ksuid.New().String()
+	input.BelongsToAccount = accountID
+	tracing.AttachAccountRoleIDToSpan(span, input.ID)
+
+	accountRole, err := s.accountRoleDataManager.CreateAccountRole(ctx, input)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "creating account role")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountRoleCreationEvent,
+		ActorUserID:      sessionCtxData.Requester.UserID,
+		BelongsToAccount: accountID,
+		ResourceType:     types.AccountRoleResourceType,
+		ResourceID:       accountRole.ID,
+	})
+
+	s.encoderDecoder.EncodeResponseWithStatus(ctx, res, accountRole, http.StatusCreated)
+}
+
+// ReadHandler returns a GET handler that returns an account role.
+func (s *service) ReadHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	// determine account ID.
+	accountID := s.accountIDFetcher(req)
+	logger = logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	if !authorizedForAccount(sessionCtxData, accountID, authorization.ReadAccountRolesPermission) {
+		logger.Debug("requester not authorized to read account roles")
+		s.encoderDecoder.EncodeUnauthorizedResponse(ctx, res)
+		return
+	}
+
+	// determine account role ID.
+	accountRoleID := s.accountRoleIDFetcher(req)
+	logger = logger.WithValue(keys.AccountRoleIDKey, accountRoleID)
+	tracing.AttachAccountRoleIDToSpan(span, accountRoleID)
+
+	accountRole, err := s.accountRoleDataManager.GetAccountRole(ctx, accountRoleID, accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving account role")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// encode our response and peace.
+	s.encoderDecoder.RespondWithData(ctx, res, accountRole)
+}
+
+// UpdateHandler returns a handler that updates an account role.
+func (s *service) UpdateHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	input := new(types.AccountRoleUpdateInput)
+	if err = s.encoderDecoder.DecodeRequest(ctx, req, input); err != nil {
+		observability.AcknowledgeError(err, logger, span, "decoding request body")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid request content", http.StatusBadRequest)
+		return
+	}
+
+	if err = input.ValidateWithContext(ctx); err != nil {
+		logger.WithValue(keys.ValidationErrorKey, err).Debug("provided input was invalid")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// determine account ID.
+	accountID := s.accountIDFetcher(req)
+	logger = logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	if !authorizedForAccount(sessionCtxData, accountID, authorization.UpdateAccountRolesPermission) {
+		logger.Debug("requester not authorized to update account roles")
+		s.encoderDecoder.EncodeUnauthorizedResponse(ctx, res)
+		return
+	}
+
+	// determine account role ID.
+	accountRoleID := s.accountRoleIDFetcher(req)
+	logger = logger.WithValue(keys.AccountRoleIDKey, accountRoleID)
+	tracing.AttachAccountRoleIDToSpan(span, accountRoleID)
+
+	accountRole, err := s.accountRoleDataManager.GetAccountRole(ctx, accountRoleID, accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving account role for update")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// update the data structure.
+	changes := accountRole.Update(input)
+
+	if err = s.accountRoleDataManager.UpdateAccountRole(ctx, accountRole); err != nil {
+		observability.AcknowledgeError(err, logger, span, "updating account role")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountRoleUpdateEvent,
+		ActorUserID:      sessionCtxData.Requester.UserID,
+		BelongsToAccount: accountID,
+		ResourceType:     types.AccountRoleResourceType,
+		ResourceID:       accountRole.ID,
+		Changes:          changes,
+	})
+
+	// encode our response and peace.
+	s.encoderDecoder.RespondWithData(ctx, res, accountRole)
+}
+
+// ArchiveHandler returns a handler that archives an account role.
+func (s *service) ArchiveHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	// determine account ID.
+	accountID := s.accountIDFetcher(req)
+	logger = logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	if !authorizedForAccount(sessionCtxData, accountID, authorization.ArchiveAccountRolesPermission) {
+		logger.Debug("requester not authorized to archive account roles")
+		s.encoderDecoder.EncodeUnauthorizedResponse(ctx, res)
+		return
+	}
+
+	// determine account role ID.
+	accountRoleID := s.accountRoleIDFetcher(req)
+	logger = logger.WithValue(keys.AccountRoleIDKey, accountRoleID)
+	tracing.AttachAccountRoleIDToSpan(span, accountRoleID)
+
+	err = s.accountRoleDataManager.ArchiveAccountRole(ctx, accountRoleID, accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "archiving account role")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountRoleArchiveEvent,
+		ActorUserID:      sessionCtxData.Requester.UserID,
+		BelongsToAccount: accountID,
+		ResourceType:     types.AccountRoleResourceType,
+		ResourceID:       accountRoleID,
+	})
+
+	// encode our response and peace.
+	res.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,597 @@
+package accountroles
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+func Test_authorizedForAccount(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with permission", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		assert.True(t, authorizedForAccount(helper.sessionCtxData, helper.exampleAccount.ID, authorization.CreateAccountRolesPermission))
+	})
+
+	T.Run("without permission", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeMemberOnly()
+
+		assert.False(t, authorizedForAccount(helper.sessionCtxData, helper.exampleAccount.ID, authorization.CreateAccountRolesPermission))
+	})
+
+	T.Run("for another account", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		assert.False(t, authorizedForAccount(helper.sessionCtxData, fakes.BuildFakeID(), authorization.ReadAccountRolesPermission))
+	})
+
+	T.Run("as service admin", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.Requester.ServicePermissions = authorization.NewServiceRolePermissionChecker(authorization.ServiceAdminRole.String())
+
+		assert.True(t, authorizedForAccount(helper.sessionCtxData, fakes.BuildFakeID(), authorization.ReadAccountRolesPermission))
+	})
+}
+
+func TestAccountRolesService_ListHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRoles",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return(fakes.BuildFakeAccountRoleList(), nil)
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("without permission", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeMemberOnly()
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with no rows returned", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRoles",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return((*types.AccountRoleList)(nil), sql.ErrNoRows)
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+
+	T.Run("with error retrieving account roles from database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRoles",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return((*types.AccountRoleList)(nil), errors.New("blah"))
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+}
+
+func TestAccountRolesService_CreateHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleInput := fakes.BuildFakeAccountRoleCreationInputFromAccountRole(helper.exampleAccountRole)
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"CreateAccountRole",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AccountRoleCreationInput) bool {
+				return input.ID != "" && input.BelongsToAccount == helper.exampleAccount.ID
+			}),
+		).Return(helper.exampleAccountRole, nil)
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.CreateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusCreated, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.CreateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("without input attached", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(nil))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.CreateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with invalid input attached", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleInput := fakes.BuildFakeAccountRoleCreationInputFromAccountRole(helper.exampleAccountRole)
+		exampleInput.Permissions = []string{authorization.CycleCookieSecretPermission.ID()}
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.CreateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("without permission", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeMemberOnly()
+
+		exampleInput := fakes.BuildFakeAccountRoleCreationInputFromAccountRole(helper.exampleAccountRole)
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.CreateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleInput := fakes.BuildFakeAccountRoleCreationInputFromAccountRole(helper.exampleAccountRole)
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"CreateAccountRole",
+			testutils.ContextMatcher,
+			mock.IsType(&types.AccountRoleCreationInput{}),
+		).Return((*types.AccountRole)(nil), errors.New("blah"))
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.CreateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+}
+
+func TestAccountRolesService_ReadHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRole",
+			testutils.ContextMatcher,
+			helper.exampleAccountRole.ID,
+			helper.exampleAccount.ID,
+		).Return(helper.exampleAccountRole, nil)
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.ReadHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ReadHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("without permission", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeMemberOnly()
+
+		helper.service.ReadHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with no such account role in database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRole",
+			testutils.ContextMatcher,
+			helper.exampleAccountRole.ID,
+			helper.exampleAccount.ID,
+		).Return((*types.AccountRole)(nil), sql.ErrNoRows)
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.ReadHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+
+	T.Run("with error reading from database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRole",
+			testutils.ContextMatcher,
+			helper.exampleAccountRole.ID,
+			helper.exampleAccount.ID,
+		).Return((*types.AccountRole)(nil), errors.New("blah"))
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.ReadHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+}
+
+func TestAccountRolesService_UpdateHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleInput := fakes.BuildFakeAccountRoleUpdateInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPut, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRole",
+			testutils.ContextMatcher,
+			helper.exampleAccountRole.ID,
+			helper.exampleAccount.ID,
+		).Return(helper.exampleAccountRole, nil)
+		accountRoleDataManager.On(
+			"UpdateAccountRole",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(updated *types.AccountRole) bool {
+				return updated.Name == exampleInput.Name
+			}),
+		).Return(nil)
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with invalid input attached", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, &types.AccountRoleUpdateInput{})
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPut, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("without permission", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeMemberOnly()
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, fakes.BuildFakeAccountRoleUpdateInput())
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPut, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with no such account role in database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, fakes.BuildFakeAccountRoleUpdateInput())
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPut, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRole",
+			testutils.ContextMatcher,
+			helper.exampleAccountRole.ID,
+			helper.exampleAccount.ID,
+		).Return((*types.AccountRole)(nil), sql.ErrNoRows)
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, fakes.BuildFakeAccountRoleUpdateInput())
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPut, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRole",
+			testutils.ContextMatcher,
+			helper.exampleAccountRole.ID,
+			helper.exampleAccount.ID,
+		).Return(helper.exampleAccountRole, nil)
+		accountRoleDataManager.On(
+			"UpdateAccountRole",
+			testutils.ContextMatcher,
+			mock.IsType(&types.AccountRole{}),
+		).Return(errors.New("blah"))
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+}
+
+func TestAccountRolesService_ArchiveHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"ArchiveAccountRole",
+			testutils.ContextMatcher,
+			helper.exampleAccountRole.ID,
+			helper.exampleAccount.ID,
+		).Return(nil)
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.ArchiveHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNoContent, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ArchiveHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("without permission", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeMemberOnly()
+
+		helper.service.ArchiveHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with no such account role in database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"ArchiveAccountRole",
+			testutils.ContextMatcher,
+			helper.exampleAccountRole.ID,
+			helper.exampleAccount.ID,
+		).Return(sql.ErrNoRows)
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.ArchiveHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"ArchiveAccountRole",
+			testutils.ContextMatcher,
+			helper.exampleAccountRole.ID,
+			helper.exampleAccount.ID,
+		).Return(errors.New("blah"))
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.ArchiveHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+}
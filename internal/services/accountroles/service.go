@@ -0,0 +1,52 @@
+package accountroles
+
+import (
+	"net/http"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing"
+	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	serviceName string = "account_roles_service"
+)
+
+var _ types.AccountRoleDataService = (*service)(nil)
+
+type (
+	// service handles account roles.
+	service struct {
+		logger                    logging.Logger
+		accountRoleDataManager    types.AccountRoleDataManager
+		auditLogEntryDataManager  types.AuditLogEntryDataManager
+		accountIDFetcher          func(*http.Request) string
+		accountRoleIDFetcher      func(*http.Request) string
+		sessionContextDataFetcher func(*http.Request) (*types.SessionContextData, error)
+		encoderDecoder            encoding.ServerEncoderDecoder
+		tracer                    tracing.Tracer
+	}
+)
+
+// ProvideService builds a new AccountRolesService.
+func ProvideService(
+	logger logging.Logger,
+	accountRoleDataManager types.AccountRoleDataManager,
+	auditLogEntryDataManager types.AuditLogEntryDataManager,
+	encoder encoding.ServerEncoderDecoder,
+	routeParamManager routing.RouteParamManager,
+) types.AccountRoleDataService {
+	return &service{
+		logger:                    logging.EnsureLogger(logger).WithName(serviceName),
+		accountIDFetcher:          routeParamManager.BuildRouteParamStringIDFetcher(AccountIDURIParamKey),
+		accountRoleIDFetcher:      routeParamManager.BuildRouteParamStringIDFetcher(AccountRoleIDURIParamKey),
+		sessionContextDataFetcher: authservice.FetchContextFromRequest,
+		accountRoleDataManager:    accountRoleDataManager,
+		auditLogEntryDataManager:  auditLogEntryDataManager,
+		encoderDecoder:            encoder,
+		tracer:                    tracing.NewTracer(serviceName),
+	}
+}
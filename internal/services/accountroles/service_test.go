@@ -0,0 +1,64 @@
+package accountroles
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mockencoding "gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding/mock"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	mockrouting "gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing/mock"
+	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+func buildTestService() *service {
+	auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+	auditLogEntryDataManager.On(
+		"CreateAuditLogEntry",
+		testutils.ContextMatcher,
+		mock.MatchedBy(testutils.AuditLogEntryCreationInputMatcher),
+	).Return(nil).Maybe()
+
+	return &service{
+		logger:                   logging.NewNoopLogger(),
+		accountRoleDataManager:   &mocktypes.AccountRoleDataManager{},
+		auditLogEntryDataManager: auditLogEntryDataManager,
+		accountIDFetcher:         func(req *http.Request) string { return "" },
+		accountRoleIDFetcher:     func(req *http.Request) string { return "" },
+		encoderDecoder:           mockencoding.NewMockEncoderDecoder(),
+		tracer:                   tracing.NewTracer("test"),
+	}
+}
+
+func TestProvideAccountRolesService(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		rpm := mockrouting.NewRouteParamManager()
+		rpm.On(
+			"BuildRouteParamStringIDFetcher",
+			AccountIDURIParamKey,
+		).Return(func(*http.Request) string { return "" })
+		rpm.On(
+			"BuildRouteParamStringIDFetcher",
+			AccountRoleIDURIParamKey,
+		).Return(func(*http.Request) string { return "" })
+
+		s := ProvideService(
+			logging.NewNoopLogger(),
+			&mocktypes.AccountRoleDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
+			mockencoding.NewMockEncoderDecoder(),
+			rpm,
+		)
+		assert.NotNil(t, s)
+
+		mock.AssertExpectationsForObjects(t, rpm)
+	})
+}
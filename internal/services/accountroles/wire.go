@@ -0,0 +1,10 @@
+package accountroles
+
+import (
+	"github.com/google/wire"
+)
+
+// Providers is our collection of what we provide to other services.
+var Providers = wire.NewSet(
+	ProvideService,
+)
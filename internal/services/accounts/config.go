@@ -12,8 +12,9 @@
 type Config struct {
 	_ struct{}
 
-	Logging            logging.Config `json:"logging" mapstructure:"logging" toml:"logging,omitempty"`
-	PreWritesTopicName string         `json:"pre_writes_topic_name" mapstructure:"pre_writes_topic_name" toml:"pre_writes_topic_name,omitempty"`
+	Logging              logging.Config `json:"logging" mapstructure:"logging" toml:"logging,omitempty"`
+	PreWritesTopicName   string         `json:"pre_writes_topic_name" mapstructure:"pre_writes_topic_name" toml:"pre_writes_topic_name,omitempty"`
+	DataChangesTopicName string         `json:"data_changes_topic_name" mapstructure:"data_changes_topic_name" toml:"data_changes_topic_name,omitempty"`
 }
 
 var _ validation.ValidatableWithContext = (*Config)(nil)
@@ -25,5 +26,6 @@ func (cfg *Config) ValidateWithContext(ctx context.Context) error {
 		cfg,
 		validation.Field(&cfg.Logging, validation.Required),
 		validation.Field(&cfg.PreWritesTopicName, validation.Required),
+		validation.Field(&cfg.DataChangesTopicName, validation.Required),
 	)
 }
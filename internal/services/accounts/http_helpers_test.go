@@ -5,6 +5,7 @@
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -16,12 +17,14 @@
 )
 
 type accountsServiceHTTPRoutesTestHelper struct {
-	ctx            context.Context
-	req            *http.Request
-	res            *httptest.ResponseRecorder
-	service        *service
-	exampleUser    *types.User
-	exampleAccount *types.Account
+	ctx                      context.Context
+	req                      *http.Request
+	res                      *httptest.ResponseRecorder
+	service                  *service
+	sessionCtxData           *types.SessionContextData
+	exampleUser              *types.User
+	exampleAccount           *types.Account
+	exampleAccountInvitation *types.AccountInvitation
 }
 
 func buildTestHelper(t *testing.T) *accountsServiceHTTPRoutesTestHelper {
@@ -34,8 +37,12 @@ func buildTestHelper(t *testing.T) *accountsServiceHTTPRoutesTestHelper {
 	helper.exampleUser = fakes.BuildFakeUser()
 	helper.exampleAccount = fakes.BuildFakeAccount()
 	helper.exampleAccount.BelongsToUser = helper.exampleUser.ID
+	helper.exampleAccountInvitation = fakes.BuildFakeAccountInvitation()
+	helper.exampleAccountInvitation.ToUser = &helper.exampleUser.ID
+	helper.exampleAccountInvitation.DestinationAccount = helper.exampleAccount.ID
+	helper.exampleAccountInvitation.ExpiresAt = uint64(time.Now().Add(time.Hour).Unix())
 
-	sessionCtxData := &types.SessionContextData{
+	helper.sessionCtxData = &types.SessionContextData{
 		Requester: types.RequesterInfo{
 			UserID:                helper.exampleUser.ID,
 			Reputation:            helper.exampleUser.ServiceAccountStatus,
@@ -50,7 +57,7 @@ func buildTestHelper(t *testing.T) *accountsServiceHTTPRoutesTestHelper {
 
 	helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
 	helper.service.sessionContextDataFetcher = func(*http.Request) (*types.SessionContextData, error) {
-		return sessionCtxData, nil
+		return helper.sessionCtxData, nil
 	}
 	helper.service.accountIDFetcher = func(req *http.Request) string {
 		return helper.exampleAccount.ID
@@ -58,6 +65,9 @@ func buildTestHelper(t *testing.T) *accountsServiceHTTPRoutesTestHelper {
 	helper.service.userIDFetcher = func(req *http.Request) string {
 		return helper.exampleUser.ID
 	}
+	helper.service.accountInvitationIDFetcher = func(req *http.Request) string {
+		return helper.exampleAccountInvitation.ID
+	}
 
 	var err error
 	helper.res = httptest.NewRecorder()
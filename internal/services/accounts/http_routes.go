@@ -1,14 +1,24 @@
 package accounts
 
 import (
+	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/segmentio/ksuid"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/audit"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
@@ -18,8 +28,34 @@
 	AccountIDURIParamKey = "accountID"
 	// UserIDURIParamKey is a standard string that we'll use to refer to user IDs with.
 	UserIDURIParamKey = "userID"
+	// AccountInvitationIDURIParamKey is a standard string that we'll use to refer to account invitation IDs with.
+	AccountInvitationIDURIParamKey = "accountInvitationID"
+
+	// accountInvitationLifetime is how long an invitation can go unanswered before it can no longer be accepted.
+	accountInvitationLifetime = 7 * 24 * time.Hour
+	// accountInvitationTokenSize is how many random bytes go into the token emailed with an invitation.
+	accountInvitationTokenSize = 32
+)
+
+var (
+	errInvalidAccountRole        = errors.New("invalid account role")
+	errInvalidAccountInvitation  = errors.New("invalid account invitation")
+	errInvitationRecipientAbsent = errors.New("invitation recipient not found")
+	errAlreadyAccountMember      = errors.New("user is already a member of the account")
 )
 
+// authorizedForAccount returns whether the requester holds a given permission in the account a request is about,
+// which is not necessarily the account they currently have active.
+func authorizedForAccount(sessionCtxData *types.SessionContextData, accountID string, perm authorization.Permission) bool {
+	if sessionCtxData.Requester.ServicePermissions != nil && sessionCtxData.Requester.ServicePermissions.IsServiceAdmin() {
+		return true
+	}
+
+	checker, ok := sessionCtxData.AccountPermissions[accountID]
+
+	return ok && checker.HasPermission(perm)
+}
+
 // ListHandler is our list route.
 func (s *service) ListHandler(res http.ResponseWriter, req *http.Request) {
 	ctx, span := s.tracer.StartSpan(req.Context())
@@ -113,6 +149,14 @@ func (s *service) CreateHandler(res http.ResponseWriter, req *http.Request) {
 	logger.Debug("created account")
 	s.accountCounter.Increment(ctx)
 
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountCreationEvent,
+		ActorUserID:      requester,
+		BelongsToAccount: account.ID,
+		ResourceType:     types.AccountResourceType,
+		ResourceID:       account.ID,
+	})
+
 	s.encoderDecoder.EncodeResponseWithStatus(ctx, res, account, http.StatusCreated)
 }
 
@@ -151,11 +195,14 @@ func (s *service) ReadHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	res.Header().Set(encoding.ETagHeaderKey, encoding.ETagForVersion(account.Version))
+
 	// encode our response and peace.
 	s.encoderDecoder.RespondWithData(ctx, res, account)
 }
 
-// UpdateHandler returns a handler that updates an account.
+// UpdateHandler returns a handler that updates an account. Requests must carry an If-Match header with the account's
+// ETag, and are answered with 428 without one, or 412 if the account has changed since.
 func (s *service) UpdateHandler(res http.ResponseWriter, req *http.Request) {
 	ctx, span := s.tracer.StartSpan(req.Context())
 	defer span.End()
@@ -194,6 +241,13 @@ func (s *service) UpdateHandler(res http.ResponseWriter, req *http.Request) {
 	logger = logger.WithValue(keys.AccountIDKey, accountID)
 	tracing.AttachAccountIDToSpan(span, accountID)
 
+	// updates have to say which version of the account they were made against, or they could clobber one they never saw.
+	if !encoding.IfMatchProvided(req) {
+		logger.Debug("update made without If-Match header")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "If-Match header required", http.StatusPreconditionRequired)
+		return
+	}
+
 	// fetch account from database.
 	account, err := s.accountDataManager.GetAccount(ctx, accountID, requester)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -205,16 +259,38 @@ func (s *service) UpdateHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// make sure nobody has changed the account since the requester read it.
+	if !encoding.IfMatchAllows(req, account.Version) {
+		logger.WithValue("version", account.Version).Debug("account has changed since it was read")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "account has changed since it was read", http.StatusPreconditionFailed)
+		return
+	}
+
 	// update the data structure.
-	account.Update(input)
+	changes := account.Update(input)
 
 	// update account in database.
-	if err = s.accountDataManager.UpdateAccount(ctx, account); err != nil {
+	if err = s.accountDataManager.UpdateAccount(ctx, account); errors.Is(err, database.ErrVersionConflict) {
+		logger.Debug("account changed while it was being updated")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "account has changed since it was read", http.StatusPreconditionFailed)
+		return
+	} else if err != nil {
 		observability.AcknowledgeError(err, logger, span, "updating account")
 		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
 		return
 	}
 
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountUpdateEvent,
+		ActorUserID:      requester,
+		BelongsToAccount: account.ID,
+		ResourceType:     types.AccountResourceType,
+		ResourceID:       account.ID,
+		Changes:          changes,
+	})
+
+	res.Header().Set(encoding.ETagHeaderKey, encoding.ETagForVersion(account.Version))
+
 	// encode our response and peace.
 	s.encoderDecoder.RespondWithData(ctx, res, account)
 }
@@ -258,66 +334,18 @@ func (s *service) ArchiveHandler(res http.ResponseWriter, req *http.Request) {
 	// notify relevant parties.
 	s.accountCounter.Decrement(ctx)
 
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountArchiveEvent,
+		ActorUserID:      requester,
+		BelongsToAccount: accountID,
+		ResourceType:     types.AccountResourceType,
+		ResourceID:       accountID,
+	})
+
 	// encode our response and peace.
 	res.WriteHeader(http.StatusNoContent)
 }
 
-// AddMemberHandler is our account creation route.
-func (s *service) AddMemberHandler(res http.ResponseWriter, req *http.Request) {
-	ctx, span := s.tracer.StartSpan(req.Context())
-	defer span.End()
-
-	logger := s.logger.WithRequest(req)
-	tracing.AttachRequestToSpan(span, req)
-
-	// determine user ID.
-	sessionCtxData, err := s.sessionContextDataFetcher(req)
-	if err != nil {
-		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
-		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
-		return
-	}
-
-	// check session context data for parsed input struct.
-	input := new(types.AddUserToAccountInput)
-	if err = s.encoderDecoder.DecodeRequest(ctx, req, input); err != nil {
-		observability.AcknowledgeError(err, logger, span, "decoding request body")
-		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid request content", http.StatusBadRequest)
-		return
-	}
-	input.ID = ksuid.New().String()
-
-	if err = input.ValidateWithContext(ctx); err != nil {
-		logger.WithValue(keys.ValidationErrorKey, err).Debug("invalid input attached to request")
-		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	requester := sessionCtxData.Requester.UserID
-	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
-	logger = logger.WithValue(keys.RequesterIDKey, requester)
-
-	accountID := s.accountIDFetcher(req)
-	tracing.AttachAccountIDToSpan(span, accountID)
-	logger = logger.WithValue(keys.AccountIDKey, accountID)
-
-	preWrite := &types.PreWriteMessage{
-		DataType:                types.UserMembershipDataType,
-		UserMembership:          input,
-		AttributableToUserID:    sessionCtxData.Requester.UserID,
-		AttributableToAccountID: accountID,
-	}
-	if err = s.preWritesPublisher.Publish(ctx, preWrite); err != nil {
-		observability.AcknowledgeError(err, logger, span, "publishing item write message")
-		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
-		return
-	}
-
-	pwr := types.PreWriteResponse{ID: input.ID}
-
-	s.encoderDecoder.EncodeResponseWithStatus(ctx, res, pwr, http.StatusAccepted)
-}
-
 // ModifyMemberPermissionsHandler is our account creation route.
 func (s *service) ModifyMemberPermissionsHandler(res http.ResponseWriter, req *http.Request) {
 	ctx, span := s.tracer.StartSpan(req.Context())
@@ -360,6 +388,16 @@ func (s *service) ModifyMemberPermissionsHandler(res http.ResponseWriter, req *h
 	logger = logger.WithValue(keys.UserIDKey, userID)
 	tracing.AttachUserIDToSpan(span, userID)
 
+	if err = s.validateAccountRoles(ctx, accountID, input.NewRoles); errors.Is(err, errInvalidAccountRole) {
+		logger.Debug("invalid role provided")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid role", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "validating account roles")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
 	// create account in database.
 	if err = s.accountMembershipDataManager.ModifyUserPermissions(ctx, accountID, userID, input); err != nil {
 		observability.AcknowledgeError(err, logger, span, "modifying user permissions")
@@ -367,9 +405,57 @@ func (s *service) ModifyMemberPermissionsHandler(res http.ResponseWriter, req *h
 		return
 	}
 
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountMemberPermissionsModifiedEvent,
+		ActorUserID:      requester,
+		BelongsToAccount: accountID,
+		ResourceType:     types.UserResourceType,
+		ResourceID:       userID,
+		Changes: []*types.FieldChangeSummary{
+			{FieldName: "roles", NewValue: input.NewRoles},
+		},
+	})
+
+	dcm := &types.DataChangeMessage{
+		MessageType: types.UpdatedMessageType,
+		DataType:    types.UserMembershipDataType,
+		UserMembership: &types.AccountUserMembership{
+			BelongsToUser:    userID,
+			BelongsToAccount: accountID,
+			AccountRoles:     input.NewRoles,
+		},
+		AttributableToUserID:    requester,
+		AttributableToAccountID: accountID,
+	}
+
+	// the change already happened, so failing to announce it shouldn't fail the request.
+	if err = s.dataChangesPublisher.Publish(ctx, dcm); err != nil {
+		observability.AcknowledgeError(err, logger, span, "publishing permissions modification message")
+	}
+
 	res.WriteHeader(http.StatusAccepted)
 }
 
+// validateAccountRoles ensures that any role that isn't built in is a custom role belonging to a given account.
+func (s *service) validateAccountRoles(ctx context.Context, accountID string, roles []string) error {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	for _, role := range roles {
+		if authorization.IsBuiltInAccountRole(role) {
+			continue
+		}
+
+		if _, err := s.accountRoleDataManager.GetAccountRole(ctx, role, accountID); errors.Is(err, sql.ErrNoRows) {
+			return errInvalidAccountRole
+		} else if err != nil {
+			return observability.PrepareError(err, s.logger.WithValue(keys.AccountRoleIDKey, role), span, "fetching account role")
+		}
+	}
+
+	return nil
+}
+
 // TransferAccountOwnershipHandler is our account creation route.
 func (s *service) TransferAccountOwnershipHandler(res http.ResponseWriter, req *http.Request) {
 	ctx, span := s.tracer.StartSpan(req.Context())
@@ -415,9 +501,62 @@ func (s *service) TransferAccountOwnershipHandler(res http.ResponseWriter, req *
 		return
 	}
 
+	dcm := &types.DataChangeMessage{
+		MessageType:             types.OwnershipTransferredMessageType,
+		DataType:                types.UserMembershipDataType,
+		OwnershipTransfer:       input,
+		AttributableToUserID:    requester,
+		AttributableToAccountID: accountID,
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountOwnershipTransferEvent,
+		ActorUserID:      requester,
+		BelongsToAccount: accountID,
+		ResourceType:     types.AccountResourceType,
+		ResourceID:       accountID,
+		Changes: []*types.FieldChangeSummary{
+			{FieldName: "owner", OldValue: input.CurrentOwner, NewValue: input.NewOwner},
+		},
+	})
+
+	// the transfer already happened, so failing to announce it shouldn't fail the request.
+	if err = s.dataChangesPublisher.Publish(ctx, dcm); err != nil {
+		observability.AcknowledgeError(err, logger, span, "publishing ownership transfer message")
+	}
+
+	s.sendOwnershipTransferAlert(ctx, logger, accountID, input.NewOwner)
+
 	res.WriteHeader(http.StatusAccepted)
 }
 
+// sendOwnershipTransferAlert lets an account's contact know that the account changed hands. The transfer
+// already happened, so failing to do so is only logged.
+func (s *service) sendOwnershipTransferAlert(ctx context.Context, logger logging.Logger, accountID, newOwnerID string) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	account, err := s.accountDataManager.GetAccount(ctx, accountID, newOwnerID)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching account to alert about ownership transfer")
+		return
+	}
+
+	if account.ContactEmail == "" {
+		return
+	}
+
+	msg, err := s.emailRenderer.BuildSecurityAlertEmail(account.ContactEmail, account.Name, types.AccountOwnershipTransferEvent)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "rendering ownership transfer alert email")
+		return
+	}
+
+	if err = s.emailer.SendEmail(ctx, msg); err != nil {
+		observability.AcknowledgeError(err, logger, span, "sending ownership transfer alert email")
+	}
+}
+
 // RemoveMemberHandler is our account creation route.
 func (s *service) RemoveMemberHandler(res http.ResponseWriter, req *http.Request) {
 	ctx, span := s.tracer.StartSpan(req.Context())
@@ -457,6 +596,30 @@ func (s *service) RemoveMemberHandler(res http.ResponseWriter, req *http.Request
 		return
 	}
 
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountMemberRemovedEvent,
+		ActorUserID:      requester,
+		BelongsToAccount: accountID,
+		ResourceType:     types.UserResourceType,
+		ResourceID:       userID,
+	})
+
+	dcm := &types.DataChangeMessage{
+		MessageType: types.ArchivedMessageType,
+		DataType:    types.UserMembershipDataType,
+		UserMembership: &types.AccountUserMembership{
+			BelongsToUser:    userID,
+			BelongsToAccount: accountID,
+		},
+		AttributableToUserID:    requester,
+		AttributableToAccountID: accountID,
+	}
+
+	// the removal already happened, so failing to announce it shouldn't fail the request.
+	if err = s.dataChangesPublisher.Publish(ctx, dcm); err != nil {
+		observability.AcknowledgeError(err, logger, span, "publishing member removal message")
+	}
+
 	res.WriteHeader(http.StatusAccepted)
 }
 
@@ -493,3 +656,625 @@ func (s *service) MarkAsDefaultAccountHandler(res http.ResponseWriter, req *http
 
 	res.WriteHeader(http.StatusAccepted)
 }
+
+// InviteUserToAccount invites a user to join an account with a given set of roles. Users can be invited by username,
+// or by email address, in which case they needn't have signed up yet.
+func (s *service) InviteUserToAccount(ctx context.Context, accountID, inviterID string, input *types.AccountInvitationCreationRequestInput) (*types.AccountInvitation, error) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	tracing.AttachAccountIDToSpan(span, accountID)
+	logger := s.logger.WithValue(keys.AccountIDKey, accountID).WithValue(keys.RequesterIDKey, inviterID)
+
+	if err := s.validateAccountRoles(ctx, accountID, input.AccountRoles); err != nil {
+		return nil, err
+	}
+
+	dbInput := &types.AccountInvitationDatabaseCreationInput{
+		ID:                 ksuid.New().String(),
+		FromUser:           inviterID,
+		ToEmail:            input.ToEmail,
+		DestinationAccount: accountID,
+		Note:               input.Note,
+		AccountRoles:       input.AccountRoles,
+		ExpiresAt:          uint64(time.Now().Add(accountInvitationLifetime).Unix()),
+	}
+
+	recipientAddress := input.ToEmail
+
+	var token string
+
+	if input.ToUsername == "" {
+		// nobody can be presumed to own an email address, so the invitation goes to whoever holds the token sent to it.
+		var err error
+		if token, err = s.secretGenerator.GenerateBase32EncodedString(ctx, accountInvitationTokenSize); err != nil {
+			return nil, observability.PrepareError(err, logger, span, "generating account invitation token")
+		}
+
+		dbInput.TokenHash = hashAccountInvitationToken(token)
+	} else {
+		invitee, err := s.userDataManager.GetUserByUsername(ctx, input.ToUsername)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errInvitationRecipientAbsent
+		} else if err != nil {
+			return nil, observability.PrepareError(err, logger, span, "fetching invitee")
+		}
+
+		logger = logger.WithValue(keys.UserIDKey, invitee.ID)
+
+		isMember, err := s.accountMembershipDataManager.UserIsMemberOfAccount(ctx, invitee.ID, accountID)
+		if err != nil {
+			return nil, observability.PrepareError(err, logger, span, "checking invitee account membership")
+		} else if isMember {
+			return nil, errAlreadyAccountMember
+		}
+
+		// the invitee's email address is only used to notify them, so that inviting someone doesn't reveal it.
+		dbInput.ToUser = &invitee.ID
+		recipientAddress = invitee.EmailAddress
+	}
+
+	invitation, err := s.accountInvitationDataManager.CreateAccountInvitation(ctx, dbInput)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "creating account invitation")
+	}
+
+	logger = logger.WithValue(keys.AccountInvitationIDKey, invitation.ID)
+	tracing.AttachAccountInvitationIDToSpan(span, invitation.ID)
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountInvitationCreationEvent,
+		ActorUserID:      inviterID,
+		BelongsToAccount: accountID,
+		ResourceType:     types.AccountInvitationResourceType,
+		ResourceID:       invitation.ID,
+		Changes: []*types.FieldChangeSummary{
+			{FieldName: "accountRoles", NewValue: invitation.AccountRoles},
+		},
+	})
+
+	if recipientAddress != "" {
+		s.sendAccountInvitationEmail(ctx, logger, invitation, recipientAddress, token)
+	}
+
+	return invitation, nil
+}
+
+// hashAccountInvitationToken hashes an account invitation token for storage. Tokens are randomly generated and only
+// usable once, so a fast hash is sufficient.
+func hashAccountInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
+
+// sendAccountInvitationEmail lets someone know they've been invited to an account. The invitation can be found
+// in the application regardless, so failing to send it is only logged.
+func (s *service) sendAccountInvitationEmail(ctx context.Context, logger logging.Logger, invitation *types.AccountInvitation, toAddress, token string) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	account, err := s.accountDataManager.GetAccount(ctx, invitation.DestinationAccount, invitation.FromUser)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching account for invitation email")
+		return
+	}
+
+	inviter, err := s.userDataManager.GetUser(ctx, invitation.FromUser)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching inviter for invitation email")
+		return
+	}
+
+	msg, err := s.emailRenderer.BuildAccountInviteEmail(toAddress, account.Name, inviter.Username, invitation.Note, token)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "rendering account invitation email")
+		return
+	}
+
+	if err = s.emailer.SendEmail(ctx, msg); err != nil {
+		observability.AcknowledgeError(err, logger, span, "sending account invitation email")
+	}
+}
+
+// InviteMemberHandler is our account invitation creation route.
+func (s *service) InviteMemberHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	input := new(types.AccountInvitationCreationRequestInput)
+	if err = s.encoderDecoder.DecodeRequest(ctx, req, input); err != nil {
+		observability.AcknowledgeError(err, logger, span, "decoding request body")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid request content", http.StatusBadRequest)
+		return
+	}
+
+	if err = input.ValidateWithContext(ctx); err != nil {
+		logger.WithValue(keys.ValidationErrorKey, err).Debug("invalid input attached to request")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	accountID := s.accountIDFetcher(req)
+	logger = logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	if !authorizedForAccount(sessionCtxData, accountID, authorization.AddMemberAccountPermission) {
+		logger.Debug("requester not authorized to invite account members")
+		s.encoderDecoder.EncodeUnauthorizedResponse(ctx, res)
+		return
+	}
+
+	invitation, err := s.InviteUserToAccount(ctx, accountID, sessionCtxData.Requester.UserID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, errInvalidAccountRole):
+			s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid role", http.StatusBadRequest)
+		case errors.Is(err, errInvitationRecipientAbsent):
+			s.encoderDecoder.EncodeErrorResponse(ctx, res, "no such user", http.StatusBadRequest)
+		case errors.Is(err, errAlreadyAccountMember):
+			s.encoderDecoder.EncodeErrorResponse(ctx, res, "user is already a member of this account", http.StatusBadRequest)
+		default:
+			observability.AcknowledgeError(err, logger, span, "inviting user to account")
+			s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		}
+
+		return
+	}
+
+	s.encoderDecoder.EncodeResponseWithStatus(ctx, res, invitation, http.StatusCreated)
+}
+
+// ListPendingInvitationsHandler is our route for listing the invitations an account has outstanding.
+func (s *service) ListPendingInvitationsHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	filter := types.ExtractQueryFilter(req)
+	logger := filter.AttachToLogger(s.logger.WithRequest(req))
+
+	tracing.AttachRequestToSpan(span, req)
+	tracing.AttachFilterToSpan(span, filter.Page, filter.Limit, string(filter.SortBy))
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	accountID := s.accountIDFetcher(req)
+	logger = logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	if !authorizedForAccount(sessionCtxData, accountID, authorization.AddMemberAccountPermission) {
+		logger.Debug("requester not authorized to read account invitations")
+		s.encoderDecoder.EncodeUnauthorizedResponse(ctx, res)
+		return
+	}
+
+	invitations, err := s.accountInvitationDataManager.GetPendingAccountInvitationsForAccount(ctx, accountID, filter)
+	if errors.Is(err, sql.ErrNoRows) {
+		// in the event no rows exist, return an empty list.
+		invitations = &types.AccountInvitationList{AccountInvitations: []*types.AccountInvitation{}}
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving account invitations")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// encode our response and peace.
+	s.encoderDecoder.RespondWithData(ctx, res, invitations)
+}
+
+// RevokeAccountInvitation withdraws an invitation an account has sent, provided it has yet to be answered.
+func (s *service) RevokeAccountInvitation(ctx context.Context, accountID, requesterID, accountInvitationID string) error {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+	logger := s.logger.WithValue(keys.AccountIDKey, accountID).
+		WithValue(keys.RequesterIDKey, requesterID).
+		WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+
+	invitation, err := s.accountInvitationDataManager.GetAccountInvitation(ctx, accountInvitationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errInvalidAccountInvitation
+	} else if err != nil {
+		return observability.PrepareError(err, logger, span, "fetching account invitation")
+	}
+
+	if invitation.DestinationAccount != accountID {
+		return errInvalidAccountInvitation
+	}
+
+	if err = s.accountInvitationDataManager.SetAccountInvitationStatus(ctx, invitation.ID, types.RevokedAccountInvitationStatus); errors.Is(err, sql.ErrNoRows) {
+		return errInvalidAccountInvitation
+	} else if err != nil {
+		return observability.PrepareError(err, logger, span, "revoking account invitation")
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountInvitationRevokedEvent,
+		ActorUserID:      requesterID,
+		BelongsToAccount: accountID,
+		ResourceType:     types.AccountInvitationResourceType,
+		ResourceID:       invitation.ID,
+		Changes: []*types.FieldChangeSummary{
+			{FieldName: "status", OldValue: invitation.Status, NewValue: types.RevokedAccountInvitationStatus},
+		},
+	})
+
+	return nil
+}
+
+// RevokeInvitationHandler is our account invitation revocation route.
+func (s *service) RevokeInvitationHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	accountID := s.accountIDFetcher(req)
+	logger = logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	accountInvitationID := s.accountInvitationIDFetcher(req)
+	logger = logger.WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+
+	if !authorizedForAccount(sessionCtxData, accountID, authorization.AddMemberAccountPermission) {
+		logger.Debug("requester not authorized to revoke account invitations")
+		s.encoderDecoder.EncodeUnauthorizedResponse(ctx, res)
+		return
+	}
+
+	if err = s.RevokeAccountInvitation(ctx, accountID, sessionCtxData.Requester.UserID, accountInvitationID); errors.Is(err, errInvalidAccountInvitation) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "revoking account invitation")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// ListReceivedInvitationsHandler is our route for listing the invitations awaiting the requester's response.
+func (s *service) ListReceivedInvitationsHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	invitations, err := s.accountInvitationDataManager.GetPendingAccountInvitationsForUser(ctx, sessionCtxData.Requester.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// in the event no rows exist, return an empty list.
+		invitations = &types.AccountInvitationList{AccountInvitations: []*types.AccountInvitation{}}
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving account invitations")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// encode our response and peace.
+	s.encoderDecoder.RespondWithData(ctx, res, invitations)
+}
+
+// fetchInvitationForRecipient fetches an account invitation that is still awaiting a response from a given user.
+// Invitations sent by email must be claimed before they can be answered.
+func (s *service) fetchInvitationForRecipient(ctx context.Context, userID, accountInvitationID string) (*types.AccountInvitation, error) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger.WithValue(keys.UserIDKey, userID).WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+
+	invitation, err := s.accountInvitationDataManager.GetAccountInvitation(ctx, accountInvitationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errInvalidAccountInvitation
+	} else if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "fetching account invitation")
+	}
+
+	if invitation.Status != types.PendingAccountInvitationStatus || invitation.IsExpired(uint64(time.Now().Unix())) {
+		return nil, errInvalidAccountInvitation
+	}
+
+	if invitation.ToUser == nil || *invitation.ToUser != userID {
+		return nil, errInvalidAccountInvitation
+	}
+
+	return invitation, nil
+}
+
+// ClaimAccountInvitation addresses the invitation a token was emailed for to a given user, who can then accept or
+// reject it like any other invitation they've received.
+func (s *service) ClaimAccountInvitation(ctx context.Context, userID, token string) (*types.AccountInvitation, error) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	tracing.AttachUserIDToSpan(span, userID)
+	logger := s.logger.WithValue(keys.UserIDKey, userID)
+
+	invitation, err := s.accountInvitationDataManager.ClaimAccountInvitation(ctx, hashAccountInvitationToken(token), userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errInvalidAccountInvitation
+	} else if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "claiming account invitation")
+	}
+
+	logger = logger.WithValue(keys.AccountInvitationIDKey, invitation.ID)
+	tracing.AttachAccountInvitationIDToSpan(span, invitation.ID)
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountInvitationClaimedEvent,
+		ActorUserID:      userID,
+		BelongsToAccount: invitation.DestinationAccount,
+		ResourceType:     types.AccountInvitationResourceType,
+		ResourceID:       invitation.ID,
+		Changes: []*types.FieldChangeSummary{
+			{FieldName: "toUser", NewValue: userID},
+		},
+	})
+
+	return invitation, nil
+}
+
+// ClaimInvitationHandler is our route for claiming an invitation that was sent to the requester's email address.
+func (s *service) ClaimInvitationHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	input := new(types.AccountInvitationClaimInput)
+	if err = s.encoderDecoder.DecodeRequest(ctx, req, input); err != nil {
+		observability.AcknowledgeError(err, logger, span, "decoding request body")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid request content", http.StatusBadRequest)
+		return
+	}
+
+	if err = input.ValidateWithContext(ctx); err != nil {
+		logger.WithValue(keys.ValidationErrorKey, err).Debug("invalid input attached to request")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	invitation, err := s.ClaimAccountInvitation(ctx, sessionCtxData.Requester.UserID, input.Token)
+	if errors.Is(err, errInvalidAccountInvitation) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "claiming account invitation")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	s.encoderDecoder.RespondWithData(ctx, res, invitation)
+}
+
+// AcceptAccountInvitation accepts an invitation on behalf of the user it was sent to. The user only becomes a
+// member of the account once the resulting membership is written.
+func (s *service) AcceptAccountInvitation(ctx context.Context, userID, accountInvitationID string) error {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	tracing.AttachUserIDToSpan(span, userID)
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+	logger := s.logger.WithValue(keys.UserIDKey, userID).WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+
+	invitation, err := s.fetchInvitationForRecipient(ctx, userID, accountInvitationID)
+	if err != nil {
+		return err
+	}
+
+	logger = logger.WithValue(keys.AccountIDKey, invitation.DestinationAccount)
+
+	isMember, err := s.accountMembershipDataManager.UserIsMemberOfAccount(ctx, userID, invitation.DestinationAccount)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "checking account membership")
+	} else if isMember {
+		return errAlreadyAccountMember
+	}
+
+	// settle the invitation first, so that it can't be accepted twice.
+	if err = s.accountInvitationDataManager.SetAccountInvitationStatus(ctx, invitation.ID, types.AcceptedAccountInvitationStatus); errors.Is(err, sql.ErrNoRows) {
+		return errInvalidAccountInvitation
+	} else if err != nil {
+		return observability.PrepareError(err, logger, span, "accepting account invitation")
+	}
+
+	preWrite := &types.PreWriteMessage{
+		DataType: types.UserMembershipDataType,
+		UserMembership: &types.AddUserToAccountInput{
+			ID:           ksuid.New().String(),
+			Reason:       "accepted account invitation",
+			UserID:       userID,
+			AccountID:    invitation.DestinationAccount,
+			AccountRoles: invitation.AccountRoles,
+		},
+		AttributableToUserID:    userID,
+		AttributableToAccountID: invitation.DestinationAccount,
+		RequestID:               audit.RequestIDFromContext(ctx),
+	}
+	if err = s.preWritesPublisher.Publish(ctx, preWrite); err != nil {
+		return observability.PrepareError(err, logger, span, "publishing account membership write message")
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountInvitationAcceptedEvent,
+		ActorUserID:      userID,
+		BelongsToAccount: invitation.DestinationAccount,
+		ResourceType:     types.AccountInvitationResourceType,
+		ResourceID:       invitation.ID,
+		Changes: []*types.FieldChangeSummary{
+			{FieldName: "status", OldValue: invitation.Status, NewValue: types.AcceptedAccountInvitationStatus},
+		},
+	})
+
+	return nil
+}
+
+// AcceptInvitationHandler is our account invitation acceptance route.
+func (s *service) AcceptInvitationHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	accountInvitationID := s.accountInvitationIDFetcher(req)
+	logger = logger.WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+
+	if err = s.AcceptAccountInvitation(ctx, sessionCtxData.Requester.UserID, accountInvitationID); err != nil {
+		switch {
+		case errors.Is(err, errInvalidAccountInvitation):
+			s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		case errors.Is(err, errAlreadyAccountMember):
+			s.encoderDecoder.EncodeErrorResponse(ctx, res, "already a member of this account", http.StatusBadRequest)
+		default:
+			observability.AcknowledgeError(err, logger, span, "accepting account invitation")
+			s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		}
+
+		return
+	}
+
+	res.WriteHeader(http.StatusAccepted)
+}
+
+// RejectAccountInvitation rejects an invitation on behalf of the user it was sent to.
+func (s *service) RejectAccountInvitation(ctx context.Context, userID, accountInvitationID string) error {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	tracing.AttachUserIDToSpan(span, userID)
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+	logger := s.logger.WithValue(keys.UserIDKey, userID).WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+
+	invitation, err := s.fetchInvitationForRecipient(ctx, userID, accountInvitationID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.accountInvitationDataManager.SetAccountInvitationStatus(ctx, invitation.ID, types.RejectedAccountInvitationStatus); errors.Is(err, sql.ErrNoRows) {
+		return errInvalidAccountInvitation
+	} else if err != nil {
+		return observability.PrepareError(err, logger, span, "rejecting account invitation")
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.AccountInvitationRejectedEvent,
+		ActorUserID:      userID,
+		BelongsToAccount: invitation.DestinationAccount,
+		ResourceType:     types.AccountInvitationResourceType,
+		ResourceID:       invitation.ID,
+		Changes: []*types.FieldChangeSummary{
+			{FieldName: "status", OldValue: invitation.Status, NewValue: types.RejectedAccountInvitationStatus},
+		},
+	})
+
+	return nil
+}
+
+// RejectInvitationHandler is our account invitation rejection route.
+func (s *service) RejectInvitationHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	accountInvitationID := s.accountInvitationIDFetcher(req)
+	logger = logger.WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+
+	if err = s.RejectAccountInvitation(ctx, sessionCtxData.Requester.UserID, accountInvitationID); errors.Is(err, errInvalidAccountInvitation) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "rejecting account invitation")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	res.WriteHeader(http.StatusNoContent)
+}
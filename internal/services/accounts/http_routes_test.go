@@ -7,7 +7,9 @@
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	mock2 "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers/mock"
 
@@ -15,10 +17,15 @@
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
+	mockemail "gitlab.com/verygoodsoftwarenotvirus/todo/internal/email/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	mockencoding "gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	mockmetrics "gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics/mock"
+	mockrandom "gitlab.com/verygoodsoftwarenotvirus/todo/internal/random/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
 	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
@@ -294,6 +301,7 @@ func TestAccountsService_ReadHandler(T *testing.T) {
 		helper.service.ReadHandler(helper.res, helper.req)
 
 		assert.Equal(t, http.StatusOK, helper.res.Code, "expected %d in status response, got %d", http.StatusOK, helper.res.Code)
+		assert.Equal(t, encoding.ETagForVersion(helper.exampleAccount.Version), helper.res.Header().Get(encoding.ETagHeaderKey))
 
 		mock.AssertExpectationsForObjects(t, accountDataManager, encoderDecoder)
 	})
@@ -397,6 +405,8 @@ func TestAccountsService_UpdateHandler(T *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, helper.req)
 
+		helper.req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(helper.exampleAccount.Version))
+
 		accountDataManager := &mocktypes.AccountDataManager{}
 		accountDataManager.On(
 			"GetAccount",
@@ -414,6 +424,101 @@ func TestAccountsService_UpdateHandler(T *testing.T) {
 		helper.service.UpdateHandler(helper.res, helper.req)
 
 		assert.Equal(t, http.StatusOK, helper.res.Code, "expected %d in status response, got %d", http.StatusOK, helper.res.Code)
+		assert.Equal(t, encoding.ETagForVersion(helper.exampleAccount.Version), helper.res.Header().Get(encoding.ETagHeaderKey))
+
+		mock.AssertExpectationsForObjects(t, accountDataManager)
+	})
+
+	T.Run("with stale If-Match header", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		exampleCreationInput := fakes.BuildFakeAccountUpdateInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleCreationInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.exampleAccount.Version = 3
+		helper.req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(2))
+
+		accountDataManager := &mocktypes.AccountDataManager{}
+		accountDataManager.On(
+			"GetAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			helper.exampleUser.ID,
+		).Return(helper.exampleAccount, nil)
+		helper.service.accountDataManager = accountDataManager
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountDataManager)
+	})
+
+	T.Run("without If-Match header", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		exampleCreationInput := fakes.BuildFakeAccountUpdateInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleCreationInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		accountDataManager := &mocktypes.AccountDataManager{}
+		helper.service.accountDataManager = accountDataManager
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusPreconditionRequired, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountDataManager)
+	})
+
+	T.Run("with version conflict updating account", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		exampleCreationInput := fakes.BuildFakeAccountUpdateInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleCreationInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(helper.exampleAccount.Version))
+
+		accountDataManager := &mocktypes.AccountDataManager{}
+		accountDataManager.On(
+			"GetAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			helper.exampleUser.ID,
+		).Return(helper.exampleAccount, nil)
+		accountDataManager.On(
+			"UpdateAccount",
+			testutils.ContextMatcher,
+			mock.IsType(&types.Account{}),
+		).Return(database.ErrVersionConflict)
+		helper.service.accountDataManager = accountDataManager
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, helper.res.Code)
 
 		mock.AssertExpectationsForObjects(t, accountDataManager)
 	})
@@ -488,6 +593,8 @@ func TestAccountsService_UpdateHandler(T *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, helper.req)
 
+		helper.req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(helper.exampleAccount.Version))
+
 		accountDataManager := &mocktypes.AccountDataManager{}
 		accountDataManager.On(
 			"GetAccount",
@@ -517,6 +624,8 @@ func TestAccountsService_UpdateHandler(T *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, helper.req)
 
+		helper.req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(helper.exampleAccount.Version))
+
 		accountDataManager := &mocktypes.AccountDataManager{}
 		accountDataManager.On(
 			"GetAccount",
@@ -546,6 +655,8 @@ func TestAccountsService_UpdateHandler(T *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, helper.req)
 
+		helper.req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(helper.exampleAccount.Version))
+
 		helper.exampleAccount = fakes.BuildFakeAccount()
 		helper.exampleAccount.BelongsToUser = helper.exampleUser.ID
 
@@ -681,7 +792,7 @@ func TestAccountsService_ArchiveHandler(T *testing.T) {
 	})
 }
 
-func TestAccountsService_AddMemberHandler(T *testing.T) {
+func TestAccountsService_ModifyMemberPermissionsHandler(T *testing.T) {
 	T.Parallel()
 
 	T.Run("standard", func(t *testing.T) {
@@ -690,7 +801,7 @@ func TestAccountsService_AddMemberHandler(T *testing.T) {
 		helper := buildTestHelper(t)
 		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
 
-		exampleInput := fakes.BuildFakeAddUserToAccountInput()
+		exampleInput := fakes.BuildFakeUserPermissionModificationInput()
 		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
 
 		var err error
@@ -698,28 +809,47 @@ func TestAccountsService_AddMemberHandler(T *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, helper.req)
 
-		mockEventProducer := &mock2.Publisher{}
-		mockEventProducer.On(
+		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
+		accountMembershipDataManager.On(
+			"ModifyUserPermissions",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.exampleAccount.ID,
+			exampleInput,
+		).Return(nil)
+		helper.service.accountMembershipDataManager = accountMembershipDataManager
+
+		dataChangesPublisher := &mock2.Publisher{}
+		dataChangesPublisher.On(
 			"Publish",
 			testutils.ContextMatcher,
-			mock.MatchedBy(testutils.PreWriteMessageMatcher),
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.UpdatedMessageType &&
+					msg.DataType == types.UserMembershipDataType &&
+					msg.UserMembership.BelongsToUser == helper.exampleUser.ID &&
+					msg.UserMembership.BelongsToAccount == helper.exampleAccount.ID
+			}),
 		).Return(nil)
-		helper.service.preWritesPublisher = mockEventProducer
+		helper.service.dataChangesPublisher = dataChangesPublisher
 
-		helper.service.AddMemberHandler(helper.res, helper.req)
+		helper.service.ModifyMemberPermissionsHandler(helper.res, helper.req)
 
 		assert.Equal(t, http.StatusAccepted, helper.res.Code)
 
-		mock.AssertExpectationsForObjects(t, mockEventProducer)
+		mock.AssertExpectationsForObjects(t, accountMembershipDataManager, dataChangesPublisher)
 	})
 
-	T.Run("with error retrieving session context data", func(t *testing.T) {
+	T.Run("with custom account role", func(t *testing.T) {
 		t.Parallel()
 
 		helper := buildTestHelper(t)
 		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
 
-		exampleInput := fakes.BuildFakeAddUserToAccountInput()
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+		exampleAccountRole.BelongsToAccount = helper.exampleAccount.ID
+
+		exampleInput := fakes.BuildFakeUserPermissionModificationInput()
+		exampleInput.NewRoles = append(exampleInput.NewRoles, exampleAccountRole.ID)
 		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
 
 		var err error
@@ -727,36 +857,52 @@ func TestAccountsService_AddMemberHandler(T *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, helper.req)
 
-		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
-
-		helper.service.AddMemberHandler(helper.res, helper.req)
-
-		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
-	})
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRole",
+			testutils.ContextMatcher,
+			exampleAccountRole.ID,
+			helper.exampleAccount.ID,
+		).Return(exampleAccountRole, nil)
+		helper.service.accountRoleDataManager = accountRoleDataManager
 
-	T.Run("with missing input", func(t *testing.T) {
-		t.Parallel()
+		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
+		accountMembershipDataManager.On(
+			"ModifyUserPermissions",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.exampleAccount.ID,
+			exampleInput,
+		).Return(nil)
+		helper.service.accountMembershipDataManager = accountMembershipDataManager
 
-		helper := buildTestHelper(t)
-		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+		dataChangesPublisher := &mock2.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.UpdatedMessageType &&
+					msg.DataType == types.UserMembershipDataType &&
+					msg.UserMembership.BelongsToUser == helper.exampleUser.ID &&
+					msg.UserMembership.BelongsToAccount == helper.exampleAccount.ID
+			}),
+		).Return(nil)
+		helper.service.dataChangesPublisher = dataChangesPublisher
 
-		var err error
-		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(nil))
-		require.NoError(t, err)
-		require.NotNil(t, helper.req)
+		helper.service.ModifyMemberPermissionsHandler(helper.res, helper.req)
 
-		helper.service.AddMemberHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
 
-		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager, accountMembershipDataManager, dataChangesPublisher)
 	})
 
-	T.Run("with invalid input", func(t *testing.T) {
+	T.Run("with error publishing data change", func(t *testing.T) {
 		t.Parallel()
 
 		helper := buildTestHelper(t)
 		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
 
-		exampleInput := &types.AddUserToAccountInput{}
+		exampleInput := fakes.BuildFakeUserPermissionModificationInput()
 		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
 
 		var err error
@@ -764,18 +910,39 @@ func TestAccountsService_AddMemberHandler(T *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, helper.req)
 
-		helper.service.AddMemberHandler(helper.res, helper.req)
+		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
+		accountMembershipDataManager.On(
+			"ModifyUserPermissions",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.exampleAccount.ID,
+			exampleInput,
+		).Return(nil)
+		helper.service.accountMembershipDataManager = accountMembershipDataManager
+
+		dataChangesPublisher := &mock2.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.IsType(&types.DataChangeMessage{}),
+		).Return(errors.New("blah"))
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
+		helper.service.ModifyMemberPermissionsHandler(helper.res, helper.req)
 
-		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountMembershipDataManager, dataChangesPublisher)
 	})
 
-	T.Run("with error publishing to event queue", func(t *testing.T) {
+	T.Run("with nonexistent custom account role", func(t *testing.T) {
 		t.Parallel()
 
 		helper := buildTestHelper(t)
 		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
 
-		exampleInput := fakes.BuildFakeAddUserToAccountInput()
+		exampleInput := fakes.BuildFakeUserPermissionModificationInput()
+		exampleInput.NewRoles = []string{fakes.BuildFakeID()}
 		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
 
 		var err error
@@ -783,32 +950,30 @@ func TestAccountsService_AddMemberHandler(T *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, helper.req)
 
-		mockEventProducer := &mock2.Publisher{}
-		mockEventProducer.On(
-			"Publish",
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRole",
 			testutils.ContextMatcher,
-			mock.MatchedBy(testutils.PreWriteMessageMatcher),
-		).Return(errors.New("blah"))
-		helper.service.preWritesPublisher = mockEventProducer
+			exampleInput.NewRoles[0],
+			helper.exampleAccount.ID,
+		).Return((*types.AccountRole)(nil), sql.ErrNoRows)
+		helper.service.accountRoleDataManager = accountRoleDataManager
 
-		helper.service.AddMemberHandler(helper.res, helper.req)
+		helper.service.ModifyMemberPermissionsHandler(helper.res, helper.req)
 
-		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
 
-		mock.AssertExpectationsForObjects(t, mockEventProducer)
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
 	})
-}
-
-func TestAccountsService_ModifyMemberPermissionsHandler(T *testing.T) {
-	T.Parallel()
 
-	T.Run("standard", func(t *testing.T) {
+	T.Run("with error fetching custom account role", func(t *testing.T) {
 		t.Parallel()
 
 		helper := buildTestHelper(t)
 		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
 
 		exampleInput := fakes.BuildFakeUserPermissionModificationInput()
+		exampleInput.NewRoles = []string{fakes.BuildFakeID()}
 		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
 
 		var err error
@@ -816,21 +981,20 @@ func TestAccountsService_ModifyMemberPermissionsHandler(T *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, helper.req)
 
-		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
-		accountMembershipDataManager.On(
-			"ModifyUserPermissions",
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRole",
 			testutils.ContextMatcher,
-			helper.exampleUser.ID,
+			exampleInput.NewRoles[0],
 			helper.exampleAccount.ID,
-			exampleInput,
-		).Return(nil)
-		helper.service.accountMembershipDataManager = accountMembershipDataManager
+		).Return((*types.AccountRole)(nil), errors.New("blah"))
+		helper.service.accountRoleDataManager = accountRoleDataManager
 
 		helper.service.ModifyMemberPermissionsHandler(helper.res, helper.req)
 
-		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
 
-		mock.AssertExpectationsForObjects(t, accountMembershipDataManager)
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
 	})
 
 	T.Run("with missing input", func(t *testing.T) {
@@ -946,11 +1110,52 @@ func TestAccountsService_TransferAccountOwnershipHandler(T *testing.T) {
 		).Return(nil)
 		helper.service.accountMembershipDataManager = accountMembershipDataManager
 
+		dataChangesPublisher := &mock2.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.OwnershipTransferredMessageType && msg.OwnershipTransfer != nil && msg.OwnershipTransfer.NewOwner == exampleInput.NewOwner
+			}),
+		).Return(nil)
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.AccountOwnershipTransferEvent &&
+					input.BelongsToAccount == helper.exampleAccount.ID &&
+					len(input.Changes) == 1 && input.Changes[0].NewValue == exampleInput.NewOwner
+			}),
+		).Return(nil)
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		accountDataManager := &mocktypes.AccountDataManager{}
+		accountDataManager.On(
+			"GetAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			exampleInput.NewOwner,
+		).Return(helper.exampleAccount, nil)
+		helper.service.accountDataManager = accountDataManager
+
+		emailer := &mockemail.Emailer{}
+		emailer.On(
+			"SendEmail",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(details *email.OutboundMessageDetails) bool {
+				return details.ToAddress == helper.exampleAccount.ContactEmail
+			}),
+		).Return(nil)
+		helper.service.emailer = emailer
+
 		helper.service.TransferAccountOwnershipHandler(helper.res, helper.req)
 
 		assert.Equal(t, http.StatusAccepted, helper.res.Code)
 
-		mock.AssertExpectationsForObjects(t, accountMembershipDataManager)
+		mock.AssertExpectationsForObjects(t, accountMembershipDataManager, dataChangesPublisher, auditLogEntryDataManager, accountDataManager, emailer)
 	})
 
 	T.Run("without input", func(t *testing.T) {
@@ -1037,53 +1242,221 @@ func TestAccountsService_TransferAccountOwnershipHandler(T *testing.T) {
 
 		mock.AssertExpectationsForObjects(t, accountMembershipDataManager)
 	})
-}
-
-func TestAccountsService_RemoveMemberHandler(T *testing.T) {
-	T.Parallel()
 
-	T.Run("standard", func(t *testing.T) {
+	T.Run("with error publishing data change message", func(t *testing.T) {
 		t.Parallel()
 
 		helper := buildTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
 
-		exampleReason := t.Name()
+		exampleInput := fakes.BuildFakeTransferAccountOwnershipInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
 
 		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
 		accountMembershipDataManager.On(
-			"RemoveUserFromAccount",
+			"TransferAccountOwnership",
 			testutils.ContextMatcher,
-			helper.exampleUser.ID,
 			helper.exampleAccount.ID,
+			exampleInput,
 		).Return(nil)
 		helper.service.accountMembershipDataManager = accountMembershipDataManager
 
-		helper.req.URL.RawQuery = fmt.Sprintf("reason=%s", url.QueryEscape(exampleReason))
+		dataChangesPublisher := &mock2.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.IsType(&types.DataChangeMessage{}),
+		).Return(errors.New("blah"))
+		helper.service.dataChangesPublisher = dataChangesPublisher
 
-		helper.service.RemoveMemberHandler(helper.res, helper.req)
+		accountDataManager := &mocktypes.AccountDataManager{}
+		accountDataManager.On(
+			"GetAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			exampleInput.NewOwner,
+		).Return((*types.Account)(nil), errors.New("blah"))
+		helper.service.accountDataManager = accountDataManager
+
+		helper.service.TransferAccountOwnershipHandler(helper.res, helper.req)
 
 		assert.Equal(t, http.StatusAccepted, helper.res.Code)
 
-		mock.AssertExpectationsForObjects(t, accountMembershipDataManager)
+		mock.AssertExpectationsForObjects(t, accountMembershipDataManager, dataChangesPublisher, accountDataManager)
 	})
 
-	T.Run("with error retrieving session context data", func(t *testing.T) {
+	T.Run("with account without contact email", func(t *testing.T) {
 		t.Parallel()
 
 		helper := buildTestHelper(t)
-		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
 
-		encoderDecoder := mockencoding.NewMockEncoderDecoder()
-		encoderDecoder.On(
-			"EncodeErrorResponse",
-			testutils.ContextMatcher,
-			testutils.HTTPResponseWriterMatcher,
-			"unauthenticated",
-			http.StatusUnauthorized,
-		).Return()
-		helper.service.encoderDecoder = encoderDecoder
+		exampleInput := fakes.BuildFakeTransferAccountOwnershipInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
 
-		helper.service.RemoveMemberHandler(helper.res, helper.req)
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
+		accountMembershipDataManager.On(
+			"TransferAccountOwnership",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			exampleInput,
+		).Return(nil)
+		helper.service.accountMembershipDataManager = accountMembershipDataManager
+
+		dataChangesPublisher := &mock2.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.IsType(&types.DataChangeMessage{}),
+		).Return(nil)
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
+		helper.exampleAccount.ContactEmail = ""
+		accountDataManager := &mocktypes.AccountDataManager{}
+		accountDataManager.On(
+			"GetAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			exampleInput.NewOwner,
+		).Return(helper.exampleAccount, nil)
+		helper.service.accountDataManager = accountDataManager
+
+		emailer := &mockemail.Emailer{}
+		helper.service.emailer = emailer
+
+		helper.service.TransferAccountOwnershipHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountMembershipDataManager, dataChangesPublisher, accountDataManager, emailer)
+	})
+}
+
+func TestAccountsService_RemoveMemberHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleReason := t.Name()
+
+		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
+		accountMembershipDataManager.On(
+			"RemoveUserFromAccount",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.exampleAccount.ID,
+		).Return(nil)
+		helper.service.accountMembershipDataManager = accountMembershipDataManager
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.AccountMemberRemovedEvent &&
+					input.BelongsToAccount == helper.exampleAccount.ID &&
+					input.ResourceID == helper.exampleUser.ID
+			}),
+		).Return(nil)
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		dataChangesPublisher := &mock2.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.ArchivedMessageType &&
+					msg.DataType == types.UserMembershipDataType &&
+					msg.UserMembership.BelongsToUser == helper.exampleUser.ID &&
+					msg.UserMembership.BelongsToAccount == helper.exampleAccount.ID
+			}),
+		).Return(nil)
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
+		helper.req.URL.RawQuery = fmt.Sprintf("reason=%s", url.QueryEscape(exampleReason))
+
+		helper.service.RemoveMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountMembershipDataManager, auditLogEntryDataManager, dataChangesPublisher)
+	})
+
+	T.Run("with error publishing data change", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleReason := t.Name()
+
+		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
+		accountMembershipDataManager.On(
+			"RemoveUserFromAccount",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.exampleAccount.ID,
+		).Return(nil)
+		helper.service.accountMembershipDataManager = accountMembershipDataManager
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.AccountMemberRemovedEvent &&
+					input.BelongsToAccount == helper.exampleAccount.ID &&
+					input.ResourceID == helper.exampleUser.ID
+			}),
+		).Return(nil)
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		dataChangesPublisher := &mock2.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.IsType(&types.DataChangeMessage{}),
+		).Return(errors.New("blah"))
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
+		helper.req.URL.RawQuery = fmt.Sprintf("reason=%s", url.QueryEscape(exampleReason))
+
+		helper.service.RemoveMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountMembershipDataManager, auditLogEntryDataManager, dataChangesPublisher)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"EncodeErrorResponse",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			"unauthenticated",
+			http.StatusUnauthorized,
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.RemoveMemberHandler(helper.res, helper.req)
 
 		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
 
@@ -1199,3 +1572,1059 @@ func TestAccountsService_MarkAsDefaultAccountHandler(T *testing.T) {
 		mock.AssertExpectationsForObjects(t, accountMembershipDataManager, encoderDecoder)
 	})
 }
+
+func TestAccountsService_InviteMemberHandler(T *testing.T) {
+	T.Parallel()
+
+	buildRequest := func(t *testing.T, helper *accountsServiceHTTPRoutesTestHelper, input *types.AccountInvitationCreationRequestInput) {
+		t.Helper()
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, input)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.sessionCtxData.AccountPermissions[helper.exampleAccount.ID] = authorization.NewAccountRolePermissionChecker(authorization.AccountAdminRole.String())
+	}
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleInvitee := fakes.BuildFakeUser()
+		exampleInput := fakes.BuildFakeAccountInvitationCreationRequestInput()
+		exampleInput.ToUsername = exampleInvitee.Username
+		buildRequest(t, helper, exampleInput)
+
+		exampleInvitation := fakes.BuildFakeAccountInvitation()
+		exampleInvitation.ToUser = &exampleInvitee.ID
+		exampleInvitation.FromUser = helper.exampleUser.ID
+		exampleInvitation.DestinationAccount = helper.exampleAccount.ID
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			exampleInvitee.Username,
+		).Return(exampleInvitee, nil)
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
+		accountMembershipDataManager.On(
+			"UserIsMemberOfAccount",
+			testutils.ContextMatcher,
+			exampleInvitee.ID,
+			helper.exampleAccount.ID,
+		).Return(false, nil)
+		helper.service.accountMembershipDataManager = accountMembershipDataManager
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"CreateAccountInvitation",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AccountInvitationDatabaseCreationInput) bool {
+				return input.ToUser != nil && *input.ToUser == exampleInvitee.ID && input.ToEmail == ""
+			}),
+		).Return(exampleInvitation, nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		accountDataManager := &mocktypes.AccountDataManager{}
+		accountDataManager.On(
+			"GetAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			helper.exampleUser.ID,
+		).Return(helper.exampleAccount, nil)
+		helper.service.accountDataManager = accountDataManager
+
+		emailer := &mockemail.Emailer{}
+		emailer.On(
+			"SendEmail",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(details *email.OutboundMessageDetails) bool {
+				return details.ToAddress == exampleInvitee.EmailAddress
+			}),
+		).Return(nil)
+		helper.service.emailer = emailer
+
+		helper.service.InviteMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusCreated, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, accountMembershipDataManager, accountInvitationDataManager, accountDataManager, emailer)
+	})
+
+	T.Run("with email address", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleInput := fakes.BuildFakeAccountInvitationCreationRequestInput()
+		exampleInput.ToUsername = ""
+		exampleInput.ToEmail = "invitee@todo.verygoodsoftwarenotvirus.ru"
+		buildRequest(t, helper, exampleInput)
+
+		exampleInvitation := fakes.BuildFakeAccountInvitation()
+		exampleInvitation.ToUser = nil
+		exampleInvitation.ToEmail = exampleInput.ToEmail
+		exampleInvitation.FromUser = helper.exampleUser.ID
+		exampleInvitation.DestinationAccount = helper.exampleAccount.ID
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"CreateAccountInvitation",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AccountInvitationDatabaseCreationInput) bool {
+				return input.ToUser == nil && input.ToEmail == exampleInput.ToEmail && input.TokenHash != ""
+			}),
+		).Return(exampleInvitation, nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		accountDataManager := &mocktypes.AccountDataManager{}
+		accountDataManager.On(
+			"GetAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			helper.exampleUser.ID,
+		).Return(helper.exampleAccount, nil)
+		helper.service.accountDataManager = accountDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		emailer := &mockemail.Emailer{}
+		emailer.On(
+			"SendEmail",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(details *email.OutboundMessageDetails) bool {
+				return details.ToAddress == exampleInput.ToEmail && strings.Contains(details.HTMLContent, "token=")
+			}),
+		).Return(errors.New("blah"))
+		helper.service.emailer = emailer
+
+		helper.service.InviteMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusCreated, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager, accountDataManager, userDataManager, emailer)
+	})
+
+	T.Run("with error generating invitation token", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleInput := fakes.BuildFakeAccountInvitationCreationRequestInput()
+		exampleInput.ToUsername = ""
+		exampleInput.ToEmail = "invitee@todo.verygoodsoftwarenotvirus.ru"
+		buildRequest(t, helper, exampleInput)
+
+		secretGenerator := &mockrandom.Generator{}
+		secretGenerator.On(
+			"GenerateBase32EncodedString",
+			testutils.ContextMatcher,
+			accountInvitationTokenSize,
+		).Return("", errors.New("blah"))
+		helper.service.secretGenerator = secretGenerator
+
+		helper.service.InviteMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, secretGenerator)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		buildRequest(t, helper, fakes.BuildFakeAccountInvitationCreationRequestInput())
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.InviteMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with missing input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(nil))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.InviteMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		buildRequest(t, helper, &types.AccountInvitationCreationRequestInput{})
+
+		helper.service.InviteMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("without permission to add members", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		buildRequest(t, helper, fakes.BuildFakeAccountInvitationCreationRequestInput())
+		helper.sessionCtxData.AccountPermissions[helper.exampleAccount.ID] = authorization.NewAccountRolePermissionChecker(authorization.AccountMemberRole.String())
+
+		helper.service.InviteMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with nonexistent custom account role", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleInput := fakes.BuildFakeAccountInvitationCreationRequestInput()
+		exampleInput.AccountRoles = []string{fakes.BuildFakeID()}
+		buildRequest(t, helper, exampleInput)
+
+		accountRoleDataManager := &mocktypes.AccountRoleDataManager{}
+		accountRoleDataManager.On(
+			"GetAccountRole",
+			testutils.ContextMatcher,
+			exampleInput.AccountRoles[0],
+			helper.exampleAccount.ID,
+		).Return((*types.AccountRole)(nil), sql.ErrNoRows)
+		helper.service.accountRoleDataManager = accountRoleDataManager
+
+		helper.service.InviteMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountRoleDataManager)
+	})
+
+	T.Run("with nonexistent invitee", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleInput := fakes.BuildFakeAccountInvitationCreationRequestInput()
+		buildRequest(t, helper, exampleInput)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			exampleInput.ToUsername,
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		helper.service.userDataManager = userDataManager
+
+		helper.service.InviteMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager)
+	})
+
+	T.Run("with invitee already a member", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleInvitee := fakes.BuildFakeUser()
+		exampleInput := fakes.BuildFakeAccountInvitationCreationRequestInput()
+		exampleInput.ToUsername = exampleInvitee.Username
+		buildRequest(t, helper, exampleInput)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			exampleInvitee.Username,
+		).Return(exampleInvitee, nil)
+		helper.service.userDataManager = userDataManager
+
+		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
+		accountMembershipDataManager.On(
+			"UserIsMemberOfAccount",
+			testutils.ContextMatcher,
+			exampleInvitee.ID,
+			helper.exampleAccount.ID,
+		).Return(true, nil)
+		helper.service.accountMembershipDataManager = accountMembershipDataManager
+
+		helper.service.InviteMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, accountMembershipDataManager)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		exampleInput := fakes.BuildFakeAccountInvitationCreationRequestInput()
+		exampleInput.ToUsername = ""
+		exampleInput.ToEmail = "invitee@todo.verygoodsoftwarenotvirus.ru"
+		buildRequest(t, helper, exampleInput)
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"CreateAccountInvitation",
+			testutils.ContextMatcher,
+			mock.IsType(&types.AccountInvitationDatabaseCreationInput{}),
+		).Return((*types.AccountInvitation)(nil), errors.New("blah"))
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.InviteMemberHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+}
+
+func TestAccountsService_ListPendingInvitationsHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.AccountPermissions[helper.exampleAccount.ID] = authorization.NewAccountRolePermissionChecker(authorization.AccountAdminRole.String())
+
+		exampleInvitationList := fakes.BuildFakeAccountInvitationList()
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return(exampleInvitationList, nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.ListPendingInvitationsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ListPendingInvitationsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("without permission to add members", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		helper.service.ListPendingInvitationsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with no rows returned", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.AccountPermissions[helper.exampleAccount.ID] = authorization.NewAccountRolePermissionChecker(authorization.AccountAdminRole.String())
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return((*types.AccountInvitationList)(nil), sql.ErrNoRows)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.ListPendingInvitationsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with error reading from database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.AccountPermissions[helper.exampleAccount.ID] = authorization.NewAccountRolePermissionChecker(authorization.AccountAdminRole.String())
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return((*types.AccountInvitationList)(nil), errors.New("blah"))
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.ListPendingInvitationsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+}
+
+func TestAccountsService_RevokeInvitationHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.AccountPermissions[helper.exampleAccount.ID] = authorization.NewAccountRolePermissionChecker(authorization.AccountAdminRole.String())
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		accountInvitationDataManager.On(
+			"SetAccountInvitationStatus",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+			types.RevokedAccountInvitationStatus,
+		).Return(nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.RevokeInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNoContent, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.RevokeInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("without permission to add members", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		helper.service.RevokeInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with invitation for another account", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.AccountPermissions[helper.exampleAccount.ID] = authorization.NewAccountRolePermissionChecker(authorization.AccountAdminRole.String())
+		helper.exampleAccountInvitation.DestinationAccount = fakes.BuildFakeID()
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.RevokeInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with invitation that is no longer pending", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.AccountPermissions[helper.exampleAccount.ID] = authorization.NewAccountRolePermissionChecker(authorization.AccountAdminRole.String())
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		accountInvitationDataManager.On(
+			"SetAccountInvitationStatus",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+			types.RevokedAccountInvitationStatus,
+		).Return(sql.ErrNoRows)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.RevokeInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.AccountPermissions[helper.exampleAccount.ID] = authorization.NewAccountRolePermissionChecker(authorization.AccountAdminRole.String())
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		accountInvitationDataManager.On(
+			"SetAccountInvitationStatus",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+			types.RevokedAccountInvitationStatus,
+		).Return(errors.New("blah"))
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.RevokeInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+}
+
+func TestAccountsService_ListReceivedInvitationsHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(fakes.BuildFakeAccountInvitationList(), nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.ListReceivedInvitationsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ListReceivedInvitationsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with no rows returned", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.AccountInvitationList)(nil), sql.ErrNoRows)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.ListReceivedInvitationsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with error reading from database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.AccountInvitationList)(nil), errors.New("blah"))
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.ListReceivedInvitationsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+}
+
+func TestAccountsService_ClaimInvitationHandler(T *testing.T) {
+	T.Parallel()
+
+	const exampleToken = "example_token"
+
+	buildRequest := func(t *testing.T, helper *accountsServiceHTTPRoutesTestHelper, input *types.AccountInvitationClaimInput) {
+		t.Helper()
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, input)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+	}
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		buildRequest(t, helper, &types.AccountInvitationClaimInput{Token: exampleToken})
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"ClaimAccountInvitation",
+			testutils.ContextMatcher,
+			hashAccountInvitationToken(exampleToken),
+			helper.exampleUser.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.ClaimInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		buildRequest(t, helper, &types.AccountInvitationClaimInput{Token: exampleToken})
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ClaimInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with missing input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(nil))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.ClaimInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		buildRequest(t, helper, &types.AccountInvitationClaimInput{})
+
+		helper.service.ClaimInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with invalid or used token", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		buildRequest(t, helper, &types.AccountInvitationClaimInput{Token: exampleToken})
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"ClaimAccountInvitation",
+			testutils.ContextMatcher,
+			hashAccountInvitationToken(exampleToken),
+			helper.exampleUser.ID,
+		).Return((*types.AccountInvitation)(nil), sql.ErrNoRows)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.ClaimInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		buildRequest(t, helper, &types.AccountInvitationClaimInput{Token: exampleToken})
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"ClaimAccountInvitation",
+			testutils.ContextMatcher,
+			hashAccountInvitationToken(exampleToken),
+			helper.exampleUser.ID,
+		).Return((*types.AccountInvitation)(nil), errors.New("blah"))
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.ClaimInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+}
+
+func TestAccountsService_AcceptInvitationHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		accountInvitationDataManager.On(
+			"SetAccountInvitationStatus",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+			types.AcceptedAccountInvitationStatus,
+		).Return(nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
+		accountMembershipDataManager.On(
+			"UserIsMemberOfAccount",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.exampleAccount.ID,
+		).Return(false, nil)
+		helper.service.accountMembershipDataManager = accountMembershipDataManager
+
+		mockEventProducer := &mock2.Publisher{}
+		mockEventProducer.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(message *types.PreWriteMessage) bool {
+				return message.DataType == types.UserMembershipDataType &&
+					message.UserMembership.UserID == helper.exampleUser.ID &&
+					message.UserMembership.AccountID == helper.exampleAccount.ID
+			}),
+		).Return(nil)
+		helper.service.preWritesPublisher = mockEventProducer
+
+		helper.service.AcceptInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager, accountMembershipDataManager, mockEventProducer)
+	})
+
+	T.Run("with unclaimed invitation sent to email address", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.exampleAccountInvitation.ToUser = nil
+		helper.exampleAccountInvitation.ToEmail = helper.exampleUser.EmailAddress
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.AcceptInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.AcceptInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with nonexistent invitation", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return((*types.AccountInvitation)(nil), sql.ErrNoRows)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.AcceptInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with expired invitation", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.exampleAccountInvitation.ExpiresAt = uint64(time.Now().Add(-time.Hour).Unix())
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.AcceptInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with invitation for another user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		otherUserID := fakes.BuildFakeID()
+		helper.exampleAccountInvitation.ToUser = &otherUserID
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.AcceptInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with user already a member", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
+		accountMembershipDataManager.On(
+			"UserIsMemberOfAccount",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.exampleAccount.ID,
+		).Return(true, nil)
+		helper.service.accountMembershipDataManager = accountMembershipDataManager
+
+		helper.service.AcceptInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager, accountMembershipDataManager)
+	})
+
+	T.Run("with error publishing to event queue", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		accountInvitationDataManager.On(
+			"SetAccountInvitationStatus",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+			types.AcceptedAccountInvitationStatus,
+		).Return(nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		accountMembershipDataManager := &mocktypes.AccountUserMembershipDataManager{}
+		accountMembershipDataManager.On(
+			"UserIsMemberOfAccount",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.exampleAccount.ID,
+		).Return(false, nil)
+		helper.service.accountMembershipDataManager = accountMembershipDataManager
+
+		mockEventProducer := &mock2.Publisher{}
+		mockEventProducer.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(testutils.PreWriteMessageMatcher),
+		).Return(errors.New("blah"))
+		helper.service.preWritesPublisher = mockEventProducer
+
+		helper.service.AcceptInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager, accountMembershipDataManager, mockEventProducer)
+	})
+}
+
+func TestAccountsService_RejectInvitationHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		accountInvitationDataManager.On(
+			"SetAccountInvitationStatus",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+			types.RejectedAccountInvitationStatus,
+		).Return(nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.RejectInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNoContent, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.RejectInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with invitation that is no longer pending", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.exampleAccountInvitation.Status = types.RevokedAccountInvitationStatus
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.RejectInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		accountInvitationDataManager := &mocktypes.AccountInvitationDataManager{}
+		accountInvitationDataManager.On(
+			"GetAccountInvitation",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+		).Return(helper.exampleAccountInvitation, nil)
+		accountInvitationDataManager.On(
+			"SetAccountInvitationStatus",
+			testutils.ContextMatcher,
+			helper.exampleAccountInvitation.ID,
+			types.RejectedAccountInvitationStatus,
+		).Return(errors.New("blah"))
+		helper.service.accountInvitationDataManager = accountInvitationDataManager
+
+		helper.service.RejectInvitationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, accountInvitationDataManager)
+	})
+}
@@ -4,11 +4,13 @@
 	"fmt"
 	"net/http"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/random"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
 	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
@@ -32,12 +34,21 @@
 		logger                       logging.Logger
 		accountDataManager           types.AccountDataManager
 		accountMembershipDataManager types.AccountUserMembershipDataManager
+		accountRoleDataManager       types.AccountRoleDataManager
+		accountInvitationDataManager types.AccountInvitationDataManager
+		userDataManager              types.UserDataManager
+		auditLogEntryDataManager     types.AuditLogEntryDataManager
+		emailer                      email.Emailer
+		emailRenderer                *email.Renderer
+		secretGenerator              random.Generator
 		accountIDFetcher             func(*http.Request) string
 		userIDFetcher                func(*http.Request) string
+		accountInvitationIDFetcher   func(*http.Request) string
 		sessionContextDataFetcher    func(*http.Request) (*types.SessionContextData, error)
 		accountCounter               metrics.UnitCounter
 		encoderDecoder               encoding.ServerEncoderDecoder
 		preWritesPublisher           publishers.Publisher
+		dataChangesPublisher         publishers.Publisher
 		tracer                       tracing.Tracer
 	}
 )
@@ -48,6 +59,12 @@ func ProvideService(
 	cfg Config,
 	accountDataManager types.AccountDataManager,
 	accountMembershipDataManager types.AccountUserMembershipDataManager,
+	accountRoleDataManager types.AccountRoleDataManager,
+	accountInvitationDataManager types.AccountInvitationDataManager,
+	userDataManager types.UserDataManager,
+	auditLogEntryDataManager types.AuditLogEntryDataManager,
+	emailer email.Emailer,
+	emailRenderer *email.Renderer,
 	encoder encoding.ServerEncoderDecoder,
 	counterProvider metrics.UnitCounterProvider,
 	routeParamManager routing.RouteParamManager,
@@ -58,15 +75,29 @@ func ProvideService(
 		return nil, fmt.Errorf("setting up event publisher: %w", err)
 	}
 
+	dataChangesPublisher, err := publisherProvider.ProviderPublisher(cfg.DataChangesTopicName)
+	if err != nil {
+		return nil, fmt.Errorf("setting up data changes publisher: %w", err)
+	}
+
 	s := &service{
 		logger:                       logging.EnsureLogger(logger).WithName(serviceName),
 		accountIDFetcher:             routeParamManager.BuildRouteParamStringIDFetcher(AccountIDURIParamKey),
 		userIDFetcher:                routeParamManager.BuildRouteParamStringIDFetcher(UserIDURIParamKey),
+		accountInvitationIDFetcher:   routeParamManager.BuildRouteParamStringIDFetcher(AccountInvitationIDURIParamKey),
 		sessionContextDataFetcher:    authservice.FetchContextFromRequest,
 		accountDataManager:           accountDataManager,
 		accountMembershipDataManager: accountMembershipDataManager,
+		accountRoleDataManager:       accountRoleDataManager,
+		accountInvitationDataManager: accountInvitationDataManager,
+		userDataManager:              userDataManager,
+		auditLogEntryDataManager:     auditLogEntryDataManager,
+		emailer:                      emailer,
+		emailRenderer:                emailRenderer,
+		secretGenerator:              random.NewGenerator(logger),
 		encoderDecoder:               encoder,
 		preWritesPublisher:           preWritesPublisher,
+		dataChangesPublisher:         dataChangesPublisher,
 		accountCounter:               metrics.EnsureUnitCounter(counterProvider, logger, counterName, counterDescription),
 		tracer:                       tracing.NewTracer(serviceName),
 	}
@@ -9,21 +9,45 @@
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
+	mockemail "gitlab.com/verygoodsoftwarenotvirus/todo/internal/email/mock"
 	mockencoding "gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics"
 	mockmetrics "gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/random"
 	mockrouting "gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing/mock"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/frontend"
 	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
 )
 
 func buildTestService() *service {
+	auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+	auditLogEntryDataManager.On(
+		"CreateAuditLogEntry",
+		testutils.ContextMatcher,
+		mock.MatchedBy(testutils.AuditLogEntryCreationInputMatcher),
+	).Return(nil).Maybe()
+
+	emailRenderer, err := email.ProvideRenderer(&email.Config{}, frontend.ProvideLocalizer())
+	if err != nil {
+		panic(err)
+	}
+
 	return &service{
 		logger:                       logging.NewNoopLogger(),
 		accountCounter:               &mockmetrics.UnitCounter{},
 		accountDataManager:           &mocktypes.AccountDataManager{},
 		accountMembershipDataManager: &mocktypes.AccountUserMembershipDataManager{},
+		accountRoleDataManager:       &mocktypes.AccountRoleDataManager{},
+		accountInvitationDataManager: &mocktypes.AccountInvitationDataManager{},
+		userDataManager:              &mocktypes.UserDataManager{},
+		auditLogEntryDataManager:     auditLogEntryDataManager,
+		emailer:                      &mockemail.Emailer{},
+		emailRenderer:                emailRenderer,
+		secretGenerator:              random.NewGenerator(logging.NewNoopLogger()),
 		accountIDFetcher:             func(req *http.Request) string { return "" },
 		encoderDecoder:               mockencoding.NewMockEncoderDecoder(),
 		tracer:                       tracing.NewTracer("test"),
@@ -46,19 +70,31 @@ func TestProvideAccountsService(t *testing.T) {
 		"BuildRouteParamStringIDFetcher",
 		UserIDURIParamKey,
 	).Return(func(*http.Request) string { return "" })
+	rpm.On(
+		"BuildRouteParamStringIDFetcher",
+		AccountInvitationIDURIParamKey,
+	).Return(func(*http.Request) string { return "" })
 
 	cfg := Config{
-		PreWritesTopicName: "pre-writes",
+		PreWritesTopicName:   "pre-writes",
+		DataChangesTopicName: "data-changes",
 	}
 
 	pp := &mock2.ProducerProvider{}
 	pp.On("ProviderPublisher", cfg.PreWritesTopicName).Return(&mock2.Publisher{}, nil)
+	pp.On("ProviderPublisher", cfg.DataChangesTopicName).Return(&mock2.Publisher{}, nil)
 
 	s, err := ProvideService(
 		logging.NewNoopLogger(),
 		cfg,
 		&mocktypes.AccountDataManager{},
 		&mocktypes.AccountUserMembershipDataManager{},
+		&mocktypes.AccountRoleDataManager{},
+		&mocktypes.AccountInvitationDataManager{},
+		&mocktypes.UserDataManager{},
+		&mocktypes.AuditLogEntryDataManager{},
+		&mockemail.Emailer{},
+		&email.Renderer{},
 		mockencoding.NewMockEncoderDecoder(),
 		ucp,
 		rpm,
@@ -1,12 +1,15 @@
 package admin
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"net/http"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/audit"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
@@ -87,7 +90,129 @@ func (s *service) UserReputationChangeHandler(res http.ResponseWriter, req *http
 		return
 	}
 
-	// handle reputation change here
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.UserReputationChangeEvent,
+		ActorUserID:  requester,
+		ResourceType: types.UserResourceType,
+		ResourceID:   input.TargetUserID,
+		Changes: []*types.FieldChangeSummary{
+			{FieldName: "reputation", NewValue: input.NewReputation},
+			{FieldName: "reputationExplanation", NewValue: input.Reason},
+		},
+	})
+
+	// a banned or terminated user shouldn't get to keep using the sessions they already have.
+	if input.NewReputation == types.BannedUserAccountStatus || input.NewReputation == types.TerminatedUserReputation {
+		s.revokeUserSessions(ctx, logger, requester, input.TargetUserID)
+	}
 
 	s.encoderDecoder.EncodeResponseWithStatus(ctx, res, nil, http.StatusAccepted)
 }
+
+// SearchReindexHandler rebuilds the items search index from the database, and reports where they had drifted apart.
+func (s *service) SearchReindexHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	report, err := s.reindexer.ReindexItems(ctx)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "reindexing items")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	s.encoderDecoder.RespondWithData(ctx, res, report)
+}
+
+// RevokeUserSessionsHandler revokes every session belonging to a given user.
+func (s *service) RevokeUserSessionsHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	userID := s.userIDFetcher(req)
+	tracing.AttachUserIDToSpan(span, userID)
+	logger = logger.WithValue(keys.UserIDKey, userID)
+
+	if err = s.userSessionDataManager.RevokeUserSessionsForUser(ctx, userID, ""); err != nil {
+		observability.AcknowledgeError(err, logger, span, "revoking user sessions")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.UserSessionsRevokedEvent,
+		ActorUserID:  sessionCtxData.Requester.UserID,
+		ResourceType: types.UserResourceType,
+		ResourceID:   userID,
+	})
+
+	s.announceUserSessionRevocation(ctx, logger, userID)
+
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// revokeUserSessions revokes every session belonging to a user. The reputation change that prompts
+// this has already happened by the time we get here, so failures are logged rather than returned.
+func (s *service) revokeUserSessions(ctx context.Context, logger logging.Logger, actorUserID, userID string) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if err := s.userSessionDataManager.RevokeUserSessionsForUser(ctx, userID, ""); err != nil {
+		observability.AcknowledgeError(err, logger, span, "revoking sessions for banned user")
+		return
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.UserSessionsRevokedEvent,
+		ActorUserID:  actorUserID,
+		ResourceType: types.UserResourceType,
+		ResourceID:   userID,
+	})
+
+	s.announceUserSessionRevocation(ctx, logger, userID)
+}
+
+// announceUserSessionRevocation lets the websockets service know to close every connection a user has open, now
+// that all of their sessions are revoked. The sessions are already gone by the time we get here, so failures are
+// only logged.
+func (s *service) announceUserSessionRevocation(ctx context.Context, logger logging.Logger, userID string) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	dcm := &types.DataChangeMessage{
+		MessageType:           types.RevokedMessageType,
+		DataType:              types.UserSessionDataType,
+		UserSessionRevocation: &types.UserSessionRevocation{},
+		AttributableToUserID:  userID,
+	}
+
+	if err := s.dataChangesPublisher.Publish(ctx, dcm); err != nil {
+		observability.AcknowledgeError(err, logger, span, "publishing user session revocation message")
+	}
+}
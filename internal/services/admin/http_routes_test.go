@@ -14,8 +14,12 @@
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	mockencoding "gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding/mock"
+	mockpublishers "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	mockreindex "gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/reindex/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
 	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
 	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
 )
@@ -47,10 +51,164 @@ func TestAdminService_UserAccountStatusChangeHandler(T *testing.T) {
 		).Return(nil)
 		helper.service.userDB = userDataManager
 
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.UserReputationChangeEvent &&
+					input.ActorUserID == helper.exampleUser.ID &&
+					input.ResourceID == helper.exampleInput.TargetUserID
+			}),
+		).Return(nil)
+		auditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.UserSessionsRevokedEvent &&
+					input.ActorUserID == helper.exampleUser.ID &&
+					input.ResourceID == helper.exampleInput.TargetUserID
+			}),
+		).Return(nil)
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSessionsForUser",
+			testutils.ContextMatcher,
+			helper.exampleInput.TargetUserID,
+			"",
+		).Return(nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		dataChangesPublisher := &mockpublishers.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.RevokedMessageType &&
+					msg.DataType == types.UserSessionDataType &&
+					msg.AttributableToUserID == helper.exampleInput.TargetUserID &&
+					msg.UserSessionRevocation.Revokes(fakes.BuildFakeID())
+			}),
+		).Return(nil)
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
 		helper.service.UserReputationChangeHandler(helper.res, helper.req)
 		assert.Equal(t, http.StatusAccepted, helper.res.Code)
 
-		mock.AssertExpectationsForObjects(t, userDataManager)
+		mock.AssertExpectationsForObjects(t, userDataManager, auditLogEntryDataManager, userSessionDataManager, dataChangesPublisher)
+	})
+
+	T.Run("terminating users", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		helper.exampleInput.NewReputation = types.TerminatedUserReputation
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, helper.exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.AdminUserDataManager{}
+		userDataManager.On(
+			"UpdateUserReputation",
+			testutils.ContextMatcher,
+			helper.exampleInput.TargetUserID,
+			helper.exampleInput,
+		).Return(nil)
+		helper.service.userDB = userDataManager
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.UserReputationChangeEvent &&
+					input.ActorUserID == helper.exampleUser.ID &&
+					input.ResourceID == helper.exampleInput.TargetUserID
+			}),
+		).Return(nil)
+		auditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.UserSessionsRevokedEvent &&
+					input.ActorUserID == helper.exampleUser.ID &&
+					input.ResourceID == helper.exampleInput.TargetUserID
+			}),
+		).Return(nil)
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSessionsForUser",
+			testutils.ContextMatcher,
+			helper.exampleInput.TargetUserID,
+			"",
+		).Return(nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		dataChangesPublisher := &mockpublishers.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.RevokedMessageType &&
+					msg.DataType == types.UserSessionDataType &&
+					msg.AttributableToUserID == helper.exampleInput.TargetUserID &&
+					msg.UserSessionRevocation.Revokes(fakes.BuildFakeID())
+			}),
+		).Return(nil)
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
+		helper.service.UserReputationChangeHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, auditLogEntryDataManager, userSessionDataManager, dataChangesPublisher)
+	})
+	T.Run("with error revoking sessions for banned user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		helper.exampleInput.NewReputation = types.BannedUserAccountStatus
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, helper.exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.AdminUserDataManager{}
+		userDataManager.On(
+			"UpdateUserReputation",
+			testutils.ContextMatcher,
+			helper.exampleInput.TargetUserID,
+			helper.exampleInput,
+		).Return(nil)
+		helper.service.userDB = userDataManager
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSessionsForUser",
+			testutils.ContextMatcher,
+			helper.exampleInput.TargetUserID,
+			"",
+		).Return(errors.New("blah"))
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		helper.service.UserReputationChangeHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, userSessionDataManager)
 	})
 
 	T.Run("back in good standing", func(t *testing.T) {
@@ -306,3 +464,182 @@ func TestAdminService_UserAccountStatusChangeHandler(T *testing.T) {
 		mock.AssertExpectationsForObjects(t, userDataManager)
 	})
 }
+
+func TestAdminService_SearchReindexHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleReport := &types.SearchReindexReport{
+			IndexName:        "items",
+			MissingFromIndex: []string{"missing"},
+			OrphanedInIndex:  []string{},
+			IndexedCount:     1,
+			Compared:         true,
+		}
+
+		reindexer := &mockreindex.Reindexer{}
+		reindexer.On("ReindexItems", testutils.ContextMatcher).Return(exampleReport, nil)
+		helper.service.reindexer = reindexer
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"RespondWithData",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			exampleReport,
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.SearchReindexHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, reindexer, encoderDecoder)
+	})
+
+	T.Run("with error fetching session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		reindexer := &mockreindex.Reindexer{}
+		helper.service.reindexer = reindexer
+
+		helper.service.SearchReindexHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, reindexer)
+	})
+
+	T.Run("with error reindexing", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		reindexer := &mockreindex.Reindexer{}
+		reindexer.On("ReindexItems", testutils.ContextMatcher).Return((*types.SearchReindexReport)(nil), errors.New("blah"))
+		helper.service.reindexer = reindexer
+
+		helper.service.SearchReindexHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, reindexer)
+	})
+}
+
+func TestAdminService_RevokeUserSessionsHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSessionsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			"",
+		).Return(nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.UserSessionsRevokedEvent && input.ResourceID == helper.exampleUser.ID
+			}),
+		).Return(nil)
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		dataChangesPublisher := &mockpublishers.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.RevokedMessageType &&
+					msg.DataType == types.UserSessionDataType &&
+					msg.AttributableToUserID == helper.exampleUser.ID &&
+					msg.UserSessionRevocation.Revokes(fakes.BuildFakeID())
+			}),
+		).Return(nil)
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
+		helper.service.RevokeUserSessionsHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusNoContent, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager, auditLogEntryDataManager, dataChangesPublisher)
+	})
+
+	T.Run("with error fetching session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.RevokeUserSessionsHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+	})
+
+	T.Run("with error revoking user sessions", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSessionsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			"",
+		).Return(errors.New("blah"))
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		helper.service.RevokeUserSessionsHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager)
+	})
+	T.Run("with error publishing revocation", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSessionsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			"",
+		).Return(nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.UserSessionsRevokedEvent && input.ResourceID == helper.exampleUser.ID
+			}),
+		).Return(nil)
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		dataChangesPublisher := &mockpublishers.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.IsType(&types.DataChangeMessage{}),
+		).Return(errors.New("blah"))
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
+		helper.service.RevokeUserSessionsHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusNoContent, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager, auditLogEntryDataManager, dataChangesPublisher)
+	})
+}
@@ -1,15 +1,18 @@
 package admin
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/alexedwards/scs/v2"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authentication"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/reindex"
 	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
@@ -25,7 +28,11 @@
 		logger                    logging.Logger
 		authenticator             authentication.Authenticator
 		userDB                    types.AdminUserDataManager
+		auditLogEntryDataManager  types.AuditLogEntryDataManager
+		userSessionDataManager    types.UserSessionDataManager
+		reindexer                 reindex.Reindexer
 		encoderDecoder            encoding.ServerEncoderDecoder
+		dataChangesPublisher      publishers.Publisher
 		sessionManager            *scs.SessionManager
 		sessionContextDataFetcher func(*http.Request) (*types.SessionContextData, error)
 		userIDFetcher             func(*http.Request) string
@@ -39,15 +46,28 @@ func ProvideService(
 	cfg *authservice.Config,
 	authenticator authentication.Authenticator,
 	userDataManager types.AdminUserDataManager,
+	auditLogEntryDataManager types.AuditLogEntryDataManager,
+	userSessionDataManager types.UserSessionDataManager,
 	sessionManager *scs.SessionManager,
 	encoder encoding.ServerEncoderDecoder,
 	routeParamManager routing.RouteParamManager,
-) types.AdminService {
+	reindexer reindex.Reindexer,
+	publisherProvider publishers.PublisherProvider,
+) (types.AdminService, error) {
+	dataChangesPublisher, err := publisherProvider.ProviderPublisher(cfg.DataChangesTopicName)
+	if err != nil {
+		return nil, fmt.Errorf("setting up data changes publisher: %w", err)
+	}
+
 	svc := &service{
 		logger:                    logging.EnsureLogger(logger).WithName(serviceName),
 		encoderDecoder:            encoder,
+		dataChangesPublisher:      dataChangesPublisher,
 		config:                    cfg,
 		userDB:                    userDataManager,
+		auditLogEntryDataManager:  auditLogEntryDataManager,
+		userSessionDataManager:    userSessionDataManager,
+		reindexer:                 reindexer,
 		authenticator:             authenticator,
 		sessionManager:            sessionManager,
 		sessionContextDataFetcher: authservice.FetchContextFromRequest,
@@ -56,5 +76,5 @@ func ProvideService(
 	}
 	svc.sessionManager.Lifetime = cfg.Cookies.Lifetime
 
-	return svc
+	return svc, nil
 }
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 
@@ -12,10 +13,14 @@
 	"github.com/stretchr/testify/require"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	mockpublishers "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	mockrouting "gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing/mock"
+	mockreindex "gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/reindex/mock"
 	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
 )
 
 func buildTestService(t *testing.T) *service {
@@ -29,15 +34,45 @@ func buildTestService(t *testing.T) *service {
 		UserIDURIParamKey,
 	).Return(func(*http.Request) string { return "" })
 
-	s := ProvideService(
+	auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+	auditLogEntryDataManager.On(
+		"CreateAuditLogEntry",
+		testutils.ContextMatcher,
+		mock.MatchedBy(testutils.AuditLogEntryCreationInputMatcher),
+	).Return(nil).Maybe()
+
+	userSessionDataManager := &mocktypes.UserSessionDataManager{}
+	userSessionDataManager.On(
+		"RevokeUserSessionsForUser",
+		testutils.ContextMatcher,
+		mock.IsType(""),
+		"",
+	).Return(nil).Maybe()
+
+	dataChangesPublisher := &mockpublishers.Publisher{}
+	dataChangesPublisher.On(
+		"Publish",
+		testutils.ContextMatcher,
+		mock.IsType(&types.DataChangeMessage{}),
+	).Return(nil).Maybe()
+
+	pp := &mockpublishers.ProducerProvider{}
+	pp.On("ProviderPublisher", "").Return(dataChangesPublisher, nil)
+
+	s, err := ProvideService(
 		logger,
 		&authservice.Config{Cookies: authservice.CookieConfig{SigningKey: "BLAHBLAHBLAHPRETENDTHISISSECRET!"}},
 		&mock2.Authenticator{},
 		&mocktypes.AdminUserDataManager{},
+		auditLogEntryDataManager,
+		userSessionDataManager,
 		scs.New(),
 		encoding.ProvideServerEncoderDecoder(logger, encoding.ContentTypeJSON),
 		rpm,
+		&mockreindex.Reindexer{},
+		pp,
 	)
+	require.NoError(t, err)
 
 	mock.AssertExpectationsForObjects(t, rpm)
 
@@ -61,18 +96,53 @@ func TestProvideAdminService(T *testing.T) {
 			UserIDURIParamKey,
 		).Return(func(*http.Request) string { return "" })
 
-		s := ProvideService(
+		pp := &mockpublishers.ProducerProvider{}
+		pp.On("ProviderPublisher", "").Return(&mockpublishers.Publisher{}, nil)
+
+		s, err := ProvideService(
 			logger,
 			&authservice.Config{Cookies: authservice.CookieConfig{SigningKey: "BLAHBLAHBLAHPRETENDTHISISSECRET!"}},
 			&mock2.Authenticator{},
 			&mocktypes.AdminUserDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
+			&mocktypes.UserSessionDataManager{},
 			scs.New(),
 			encoding.ProvideServerEncoderDecoder(logger, encoding.ContentTypeJSON),
 			rpm,
+			&mockreindex.Reindexer{},
+			pp,
 		)
 
 		assert.NotNil(t, s)
+		assert.NoError(t, err)
 
 		mock.AssertExpectationsForObjects(t, rpm)
 	})
+	T.Run("with error providing data changes publisher", func(t *testing.T) {
+		t.Parallel()
+
+		logger := logging.NewNoopLogger()
+
+		pp := &mockpublishers.ProducerProvider{}
+		pp.On("ProviderPublisher", "").Return((*mockpublishers.Publisher)(nil), errors.New("blah"))
+
+		s, err := ProvideService(
+			logger,
+			&authservice.Config{Cookies: authservice.CookieConfig{SigningKey: "BLAHBLAHBLAHPRETENDTHISISSECRET!"}},
+			&mock2.Authenticator{},
+			&mocktypes.AdminUserDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
+			&mocktypes.UserSessionDataManager{},
+			scs.New(),
+			encoding.ProvideServerEncoderDecoder(logger, encoding.ContentTypeJSON),
+			mockrouting.NewRouteParamManager(),
+			&mockreindex.Reindexer{},
+			pp,
+		)
+
+		assert.Nil(t, s)
+		assert.Error(t, err)
+
+		mock.AssertExpectationsForObjects(t, pp)
+	})
 }
@@ -8,6 +8,7 @@
 
 	"github.com/segmentio/ksuid"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/audit"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
@@ -102,6 +103,15 @@ func (s *service) CreateHandler(res http.ResponseWriter, req *http.Request) {
 	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
 	logger = sessionCtxData.AttachToLogger(logger).WithValue("username", input.Username)
 
+	// API clients can only be restricted to accounts their owner belongs to.
+	for _, accountID := range input.Accounts {
+		if _, isMember := sessionCtxData.AccountPermissions[accountID]; !isMember {
+			logger.WithValue(keys.AccountIDKey, accountID).Debug("API client restricted to account its owner does not belong to")
+			s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid account", http.StatusBadRequest)
+			return
+		}
+	}
+
 	// retrieve user.
 	user, err := s.userDataManager.GetUser(ctx, sessionCtxData.Requester.UserID)
 	if err != nil {
@@ -160,6 +170,14 @@ func (s *service) CreateHandler(res http.ResponseWriter, req *http.Request) {
 	tracing.AttachAPIClientDatabaseIDToSpan(span, client.ID)
 	s.apiClientCounter.Increment(ctx)
 
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.APIClientCreationEvent,
+		ActorUserID:      user.ID,
+		BelongsToAccount: sessionCtxData.ActiveAccountID,
+		ResourceType:     types.APIClientResourceType,
+		ResourceID:       client.ID,
+	})
+
 	resObj := &types.APIClientCreationResponse{
 		ID:           client.ID,
 		ClientID:     client.ClientID,
@@ -247,6 +265,14 @@ func (s *service) ArchiveHandler(res http.ResponseWriter, req *http.Request) {
 	// notify relevant parties.
 	s.apiClientCounter.Decrement(ctx)
 
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.APIClientArchiveEvent,
+		ActorUserID:      sessionCtxData.Requester.UserID,
+		BelongsToAccount: sessionCtxData.ActiveAccountID,
+		ResourceType:     types.APIClientResourceType,
+		ResourceID:       apiClientID,
+	})
+
 	// encode our response and peace.
 	res.WriteHeader(http.StatusNoContent)
 }
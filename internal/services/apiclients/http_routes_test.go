@@ -207,10 +207,21 @@ func TestAPIClientsService_CreateHandler(T *testing.T) {
 		uc.On("Increment", testutils.ContextMatcher).Return()
 		helper.service.apiClientCounter = uc
 
+		mockDB.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.APIClientCreationEvent &&
+					input.ActorUserID == helper.exampleUser.ID &&
+					input.ResourceID == helper.exampleAPIClient.ID
+			}),
+		).Return(nil)
+		helper.service.auditLogEntryDataManager = mockDB
+
 		helper.service.CreateHandler(helper.res, helper.req)
 		assert.Equal(t, http.StatusCreated, helper.res.Code)
 
-		mock.AssertExpectationsForObjects(t, mockDB, a, sg, uc)
+		mock.AssertExpectationsForObjects(t, mockDB, mockDB.AuditLogEntryDataManager, a, sg, uc)
 	})
 
 	T.Run("with error retrieving session context data", func(t *testing.T) {
@@ -258,6 +269,24 @@ func TestAPIClientsService_CreateHandler(T *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
 	})
 
+	T.Run("with account the user does not belong to", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		helper.exampleInput.Accounts = []string{fakes.BuildFakeID()}
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, helper.exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.CreateHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
 	T.Run("with error retrieving user", func(t *testing.T) {
 		t.Parallel()
 
@@ -33,6 +33,7 @@
 		cfg                       *config
 		apiClientDataManager      types.APIClientDataManager
 		userDataManager           types.UserDataManager
+		auditLogEntryDataManager  types.AuditLogEntryDataManager
 		authenticator             authentication.Authenticator
 		encoderDecoder            encoding.ServerEncoderDecoder
 		urlClientIDExtractor      func(req *http.Request) string
@@ -48,6 +49,7 @@ func ProvideAPIClientsService(
 	logger logging.Logger,
 	clientDataManager types.APIClientDataManager,
 	userDataManager types.UserDataManager,
+	auditLogEntryDataManager types.AuditLogEntryDataManager,
 	authenticator authentication.Authenticator,
 	encoderDecoder encoding.ServerEncoderDecoder,
 	counterProvider metrics.UnitCounterProvider,
@@ -59,6 +61,7 @@ func ProvideAPIClientsService(
 		cfg:                       cfg,
 		apiClientDataManager:      clientDataManager,
 		userDataManager:           userDataManager,
+		auditLogEntryDataManager:  auditLogEntryDataManager,
 		authenticator:             authenticator,
 		encoderDecoder:            encoderDecoder,
 		urlClientIDExtractor:      routeParamManager.BuildRouteParamStringIDFetcher(APIClientIDURIParamKey),
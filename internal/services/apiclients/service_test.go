@@ -20,13 +20,22 @@
 	mockrouting "gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing/mock"
 	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
 	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
 )
 
 func buildTestService(t *testing.T) *service {
 	t.Helper()
 
+	auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+	auditLogEntryDataManager.On(
+		"CreateAuditLogEntry",
+		testutils.ContextMatcher,
+		mock.MatchedBy(testutils.AuditLogEntryCreationInputMatcher),
+	).Return(nil).Maybe()
+
 	return &service{
 		apiClientDataManager:      database.BuildMockDatabase(),
+		auditLogEntryDataManager:  auditLogEntryDataManager,
 		logger:                    logging.NewNoopLogger(),
 		encoderDecoder:            mockencoding.NewMockEncoderDecoder(),
 		authenticator:             &mock2.Authenticator{},
@@ -56,6 +65,7 @@ func TestProvideAPIClientsService(T *testing.T) {
 			logging.NewNoopLogger(),
 			mockAPIClientDataManager,
 			&mocktypes.UserDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
 			&mock2.Authenticator{},
 			mockencoding.NewMockEncoderDecoder(),
 			func(counterName, description string) metrics.UnitCounter {
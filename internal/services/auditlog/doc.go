@@ -0,0 +1,4 @@
+/*
+Package auditlog provides a series of HTTP handlers for reading the record of mutating operations performed in the service.
+*/
+package auditlog
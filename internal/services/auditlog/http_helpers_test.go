@@ -0,0 +1,77 @@
+package auditlog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+type auditLogServiceHTTPRoutesTestHelper struct {
+	ctx            context.Context
+	req            *http.Request
+	res            *httptest.ResponseRecorder
+	service        *service
+	sessionCtxData *types.SessionContextData
+	exampleUser    *types.User
+	exampleAccount *types.Account
+}
+
+func buildTestHelper(t *testing.T) *auditLogServiceHTTPRoutesTestHelper {
+	t.Helper()
+
+	helper := &auditLogServiceHTTPRoutesTestHelper{}
+
+	helper.ctx = context.Background()
+	helper.service = buildTestService()
+	helper.exampleUser = fakes.BuildFakeUser()
+	helper.exampleAccount = fakes.BuildFakeAccount()
+	helper.exampleAccount.BelongsToUser = helper.exampleUser.ID
+
+	helper.service.accountIDFetcher = func(*http.Request) string {
+		return helper.exampleAccount.ID
+	}
+
+	helper.sessionCtxData = &types.SessionContextData{
+		Requester: types.RequesterInfo{
+			UserID:                helper.exampleUser.ID,
+			Reputation:            helper.exampleUser.ServiceAccountStatus,
+			ReputationExplanation: helper.exampleUser.ReputationExplanation,
+			ServicePermissions:    authorization.NewServiceRolePermissionChecker(helper.exampleUser.ServiceRoles...),
+		},
+		ActiveAccountID: helper.exampleAccount.ID,
+		AccountPermissions: map[string]authorization.AccountRolePermissionsChecker{
+			helper.exampleAccount.ID: authorization.NewAccountRolePermissionChecker(authorization.AccountAdminRole.String()),
+		},
+	}
+	helper.service.sessionContextDataFetcher = func(*http.Request) (*types.SessionContextData, error) {
+		return helper.sessionCtxData, nil
+	}
+
+	helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+	req := testutils.BuildTestRequest(t)
+
+	helper.req = req.WithContext(context.WithValue(req.Context(), types.SessionContextDataKey, helper.sessionCtxData))
+
+	helper.res = httptest.NewRecorder()
+
+	return helper
+}
+
+// makeMemberOnly demotes the requester to a plain member of the example account.
+func (helper *auditLogServiceHTTPRoutesTestHelper) makeMemberOnly() {
+	helper.sessionCtxData.AccountPermissions[helper.exampleAccount.ID] = authorization.NewAccountRolePermissionChecker(authorization.AccountMemberRole.String())
+}
+
+// makeServiceAdmin promotes the requester to a service admin.
+func (helper *auditLogServiceHTTPRoutesTestHelper) makeServiceAdmin() {
+	helper.sessionCtxData.Requester.ServicePermissions = authorization.NewServiceRolePermissionChecker(authorization.ServiceAdminRole.String())
+}
@@ -0,0 +1,120 @@
+package auditlog
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	// AccountIDURIParamKey is a standard string that we'll use to refer to account IDs with.
+	AccountIDURIParamKey = "accountID"
+)
+
+// authorizedForAccount returns whether the requester holds a given permission in the account a request is about,
+// which is not necessarily the account they currently have active.
+func authorizedForAccount(sessionCtxData *types.SessionContextData, accountID string, perm authorization.Permission) bool {
+	if sessionCtxData.Requester.ServicePermissions != nil && sessionCtxData.Requester.ServicePermissions.IsServiceAdmin() {
+		return true
+	}
+
+	checker, ok := sessionCtxData.AccountPermissions[accountID]
+
+	return ok && checker.HasPermission(perm)
+}
+
+// ListHandler is our system-wide list route, intended for service admins.
+func (s *service) ListHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	filter := types.ExtractAuditLogEntryQueryFilter(req)
+	logger := filter.AttachToLogger(s.logger.WithRequest(req))
+
+	tracing.AttachRequestToSpan(span, req)
+	tracing.AttachFilterToSpan(span, filter.Page, filter.Limit, string(filter.SortBy))
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	servicePermissions := sessionCtxData.ServiceRolePermissionChecker()
+	if servicePermissions == nil || !servicePermissions.HasPermission(authorization.ReadAllAuditLogEntriesPermission) {
+		logger.Debug("requester not authorized to read all audit log entries")
+		s.encoderDecoder.EncodeUnauthorizedResponse(ctx, res)
+		return
+	}
+
+	entries, err := s.auditLogEntryDataManager.GetAuditLogEntries(ctx, filter)
+	if errors.Is(err, sql.ErrNoRows) {
+		// in the event no rows exist, return an empty list.
+		entries = &types.AuditLogEntryList{Entries: []*types.AuditLogEntry{}}
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving audit log entries")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// encode our response and peace.
+	s.encoderDecoder.RespondWithData(ctx, res, entries)
+}
+
+// ListForAccountHandler is our account-scoped list route, intended for account admins.
+func (s *service) ListForAccountHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	filter := types.ExtractAuditLogEntryQueryFilter(req)
+	logger := filter.AttachToLogger(s.logger.WithRequest(req))
+
+	tracing.AttachRequestToSpan(span, req)
+	tracing.AttachFilterToSpan(span, filter.Page, filter.Limit, string(filter.SortBy))
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	// determine account ID.
+	accountID := s.accountIDFetcher(req)
+	logger = logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	if !authorizedForAccount(sessionCtxData, accountID, authorization.ReadAuditLogEntriesPermission) {
+		logger.Debug("requester not authorized to read account audit log entries")
+		s.encoderDecoder.EncodeUnauthorizedResponse(ctx, res)
+		return
+	}
+
+	entries, err := s.auditLogEntryDataManager.GetAuditLogEntriesForAccount(ctx, accountID, filter)
+	if errors.Is(err, sql.ErrNoRows) {
+		// in the event no rows exist, return an empty list.
+		entries = &types.AuditLogEntryList{Entries: []*types.AuditLogEntry{}}
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving audit log entries")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// encode our response and peace.
+	s.encoderDecoder.RespondWithData(ctx, res, entries)
+}
@@ -0,0 +1,260 @@
+package auditlog
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+func Test_authorizedForAccount(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with permission", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		assert.True(t, authorizedForAccount(helper.sessionCtxData, helper.exampleAccount.ID, authorization.ReadAuditLogEntriesPermission))
+	})
+
+	T.Run("without permission", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeMemberOnly()
+
+		assert.False(t, authorizedForAccount(helper.sessionCtxData, helper.exampleAccount.ID, authorization.ReadAuditLogEntriesPermission))
+	})
+
+	T.Run("for another account", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		assert.False(t, authorizedForAccount(helper.sessionCtxData, fakes.BuildFakeID(), authorization.ReadAuditLogEntriesPermission))
+	})
+
+	T.Run("as service admin", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeServiceAdmin()
+
+		assert.True(t, authorizedForAccount(helper.sessionCtxData, fakes.BuildFakeID(), authorization.ReadAuditLogEntriesPermission))
+	})
+}
+
+func TestAuditLogService_ListHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeServiceAdmin()
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"GetAuditLogEntries",
+			testutils.ContextMatcher,
+			mock.IsType(&types.AuditLogEntryQueryFilter{}),
+		).Return(fakes.BuildFakeAuditLogEntryList(), nil)
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, auditLogEntryDataManager)
+	})
+
+	T.Run("with query filters", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeServiceAdmin()
+
+		filter := types.DefaultAuditLogEntryQueryFilter()
+		filter.EventType = types.UserReputationChangeEvent
+		filter.ActorUserID = helper.exampleUser.ID
+		helper.req.URL.RawQuery = filter.ToValues().Encode()
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"GetAuditLogEntries",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(qf *types.AuditLogEntryQueryFilter) bool {
+				return qf.EventType == filter.EventType && qf.ActorUserID == filter.ActorUserID
+			}),
+		).Return(fakes.BuildFakeAuditLogEntryList(), nil)
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, auditLogEntryDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("without service admin permission", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with no rows returned", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeServiceAdmin()
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"GetAuditLogEntries",
+			testutils.ContextMatcher,
+			mock.IsType(&types.AuditLogEntryQueryFilter{}),
+		).Return((*types.AuditLogEntryList)(nil), sql.ErrNoRows)
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, auditLogEntryDataManager)
+	})
+
+	T.Run("with error retrieving audit log entries from database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeServiceAdmin()
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"GetAuditLogEntries",
+			testutils.ContextMatcher,
+			mock.IsType(&types.AuditLogEntryQueryFilter{}),
+		).Return((*types.AuditLogEntryList)(nil), errors.New("blah"))
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, auditLogEntryDataManager)
+	})
+}
+
+func TestAuditLogService_ListForAccountHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"GetAuditLogEntriesForAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			mock.IsType(&types.AuditLogEntryQueryFilter{}),
+		).Return(fakes.BuildFakeAuditLogEntryList(), nil)
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		helper.service.ListForAccountHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, auditLogEntryDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ListForAccountHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("without permission", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.makeMemberOnly()
+
+		helper.service.ListForAccountHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with no rows returned", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"GetAuditLogEntriesForAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			mock.IsType(&types.AuditLogEntryQueryFilter{}),
+		).Return((*types.AuditLogEntryList)(nil), sql.ErrNoRows)
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		helper.service.ListForAccountHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, auditLogEntryDataManager)
+	})
+
+	T.Run("with error retrieving audit log entries from database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+		auditLogEntryDataManager.On(
+			"GetAuditLogEntriesForAccount",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			mock.IsType(&types.AuditLogEntryQueryFilter{}),
+		).Return((*types.AuditLogEntryList)(nil), errors.New("blah"))
+		helper.service.auditLogEntryDataManager = auditLogEntryDataManager
+
+		helper.service.ListForAccountHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, auditLogEntryDataManager)
+	})
+}
@@ -0,0 +1,47 @@
+package auditlog
+
+import (
+	"net/http"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing"
+	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	serviceName string = "audit_log_service"
+)
+
+var _ types.AuditLogEntryDataService = (*service)(nil)
+
+type (
+	// service handles audit log entries.
+	service struct {
+		logger                    logging.Logger
+		auditLogEntryDataManager  types.AuditLogEntryDataManager
+		accountIDFetcher          func(*http.Request) string
+		sessionContextDataFetcher func(*http.Request) (*types.SessionContextData, error)
+		encoderDecoder            encoding.ServerEncoderDecoder
+		tracer                    tracing.Tracer
+	}
+)
+
+// ProvideService builds a new AuditLogEntryDataService.
+func ProvideService(
+	logger logging.Logger,
+	auditLogEntryDataManager types.AuditLogEntryDataManager,
+	encoder encoding.ServerEncoderDecoder,
+	routeParamManager routing.RouteParamManager,
+) types.AuditLogEntryDataService {
+	return &service{
+		logger:                    logging.EnsureLogger(logger).WithName(serviceName),
+		accountIDFetcher:          routeParamManager.BuildRouteParamStringIDFetcher(AccountIDURIParamKey),
+		sessionContextDataFetcher: authservice.FetchContextFromRequest,
+		auditLogEntryDataManager:  auditLogEntryDataManager,
+		encoderDecoder:            encoder,
+		tracer:                    tracing.NewTracer(serviceName),
+	}
+}
@@ -17,6 +17,9 @@
 	pasetoKeyRequiredLength = 32
 	pasetoDataKey           = "paseto_data"
 	maxPASETOLifetime       = 10 * time.Minute
+
+	// DefaultWebAuthnRelyingPartyDisplayName is the default WebAuthnConfig.RelyingPartyDisplayName.
+	DefaultWebAuthnRelyingPartyDisplayName = "Todo"
 )
 
 type (
@@ -41,16 +44,42 @@
 		Lifetime     time.Duration `json:"lifetime" mapstructure:"lifetime" toml:"lifetime,omitempty"`
 	}
 
+	// WebAuthnConfig holds our WebAuthn relying party settings. WebAuthn credentials are only
+	// available as a second factor when a relying party ID is configured.
+	WebAuthnConfig struct {
+		_ struct{}
+
+		RelyingPartyID          string `json:"relying_party_id" mapstructure:"relying_party_id" toml:"relying_party_id,omitempty"`
+		RelyingPartyDisplayName string `json:"relying_party_display_name" mapstructure:"relying_party_display_name" toml:"relying_party_display_name,omitempty"`
+		RelyingPartyOrigin      string `json:"relying_party_origin" mapstructure:"relying_party_origin" toml:"relying_party_origin,omitempty"`
+	}
+
+	// OIDCConfig holds our OpenID Connect relying party settings. Single sign-on through an
+	// identity provider is only available when an issuer URL is configured.
+	OIDCConfig struct {
+		_ struct{}
+
+		IssuerURL      string   `json:"issuer_url" mapstructure:"issuer_url" toml:"issuer_url,omitempty"`
+		ClientID       string   `json:"client_id" mapstructure:"client_id" toml:"client_id,omitempty"`
+		ClientSecret   string   `json:"client_secret" mapstructure:"client_secret" toml:"client_secret,omitempty"`
+		RedirectURL    string   `json:"redirect_url" mapstructure:"redirect_url" toml:"redirect_url,omitempty"`
+		Scopes         []string `json:"scopes" mapstructure:"scopes" toml:"scopes,omitempty"`
+		ProvisionUsers bool     `json:"provision_users" mapstructure:"provision_users" toml:"provision_users,omitempty"`
+	}
+
 	// Config represents our passwords configuration.
 	Config struct {
 		_ struct{}
 
-		PASETO                PASETOConfig `json:"paseto" mapstructure:"paseto" toml:"paseto,omitempty"`
-		Cookies               CookieConfig `json:"cookies" mapstructure:"cookies" toml:"cookies,omitempty"`
-		Debug                 bool         `json:"debug" mapstructure:"debug" toml:"debug,omitempty"`
-		EnableUserSignup      bool         `json:"enable_user_signup" mapstructure:"enable_user_signup" toml:"enable_user_signup,omitempty"`
-		MinimumUsernameLength uint8        `json:"minimum_username_length" mapstructure:"minimum_username_length" toml:"minimum_username_length,omitempty"`
-		MinimumPasswordLength uint8        `json:"minimum_password_length" mapstructure:"minimum_password_length" toml:"minimum_password_length,omitempty"`
+		PASETO                PASETOConfig   `json:"paseto" mapstructure:"paseto" toml:"paseto,omitempty"`
+		Cookies               CookieConfig   `json:"cookies" mapstructure:"cookies" toml:"cookies,omitempty"`
+		WebAuthn              WebAuthnConfig `json:"webauthn" mapstructure:"webauthn" toml:"webauthn,omitempty"`
+		OIDC                  OIDCConfig     `json:"oidc" mapstructure:"oidc" toml:"oidc,omitempty"`
+		Debug                 bool           `json:"debug" mapstructure:"debug" toml:"debug,omitempty"`
+		EnableUserSignup      bool           `json:"enable_user_signup" mapstructure:"enable_user_signup" toml:"enable_user_signup,omitempty"`
+		MinimumUsernameLength uint8          `json:"minimum_username_length" mapstructure:"minimum_username_length" toml:"minimum_username_length,omitempty"`
+		MinimumPasswordLength uint8          `json:"minimum_password_length" mapstructure:"minimum_password_length" toml:"minimum_password_length,omitempty"`
+		DataChangesTopicName  string         `json:"data_changes_topic_name" mapstructure:"data_changes_topic_name" toml:"data_changes_topic_name,omitempty"`
 	}
 )
 
@@ -75,6 +104,25 @@ func (cfg *PASETOConfig) ValidateWithContext(ctx context.Context) error {
 	)
 }
 
+var _ validation.ValidatableWithContext = (*WebAuthnConfig)(nil)
+
+// ValidateWithContext validates a WebAuthnConfig struct.
+func (cfg *WebAuthnConfig) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, cfg,
+		validation.Field(&cfg.RelyingPartyOrigin, validation.When(cfg.RelyingPartyID != "", validation.Required)),
+	)
+}
+
+var _ validation.ValidatableWithContext = (*OIDCConfig)(nil)
+
+// ValidateWithContext validates an OIDCConfig struct.
+func (cfg *OIDCConfig) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, cfg,
+		validation.Field(&cfg.ClientID, validation.When(cfg.IssuerURL != "", validation.Required)),
+		validation.Field(&cfg.RedirectURL, validation.When(cfg.IssuerURL != "", validation.Required)),
+	)
+}
+
 var _ validation.ValidatableWithContext = (*Config)(nil)
 
 // ValidateWithContext validates a Config struct.
@@ -82,7 +130,10 @@ func (cfg *Config) ValidateWithContext(ctx context.Context) error {
 	return validation.ValidateStructWithContext(ctx, cfg,
 		validation.Field(&cfg.Cookies, validation.Required),
 		validation.Field(&cfg.PASETO, validation.Required),
+		validation.Field(&cfg.WebAuthn),
+		validation.Field(&cfg.OIDC),
 		validation.Field(&cfg.MinimumUsernameLength, validation.Required),
 		validation.Field(&cfg.MinimumPasswordLength, validation.Required),
+		validation.Field(&cfg.DataChangesTopicName, validation.Required),
 	)
 }
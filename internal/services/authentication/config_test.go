@@ -36,6 +36,7 @@ func TestConfig_Validate(T *testing.T) {
 			EnableUserSignup:      false,
 			MinimumUsernameLength: 123,
 			MinimumPasswordLength: 123,
+			DataChangesTopicName:  "data_changes",
 		}
 
 		assert.NoError(t, cfg.ValidateWithContext(ctx))
@@ -78,3 +79,77 @@ func TestPASETOConfig_Validate(T *testing.T) {
 		assert.NoError(t, cfg.ValidateWithContext(ctx))
 	})
 }
+
+func TestWebAuthnConfig_Validate(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &WebAuthnConfig{
+			RelyingPartyID:     "localhost",
+			RelyingPartyOrigin: "http://localhost:8888",
+		}
+		ctx := context.Background()
+
+		assert.NoError(t, cfg.ValidateWithContext(ctx))
+	})
+
+	T.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &WebAuthnConfig{}
+		ctx := context.Background()
+
+		assert.NoError(t, cfg.ValidateWithContext(ctx))
+	})
+
+	T.Run("with relying party ID but no origin", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &WebAuthnConfig{
+			RelyingPartyID: "localhost",
+		}
+		ctx := context.Background()
+
+		assert.Error(t, cfg.ValidateWithContext(ctx))
+	})
+}
+
+func TestOIDCConfig_Validate(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &OIDCConfig{
+			IssuerURL:   "https://login.example.com",
+			ClientID:    "client_id",
+			RedirectURL: "http://localhost:8888/auth/oidc/callback",
+		}
+		ctx := context.Background()
+
+		assert.NoError(t, cfg.ValidateWithContext(ctx))
+	})
+
+	T.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &OIDCConfig{}
+		ctx := context.Background()
+
+		assert.NoError(t, cfg.ValidateWithContext(ctx))
+	})
+
+	T.Run("with issuer but no client ID", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &OIDCConfig{
+			IssuerURL:   "https://login.example.com",
+			RedirectURL: "http://localhost:8888/auth/oidc/callback",
+		}
+		ctx := context.Background()
+
+		assert.Error(t, cfg.ValidateWithContext(ctx))
+	})
+}
@@ -7,6 +7,7 @@
 	"time"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authentication"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
@@ -15,8 +16,50 @@
 
 var (
 	errNoUserIDFoundInSession = errors.New("no user ID found in session")
+	errNoPermittedAccounts    = errors.New("API client is not permitted to act within any of its owner's accounts")
 )
 
+// scopeSessionContextDataToAPIClient narrows session context data to the accounts and permissions an API client
+// was created with, so that a token never grants more than both the API client and its owner are allowed.
+func scopeSessionContextDataToAPIClient(sessionCtxData *types.SessionContextData, client *types.APIClient) error {
+	if len(client.Accounts) > 0 {
+		permitted := map[string]bool{}
+		for _, accountID := range client.Accounts {
+			permitted[accountID] = true
+		}
+
+		for accountID := range sessionCtxData.AccountPermissions {
+			if !permitted[accountID] {
+				delete(sessionCtxData.AccountPermissions, accountID)
+			}
+		}
+
+		if len(sessionCtxData.AccountPermissions) == 0 {
+			return errNoPermittedAccounts
+		}
+
+		// the owner's active account may not be one the API client can act within.
+		if _, ok := sessionCtxData.AccountPermissions[sessionCtxData.ActiveAccountID]; !ok {
+			for _, accountID := range client.Accounts {
+				if _, isMember := sessionCtxData.AccountPermissions[accountID]; isMember {
+					sessionCtxData.ActiveAccountID = accountID
+					break
+				}
+			}
+		}
+	}
+
+	if client.IsScoped() {
+		for accountID, checker := range sessionCtxData.AccountPermissions {
+			sessionCtxData.AccountPermissions[accountID] = authorization.NewScopedAccountRolePermissionChecker(checker, client.Permissions...)
+		}
+
+		sessionCtxData.Requester.ServicePermissions = authorization.NewScopedServiceRolePermissionChecker(sessionCtxData.Requester.ServicePermissions, client.AdminPermissions...)
+	}
+
+	return nil
+}
+
 func (s *service) overrideSessionContextDataValuesWithSessionData(ctx context.Context, sessionCtxData *types.SessionContextData) {
 	ctx, span := s.tracer.StartSpan(ctx)
 	defer span.End()
@@ -24,6 +67,10 @@ func (s *service) overrideSessionContextDataValuesWithSessionData(ctx context.Co
 	if activeAccount, ok := s.sessionManager.Get(ctx, accountIDContextKey).(string); ok {
 		sessionCtxData.ActiveAccountID = activeAccount
 	}
+
+	if userSessionID, ok := s.sessionManager.Get(ctx, userSessionIDContextKey).(string); ok {
+		sessionCtxData.UserSessionID = userSessionID
+	}
 }
 
 // getUserIDFromCookie takes a request object and fetches the cookie data if it is present.
@@ -83,7 +130,8 @@ func (s *service) determineUserFromRequestCookie(ctx context.Context, req *http.
 	return user, nil
 }
 
-// validateLogin takes login information and returns whether the login is valid.
+// validateLogin takes login information and returns whether the login is valid. The second factor
+// may be either a TOTP token or a WebAuthn assertion from one of the user's registered credentials.
 // In the event that there's an error, this function will return false and the error.
 func (s *service) validateLogin(ctx context.Context, user *types.User, loginInput *types.UserLoginInput) (bool, error) {
 	ctx, span := s.tracer.StartSpan(ctx)
@@ -91,6 +139,12 @@ func (s *service) validateLogin(ctx context.Context, user *types.User, loginInpu
 
 	// alias the relevant data.
 	logger := s.logger.WithValue(keys.UsernameKey, user.Username)
+	usingWebAuthn := len(loginInput.WebAuthnAssertion) > 0
+
+	totpToken := loginInput.TOTPToken
+	if usingWebAuthn {
+		totpToken = ""
+	}
 
 	// check for login validity.
 	loginValid, err := s.authenticator.ValidateLogin(
@@ -98,9 +152,14 @@ func (s *service) validateLogin(ctx context.Context, user *types.User, loginInpu
 		user.HashedPassword,
 		loginInput.Password,
 		user.TwoFactorSecret,
-		loginInput.TOTPToken,
+		totpToken,
 	)
 
+	// the authenticator still tells us whether the password matched when it rejects the TOTP token.
+	if usingWebAuthn && errors.Is(err, authentication.ErrInvalidTOTPToken) {
+		err = nil
+	}
+
 	if errors.Is(err, authentication.ErrInvalidTOTPToken) || errors.Is(err, authentication.ErrPasswordDoesNotMatch) {
 		return false, err
 	}
@@ -109,6 +168,14 @@ func (s *service) validateLogin(ctx context.Context, user *types.User, loginInpu
 		return false, observability.PrepareError(err, logger, span, "validating login")
 	}
 
+	if usingWebAuthn && loginValid {
+		if err = s.validateWebAuthnAssertion(ctx, user, loginInput); errors.Is(err, errInvalidWebAuthnAssertion) {
+			return false, err
+		} else if err != nil {
+			return false, observability.PrepareError(err, logger, span, "validating WebAuthn assertion")
+		}
+	}
+
 	logger.Debug("login validated")
 
 	return loginValid, nil
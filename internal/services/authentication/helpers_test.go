@@ -14,7 +14,9 @@
 	"github.com/stretchr/testify/require"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authentication"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
 	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
 	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
 )
@@ -293,3 +295,76 @@ func TestAuthenticationService_buildCookie(T *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func Test_scopeSessionContextDataToAPIClient(T *testing.T) {
+	T.Parallel()
+
+	buildSessionContextData := func(accountIDs ...string) *types.SessionContextData {
+		sessionCtxData := &types.SessionContextData{
+			Requester: types.RequesterInfo{
+				UserID:             fakes.BuildFakeID(),
+				ServicePermissions: authorization.NewServiceRolePermissionChecker(authorization.ServiceAdminRole.String()),
+			},
+			ActiveAccountID:    accountIDs[0],
+			AccountPermissions: map[string]authorization.AccountRolePermissionsChecker{},
+		}
+
+		for _, accountID := range accountIDs {
+			sessionCtxData.AccountPermissions[accountID] = authorization.NewAccountRolePermissionChecker(authorization.AccountAdminRole.String())
+		}
+
+		return sessionCtxData
+	}
+
+	T.Run("without scopes", func(t *testing.T) {
+		t.Parallel()
+
+		sessionCtxData := buildSessionContextData(fakes.BuildFakeID(), fakes.BuildFakeID())
+
+		require.NoError(t, scopeSessionContextDataToAPIClient(sessionCtxData, fakes.BuildFakeAPIClient()))
+
+		assert.Len(t, sessionCtxData.AccountPermissions, 2)
+		assert.True(t, sessionCtxData.AccountRolePermissionsChecker().CanCreateWebhooks())
+		assert.True(t, sessionCtxData.ServiceRolePermissionChecker().IsServiceAdmin())
+	})
+
+	T.Run("with permissions", func(t *testing.T) {
+		t.Parallel()
+
+		sessionCtxData := buildSessionContextData(fakes.BuildFakeID())
+		exampleAPIClient := fakes.BuildFakeAPIClient()
+		exampleAPIClient.Permissions = []string{authorization.ReadItemsPermission.ID()}
+
+		require.NoError(t, scopeSessionContextDataToAPIClient(sessionCtxData, exampleAPIClient))
+
+		assert.True(t, sessionCtxData.AccountRolePermissionsChecker().HasPermission(authorization.ReadItemsPermission))
+		assert.False(t, sessionCtxData.AccountRolePermissionsChecker().HasPermission(authorization.CreateItemsPermission))
+		assert.False(t, sessionCtxData.ServiceRolePermissionChecker().IsServiceAdmin())
+		assert.False(t, sessionCtxData.ServiceRolePermissionChecker().HasPermission(authorization.CreateItemsPermission))
+	})
+
+	T.Run("with accounts", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		sessionCtxData := buildSessionContextData(fakes.BuildFakeID(), exampleAccountID)
+		exampleAPIClient := fakes.BuildFakeAPIClient()
+		exampleAPIClient.Accounts = []string{exampleAccountID}
+
+		require.NoError(t, scopeSessionContextDataToAPIClient(sessionCtxData, exampleAPIClient))
+
+		assert.Len(t, sessionCtxData.AccountPermissions, 1)
+		assert.Equal(t, exampleAccountID, sessionCtxData.ActiveAccountID)
+		assert.True(t, sessionCtxData.AccountRolePermissionsChecker().CanCreateWebhooks())
+	})
+
+	T.Run("with no accounts in common", func(t *testing.T) {
+		t.Parallel()
+
+		sessionCtxData := buildSessionContextData(fakes.BuildFakeID())
+		exampleAPIClient := fakes.BuildFakeAPIClient()
+		exampleAPIClient.Accounts = []string{fakes.BuildFakeID()}
+
+		assert.ErrorIs(t, scopeSessionContextDataToAPIClient(sessionCtxData, exampleAPIClient), errNoPermittedAccounts)
+	})
+}
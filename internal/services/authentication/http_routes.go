@@ -1,9 +1,11 @@
 package authentication
 
 import (
+	"bytes"
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
+	"crypto/subtle"
 	"database/sql"
 	"encoding/base64"
 	"errors"
@@ -11,22 +13,44 @@
 	"net/http"
 	"time"
 
+	"github.com/coreos/go-oidc"
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/duo-labs/webauthn/webauthn"
 	"github.com/google/uuid"
 	"github.com/gorilla/securecookie"
 	"github.com/o1egl/paseto"
+	"github.com/segmentio/ksuid"
+	"golang.org/x/oauth2"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/audit"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authentication"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
-func (s *service) issueSessionManagedCookie(ctx context.Context, accountID, requesterID string) (cookie *http.Cookie, err error) {
+const (
+	// UserSessionIDURIParamKey is used to refer to user session IDs in router params.
+	UserSessionIDURIParamKey = "userSessionID"
+	// WebAuthnCredentialIDURIParamKey is used to refer to WebAuthn credential IDs in router params.
+	WebAuthnCredentialIDURIParamKey = "webAuthnCredentialID"
+)
+
+// issueSessionManagedCookie issues a new session cookie. If userSessionID is empty, a new user session is recorded
+// for the request, otherwise the existing user session is pointed at the newly issued token.
+func (s *service) issueSessionManagedCookie(ctx context.Context, req *http.Request, accountID, requesterID, userSessionID string) (cookie *http.Cookie, err error) {
 	ctx, span := s.tracer.StartSpan(ctx)
 	defer span.End()
 
-	logger := s.logger
+	newUserSession := userSessionID == ""
+	if newUserSession {
+		userSessionID = ksuid.New().String()
+	}
+
+	logger := s.logger.WithValue(keys.UserSessionIDKey, userSessionID)
+	tracing.AttachUserSessionIDToSpan(span, userSessionID)
 
 	ctx, err = s.sessionManager.Load(ctx, "")
 	if err != nil {
@@ -42,6 +66,7 @@ func (s *service) issueSessionManagedCookie(ctx context.Context, accountID, requ
 
 	s.sessionManager.Put(ctx, accountIDContextKey, accountID)
 	s.sessionManager.Put(ctx, userIDContextKey, requesterID)
+	s.sessionManager.Put(ctx, userSessionIDContextKey, userSessionID)
 
 	token, expiry, err := s.sessionManager.Commit(ctx)
 	if err != nil {
@@ -50,6 +75,17 @@ func (s *service) issueSessionManagedCookie(ctx context.Context, accountID, requ
 		return nil, err
 	}
 
+	if newUserSession {
+		input := types.BuildUserSessionDatabaseCreationInput(req, userSessionID, token, requesterID, accountID)
+		if _, err = s.userSessionDataManager.CreateUserSession(ctx, input); err != nil {
+			observability.AcknowledgeError(err, logger, span, "recording user session")
+			return nil, err
+		}
+	} else if err = s.userSessionDataManager.UpdateUserSessionToken(ctx, userSessionID, token, accountID); err != nil {
+		observability.AcknowledgeError(err, logger, span, "updating user session")
+		return nil, err
+	}
+
 	cookie, err = s.buildCookie(token, expiry)
 	if err != nil {
 		observability.AcknowledgeError(err, logger, span, "building cookie")
@@ -68,7 +104,7 @@ func (s *service) issueSessionManagedCookie(ctx context.Context, accountID, requ
 	ErrInvalidCredentials = errors.New("invalid credentials")
 )
 
-func (s *service) AuthenticateUser(ctx context.Context, loginData *types.UserLoginInput) (*types.User, *http.Cookie, error) {
+func (s *service) AuthenticateUser(ctx context.Context, req *http.Request, loginData *types.UserLoginInput) (*types.User, *http.Cookie, error) {
 	ctx, span := s.tracer.StartSpan(ctx)
 	defer span.End()
 
@@ -97,6 +133,8 @@ func (s *service) AuthenticateUser(ctx context.Context, loginData *types.UserLog
 			return user, nil, ErrInvalidCredentials
 		} else if errors.Is(err, authentication.ErrPasswordDoesNotMatch) {
 			return user, nil, ErrInvalidCredentials
+		} else if errors.Is(err, errInvalidWebAuthnAssertion) {
+			return user, nil, ErrInvalidCredentials
 		}
 
 		logger.Error(err, "error encountered validating login")
@@ -112,7 +150,7 @@ func (s *service) AuthenticateUser(ctx context.Context, loginData *types.UserLog
 		return user, nil, observability.PrepareError(err, logger, span, "fetching user memberships")
 	}
 
-	cookie, err := s.issueSessionManagedCookie(ctx, defaultAccountID, user.ID)
+	cookie, err := s.issueSessionManagedCookie(ctx, req, defaultAccountID, user.ID, "")
 	if err != nil {
 		return user, nil, observability.PrepareError(err, logger, span, "issuing cookie")
 	}
@@ -143,7 +181,7 @@ func (s *service) BeginSessionHandler(res http.ResponseWriter, req *http.Request
 
 	logger = logger.WithValue(keys.UsernameKey, loginData.Username)
 
-	user, cookie, err := s.AuthenticateUser(ctx, loginData)
+	user, cookie, err := s.AuthenticateUser(ctx, req, loginData)
 	if err != nil {
 		switch {
 		case errors.Is(err, ErrUserNotFound):
@@ -219,7 +257,7 @@ func (s *service) ChangeActiveAccountHandler(res http.ResponseWriter, req *http.
 		return
 	}
 
-	cookie, err := s.issueSessionManagedCookie(ctx, accountID, requesterID)
+	cookie, err := s.issueSessionManagedCookie(ctx, req, accountID, requesterID, sessionCtxData.UserSessionID)
 	if err != nil {
 		observability.AcknowledgeError(err, logger, span, "issuing cookie")
 		s.encoderDecoder.EncodeErrorResponse(ctx, res, staticError, http.StatusInternalServerError)
@@ -249,6 +287,17 @@ func (s *service) LogoutUser(ctx context.Context, sessionCtxData *types.SessionC
 		return observability.PrepareError(destroyErr, logger, span, "destroying user session")
 	}
 
+	if sessionCtxData != nil && sessionCtxData.UserSessionID != "" {
+		logger = logger.WithValue(keys.UserSessionIDKey, sessionCtxData.UserSessionID)
+
+		revokeErr := s.userSessionDataManager.RevokeUserSession(ctx, sessionCtxData.UserSessionID, sessionCtxData.Requester.UserID)
+		if revokeErr != nil && !errors.Is(revokeErr, sql.ErrNoRows) {
+			return observability.PrepareError(revokeErr, logger, span, "revoking user session")
+		}
+
+		s.announceUserSessionRevocation(ctx, logger, sessionCtxData.Requester.UserID, &types.UserSessionRevocation{UserSessionID: sessionCtxData.UserSessionID})
+	}
+
 	newCookie, cookieBuildingErr := s.buildCookie("deleted", time.Time{})
 	if cookieBuildingErr != nil || newCookie == nil {
 		return observability.PrepareError(cookieBuildingErr, logger, span, "building cookie")
@@ -397,6 +446,12 @@ func (s *service) PASETOHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if err = scopeSessionContextDataToAPIClient(sessionCtxData, client); err != nil {
+		observability.AcknowledgeError(err, logger, span, "scoping perms to API client")
+		s.encoderDecoder.EncodeUnauthorizedResponse(ctx, res)
+		return
+	}
+
 	var requestedAccountID string
 
 	if requestedAccount != "" {
@@ -502,3 +557,617 @@ func (s *service) CycleCookieSecretHandler(res http.ResponseWriter, req *http.Re
 
 	res.WriteHeader(http.StatusAccepted)
 }
+
+// ListUserSessionsHandler lists the active sessions belonging to the requesting user.
+func (s *service) ListUserSessionsHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	filter := types.ExtractQueryFilter(req)
+	logger := filter.AttachToLogger(s.logger)
+
+	tracing.AttachRequestToSpan(span, req)
+	tracing.AttachFilterToSpan(span, filter.Page, filter.Limit, string(filter.SortBy))
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	userSessions, err := s.userSessionDataManager.GetUserSessionsForUser(ctx, sessionCtxData.Requester.UserID, filter)
+	if errors.Is(err, sql.ErrNoRows) {
+		userSessions = &types.UserSessionList{
+			UserSessions: []*types.UserSession{},
+		}
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching user sessions")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	for _, userSession := range userSessions.UserSessions {
+		userSession.Current = userSession.ID == sessionCtxData.UserSessionID
+	}
+
+	s.encoderDecoder.RespondWithData(ctx, res, userSessions)
+}
+
+// RevokeUserSessionHandler revokes one of the requesting user's sessions.
+func (s *service) RevokeUserSessionHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	userSessionID := s.userSessionIDFetcher(req)
+	tracing.AttachUserSessionIDToSpan(span, userSessionID)
+	logger = logger.WithValue(keys.UserSessionIDKey, userSessionID)
+
+	err = s.userSessionDataManager.RevokeUserSession(ctx, userSessionID, sessionCtxData.Requester.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "revoking user session")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.UserSessionRevokedEvent,
+		ActorUserID:  sessionCtxData.Requester.UserID,
+		ResourceType: types.UserSessionResourceType,
+		ResourceID:   userSessionID,
+	})
+
+	s.announceUserSessionRevocation(ctx, logger, sessionCtxData.Requester.UserID, &types.UserSessionRevocation{UserSessionID: userSessionID})
+
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeOtherUserSessionsHandler revokes every one of the requesting user's sessions, save the one making the request.
+func (s *service) RevokeOtherUserSessionsHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	if err = s.userSessionDataManager.RevokeUserSessionsForUser(ctx, sessionCtxData.Requester.UserID, sessionCtxData.UserSessionID); err != nil {
+		observability.AcknowledgeError(err, logger, span, "revoking user sessions")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.UserSessionsRevokedEvent,
+		ActorUserID:  sessionCtxData.Requester.UserID,
+		ResourceType: types.UserResourceType,
+		ResourceID:   sessionCtxData.Requester.UserID,
+	})
+
+	s.announceUserSessionRevocation(ctx, logger, sessionCtxData.Requester.UserID, &types.UserSessionRevocation{KeptUserSessionID: sessionCtxData.UserSessionID})
+
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// announceUserSessionRevocation lets the websockets service know to close whatever connections were opened with
+// sessions that have been revoked. The sessions are already revoked by the time we get here, so failures are only
+// logged.
+func (s *service) announceUserSessionRevocation(ctx context.Context, logger logging.Logger, userID string, revocation *types.UserSessionRevocation) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	dcm := &types.DataChangeMessage{
+		MessageType:           types.RevokedMessageType,
+		DataType:              types.UserSessionDataType,
+		UserSessionRevocation: revocation,
+		AttributableToUserID:  userID,
+	}
+
+	if err := s.dataChangesPublisher.Publish(ctx, dcm); err != nil {
+		observability.AcknowledgeError(err, logger, span, "publishing user session revocation message")
+	}
+}
+
+// ListWebAuthnCredentialsHandler lists the WebAuthn credentials the requesting user has registered.
+func (s *service) ListWebAuthnCredentialsHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	credentials, err := s.webAuthnCredentialDataManager.GetWebAuthnCredentialsForUser(ctx, sessionCtxData.Requester.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		credentials = &types.WebAuthnCredentialList{
+			WebAuthnCredentials: []*types.WebAuthnCredential{},
+		}
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching WebAuthn credentials")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	s.encoderDecoder.RespondWithData(ctx, res, credentials)
+}
+
+// BeginWebAuthnRegistrationHandler begins the ceremony for registering a new WebAuthn credential to the requesting user.
+func (s *service) BeginWebAuthnRegistrationHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	if s.webAuthn == nil {
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errWebAuthnNotEnabled.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	user, err := s.userDataManager.GetUser(ctx, sessionCtxData.Requester.UserID)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching user")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	wu, err := s.buildWebAuthnUser(ctx, user)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "building WebAuthn user")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	options, sessionData, err := s.webAuthn.BeginRegistration(wu, webauthn.WithExclusions(wu.credentialDescriptors()))
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "beginning WebAuthn registration")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	ceremonyToken, err := s.encodeWebAuthnCeremony(webAuthnRegistrationCeremonyName, sessionData)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "encoding WebAuthn ceremony")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	s.encoderDecoder.RespondWithData(ctx, res, &types.WebAuthnRegistrationOptions{
+		Options:       options,
+		CeremonyToken: ceremonyToken,
+	})
+}
+
+// FinishWebAuthnRegistrationHandler verifies the credential an authenticator produced during a registration
+// ceremony, and saves it for the requesting user.
+func (s *service) FinishWebAuthnRegistrationHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	if s.webAuthn == nil {
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errWebAuthnNotEnabled.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	input := new(types.WebAuthnRegistrationFinishInput)
+	if err = s.encoderDecoder.DecodeRequest(ctx, req, input); err != nil {
+		observability.AcknowledgeError(err, logger, span, "decoding request body")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid request content", http.StatusBadRequest)
+		return
+	}
+
+	if err = input.ValidateWithContext(ctx); err != nil {
+		observability.AcknowledgeError(err, logger, span, "validating input")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sessionData, err := s.decodeWebAuthnCeremony(webAuthnRegistrationCeremonyName, input.CeremonyToken)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "decoding WebAuthn ceremony")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	parsedCredential, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(input.Credential))
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "parsing WebAuthn credential")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid credential", http.StatusBadRequest)
+		return
+	}
+
+	user, err := s.userDataManager.GetUser(ctx, sessionCtxData.Requester.UserID)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching user")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	wu, err := s.buildWebAuthnUser(ctx, user)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "building WebAuthn user")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	credential, err := s.webAuthn.CreateCredential(wu, *sessionData, parsedCredential)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "verifying WebAuthn credential")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid credential", http.StatusBadRequest)
+		return
+	}
+
+	dbInput := &types.WebAuthnCredentialDatabaseCreationInput{
+		ID:              ksuid.New().String(),
+		Name:            input.Name,
+		BelongsToUser:   user.ID,
+		AttestationType: credential.AttestationType,
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+	}
+
+	created, err := s.webAuthnCredentialDataManager.CreateWebAuthnCredential(ctx, dbInput)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "saving WebAuthn credential")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.WebAuthnCredentialCreationEvent,
+		ActorUserID:  user.ID,
+		ResourceType: types.WebAuthnCredentialResourceType,
+		ResourceID:   created.ID,
+	})
+
+	s.encoderDecoder.EncodeResponseWithStatus(ctx, res, created, http.StatusCreated)
+}
+
+// ArchiveWebAuthnCredentialHandler removes one of the requesting user's WebAuthn credentials.
+func (s *service) ArchiveWebAuthnCredentialHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	webAuthnCredentialID := s.webAuthnCredentialIDFetcher(req)
+	tracing.AttachWebAuthnCredentialIDToSpan(span, webAuthnCredentialID)
+	logger = logger.WithValue(keys.WebAuthnCredentialIDKey, webAuthnCredentialID)
+
+	err = s.webAuthnCredentialDataManager.ArchiveWebAuthnCredential(ctx, webAuthnCredentialID, sessionCtxData.Requester.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "archiving WebAuthn credential")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.WebAuthnCredentialArchiveEvent,
+		ActorUserID:  sessionCtxData.Requester.UserID,
+		ResourceType: types.WebAuthnCredentialResourceType,
+		ResourceID:   webAuthnCredentialID,
+	})
+
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// BeginWebAuthnLoginHandler begins the ceremony for logging in with a WebAuthn credential in place of a TOTP token.
+// The resulting assertion and ceremony token are submitted alongside the user's password to BeginSessionHandler.
+func (s *service) BeginWebAuthnLoginHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	if s.webAuthn == nil {
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errWebAuthnNotEnabled.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	input := new(types.WebAuthnLoginBeginInput)
+	if err := s.encoderDecoder.DecodeRequest(ctx, req, input); err != nil {
+		observability.AcknowledgeError(err, logger, span, "decoding request body")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid request content", http.StatusBadRequest)
+		return
+	}
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		observability.AcknowledgeError(err, logger, span, "validating input")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	logger = logger.WithValue(keys.UsernameKey, input.Username)
+
+	user, err := s.userDataManager.GetUserByUsername(ctx, input.Username)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching user")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	wu, err := s.buildWebAuthnUser(ctx, user)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "building WebAuthn user")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	if len(wu.credentials) == 0 {
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errNoWebAuthnCredentialsFound.Error(), http.StatusBadRequest)
+		return
+	}
+
+	options, sessionData, err := s.webAuthn.BeginLogin(wu)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "beginning WebAuthn login")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	ceremonyToken, err := s.encodeWebAuthnCeremony(webAuthnLoginCeremonyName, sessionData)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "encoding WebAuthn ceremony")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	s.encoderDecoder.RespondWithData(ctx, res, &types.WebAuthnLoginOptions{
+		Options:       options,
+		CeremonyToken: ceremonyToken,
+	})
+}
+
+// BeginOIDCLoginHandler sends the user off to the configured identity provider to sign in, using the
+// authorization code flow with PKCE. If the user is already logged in, the identity they sign in with is
+// linked to their account.
+func (s *service) BeginOIDCLoginHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	if s.config.OIDC.IssuerURL == "" {
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errOIDCNotEnabled.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	rp, err := s.getOIDCRelyingParty(ctx)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching OIDC relying party")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	loginState, err := buildOIDCLoginState(ctx)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "building OIDC login state")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// someone who's already logged in is linking an identity to their own account.
+	if _, userID, cookieErr := s.getUserIDFromCookie(ctx, req); cookieErr == nil {
+		loginState.LinkToUser = userID
+	}
+
+	encodedState, err := s.ceremonyManager.Encode(oidcLoginStateName, loginState)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "encoding OIDC login state")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	http.SetCookie(res, s.buildOIDCLoginCookie(encodedState, int(oidcLoginLifetime.Seconds())))
+
+	authCodeURL := rp.oauth2Config.AuthCodeURL(
+		loginState.State,
+		oidc.Nonce(loginState.Nonce),
+		oauth2.SetAuthURLParam("code_challenge", pkceCodeChallenge(loginState.CodeVerifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+
+	http.Redirect(res, req, authCodeURL, http.StatusFound)
+}
+
+// OIDCCallbackHandler is where the identity provider sends the user back to. It validates their ID token,
+// finds or provisions the user it belongs to, and issues the same session cookie BeginSessionHandler does.
+func (s *service) OIDCCallbackHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	if s.config.OIDC.IssuerURL == "" {
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errOIDCNotEnabled.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	rp, err := s.getOIDCRelyingParty(ctx)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching OIDC relying party")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	loginState, err := s.decodeOIDCLoginState(req)
+	if err != nil {
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// login state is only good for one attempt.
+	http.SetCookie(res, s.buildOIDCLoginCookie("", -1))
+
+	query := req.URL.Query()
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(loginState.State)) != 1 {
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errInvalidOIDCLoginState.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if providerErr := query.Get("error"); providerErr != "" {
+		logger.WithValue("oidc_error", providerErr).Debug("identity provider declined login")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errInvalidOIDCLogin.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	token, err := rp.oauth2Config.Exchange(ctx, query.Get("code"), oauth2.SetAuthURLParam("code_verifier", loginState.CodeVerifier))
+	if err != nil {
+		logger.WithValue("reason", err.Error()).Debug("exchanging OIDC authorization code")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errInvalidOIDCLogin.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		logger.Debug("identity provider did not return an ID token")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errInvalidOIDCLogin.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	idToken, err := rp.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		logger.WithValue("reason", err.Error()).Debug("ID token failed validation")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errInvalidOIDCLogin.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(loginState.Nonce)) != 1 {
+		logger.Debug("ID token nonce did not match")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errInvalidOIDCLogin.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	claims := &oidcClaims{}
+	if err = idToken.Claims(claims); err != nil {
+		logger.WithValue("reason", err.Error()).Debug("parsing ID token claims")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, errInvalidOIDCLogin.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	user, err := s.resolveOIDCUser(ctx, idToken.Issuer, idToken.Subject, loginState.LinkToUser, claims)
+	if errors.Is(err, ErrUserNotFound) {
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "login was invalid", http.StatusUnauthorized)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "resolving OIDC user")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	logger = logger.WithValue(keys.UserIDKey, user.ID)
+	tracing.AttachUserToSpan(span, user)
+
+	if user.IsBanned() {
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, user.ReputationExplanation, http.StatusForbidden)
+		return
+	}
+
+	defaultAccountID, err := s.accountMembershipManager.GetDefaultAccountIDForUser(ctx, user.ID)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching user memberships")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	cookie, err := s.issueSessionManagedCookie(ctx, req, defaultAccountID, user.ID, "")
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "issuing cookie")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	http.SetCookie(res, cookie)
+	http.Redirect(res, req, oidcLoginRedirectURL, http.StatusFound)
+
+	logger.Debug("user logged in via OIDC")
+}
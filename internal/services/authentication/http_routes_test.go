@@ -12,11 +12,15 @@
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	mock2 "gitlab.com/verygoodsoftwarenotvirus/todo/internal/authentication/mock"
 
+	"github.com/coreos/go-oidc"
 	"github.com/gorilla/securecookie"
 	"github.com/o1egl/paseto"
 	"github.com/stretchr/testify/assert"
@@ -26,6 +30,7 @@
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authentication"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	mockpublishers "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/random"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
@@ -49,10 +54,21 @@ func TestAuthenticationService_issueSessionManagedCookie(T *testing.T) {
 		sm.On("RenewToken", testutils.ContextMatcher).Return(nil)
 		sm.On("Put", testutils.ContextMatcher, userIDContextKey, helper.exampleUser.ID)
 		sm.On("Put", testutils.ContextMatcher, accountIDContextKey, helper.exampleAccount.ID)
+		sm.On("Put", testutils.ContextMatcher, userSessionIDContextKey, mock.IsType(""))
 		sm.On("Commit", testutils.ContextMatcher).Return(expectedToken, time.Now().Add(24*time.Hour), nil)
 		helper.service.sessionManager = sm
 
-		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.exampleAccount.ID, helper.exampleUser.ID)
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"CreateUserSession",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.UserSessionDatabaseCreationInput) bool {
+				return input.ID != "" && input.Token == expectedToken && input.BelongsToUser == helper.exampleUser.ID && input.ActiveAccount == helper.exampleAccount.ID
+			}),
+		).Return(fakes.BuildFakeUserSession(), nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.req, helper.exampleAccount.ID, helper.exampleUser.ID, "")
 		require.NotNil(t, cookie)
 		assert.NoError(t, err)
 
@@ -61,7 +77,108 @@ func TestAuthenticationService_issueSessionManagedCookie(T *testing.T) {
 
 		assert.Equal(t, expectedToken, actualToken)
 
-		mock.AssertExpectationsForObjects(t, sm)
+		mock.AssertExpectationsForObjects(t, sm, userSessionDataManager)
+	})
+
+	T.Run("with existing user session", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		expectedToken, err := random.GenerateBase64EncodedString(helper.ctx, 32)
+		require.NoError(t, err)
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		sm := &mockSessionManager{}
+		sm.On("Load", testutils.ContextMatcher, "").Return(helper.ctx, nil)
+		sm.On("RenewToken", testutils.ContextMatcher).Return(nil)
+		sm.On("Put", testutils.ContextMatcher, userIDContextKey, helper.exampleUser.ID)
+		sm.On("Put", testutils.ContextMatcher, accountIDContextKey, helper.exampleAccount.ID)
+		sm.On("Put", testutils.ContextMatcher, userSessionIDContextKey, exampleUserSession.ID)
+		sm.On("Commit", testutils.ContextMatcher).Return(expectedToken, time.Now().Add(24*time.Hour), nil)
+		helper.service.sessionManager = sm
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"UpdateUserSessionToken",
+			testutils.ContextMatcher,
+			exampleUserSession.ID,
+			expectedToken,
+			helper.exampleAccount.ID,
+		).Return(nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.req, helper.exampleAccount.ID, helper.exampleUser.ID, exampleUserSession.ID)
+		require.NotNil(t, cookie)
+		assert.NoError(t, err)
+
+		mock.AssertExpectationsForObjects(t, sm, userSessionDataManager)
+	})
+
+	T.Run("with error recording user session", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		expectedToken, err := random.GenerateBase64EncodedString(helper.ctx, 32)
+		require.NoError(t, err)
+
+		sm := &mockSessionManager{}
+		sm.On("Load", testutils.ContextMatcher, "").Return(helper.ctx, nil)
+		sm.On("RenewToken", testutils.ContextMatcher).Return(nil)
+		sm.On("Put", testutils.ContextMatcher, userIDContextKey, helper.exampleUser.ID)
+		sm.On("Put", testutils.ContextMatcher, accountIDContextKey, helper.exampleAccount.ID)
+		sm.On("Put", testutils.ContextMatcher, userSessionIDContextKey, mock.IsType(""))
+		sm.On("Commit", testutils.ContextMatcher).Return(expectedToken, time.Now().Add(24*time.Hour), nil)
+		helper.service.sessionManager = sm
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"CreateUserSession",
+			testutils.ContextMatcher,
+			mock.IsType(&types.UserSessionDatabaseCreationInput{}),
+		).Return((*types.UserSession)(nil), errors.New("blah"))
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.req, helper.exampleAccount.ID, helper.exampleUser.ID, "")
+		require.Nil(t, cookie)
+		assert.Error(t, err)
+
+		mock.AssertExpectationsForObjects(t, sm, userSessionDataManager)
+	})
+
+	T.Run("with error updating user session", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		expectedToken, err := random.GenerateBase64EncodedString(helper.ctx, 32)
+		require.NoError(t, err)
+
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		sm := &mockSessionManager{}
+		sm.On("Load", testutils.ContextMatcher, "").Return(helper.ctx, nil)
+		sm.On("RenewToken", testutils.ContextMatcher).Return(nil)
+		sm.On("Put", testutils.ContextMatcher, userIDContextKey, helper.exampleUser.ID)
+		sm.On("Put", testutils.ContextMatcher, accountIDContextKey, helper.exampleAccount.ID)
+		sm.On("Put", testutils.ContextMatcher, userSessionIDContextKey, exampleUserSession.ID)
+		sm.On("Commit", testutils.ContextMatcher).Return(expectedToken, time.Now().Add(24*time.Hour), nil)
+		helper.service.sessionManager = sm
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"UpdateUserSessionToken",
+			testutils.ContextMatcher,
+			exampleUserSession.ID,
+			expectedToken,
+			helper.exampleAccount.ID,
+		).Return(errors.New("blah"))
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.req, helper.exampleAccount.ID, helper.exampleUser.ID, exampleUserSession.ID)
+		require.Nil(t, cookie)
+		assert.Error(t, err)
+
+		mock.AssertExpectationsForObjects(t, sm, userSessionDataManager)
 	})
 
 	T.Run("with error loading from session manager", func(t *testing.T) {
@@ -73,7 +190,7 @@ func TestAuthenticationService_issueSessionManagedCookie(T *testing.T) {
 		sm.On("Load", testutils.ContextMatcher, "").Return(helper.ctx, errors.New("blah"))
 		helper.service.sessionManager = sm
 
-		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.exampleAccount.ID, helper.exampleUser.ID)
+		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.req, helper.exampleAccount.ID, helper.exampleUser.ID, "")
 		require.Nil(t, cookie)
 		assert.Error(t, err)
 
@@ -90,7 +207,7 @@ func TestAuthenticationService_issueSessionManagedCookie(T *testing.T) {
 		sm.On("RenewToken", testutils.ContextMatcher).Return(errors.New("blah"))
 		helper.service.sessionManager = sm
 
-		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.exampleAccount.ID, helper.exampleUser.ID)
+		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.req, helper.exampleAccount.ID, helper.exampleUser.ID, "")
 		require.Nil(t, cookie)
 		assert.Error(t, err)
 
@@ -109,10 +226,11 @@ func TestAuthenticationService_issueSessionManagedCookie(T *testing.T) {
 		sm.On("RenewToken", testutils.ContextMatcher).Return(nil)
 		sm.On("Put", testutils.ContextMatcher, userIDContextKey, helper.exampleUser.ID)
 		sm.On("Put", testutils.ContextMatcher, accountIDContextKey, helper.exampleAccount.ID)
+		sm.On("Put", testutils.ContextMatcher, userSessionIDContextKey, mock.IsType(""))
 		sm.On("Commit", testutils.ContextMatcher).Return(expectedToken, time.Now(), errors.New("blah"))
 		helper.service.sessionManager = sm
 
-		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.exampleAccount.ID, helper.exampleUser.ID)
+		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.req, helper.exampleAccount.ID, helper.exampleUser.ID, "")
 		require.Nil(t, cookie)
 		assert.Error(t, err)
 
@@ -131,6 +249,7 @@ func TestAuthenticationService_issueSessionManagedCookie(T *testing.T) {
 		sm.On("RenewToken", testutils.ContextMatcher).Return(nil)
 		sm.On("Put", testutils.ContextMatcher, userIDContextKey, helper.exampleUser.ID)
 		sm.On("Put", testutils.ContextMatcher, accountIDContextKey, helper.exampleAccount.ID)
+		sm.On("Put", testutils.ContextMatcher, userSessionIDContextKey, mock.IsType(""))
 		sm.On("Commit", testutils.ContextMatcher).Return(expectedToken, time.Now().Add(24*time.Hour), nil)
 		helper.service.sessionManager = sm
 
@@ -139,7 +258,7 @@ func TestAuthenticationService_issueSessionManagedCookie(T *testing.T) {
 			[]byte(""),
 		)
 
-		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.exampleAccount.ID, helper.exampleUser.ID)
+		cookie, err := helper.service.issueSessionManagedCookie(helper.ctx, helper.req, helper.exampleAccount.ID, helper.exampleUser.ID, "")
 		require.Nil(t, cookie)
 		assert.Error(t, err)
 	})
@@ -482,6 +601,119 @@ func TestAuthenticationService_LoginHandler(T *testing.T) {
 		mock.AssertExpectationsForObjects(t, userDataManager, authenticator)
 	})
 
+	T.Run("with WebAuthn assertion", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		webAuthnAuthenticator, exampleCredential := registerWebAuthnCredentialForTest(t, helper.service, helper.exampleUser)
+		ceremonyToken, assertion := buildWebAuthnLoginForTest(t, helper.service, helper.exampleUser, webAuthnAuthenticator, exampleCredential)
+
+		helper.exampleLoginInput.TOTPToken = ""
+		helper.exampleLoginInput.WebAuthnCeremonyToken = ceremonyToken
+		helper.exampleLoginInput.WebAuthnAssertion = assertion
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, helper.exampleLoginInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		authenticator := &mock2.Authenticator{}
+		authenticator.On(
+			"ValidateLogin",
+			testutils.ContextMatcher,
+			helper.exampleUser.HashedPassword,
+			helper.exampleLoginInput.Password,
+			helper.exampleUser.TwoFactorSecret,
+			"",
+		).Return(true, authentication.ErrInvalidTOTPToken)
+		helper.service.authenticator = authenticator
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(&types.WebAuthnCredentialList{WebAuthnCredentials: []*types.WebAuthnCredential{exampleCredential}}, nil)
+		webAuthnCredentialDataManager.On(
+			"MarkWebAuthnCredentialAsUsed",
+			testutils.ContextMatcher,
+			exampleCredential.ID,
+			uint32(1),
+		).Return(nil)
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		membershipDB := &mocktypes.AccountUserMembershipDataManager{}
+		membershipDB.On(
+			"GetDefaultAccountIDForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleAccount.ID, nil)
+		helper.service.accountMembershipManager = membershipDB
+
+		helper.service.BeginSessionHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+		assert.NotEmpty(t, helper.res.Header().Get("Set-Cookie"))
+
+		mock.AssertExpectationsForObjects(t, userDataManager, authenticator, webAuthnCredentialDataManager, membershipDB)
+	})
+
+	T.Run("with invalid WebAuthn assertion", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		helper.exampleLoginInput.TOTPToken = ""
+		helper.exampleLoginInput.WebAuthnCeremonyToken = t.Name()
+		helper.exampleLoginInput.WebAuthnAssertion = json.RawMessage(`{}`)
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, helper.exampleLoginInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		authenticator := &mock2.Authenticator{}
+		authenticator.On(
+			"ValidateLogin",
+			testutils.ContextMatcher,
+			helper.exampleUser.HashedPassword,
+			helper.exampleLoginInput.Password,
+			helper.exampleUser.TwoFactorSecret,
+			"",
+		).Return(true, authentication.ErrInvalidTOTPToken)
+		helper.service.authenticator = authenticator
+
+		helper.service.BeginSessionHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+		assert.Empty(t, helper.res.Header().Get("Set-Cookie"))
+
+		mock.AssertExpectationsForObjects(t, userDataManager, authenticator)
+	})
+
 	T.Run("with error fetching default account", func(t *testing.T) {
 		t.Parallel()
 
@@ -680,6 +912,7 @@ func TestAuthenticationService_LoginHandler(T *testing.T) {
 		sm.On("RenewToken", testutils.ContextMatcher).Return(nil)
 		sm.On("Put", testutils.ContextMatcher, userIDContextKey, helper.exampleUser.ID)
 		sm.On("Put", testutils.ContextMatcher, accountIDContextKey, helper.exampleAccount.ID)
+		sm.On("Put", testutils.ContextMatcher, userSessionIDContextKey, mock.IsType(""))
 		sm.On("Commit", testutils.ContextMatcher).Return("", time.Now(), errors.New("blah"))
 		helper.service.sessionManager = sm
 
@@ -839,6 +1072,48 @@ func TestAuthenticationService_ChangeActiveAccountHandler(T *testing.T) {
 		mock.AssertExpectationsForObjects(t, accountMembershipManager)
 	})
 
+	T.Run("with existing user session", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+		helper.sessionCtxData.UserSessionID = fakes.BuildFakeID()
+
+		exampleInput := fakes.BuildFakeChangeActiveAccountInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		accountMembershipManager := &mocktypes.AccountUserMembershipDataManager{}
+		accountMembershipManager.On(
+			"UserIsMemberOfAccount",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			exampleInput.AccountID,
+		).Return(true, nil)
+		helper.service.accountMembershipManager = accountMembershipManager
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"UpdateUserSessionToken",
+			testutils.ContextMatcher,
+			helper.sessionCtxData.UserSessionID,
+			mock.IsType(""),
+			exampleInput.AccountID,
+		).Return(nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		helper.service.ChangeActiveAccountHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+		assert.NotEmpty(t, helper.res.Header().Get("Set-Cookie"))
+
+		mock.AssertExpectationsForObjects(t, accountMembershipManager, userSessionDataManager)
+	})
+
 	T.Run("with error fetching session context data", func(t *testing.T) {
 		t.Parallel()
 
@@ -1050,6 +1325,7 @@ func TestAuthenticationService_ChangeActiveAccountHandler(T *testing.T) {
 		sm.On("RenewToken", testutils.ContextMatcher).Return(nil)
 		sm.On("Put", testutils.ContextMatcher, userIDContextKey, helper.exampleUser.ID)
 		sm.On("Put", testutils.ContextMatcher, accountIDContextKey, exampleInput.AccountID)
+		sm.On("Put", testutils.ContextMatcher, userSessionIDContextKey, mock.IsType(""))
 		sm.On("Commit", testutils.ContextMatcher).Return("", time.Now(), errors.New("blah"))
 		helper.service.sessionManager = sm
 
@@ -1154,6 +1430,56 @@ func TestAuthenticationService_LogoutHandler(T *testing.T) {
 		assert.Contains(t, actualCookie, "Max-Age=0")
 	})
 
+	T.Run("with user session", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.UserSessionID = fakes.BuildFakeID()
+
+		helper.ctx, helper.req, _ = attachCookieToRequestForTest(t, helper.service, helper.req, helper.exampleUser)
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSession",
+			testutils.ContextMatcher,
+			helper.sessionCtxData.UserSessionID,
+			helper.exampleUser.ID,
+		).Return(nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		helper.service.EndSessionHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusSeeOther, helper.res.Code)
+		actualCookie := helper.res.Header().Get("Set-Cookie")
+		assert.Contains(t, actualCookie, "Max-Age=0")
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager)
+	})
+
+	T.Run("with error revoking user session", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.UserSessionID = fakes.BuildFakeID()
+
+		helper.ctx, helper.req, _ = attachCookieToRequestForTest(t, helper.service, helper.req, helper.exampleUser)
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSession",
+			testutils.ContextMatcher,
+			helper.sessionCtxData.UserSessionID,
+			helper.exampleUser.ID,
+		).Return(errors.New("blah"))
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		helper.service.EndSessionHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager)
+	})
+
 	T.Run("with error retrieving session context data", func(t *testing.T) {
 		t.Parallel()
 
@@ -1937,3 +2263,1590 @@ func TestAuthenticationService_PASETOHandler(T *testing.T) {
 		mock.AssertExpectationsForObjects(t, apiClientDataManager, userDataManager, membershipDB)
 	})
 }
+
+func TestAuthenticationService_ListUserSessionsHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleUserSessionList := fakes.BuildFakeUserSessionList()
+		helper.sessionCtxData.UserSessionID = exampleUserSessionList.UserSessions[0].ID
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"GetUserSessionsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return(exampleUserSessionList, nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		helper.service.ListUserSessionsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		var actual *types.UserSessionList
+		require.NoError(t, json.NewDecoder(helper.res.Body).Decode(&actual))
+		require.NotEmpty(t, actual.UserSessions)
+
+		for _, userSession := range actual.UserSessions {
+			assert.Equal(t, userSession.ID == helper.sessionCtxData.UserSessionID, userSession.Current)
+		}
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ListUserSessionsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with no rows returned", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"GetUserSessionsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return((*types.UserSessionList)(nil), sql.ErrNoRows)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		helper.service.ListUserSessionsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager)
+	})
+
+	T.Run("with error fetching user sessions", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"GetUserSessionsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return((*types.UserSessionList)(nil), errors.New("blah"))
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		helper.service.ListUserSessionsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager)
+	})
+}
+
+func TestAuthenticationService_RevokeUserSessionHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleUserSession := fakes.BuildFakeUserSession()
+		helper.service.userSessionIDFetcher = func(*http.Request) string {
+			return exampleUserSession.ID
+		}
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSession",
+			testutils.ContextMatcher,
+			exampleUserSession.ID,
+			helper.exampleUser.ID,
+		).Return(nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		dataChangesPublisher := &mockpublishers.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.RevokedMessageType &&
+					msg.DataType == types.UserSessionDataType &&
+					msg.AttributableToUserID == helper.exampleUser.ID &&
+					msg.UserSessionRevocation.UserSessionID == exampleUserSession.ID
+			}),
+		).Return(nil)
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
+		helper.service.RevokeUserSessionHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNoContent, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager, dataChangesPublisher)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.RevokeUserSessionHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with nonexistent user session", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleUserSession := fakes.BuildFakeUserSession()
+		helper.service.userSessionIDFetcher = func(*http.Request) string {
+			return exampleUserSession.ID
+		}
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSession",
+			testutils.ContextMatcher,
+			exampleUserSession.ID,
+			helper.exampleUser.ID,
+		).Return(sql.ErrNoRows)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		helper.service.RevokeUserSessionHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager)
+	})
+
+	T.Run("with error revoking user session", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleUserSession := fakes.BuildFakeUserSession()
+		helper.service.userSessionIDFetcher = func(*http.Request) string {
+			return exampleUserSession.ID
+		}
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSession",
+			testutils.ContextMatcher,
+			exampleUserSession.ID,
+			helper.exampleUser.ID,
+		).Return(errors.New("blah"))
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		helper.service.RevokeUserSessionHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager)
+	})
+
+	T.Run("with error publishing revocation", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleUserSession := fakes.BuildFakeUserSession()
+		helper.service.userSessionIDFetcher = func(*http.Request) string {
+			return exampleUserSession.ID
+		}
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSession",
+			testutils.ContextMatcher,
+			exampleUserSession.ID,
+			helper.exampleUser.ID,
+		).Return(nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		dataChangesPublisher := &mockpublishers.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.IsType(&types.DataChangeMessage{}),
+		).Return(errors.New("blah"))
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
+		helper.service.RevokeUserSessionHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNoContent, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager, dataChangesPublisher)
+	})
+}
+
+func TestAuthenticationService_RevokeOtherUserSessionsHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.UserSessionID = fakes.BuildFakeID()
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSessionsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.sessionCtxData.UserSessionID,
+		).Return(nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		dataChangesPublisher := &mockpublishers.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.RevokedMessageType &&
+					msg.DataType == types.UserSessionDataType &&
+					msg.AttributableToUserID == helper.exampleUser.ID &&
+					msg.UserSessionRevocation.KeptUserSessionID == helper.sessionCtxData.UserSessionID
+			}),
+		).Return(nil)
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
+		helper.service.RevokeOtherUserSessionsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNoContent, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager, dataChangesPublisher)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.RevokeOtherUserSessionsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with error revoking user sessions", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.UserSessionID = fakes.BuildFakeID()
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSessionsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.sessionCtxData.UserSessionID,
+		).Return(errors.New("blah"))
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		helper.service.RevokeOtherUserSessionsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager)
+	})
+
+	T.Run("with error publishing revocation", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.sessionCtxData.UserSessionID = fakes.BuildFakeID()
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"RevokeUserSessionsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.sessionCtxData.UserSessionID,
+		).Return(nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		dataChangesPublisher := &mockpublishers.Publisher{}
+		dataChangesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.IsType(&types.DataChangeMessage{}),
+		).Return(errors.New("blah"))
+		helper.service.dataChangesPublisher = dataChangesPublisher
+
+		helper.service.RevokeOtherUserSessionsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNoContent, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userSessionDataManager, dataChangesPublisher)
+	})
+}
+
+func TestAuthenticationService_ListWebAuthnCredentialsHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleCredentialList := fakes.BuildFakeWebAuthnCredentialList()
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(exampleCredentialList, nil)
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.ListWebAuthnCredentialsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		var actual *types.WebAuthnCredentialList
+		require.NoError(t, json.NewDecoder(helper.res.Body).Decode(&actual))
+		assert.Len(t, actual.WebAuthnCredentials, len(exampleCredentialList.WebAuthnCredentials))
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ListWebAuthnCredentialsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with no rows returned", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.WebAuthnCredentialList)(nil), sql.ErrNoRows)
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.ListWebAuthnCredentialsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with error fetching WebAuthn credentials", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.WebAuthnCredentialList)(nil), errors.New("blah"))
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.ListWebAuthnCredentialsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+}
+
+func TestAuthenticationService_BeginWebAuthnRegistrationHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.WebAuthnCredentialList)(nil), sql.ErrNoRows)
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.BeginWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		var actual *types.WebAuthnRegistrationOptions
+		require.NoError(t, json.NewDecoder(helper.res.Body).Decode(&actual))
+		require.NotNil(t, actual.Options)
+		assert.Equal(t, testWebAuthnRelyingPartyID, actual.Options.Response.RelyingParty.ID)
+		assert.NotEmpty(t, actual.CeremonyToken)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with WebAuthn disabled", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.webAuthn = nil
+
+		helper.service.BeginWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotImplemented, helper.res.Code)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.BeginWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with error fetching user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.User)(nil), errors.New("blah"))
+		helper.service.userDataManager = userDataManager
+
+		helper.service.BeginWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager)
+	})
+
+	T.Run("with error fetching WebAuthn credentials", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.WebAuthnCredentialList)(nil), errors.New("blah"))
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.BeginWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, webAuthnCredentialDataManager)
+	})
+}
+
+// buildWebAuthnRegistrationFinishInputForTest begins a registration ceremony for a user and has a software
+// authenticator answer it, returning the input a client would submit to finish registering.
+func buildWebAuthnRegistrationFinishInputForTest(t *testing.T, s *service, user *types.User) *types.WebAuthnRegistrationFinishInput {
+	t.Helper()
+
+	options, sessionData, err := s.webAuthn.BeginRegistration(&webAuthnUser{user: user})
+	require.NoError(t, err)
+
+	ceremonyToken, err := s.encodeWebAuthnCeremony(webAuthnRegistrationCeremonyName, sessionData)
+	require.NoError(t, err)
+
+	credential, err := testutils.NewSoftwareAuthenticator(testWebAuthnOrigin).CreateCredential(options)
+	require.NoError(t, err)
+
+	return &types.WebAuthnRegistrationFinishInput{
+		Name:          t.Name(),
+		CeremonyToken: ceremonyToken,
+		Credential:    credential,
+	}
+}
+
+func TestAuthenticationService_FinishWebAuthnRegistrationHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleInput := buildWebAuthnRegistrationFinishInputForTest(t, helper.service, helper.exampleUser)
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.WebAuthnCredentialList)(nil), sql.ErrNoRows)
+		webAuthnCredentialDataManager.On(
+			"CreateWebAuthnCredential",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.WebAuthnCredentialDatabaseCreationInput) bool {
+				return input.Name == exampleInput.Name &&
+					input.BelongsToUser == helper.exampleUser.ID &&
+					len(input.CredentialID) > 0 &&
+					len(input.PublicKey) > 0
+			}),
+		).Return(exampleCredential, nil)
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.FinishWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusCreated, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with WebAuthn disabled", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.webAuthn = nil
+
+		helper.service.FinishWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotImplemented, helper.res.Code)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.FinishWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with missing input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(nil))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.FinishWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, &types.WebAuthnRegistrationFinishInput{})
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.FinishWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with invalid ceremony token", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleInput := buildWebAuthnRegistrationFinishInputForTest(t, helper.service, helper.exampleUser)
+		exampleInput.CeremonyToken = t.Name()
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.FinishWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with unparseable credential", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleInput := buildWebAuthnRegistrationFinishInputForTest(t, helper.service, helper.exampleUser)
+		exampleInput.Credential = json.RawMessage(`{}`)
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.FinishWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with error fetching user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleInput := buildWebAuthnRegistrationFinishInputForTest(t, helper.service, helper.exampleUser)
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.User)(nil), errors.New("blah"))
+		helper.service.userDataManager = userDataManager
+
+		helper.service.FinishWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager)
+	})
+
+	T.Run("with credential for another user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleInput := buildWebAuthnRegistrationFinishInputForTest(t, helper.service, fakes.BuildFakeUser())
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.WebAuthnCredentialList)(nil), sql.ErrNoRows)
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.FinishWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with error saving credential", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleInput := buildWebAuthnRegistrationFinishInputForTest(t, helper.service, helper.exampleUser)
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.WebAuthnCredentialList)(nil), sql.ErrNoRows)
+		webAuthnCredentialDataManager.On(
+			"CreateWebAuthnCredential",
+			testutils.ContextMatcher,
+			mock.IsType(&types.WebAuthnCredentialDatabaseCreationInput{}),
+		).Return((*types.WebAuthnCredential)(nil), errors.New("blah"))
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.FinishWebAuthnRegistrationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, webAuthnCredentialDataManager)
+	})
+}
+
+func TestAuthenticationService_ArchiveWebAuthnCredentialHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+		helper.service.webAuthnCredentialIDFetcher = func(*http.Request) string {
+			return exampleCredential.ID
+		}
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"ArchiveWebAuthnCredential",
+			testutils.ContextMatcher,
+			exampleCredential.ID,
+			helper.exampleUser.ID,
+		).Return(nil)
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.ArchiveWebAuthnCredentialHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNoContent, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ArchiveWebAuthnCredentialHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with nonexistent WebAuthn credential", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+		helper.service.webAuthnCredentialIDFetcher = func(*http.Request) string {
+			return exampleCredential.ID
+		}
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"ArchiveWebAuthnCredential",
+			testutils.ContextMatcher,
+			exampleCredential.ID,
+			helper.exampleUser.ID,
+		).Return(sql.ErrNoRows)
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.ArchiveWebAuthnCredentialHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with error archiving WebAuthn credential", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+		helper.service.webAuthnCredentialIDFetcher = func(*http.Request) string {
+			return exampleCredential.ID
+		}
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"ArchiveWebAuthnCredential",
+			testutils.ContextMatcher,
+			exampleCredential.ID,
+			helper.exampleUser.ID,
+		).Return(errors.New("blah"))
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.ArchiveWebAuthnCredentialHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+}
+
+func TestAuthenticationService_BeginWebAuthnLoginHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleCredentialList := fakes.BuildFakeWebAuthnCredentialList()
+		exampleInput := &types.WebAuthnLoginBeginInput{Username: helper.exampleUser.Username}
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(exampleCredentialList, nil)
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.BeginWebAuthnLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		var actual *types.WebAuthnLoginOptions
+		require.NoError(t, json.NewDecoder(helper.res.Body).Decode(&actual))
+		require.NotNil(t, actual.Options)
+		assert.Len(t, actual.Options.Response.AllowedCredentials, len(exampleCredentialList.WebAuthnCredentials))
+		assert.NotEmpty(t, actual.CeremonyToken)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with WebAuthn disabled", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.webAuthn = nil
+
+		helper.service.BeginWebAuthnLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotImplemented, helper.res.Code)
+	})
+
+	T.Run("with missing input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(nil))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.BeginWebAuthnLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, &types.WebAuthnLoginBeginInput{})
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.BeginWebAuthnLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with nonexistent user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleInput := &types.WebAuthnLoginBeginInput{Username: helper.exampleUser.Username}
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		helper.service.userDataManager = userDataManager
+
+		helper.service.BeginWebAuthnLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager)
+	})
+
+	T.Run("with error fetching user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleInput := &types.WebAuthnLoginBeginInput{Username: helper.exampleUser.Username}
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return((*types.User)(nil), errors.New("blah"))
+		helper.service.userDataManager = userDataManager
+
+		helper.service.BeginWebAuthnLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager)
+	})
+
+	T.Run("with no registered credentials", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleInput := &types.WebAuthnLoginBeginInput{Username: helper.exampleUser.Username}
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.WebAuthnCredentialList)(nil), sql.ErrNoRows)
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.BeginWebAuthnLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with error fetching WebAuthn credentials", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleInput := &types.WebAuthnLoginBeginInput{Username: helper.exampleUser.Username}
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.WebAuthnCredentialList)(nil), errors.New("blah"))
+		helper.service.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		helper.service.BeginWebAuthnLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, webAuthnCredentialDataManager)
+	})
+}
+
+func TestAuthenticationService_BeginOIDCLoginHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{})
+
+		helper.service.BeginOIDCLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusFound, helper.res.Code)
+
+		location, err := url.Parse(helper.res.Header().Get("Location"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(location.String(), provider.IssuerURL()))
+
+		query := location.Query()
+		assert.Equal(t, testOIDCClientID, query.Get("client_id"))
+		assert.Equal(t, testOIDCRedirectURL, query.Get("redirect_uri"))
+		assert.Equal(t, "S256", query.Get("code_challenge_method"))
+		assert.NotEmpty(t, query.Get("code_challenge"))
+		assert.NotEmpty(t, query.Get("nonce"))
+
+		cookies := helper.res.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, oidcLoginCookieName, cookies[0].Name)
+
+		req := httptest.NewRequest(http.MethodGet, testOIDCRedirectURL, nil)
+		req.AddCookie(cookies[0])
+
+		loginState, err := helper.service.decodeOIDCLoginState(req)
+		require.NoError(t, err)
+		assert.Equal(t, loginState.State, query.Get("state"))
+		assert.Equal(t, loginState.Nonce, query.Get("nonce"))
+		assert.Equal(t, pkceCodeChallenge(loginState.CodeVerifier), query.Get("code_challenge"))
+	})
+
+	T.Run("with logged in user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{})
+		_, helper.req, _ = attachCookieToRequestForTest(t, helper.service, helper.req, helper.exampleUser)
+
+		helper.service.BeginOIDCLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusFound, helper.res.Code)
+
+		cookies := helper.res.Result().Cookies()
+		require.Len(t, cookies, 1)
+
+		req := httptest.NewRequest(http.MethodGet, testOIDCRedirectURL, nil)
+		req.AddCookie(cookies[0])
+
+		loginState, err := helper.service.decodeOIDCLoginState(req)
+		require.NoError(t, err)
+		assert.Equal(t, helper.exampleUser.ID, loginState.LinkToUser)
+	})
+
+	T.Run("with OIDC disabled", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		helper.service.BeginOIDCLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotImplemented, helper.res.Code)
+	})
+
+	T.Run("with undiscoverable provider", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{})
+		provider.Close()
+
+		helper.service.BeginOIDCLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+	})
+
+	T.Run("with error encoding login state", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{})
+
+		ceremonyManager := &mockCookieEncoderDecoder{}
+		ceremonyManager.On(
+			"Encode",
+			oidcLoginStateName,
+			mock.IsType(&oidcLoginState{}),
+		).Return("", errors.New("blah"))
+		helper.service.ceremonyManager = ceremonyManager
+
+		helper.service.BeginOIDCLoginHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, ceremonyManager)
+	})
+}
+
+func TestAuthenticationService_OIDCCallbackHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+		exampleIdentity.BelongsToUser = helper.exampleUser.ID
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: exampleIdentity.Subject})
+		exampleIdentity.Issuer = provider.IssuerURL()
+
+		helper.req = buildOIDCCallbackRequestForTest(t, helper.service, provider)
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return(exampleIdentity, nil)
+		helper.service.oidcIdentityDataManager = oidcIdentityDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		membershipDB := &mocktypes.AccountUserMembershipDataManager{}
+		membershipDB.On(
+			"GetDefaultAccountIDForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleAccount.ID, nil)
+		helper.service.accountMembershipManager = membershipDB
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusFound, helper.res.Code)
+		assert.Equal(t, oidcLoginRedirectURL, helper.res.Header().Get("Location"))
+
+		var sessionCookie, loginStateCookie *http.Cookie
+		for _, c := range helper.res.Result().Cookies() {
+			switch c.Name {
+			case helper.service.config.Cookies.Name:
+				sessionCookie = c
+			case oidcLoginCookieName:
+				loginStateCookie = c
+			}
+		}
+
+		require.NotNil(t, sessionCookie)
+		assert.NotEmpty(t, sessionCookie.Value)
+		require.NotNil(t, loginStateCookie)
+		assert.Empty(t, loginStateCookie.Value)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager, userDataManager, membershipDB)
+	})
+
+	T.Run("linking identity to logged in user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+		exampleIdentity.BelongsToUser = helper.exampleUser.ID
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: exampleIdentity.Subject})
+		exampleIdentity.Issuer = provider.IssuerURL()
+
+		helper.req = buildLinkingOIDCCallbackRequestForTest(t, helper.service, provider, helper.exampleUser)
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return((*types.OIDCIdentity)(nil), sql.ErrNoRows)
+		oidcIdentityDataManager.On(
+			"CreateOIDCIdentity",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.OIDCIdentityDatabaseCreationInput) bool {
+				return input.Issuer == exampleIdentity.Issuer && input.Subject == exampleIdentity.Subject && input.BelongsToUser == helper.exampleUser.ID
+			}),
+		).Return(exampleIdentity, nil)
+		helper.service.oidcIdentityDataManager = oidcIdentityDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		membershipDB := &mocktypes.AccountUserMembershipDataManager{}
+		membershipDB.On(
+			"GetDefaultAccountIDForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleAccount.ID, nil)
+		helper.service.accountMembershipManager = membershipDB
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager, userDataManager, membershipDB)
+	})
+
+	T.Run("with OIDC disabled", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotImplemented, helper.res.Code)
+	})
+
+	T.Run("with undiscoverable provider", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{})
+		provider.Close()
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+	})
+
+	T.Run("without login state", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: "subject"})
+
+		callbackReq := buildOIDCCallbackRequestForTest(t, helper.service, provider)
+		helper.req = httptest.NewRequest(http.MethodGet, callbackReq.URL.String(), nil)
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with mismatched state", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: "subject"})
+
+		helper.req = buildOIDCCallbackRequestForTest(t, helper.service, provider)
+		query := helper.req.URL.Query()
+		query.Set("state", "blah")
+		helper.req.URL.RawQuery = query.Encode()
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with error from identity provider", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: "subject"})
+
+		helper.req = buildOIDCCallbackRequestForTest(t, helper.service, provider)
+		query := helper.req.URL.Query()
+		query.Del("code")
+		query.Set("error", "access_denied")
+		helper.req.URL.RawQuery = query.Encode()
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with invalid authorization code", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: "subject"})
+
+		helper.req = buildOIDCCallbackRequestForTest(t, helper.service, provider)
+		query := helper.req.URL.Query()
+		query.Set("code", "blah")
+		helper.req.URL.RawQuery = query.Encode()
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with mismatched code verifier", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: "subject"})
+
+		helper.req = buildOIDCCallbackRequestForTest(t, helper.service, provider)
+
+		// swap the login state for one with the same state but a different code verifier.
+		loginState, err := helper.service.decodeOIDCLoginState(helper.req)
+		require.NoError(t, err)
+		loginState.CodeVerifier = strings.Repeat("a", 43)
+
+		encoded, err := helper.service.ceremonyManager.Encode(oidcLoginStateName, loginState)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, helper.req.URL.String(), nil)
+		req.AddCookie(helper.service.buildOIDCLoginCookie(encoded, int(oidcLoginLifetime.Seconds())))
+		helper.req = req
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with mismatched nonce", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: "subject"})
+
+		helper.req = buildOIDCCallbackRequestForTest(t, helper.service, provider)
+
+		loginState, err := helper.service.decodeOIDCLoginState(helper.req)
+		require.NoError(t, err)
+		loginState.Nonce = "blah"
+
+		encoded, err := helper.service.ceremonyManager.Encode(oidcLoginStateName, loginState)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, helper.req.URL.String(), nil)
+		req.AddCookie(helper.service.buildOIDCLoginCookie(encoded, int(oidcLoginLifetime.Seconds())))
+		helper.req = req
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with ID token from another client", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: "subject"})
+
+		helper.req = buildOIDCCallbackRequestForTest(t, helper.service, provider)
+
+		// a relying party for a different client ID exchanges the same code, but won't accept the resulting ID token.
+		rp, err := helper.service.getOIDCRelyingParty(helper.ctx)
+		require.NoError(t, err)
+		rp.verifier = oidc.NewVerifier(provider.IssuerURL(), oidc.NewRemoteKeySet(context.Background(), provider.IssuerURL()+"/jwks"), &oidc.Config{ClientID: "blah"})
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: "subject"})
+
+		helper.req = buildOIDCCallbackRequestForTest(t, helper.service, provider)
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			provider.IssuerURL(),
+			"subject",
+		).Return((*types.OIDCIdentity)(nil), sql.ErrNoRows)
+		helper.service.oidcIdentityDataManager = oidcIdentityDataManager
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager)
+	})
+
+	T.Run("with error resolving user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: "subject"})
+
+		helper.req = buildOIDCCallbackRequestForTest(t, helper.service, provider)
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			provider.IssuerURL(),
+			"subject",
+		).Return((*types.OIDCIdentity)(nil), errors.New("blah"))
+		helper.service.oidcIdentityDataManager = oidcIdentityDataManager
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager)
+	})
+
+	T.Run("with banned user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.exampleUser.ServiceAccountStatus = types.BannedUserAccountStatus
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+		exampleIdentity.BelongsToUser = helper.exampleUser.ID
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: exampleIdentity.Subject})
+		exampleIdentity.Issuer = provider.IssuerURL()
+
+		helper.req = buildOIDCCallbackRequestForTest(t, helper.service, provider)
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return(exampleIdentity, nil)
+		helper.service.oidcIdentityDataManager = oidcIdentityDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusForbidden, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager, userDataManager)
+	})
+
+	T.Run("with error fetching default account", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+		exampleIdentity.BelongsToUser = helper.exampleUser.ID
+		provider := enableOIDCForTest(t, helper.service, testutils.MockOIDCIdentity{Subject: exampleIdentity.Subject})
+		exampleIdentity.Issuer = provider.IssuerURL()
+
+		helper.req = buildOIDCCallbackRequestForTest(t, helper.service, provider)
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return(exampleIdentity, nil)
+		helper.service.oidcIdentityDataManager = oidcIdentityDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = userDataManager
+
+		membershipDB := &mocktypes.AccountUserMembershipDataManager{}
+		membershipDB.On(
+			"GetDefaultAccountIDForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return("", errors.New("blah"))
+		helper.service.accountMembershipManager = membershipDB
+
+		helper.service.OIDCCallbackHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager, userDataManager, membershipDB)
+	})
+}
@@ -108,6 +108,13 @@ func (s *service) UserAttributionMiddleware(next http.Handler) http.Handler {
 
 			s.overrideSessionContextDataValuesWithSessionData(ctx, sessionCtxData)
 
+			if sessionCtxData.UserSessionID != "" {
+				// the database only records sightings once in a while, so this is cheaper than it looks.
+				if err = s.userSessionDataManager.MarkUserSessionAsSeen(ctx, sessionCtxData.UserSessionID); err != nil {
+					observability.AcknowledgeError(err, logger, span, "marking user session as seen")
+				}
+			}
+
 			next.ServeHTTP(res, req.WithContext(context.WithValue(ctx, types.SessionContextDataKey, sessionCtxData)))
 			return
 		}
@@ -16,6 +16,7 @@
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
 	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
 	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
 )
@@ -208,6 +209,71 @@ func TestAuthenticationService_UserAttributionMiddleware(T *testing.T) {
 		mock.AssertExpectationsForObjects(t, mockAccountMembershipManager, h)
 	})
 
+	T.Run("with user session", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		sessionCtxData := &types.SessionContextData{
+			Requester: types.RequesterInfo{
+				UserID:                helper.exampleUser.ID,
+				Reputation:            helper.exampleUser.ServiceAccountStatus,
+				ReputationExplanation: helper.exampleUser.ReputationExplanation,
+				ServicePermissions:    authorization.NewServiceRolePermissionChecker(helper.exampleUser.ServiceRoles...),
+			},
+			ActiveAccountID:    helper.exampleAccount.ID,
+			AccountPermissions: helper.examplePermCheckers,
+		}
+
+		mockAccountMembershipManager := &mocktypes.AccountUserMembershipDataManager{}
+		mockAccountMembershipManager.On(
+			"BuildSessionContextDataForUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(sessionCtxData, nil)
+		helper.service.accountMembershipManager = mockAccountMembershipManager
+
+		userSessionDataManager := &mocktypes.UserSessionDataManager{}
+		userSessionDataManager.On(
+			"MarkUserSessionAsSeen",
+			testutils.ContextMatcher,
+			exampleUserSession.ID,
+		).Return(nil)
+		helper.service.userSessionDataManager = userSessionDataManager
+
+		ctx, err := helper.service.sessionManager.Load(helper.req.Context(), "")
+		require.NoError(t, err)
+		require.NoError(t, helper.service.sessionManager.RenewToken(ctx))
+
+		helper.service.sessionManager.Put(ctx, userIDContextKey, helper.exampleUser.ID)
+		helper.service.sessionManager.Put(ctx, accountIDContextKey, helper.exampleAccount.ID)
+		helper.service.sessionManager.Put(ctx, userSessionIDContextKey, exampleUserSession.ID)
+
+		token, expiry, err := helper.service.sessionManager.Commit(ctx)
+		require.NoError(t, err)
+
+		c, err := helper.service.buildCookie(token, expiry)
+		require.NoError(t, err)
+		helper.req.AddCookie(c)
+
+		h := &testutils.MockHTTPHandler{}
+		h.On(
+			"ServeHTTP",
+			testutils.HTTPResponseWriterMatcher,
+			mock.MatchedBy(func(req *http.Request) bool {
+				actual, ok := req.Context().Value(types.SessionContextDataKey).(*types.SessionContextData)
+				return ok && actual.UserSessionID == exampleUserSession.ID
+			}),
+		).Return()
+
+		helper.service.UserAttributionMiddleware(h).ServeHTTP(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code, "expected %d in status response, got %d", http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockAccountMembershipManager, userSessionDataManager, h)
+	})
+
 	T.Run("with error building session context data for user", func(t *testing.T) {
 		t.Parallel()
 
@@ -521,6 +587,41 @@ func TestAuthenticationService_PermissionFilterMiddleware(T *testing.T) {
 
 		assert.Equal(t, http.StatusUnauthorized, helper.res.Code, "expected %d in status response, got %d", http.StatusOK, helper.res.Code)
 	})
+
+	T.Run("with API client scoped to other permissions", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		helper.exampleUser.ServiceRoles = []string{authorization.ServiceAdminRole.String()}
+		helper.setContextFetcher(t)
+
+		exampleAPIClient := fakes.BuildFakeAPIClient()
+		exampleAPIClient.Permissions = []string{authorization.ReadItemsPermission.ID()}
+
+		sessionCtxData := &types.SessionContextData{
+			Requester: types.RequesterInfo{
+				UserID:                helper.exampleUser.ID,
+				Reputation:            helper.exampleUser.ServiceAccountStatus,
+				ReputationExplanation: helper.exampleUser.ReputationExplanation,
+				ServicePermissions:    authorization.NewServiceRolePermissionChecker(helper.exampleUser.ServiceRoles...),
+			},
+			ActiveAccountID: helper.exampleAccount.ID,
+			AccountPermissions: map[string]authorization.AccountRolePermissionsChecker{
+				helper.exampleAccount.ID: authorization.NewAccountRolePermissionChecker(authorization.AccountAdminRole.String()),
+			},
+		}
+		require.NoError(t, scopeSessionContextDataToAPIClient(sessionCtxData, exampleAPIClient))
+
+		helper.req = helper.req.WithContext(context.WithValue(helper.req.Context(), types.SessionContextDataKey, sessionCtxData))
+		helper.service.sessionContextDataFetcher = func(*http.Request) (*types.SessionContextData, error) {
+			return sessionCtxData, nil
+		}
+
+		helper.service.PermissionFilterMiddleware(authorization.AddMemberAccountPermission)(nil).ServeHTTP(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code, "expected %d in status response, got %d", http.StatusOK, helper.res.Code)
+	})
 }
 
 func TestAuthenticationService_AdminMiddleware(T *testing.T) {
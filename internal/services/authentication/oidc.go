@@ -0,0 +1,321 @@
+package authentication
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/coreos/go-oidc"
+	"github.com/segmentio/ksuid"
+	"golang.org/x/oauth2"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/audit"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/random"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	// oidcLoginLifetime is how long a user has to complete a login at the identity provider. It matches
+	// the MaxAge of the ceremony manager, which is what signs the login state.
+	oidcLoginLifetime = webAuthnCeremonyLifetime
+
+	oidcLoginStateName   = "oidc_login"
+	oidcLoginCookieName  = "todo_oidc_login"
+	oidcLoginCookiePath  = "/auth/oidc"
+	oidcLoginRedirectURL = "/"
+
+	oidcRandomValueSize            = 32
+	oidcProvisionedPasswordSize    = 64
+	oidcProvisionedTOTPSecretSize  = 64
+	oidcProvisionedUsernameEntropy = 5
+)
+
+var (
+	errOIDCNotEnabled        = errors.New("OIDC single sign-on is not enabled")
+	errInvalidOIDCLoginState = errors.New("invalid OIDC login state")
+	errInvalidOIDCLogin      = errors.New("invalid OIDC login")
+)
+
+// oidcRelyingParty holds everything we need to talk to a discovered OpenID Connect provider.
+type oidcRelyingParty struct {
+	oauth2Config *oauth2.Config
+	verifier     *oidc.IDTokenVerifier
+}
+
+// oidcLoginState is what we remember about a login between sending the user to the identity provider
+// and them coming back, so that the callback can be tied to the browser that started it. LinkToUser is
+// set when someone who was already logged in started the login, and is the only user a new identity
+// will ever be linked to.
+type oidcLoginState struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+	LinkToUser   string
+}
+
+// oidcClaims are the ID token claims we use to find or provision a user.
+type oidcClaims struct {
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// getOIDCRelyingParty discovers the configured identity provider the first time it's needed, so that an
+// unreachable provider doesn't prevent the server from starting.
+func (s *service) getOIDCRelyingParty(ctx context.Context) (*oidcRelyingParty, error) {
+	_, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	s.oidcRelyingPartyMu.Lock()
+	defer s.oidcRelyingPartyMu.Unlock()
+
+	if s.oidcRelyingParty != nil {
+		return s.oidcRelyingParty, nil
+	}
+
+	cfg := s.config.OIDC
+	logger := s.logger.WithValue("oidc_issuer", cfg.IssuerURL)
+
+	// the provider holds onto this context for fetching signing keys later, so it can't be scoped to a request.
+	provider, err := oidc.NewProvider(context.Background(), cfg.IssuerURL)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "discovering OIDC provider")
+	}
+
+	scopes := []string{oidc.ScopeOpenID, "profile", "email"}
+	if len(cfg.Scopes) > 0 {
+		scopes = append([]string{oidc.ScopeOpenID}, cfg.Scopes...)
+	}
+
+	s.oidcRelyingParty = &oidcRelyingParty{
+		oauth2Config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+	}
+
+	return s.oidcRelyingParty, nil
+}
+
+// buildOIDCLoginState generates the state, nonce, and PKCE code verifier for a new login.
+func buildOIDCLoginState(ctx context.Context) (*oidcLoginState, error) {
+	x := &oidcLoginState{}
+
+	for _, v := range []*string{&x.State, &x.Nonce, &x.CodeVerifier} {
+		value, err := random.GenerateBase64EncodedString(ctx, oidcRandomValueSize)
+		if err != nil {
+			return nil, err
+		}
+
+		*v = value
+	}
+
+	return x, nil
+}
+
+// pkceCodeChallenge derives the S256 PKCE code challenge for a given code verifier.
+func pkceCodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// buildOIDCLoginCookie builds the short-lived cookie that carries a login's state to the callback. It has to be
+// sent on the identity provider's cross-site redirect back to us, so unlike the session cookie it can't be strict.
+func (s *service) buildOIDCLoginCookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oidcLoginCookieName,
+		Value:    value,
+		Path:     oidcLoginCookiePath,
+		HttpOnly: true,
+		Secure:   s.config.Cookies.SecureOnly,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   maxAge,
+	}
+}
+
+// decodeOIDCLoginState unpacks the login state from the cookie set by BeginOIDCLoginHandler.
+func (s *service) decodeOIDCLoginState(req *http.Request) (*oidcLoginState, error) {
+	cookie, err := req.Cookie(oidcLoginCookieName)
+	if err != nil {
+		return nil, errInvalidOIDCLoginState
+	}
+
+	x := &oidcLoginState{}
+	if err = s.ceremonyManager.Decode(oidcLoginStateName, cookie.Value, x); err != nil {
+		return nil, errInvalidOIDCLoginState
+	}
+
+	return x, nil
+}
+
+// resolveOIDCUser finds the user an identity provider's subject belongs to. Subjects we haven't seen before are
+// linked to linkToUserID if the login was started by someone already logged in, or to a freshly provisioned user
+// if the deployment allows it. We never link by email address, since ours are neither verified nor unique.
+// Subjects that can't be tied to anybody yield ErrUserNotFound.
+func (s *service) resolveOIDCUser(ctx context.Context, issuer, subject, linkToUserID string, claims *oidcClaims) (*types.User, error) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger.WithValue("oidc_issuer", issuer)
+
+	identity, err := s.oidcIdentityDataManager.GetOIDCIdentity(ctx, issuer, subject)
+	if err == nil {
+		user, userFetchErr := s.userDataManager.GetUser(ctx, identity.BelongsToUser)
+		if errors.Is(userFetchErr, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		} else if userFetchErr != nil {
+			return nil, observability.PrepareError(userFetchErr, logger, span, "fetching user for OIDC identity")
+		}
+
+		return user, nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, observability.PrepareError(err, logger, span, "fetching OIDC identity")
+	}
+
+	var user *types.User
+	switch {
+	case linkToUserID != "":
+		user, err = s.userDataManager.GetUser(ctx, linkToUserID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		} else if err != nil {
+			return nil, observability.PrepareError(err, logger, span, "fetching user to link OIDC identity to")
+		}
+	case s.config.OIDC.ProvisionUsers:
+		if user, err = s.provisionOIDCUser(ctx, subject, claims); err != nil {
+			return nil, observability.PrepareError(err, logger, span, "provisioning user")
+		}
+	default:
+		return nil, ErrUserNotFound
+	}
+
+	logger = logger.WithValue(keys.UserIDKey, user.ID)
+
+	created, err := s.oidcIdentityDataManager.CreateOIDCIdentity(ctx, &types.OIDCIdentityDatabaseCreationInput{
+		ID:            ksuid.New().String(),
+		Issuer:        issuer,
+		Subject:       subject,
+		BelongsToUser: user.ID,
+	})
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "linking OIDC identity")
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.OIDCIdentityLinkedEvent,
+		ActorUserID:  user.ID,
+		ResourceType: types.OIDCIdentityResourceType,
+		ResourceID:   created.ID,
+	})
+
+	return user, nil
+}
+
+// provisionOIDCUser creates a user for someone who has only ever signed in through the identity provider. They're
+// given a random password and an already verified TOTP secret, neither of which they know, so the identity provider
+// remains their only way in until they reset their password.
+func (s *service) provisionOIDCUser(ctx context.Context, subject string, claims *oidcClaims) (*types.User, error) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger
+
+	username, err := s.availableOIDCUsername(ctx, subject, claims)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "choosing username")
+	}
+
+	logger = logger.WithValue(keys.UsernameKey, username)
+	tracing.AttachUsernameToSpan(span, username)
+
+	password, err := random.GenerateBase64EncodedString(ctx, oidcProvisionedPasswordSize)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "generating password")
+	}
+
+	hashedPassword, err := s.authenticator.HashPassword(ctx, password)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "hashing password")
+	}
+
+	twoFactorSecret, err := random.GenerateBase32EncodedString(ctx, oidcProvisionedTOTPSecretSize)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "generating TOTP secret")
+	}
+
+	input := &types.UserDataStoreCreationInput{
+		ID:              ksuid.New().String(),
+		Username:        username,
+		HashedPassword:  hashedPassword,
+		TwoFactorSecret: twoFactorSecret,
+	}
+
+	// only verified addresses are worth keeping for contacting the user; they're never used to link logins.
+	if claims.EmailVerified {
+		input.EmailAddress = claims.Email
+	}
+
+	user, err := s.userDataManager.CreateUser(ctx, input)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "creating user")
+	}
+
+	logger = logger.WithValue(keys.UserIDKey, user.ID)
+	tracing.AttachUserIDToSpan(span, user.ID)
+
+	if err = s.userDataManager.MarkUserTwoFactorSecretAsVerified(ctx, user.ID); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "verifying two factor secret")
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.UserCreationEvent,
+		ActorUserID:  user.ID,
+		ResourceType: types.UserResourceType,
+		ResourceID:   user.ID,
+	})
+
+	logger.Info("user provisioned via OIDC")
+
+	return user, nil
+}
+
+// availableOIDCUsername picks a username for a provisioned user, preferring what the identity provider suggests.
+func (s *service) availableOIDCUsername(ctx context.Context, subject string, claims *oidcClaims) (string, error) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	username := claims.PreferredUsername
+	if username == "" && claims.Email != "" {
+		username = strings.Split(claims.Email, "@")[0]
+	}
+
+	if username == "" {
+		username = subject
+	}
+
+	_, err := s.userDataManager.GetUserByUsername(ctx, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return username, nil
+	} else if err != nil {
+		return "", observability.PrepareError(err, s.logger, span, "checking username availability")
+	}
+
+	suffix, err := random.GenerateBase32EncodedString(ctx, oidcProvisionedUsernameEntropy)
+	if err != nil {
+		return "", observability.PrepareError(err, s.logger, span, "generating username suffix")
+	}
+
+	return fmt.Sprintf("%s_%s", username, strings.ToLower(suffix)), nil
+}
@@ -0,0 +1,783 @@
+package authentication
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mock2 "gitlab.com/verygoodsoftwarenotvirus/todo/internal/authentication/mock"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+const (
+	testOIDCClientID    = "todo"
+	testOIDCRedirectURL = "http://localhost:8888/auth/oidc/callback"
+)
+
+// enableOIDCForTest points a service at a freshly started mock identity provider.
+func enableOIDCForTest(t *testing.T, s *service, identity testutils.MockOIDCIdentity) *testutils.MockOIDCProvider {
+	t.Helper()
+
+	provider, err := testutils.NewMockOIDCProvider(identity)
+	require.NoError(t, err)
+	t.Cleanup(provider.Close)
+
+	s.config.OIDC = OIDCConfig{
+		IssuerURL:   provider.IssuerURL(),
+		ClientID:    testOIDCClientID,
+		RedirectURL: testOIDCRedirectURL,
+	}
+
+	return provider
+}
+
+// buildOIDCCallbackRequestForTest begins a login and has the mock identity provider approve it, returning the
+// callback request the user's browser would make, login state cookie and all.
+func buildOIDCCallbackRequestForTest(t *testing.T, s *service, provider *testutils.MockOIDCProvider) *http.Request {
+	t.Helper()
+
+	return completeOIDCLoginForTest(t, s, provider, httptest.NewRequest(http.MethodGet, "http://localhost:8888/auth/oidc/login", nil))
+}
+
+// buildLinkingOIDCCallbackRequestForTest is buildOIDCCallbackRequestForTest for a login begun by a user who was
+// already logged in.
+func buildLinkingOIDCCallbackRequestForTest(t *testing.T, s *service, provider *testutils.MockOIDCProvider, user *types.User) *http.Request {
+	t.Helper()
+
+	_, req, _ := attachCookieToRequestForTest(t, s, httptest.NewRequest(http.MethodGet, "http://localhost:8888/auth/oidc/login", nil), user)
+
+	return completeOIDCLoginForTest(t, s, provider, req)
+}
+
+func completeOIDCLoginForTest(t *testing.T, s *service, provider *testutils.MockOIDCProvider, beginReq *http.Request) *http.Request {
+	t.Helper()
+
+	res := httptest.NewRecorder()
+	s.BeginOIDCLoginHandler(res, beginReq)
+	require.Equal(t, http.StatusFound, res.Code)
+
+	callbackURL, err := provider.Authorize(res.Header().Get("Location"))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, callbackURL.String(), nil)
+	for _, c := range res.Result().Cookies() {
+		req.AddCookie(c)
+	}
+
+	return req
+}
+
+func TestPKCECodeChallenge(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		// example from RFC 7636, appendix B.
+		assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", pkceCodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+	})
+}
+
+func TestBuildOIDCLoginState(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		actual, err := buildOIDCLoginState(context.Background())
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, actual.State)
+		assert.NotEmpty(t, actual.Nonce)
+		assert.NotEqual(t, actual.State, actual.Nonce)
+		// RFC 7636 requires code verifiers to be between 43 and 128 characters.
+		assert.GreaterOrEqual(t, len(actual.CodeVerifier), 43)
+	})
+}
+
+func TestAuthenticationService_getOIDCRelyingParty(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		enableOIDCForTest(t, s, testutils.MockOIDCIdentity{})
+
+		first, err := s.getOIDCRelyingParty(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, first)
+
+		second, err := s.getOIDCRelyingParty(ctx)
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+	})
+
+	T.Run("with custom scopes", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		enableOIDCForTest(t, s, testutils.MockOIDCIdentity{})
+		s.config.OIDC.Scopes = []string{"groups"}
+
+		actual, err := s.getOIDCRelyingParty(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"openid", "groups"}, actual.oauth2Config.Scopes)
+	})
+
+	T.Run("with undiscoverable provider", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		provider := enableOIDCForTest(t, s, testutils.MockOIDCIdentity{})
+		provider.Close()
+
+		actual, err := s.getOIDCRelyingParty(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestAuthenticationService_decodeOIDCLoginState(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestService(t)
+
+		expected, err := buildOIDCLoginState(context.Background())
+		require.NoError(t, err)
+
+		encoded, err := s.ceremonyManager.Encode(oidcLoginStateName, expected)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, testOIDCRedirectURL, nil)
+		req.AddCookie(s.buildOIDCLoginCookie(encoded, int(oidcLoginLifetime.Seconds())))
+
+		actual, err := s.decodeOIDCLoginState(req)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	T.Run("without cookie", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestService(t)
+		req := httptest.NewRequest(http.MethodGet, testOIDCRedirectURL, nil)
+
+		actual, err := s.decodeOIDCLoginState(req)
+		assert.ErrorIs(t, err, errInvalidOIDCLoginState)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with tampered cookie", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestService(t)
+		req := httptest.NewRequest(http.MethodGet, testOIDCRedirectURL, nil)
+		req.AddCookie(s.buildOIDCLoginCookie("blah", int(oidcLoginLifetime.Seconds())))
+
+		actual, err := s.decodeOIDCLoginState(req)
+		assert.ErrorIs(t, err, errInvalidOIDCLoginState)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestAuthenticationService_resolveOIDCUser(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with linked identity", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+		exampleIdentity.BelongsToUser = exampleUser.ID
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return(exampleIdentity, nil)
+		s.oidcIdentityDataManager = oidcIdentityDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(exampleUser, nil)
+		s.userDataManager = userDataManager
+
+		actual, err := s.resolveOIDCUser(ctx, exampleIdentity.Issuer, exampleIdentity.Subject, "", &oidcClaims{})
+		assert.NoError(t, err)
+		assert.Equal(t, exampleUser, actual)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager, userDataManager)
+	})
+
+	T.Run("with identity linked to nonexistent user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return(exampleIdentity, nil)
+		s.oidcIdentityDataManager = oidcIdentityDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			exampleIdentity.BelongsToUser,
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		s.userDataManager = userDataManager
+
+		actual, err := s.resolveOIDCUser(ctx, exampleIdentity.Issuer, exampleIdentity.Subject, "", &oidcClaims{})
+		assert.ErrorIs(t, err, ErrUserNotFound)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager, userDataManager)
+	})
+
+	T.Run("with error fetching identity", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return((*types.OIDCIdentity)(nil), errors.New("blah"))
+		s.oidcIdentityDataManager = oidcIdentityDataManager
+
+		actual, err := s.resolveOIDCUser(ctx, exampleIdentity.Issuer, exampleIdentity.Subject, "", &oidcClaims{})
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager)
+	})
+
+	T.Run("links identity to logged in user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+		exampleIdentity.BelongsToUser = exampleUser.ID
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return((*types.OIDCIdentity)(nil), sql.ErrNoRows)
+		oidcIdentityDataManager.On(
+			"CreateOIDCIdentity",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.OIDCIdentityDatabaseCreationInput) bool {
+				return input.Issuer == exampleIdentity.Issuer && input.Subject == exampleIdentity.Subject && input.BelongsToUser == exampleUser.ID
+			}),
+		).Return(exampleIdentity, nil)
+		s.oidcIdentityDataManager = oidcIdentityDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(exampleUser, nil)
+		s.userDataManager = userDataManager
+
+		actual, err := s.resolveOIDCUser(ctx, exampleIdentity.Issuer, exampleIdentity.Subject, exampleUser.ID, &oidcClaims{})
+		assert.NoError(t, err)
+		assert.Equal(t, exampleUser, actual)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager, userDataManager)
+	})
+
+	T.Run("does not link user by email address", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+		exampleClaims := &oidcClaims{Email: fakes.BuildFakeUser().EmailAddress, EmailVerified: true}
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return((*types.OIDCIdentity)(nil), sql.ErrNoRows)
+		s.oidcIdentityDataManager = oidcIdentityDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		s.userDataManager = userDataManager
+
+		actual, err := s.resolveOIDCUser(ctx, exampleIdentity.Issuer, exampleIdentity.Subject, "", exampleClaims)
+		assert.ErrorIs(t, err, ErrUserNotFound)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager, userDataManager)
+	})
+
+	T.Run("with nonexistent user to link to", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return((*types.OIDCIdentity)(nil), sql.ErrNoRows)
+		s.oidcIdentityDataManager = oidcIdentityDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		s.userDataManager = userDataManager
+
+		actual, err := s.resolveOIDCUser(ctx, exampleIdentity.Issuer, exampleIdentity.Subject, exampleUser.ID, &oidcClaims{})
+		assert.ErrorIs(t, err, ErrUserNotFound)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager, userDataManager)
+	})
+
+	T.Run("with error fetching user to link to", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return((*types.OIDCIdentity)(nil), sql.ErrNoRows)
+		s.oidcIdentityDataManager = oidcIdentityDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return((*types.User)(nil), errors.New("blah"))
+		s.userDataManager = userDataManager
+
+		actual, err := s.resolveOIDCUser(ctx, exampleIdentity.Issuer, exampleIdentity.Subject, exampleUser.ID, &oidcClaims{})
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager, userDataManager)
+	})
+
+	T.Run("provisions user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		s.config.OIDC.ProvisionUsers = true
+		exampleUser := fakes.BuildFakeUser()
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+		exampleIdentity.BelongsToUser = exampleUser.ID
+		exampleClaims := &oidcClaims{PreferredUsername: exampleUser.Username}
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return((*types.OIDCIdentity)(nil), sql.ErrNoRows)
+		oidcIdentityDataManager.On(
+			"CreateOIDCIdentity",
+			testutils.ContextMatcher,
+			mock.IsType(&types.OIDCIdentityDatabaseCreationInput{}),
+		).Return(exampleIdentity, nil)
+		s.oidcIdentityDataManager = oidcIdentityDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			exampleUser.Username,
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		userDataManager.On(
+			"CreateUser",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.UserDataStoreCreationInput) bool {
+				return input.Username == exampleUser.Username && input.EmailAddress == "" && input.TwoFactorSecret != ""
+			}),
+		).Return(exampleUser, nil)
+		userDataManager.On(
+			"MarkUserTwoFactorSecretAsVerified",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(nil)
+		s.userDataManager = userDataManager
+
+		authenticator := &mock2.Authenticator{}
+		authenticator.On(
+			"HashPassword",
+			testutils.ContextMatcher,
+			mock.IsType(""),
+		).Return(exampleUser.HashedPassword, nil)
+		s.authenticator = authenticator
+
+		actual, err := s.resolveOIDCUser(ctx, exampleIdentity.Issuer, exampleIdentity.Subject, "", exampleClaims)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleUser, actual)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager, userDataManager, authenticator)
+	})
+
+	T.Run("with error linking identity", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleIdentity := fakes.BuildFakeOIDCIdentity()
+
+		oidcIdentityDataManager := &mocktypes.OIDCIdentityDataManager{}
+		oidcIdentityDataManager.On(
+			"GetOIDCIdentity",
+			testutils.ContextMatcher,
+			exampleIdentity.Issuer,
+			exampleIdentity.Subject,
+		).Return((*types.OIDCIdentity)(nil), sql.ErrNoRows)
+		oidcIdentityDataManager.On(
+			"CreateOIDCIdentity",
+			testutils.ContextMatcher,
+			mock.IsType(&types.OIDCIdentityDatabaseCreationInput{}),
+		).Return((*types.OIDCIdentity)(nil), errors.New("blah"))
+		s.oidcIdentityDataManager = oidcIdentityDataManager
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(exampleUser, nil)
+		s.userDataManager = userDataManager
+
+		actual, err := s.resolveOIDCUser(ctx, exampleIdentity.Issuer, exampleIdentity.Subject, exampleUser.ID, &oidcClaims{})
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, oidcIdentityDataManager, userDataManager)
+	})
+}
+
+func TestAuthenticationService_provisionOIDCUser(T *testing.T) {
+	T.Parallel()
+
+	T.Run("keeps verified email address", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleClaims := &oidcClaims{PreferredUsername: exampleUser.Username, Email: exampleUser.EmailAddress, EmailVerified: true}
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			exampleUser.Username,
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		userDataManager.On(
+			"CreateUser",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.UserDataStoreCreationInput) bool {
+				return input.EmailAddress == exampleUser.EmailAddress
+			}),
+		).Return(exampleUser, nil)
+		userDataManager.On(
+			"MarkUserTwoFactorSecretAsVerified",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(nil)
+		s.userDataManager = userDataManager
+
+		authenticator := &mock2.Authenticator{}
+		authenticator.On(
+			"HashPassword",
+			testutils.ContextMatcher,
+			mock.IsType(""),
+		).Return(exampleUser.HashedPassword, nil)
+		s.authenticator = authenticator
+
+		actual, err := s.provisionOIDCUser(ctx, "subject", exampleClaims)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleUser, actual)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, authenticator)
+	})
+
+	T.Run("with error hashing password", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleClaims := &oidcClaims{PreferredUsername: exampleUser.Username}
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			exampleUser.Username,
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		s.userDataManager = userDataManager
+
+		authenticator := &mock2.Authenticator{}
+		authenticator.On(
+			"HashPassword",
+			testutils.ContextMatcher,
+			mock.IsType(""),
+		).Return("", errors.New("blah"))
+		s.authenticator = authenticator
+
+		actual, err := s.provisionOIDCUser(ctx, "subject", exampleClaims)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, authenticator)
+	})
+
+	T.Run("with error creating user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleClaims := &oidcClaims{PreferredUsername: exampleUser.Username}
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			exampleUser.Username,
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		userDataManager.On(
+			"CreateUser",
+			testutils.ContextMatcher,
+			mock.IsType(&types.UserDataStoreCreationInput{}),
+		).Return((*types.User)(nil), errors.New("blah"))
+		s.userDataManager = userDataManager
+
+		authenticator := &mock2.Authenticator{}
+		authenticator.On(
+			"HashPassword",
+			testutils.ContextMatcher,
+			mock.IsType(""),
+		).Return(exampleUser.HashedPassword, nil)
+		s.authenticator = authenticator
+
+		actual, err := s.provisionOIDCUser(ctx, "subject", exampleClaims)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, authenticator)
+	})
+
+	T.Run("with error verifying two factor secret", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleClaims := &oidcClaims{PreferredUsername: exampleUser.Username}
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			exampleUser.Username,
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		userDataManager.On(
+			"CreateUser",
+			testutils.ContextMatcher,
+			mock.IsType(&types.UserDataStoreCreationInput{}),
+		).Return(exampleUser, nil)
+		userDataManager.On(
+			"MarkUserTwoFactorSecretAsVerified",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(errors.New("blah"))
+		s.userDataManager = userDataManager
+
+		authenticator := &mock2.Authenticator{}
+		authenticator.On(
+			"HashPassword",
+			testutils.ContextMatcher,
+			mock.IsType(""),
+		).Return(exampleUser.HashedPassword, nil)
+		s.authenticator = authenticator
+
+		actual, err := s.provisionOIDCUser(ctx, "subject", exampleClaims)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, userDataManager, authenticator)
+	})
+}
+
+func TestAuthenticationService_availableOIDCUsername(T *testing.T) {
+	T.Parallel()
+
+	T.Run("prefers preferred username", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			"preferred",
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		s.userDataManager = userDataManager
+
+		actual, err := s.availableOIDCUsername(ctx, "subject", &oidcClaims{PreferredUsername: "preferred", Email: "email@todo.verygoodsoftwarenotvirus.ru"})
+		assert.NoError(t, err)
+		assert.Equal(t, "preferred", actual)
+
+		mock.AssertExpectationsForObjects(t, userDataManager)
+	})
+
+	T.Run("falls back to email address", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			"email",
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		s.userDataManager = userDataManager
+
+		actual, err := s.availableOIDCUsername(ctx, "subject", &oidcClaims{Email: "email@todo.verygoodsoftwarenotvirus.ru"})
+		assert.NoError(t, err)
+		assert.Equal(t, "email", actual)
+
+		mock.AssertExpectationsForObjects(t, userDataManager)
+	})
+
+	T.Run("falls back to subject", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			"subject",
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		s.userDataManager = userDataManager
+
+		actual, err := s.availableOIDCUsername(ctx, "subject", &oidcClaims{})
+		assert.NoError(t, err)
+		assert.Equal(t, "subject", actual)
+
+		mock.AssertExpectationsForObjects(t, userDataManager)
+	})
+
+	T.Run("with username already taken", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			"preferred",
+		).Return(fakes.BuildFakeUser(), nil)
+		s.userDataManager = userDataManager
+
+		actual, err := s.availableOIDCUsername(ctx, "subject", &oidcClaims{PreferredUsername: "preferred"})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(actual, "preferred_"))
+		assert.NotEqual(t, "preferred_", actual)
+
+		mock.AssertExpectationsForObjects(t, userDataManager)
+	})
+
+	T.Run("with error checking username", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+
+		userDataManager := &mocktypes.UserDataManager{}
+		userDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			"preferred",
+		).Return((*types.User)(nil), errors.New("blah"))
+		s.userDataManager = userDataManager
+
+		actual, err := s.availableOIDCUsername(ctx, "subject", &oidcClaims{PreferredUsername: "preferred"})
+		assert.Error(t, err)
+		assert.Empty(t, actual)
+
+		mock.AssertExpectationsForObjects(t, userDataManager)
+	})
+}
@@ -3,23 +3,28 @@
 import (
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/alexedwards/scs/v2"
+	"github.com/duo-labs/webauthn/webauthn"
 	"github.com/gorilla/securecookie"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authentication"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
 const (
-	serviceName         = "auth_service"
-	userIDContextKey    = string(types.UserIDContextKey)
-	accountIDContextKey = string(types.AccountIDContextKey)
-	cookieErrorLogName  = "_COOKIE_CONSTRUCTION_ERROR_"
-	cookieSecretSize    = 64
+	serviceName             = "auth_service"
+	userIDContextKey        = string(types.UserIDContextKey)
+	accountIDContextKey     = string(types.AccountIDContextKey)
+	userSessionIDContextKey = "user_session_id"
+	cookieErrorLogName      = "_COOKIE_CONSTRUCTION_ERROR_"
+	cookieSecretSize        = 64
 )
 
 type (
@@ -31,17 +36,28 @@
 
 	// service handles passwords service-wide.
 	service struct {
-		config                    *Config
-		logger                    logging.Logger
-		authenticator             authentication.Authenticator
-		userDataManager           types.UserDataManager
-		apiClientManager          types.APIClientDataManager
-		accountMembershipManager  types.AccountUserMembershipDataManager
-		encoderDecoder            encoding.ServerEncoderDecoder
-		cookieManager             cookieEncoderDecoder
-		sessionManager            sessionManager
-		sessionContextDataFetcher func(*http.Request) (*types.SessionContextData, error)
-		tracer                    tracing.Tracer
+		config                        *Config
+		logger                        logging.Logger
+		authenticator                 authentication.Authenticator
+		userDataManager               types.UserDataManager
+		apiClientManager              types.APIClientDataManager
+		accountMembershipManager      types.AccountUserMembershipDataManager
+		userSessionDataManager        types.UserSessionDataManager
+		webAuthnCredentialDataManager types.WebAuthnCredentialDataManager
+		oidcIdentityDataManager       types.OIDCIdentityDataManager
+		auditLogEntryDataManager      types.AuditLogEntryDataManager
+		encoderDecoder                encoding.ServerEncoderDecoder
+		dataChangesPublisher          publishers.Publisher
+		cookieManager                 cookieEncoderDecoder
+		ceremonyManager               cookieEncoderDecoder
+		sessionManager                sessionManager
+		webAuthn                      *webauthn.WebAuthn
+		oidcRelyingParty              *oidcRelyingParty
+		oidcRelyingPartyMu            sync.Mutex
+		sessionContextDataFetcher     func(*http.Request) (*types.SessionContextData, error)
+		userSessionIDFetcher          func(*http.Request) string
+		webAuthnCredentialIDFetcher   func(*http.Request) string
+		tracer                        tracing.Tracer
 	}
 )
 
@@ -53,26 +69,51 @@ func ProvideService(
 	userDataManager types.UserDataManager,
 	apiClientsService types.APIClientDataManager,
 	accountMembershipManager types.AccountUserMembershipDataManager,
+	userSessionDataManager types.UserSessionDataManager,
+	webAuthnCredentialDataManager types.WebAuthnCredentialDataManager,
+	oidcIdentityDataManager types.OIDCIdentityDataManager,
+	auditLogEntryDataManager types.AuditLogEntryDataManager,
 	sessionManager *scs.SessionManager,
 	encoder encoding.ServerEncoderDecoder,
+	routeParamManager routing.RouteParamManager,
+	publisherProvider publishers.PublisherProvider,
 ) (types.AuthService, error) {
 	hashKey := []byte(cfg.Cookies.HashKey)
 	if len(hashKey) == 0 {
 		hashKey = securecookie.GenerateRandomKey(cookieSecretSize)
 	}
 
+	webAuthn, err := provideWebAuthn(cfg.WebAuthn)
+	if err != nil {
+		return nil, fmt.Errorf("configuring WebAuthn: %w", err)
+	}
+
+	dataChangesPublisher, err := publisherProvider.ProviderPublisher(cfg.DataChangesTopicName)
+	if err != nil {
+		return nil, fmt.Errorf("setting up data changes publisher: %w", err)
+	}
+
 	svc := &service{
-		logger:                    logging.EnsureLogger(logger).WithName(serviceName),
-		encoderDecoder:            encoder,
-		config:                    cfg,
-		userDataManager:           userDataManager,
-		apiClientManager:          apiClientsService,
-		accountMembershipManager:  accountMembershipManager,
-		authenticator:             authenticator,
-		sessionManager:            sessionManager,
-		sessionContextDataFetcher: FetchContextFromRequest,
-		cookieManager:             securecookie.New(hashKey, []byte(cfg.Cookies.SigningKey)),
-		tracer:                    tracing.NewTracer(serviceName),
+		logger:                        logging.EnsureLogger(logger).WithName(serviceName),
+		encoderDecoder:                encoder,
+		dataChangesPublisher:          dataChangesPublisher,
+		config:                        cfg,
+		userDataManager:               userDataManager,
+		apiClientManager:              apiClientsService,
+		accountMembershipManager:      accountMembershipManager,
+		userSessionDataManager:        userSessionDataManager,
+		webAuthnCredentialDataManager: webAuthnCredentialDataManager,
+		oidcIdentityDataManager:       oidcIdentityDataManager,
+		auditLogEntryDataManager:      auditLogEntryDataManager,
+		authenticator:                 authenticator,
+		sessionManager:                sessionManager,
+		webAuthn:                      webAuthn,
+		sessionContextDataFetcher:     FetchContextFromRequest,
+		userSessionIDFetcher:          routeParamManager.BuildRouteParamStringIDFetcher(UserSessionIDURIParamKey),
+		webAuthnCredentialIDFetcher:   routeParamManager.BuildRouteParamStringIDFetcher(WebAuthnCredentialIDURIParamKey),
+		cookieManager:                 securecookie.New(hashKey, []byte(cfg.Cookies.SigningKey)),
+		ceremonyManager:               securecookie.New(hashKey, []byte(cfg.Cookies.SigningKey)).MaxAge(int(webAuthnCeremonyLifetime.Seconds())),
+		tracer:                        tracing.NewTracer(serviceName),
 	}
 
 	if _, err := svc.cookieManager.Encode(cfg.Cookies.Name, "blah"); err != nil {
@@ -1,18 +1,31 @@
 package authentication
 
 import (
+	"errors"
+	"net/http"
 	"testing"
 	"time"
 
-	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authentication/mock"
+	mock2 "gitlab.com/verygoodsoftwarenotvirus/todo/internal/authentication/mock"
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	mockpublishers "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	mockrouting "gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing/mock"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+const (
+	testWebAuthnRelyingPartyID = "localhost"
+	testWebAuthnOrigin         = "http://localhost:8888"
+	testDataChangesTopicName   = "data_changes"
 )
 
 func buildTestService(t *testing.T) *service {
@@ -21,6 +34,48 @@ func buildTestService(t *testing.T) *service {
 	logger := logging.NewNoopLogger()
 	encoderDecoder := encoding.ProvideServerEncoderDecoder(logger, encoding.ContentTypeJSON)
 
+	rpm := mockrouting.NewRouteParamManager()
+	rpm.On(
+		"BuildRouteParamStringIDFetcher",
+		UserSessionIDURIParamKey,
+	).Return(func(*http.Request) string { return "" })
+	rpm.On(
+		"BuildRouteParamStringIDFetcher",
+		WebAuthnCredentialIDURIParamKey,
+	).Return(func(*http.Request) string { return "" })
+
+	// most tests don't care about session bookkeeping, so tests that do replace this.
+	userSessionDataManager := &mocktypes.UserSessionDataManager{}
+	userSessionDataManager.On(
+		"CreateUserSession",
+		testutils.ContextMatcher,
+		mock.IsType(&types.UserSessionDatabaseCreationInput{}),
+	).Return(&types.UserSession{}, nil).Maybe()
+	userSessionDataManager.On(
+		"UpdateUserSessionToken",
+		testutils.ContextMatcher,
+		mock.IsType(""),
+		mock.IsType(""),
+		mock.IsType(""),
+	).Return(nil).Maybe()
+
+	auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+	auditLogEntryDataManager.On(
+		"CreateAuditLogEntry",
+		testutils.ContextMatcher,
+		mock.MatchedBy(testutils.AuditLogEntryCreationInputMatcher),
+	).Return(nil).Maybe()
+
+	dataChangesPublisher := &mockpublishers.Publisher{}
+	dataChangesPublisher.On(
+		"Publish",
+		testutils.ContextMatcher,
+		mock.IsType(&types.DataChangeMessage{}),
+	).Return(nil).Maybe()
+
+	pp := &mockpublishers.ProducerProvider{}
+	pp.On("ProviderPublisher", testDataChangesTopicName).Return(dataChangesPublisher, nil)
+
 	s, err := ProvideService(
 		logger,
 		&Config{
@@ -28,21 +83,34 @@ func buildTestService(t *testing.T) *service {
 				Name:       DefaultCookieName,
 				SigningKey: "BLAHBLAHBLAHPRETENDTHISISSECRET!",
 			},
+			DataChangesTopicName: testDataChangesTopicName,
 			PASETO: PASETOConfig{
 				Issuer:       "test",
 				LocalModeKey: []byte("BLAHBLAHBLAHPRETENDTHISISSECRET!"),
 				Lifetime:     time.Hour,
 			},
+			WebAuthn: WebAuthnConfig{
+				RelyingPartyID:     testWebAuthnRelyingPartyID,
+				RelyingPartyOrigin: testWebAuthnOrigin,
+			},
 		},
-		&mock.Authenticator{},
+		&mock2.Authenticator{},
 		&mocktypes.UserDataManager{},
 		&mocktypes.APIClientDataManager{},
 		&mocktypes.AccountUserMembershipDataManager{},
+		userSessionDataManager,
+		&mocktypes.WebAuthnCredentialDataManager{},
+		&mocktypes.OIDCIdentityDataManager{},
+		auditLogEntryDataManager,
 		scs.New(),
 		encoderDecoder,
+		rpm,
+		pp,
 	)
 	require.NoError(t, err)
 
+	mock.AssertExpectationsForObjects(t, rpm)
+
 	return s.(*service)
 }
 
@@ -54,6 +122,19 @@ func TestProvideService(T *testing.T) {
 		logger := logging.NewNoopLogger()
 		encoderDecoder := encoding.ProvideServerEncoderDecoder(logger, encoding.ContentTypeJSON)
 
+		pp := &mockpublishers.ProducerProvider{}
+		pp.On("ProviderPublisher", "").Return(&mockpublishers.Publisher{}, nil)
+
+		rpm := mockrouting.NewRouteParamManager()
+		rpm.On(
+			"BuildRouteParamStringIDFetcher",
+			UserSessionIDURIParamKey,
+		).Return(func(*http.Request) string { return "" })
+		rpm.On(
+			"BuildRouteParamStringIDFetcher",
+			WebAuthnCredentialIDURIParamKey,
+		).Return(func(*http.Request) string { return "" })
+
 		s, err := ProvideService(
 			logger,
 			&Config{
@@ -62,12 +143,18 @@ func TestProvideService(T *testing.T) {
 					SigningKey: "BLAHBLAHBLAHPRETENDTHISISSECRET!",
 				},
 			},
-			&mock.Authenticator{},
+			&mock2.Authenticator{},
 			&mocktypes.UserDataManager{},
 			&mocktypes.APIClientDataManager{},
 			&mocktypes.AccountUserMembershipDataManager{},
+			&mocktypes.UserSessionDataManager{},
+			&mocktypes.WebAuthnCredentialDataManager{},
+			&mocktypes.OIDCIdentityDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
 			scs.New(),
 			encoderDecoder,
+			rpm,
+			pp,
 		)
 
 		assert.NotNil(t, s)
@@ -79,6 +166,19 @@ func TestProvideService(T *testing.T) {
 		logger := logging.NewNoopLogger()
 		encoderDecoder := encoding.ProvideServerEncoderDecoder(logger, encoding.ContentTypeJSON)
 
+		pp := &mockpublishers.ProducerProvider{}
+		pp.On("ProviderPublisher", "").Return(&mockpublishers.Publisher{}, nil)
+
+		rpm := mockrouting.NewRouteParamManager()
+		rpm.On(
+			"BuildRouteParamStringIDFetcher",
+			UserSessionIDURIParamKey,
+		).Return(func(*http.Request) string { return "" })
+		rpm.On(
+			"BuildRouteParamStringIDFetcher",
+			WebAuthnCredentialIDURIParamKey,
+		).Return(func(*http.Request) string { return "" })
+
 		s, err := ProvideService(
 			logger,
 			&Config{
@@ -87,12 +187,99 @@ func TestProvideService(T *testing.T) {
 					SigningKey: "BLAHBLAHBLAH",
 				},
 			},
-			&mock.Authenticator{},
+			&mock2.Authenticator{},
+			&mocktypes.UserDataManager{},
+			&mocktypes.APIClientDataManager{},
+			&mocktypes.AccountUserMembershipDataManager{},
+			&mocktypes.UserSessionDataManager{},
+			&mocktypes.WebAuthnCredentialDataManager{},
+			&mocktypes.OIDCIdentityDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
+			scs.New(),
+			encoderDecoder,
+			rpm,
+			pp,
+		)
+
+		assert.Nil(t, s)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid WebAuthn config", func(t *testing.T) {
+		t.Parallel()
+		logger := logging.NewNoopLogger()
+		encoderDecoder := encoding.ProvideServerEncoderDecoder(logger, encoding.ContentTypeJSON)
+
+		pp := &mockpublishers.ProducerProvider{}
+		pp.On("ProviderPublisher", "").Return(&mockpublishers.Publisher{}, nil)
+
+		s, err := ProvideService(
+			logger,
+			&Config{
+				Cookies: CookieConfig{
+					Name:       DefaultCookieName,
+					SigningKey: "BLAHBLAHBLAHPRETENDTHISISSECRET!",
+				},
+				WebAuthn: WebAuthnConfig{
+					RelyingPartyID: ":",
+				},
+			},
+			&mock2.Authenticator{},
+			&mocktypes.UserDataManager{},
+			&mocktypes.APIClientDataManager{},
+			&mocktypes.AccountUserMembershipDataManager{},
+			&mocktypes.UserSessionDataManager{},
+			&mocktypes.WebAuthnCredentialDataManager{},
+			&mocktypes.OIDCIdentityDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
+			scs.New(),
+			encoderDecoder,
+			mockrouting.NewRouteParamManager(),
+			pp,
+		)
+
+		assert.Nil(t, s)
+		assert.Error(t, err)
+	})
+
+	T.Run("with error providing data changes publisher", func(t *testing.T) {
+		t.Parallel()
+		logger := logging.NewNoopLogger()
+		encoderDecoder := encoding.ProvideServerEncoderDecoder(logger, encoding.ContentTypeJSON)
+
+		pp := &mockpublishers.ProducerProvider{}
+		pp.On("ProviderPublisher", "").Return((*mockpublishers.Publisher)(nil), errors.New("blah"))
+
+		rpm := mockrouting.NewRouteParamManager()
+		rpm.On(
+			"BuildRouteParamStringIDFetcher",
+			UserSessionIDURIParamKey,
+		).Return(func(*http.Request) string { return "" })
+		rpm.On(
+			"BuildRouteParamStringIDFetcher",
+			WebAuthnCredentialIDURIParamKey,
+		).Return(func(*http.Request) string { return "" })
+
+		s, err := ProvideService(
+			logger,
+			&Config{
+				Cookies: CookieConfig{
+					Name:       DefaultCookieName,
+					SigningKey: "BLAHBLAHBLAHPRETENDTHISISSECRET!",
+				},
+			},
+			&mock2.Authenticator{},
 			&mocktypes.UserDataManager{},
 			&mocktypes.APIClientDataManager{},
 			&mocktypes.AccountUserMembershipDataManager{},
+			&mocktypes.UserSessionDataManager{},
+			&mocktypes.WebAuthnCredentialDataManager{},
+			&mocktypes.OIDCIdentityDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
 			scs.New(),
 			encoderDecoder,
+			rpm,
+			pp,
 		)
 
 		assert.Nil(t, s)
@@ -0,0 +1,214 @@
+package authentication
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/duo-labs/webauthn/webauthn"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	// webAuthnCeremonyLifetime is how long a user has to complete a WebAuthn ceremony once it's begun.
+	webAuthnCeremonyLifetime = 5 * time.Minute
+
+	webAuthnRegistrationCeremonyName = "webauthn_registration"
+	webAuthnLoginCeremonyName        = "webauthn_login"
+)
+
+var (
+	errWebAuthnNotEnabled         = errors.New("WebAuthn is not enabled")
+	errInvalidWebAuthnCeremony    = errors.New("invalid WebAuthn ceremony token")
+	errInvalidWebAuthnAssertion   = errors.New("invalid WebAuthn assertion")
+	errNoWebAuthnCredentialsFound = errors.New("no WebAuthn credentials registered")
+)
+
+// provideWebAuthn builds the WebAuthn relying party for a given config, or returns nil if WebAuthn isn't configured.
+func provideWebAuthn(cfg WebAuthnConfig) (*webauthn.WebAuthn, error) {
+	if cfg.RelyingPartyID == "" {
+		return nil, nil
+	}
+
+	displayName := cfg.RelyingPartyDisplayName
+	if displayName == "" {
+		displayName = DefaultWebAuthnRelyingPartyDisplayName
+	}
+
+	return webauthn.New(&webauthn.Config{
+		RPID:          cfg.RelyingPartyID,
+		RPDisplayName: displayName,
+		RPOrigin:      cfg.RelyingPartyOrigin,
+		Timeout:       int(webAuthnCeremonyLifetime.Milliseconds()),
+	})
+}
+
+// webAuthnUser adapts a user and their registered credentials to what the webauthn library expects.
+type webAuthnUser struct {
+	user        *types.User
+	credentials []*types.WebAuthnCredential
+}
+
+var _ webauthn.User = (*webAuthnUser)(nil)
+
+// WebAuthnID implements the webauthn.User interface.
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return []byte(u.user.ID)
+}
+
+// WebAuthnName implements the webauthn.User interface.
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.user.Username
+}
+
+// WebAuthnDisplayName implements the webauthn.User interface.
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.user.Username
+}
+
+// WebAuthnIcon implements the webauthn.User interface.
+func (u *webAuthnUser) WebAuthnIcon() string {
+	return ""
+}
+
+// WebAuthnCredentials implements the webauthn.User interface.
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(u.credentials))
+
+	for _, c := range u.credentials {
+		credentials = append(credentials, webauthn.Credential{
+			ID:              c.CredentialID,
+			PublicKey:       c.PublicKey,
+			AttestationType: c.AttestationType,
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    c.AAGUID,
+				SignCount: c.SignCount,
+			},
+		})
+	}
+
+	return credentials
+}
+
+// credentialDescriptors describes the user's existing credentials, so authenticators can avoid registering twice.
+func (u *webAuthnUser) credentialDescriptors() []protocol.CredentialDescriptor {
+	descriptors := make([]protocol.CredentialDescriptor, 0, len(u.credentials))
+
+	for _, c := range u.credentials {
+		descriptors = append(descriptors, protocol.CredentialDescriptor{
+			Type:         protocol.PublicKeyCredentialType,
+			CredentialID: c.CredentialID,
+		})
+	}
+
+	return descriptors
+}
+
+// findCredential returns the stored credential with a given credential ID, if the user has one.
+func (u *webAuthnUser) findCredential(credentialID []byte) *types.WebAuthnCredential {
+	for _, c := range u.credentials {
+		if bytes.Equal(c.CredentialID, credentialID) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// buildWebAuthnUser fetches a user's registered credentials and wraps them up for the webauthn library.
+func (s *service) buildWebAuthnUser(ctx context.Context, user *types.User) (*webAuthnUser, error) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger.WithValue(keys.UserIDKey, user.ID)
+
+	credentials, err := s.webAuthnCredentialDataManager.GetWebAuthnCredentialsForUser(ctx, user.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		credentials = &types.WebAuthnCredentialList{}
+	} else if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "fetching WebAuthn credentials")
+	}
+
+	return &webAuthnUser{user: user, credentials: credentials.WebAuthnCredentials}, nil
+}
+
+// encodeWebAuthnCeremony packs the state of an in-progress WebAuthn ceremony into an opaque, signed token,
+// so that we don't have to hold onto it server-side while the user talks to their authenticator.
+func (s *service) encodeWebAuthnCeremony(ceremonyName string, sessionData *webauthn.SessionData) (string, error) {
+	return s.ceremonyManager.Encode(ceremonyName, sessionData)
+}
+
+// decodeWebAuthnCeremony unpacks a token produced by encodeWebAuthnCeremony for the same kind of ceremony.
+func (s *service) decodeWebAuthnCeremony(ceremonyName, token string) (*webauthn.SessionData, error) {
+	sessionData := &webauthn.SessionData{}
+	if err := s.ceremonyManager.Decode(ceremonyName, token, sessionData); err != nil {
+		return nil, errInvalidWebAuthnCeremony
+	}
+
+	return sessionData, nil
+}
+
+// validateWebAuthnAssertion checks a login's WebAuthn assertion against the credentials the user has registered,
+// and records the credential's use. Assertions that don't check out yield errInvalidWebAuthnAssertion.
+func (s *service) validateWebAuthnAssertion(ctx context.Context, user *types.User, loginInput *types.UserLoginInput) error {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger.WithValue(keys.UserIDKey, user.ID)
+
+	if s.webAuthn == nil {
+		return errInvalidWebAuthnAssertion
+	}
+
+	sessionData, err := s.decodeWebAuthnCeremony(webAuthnLoginCeremonyName, loginInput.WebAuthnCeremonyToken)
+	if err != nil {
+		logger.Debug("invalid WebAuthn ceremony token provided")
+		return errInvalidWebAuthnAssertion
+	}
+
+	assertion, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(loginInput.WebAuthnAssertion))
+	if err != nil {
+		logger.Debug("unparseable WebAuthn assertion provided")
+		return errInvalidWebAuthnAssertion
+	}
+
+	wu, err := s.buildWebAuthnUser(ctx, user)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building WebAuthn user")
+	}
+
+	credential, err := s.webAuthn.ValidateLogin(wu, *sessionData, assertion)
+	if err != nil {
+		logger.WithValue("reason", err.Error()).Debug("WebAuthn assertion failed validation")
+		return errInvalidWebAuthnAssertion
+	}
+
+	// a signature counter that didn't advance suggests the authenticator has been cloned.
+	if credential.Authenticator.CloneWarning {
+		logger.Info("WebAuthn signature counter did not advance")
+		return errInvalidWebAuthnAssertion
+	}
+
+	storedCredential := wu.findCredential(credential.ID)
+	if storedCredential == nil {
+		return errInvalidWebAuthnAssertion
+	}
+
+	tracing.AttachWebAuthnCredentialIDToSpan(span, storedCredential.ID)
+	logger = logger.WithValue(keys.WebAuthnCredentialIDKey, storedCredential.ID)
+
+	if err = s.webAuthnCredentialDataManager.MarkWebAuthnCredentialAsUsed(ctx, storedCredential.ID, credential.Authenticator.SignCount); err != nil {
+		return observability.PrepareError(err, logger, span, "marking WebAuthn credential as used")
+	}
+
+	logger.Debug("WebAuthn assertion validated")
+
+	return nil
+}
@@ -0,0 +1,457 @@
+package authentication
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/duo-labs/webauthn/webauthn"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+// registerWebAuthnCredentialForTest runs a registration ceremony against a software authenticator, and returns
+// the authenticator along with the credential as the service would have stored it.
+func registerWebAuthnCredentialForTest(t *testing.T, s *service, user *types.User) (*testutils.SoftwareAuthenticator, *types.WebAuthnCredential) {
+	t.Helper()
+
+	wu := &webAuthnUser{user: user}
+
+	options, sessionData, err := s.webAuthn.BeginRegistration(wu)
+	require.NoError(t, err)
+
+	authenticator := testutils.NewSoftwareAuthenticator(testWebAuthnOrigin)
+	rawCredential, err := authenticator.CreateCredential(options)
+	require.NoError(t, err)
+
+	parsedCredential, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(rawCredential))
+	require.NoError(t, err)
+
+	credential, err := s.webAuthn.CreateCredential(wu, *sessionData, parsedCredential)
+	require.NoError(t, err)
+
+	return authenticator, &types.WebAuthnCredential{
+		ID:              ksuid.New().String(),
+		Name:            t.Name(),
+		BelongsToUser:   user.ID,
+		AttestationType: credential.AttestationType,
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+	}
+}
+
+// buildWebAuthnLoginForTest begins a login ceremony for a user and has a software authenticator answer it,
+// returning the ceremony token and assertion a client would submit with its login.
+func buildWebAuthnLoginForTest(t *testing.T, s *service, user *types.User, authenticator *testutils.SoftwareAuthenticator, credentials ...*types.WebAuthnCredential) (string, json.RawMessage) {
+	t.Helper()
+
+	options, sessionData, err := s.webAuthn.BeginLogin(&webAuthnUser{user: user, credentials: credentials})
+	require.NoError(t, err)
+
+	ceremonyToken, err := s.encodeWebAuthnCeremony(webAuthnLoginCeremonyName, sessionData)
+	require.NoError(t, err)
+
+	assertion, err := authenticator.GetAssertion(options)
+	require.NoError(t, err)
+
+	return ceremonyToken, assertion
+}
+
+func TestProvideWebAuthn(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		actual, err := provideWebAuthn(WebAuthnConfig{
+			RelyingPartyID:     testWebAuthnRelyingPartyID,
+			RelyingPartyOrigin: testWebAuthnOrigin,
+		})
+		assert.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Equal(t, DefaultWebAuthnRelyingPartyDisplayName, actual.Config.RPDisplayName)
+	})
+
+	T.Run("without relying party ID", func(t *testing.T) {
+		t.Parallel()
+
+		actual, err := provideWebAuthn(WebAuthnConfig{})
+		assert.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestWebAuthnUser(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUser := fakes.BuildFakeUser()
+		exampleCredentials := fakes.BuildFakeWebAuthnCredentialList().WebAuthnCredentials
+
+		wu := &webAuthnUser{user: exampleUser, credentials: exampleCredentials}
+
+		assert.Equal(t, []byte(exampleUser.ID), wu.WebAuthnID())
+		assert.Equal(t, exampleUser.Username, wu.WebAuthnName())
+		assert.Equal(t, exampleUser.Username, wu.WebAuthnDisplayName())
+		assert.Empty(t, wu.WebAuthnIcon())
+
+		credentials := wu.WebAuthnCredentials()
+		descriptors := wu.credentialDescriptors()
+		require.Len(t, credentials, len(exampleCredentials))
+		require.Len(t, descriptors, len(exampleCredentials))
+
+		for i, c := range exampleCredentials {
+			assert.Equal(t, c.CredentialID, credentials[i].ID)
+			assert.Equal(t, c.PublicKey, credentials[i].PublicKey)
+			assert.Equal(t, c.SignCount, credentials[i].Authenticator.SignCount)
+			assert.Equal(t, protocol.CredentialDescriptor{Type: protocol.PublicKeyCredentialType, CredentialID: c.CredentialID}, descriptors[i])
+		}
+
+		assert.Equal(t, exampleCredentials[1], wu.findCredential(exampleCredentials[1].CredentialID))
+		assert.Nil(t, wu.findCredential([]byte("nonexistent")))
+	})
+}
+
+func TestAuthenticationService_buildWebAuthnUser(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+		exampleCredentialList := fakes.BuildFakeWebAuthnCredentialList()
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(exampleCredentialList, nil)
+		s.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		actual, err := s.buildWebAuthnUser(ctx, exampleUser)
+		assert.NoError(t, err)
+		assert.Equal(t, &webAuthnUser{user: exampleUser, credentials: exampleCredentialList.WebAuthnCredentials}, actual)
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with no credentials", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return((*types.WebAuthnCredentialList)(nil), sql.ErrNoRows)
+		s.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		actual, err := s.buildWebAuthnUser(ctx, exampleUser)
+		assert.NoError(t, err)
+		require.NotNil(t, actual)
+		assert.Empty(t, actual.credentials)
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with error fetching credentials", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return((*types.WebAuthnCredentialList)(nil), errors.New("blah"))
+		s.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		actual, err := s.buildWebAuthnUser(ctx, exampleUser)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+}
+
+func TestAuthenticationService_encodeWebAuthnCeremony(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestService(t)
+		exampleSessionData := &webauthn.SessionData{
+			Challenge: t.Name(),
+			UserID:    []byte(fakes.BuildFakeID()),
+		}
+
+		token, err := s.encodeWebAuthnCeremony(webAuthnLoginCeremonyName, exampleSessionData)
+		require.NoError(t, err)
+
+		actual, err := s.decodeWebAuthnCeremony(webAuthnLoginCeremonyName, token)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleSessionData, actual)
+	})
+
+	T.Run("with token for another ceremony", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestService(t)
+		exampleSessionData := &webauthn.SessionData{
+			Challenge: t.Name(),
+			UserID:    []byte(fakes.BuildFakeID()),
+		}
+
+		token, err := s.encodeWebAuthnCeremony(webAuthnRegistrationCeremonyName, exampleSessionData)
+		require.NoError(t, err)
+
+		actual, err := s.decodeWebAuthnCeremony(webAuthnLoginCeremonyName, token)
+		assert.True(t, errors.Is(err, errInvalidWebAuthnCeremony))
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with garbage token", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestService(t)
+
+		actual, err := s.decodeWebAuthnCeremony(webAuthnLoginCeremonyName, t.Name())
+		assert.True(t, errors.Is(err, errInvalidWebAuthnCeremony))
+		assert.Nil(t, actual)
+	})
+}
+
+func TestAuthenticationService_validateWebAuthnAssertion(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+
+		authenticator, exampleCredential := registerWebAuthnCredentialForTest(t, s, exampleUser)
+		ceremonyToken, assertion := buildWebAuthnLoginForTest(t, s, exampleUser, authenticator, exampleCredential)
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(&types.WebAuthnCredentialList{WebAuthnCredentials: []*types.WebAuthnCredential{exampleCredential}}, nil)
+		webAuthnCredentialDataManager.On(
+			"MarkWebAuthnCredentialAsUsed",
+			testutils.ContextMatcher,
+			exampleCredential.ID,
+			uint32(1),
+		).Return(nil)
+		s.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		loginInput := &types.UserLoginInput{
+			WebAuthnCeremonyToken: ceremonyToken,
+			WebAuthnAssertion:     assertion,
+		}
+
+		assert.NoError(t, s.validateWebAuthnAssertion(ctx, exampleUser, loginInput))
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with WebAuthn disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		s.webAuthn = nil
+
+		err := s.validateWebAuthnAssertion(ctx, fakes.BuildFakeUser(), &types.UserLoginInput{})
+		assert.True(t, errors.Is(err, errInvalidWebAuthnAssertion))
+	})
+
+	T.Run("with invalid ceremony token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+
+		loginInput := &types.UserLoginInput{
+			WebAuthnCeremonyToken: t.Name(),
+			WebAuthnAssertion:     json.RawMessage(`{}`),
+		}
+
+		err := s.validateWebAuthnAssertion(ctx, fakes.BuildFakeUser(), loginInput)
+		assert.True(t, errors.Is(err, errInvalidWebAuthnAssertion))
+	})
+
+	T.Run("with unparseable assertion", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+
+		authenticator, exampleCredential := registerWebAuthnCredentialForTest(t, s, exampleUser)
+		ceremonyToken, _ := buildWebAuthnLoginForTest(t, s, exampleUser, authenticator, exampleCredential)
+
+		loginInput := &types.UserLoginInput{
+			WebAuthnCeremonyToken: ceremonyToken,
+			WebAuthnAssertion:     json.RawMessage(`{}`),
+		}
+
+		err := s.validateWebAuthnAssertion(ctx, exampleUser, loginInput)
+		assert.True(t, errors.Is(err, errInvalidWebAuthnAssertion))
+	})
+
+	T.Run("with error fetching credentials", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+
+		authenticator, exampleCredential := registerWebAuthnCredentialForTest(t, s, exampleUser)
+		ceremonyToken, assertion := buildWebAuthnLoginForTest(t, s, exampleUser, authenticator, exampleCredential)
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return((*types.WebAuthnCredentialList)(nil), errors.New("blah"))
+		s.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		loginInput := &types.UserLoginInput{
+			WebAuthnCeremonyToken: ceremonyToken,
+			WebAuthnAssertion:     assertion,
+		}
+
+		err := s.validateWebAuthnAssertion(ctx, exampleUser, loginInput)
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, errInvalidWebAuthnAssertion))
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with assertion for another user", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+		otherUser := fakes.BuildFakeUser()
+
+		authenticator, exampleCredential := registerWebAuthnCredentialForTest(t, s, otherUser)
+		ceremonyToken, assertion := buildWebAuthnLoginForTest(t, s, otherUser, authenticator, exampleCredential)
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(&types.WebAuthnCredentialList{}, nil)
+		s.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		loginInput := &types.UserLoginInput{
+			WebAuthnCeremonyToken: ceremonyToken,
+			WebAuthnAssertion:     assertion,
+		}
+
+		err := s.validateWebAuthnAssertion(ctx, exampleUser, loginInput)
+		assert.True(t, errors.Is(err, errInvalidWebAuthnAssertion))
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with signature counter that did not advance", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+
+		authenticator, exampleCredential := registerWebAuthnCredentialForTest(t, s, exampleUser)
+		ceremonyToken, assertion := buildWebAuthnLoginForTest(t, s, exampleUser, authenticator, exampleCredential)
+		exampleCredential.SignCount = 10
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(&types.WebAuthnCredentialList{WebAuthnCredentials: []*types.WebAuthnCredential{exampleCredential}}, nil)
+		s.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		loginInput := &types.UserLoginInput{
+			WebAuthnCeremonyToken: ceremonyToken,
+			WebAuthnAssertion:     assertion,
+		}
+
+		err := s.validateWebAuthnAssertion(ctx, exampleUser, loginInput)
+		assert.True(t, errors.Is(err, errInvalidWebAuthnAssertion))
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+
+	T.Run("with error marking credential as used", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestService(t)
+		exampleUser := fakes.BuildFakeUser()
+
+		authenticator, exampleCredential := registerWebAuthnCredentialForTest(t, s, exampleUser)
+		ceremonyToken, assertion := buildWebAuthnLoginForTest(t, s, exampleUser, authenticator, exampleCredential)
+
+		webAuthnCredentialDataManager := &mocktypes.WebAuthnCredentialDataManager{}
+		webAuthnCredentialDataManager.On(
+			"GetWebAuthnCredentialsForUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(&types.WebAuthnCredentialList{WebAuthnCredentials: []*types.WebAuthnCredential{exampleCredential}}, nil)
+		webAuthnCredentialDataManager.On(
+			"MarkWebAuthnCredentialAsUsed",
+			testutils.ContextMatcher,
+			exampleCredential.ID,
+			uint32(1),
+		).Return(errors.New("blah"))
+		s.webAuthnCredentialDataManager = webAuthnCredentialDataManager
+
+		loginInput := &types.UserLoginInput{
+			WebAuthnCeremonyToken: ceremonyToken,
+			WebAuthnAssertion:     assertion,
+		}
+
+		err := s.validateWebAuthnAssertion(ctx, exampleUser, loginInput)
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, errInvalidWebAuthnAssertion))
+
+		mock.AssertExpectationsForObjects(t, webAuthnCredentialDataManager)
+	})
+}
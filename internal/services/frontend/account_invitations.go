@@ -0,0 +1,285 @@
+package frontend
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+	"strings"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+const (
+	accountInvitationIDURLParamKey = "accountInvitation"
+	accountInvitationTokenQueryKey = "token"
+
+	accountInvitationRecipientFormKey = "recipient"
+	accountInvitationNoteFormKey      = "note"
+	accountInvitationRoleFormKey      = "accountRole"
+)
+
+type accountRoleOption struct {
+	Value string
+	Label string
+}
+
+func (s *service) fetchReceivedAccountInvitations(ctx context.Context, sessionCtxData *types.SessionContextData) (invitations *types.AccountInvitationList, err error) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger.WithValue(keys.UserIDKey, sessionCtxData.Requester.UserID)
+
+	if s.useFakeData {
+		return fakes.BuildFakeAccountInvitationList(), nil
+	}
+
+	invitations, err = s.dataStore.GetPendingAccountInvitationsForUser(ctx, sessionCtxData.Requester.UserID)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "fetching account invitations data")
+	}
+
+	return invitations, nil
+}
+
+func (s *service) fetchPendingAccountInvitations(ctx context.Context, sessionCtxData *types.SessionContextData) (invitations *types.AccountInvitationList, err error) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger.WithValue(keys.AccountIDKey, sessionCtxData.ActiveAccountID)
+
+	if s.useFakeData {
+		return fakes.BuildFakeAccountInvitationList(), nil
+	}
+
+	invitations, err = s.dataStore.GetPendingAccountInvitationsForAccount(ctx, sessionCtxData.ActiveAccountID, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "fetching account invitations data")
+	}
+
+	return invitations, nil
+}
+
+// fetchAccountRoleOptions builds the list of roles an account's members can be given.
+func (s *service) fetchAccountRoleOptions(ctx context.Context, sessionCtxData *types.SessionContextData) ([]*accountRoleOption, error) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger.WithValue(keys.AccountIDKey, sessionCtxData.ActiveAccountID)
+
+	options := []*accountRoleOption{
+		{Value: authorization.AccountMemberRole.String(), Label: "Member"},
+		{Value: authorization.AccountAdminRole.String(), Label: "Admin"},
+	}
+
+	var (
+		customRoles *types.AccountRoleList
+		err         error
+	)
+
+	if s.useFakeData {
+		customRoles = fakes.BuildFakeAccountRoleList()
+	} else {
+		customRoles, err = s.dataStore.GetAccountRoles(ctx, sessionCtxData.ActiveAccountID, nil)
+		if err != nil {
+			return nil, observability.PrepareError(err, logger, span, "fetching account roles data")
+		}
+	}
+
+	for _, role := range customRoles.AccountRoles {
+		options = append(options, &accountRoleOption{Value: role.ID, Label: role.Name})
+	}
+
+	return options, nil
+}
+
+//go:embed templates/partials/settings/account_invitations.gotpl
+var accountInvitationsPageSrc string
+
+func (s *service) buildAccountInvitationsView(includeBaseTemplate bool) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+		ctx, span := s.tracer.StartSpan(req.Context())
+		defer span.End()
+
+		logger := s.logger.WithRequest(req)
+		tracing.AttachRequestToSpan(span, req)
+
+		sessionCtxData, err := s.sessionContextDataFetcher(req)
+		if err != nil {
+			observability.AcknowledgeError(err, logger, span, "no session context data attached to request")
+			http.Redirect(res, req, buildRedirectURL("/login", req.URL.RequestURI()), unauthorizedRedirectResponseCode)
+			return
+		}
+
+		// invitations sent by email link here with a token, which claims the invitation for whoever follows it.
+		if token := req.URL.Query().Get(accountInvitationTokenQueryKey); token != "" && !s.useFakeData {
+			if _, err = s.accountsService.ClaimAccountInvitation(ctx, sessionCtxData.Requester.UserID, token); err != nil {
+				observability.AcknowledgeError(err, logger, span, "claiming account invitation")
+			}
+		}
+
+		invitations, err := s.fetchReceivedAccountInvitations(ctx, sessionCtxData)
+		if err != nil {
+			observability.AcknowledgeError(err, logger, span, "fetching account invitations from datastore")
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if includeBaseTemplate {
+			tmpl := s.renderTemplateIntoBaseTemplate(accountInvitationsPageSrc, nil)
+
+			page := &pageData{
+				IsLoggedIn:  sessionCtxData != nil,
+				Title:       "Invitations",
+				ContentData: invitations,
+			}
+			if sessionCtxData != nil {
+				page.IsServiceAdmin = sessionCtxData.Requester.ServicePermissions.IsServiceAdmin()
+			}
+
+			s.renderTemplateToResponse(ctx, tmpl, page, res)
+		} else {
+			tmpl := s.parseTemplate(ctx, "", accountInvitationsPageSrc, nil)
+
+			s.renderTemplateToResponse(ctx, tmpl, invitations, res)
+		}
+	}
+}
+
+// parseFormEncodedAccountInvitationCreationInput checks a request for an account invitation form, and returns the parsed input.
+func (s *service) parseFormEncodedAccountInvitationCreationInput(ctx context.Context, req *http.Request) *types.AccountInvitationCreationRequestInput {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	form, err := s.extractFormFromRequest(ctx, req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "parsing account invitation creation input")
+		return nil
+	}
+
+	input := &types.AccountInvitationCreationRequestInput{
+		Note:         form.Get(accountInvitationNoteFormKey),
+		AccountRoles: []string{form.Get(accountInvitationRoleFormKey)},
+	}
+
+	// the same field accepts either, since usernames can't contain an @ sign.
+	if recipient := form.Get(accountInvitationRecipientFormKey); strings.Contains(recipient, "@") {
+		input.ToEmail = recipient
+	} else {
+		input.ToUsername = recipient
+	}
+
+	if err = input.ValidateWithContext(ctx); err != nil {
+		logger.WithValue(keys.ValidationErrorKey, err).Debug("invalid account invitation creation input")
+		return nil
+	}
+
+	return input
+}
+
+func (s *service) handleAccountInvitationSubmission(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "no session context data attached to request")
+		http.Redirect(res, req, "/login", unauthorizedRedirectResponseCode)
+		return
+	}
+
+	input := s.parseFormEncodedAccountInvitationCreationInput(ctx, req)
+	if input == nil {
+		logger.Debug("no input found for account invitation request")
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !s.useFakeData {
+		if _, err = s.accountsService.InviteUserToAccount(ctx, sessionCtxData.ActiveAccountID, sessionCtxData.Requester.UserID, input); err != nil {
+			observability.AcknowledgeError(err, logger, span, "inviting user to account")
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	htmxRedirectTo(res, "/account/settings")
+	res.WriteHeader(http.StatusCreated)
+}
+
+func (s *service) handleAccountInvitationRevocation(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "no session context data attached to request")
+		http.Redirect(res, req, "/login", unauthorizedRedirectResponseCode)
+		return
+	}
+
+	accountInvitationID := s.accountInvitationIDFetcher(req)
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+	logger = logger.WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+
+	if !s.useFakeData {
+		if err = s.accountsService.RevokeAccountInvitation(ctx, sessionCtxData.ActiveAccountID, sessionCtxData.Requester.UserID, accountInvitationID); err != nil {
+			observability.AcknowledgeError(err, logger, span, "revoking account invitation")
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// the row the request came from is swapped out for this empty response.
+	res.WriteHeader(http.StatusOK)
+}
+
+func (s *service) buildAccountInvitationResponseHandler(accept bool) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+		ctx, span := s.tracer.StartSpan(req.Context())
+		defer span.End()
+
+		logger := s.logger.WithRequest(req).WithValue("accept", accept)
+		tracing.AttachRequestToSpan(span, req)
+
+		sessionCtxData, err := s.sessionContextDataFetcher(req)
+		if err != nil {
+			observability.AcknowledgeError(err, logger, span, "no session context data attached to request")
+			http.Redirect(res, req, "/login", unauthorizedRedirectResponseCode)
+			return
+		}
+
+		accountInvitationID := s.accountInvitationIDFetcher(req)
+		tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+		logger = logger.WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+
+		if !s.useFakeData {
+			respond := s.accountsService.RejectAccountInvitation
+			if accept {
+				respond = s.accountsService.AcceptAccountInvitation
+			}
+
+			if err = respond(ctx, sessionCtxData.Requester.UserID, accountInvitationID); err != nil {
+				observability.AcknowledgeError(err, logger, span, "responding to account invitation")
+				res.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		// the row the request came from is swapped out for this empty response.
+		res.WriteHeader(http.StatusOK)
+	}
+}
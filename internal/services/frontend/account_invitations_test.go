@@ -0,0 +1,506 @@
+package frontend
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+func buildFormFromAccountInvitationCreationRequest(recipient string, input *types.AccountInvitationCreationRequestInput) url.Values {
+	form := url.Values{}
+
+	form.Set(accountInvitationRecipientFormKey, recipient)
+	form.Set(accountInvitationNoteFormKey, input.Note)
+	form.Set(accountInvitationRoleFormKey, input.AccountRoles[0])
+
+	return form
+}
+
+func TestService_buildAccountInvitationsView(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with base template", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.AccountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForUser",
+			testutils.ContextMatcher,
+			s.exampleUser.ID,
+		).Return(fakes.BuildFakeAccountInvitationList(), nil)
+		s.service.dataStore = mockDB
+
+		s.service.buildAccountInvitationsView(true)(s.res, s.req)
+
+		assert.Equal(t, http.StatusOK, s.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB)
+	})
+
+	T.Run("without base template", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.AccountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForUser",
+			testutils.ContextMatcher,
+			s.exampleUser.ID,
+		).Return(fakes.BuildFakeAccountInvitationList(), nil)
+		s.service.dataStore = mockDB
+
+		s.service.buildAccountInvitationsView(false)(s.res, s.req)
+
+		assert.Equal(t, http.StatusOK, s.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB)
+	})
+
+	T.Run("with fake mode", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.service.useFakeData = true
+
+		s.service.buildAccountInvitationsView(true)(s.res, s.req)
+
+		assert.Equal(t, http.StatusOK, s.res.Code)
+	})
+
+	T.Run("with error fetching session context data", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		s.service.buildAccountInvitationsView(true)(s.res, s.req)
+
+		assert.Equal(t, unauthorizedRedirectResponseCode, s.res.Code)
+	})
+
+	T.Run("with token", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.req = httptest.NewRequest(http.MethodGet, "/invitations?token=abc123", nil)
+
+		exampleInvitation := fakes.BuildFakeAccountInvitation()
+		mockAccountsService := &mocktypes.AccountsService{}
+		mockAccountsService.On(
+			"ClaimAccountInvitation",
+			testutils.ContextMatcher,
+			s.exampleUser.ID,
+			"abc123",
+		).Return(exampleInvitation, nil)
+		s.service.accountsService = mockAccountsService
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.AccountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForUser",
+			testutils.ContextMatcher,
+			s.exampleUser.ID,
+		).Return(&types.AccountInvitationList{AccountInvitations: []*types.AccountInvitation{exampleInvitation}}, nil)
+		s.service.dataStore = mockDB
+
+		s.service.buildAccountInvitationsView(true)(s.res, s.req)
+
+		assert.Equal(t, http.StatusOK, s.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockAccountsService, mockDB)
+	})
+
+	T.Run("with error claiming invitation", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.req = httptest.NewRequest(http.MethodGet, "/invitations?token=abc123", nil)
+
+		mockAccountsService := &mocktypes.AccountsService{}
+		mockAccountsService.On(
+			"ClaimAccountInvitation",
+			testutils.ContextMatcher,
+			s.exampleUser.ID,
+			"abc123",
+		).Return((*types.AccountInvitation)(nil), errors.New("blah"))
+		s.service.accountsService = mockAccountsService
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.AccountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForUser",
+			testutils.ContextMatcher,
+			s.exampleUser.ID,
+		).Return(fakes.BuildFakeAccountInvitationList(), nil)
+		s.service.dataStore = mockDB
+
+		s.service.buildAccountInvitationsView(true)(s.res, s.req)
+
+		assert.Equal(t, http.StatusOK, s.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockAccountsService, mockDB)
+	})
+
+	T.Run("with error fetching account invitations", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.AccountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForUser",
+			testutils.ContextMatcher,
+			s.exampleUser.ID,
+		).Return((*types.AccountInvitationList)(nil), errors.New("blah"))
+		s.service.dataStore = mockDB
+
+		s.service.buildAccountInvitationsView(true)(s.res, s.req)
+
+		assert.Equal(t, http.StatusInternalServerError, s.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB)
+	})
+}
+
+func TestService_parseFormEncodedAccountInvitationCreationInput(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with username", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		expected := fakes.BuildFakeAccountInvitationCreationRequestInput()
+		form := buildFormFromAccountInvitationCreationRequest(expected.ToUsername, expected)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+
+		actual := s.service.parseFormEncodedAccountInvitationCreationInput(s.ctx, req)
+		assert.Equal(t, expected, actual)
+	})
+
+	T.Run("with email address", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		expected := fakes.BuildFakeAccountInvitationCreationRequestInput()
+		expected.ToUsername = ""
+		expected.ToEmail = "invitee@todo.verygoodsoftwarenotvirus.ru"
+		form := buildFormFromAccountInvitationCreationRequest(expected.ToEmail, expected)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+
+		actual := s.service.parseFormEncodedAccountInvitationCreationInput(s.ctx, req)
+		assert.Equal(t, expected, actual)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(url.Values{}.Encode()))
+
+		actual := s.service.parseFormEncodedAccountInvitationCreationInput(s.ctx, req)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with error extracting form from request", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		badBody := &testutils.MockReadCloser{}
+		badBody.On("Read", mock.IsType([]byte{})).Return(0, errors.New("blah"))
+
+		req := httptest.NewRequest(http.MethodPost, "/", badBody)
+
+		actual := s.service.parseFormEncodedAccountInvitationCreationInput(s.ctx, req)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestService_handleAccountInvitationSubmission(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleInput := fakes.BuildFakeAccountInvitationCreationRequestInput()
+		form := buildFormFromAccountInvitationCreationRequest(exampleInput.ToUsername, exampleInput)
+
+		mockAccountsService := &mocktypes.AccountsService{}
+		mockAccountsService.On(
+			"InviteUserToAccount",
+			testutils.ContextMatcher,
+			s.exampleAccount.ID,
+			s.exampleUser.ID,
+			exampleInput,
+		).Return(fakes.BuildFakeAccountInvitation(), nil)
+		s.service.accountsService = mockAccountsService
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+
+		s.service.handleAccountInvitationSubmission(s.res, req)
+
+		assert.Equal(t, http.StatusCreated, s.res.Code)
+		assert.NotEmpty(t, s.res.Header().Get(htmxRedirectionHeader))
+
+		mock.AssertExpectationsForObjects(t, mockAccountsService)
+	})
+
+	T.Run("with error fetching session context data", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		s.service.handleAccountInvitationSubmission(s.res, s.req)
+
+		assert.Equal(t, unauthorizedRedirectResponseCode, s.res.Code)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(url.Values{}.Encode()))
+
+		s.service.handleAccountInvitationSubmission(s.res, req)
+
+		assert.Equal(t, http.StatusBadRequest, s.res.Code)
+	})
+
+	T.Run("with error inviting user", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleInput := fakes.BuildFakeAccountInvitationCreationRequestInput()
+		form := buildFormFromAccountInvitationCreationRequest(exampleInput.ToUsername, exampleInput)
+
+		mockAccountsService := &mocktypes.AccountsService{}
+		mockAccountsService.On(
+			"InviteUserToAccount",
+			testutils.ContextMatcher,
+			s.exampleAccount.ID,
+			s.exampleUser.ID,
+			exampleInput,
+		).Return((*types.AccountInvitation)(nil), errors.New("blah"))
+		s.service.accountsService = mockAccountsService
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+
+		s.service.handleAccountInvitationSubmission(s.res, req)
+
+		assert.Equal(t, http.StatusInternalServerError, s.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockAccountsService)
+	})
+}
+
+func TestService_handleAccountInvitationRevocation(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleAccountInvitation := fakes.BuildFakeAccountInvitation()
+		s.service.accountInvitationIDFetcher = func(*http.Request) string {
+			return exampleAccountInvitation.ID
+		}
+
+		mockAccountsService := &mocktypes.AccountsService{}
+		mockAccountsService.On(
+			"RevokeAccountInvitation",
+			testutils.ContextMatcher,
+			s.exampleAccount.ID,
+			s.exampleUser.ID,
+			exampleAccountInvitation.ID,
+		).Return(nil)
+		s.service.accountsService = mockAccountsService
+
+		s.service.handleAccountInvitationRevocation(s.res, s.req)
+
+		assert.Equal(t, http.StatusOK, s.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockAccountsService)
+	})
+
+	T.Run("with error fetching session context data", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		s.service.handleAccountInvitationRevocation(s.res, s.req)
+
+		assert.Equal(t, unauthorizedRedirectResponseCode, s.res.Code)
+	})
+
+	T.Run("with error revoking invitation", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleAccountInvitation := fakes.BuildFakeAccountInvitation()
+		s.service.accountInvitationIDFetcher = func(*http.Request) string {
+			return exampleAccountInvitation.ID
+		}
+
+		mockAccountsService := &mocktypes.AccountsService{}
+		mockAccountsService.On(
+			"RevokeAccountInvitation",
+			testutils.ContextMatcher,
+			s.exampleAccount.ID,
+			s.exampleUser.ID,
+			exampleAccountInvitation.ID,
+		).Return(errors.New("blah"))
+		s.service.accountsService = mockAccountsService
+
+		s.service.handleAccountInvitationRevocation(s.res, s.req)
+
+		assert.Equal(t, http.StatusInternalServerError, s.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockAccountsService)
+	})
+}
+
+func TestService_buildAccountInvitationResponseHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("accepting", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleAccountInvitation := fakes.BuildFakeAccountInvitation()
+		s.service.accountInvitationIDFetcher = func(*http.Request) string {
+			return exampleAccountInvitation.ID
+		}
+
+		mockAccountsService := &mocktypes.AccountsService{}
+		mockAccountsService.On(
+			"AcceptAccountInvitation",
+			testutils.ContextMatcher,
+			s.exampleUser.ID,
+			exampleAccountInvitation.ID,
+		).Return(nil)
+		s.service.accountsService = mockAccountsService
+
+		s.service.buildAccountInvitationResponseHandler(true)(s.res, s.req)
+
+		assert.Equal(t, http.StatusOK, s.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockAccountsService)
+	})
+
+	T.Run("rejecting", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleAccountInvitation := fakes.BuildFakeAccountInvitation()
+		s.service.accountInvitationIDFetcher = func(*http.Request) string {
+			return exampleAccountInvitation.ID
+		}
+
+		mockAccountsService := &mocktypes.AccountsService{}
+		mockAccountsService.On(
+			"RejectAccountInvitation",
+			testutils.ContextMatcher,
+			s.exampleUser.ID,
+			exampleAccountInvitation.ID,
+		).Return(nil)
+		s.service.accountsService = mockAccountsService
+
+		s.service.buildAccountInvitationResponseHandler(false)(s.res, s.req)
+
+		assert.Equal(t, http.StatusOK, s.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockAccountsService)
+	})
+
+	T.Run("with error fetching session context data", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		s.service.buildAccountInvitationResponseHandler(true)(s.res, s.req)
+
+		assert.Equal(t, unauthorizedRedirectResponseCode, s.res.Code)
+	})
+
+	T.Run("with error responding to invitation", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleAccountInvitation := fakes.BuildFakeAccountInvitation()
+		s.service.accountInvitationIDFetcher = func(*http.Request) string {
+			return exampleAccountInvitation.ID
+		}
+
+		mockAccountsService := &mocktypes.AccountsService{}
+		mockAccountsService.On(
+			"AcceptAccountInvitation",
+			testutils.ContextMatcher,
+			s.exampleUser.ID,
+			exampleAccountInvitation.ID,
+		).Return(errors.New("blah"))
+		s.service.accountsService = mockAccountsService
+
+		s.service.buildAccountInvitationResponseHandler(true)(s.res, s.req)
+
+		assert.Equal(t, http.StatusInternalServerError, s.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockAccountsService)
+	})
+}
+
+func TestService_fetchAccountRoleOptions(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleAccountRoleList := fakes.BuildFakeAccountRoleList()
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.AccountRoleDataManager.On(
+			"GetAccountRoles",
+			testutils.ContextMatcher,
+			s.exampleAccount.ID,
+			(*types.QueryFilter)(nil),
+		).Return(exampleAccountRoleList, nil)
+		s.service.dataStore = mockDB
+
+		actual, err := s.service.fetchAccountRoleOptions(s.ctx, s.sessionCtxData)
+		assert.NoError(t, err)
+		assert.Len(t, actual, len(exampleAccountRoleList.AccountRoles)+2)
+		assert.Equal(t, authorization.AccountMemberRole.String(), actual[0].Value)
+
+		mock.AssertExpectationsForObjects(t, mockDB)
+	})
+}
@@ -56,6 +56,14 @@ func (s *service) buildLoginView(includeBaseTemplate bool) func(http.ResponseWri
 	totpTokenFormKey = "totpToken"
 	// userIDFormKey is the string we look for in request forms for user IDs.
 	userIDFormKey = "userID"
+	// emailAddressFormKey is the string we look for in request forms for email addresses.
+	emailAddressFormKey = "emailAddress"
+	// newPasswordFormKey is the string we look for in request forms for new passwords.
+	newPasswordFormKey = "newPassword"
+	// passwordResetTokenFormKey is the string we look for in request forms for password reset tokens.
+	passwordResetTokenFormKey = "token"
+	// passwordResetTokenQueryKey is the query parameter password reset links carry their token in.
+	passwordResetTokenQueryKey = "token"
 )
 
 // parseLoginInputFromForm checks a request for a login form, and returns the parsed login data if relevant.
@@ -102,7 +110,7 @@ func (s *service) handleLoginSubmission(res http.ResponseWriter, req *http.Reque
 	}
 
 	if !s.useFakeData {
-		_, cookie, err := s.authService.AuthenticateUser(ctx, loginInput)
+		_, cookie, err := s.authService.AuthenticateUser(ctx, req, loginInput)
 		if err != nil {
 			s.renderStringToResponse(loginPrompt, res)
 			return
@@ -169,6 +177,9 @@ func (s *service) registrationView(res http.ResponseWriter, req *http.Request) {
 //go:embed templates/partials/auth/registration_success.gotpl
 var successfulRegistrationResponse string
 
+//go:embed templates/partials/auth/totp_recovery_codes.gotpl
+var totpRecoveryCodesPrompt string
+
 type totpVerificationPrompt struct {
 	TwoFactorQRCode template.URL
 	UserID          string
@@ -187,8 +198,9 @@ func (s *service) parseFormEncodedRegistrationRequest(ctx context.Context, req *
 	}
 
 	input := &types.UserRegistrationInput{
-		Username: form.Get(usernameFormKey),
-		Password: form.Get(passwordFormKey),
+		Username:     form.Get(usernameFormKey),
+		Password:     form.Get(passwordFormKey),
+		EmailAddress: form.Get(emailAddressFormKey),
 	}
 
 	if input.Username != "" && input.Password != "" {
@@ -275,12 +287,169 @@ func (s *service) handleTOTPVerificationSubmission(res http.ResponseWriter, req
 		return
 	}
 
-	if err := s.usersService.VerifyUserTwoFactorSecret(ctx, verificationInput); err != nil {
+	recoveryCodes, err := s.usersService.VerifyUserTwoFactorSecret(ctx, verificationInput)
+	if err != nil {
 		observability.AcknowledgeError(err, logger, span, "rendering two factor secret verification prompt into dashboard")
 		res.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	tmpl := s.parseTemplate(ctx, "", totpRecoveryCodesPrompt, nil)
+
+	s.renderTemplateToResponse(ctx, tmpl, recoveryCodes, res)
+}
+
+//go:embed templates/partials/auth/forgot_password.gotpl
+var forgotPasswordPrompt string
+
+func (s *service) buildForgotPasswordView(includeBaseTemplate bool) func(http.ResponseWriter, *http.Request) {
+	return func(res http.ResponseWriter, req *http.Request) {
+		ctx, span := s.tracer.StartSpan(req.Context())
+		defer span.End()
+
+		tracing.AttachRequestToSpan(span, req)
+
+		if includeBaseTemplate {
+			tmpl := s.renderTemplateIntoBaseTemplate(forgotPasswordPrompt, nil)
+
+			data := pageData{
+				IsLoggedIn:  false,
+				Title:       "Forgot Password",
+				ContentData: nil,
+			}
+
+			s.renderTemplateToResponse(ctx, tmpl, data, res)
+		} else {
+			tmpl := s.parseTemplate(ctx, "", forgotPasswordPrompt, nil)
+
+			s.renderTemplateToResponse(ctx, tmpl, nil, res)
+		}
+	}
+}
+
+//go:embed templates/partials/auth/password_reset_requested.gotpl
+var passwordResetRequestedResponse string
+
+// parseFormEncodedPasswordResetRequest checks a request for a password reset request form, and returns the parsed input.
+func (s *service) parseFormEncodedPasswordResetRequest(ctx context.Context, req *http.Request) *types.PasswordResetTokenCreationRequestInput {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	tracing.AttachRequestToSpan(span, req)
+
+	form, err := s.extractFormFromRequest(ctx, req)
+	if err != nil {
+		return nil
+	}
+
+	input := &types.PasswordResetTokenCreationRequestInput{
+		Username: form.Get(usernameFormKey),
+	}
+
+	if input.Username != "" {
+		return input
+	}
+
+	return nil
+}
+
+func (s *service) handlePasswordResetRequestSubmission(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	input := s.parseFormEncodedPasswordResetRequest(ctx, req)
+	if input == nil {
+		logger.Debug("no input found for password reset request")
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !s.useFakeData {
+		if err := s.usersService.RequestPasswordReset(ctx, input); err != nil {
+			observability.AcknowledgeError(err, logger, span, "requesting password reset")
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	s.renderStringToResponse(passwordResetRequestedResponse, res)
+}
+
+//go:embed templates/partials/auth/reset_password.gotpl
+var resetPasswordPrompt string
+
+type resetPasswordPromptData struct {
+	Token string
+}
+
+func (s *service) resetPasswordView(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	tracing.AttachRequestToSpan(span, req)
+
+	tmpl := s.renderTemplateIntoBaseTemplate(resetPasswordPrompt, nil)
+	data := pageData{
+		IsLoggedIn: false,
+		Title:      "Reset Password",
+		ContentData: &resetPasswordPromptData{
+			Token: req.URL.Query().Get(passwordResetTokenQueryKey),
+		},
+	}
+
+	s.renderTemplateToResponse(ctx, tmpl, data, res)
+}
+
+// parseFormEncodedPasswordResetRedemptionRequest checks a request for a password reset form, and returns the parsed input.
+func (s *service) parseFormEncodedPasswordResetRedemptionRequest(ctx context.Context, req *http.Request) *types.PasswordResetTokenRedemptionRequestInput {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	tracing.AttachRequestToSpan(span, req)
+
+	form, err := s.extractFormFromRequest(ctx, req)
+	if err != nil {
+		return nil
+	}
+
+	input := &types.PasswordResetTokenRedemptionRequestInput{
+		Token:       form.Get(passwordResetTokenFormKey),
+		NewPassword: form.Get(newPasswordFormKey),
+	}
+
+	if input.Token != "" && input.NewPassword != "" {
+		return input
+	}
+
+	return nil
+}
+
+func (s *service) handlePasswordResetRedemptionSubmission(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	input := s.parseFormEncodedPasswordResetRedemptionRequest(ctx, req)
+	if input == nil {
+		logger.Debug("no input found for password reset redemption request")
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !s.useFakeData {
+		if err := s.usersService.RedeemPasswordResetToken(ctx, input); err != nil {
+			observability.AcknowledgeError(err, logger, span, "redeeming password reset token")
+			tmpl := s.parseTemplate(ctx, "", resetPasswordPrompt, nil)
+			s.renderTemplateToResponse(ctx, tmpl, &resetPasswordPromptData{Token: input.Token}, res)
+			return
+		}
+	}
+
 	htmxRedirectTo(res, "/login")
 	res.WriteHeader(http.StatusAccepted)
 }
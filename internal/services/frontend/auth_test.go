@@ -128,6 +128,7 @@ func TestService_handleLoginSubmission(T *testing.T) {
 		mockAuthService.On(
 			"AuthenticateUser",
 			testutils.ContextMatcher,
+			mock.IsType(&http.Request{}),
 			expected,
 		).Return((*types.User)(nil), expectedCookie, nil)
 		s.service.authService = mockAuthService
@@ -170,6 +171,7 @@ func TestService_handleLoginSubmission(T *testing.T) {
 		mockAuthService.On(
 			"AuthenticateUser",
 			testutils.ContextMatcher,
+			mock.IsType(&http.Request{}),
 			expected,
 		).Return((*types.User)(nil), (*http.Cookie)(nil), errors.New("blah"))
 		s.service.authService = mockAuthService
@@ -304,6 +306,7 @@ func buildFormFromRegistrationRequest(input *types.UserRegistrationInput) url.Va
 
 	form.Set(usernameFormKey, input.Username)
 	form.Set(passwordFormKey, input.Password)
+	form.Set(emailAddressFormKey, input.EmailAddress)
 
 	return form
 }
@@ -533,12 +536,14 @@ func TestService_handleTOTPVerificationSubmission(T *testing.T) {
 			"VerifyUserTwoFactorSecret",
 			testutils.ContextMatcher,
 			expected,
-		).Return(nil)
+		).Return(fakes.BuildFakeTOTPRecoveryCodesResponse(), nil)
 		s.service.usersService = mockUsersService
 
 		s.service.handleTOTPVerificationSubmission(res, req)
 
-		assert.Equal(t, http.StatusAccepted, res.Code)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockUsersService)
 	})
 
 	T.Run("with invalid input", func(t *testing.T) {
@@ -571,7 +576,7 @@ func TestService_handleTOTPVerificationSubmission(T *testing.T) {
 			"VerifyUserTwoFactorSecret",
 			testutils.ContextMatcher,
 			expected,
-		).Return(errors.New("blah"))
+		).Return((*types.TOTPRecoveryCodesResponse)(nil), errors.New("blah"))
 		s.service.usersService = mockUsersService
 
 		s.service.handleTOTPVerificationSubmission(res, req)
@@ -579,3 +584,298 @@ func TestService_handleTOTPVerificationSubmission(T *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, res.Code)
 	})
 }
+
+func TestService_buildForgotPasswordView(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with base template", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		res := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/whatever", nil)
+
+		s.service.buildForgotPasswordView(true)(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+
+	T.Run("without base template", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		res := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/whatever", nil)
+
+		s.service.buildForgotPasswordView(false)(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+}
+
+func buildFormFromPasswordResetRequest(input *types.PasswordResetTokenCreationRequestInput) url.Values {
+	form := url.Values{}
+
+	form.Set(usernameFormKey, input.Username)
+
+	return form
+}
+
+func TestService_parseFormEncodedPasswordResetRequest(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		expected := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+		form := buildFormFromPasswordResetRequest(expected)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+
+		actual := s.service.parseFormEncodedPasswordResetRequest(s.ctx, req)
+
+		assert.Equal(t, expected, actual)
+	})
+
+	T.Run("with invalid request body", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		badBody := &testutils.MockReadCloser{}
+		badBody.On("Read", mock.IsType([]byte{})).Return(0, errors.New("blah"))
+
+		req := httptest.NewRequest(http.MethodPost, "/", badBody)
+
+		actual := s.service.parseFormEncodedPasswordResetRequest(s.ctx, req)
+
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with invalid form", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+		actual := s.service.parseFormEncodedPasswordResetRequest(s.ctx, req)
+
+		assert.Nil(t, actual)
+	})
+}
+
+func TestService_handlePasswordResetRequestSubmission(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		expected := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+		form := buildFormFromPasswordResetRequest(expected)
+
+		mockUsersService := &mocktypes.UsersService{}
+		mockUsersService.On(
+			"RequestPasswordReset",
+			testutils.ContextMatcher,
+			expected,
+		).Return(nil)
+		s.service.usersService = mockUsersService
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		res := httptest.NewRecorder()
+
+		s.service.handlePasswordResetRequestSubmission(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockUsersService)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		res := httptest.NewRecorder()
+
+		s.service.handlePasswordResetRequestSubmission(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+
+	T.Run("with error requesting password reset", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		expected := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+		form := buildFormFromPasswordResetRequest(expected)
+
+		mockUsersService := &mocktypes.UsersService{}
+		mockUsersService.On(
+			"RequestPasswordReset",
+			testutils.ContextMatcher,
+			expected,
+		).Return(errors.New("blah"))
+		s.service.usersService = mockUsersService
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		res := httptest.NewRecorder()
+
+		s.service.handlePasswordResetRequestSubmission(res, req)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockUsersService)
+	})
+}
+
+func TestService_resetPasswordView(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		res := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/reset_password?token=blah", nil)
+
+		s.service.resetPasswordView(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), `value="blah"`)
+	})
+}
+
+func buildFormFromPasswordResetRedemptionRequest(input *types.PasswordResetTokenRedemptionRequestInput) url.Values {
+	form := url.Values{}
+
+	form.Set(passwordResetTokenFormKey, input.Token)
+	form.Set(newPasswordFormKey, input.NewPassword)
+
+	return form
+}
+
+func TestService_parseFormEncodedPasswordResetRedemptionRequest(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		expected := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		form := buildFormFromPasswordResetRedemptionRequest(expected)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+
+		actual := s.service.parseFormEncodedPasswordResetRedemptionRequest(s.ctx, req)
+
+		assert.Equal(t, expected, actual)
+	})
+
+	T.Run("with invalid request body", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		badBody := &testutils.MockReadCloser{}
+		badBody.On("Read", mock.IsType([]byte{})).Return(0, errors.New("blah"))
+
+		req := httptest.NewRequest(http.MethodPost, "/", badBody)
+
+		actual := s.service.parseFormEncodedPasswordResetRedemptionRequest(s.ctx, req)
+
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with invalid form", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+
+		actual := s.service.parseFormEncodedPasswordResetRedemptionRequest(s.ctx, req)
+
+		assert.Nil(t, actual)
+	})
+}
+
+func TestService_handlePasswordResetRedemptionSubmission(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		expected := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		form := buildFormFromPasswordResetRedemptionRequest(expected)
+
+		mockUsersService := &mocktypes.UsersService{}
+		mockUsersService.On(
+			"RedeemPasswordResetToken",
+			testutils.ContextMatcher,
+			expected,
+		).Return(nil)
+		s.service.usersService = mockUsersService
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		res := httptest.NewRecorder()
+
+		s.service.handlePasswordResetRedemptionSubmission(res, req)
+
+		assert.Equal(t, http.StatusAccepted, res.Code)
+		assert.Equal(t, "/login", res.Header().Get(htmxRedirectionHeader))
+
+		mock.AssertExpectationsForObjects(t, mockUsersService)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		res := httptest.NewRecorder()
+
+		s.service.handlePasswordResetRedemptionSubmission(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+
+	T.Run("with error redeeming token", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		expected := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		form := buildFormFromPasswordResetRedemptionRequest(expected)
+
+		mockUsersService := &mocktypes.UsersService{}
+		mockUsersService.On(
+			"RedeemPasswordResetToken",
+			testutils.ContextMatcher,
+			expected,
+		).Return(errors.New("blah"))
+		s.service.usersService = mockUsersService
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		res := httptest.NewRecorder()
+
+		s.service.handlePasswordResetRedemptionSubmission(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Empty(t, res.Header().Get(htmxRedirectionHeader))
+
+		mock.AssertExpectationsForObjects(t, mockUsersService)
+	})
+}
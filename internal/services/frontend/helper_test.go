@@ -43,6 +43,7 @@ func buildTestHelper(t *testing.T) *serviceHTTPRoutesTestHelper {
 	logger := logging.NewNoopLogger()
 	authService := &mocktypes.AuthService{}
 	usersService := &mocktypes.UsersService{}
+	accountsService := &mocktypes.AccountsService{}
 	dataManager := database.BuildMockDatabase()
 
 	rpm := mockrouting.NewRouteParamManager()
@@ -65,6 +66,7 @@ func buildTestHelper(t *testing.T) *serviceHTTPRoutesTestHelper {
 		logger,
 		authService,
 		usersService,
+		accountsService,
 		dataManager,
 		rpm,
 	).(*service)
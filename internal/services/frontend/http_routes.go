@@ -34,6 +34,12 @@ func (s *service) SetupRoutes(router routing.Router) {
 	router.Post("/auth/submit_registration", s.handleRegistrationSubmission)
 	router.Post("/auth/verify_two_factor_secret", s.handleTOTPVerificationSubmission)
 
+	router.Get("/forgot_password", s.buildForgotPasswordView(true))
+	router.Get("/components/forgot_password_prompt", s.buildForgotPasswordView(false))
+	router.Post("/auth/request_password_reset", s.handlePasswordResetRequestSubmission)
+	router.Get("/reset_password", s.resetPasswordView)
+	router.Post("/auth/redeem_password_reset", s.handlePasswordResetRedemptionSubmission)
+
 	singleAccountPattern := fmt.Sprintf(numericIDPattern, accountIDURLParamKey)
 	router.Get("/accounts", s.buildAccountsTableView(true))
 	router.Get(fmt.Sprintf("/accounts/%s", singleAccountPattern), s.buildAccountEditorView(true))
@@ -66,6 +72,17 @@ func (s *service) SetupRoutes(router routing.Router) {
 		Get("/account/settings", s.buildAccountSettingsView(true))
 	router.WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.UpdateAccountPermission)).
 		Get("/dashboard_pages/account/settings", s.buildAccountSettingsView(false))
+
+	singleAccountInvitationPattern := fmt.Sprintf("{%s}", accountInvitationIDURLParamKey)
+	router.Get("/invitations", s.buildAccountInvitationsView(true))
+	router.Get("/dashboard_pages/invitations", s.buildAccountInvitationsView(false))
+	router.Post(fmt.Sprintf("/dashboard_pages/invitations/%s/accept", singleAccountInvitationPattern), s.buildAccountInvitationResponseHandler(true))
+	router.Post(fmt.Sprintf("/dashboard_pages/invitations/%s/reject", singleAccountInvitationPattern), s.buildAccountInvitationResponseHandler(false))
+	router.WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.AddMemberAccountPermission)).
+		Post("/account/invitations/new/submit", s.handleAccountInvitationSubmission)
+	router.WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.AddMemberAccountPermission)).
+		Delete(fmt.Sprintf("/dashboard_pages/account/invitations/%s", singleAccountInvitationPattern), s.handleAccountInvitationRevocation)
+
 	router.WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.SearchUserPermission)).
 		Get("/admin/users/search", s.buildUsersTableView(true, true))
 	router.WithMiddleware(s.authService.PermissionFilterMiddleware(authorization.SearchUserPermission)).
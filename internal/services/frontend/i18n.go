@@ -14,7 +14,8 @@
 //go:embed translations/*.toml
 var translationsDir embed.FS
 
-func provideLocalizer() *i18n.Localizer {
+// ProvideLocalizer provides a localizer for the translations we ship with.
+func ProvideLocalizer() *i18n.Localizer {
 	bundle := i18n.NewBundle(language.English)
 	bundle.RegisterUnmarshalFunc("toml", toml.Unmarshal)
 
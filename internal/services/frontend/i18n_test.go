@@ -18,12 +18,12 @@ func TestService_getSimpleLocalizedString(T *testing.T) {
 	})
 }
 
-func Test_provideLocalizer(T *testing.T) {
+func TestProvideLocalizer(T *testing.T) {
 	T.Parallel()
 
 	T.Run("obligatory", func(t *testing.T) {
 		t.Parallel()
 
-		assert.NotNil(t, provideLocalizer())
+		assert.NotNil(t, ProvideLocalizer())
 	})
 }
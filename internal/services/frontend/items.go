@@ -3,10 +3,13 @@
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"html/template"
 	"net/http"
+	"strconv"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	keys "gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
@@ -91,6 +94,7 @@ func (s *service) buildItemCreatorView(includeBaseTemplate bool) func(http.Respo
 
 	itemUpdateInputNameFormKey    = nameFormKey
 	itemUpdateInputDetailsFormKey = detailsFormKey
+	itemUpdateInputVersionFormKey = "version"
 )
 
 // parseFormEncodedItemCreationInput checks a request for an ItemCreationInput.
@@ -184,6 +188,9 @@ func (s *service) buildItemEditorView(includeBaseTemplate bool) func(http.Respon
 			"componentTitle": func(x *types.Item) string {
 				return fmt.Sprintf("Item %s", x.ID)
 			},
+			"currentUserID": func() string {
+				return sessionCtxData.Requester.UserID
+			},
 		}
 
 		if includeBaseTemplate {
@@ -286,8 +293,9 @@ func (s *service) buildItemsTableView(includeBaseTemplate bool) func(http.Respon
 	}
 }
 
-// parseFormEncodedItemUpdateInput checks a request for an ItemUpdateInput.
-func (s *service) parseFormEncodedItemUpdateInput(ctx context.Context, req *http.Request, sessionCtxData *types.SessionContextData) (updateInput *types.ItemUpdateInput) {
+// parseFormEncodedItemUpdateInput checks a request for an ItemUpdateInput, along with the version of the item the
+// editor was showing, which is zero if the form didn't say.
+func (s *service) parseFormEncodedItemUpdateInput(ctx context.Context, req *http.Request, sessionCtxData *types.SessionContextData) (updateInput *types.ItemUpdateInput, readVersion uint64) {
 	ctx, span := s.tracer.StartSpan(ctx)
 	defer span.End()
 
@@ -297,7 +305,7 @@ func (s *service) parseFormEncodedItemUpdateInput(ctx context.Context, req *http
 	form, err := s.extractFormFromRequest(ctx, req)
 	if err != nil {
 		observability.AcknowledgeError(err, logger, span, "parsing item creation input")
-		return nil
+		return nil, 0
 	}
 
 	updateInput = &types.ItemUpdateInput{
@@ -309,10 +317,17 @@ func (s *service) parseFormEncodedItemUpdateInput(ctx context.Context, req *http
 	if err = updateInput.ValidateWithContext(ctx); err != nil {
 		logger = logger.WithValue("input", updateInput)
 		observability.AcknowledgeError(err, logger, span, "invalid item creation input")
-		return nil
+		return nil, 0
 	}
 
-	return updateInput
+	if rawVersion := form.Get(itemUpdateInputVersionFormKey); rawVersion != "" {
+		if readVersion, err = strconv.ParseUint(rawVersion, 10, 64); err != nil {
+			observability.AcknowledgeError(err, logger, span, "invalid item version")
+			return nil, 0
+		}
+	}
+
+	return updateInput, readVersion
 }
 
 func (s *service) handleItemUpdateRequest(res http.ResponseWriter, req *http.Request) {
@@ -329,13 +344,20 @@ func (s *service) handleItemUpdateRequest(res http.ResponseWriter, req *http.Req
 		return
 	}
 
-	updateInput := s.parseFormEncodedItemUpdateInput(ctx, req, sessionCtxData)
+	updateInput, readVersion := s.parseFormEncodedItemUpdateInput(ctx, req, sessionCtxData)
 	if updateInput == nil {
 		observability.AcknowledgeError(err, logger, span, "no update input attached to request")
 		res.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
+	// the editor always says which version it was showing, so an update without one could clobber changes nobody saw.
+	if readVersion == 0 {
+		logger.Debug("item update made without version")
+		res.WriteHeader(http.StatusPreconditionRequired)
+		return
+	}
+
 	item, err := s.fetchItem(ctx, req, sessionCtxData)
 	if err != nil {
 		observability.AcknowledgeError(err, logger, span, "fetching item from datastore")
@@ -343,9 +365,20 @@ func (s *service) handleItemUpdateRequest(res http.ResponseWriter, req *http.Req
 		return
 	}
 
+	// make sure nobody has changed the item since the editor was showing it.
+	if readVersion != item.Version {
+		logger.WithValue("version", item.Version).Debug("item has changed since it was read")
+		res.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
 	item.Update(updateInput)
 
-	if err = s.dataStore.UpdateItem(ctx, item); err != nil {
+	if err = s.dataStore.UpdateItem(ctx, item); errors.Is(err, database.ErrVersionConflict) {
+		logger.Debug("item changed while it was being updated")
+		res.WriteHeader(http.StatusPreconditionFailed)
+		return
+	} else if err != nil {
 		observability.AcknowledgeError(err, logger, span, "fetching item from datastore")
 		res.WriteHeader(http.StatusInternalServerError)
 		return
@@ -355,6 +388,9 @@ func (s *service) handleItemUpdateRequest(res http.ResponseWriter, req *http.Req
 		"componentTitle": func(x *types.Item) string {
 			return fmt.Sprintf("Item %s", x.ID)
 		},
+		"currentUserID": func() string {
+			return sessionCtxData.Requester.UserID
+		},
 	}
 
 	tmpl := s.parseTemplate(ctx, "", itemEditorTemplate, tmplFuncMap)
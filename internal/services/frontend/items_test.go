@@ -2,6 +2,7 @@
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -359,6 +360,8 @@ func TestService_buildItemEditorView(T *testing.T) {
 		s.service.buildItemEditorView(false)(res, req)
 
 		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), fmt.Sprintf(`data-item-id="%s"`, exampleItem.ID))
+		assert.Contains(t, res.Body.String(), fmt.Sprintf(`data-user-id="%s"`, s.sessionCtxData.Requester.UserID))
 
 		mock.AssertExpectationsForObjects(t, mockDB)
 	})
@@ -584,6 +587,16 @@ func attachItemUpdateInputToRequest(input *types.ItemUpdateInput) *http.Request
 	return httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(form.Encode()))
 }
 
+func attachVersionedItemUpdateInputToRequest(input *types.ItemUpdateInput, version string) *http.Request {
+	form := url.Values{
+		itemUpdateInputNameFormKey:    {anyToString(input.Name)},
+		itemUpdateInputDetailsFormKey: {anyToString(input.Details)},
+		itemUpdateInputVersionFormKey: {version},
+	}
+
+	return httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(form.Encode()))
+}
+
 func TestService_parseFormEncodedItemUpdateInput(T *testing.T) {
 	T.Parallel()
 
@@ -602,8 +615,40 @@ func TestService_parseFormEncodedItemUpdateInput(T *testing.T) {
 
 		req := attachItemUpdateInputToRequest(expected)
 
-		actual := s.service.parseFormEncodedItemUpdateInput(s.ctx, req, s.sessionCtxData)
+		actual, readVersion := s.service.parseFormEncodedItemUpdateInput(s.ctx, req, s.sessionCtxData)
 		assert.Equal(t, expected, actual)
+		assert.Zero(t, readVersion)
+	})
+
+	T.Run("with version", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleItem := fakes.BuildFakeItem()
+		exampleItem.BelongsToAccount = s.exampleAccount.ID
+
+		expected := fakes.BuildFakeItemUpdateInputFromItem(exampleItem)
+
+		req := attachVersionedItemUpdateInputToRequest(expected, "3")
+
+		actual, readVersion := s.service.parseFormEncodedItemUpdateInput(s.ctx, req, s.sessionCtxData)
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, uint64(3), readVersion)
+	})
+
+	T.Run("with invalid version", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleItem := fakes.BuildFakeItem()
+		exampleItem.BelongsToAccount = s.exampleAccount.ID
+
+		req := attachVersionedItemUpdateInputToRequest(fakes.BuildFakeItemUpdateInputFromItem(exampleItem), "latest")
+
+		actual, _ := s.service.parseFormEncodedItemUpdateInput(s.ctx, req, s.sessionCtxData)
+		assert.Nil(t, actual)
 	})
 
 	T.Run("with invalid form", func(t *testing.T) {
@@ -616,7 +661,7 @@ func TestService_parseFormEncodedItemUpdateInput(T *testing.T) {
 
 		req := httptest.NewRequest(http.MethodGet, "/test", badBody)
 
-		actual := s.service.parseFormEncodedItemUpdateInput(s.ctx, req, s.sessionCtxData)
+		actual, _ := s.service.parseFormEncodedItemUpdateInput(s.ctx, req, s.sessionCtxData)
 		assert.Nil(t, actual)
 	})
 
@@ -629,7 +674,7 @@ func TestService_parseFormEncodedItemUpdateInput(T *testing.T) {
 
 		req := attachItemUpdateInputToRequest(exampleInput)
 
-		actual := s.service.parseFormEncodedItemUpdateInput(s.ctx, req, s.sessionCtxData)
+		actual, _ := s.service.parseFormEncodedItemUpdateInput(s.ctx, req, s.sessionCtxData)
 		assert.Nil(t, actual)
 	})
 }
@@ -666,7 +711,7 @@ func TestService_handleItemUpdateRequest(T *testing.T) {
 		s.service.dataStore = mockDB
 
 		res := httptest.NewRecorder()
-		req := attachItemUpdateInputToRequest(exampleInput)
+		req := attachVersionedItemUpdateInputToRequest(exampleInput, fmt.Sprintf("%d", exampleItem.Version))
 
 		s.service.handleItemUpdateRequest(res, req)
 
@@ -738,7 +783,7 @@ func TestService_handleItemUpdateRequest(T *testing.T) {
 		s.service.dataStore = mockDB
 
 		res := httptest.NewRecorder()
-		req := attachItemUpdateInputToRequest(exampleInput)
+		req := attachVersionedItemUpdateInputToRequest(exampleInput, fmt.Sprintf("%d", exampleItem.Version))
 
 		s.service.handleItemUpdateRequest(res, req)
 
@@ -776,7 +821,7 @@ func TestService_handleItemUpdateRequest(T *testing.T) {
 		s.service.dataStore = mockDB
 
 		res := httptest.NewRecorder()
-		req := attachItemUpdateInputToRequest(exampleInput)
+		req := attachVersionedItemUpdateInputToRequest(exampleInput, fmt.Sprintf("%d", exampleItem.Version))
 
 		s.service.handleItemUpdateRequest(res, req)
 
@@ -784,6 +829,102 @@ func TestService_handleItemUpdateRequest(T *testing.T) {
 
 		mock.AssertExpectationsForObjects(t, mockDB)
 	})
+
+	T.Run("without version", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleItem := fakes.BuildFakeItem()
+		exampleItem.BelongsToAccount = s.exampleAccount.ID
+		s.service.itemIDFetcher = func(*http.Request) string {
+			return exampleItem.ID
+		}
+
+		exampleInput := fakes.BuildFakeItemUpdateInputFromItem(exampleItem)
+
+		mockDB := database.BuildMockDatabase()
+		s.service.dataStore = mockDB
+
+		res := httptest.NewRecorder()
+		req := attachItemUpdateInputToRequest(exampleInput)
+
+		s.service.handleItemUpdateRequest(res, req)
+
+		assert.Equal(t, http.StatusPreconditionRequired, res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB)
+	})
+
+	T.Run("with stale version", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleItem := fakes.BuildFakeItem()
+		exampleItem.BelongsToAccount = s.exampleAccount.ID
+		s.service.itemIDFetcher = func(*http.Request) string {
+			return exampleItem.ID
+		}
+
+		exampleInput := fakes.BuildFakeItemUpdateInputFromItem(exampleItem)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.ItemDataManager.On(
+			"GetItem",
+			testutils.ContextMatcher,
+			exampleItem.ID,
+			s.sessionCtxData.ActiveAccountID,
+		).Return(exampleItem, nil)
+		s.service.dataStore = mockDB
+
+		res := httptest.NewRecorder()
+		req := attachVersionedItemUpdateInputToRequest(exampleInput, fmt.Sprintf("%d", exampleItem.Version+1))
+
+		s.service.handleItemUpdateRequest(res, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB)
+	})
+
+	T.Run("with version conflict updating data", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleItem := fakes.BuildFakeItem()
+		exampleItem.BelongsToAccount = s.exampleAccount.ID
+		s.service.itemIDFetcher = func(*http.Request) string {
+			return exampleItem.ID
+		}
+
+		exampleInput := fakes.BuildFakeItemUpdateInputFromItem(exampleItem)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.ItemDataManager.On(
+			"GetItem",
+			testutils.ContextMatcher,
+			exampleItem.ID,
+			s.sessionCtxData.ActiveAccountID,
+		).Return(exampleItem, nil)
+
+		mockDB.ItemDataManager.On(
+			"UpdateItem",
+			testutils.ContextMatcher,
+			exampleItem,
+		).Return(database.ErrVersionConflict)
+		s.service.dataStore = mockDB
+
+		res := httptest.NewRecorder()
+		req := attachVersionedItemUpdateInputToRequest(exampleInput, fmt.Sprintf("%d", exampleItem.Version))
+
+		s.service.handleItemUpdateRequest(res, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB)
+	})
 }
 
 func TestService_handleItemArchiveRequest(T *testing.T) {
@@ -28,14 +28,25 @@
 		PermissionFilterMiddleware(permissions ...authorization.Permission) func(next http.Handler) http.Handler
 		ServiceAdminMiddleware(next http.Handler) http.Handler
 
-		AuthenticateUser(ctx context.Context, loginData *types.UserLoginInput) (*types.User, *http.Cookie, error)
+		AuthenticateUser(ctx context.Context, req *http.Request, loginData *types.UserLoginInput) (*types.User, *http.Cookie, error)
 		LogoutUser(ctx context.Context, sessionCtxData *types.SessionContextData, req *http.Request, res http.ResponseWriter) error
 	}
 
 	// UsersService is a subset of the larger types.UsersService interface.
 	UsersService interface {
 		RegisterUser(ctx context.Context, registrationInput *types.UserRegistrationInput) (*types.UserCreationResponse, error)
-		VerifyUserTwoFactorSecret(ctx context.Context, input *types.TOTPSecretVerificationInput) error
+		VerifyUserTwoFactorSecret(ctx context.Context, input *types.TOTPSecretVerificationInput) (*types.TOTPRecoveryCodesResponse, error)
+		RequestPasswordReset(ctx context.Context, input *types.PasswordResetTokenCreationRequestInput) error
+		RedeemPasswordResetToken(ctx context.Context, input *types.PasswordResetTokenRedemptionRequestInput) error
+	}
+
+	// AccountsService is a subset of the larger types.AccountDataService interface.
+	AccountsService interface {
+		InviteUserToAccount(ctx context.Context, accountID, inviterID string, input *types.AccountInvitationCreationRequestInput) (*types.AccountInvitation, error)
+		ClaimAccountInvitation(ctx context.Context, userID, token string) (*types.AccountInvitation, error)
+		AcceptAccountInvitation(ctx context.Context, userID, accountInvitationID string) error
+		RejectAccountInvitation(ctx context.Context, userID, accountInvitationID string) error
+		RevokeAccountInvitation(ctx context.Context, accountID, requesterID, accountInvitationID string) error
 	}
 
 	// Service serves HTML.
@@ -44,20 +55,22 @@
 	}
 
 	service struct {
-		usersService              UsersService
-		logger                    logging.Logger
-		tracer                    tracing.Tracer
-		panicker                  panicking.Panicker
-		authService               AuthService
-		dataStore                 database.DataManager
-		itemIDFetcher             func(*http.Request) string
-		localizer                 *i18n.Localizer
-		templateFuncMap           template.FuncMap
-		sessionContextDataFetcher func(*http.Request) (*types.SessionContextData, error)
-		accountIDFetcher          func(*http.Request) string
-		apiClientIDFetcher        func(*http.Request) string
-		webhookIDFetcher          func(*http.Request) string
-		useFakeData               bool
+		usersService               UsersService
+		accountsService            AccountsService
+		logger                     logging.Logger
+		tracer                     tracing.Tracer
+		panicker                   panicking.Panicker
+		authService                AuthService
+		dataStore                  database.DataManager
+		itemIDFetcher              func(*http.Request) string
+		localizer                  *i18n.Localizer
+		templateFuncMap            template.FuncMap
+		sessionContextDataFetcher  func(*http.Request) (*types.SessionContextData, error)
+		accountIDFetcher           func(*http.Request) string
+		accountInvitationIDFetcher func(*http.Request) string
+		apiClientIDFetcher         func(*http.Request) string
+		webhookIDFetcher           func(*http.Request) string
+		useFakeData                bool
 	}
 )
 
@@ -67,23 +80,26 @@ func ProvideService(
 	logger logging.Logger,
 	authService AuthService,
 	usersService UsersService,
+	accountsService AccountsService,
 	dataStore database.DataManager,
 	routeParamManager routing.RouteParamManager,
 ) Service {
 	svc := &service{
-		useFakeData:               cfg.UseFakeData,
-		logger:                    logging.EnsureLogger(logger).WithName(serviceName),
-		tracer:                    tracing.NewTracer(serviceName),
-		panicker:                  panicking.NewProductionPanicker(),
-		localizer:                 provideLocalizer(),
-		sessionContextDataFetcher: authservice.FetchContextFromRequest,
-		authService:               authService,
-		usersService:              usersService,
-		dataStore:                 dataStore,
-		apiClientIDFetcher:        routeParamManager.BuildRouteParamStringIDFetcher(apiClientIDURLParamKey),
-		accountIDFetcher:          routeParamManager.BuildRouteParamStringIDFetcher(accountIDURLParamKey),
-		webhookIDFetcher:          routeParamManager.BuildRouteParamStringIDFetcher(webhookIDURLParamKey),
-		itemIDFetcher:             routeParamManager.BuildRouteParamStringIDFetcher(itemIDURLParamKey),
+		useFakeData:                cfg.UseFakeData,
+		logger:                     logging.EnsureLogger(logger).WithName(serviceName),
+		tracer:                     tracing.NewTracer(serviceName),
+		panicker:                   panicking.NewProductionPanicker(),
+		localizer:                  ProvideLocalizer(),
+		sessionContextDataFetcher:  authservice.FetchContextFromRequest,
+		authService:                authService,
+		usersService:               usersService,
+		accountsService:            accountsService,
+		dataStore:                  dataStore,
+		apiClientIDFetcher:         routeParamManager.BuildRouteParamStringIDFetcher(apiClientIDURLParamKey),
+		accountIDFetcher:           routeParamManager.BuildRouteParamStringIDFetcher(accountIDURLParamKey),
+		accountInvitationIDFetcher: routeParamManager.BuildRouteParamStringIDFetcher(accountInvitationIDURLParamKey),
+		webhookIDFetcher:           routeParamManager.BuildRouteParamStringIDFetcher(webhookIDURLParamKey),
+		itemIDFetcher:              routeParamManager.BuildRouteParamStringIDFetcher(itemIDURLParamKey),
 		templateFuncMap: map[string]interface{}{
 			"relativeTime":        relativeTime,
 			"relativeTimeFromPtr": relativeTimeFromPtr,
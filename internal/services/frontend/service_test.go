@@ -24,11 +24,13 @@ func TestProvideService(t *testing.T) {
 	logger := logging.NewNoopLogger()
 	authService := &mocktypes.AuthService{}
 	usersService := &mocktypes.UsersService{}
+	accountsService := &mocktypes.AccountsService{}
 	dataManager := database.BuildMockDatabase()
 
 	rpm := mockrouting.NewRouteParamManager()
 	rpm.On("BuildRouteParamStringIDFetcher", apiClientIDURLParamKey).Return(dummyIDFetcher)
 	rpm.On("BuildRouteParamStringIDFetcher", accountIDURLParamKey).Return(dummyIDFetcher)
+	rpm.On("BuildRouteParamStringIDFetcher", accountInvitationIDURLParamKey).Return(dummyIDFetcher)
 	rpm.On("BuildRouteParamStringIDFetcher", webhookIDURLParamKey).Return(dummyIDFetcher)
 	rpm.On("BuildRouteParamStringIDFetcher", itemIDURLParamKey).Return(dummyIDFetcher)
 
@@ -37,10 +39,11 @@ func TestProvideService(t *testing.T) {
 		logger,
 		authService,
 		usersService,
+		accountsService,
 		dataManager,
 		rpm,
 	)
 
-	mock.AssertExpectationsForObjects(t, authService, usersService, dataManager, rpm)
+	mock.AssertExpectationsForObjects(t, authService, usersService, accountsService, dataManager, rpm)
 	assert.NotNil(t, s)
 }
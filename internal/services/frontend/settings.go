@@ -60,7 +60,9 @@ func (s *service) buildUserSettingsView(includeBaseTemplate bool) func(http.Resp
 var accountSettingsPageSrc string
 
 type accountSettingsPageContent struct {
-	Account *types.Account
+	Account            *types.Account
+	PendingInvitations *types.AccountInvitationList
+	AccountRoles       []*accountRoleOption
 }
 
 func (s *service) buildAccountSettingsView(includeBaseTemplate bool) func(http.ResponseWriter, *http.Request) {
@@ -86,8 +88,24 @@ func (s *service) buildAccountSettingsView(includeBaseTemplate bool) func(http.R
 			return
 		}
 
+		pendingInvitations, err := s.fetchPendingAccountInvitations(ctx, sessionCtxData)
+		if err != nil {
+			observability.AcknowledgeError(err, logger, span, "retrieving account invitations from database")
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		accountRoles, err := s.fetchAccountRoleOptions(ctx, sessionCtxData)
+		if err != nil {
+			observability.AcknowledgeError(err, logger, span, "retrieving account roles from database")
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
 		contentData := &accountSettingsPageContent{
-			Account: account,
+			Account:            account,
+			PendingInvitations: pendingInvitations,
+			AccountRoles:       accountRoles,
 		}
 
 		funcMap := template.FuncMap{}
@@ -142,6 +142,18 @@ func TestService_buildAccountSettingsView(T *testing.T) {
 			exampleSessionContextData.ActiveAccountID,
 			exampleSessionContextData.Requester.UserID,
 		).Return(exampleAccount, nil)
+		mockDB.AccountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForAccount",
+			testutils.ContextMatcher,
+			exampleSessionContextData.ActiveAccountID,
+			(*types.QueryFilter)(nil),
+		).Return(fakes.BuildFakeAccountInvitationList(), nil)
+		mockDB.AccountRoleDataManager.On(
+			"GetAccountRoles",
+			testutils.ContextMatcher,
+			exampleSessionContextData.ActiveAccountID,
+			(*types.QueryFilter)(nil),
+		).Return(fakes.BuildFakeAccountRoleList(), nil)
 		s.service.dataStore = mockDB
 
 		res := httptest.NewRecorder()
@@ -172,6 +184,18 @@ func TestService_buildAccountSettingsView(T *testing.T) {
 			exampleSessionContextData.ActiveAccountID,
 			exampleSessionContextData.Requester.UserID,
 		).Return(exampleAccount, nil)
+		mockDB.AccountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForAccount",
+			testutils.ContextMatcher,
+			exampleSessionContextData.ActiveAccountID,
+			(*types.QueryFilter)(nil),
+		).Return(fakes.BuildFakeAccountInvitationList(), nil)
+		mockDB.AccountRoleDataManager.On(
+			"GetAccountRoles",
+			testutils.ContextMatcher,
+			exampleSessionContextData.ActiveAccountID,
+			(*types.QueryFilter)(nil),
+		).Return(fakes.BuildFakeAccountRoleList(), nil)
 		s.service.dataStore = mockDB
 
 		res := httptest.NewRecorder()
@@ -228,6 +252,84 @@ func TestService_buildAccountSettingsView(T *testing.T) {
 
 		mock.AssertExpectationsForObjects(t, mockDB)
 	})
+
+	T.Run("with error fetching account invitations from database", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleAccount := fakes.BuildFakeAccount()
+		exampleSessionContextData := fakes.BuildFakeSessionContextDataForAccount(exampleAccount)
+		s.service.sessionContextDataFetcher = func(*http.Request) (*types.SessionContextData, error) {
+			return exampleSessionContextData, nil
+		}
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.AccountDataManager.On(
+			"GetAccount",
+			testutils.ContextMatcher,
+			exampleSessionContextData.ActiveAccountID,
+			exampleSessionContextData.Requester.UserID,
+		).Return(exampleAccount, nil)
+		mockDB.AccountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForAccount",
+			testutils.ContextMatcher,
+			exampleSessionContextData.ActiveAccountID,
+			(*types.QueryFilter)(nil),
+		).Return((*types.AccountInvitationList)(nil), errors.New("blah"))
+		s.service.dataStore = mockDB
+
+		res := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/whatever", nil)
+
+		s.service.buildAccountSettingsView(true)(res, req)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB)
+	})
+
+	T.Run("with error fetching account roles from database", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		exampleAccount := fakes.BuildFakeAccount()
+		exampleSessionContextData := fakes.BuildFakeSessionContextDataForAccount(exampleAccount)
+		s.service.sessionContextDataFetcher = func(*http.Request) (*types.SessionContextData, error) {
+			return exampleSessionContextData, nil
+		}
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.AccountDataManager.On(
+			"GetAccount",
+			testutils.ContextMatcher,
+			exampleSessionContextData.ActiveAccountID,
+			exampleSessionContextData.Requester.UserID,
+		).Return(exampleAccount, nil)
+		mockDB.AccountInvitationDataManager.On(
+			"GetPendingAccountInvitationsForAccount",
+			testutils.ContextMatcher,
+			exampleSessionContextData.ActiveAccountID,
+			(*types.QueryFilter)(nil),
+		).Return(fakes.BuildFakeAccountInvitationList(), nil)
+		mockDB.AccountRoleDataManager.On(
+			"GetAccountRoles",
+			testutils.ContextMatcher,
+			exampleSessionContextData.ActiveAccountID,
+			(*types.QueryFilter)(nil),
+		).Return((*types.AccountRoleList)(nil), errors.New("blah"))
+		s.service.dataStore = mockDB
+
+		res := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/whatever", nil)
+
+		s.service.buildAccountSettingsView(true)(res, req)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB)
+	})
 }
 
 func TestService_buildAdminSettingsView(T *testing.T) {
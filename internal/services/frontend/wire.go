@@ -12,6 +12,8 @@
 		ProvideService,
 		ProvideAuthService,
 		ProvideUsersService,
+		ProvideAccountsService,
+		ProvideLocalizer,
 	)
 )
 
@@ -24,3 +26,8 @@ func ProvideAuthService(x types.AuthService) AuthService {
 func ProvideUsersService(x types.UserDataService) UsersService {
 	return x
 }
+
+// ProvideAccountsService does what I hope one day wire figures out how to do.
+func ProvideAccountsService(x types.AccountDataService) AccountsService {
+	return x
+}
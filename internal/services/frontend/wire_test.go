@@ -27,3 +27,13 @@ func TestProvideUsersService(T *testing.T) {
 		assert.NotNil(t, ProvideUsersService(&mocktypes.UsersService{}))
 	})
 }
+
+func TestProvideAccountsService(T *testing.T) {
+	T.Parallel()
+
+	T.Run("obligatory", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NotNil(t, ProvideAccountsService(&mocktypes.AccountsService{}))
+	})
+}
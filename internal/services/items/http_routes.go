@@ -8,6 +8,8 @@
 
 	"github.com/segmentio/ksuid"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/audit"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
@@ -72,6 +74,7 @@ func (s *service) CreateHandler(res http.ResponseWriter, req *http.Request) {
 		Item:                    input,
 		AttributableToUserID:    sessionCtxData.Requester.UserID,
 		AttributableToAccountID: sessionCtxData.ActiveAccountID,
+		RequestID:               audit.RequestIDFromContext(ctx),
 	}
 	if err = s.preWritesPublisher.Publish(ctx, preWrite); err != nil {
 		observability.AcknowledgeError(err, logger, span, "publishing item write message")
@@ -119,6 +122,8 @@ func (s *service) ReadHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	res.Header().Set(encoding.ETagHeaderKey, encoding.ETagForVersion(x.Version))
+
 	// encode our response and peace.
 	s.encoderDecoder.RespondWithData(ctx, res, x)
 }
@@ -189,13 +194,18 @@ func (s *service) SearchHandler(res http.ResponseWriter, req *http.Request) {
 	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
 	logger = sessionCtxData.AttachToLogger(logger)
 
-	relevantIDs, err := s.search.Search(ctx, query, sessionCtxData.ActiveAccountID)
+	searchResults, err := s.search.Search(ctx, query, sessionCtxData.ActiveAccountID, filter)
 	if err != nil {
 		observability.AcknowledgeError(err, logger, span, "executing item search query")
 		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
 		return
 	}
 
+	relevantIDs := []string{}
+	for _, result := range searchResults {
+		relevantIDs = append(relevantIDs, result.ID)
+	}
+
 	// fetch items from database.
 	items, err := s.itemDataManager.GetItemsWithIDs(ctx, sessionCtxData.ActiveAccountID, filter.Limit, relevantIDs)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -207,11 +217,29 @@ func (s *service) SearchHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	itemsByID := map[string]*types.Item{}
+	for _, item := range items {
+		itemsByID[item.ID] = item
+	}
+
+	// keep the search index's relevance order, skipping anything the database no longer has.
+	results := []*types.ItemSearchResult{}
+	for _, result := range searchResults {
+		if item, ok := itemsByID[result.ID]; ok {
+			results = append(results, &types.ItemSearchResult{
+				Item:       item,
+				Highlights: result.Highlights,
+				Score:      result.Score,
+			})
+		}
+	}
+
 	// encode our response and peace.
-	s.encoderDecoder.RespondWithData(ctx, res, items)
+	s.encoderDecoder.RespondWithData(ctx, res, results)
 }
 
-// UpdateHandler returns a handler that updates an item.
+// UpdateHandler returns a handler that updates an item. Requests must carry an If-Match header with the item's
+// ETag, and are answered with 428 without one, or 412 if the item has changed since.
 func (s *service) UpdateHandler(res http.ResponseWriter, req *http.Request) {
 	ctx, span := s.tracer.StartSpan(req.Context())
 	defer span.End()
@@ -250,6 +278,13 @@ func (s *service) UpdateHandler(res http.ResponseWriter, req *http.Request) {
 	tracing.AttachItemIDToSpan(span, itemID)
 	logger = logger.WithValue(keys.ItemIDKey, itemID)
 
+	// updates have to say which version of the item they were made against, or they could clobber one they never saw.
+	if !encoding.IfMatchProvided(req) {
+		logger.Debug("update made without If-Match header")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "If-Match header required", http.StatusPreconditionRequired)
+		return
+	}
+
 	// fetch item from database.
 	item, err := s.itemDataManager.GetItem(ctx, itemID, sessionCtxData.ActiveAccountID)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -261,14 +296,23 @@ func (s *service) UpdateHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// make sure nobody has changed the item since the requester read it.
+	if !encoding.IfMatchAllows(req, item.Version) {
+		logger.WithValue("version", item.Version).Debug("item has changed since it was read")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "item has changed since it was read", http.StatusPreconditionFailed)
+		return
+	}
+
 	// update the item.
-	item.Update(input)
+	changes := item.Update(input)
 
 	pum := &types.PreUpdateMessage{
 		DataType:                types.ItemDataType,
 		Item:                    item,
+		Changes:                 changes,
 		AttributableToUserID:    sessionCtxData.Requester.UserID,
 		AttributableToAccountID: sessionCtxData.ActiveAccountID,
+		RequestID:               audit.RequestIDFromContext(ctx),
 	}
 	if err = s.preUpdatesPublisher.Publish(ctx, pum); err != nil {
 		observability.AcknowledgeError(err, logger, span, "publishing item update message")
@@ -276,6 +320,10 @@ func (s *service) UpdateHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// the update is written later, at the version it was read at, so respond with the version it will have once it is.
+	item.Version++
+	res.Header().Set(encoding.ETagHeaderKey, encoding.ETagForVersion(item.Version))
+
 	// encode our response and peace.
 	s.encoderDecoder.RespondWithData(ctx, res, item)
 }
@@ -319,6 +367,7 @@ func (s *service) ArchiveHandler(res http.ResponseWriter, req *http.Request) {
 		RelevantID:              itemID,
 		AttributableToUserID:    sessionCtxData.Requester.UserID,
 		AttributableToAccountID: sessionCtxData.ActiveAccountID,
+		RequestID:               audit.RequestIDFromContext(ctx),
 	}
 	if err = s.preArchivesPublisher.Publish(ctx, pam); err != nil {
 		observability.AcknowledgeError(err, logger, span, "publishing item archive message")
@@ -18,6 +18,7 @@
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	mockencoding "gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
 	mocksearch "gitlab.com/verygoodsoftwarenotvirus/todo/internal/search/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
@@ -189,6 +190,7 @@ func TestItemsService_ReadHandler(T *testing.T) {
 		helper.service.ReadHandler(helper.res, helper.req)
 
 		assert.Equal(t, http.StatusOK, helper.res.Code, "expected %d in status response, got %d", http.StatusOK, helper.res.Code)
+		assert.Equal(t, encoding.ETagForVersion(helper.exampleItem.Version), helper.res.Header().Get(encoding.ETagHeaderKey))
 
 		mock.AssertExpectationsForObjects(t, itemDataManager, encoderDecoder)
 	})
@@ -402,8 +404,10 @@ func TestItemsService_SearchHandler(T *testing.T) {
 	exampleLimit := uint8(123)
 	exampleItemList := fakes.BuildFakeItemList()
 	exampleItemIDs := []string{}
+	exampleSearchResults := []*search.Result{}
 	for _, x := range exampleItemList.Items {
 		exampleItemIDs = append(exampleItemIDs, x.ID)
+		exampleSearchResults = append(exampleSearchResults, &search.Result{ID: x.ID})
 	}
 
 	T.Run("standard", func(t *testing.T) {
@@ -422,7 +426,8 @@ func TestItemsService_SearchHandler(T *testing.T) {
 			testutils.ContextMatcher,
 			exampleQuery,
 			helper.exampleAccount.ID,
-		).Return(exampleItemIDs, nil)
+			mock.IsType(&types.QueryFilter{}),
+		).Return(exampleSearchResults, nil)
 		helper.service.search = indexManager
 
 		itemDataManager := &mocktypes.ItemDataManager{}
@@ -440,7 +445,65 @@ func TestItemsService_SearchHandler(T *testing.T) {
 			"RespondWithData",
 			testutils.ContextMatcher,
 			testutils.HTTPResponseWriterMatcher,
-			mock.IsType([]*types.Item{}),
+			mock.IsType([]*types.ItemSearchResult{}),
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.SearchHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code, "expected %d in status response, got %d", http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, indexManager, itemDataManager, encoderDecoder)
+	})
+
+	T.Run("preserves relevance order and search details", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		helper.req.URL.RawQuery = url.Values{
+			types.SearchQueryKey: []string{exampleQuery},
+			types.LimitQueryKey:  []string{strconv.Itoa(int(exampleLimit))},
+		}.Encode()
+
+		first, second := fakes.BuildFakeItem(), fakes.BuildFakeItem()
+		searchResults := []*search.Result{
+			{ID: second.ID, Score: 2, Highlights: map[string][]string{"name": {"<em>thing</em>"}}},
+			{ID: first.ID, Score: 1},
+		}
+		relevantIDs := []string{second.ID, first.ID}
+
+		indexManager := &mocksearch.IndexManager{}
+		indexManager.On(
+			"Search",
+			testutils.ContextMatcher,
+			exampleQuery,
+			helper.exampleAccount.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return(searchResults, nil)
+		helper.service.search = indexManager
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On(
+			"GetItemsWithIDs",
+			testutils.ContextMatcher,
+			helper.exampleAccount.ID,
+			exampleLimit,
+			relevantIDs,
+		).Return([]*types.Item{first, second}, nil)
+		helper.service.itemDataManager = itemDataManager
+
+		expected := []*types.ItemSearchResult{
+			{Item: second, Score: 2, Highlights: searchResults[0].Highlights},
+			{Item: first, Score: 1},
+		}
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"RespondWithData",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			expected,
 		).Return()
 		helper.service.encoderDecoder = encoderDecoder
 
@@ -488,7 +551,8 @@ func TestItemsService_SearchHandler(T *testing.T) {
 			testutils.ContextMatcher,
 			exampleQuery,
 			helper.exampleAccount.ID,
-		).Return([]string{}, errors.New("blah"))
+			mock.IsType(&types.QueryFilter{}),
+		).Return([]*search.Result{}, errors.New("blah"))
 		helper.service.search = indexManager
 
 		encoderDecoder := mockencoding.NewMockEncoderDecoder()
@@ -522,7 +586,8 @@ func TestItemsService_SearchHandler(T *testing.T) {
 			testutils.ContextMatcher,
 			exampleQuery,
 			helper.exampleAccount.ID,
-		).Return(exampleItemIDs, nil)
+			mock.IsType(&types.QueryFilter{}),
+		).Return(exampleSearchResults, nil)
 		helper.service.search = indexManager
 
 		itemDataManager := &mocktypes.ItemDataManager{}
@@ -540,7 +605,7 @@ func TestItemsService_SearchHandler(T *testing.T) {
 			"RespondWithData",
 			testutils.ContextMatcher,
 			testutils.HTTPResponseWriterMatcher,
-			mock.IsType([]*types.Item{}),
+			mock.IsType([]*types.ItemSearchResult{}),
 		).Return()
 		helper.service.encoderDecoder = encoderDecoder
 
@@ -566,7 +631,8 @@ func TestItemsService_SearchHandler(T *testing.T) {
 			testutils.ContextMatcher,
 			exampleQuery,
 			helper.exampleAccount.ID,
-		).Return(exampleItemIDs, nil)
+			mock.IsType(&types.QueryFilter{}),
+		).Return(exampleSearchResults, nil)
 		helper.service.search = indexManager
 
 		itemDataManager := &mocktypes.ItemDataManager{}
@@ -621,17 +687,86 @@ func TestItemsService_UpdateHandler(T *testing.T) {
 		).Return(helper.exampleItem, nil)
 		helper.service.itemDataManager = itemDataManager
 
+		readVersion := helper.exampleItem.Version
+		helper.req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(readVersion))
+
 		mockEventProducer := &mock2.Publisher{}
 		mockEventProducer.On(
 			"Publish",
 			testutils.ContextMatcher,
-			mock.MatchedBy(testutils.PreUpdateMessageMatcher),
+			mock.MatchedBy(func(message *types.PreUpdateMessage) bool {
+				return message.Item.Version == readVersion
+			}),
 		).Return(nil)
 		helper.service.preUpdatesPublisher = mockEventProducer
 
 		helper.service.UpdateHandler(helper.res, helper.req)
 
 		assert.Equal(t, http.StatusOK, helper.res.Code, "expected %d in status response, got %d", http.StatusOK, helper.res.Code)
+		assert.Equal(t, encoding.ETagForVersion(readVersion+1), helper.res.Header().Get(encoding.ETagHeaderKey))
+
+		mock.AssertExpectationsForObjects(t, itemDataManager, mockEventProducer)
+	})
+
+	T.Run("with stale If-Match header", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		exampleCreationInput := fakes.BuildFakeItemUpdateInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleCreationInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.exampleItem.Version = 3
+		helper.req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(2))
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		itemDataManager.On(
+			"GetItem",
+			testutils.ContextMatcher,
+			helper.exampleItem.ID,
+			helper.exampleAccount.ID,
+		).Return(helper.exampleItem, nil)
+		helper.service.itemDataManager = itemDataManager
+
+		mockEventProducer := &mock2.Publisher{}
+		helper.service.preUpdatesPublisher = mockEventProducer
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, itemDataManager, mockEventProducer)
+	})
+
+	T.Run("without If-Match header", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		exampleCreationInput := fakes.BuildFakeItemUpdateInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleCreationInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		itemDataManager := &mocktypes.ItemDataManager{}
+		helper.service.itemDataManager = itemDataManager
+
+		mockEventProducer := &mock2.Publisher{}
+		helper.service.preUpdatesPublisher = mockEventProducer
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusPreconditionRequired, helper.res.Code)
 
 		mock.AssertExpectationsForObjects(t, itemDataManager, mockEventProducer)
 	})
@@ -696,6 +831,8 @@ func TestItemsService_UpdateHandler(T *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, helper.req)
 
+		helper.req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(helper.exampleItem.Version))
+
 		itemDataManager := &mocktypes.ItemDataManager{}
 		itemDataManager.On(
 			"GetItem",
@@ -726,6 +863,8 @@ func TestItemsService_UpdateHandler(T *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, helper.req)
 
+		helper.req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(helper.exampleItem.Version))
+
 		itemDataManager := &mocktypes.ItemDataManager{}
 		itemDataManager.On(
 			"GetItem",
@@ -756,6 +895,8 @@ func TestItemsService_UpdateHandler(T *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, helper.req)
 
+		helper.req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(helper.exampleItem.Version))
+
 		itemDataManager := &mocktypes.ItemDataManager{}
 		itemDataManager.On(
 			"GetItem",
@@ -53,7 +53,7 @@ func ProvideService(
 	publisherProvider publishers.PublisherProvider,
 ) (types.ItemDataService, error) {
 	client := &http.Client{Transport: tracing.BuildTracedHTTPTransport(time.Second)}
-	searchIndexManager, err := searchIndexProvider(ctx, logger, client, search.IndexPath(cfg.SearchIndexPath), "items", "name", "description")
+	searchIndexManager, err := searchIndexProvider(ctx, logger, client, search.IndexPath(cfg.SearchIndexPath), "items", "name", "details")
 	if err != nil {
 		return nil, fmt.Errorf("setting up search index: %w", err)
 	}
@@ -0,0 +1,4 @@
+/*
+Package notifications provides a series of HTTP handlers for reading and acknowledging a user's in-app notifications.
+*/
+package notifications
@@ -0,0 +1,70 @@
+package notifications
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+type notificationsServiceHTTPRoutesTestHelper struct {
+	ctx                 context.Context
+	req                 *http.Request
+	res                 *httptest.ResponseRecorder
+	service             *service
+	exampleUser         *types.User
+	exampleAccount      *types.Account
+	exampleNotification *types.Notification
+}
+
+func buildTestHelper(t *testing.T) *notificationsServiceHTTPRoutesTestHelper {
+	t.Helper()
+
+	helper := &notificationsServiceHTTPRoutesTestHelper{}
+
+	helper.ctx = context.Background()
+	helper.service = buildTestService()
+	helper.exampleUser = fakes.BuildFakeUser()
+	helper.exampleAccount = fakes.BuildFakeAccount()
+	helper.exampleAccount.BelongsToUser = helper.exampleUser.ID
+	helper.exampleNotification = fakes.BuildFakeNotification()
+	helper.exampleNotification.BelongsToAccount = helper.exampleAccount.ID
+	helper.exampleNotification.BelongsToUser = helper.exampleUser.ID
+
+	helper.service.notificationIDFetcher = func(*http.Request) string {
+		return helper.exampleNotification.ID
+	}
+
+	sessionCtxData := &types.SessionContextData{
+		Requester: types.RequesterInfo{
+			UserID:                helper.exampleUser.ID,
+			Reputation:            helper.exampleUser.ServiceAccountStatus,
+			ReputationExplanation: helper.exampleUser.ReputationExplanation,
+			ServicePermissions:    authorization.NewServiceRolePermissionChecker(helper.exampleUser.ServiceRoles...),
+		},
+		ActiveAccountID: helper.exampleAccount.ID,
+		AccountPermissions: map[string]authorization.AccountRolePermissionsChecker{
+			helper.exampleAccount.ID: authorization.NewAccountRolePermissionChecker(authorization.AccountMemberRole.String()),
+		},
+	}
+	helper.service.sessionContextDataFetcher = func(*http.Request) (*types.SessionContextData, error) {
+		return sessionCtxData, nil
+	}
+
+	helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+	req := testutils.BuildTestRequest(t)
+
+	helper.req = req.WithContext(context.WithValue(req.Context(), types.SessionContextDataKey, sessionCtxData))
+
+	helper.res = httptest.NewRecorder()
+
+	return helper
+}
@@ -0,0 +1,402 @@
+package notifications
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mockencoding "gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding/mock"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+func TestNotificationsService_ListHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		notificationDataManager := &mocktypes.NotificationDataManager{}
+		notificationDataManager.On(
+			"GetNotifications",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return(fakes.BuildFakeNotificationList(), nil)
+		helper.service.notificationDataManager = notificationDataManager
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"RespondWithData",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			mock.IsType(&types.NotificationList{}),
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, notificationDataManager, encoderDecoder)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"EncodeErrorResponse",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			"unauthenticated",
+			http.StatusUnauthorized,
+		)
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, encoderDecoder)
+	})
+
+	T.Run("with no rows returned", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		notificationDataManager := &mocktypes.NotificationDataManager{}
+		notificationDataManager.On(
+			"GetNotifications",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return((*types.NotificationList)(nil), sql.ErrNoRows)
+		helper.service.notificationDataManager = notificationDataManager
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"RespondWithData",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			mock.IsType(&types.NotificationList{}),
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, notificationDataManager, encoderDecoder)
+	})
+
+	T.Run("with error retrieving notifications from database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		notificationDataManager := &mocktypes.NotificationDataManager{}
+		notificationDataManager.On(
+			"GetNotifications",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return((*types.NotificationList)(nil), errors.New("blah"))
+		helper.service.notificationDataManager = notificationDataManager
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"EncodeUnspecifiedInternalServerErrorResponse",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.ListHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, notificationDataManager, encoderDecoder)
+	})
+}
+
+func TestNotificationsService_UnreadCountHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		notificationDataManager := &mocktypes.NotificationDataManager{}
+		notificationDataManager.On(
+			"GetUnreadNotificationCount",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(uint64(3), nil)
+		helper.service.notificationDataManager = notificationDataManager
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"RespondWithData",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			mock.IsType(&types.NotificationUnreadCount{}),
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.UnreadCountHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, notificationDataManager, encoderDecoder)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"EncodeErrorResponse",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			"unauthenticated",
+			http.StatusUnauthorized,
+		)
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.UnreadCountHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, encoderDecoder)
+	})
+
+	T.Run("with error counting notifications in database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		notificationDataManager := &mocktypes.NotificationDataManager{}
+		notificationDataManager.On(
+			"GetUnreadNotificationCount",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(uint64(0), errors.New("blah"))
+		helper.service.notificationDataManager = notificationDataManager
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"EncodeUnspecifiedInternalServerErrorResponse",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.UnreadCountHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, notificationDataManager, encoderDecoder)
+	})
+}
+
+func TestNotificationsService_MarkAsSeenHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		notificationDataManager := &mocktypes.NotificationDataManager{}
+		notificationDataManager.On(
+			"MarkNotificationAsSeen",
+			testutils.ContextMatcher,
+			helper.exampleNotification.ID,
+			helper.exampleUser.ID,
+		).Return(nil)
+		helper.service.notificationDataManager = notificationDataManager
+
+		helper.service.MarkAsSeenHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNoContent, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, notificationDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"EncodeErrorResponse",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			"unauthenticated",
+			http.StatusUnauthorized,
+		)
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.MarkAsSeenHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, encoderDecoder)
+	})
+
+	T.Run("with nonexistent notification", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		notificationDataManager := &mocktypes.NotificationDataManager{}
+		notificationDataManager.On(
+			"MarkNotificationAsSeen",
+			testutils.ContextMatcher,
+			helper.exampleNotification.ID,
+			helper.exampleUser.ID,
+		).Return(sql.ErrNoRows)
+		helper.service.notificationDataManager = notificationDataManager
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"EncodeNotFoundResponse",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.MarkAsSeenHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, notificationDataManager, encoderDecoder)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		notificationDataManager := &mocktypes.NotificationDataManager{}
+		notificationDataManager.On(
+			"MarkNotificationAsSeen",
+			testutils.ContextMatcher,
+			helper.exampleNotification.ID,
+			helper.exampleUser.ID,
+		).Return(errors.New("blah"))
+		helper.service.notificationDataManager = notificationDataManager
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"EncodeUnspecifiedInternalServerErrorResponse",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.MarkAsSeenHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, notificationDataManager, encoderDecoder)
+	})
+}
+
+func TestNotificationsService_MarkAllAsSeenHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		notificationDataManager := &mocktypes.NotificationDataManager{}
+		notificationDataManager.On(
+			"MarkAllNotificationsAsSeen",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(nil)
+		helper.service.notificationDataManager = notificationDataManager
+
+		helper.service.MarkAllAsSeenHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusNoContent, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, notificationDataManager)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"EncodeErrorResponse",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			"unauthenticated",
+			http.StatusUnauthorized,
+		)
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.MarkAllAsSeenHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, encoderDecoder)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+
+		notificationDataManager := &mocktypes.NotificationDataManager{}
+		notificationDataManager.On(
+			"MarkAllNotificationsAsSeen",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(errors.New("blah"))
+		helper.service.notificationDataManager = notificationDataManager
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"EncodeUnspecifiedInternalServerErrorResponse",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.MarkAllAsSeenHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, notificationDataManager, encoderDecoder)
+	})
+}
@@ -0,0 +1,47 @@
+package notifications
+
+import (
+	"net/http"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing"
+	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	serviceName string = "notifications_service"
+)
+
+var _ types.NotificationDataService = (*service)(nil)
+
+type (
+	// service handles notifications.
+	service struct {
+		logger                    logging.Logger
+		notificationDataManager   types.NotificationDataManager
+		notificationIDFetcher     func(*http.Request) string
+		sessionContextDataFetcher func(*http.Request) (*types.SessionContextData, error)
+		encoderDecoder            encoding.ServerEncoderDecoder
+		tracer                    tracing.Tracer
+	}
+)
+
+// ProvideService builds a new NotificationsService.
+func ProvideService(
+	logger logging.Logger,
+	notificationDataManager types.NotificationDataManager,
+	encoder encoding.ServerEncoderDecoder,
+	routeParamManager routing.RouteParamManager,
+) types.NotificationDataService {
+	return &service{
+		logger:                    logging.EnsureLogger(logger).WithName(serviceName),
+		notificationIDFetcher:     routeParamManager.BuildRouteParamStringIDFetcher(NotificationIDURIParamKey),
+		sessionContextDataFetcher: authservice.FetchContextFromRequest,
+		notificationDataManager:   notificationDataManager,
+		encoderDecoder:            encoder,
+		tracer:                    tracing.NewTracer(serviceName),
+	}
+}
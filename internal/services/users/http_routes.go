@@ -3,12 +3,16 @@
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"image/png"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/boombuler/barcode"
 	"github.com/boombuler/barcode/qr"
@@ -16,9 +20,11 @@
 	"github.com/segmentio/ksuid"
 	passwordvalidator "github.com/wagslane/go-password-validator"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/audit"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authentication"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
@@ -31,6 +37,11 @@
 	base64ImagePrefix      = "data:image/jpeg;base64,"
 	minimumPasswordEntropy = 75
 	totpSecretSize         = 64
+	totpRecoveryCodeCount  = 10
+	totpRecoveryCodeSize   = 10
+	passwordResetTokenSize = 32
+
+	passwordResetTokenLifetime = 30 * time.Minute
 )
 
 // validateCredentialChangeRequest takes a user's credentials and determines
@@ -126,6 +137,7 @@ func (s *service) RegisterUser(ctx context.Context, registrationInput *types.Use
 	input := &types.UserDataStoreCreationInput{
 		ID:              ksuid.New().String(),
 		Username:        registrationInput.Username,
+		EmailAddress:    registrationInput.EmailAddress,
 		HashedPassword:  hp,
 		TwoFactorSecret: "",
 	}
@@ -145,6 +157,13 @@ func (s *service) RegisterUser(ctx context.Context, registrationInput *types.Use
 	tracing.AttachUserIDToSpan(span, user.ID)
 	s.userCounter.Increment(ctx)
 
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.UserCreationEvent,
+		ActorUserID:  user.ID,
+		ResourceType: types.UserResourceType,
+		ResourceID:   user.ID,
+	})
+
 	// UserCreationResponse is a struct we can use to notify the user of their two factor secret, but ideally just this once and then never again.
 	ucr := &types.UserCreationResponse{
 		CreatedUserID:   user.ID,
@@ -317,9 +336,51 @@ func (s *service) ReadHandler(res http.ResponseWriter, req *http.Request) {
 	s.encoderDecoder.RespondWithData(ctx, res, x)
 }
 
-var errSecretAlreadyVerified = errors.New("secret already verified")
+var (
+	errSecretAlreadyVerified     = errors.New("secret already verified")
+	errInvalidPasswordResetToken = errors.New("invalid password reset token")
+	errPasswordTooWeak           = errors.New("password too weak")
+	errInvalidRecoveryAttempt    = errors.New("invalid TOTP recovery attempt")
+)
+
+// hashSecretValue hashes a single-use secret like a password reset token or TOTP recovery code for storage. These
+// values are randomly generated and only valid briefly or once, so a fast hash is sufficient.
+func hashSecretValue(x string) string {
+	sum := sha256.Sum256([]byte(strings.ToUpper(strings.TrimSpace(x))))
+	return hex.EncodeToString(sum[:])
+}
 
-func (s *service) VerifyUserTwoFactorSecret(ctx context.Context, input *types.TOTPSecretVerificationInput) error {
+// generateTOTPRecoveryCodes issues a new set of TOTP recovery codes for a user, replacing any they had before.
+func (s *service) generateTOTPRecoveryCodes(ctx context.Context, userID string) (*types.TOTPRecoveryCodesResponse, error) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger.WithValue(keys.UserIDKey, userID)
+
+	x := &types.TOTPRecoveryCodesResponse{RecoveryCodes: []string{}}
+	inputs := []*types.TOTPRecoveryCodeDatabaseCreationInput{}
+
+	for i := 0; i < totpRecoveryCodeCount; i++ {
+		code, err := s.secretGenerator.GenerateBase32EncodedString(ctx, totpRecoveryCodeSize)
+		if err != nil {
+			return nil, observability.PrepareError(err, logger, span, "generating TOTP recovery code")
+		}
+
+		x.RecoveryCodes = append(x.RecoveryCodes, code)
+		inputs = append(inputs, &types.TOTPRecoveryCodeDatabaseCreationInput{
+			ID:         ksuid.New().String(),
+			HashedCode: hashSecretValue(code),
+		})
+	}
+
+	if err := s.recoveryCodeManager.CreateTOTPRecoveryCodes(ctx, userID, inputs); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "saving TOTP recovery codes")
+	}
+
+	return x, nil
+}
+
+func (s *service) VerifyUserTwoFactorSecret(ctx context.Context, input *types.TOTPSecretVerificationInput) (*types.TOTPRecoveryCodesResponse, error) {
 	ctx, span := s.tracer.StartSpan(ctx)
 	defer span.End()
 
@@ -327,7 +388,7 @@ func (s *service) VerifyUserTwoFactorSecret(ctx context.Context, input *types.TO
 
 	user, fetchUserErr := s.userDataManager.GetUserWithUnverifiedTwoFactorSecret(ctx, input.UserID)
 	if fetchUserErr != nil {
-		return observability.PrepareError(fetchUserErr, logger, span, "fetching user with unverified two factor secret")
+		return nil, observability.PrepareError(fetchUserErr, logger, span, "fetching user with unverified two factor secret")
 	}
 
 	tracing.AttachUserIDToSpan(span, user.ID)
@@ -336,23 +397,28 @@ func (s *service) VerifyUserTwoFactorSecret(ctx context.Context, input *types.TO
 	if user.TwoFactorSecretVerifiedOn != nil {
 		// I suppose if this happens too many times, we might want to keep track of that
 		logger.Debug("two factor secret already verified")
-		return errSecretAlreadyVerified
+		return nil, errSecretAlreadyVerified
 	}
 
 	totpValid := totp.Validate(input.TOTPToken, user.TwoFactorSecret)
 	if !totpValid {
-		return authentication.ErrInvalidTOTPToken
+		return nil, authentication.ErrInvalidTOTPToken
 	}
 
 	if updateUserErr := s.userDataManager.MarkUserTwoFactorSecretAsVerified(ctx, user.ID); updateUserErr != nil {
-		return observability.PrepareError(updateUserErr, logger, span, "marking 2FA secret as validated")
+		return nil, observability.PrepareError(updateUserErr, logger, span, "marking 2FA secret as validated")
 	}
 
-	return nil
+	recoveryCodes, err := s.generateTOTPRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "generating TOTP recovery codes")
+	}
+
+	return recoveryCodes, nil
 }
 
-// TOTPSecretVerificationHandler accepts a TOTP token as input and returns 200 if the TOTP token
-// is validated by the user's TOTP secret.
+// TOTPSecretVerificationHandler accepts a TOTP token as input and returns 202 if the TOTP token
+// is validated by the user's TOTP secret, along with the user's new set of TOTP recovery codes.
 func (s *service) TOTPSecretVerificationHandler(res http.ResponseWriter, req *http.Request) {
 	ctx, span := s.tracer.StartSpan(req.Context())
 	defer span.End()
@@ -376,7 +442,8 @@ func (s *service) TOTPSecretVerificationHandler(res http.ResponseWriter, req *ht
 
 	logger = logger.WithValue(keys.UserIDKey, input.UserID)
 
-	if twoFactorSecretVerificationError := s.VerifyUserTwoFactorSecret(ctx, input); twoFactorSecretVerificationError != nil {
+	recoveryCodes, twoFactorSecretVerificationError := s.VerifyUserTwoFactorSecret(ctx, input)
+	if twoFactorSecretVerificationError != nil {
 		switch {
 		case errors.Is(twoFactorSecretVerificationError, authentication.ErrInvalidTOTPToken):
 			s.encoderDecoder.EncodeInvalidInputResponse(ctx, res)
@@ -391,7 +458,7 @@ func (s *service) TOTPSecretVerificationHandler(res http.ResponseWriter, req *ht
 		}
 	}
 
-	res.WriteHeader(http.StatusAccepted)
+	s.encoderDecoder.EncodeResponseWithStatus(ctx, res, recoveryCodes, http.StatusAccepted)
 }
 
 // NewTOTPSecretHandler fetches a user, and issues them a new TOTP secret, after validating
@@ -461,6 +528,15 @@ func (s *service) NewTOTPSecretHandler(res http.ResponseWriter, req *http.Reques
 		return
 	}
 
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.UserTwoFactorSecretChangeEvent,
+		ActorUserID:  user.ID,
+		ResourceType: types.UserResourceType,
+		ResourceID:   user.ID,
+	})
+
+	s.sendSecurityAlert(ctx, logger, user, types.UserTwoFactorSecretChangeEvent)
+
 	// let the requester know we're all good.
 	result := &types.TOTPSecretRefreshResponse{
 		TwoFactorSecret: user.TwoFactorSecret,
@@ -541,10 +617,42 @@ func (s *service) UpdatePasswordHandler(res http.ResponseWriter, req *http.Reque
 		return
 	}
 
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.UserPasswordChangeEvent,
+		ActorUserID:  user.ID,
+		ResourceType: types.UserResourceType,
+		ResourceID:   user.ID,
+	})
+
+	s.sendSecurityAlert(ctx, logger, user, types.UserPasswordChangeEvent)
+
 	// we're all good, log the user out
 	http.SetCookie(res, &http.Cookie{MaxAge: -1})
 }
 
+// sendSecurityAlert lets a user know that something sensitive about their user changed. Failing to do so
+// shouldn't fail the change itself, so errors are only logged.
+func (s *service) sendSecurityAlert(ctx context.Context, logger logging.Logger, user *types.User, eventType string) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if user.EmailAddress == "" {
+		return
+	}
+
+	logger = logger.WithValue(keys.AuditLogEntryEventTypeKey, eventType)
+
+	msg, err := s.emailRenderer.BuildSecurityAlertEmail(user.EmailAddress, user.Username, eventType)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "rendering security alert email")
+		return
+	}
+
+	if err = s.emailer.SendEmail(ctx, msg); err != nil {
+		observability.AcknowledgeError(err, logger, span, "sending security alert email")
+	}
+}
+
 func stringPointer(storageProviderPath string) *string {
 	return &storageProviderPath
 }
@@ -629,6 +737,302 @@ func (s *service) ArchiveHandler(res http.ResponseWriter, req *http.Request) {
 	// inform the relatives.
 	s.userCounter.Decrement(ctx)
 
+	// note who did the deed, if we can tell.
+	var actorUserID string
+	if sessionCtxData, sessionErr := s.sessionContextDataFetcher(req); sessionErr == nil {
+		actorUserID = sessionCtxData.Requester.UserID
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.UserArchiveEvent,
+		ActorUserID:  actorUserID,
+		ResourceType: types.UserResourceType,
+		ResourceID:   userID,
+	})
+
 	// we're all good.
 	res.WriteHeader(http.StatusNoContent)
 }
+
+// RequestPasswordReset issues a password reset token for a user and sends it to them. If no such user
+// exists, nothing is sent, and no error is returned, so that callers can't use this to discover usernames.
+func (s *service) RequestPasswordReset(ctx context.Context, input *types.PasswordResetTokenCreationRequestInput) error {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger.WithValue(keys.UsernameKey, input.Username)
+	tracing.AttachUsernameToSpan(span, input.Username)
+
+	user, err := s.userDataManager.GetUserByUsername(ctx, input.Username)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Debug("password reset requested for nonexistent user")
+		return nil
+	} else if err != nil {
+		return observability.PrepareError(err, logger, span, "fetching user")
+	}
+
+	logger = logger.WithValue(keys.UserIDKey, user.ID)
+	tracing.AttachUserIDToSpan(span, user.ID)
+
+	token, err := s.secretGenerator.GenerateBase32EncodedString(ctx, passwordResetTokenSize)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "generating password reset token")
+	}
+
+	dbInput := &types.PasswordResetTokenDatabaseCreationInput{
+		ID:            ksuid.New().String(),
+		HashedToken:   hashSecretValue(token),
+		BelongsToUser: user.ID,
+		ExpiresAt:     uint64(time.Now().Add(passwordResetTokenLifetime).Unix()),
+	}
+
+	if _, err = s.passwordResetTokenManager.CreatePasswordResetToken(ctx, dbInput); err != nil {
+		return observability.PrepareError(err, logger, span, "creating password reset token")
+	}
+
+	if user.EmailAddress == "" {
+		logger.Info("password reset requested for user without an email address")
+		return nil
+	}
+
+	msg, err := s.emailRenderer.BuildPasswordResetEmail(user, token)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "rendering password reset email")
+	}
+
+	if err = s.emailer.SendEmail(ctx, msg); err != nil {
+		return observability.PrepareError(err, logger, span, "sending password reset email")
+	}
+
+	return nil
+}
+
+// RequestPasswordResetHandler is our password reset request route.
+func (s *service) RequestPasswordResetHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// decode the request.
+	input := new(types.PasswordResetTokenCreationRequestInput)
+	if err := s.encoderDecoder.DecodeRequest(ctx, req, input); err != nil {
+		observability.AcknowledgeError(err, logger, span, "decoding request body")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid request content", http.StatusBadRequest)
+		return
+	}
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		logger.WithValue(keys.ValidationErrorKey, err).Debug("provided input was invalid")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.RequestPasswordReset(ctx, input); err != nil {
+		observability.AcknowledgeError(err, logger, span, "requesting password reset")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	res.WriteHeader(http.StatusAccepted)
+}
+
+// RedeemPasswordResetToken sets a new password for the user a valid password reset token belongs to.
+func (s *service) RedeemPasswordResetToken(ctx context.Context, input *types.PasswordResetTokenRedemptionRequestInput) error {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger
+
+	token, err := s.passwordResetTokenManager.GetPasswordResetTokenByToken(ctx, hashSecretValue(input.Token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return errInvalidPasswordResetToken
+	} else if err != nil {
+		return observability.PrepareError(err, logger, span, "fetching password reset token")
+	}
+
+	if token.IsExpired(uint64(time.Now().Unix())) {
+		return errInvalidPasswordResetToken
+	}
+
+	logger = logger.WithValue(keys.PasswordResetTokenIDKey, token.ID).WithValue(keys.UserIDKey, token.BelongsToUser)
+	tracing.AttachUserIDToSpan(span, token.BelongsToUser)
+
+	// ensure the password isn't garbage-tier
+	if err = passwordvalidator.Validate(input.NewPassword, minimumPasswordEntropy); err != nil {
+		logger.WithValue("password_validation_error", err).Debug("invalid password provided")
+		return errPasswordTooWeak
+	}
+
+	newPasswordHash, err := s.authenticator.HashPassword(ctx, input.NewPassword)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "hashing password")
+	}
+
+	// redeem the token first, so that it can't be used twice.
+	if err = s.passwordResetTokenManager.RedeemPasswordResetToken(ctx, token.ID); errors.Is(err, sql.ErrNoRows) {
+		return errInvalidPasswordResetToken
+	} else if err != nil {
+		return observability.PrepareError(err, logger, span, "redeeming password reset token")
+	}
+
+	if err = s.userDataManager.UpdateUserPassword(ctx, token.BelongsToUser, newPasswordHash); err != nil {
+		return observability.PrepareError(err, logger, span, "updating user password")
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.UserPasswordResetEvent,
+		ActorUserID:  token.BelongsToUser,
+		ResourceType: types.UserResourceType,
+		ResourceID:   token.BelongsToUser,
+	})
+
+	user, err := s.userDataManager.GetUser(ctx, token.BelongsToUser)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching user to alert about password reset")
+		return nil
+	}
+
+	s.sendSecurityAlert(ctx, logger, user, types.UserPasswordResetEvent)
+
+	return nil
+}
+
+// RedeemPasswordResetHandler is our password reset token redemption route.
+func (s *service) RedeemPasswordResetHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// decode the request.
+	input := new(types.PasswordResetTokenRedemptionRequestInput)
+	if err := s.encoderDecoder.DecodeRequest(ctx, req, input); err != nil {
+		observability.AcknowledgeError(err, logger, span, "decoding request body")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid request content", http.StatusBadRequest)
+		return
+	}
+
+	if err := input.ValidateWithContext(ctx, s.authSettings.MinimumPasswordLength); err != nil {
+		logger.WithValue(keys.ValidationErrorKey, err).Debug("provided input was invalid")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.RedeemPasswordResetToken(ctx, input); err != nil {
+		switch {
+		case errors.Is(err, errInvalidPasswordResetToken):
+			s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid or expired password reset token", http.StatusBadRequest)
+		case errors.Is(err, errPasswordTooWeak):
+			s.encoderDecoder.EncodeErrorResponse(ctx, res, "new password is too weak!", http.StatusBadRequest)
+		default:
+			observability.AcknowledgeError(err, logger, span, "redeeming password reset token")
+			s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		}
+
+		return
+	}
+
+	res.WriteHeader(http.StatusAccepted)
+}
+
+// RecoverTwoFactorSecret redeems one of a user's TOTP recovery codes, and issues them a new, unverified
+// two factor secret in place of the one they lost.
+func (s *service) RecoverTwoFactorSecret(ctx context.Context, input *types.TOTPRecoveryInput) (*types.TOTPRecoveryResponse, error) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := s.logger.WithValue(keys.UsernameKey, input.Username)
+	tracing.AttachUsernameToSpan(span, input.Username)
+
+	user, err := s.userDataManager.GetUserByUsername(ctx, input.Username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errInvalidRecoveryAttempt
+	} else if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "fetching user")
+	}
+
+	logger = logger.WithValue(keys.UserIDKey, user.ID)
+	tracing.AttachUserIDToSpan(span, user.ID)
+
+	if user.IsBanned() {
+		return nil, errInvalidRecoveryAttempt
+	}
+
+	// the user doesn't have a TOTP code to give us, but ValidateLogin still reports whether the password matched.
+	if passwordMatches, _ := s.authenticator.ValidateLogin(ctx, user.HashedPassword, input.Password, user.TwoFactorSecret, ""); !passwordMatches {
+		return nil, errInvalidRecoveryAttempt
+	}
+
+	if err = s.recoveryCodeManager.RedeemTOTPRecoveryCode(ctx, user.ID, hashSecretValue(input.RecoveryCode)); errors.Is(err, sql.ErrNoRows) {
+		return nil, errInvalidRecoveryAttempt
+	} else if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "redeeming TOTP recovery code")
+	}
+
+	tfs, err := s.secretGenerator.GenerateBase32EncodedString(ctx, totpSecretSize)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "generating 2FA secret")
+	}
+
+	user.TwoFactorSecret = tfs
+	user.TwoFactorSecretVerifiedOn = nil
+
+	if err = s.userDataManager.UpdateUser(ctx, user); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "updating 2FA secret")
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:    types.UserTwoFactorSecretRecoveryEvent,
+		ActorUserID:  user.ID,
+		ResourceType: types.UserResourceType,
+		ResourceID:   user.ID,
+	})
+
+	s.sendSecurityAlert(ctx, logger, user, types.UserTwoFactorSecretRecoveryEvent)
+
+	x := &types.TOTPRecoveryResponse{
+		UserID:          user.ID,
+		TwoFactorSecret: user.TwoFactorSecret,
+		TwoFactorQRCode: s.buildQRCode(ctx, user.Username, user.TwoFactorSecret),
+	}
+
+	return x, nil
+}
+
+// TOTPRecoveryHandler lets a user who has lost their 2FA device trade a recovery code for a new secret.
+func (s *service) TOTPRecoveryHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// decode the request.
+	input := new(types.TOTPRecoveryInput)
+	if err := s.encoderDecoder.DecodeRequest(ctx, req, input); err != nil {
+		observability.AcknowledgeError(err, logger, span, "decoding request body")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid request content", http.StatusBadRequest)
+		return
+	}
+
+	if err := input.ValidateWithContext(ctx, s.authSettings.MinimumUsernameLength, s.authSettings.MinimumPasswordLength); err != nil {
+		logger.WithValue(keys.ValidationErrorKey, err).Debug("provided input was invalid")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := s.RecoverTwoFactorSecret(ctx, input)
+	if errors.Is(err, errInvalidRecoveryAttempt) {
+		s.encoderDecoder.EncodeUnauthorizedResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "recovering two factor secret")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	s.encoderDecoder.EncodeResponseWithStatus(ctx, res, result, http.StatusAccepted)
+}
@@ -4,11 +4,13 @@
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	random "gitlab.com/verygoodsoftwarenotvirus/todo/internal/random/mock"
 
@@ -19,6 +21,8 @@
 	"github.com/stretchr/testify/require"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
+	mockemail "gitlab.com/verygoodsoftwarenotvirus/todo/internal/email/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	mockencoding "gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
@@ -27,6 +31,7 @@
 	mockuploads "gitlab.com/verygoodsoftwarenotvirus/todo/internal/uploads/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
 	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
 )
 
@@ -878,11 +883,24 @@ func TestService_TOTPSecretVerificationHandler(T *testing.T) {
 		).Return(nil)
 		helper.service.userDataManager = mockDB
 
+		recoveryCodeManager := &mocktypes.TOTPRecoveryCodeDataManager{}
+		recoveryCodeManager.On(
+			"CreateTOTPRecoveryCodes",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			mock.IsType([]*types.TOTPRecoveryCodeDatabaseCreationInput{}),
+		).Return(nil)
+		helper.service.recoveryCodeManager = recoveryCodeManager
+
 		helper.service.TOTPSecretVerificationHandler(helper.res, helper.req)
 
 		assert.Equal(t, http.StatusAccepted, helper.res.Code)
 
-		mock.AssertExpectationsForObjects(t, mockDB)
+		var actual *types.TOTPRecoveryCodesResponse
+		require.NoError(t, json.NewDecoder(helper.res.Body).Decode(&actual))
+		assert.Len(t, actual.RecoveryCodes, totpRecoveryCodeCount)
+
+		mock.AssertExpectationsForObjects(t, mockDB, recoveryCodeManager)
 	})
 
 	T.Run("without input attached to request", func(t *testing.T) {
@@ -1100,6 +1118,50 @@ func TestService_TOTPSecretVerificationHandler(T *testing.T) {
 
 		mock.AssertExpectationsForObjects(t, mockDB)
 	})
+	T.Run("with error saving recovery codes", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		exampleInput := fakes.BuildFakeTOTPSecretVerificationInputForUser(helper.exampleUser)
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.exampleUser.TwoFactorSecretVerifiedOn = nil
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"GetUserWithUnverifiedTwoFactorSecret",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		mockDB.UserDataManager.On(
+			"MarkUserTwoFactorSecretAsVerified",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(nil)
+		helper.service.userDataManager = mockDB
+
+		recoveryCodeManager := &mocktypes.TOTPRecoveryCodeDataManager{}
+		recoveryCodeManager.On(
+			"CreateTOTPRecoveryCodes",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			mock.IsType([]*types.TOTPRecoveryCodeDatabaseCreationInput{}),
+		).Return(errors.New("blah"))
+		helper.service.recoveryCodeManager = recoveryCodeManager
+
+		helper.service.TOTPSecretVerificationHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB, recoveryCodeManager)
+	})
 }
 
 func TestService_NewTOTPSecretHandler(T *testing.T) {
@@ -1960,3 +2022,895 @@ func TestService_ArchiveHandler(T *testing.T) {
 		mock.AssertExpectationsForObjects(t, mockDB, encoderDecoder)
 	})
 }
+
+func TestService_RequestPasswordResetHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+		exampleInput.Username = helper.exampleUser.Username
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = mockDB
+
+		exampleToken := "BLAHBLAHBLAH"
+		sg := &random.Generator{}
+		sg.On(
+			"GenerateBase32EncodedString",
+			testutils.ContextMatcher,
+			passwordResetTokenSize,
+		).Return(exampleToken, nil)
+		helper.service.secretGenerator = sg
+
+		passwordResetTokenManager := &mocktypes.PasswordResetTokenDataManager{}
+		passwordResetTokenManager.On(
+			"CreatePasswordResetToken",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.PasswordResetTokenDatabaseCreationInput) bool {
+				return input.HashedToken == hashSecretValue(exampleToken) && input.BelongsToUser == helper.exampleUser.ID
+			}),
+		).Return(fakes.BuildFakePasswordResetToken(), nil)
+		helper.service.passwordResetTokenManager = passwordResetTokenManager
+
+		emailer := &mockemail.Emailer{}
+		emailer.On(
+			"SendEmail",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(details *email.OutboundMessageDetails) bool {
+				return details.ToAddress == helper.exampleUser.EmailAddress && strings.Contains(details.HTMLContent, exampleToken)
+			}),
+		).Return(nil)
+		helper.service.emailer = emailer
+
+		helper.service.RequestPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB, sg, passwordResetTokenManager, emailer)
+	})
+
+	T.Run("without input attached to request", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(nil))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.RequestPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with invalid input attached to request", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, &types.PasswordResetTokenCreationRequestInput{})
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.RequestPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with nonexistent user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			exampleInput.Username,
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		helper.service.userDataManager = mockDB
+
+		helper.service.RequestPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB)
+	})
+
+	T.Run("with error creating password reset token", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+		exampleInput.Username = helper.exampleUser.Username
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = mockDB
+
+		passwordResetTokenManager := &mocktypes.PasswordResetTokenDataManager{}
+		passwordResetTokenManager.On(
+			"CreatePasswordResetToken",
+			testutils.ContextMatcher,
+			mock.IsType(&types.PasswordResetTokenDatabaseCreationInput{}),
+		).Return((*types.PasswordResetToken)(nil), errors.New("blah"))
+		helper.service.passwordResetTokenManager = passwordResetTokenManager
+
+		helper.service.RequestPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB, passwordResetTokenManager)
+	})
+
+	T.Run("with user without email address", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.exampleUser.EmailAddress = ""
+
+		exampleInput := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+		exampleInput.Username = helper.exampleUser.Username
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = mockDB
+
+		passwordResetTokenManager := &mocktypes.PasswordResetTokenDataManager{}
+		passwordResetTokenManager.On(
+			"CreatePasswordResetToken",
+			testutils.ContextMatcher,
+			mock.IsType(&types.PasswordResetTokenDatabaseCreationInput{}),
+		).Return(fakes.BuildFakePasswordResetToken(), nil)
+		helper.service.passwordResetTokenManager = passwordResetTokenManager
+
+		emailer := &mockemail.Emailer{}
+		helper.service.emailer = emailer
+
+		helper.service.RequestPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB, passwordResetTokenManager, emailer)
+	})
+
+	T.Run("with error sending password reset token", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+		exampleInput.Username = helper.exampleUser.Username
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = mockDB
+
+		passwordResetTokenManager := &mocktypes.PasswordResetTokenDataManager{}
+		passwordResetTokenManager.On(
+			"CreatePasswordResetToken",
+			testutils.ContextMatcher,
+			mock.IsType(&types.PasswordResetTokenDatabaseCreationInput{}),
+		).Return(fakes.BuildFakePasswordResetToken(), nil)
+		helper.service.passwordResetTokenManager = passwordResetTokenManager
+
+		emailer := &mockemail.Emailer{}
+		emailer.On(
+			"SendEmail",
+			testutils.ContextMatcher,
+			mock.IsType(&email.OutboundMessageDetails{}),
+		).Return(errors.New("blah"))
+		helper.service.emailer = emailer
+
+		helper.service.RequestPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB, passwordResetTokenManager, emailer)
+	})
+}
+
+func TestService_RedeemPasswordResetHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		exampleToken := fakes.BuildFakePasswordResetToken()
+		exampleToken.BelongsToUser = helper.exampleUser.ID
+		exampleToken.ExpiresAt = uint64(time.Now().Add(time.Hour).Unix())
+
+		passwordResetTokenManager := &mocktypes.PasswordResetTokenDataManager{}
+		passwordResetTokenManager.On(
+			"GetPasswordResetTokenByToken",
+			testutils.ContextMatcher,
+			hashSecretValue(exampleInput.Token),
+		).Return(exampleToken, nil)
+		passwordResetTokenManager.On(
+			"RedeemPasswordResetToken",
+			testutils.ContextMatcher,
+			exampleToken.ID,
+		).Return(nil)
+		helper.service.passwordResetTokenManager = passwordResetTokenManager
+
+		auth := &mock2.Authenticator{}
+		auth.On(
+			"HashPassword",
+			testutils.ContextMatcher,
+			exampleInput.NewPassword,
+		).Return(helper.exampleUser.HashedPassword, nil)
+		helper.service.authenticator = auth
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"UpdateUserPassword",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.exampleUser.HashedPassword,
+		).Return(nil)
+		mockDB.UserDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = mockDB
+
+		emailer := &mockemail.Emailer{}
+		emailer.On(
+			"SendEmail",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(details *email.OutboundMessageDetails) bool {
+				return details.ToAddress == helper.exampleUser.EmailAddress
+			}),
+		).Return(nil)
+		helper.service.emailer = emailer
+
+		helper.service.RedeemPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, passwordResetTokenManager, auth, mockDB, emailer)
+	})
+
+	T.Run("with error fetching user to alert", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		exampleToken := fakes.BuildFakePasswordResetToken()
+		exampleToken.BelongsToUser = helper.exampleUser.ID
+		exampleToken.ExpiresAt = uint64(time.Now().Add(time.Hour).Unix())
+
+		passwordResetTokenManager := &mocktypes.PasswordResetTokenDataManager{}
+		passwordResetTokenManager.On(
+			"GetPasswordResetTokenByToken",
+			testutils.ContextMatcher,
+			hashSecretValue(exampleInput.Token),
+		).Return(exampleToken, nil)
+		passwordResetTokenManager.On(
+			"RedeemPasswordResetToken",
+			testutils.ContextMatcher,
+			exampleToken.ID,
+		).Return(nil)
+		helper.service.passwordResetTokenManager = passwordResetTokenManager
+
+		auth := &mock2.Authenticator{}
+		auth.On(
+			"HashPassword",
+			testutils.ContextMatcher,
+			exampleInput.NewPassword,
+		).Return(helper.exampleUser.HashedPassword, nil)
+		helper.service.authenticator = auth
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"UpdateUserPassword",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.exampleUser.HashedPassword,
+		).Return(nil)
+		mockDB.UserDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+		).Return((*types.User)(nil), errors.New("blah"))
+		helper.service.userDataManager = mockDB
+
+		emailer := &mockemail.Emailer{}
+		helper.service.emailer = emailer
+
+		helper.service.RedeemPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, passwordResetTokenManager, auth, mockDB, emailer)
+	})
+
+	T.Run("without input attached to request", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(nil))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.RedeemPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with invalid input attached to request", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, &types.PasswordResetTokenRedemptionRequestInput{})
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.RedeemPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with nonexistent token", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		passwordResetTokenManager := &mocktypes.PasswordResetTokenDataManager{}
+		passwordResetTokenManager.On(
+			"GetPasswordResetTokenByToken",
+			testutils.ContextMatcher,
+			hashSecretValue(exampleInput.Token),
+		).Return((*types.PasswordResetToken)(nil), sql.ErrNoRows)
+		helper.service.passwordResetTokenManager = passwordResetTokenManager
+
+		helper.service.RedeemPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, passwordResetTokenManager)
+	})
+
+	T.Run("with expired token", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		exampleToken := fakes.BuildFakePasswordResetToken()
+		exampleToken.ExpiresAt = uint64(time.Now().Add(-time.Hour).Unix())
+
+		passwordResetTokenManager := &mocktypes.PasswordResetTokenDataManager{}
+		passwordResetTokenManager.On(
+			"GetPasswordResetTokenByToken",
+			testutils.ContextMatcher,
+			hashSecretValue(exampleInput.Token),
+		).Return(exampleToken, nil)
+		helper.service.passwordResetTokenManager = passwordResetTokenManager
+
+		helper.service.RedeemPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, passwordResetTokenManager)
+	})
+
+	T.Run("with weak password", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		exampleInput.NewPassword = "aaaaaaaa"
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		exampleToken := fakes.BuildFakePasswordResetToken()
+		exampleToken.ExpiresAt = uint64(time.Now().Add(time.Hour).Unix())
+
+		passwordResetTokenManager := &mocktypes.PasswordResetTokenDataManager{}
+		passwordResetTokenManager.On(
+			"GetPasswordResetTokenByToken",
+			testutils.ContextMatcher,
+			hashSecretValue(exampleInput.Token),
+		).Return(exampleToken, nil)
+		helper.service.passwordResetTokenManager = passwordResetTokenManager
+
+		helper.service.RedeemPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, passwordResetTokenManager)
+	})
+
+	T.Run("with error updating password", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		exampleToken := fakes.BuildFakePasswordResetToken()
+		exampleToken.BelongsToUser = helper.exampleUser.ID
+		exampleToken.ExpiresAt = uint64(time.Now().Add(time.Hour).Unix())
+
+		passwordResetTokenManager := &mocktypes.PasswordResetTokenDataManager{}
+		passwordResetTokenManager.On(
+			"GetPasswordResetTokenByToken",
+			testutils.ContextMatcher,
+			hashSecretValue(exampleInput.Token),
+		).Return(exampleToken, nil)
+		passwordResetTokenManager.On(
+			"RedeemPasswordResetToken",
+			testutils.ContextMatcher,
+			exampleToken.ID,
+		).Return(nil)
+		helper.service.passwordResetTokenManager = passwordResetTokenManager
+
+		auth := &mock2.Authenticator{}
+		auth.On(
+			"HashPassword",
+			testutils.ContextMatcher,
+			exampleInput.NewPassword,
+		).Return(helper.exampleUser.HashedPassword, nil)
+		helper.service.authenticator = auth
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"UpdateUserPassword",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			helper.exampleUser.HashedPassword,
+		).Return(errors.New("blah"))
+		helper.service.userDataManager = mockDB
+
+		helper.service.RedeemPasswordResetHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, passwordResetTokenManager, auth, mockDB)
+	})
+}
+
+func TestService_TOTPRecoveryHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakeTOTPRecoveryInput()
+		exampleInput.Username = helper.exampleUser.Username
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		mockDB.UserDataManager.On(
+			"UpdateUser",
+			testutils.ContextMatcher,
+			mock.IsType(&types.User{}),
+		).Return(nil)
+		helper.service.userDataManager = mockDB
+
+		auth := &mock2.Authenticator{}
+		auth.On(
+			"ValidateLogin",
+			testutils.ContextMatcher,
+			helper.exampleUser.HashedPassword,
+			exampleInput.Password,
+			helper.exampleUser.TwoFactorSecret,
+			"",
+		).Return(true, errors.New("blah"))
+		helper.service.authenticator = auth
+
+		recoveryCodeManager := &mocktypes.TOTPRecoveryCodeDataManager{}
+		recoveryCodeManager.On(
+			"RedeemTOTPRecoveryCode",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			hashSecretValue(exampleInput.RecoveryCode),
+		).Return(nil)
+		helper.service.recoveryCodeManager = recoveryCodeManager
+
+		helper.service.TOTPRecoveryHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		var actual *types.TOTPRecoveryResponse
+		require.NoError(t, json.NewDecoder(helper.res.Body).Decode(&actual))
+		assert.Equal(t, helper.exampleUser.ID, actual.UserID)
+		assert.NotEmpty(t, actual.TwoFactorSecret)
+
+		mock.AssertExpectationsForObjects(t, mockDB, auth, recoveryCodeManager)
+	})
+
+	T.Run("without input attached to request", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(nil))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.TOTPRecoveryHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with invalid input attached to request", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, &types.TOTPRecoveryInput{})
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.TOTPRecoveryHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with nonexistent user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakeTOTPRecoveryInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			exampleInput.Username,
+		).Return((*types.User)(nil), sql.ErrNoRows)
+		helper.service.userDataManager = mockDB
+
+		helper.service.TOTPRecoveryHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB)
+	})
+
+	T.Run("with invalid password", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakeTOTPRecoveryInput()
+		exampleInput.Username = helper.exampleUser.Username
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = mockDB
+
+		auth := &mock2.Authenticator{}
+		auth.On(
+			"ValidateLogin",
+			testutils.ContextMatcher,
+			helper.exampleUser.HashedPassword,
+			exampleInput.Password,
+			helper.exampleUser.TwoFactorSecret,
+			"",
+		).Return(false, nil)
+		helper.service.authenticator = auth
+
+		helper.service.TOTPRecoveryHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB, auth)
+	})
+
+	T.Run("with invalid recovery code", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakeTOTPRecoveryInput()
+		exampleInput.Username = helper.exampleUser.Username
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		helper.service.userDataManager = mockDB
+
+		auth := &mock2.Authenticator{}
+		auth.On(
+			"ValidateLogin",
+			testutils.ContextMatcher,
+			helper.exampleUser.HashedPassword,
+			exampleInput.Password,
+			helper.exampleUser.TwoFactorSecret,
+			"",
+		).Return(true, nil)
+		helper.service.authenticator = auth
+
+		recoveryCodeManager := &mocktypes.TOTPRecoveryCodeDataManager{}
+		recoveryCodeManager.On(
+			"RedeemTOTPRecoveryCode",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			hashSecretValue(exampleInput.RecoveryCode),
+		).Return(sql.ErrNoRows)
+		helper.service.recoveryCodeManager = recoveryCodeManager
+
+		helper.service.TOTPRecoveryHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB, auth, recoveryCodeManager)
+	})
+
+	T.Run("with error updating user", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleInput := fakes.BuildFakeTOTPRecoveryInput()
+		exampleInput.Username = helper.exampleUser.Username
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		mockDB := database.BuildMockDatabase()
+		mockDB.UserDataManager.On(
+			"GetUserByUsername",
+			testutils.ContextMatcher,
+			helper.exampleUser.Username,
+		).Return(helper.exampleUser, nil)
+		mockDB.UserDataManager.On(
+			"UpdateUser",
+			testutils.ContextMatcher,
+			mock.IsType(&types.User{}),
+		).Return(errors.New("blah"))
+		helper.service.userDataManager = mockDB
+
+		auth := &mock2.Authenticator{}
+		auth.On(
+			"ValidateLogin",
+			testutils.ContextMatcher,
+			helper.exampleUser.HashedPassword,
+			exampleInput.Password,
+			helper.exampleUser.TwoFactorSecret,
+			"",
+		).Return(true, nil)
+		helper.service.authenticator = auth
+
+		recoveryCodeManager := &mocktypes.TOTPRecoveryCodeDataManager{}
+		recoveryCodeManager.On(
+			"RedeemTOTPRecoveryCode",
+			testutils.ContextMatcher,
+			helper.exampleUser.ID,
+			hashSecretValue(exampleInput.RecoveryCode),
+		).Return(nil)
+		helper.service.recoveryCodeManager = recoveryCodeManager
+
+		helper.service.TOTPRecoveryHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, mockDB, auth, recoveryCodeManager)
+	})
+}
+
+func TestService_sendSecurityAlert(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		emailer := &mockemail.Emailer{}
+		emailer.On(
+			"SendEmail",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(details *email.OutboundMessageDetails) bool {
+				return details.ToAddress == helper.exampleUser.EmailAddress
+			}),
+		).Return(nil)
+		helper.service.emailer = emailer
+
+		helper.service.sendSecurityAlert(helper.ctx, logging.NewNoopLogger(), helper.exampleUser, types.UserPasswordChangeEvent)
+
+		mock.AssertExpectationsForObjects(t, emailer)
+	})
+
+	T.Run("without email address", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.exampleUser.EmailAddress = ""
+
+		emailer := &mockemail.Emailer{}
+		helper.service.emailer = emailer
+
+		helper.service.sendSecurityAlert(helper.ctx, logging.NewNoopLogger(), helper.exampleUser, types.UserPasswordChangeEvent)
+
+		mock.AssertExpectationsForObjects(t, emailer)
+	})
+
+	T.Run("with unknown event type", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		emailer := &mockemail.Emailer{}
+		helper.service.emailer = emailer
+
+		helper.service.sendSecurityAlert(helper.ctx, logging.NewNoopLogger(), helper.exampleUser, types.ItemCreationEvent)
+
+		mock.AssertExpectationsForObjects(t, emailer)
+	})
+
+	T.Run("with error sending email", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		emailer := &mockemail.Emailer{}
+		emailer.On(
+			"SendEmail",
+			testutils.ContextMatcher,
+			mock.IsType(&email.OutboundMessageDetails{}),
+		).Return(errors.New("blah"))
+		helper.service.emailer = emailer
+
+		helper.service.sendSecurityAlert(helper.ctx, logging.NewNoopLogger(), helper.exampleUser, types.UserPasswordChangeEvent)
+
+		mock.AssertExpectationsForObjects(t, emailer)
+	})
+}
@@ -4,6 +4,7 @@
 	"net/http"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authentication"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics"
@@ -34,6 +35,11 @@
 	service struct {
 		userDataManager           types.UserDataManager
 		accountDataManager        types.AccountDataManager
+		auditLogEntryDataManager  types.AuditLogEntryDataManager
+		passwordResetTokenManager types.PasswordResetTokenDataManager
+		recoveryCodeManager       types.TOTPRecoveryCodeDataManager
+		emailer                   email.Emailer
+		emailRenderer             *email.Renderer
 		authSettings              *authservice.Config
 		authenticator             authentication.Authenticator
 		logger                    logging.Logger
@@ -54,6 +60,11 @@ func ProvideUsersService(
 	logger logging.Logger,
 	userDataManager types.UserDataManager,
 	accountDataManager types.AccountDataManager,
+	auditLogEntryDataManager types.AuditLogEntryDataManager,
+	passwordResetTokenManager types.PasswordResetTokenDataManager,
+	recoveryCodeManager types.TOTPRecoveryCodeDataManager,
+	emailer email.Emailer,
+	emailRenderer *email.Renderer,
 	authenticator authentication.Authenticator,
 	encoder encoding.ServerEncoderDecoder,
 	counterProvider metrics.UnitCounterProvider,
@@ -65,6 +76,11 @@ func ProvideUsersService(
 		logger:                    logging.EnsureLogger(logger).WithName(serviceName),
 		userDataManager:           userDataManager,
 		accountDataManager:        accountDataManager,
+		auditLogEntryDataManager:  auditLogEntryDataManager,
+		passwordResetTokenManager: passwordResetTokenManager,
+		recoveryCodeManager:       recoveryCodeManager,
+		emailer:                   emailer,
+		emailRenderer:             emailRenderer,
 		authenticator:             authenticator,
 		userIDFetcher:             routeParamManager.BuildRouteParamStringIDFetcher(UserIDURIParamKey),
 		sessionContextDataFetcher: authservice.FetchContextFromRequest,
@@ -8,8 +8,11 @@
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
+	mockemail "gitlab.com/verygoodsoftwarenotvirus/todo/internal/email/mock"
 	mockencoding "gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics"
@@ -17,6 +20,7 @@
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing/chi"
 	mockrouting "gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing/mock"
 	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/frontend"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/uploads/images"
 	mockuploads "gitlab.com/verygoodsoftwarenotvirus/todo/internal/uploads/mock"
 	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
@@ -35,11 +39,33 @@ func buildTestService(t *testing.T) *service {
 		testutils.ContextMatcher,
 	).Return(expectedUserCount, nil)
 
+	auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+	auditLogEntryDataManager.On(
+		"CreateAuditLogEntry",
+		testutils.ContextMatcher,
+		mock.MatchedBy(testutils.AuditLogEntryCreationInputMatcher),
+	).Return(nil).Maybe()
+
+	emailer := &mockemail.Emailer{}
+	emailer.On(
+		"SendEmail",
+		testutils.ContextMatcher,
+		mock.IsType(&email.OutboundMessageDetails{}),
+	).Return(nil).Maybe()
+
+	emailRenderer, err := email.ProvideRenderer(&email.Config{}, frontend.ProvideLocalizer())
+	require.NoError(t, err)
+
 	s := ProvideUsersService(
 		&authservice.Config{},
 		logging.NewNoopLogger(),
 		&mocktypes.UserDataManager{},
 		&mocktypes.AccountDataManager{},
+		auditLogEntryDataManager,
+		&mocktypes.PasswordResetTokenDataManager{},
+		&mocktypes.TOTPRecoveryCodeDataManager{},
+		emailer,
+		emailRenderer,
 		&mock2.Authenticator{},
 		mockencoding.NewMockEncoderDecoder(),
 		func(counterName, description string) metrics.UnitCounter {
@@ -72,6 +98,11 @@ func TestProvideUsersService(T *testing.T) {
 			logging.NewNoopLogger(),
 			&mocktypes.UserDataManager{},
 			&mocktypes.AccountDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
+			&mocktypes.PasswordResetTokenDataManager{},
+			&mocktypes.TOTPRecoveryCodeDataManager{},
+			&mockemail.Emailer{},
+			&email.Renderer{},
 			&mock2.Authenticator{},
 			mockencoding.NewMockEncoderDecoder(),
 			func(counterName, description string) metrics.UnitCounter {
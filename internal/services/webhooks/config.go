@@ -2,17 +2,21 @@
 
 import (
 	"context"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 // Config represents our database configuration.
 type Config struct {
-	_                    struct{}
-	PreWritesTopicName   string `json:"pre_writes_topic_name" mapstructure:"pre_writes_topic_name" toml:"pre_writes_topic_name,omitempty"`
-	PreArchivesTopicName string `json:"pre_archives_topic_name" mapstructure:"pre_archives_topic_name" toml:"pre_archives_topic_name,omitempty"`
-	Debug                bool   `json:"debug" mapstructure:"debug" toml:"debug,omitempty"`
-	Enabled              bool   `json:"enabled" mapstructure:"enabled" toml:"enabled,omitempty"`
+	_                        struct{}
+	PreWritesTopicName       string        `json:"pre_writes_topic_name" mapstructure:"pre_writes_topic_name" toml:"pre_writes_topic_name,omitempty"`
+	PreUpdatesTopicName      string        `json:"pre_updates_topic_name" mapstructure:"pre_updates_topic_name" toml:"pre_updates_topic_name,omitempty"`
+	PreArchivesTopicName     string        `json:"pre_archives_topic_name" mapstructure:"pre_archives_topic_name" toml:"pre_archives_topic_name,omitempty"`
+	DataChangesTopicName     string        `json:"data_changes_topic_name" mapstructure:"data_changes_topic_name" toml:"data_changes_topic_name,omitempty"`
+	SigningSecretGracePeriod time.Duration `json:"signing_secret_grace_period" mapstructure:"signing_secret_grace_period" toml:"signing_secret_grace_period,omitempty"`
+	Debug                    bool          `json:"debug" mapstructure:"debug" toml:"debug,omitempty"`
+	Enabled                  bool          `json:"enabled" mapstructure:"enabled" toml:"enabled,omitempty"`
 }
 
 var _ validation.ValidatableWithContext = (*Config)(nil)
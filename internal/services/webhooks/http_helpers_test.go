@@ -22,6 +22,7 @@ type webhooksServiceHTTPRoutesTestHelper struct {
 	exampleUser          *types.User
 	exampleAccount       *types.Account
 	exampleWebhook       *types.Webhook
+	exampleAttempt       *types.WebhookDeliveryAttempt
 	exampleCreationInput *types.WebhookCreationInput
 }
 
@@ -37,12 +38,18 @@ func newTestHelper(t *testing.T) *webhooksServiceHTTPRoutesTestHelper {
 	helper.exampleAccount.BelongsToUser = helper.exampleUser.ID
 	helper.exampleWebhook = fakes.BuildFakeWebhook()
 	helper.exampleWebhook.BelongsToAccount = helper.exampleAccount.ID
+	helper.exampleAttempt = fakes.BuildFakeWebhookDeliveryAttempt()
+	helper.exampleAttempt.BelongsToWebhook = helper.exampleWebhook.ID
 	helper.exampleCreationInput = fakes.BuildFakeWebhookCreationInputFromWebhook(helper.exampleWebhook)
 
 	helper.service.webhookIDFetcher = func(*http.Request) string {
 		return helper.exampleWebhook.ID
 	}
 
+	helper.service.deliveryAttemptIDFetcher = func(*http.Request) string {
+		return helper.exampleAttempt.ID
+	}
+
 	sessionCtxData := &types.SessionContextData{
 		Requester: types.RequesterInfo{
 			UserID:                helper.exampleUser.ID,
@@ -4,9 +4,11 @@
 	"database/sql"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/segmentio/ksuid"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/audit"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
@@ -16,6 +18,11 @@
 const (
 	// WebhookIDURIParamKey is a standard string that we'll use to refer to webhook IDs with.
 	WebhookIDURIParamKey = "webhookID"
+	// signingSecretSize is how many random bytes go into a webhook signing secret.
+	signingSecretSize = 32
+
+	// WebhookDeliveryAttemptIDURIParamKey is a standard string that we'll use to refer to webhook delivery attempt IDs with.
+	WebhookDeliveryAttemptIDURIParamKey = "webhookDeliveryAttemptID"
 )
 
 // CreateHandler is our webhook creation route.
@@ -55,11 +62,18 @@ func (s *service) CreateHandler(res http.ResponseWriter, req *http.Request) {
 	tracing.AttachWebhookIDToSpan(span, input.ID)
 	input.BelongsToAccount = sessionCtxData.ActiveAccountID
 
+	if input.SigningSecret, err = s.secretGenerator.GenerateBase64EncodedString(ctx, signingSecretSize); err != nil {
+		observability.AcknowledgeError(err, logger, span, "generating webhook signing secret")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
 	preWrite := &types.PreWriteMessage{
 		DataType:                types.WebhookDataType,
 		Webhook:                 input,
 		AttributableToUserID:    sessionCtxData.Requester.UserID,
 		AttributableToAccountID: sessionCtxData.ActiveAccountID,
+		RequestID:               audit.RequestIDFromContext(ctx),
 	}
 	if err = s.preWritesPublisher.Publish(ctx, preWrite); err != nil {
 		observability.AcknowledgeError(err, logger, span, "publishing webhook write message")
@@ -67,9 +81,13 @@ func (s *service) CreateHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	pwr := types.PreWriteResponse{ID: input.ID}
+	// the signing secret is only ever disclosed here, so the user must save it now.
+	resObj := &types.WebhookCreationResponse{
+		ID:            input.ID,
+		SigningSecret: input.SigningSecret,
+	}
 
-	s.encoderDecoder.EncodeResponseWithStatus(ctx, res, pwr, http.StatusCreated)
+	s.encoderDecoder.EncodeResponseWithStatus(ctx, res, resObj, http.StatusCreated)
 }
 
 // ListHandler is our list route.
@@ -153,6 +171,77 @@ func (s *service) ReadHandler(res http.ResponseWriter, req *http.Request) {
 	s.encoderDecoder.RespondWithData(ctx, res, webhook)
 }
 
+// UpdateHandler returns a handler that updates a webhook.
+func (s *service) UpdateHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	// check for parsed input attached to session context data.
+	input := new(types.WebhookUpdateInput)
+	if err = s.encoderDecoder.DecodeRequest(ctx, req, input); err != nil {
+		logger.Error(err, "error encountered decoding request body")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid request content", http.StatusBadRequest)
+		return
+	}
+
+	if err = input.ValidateWithContext(ctx); err != nil {
+		logger.Error(err, "provided input was invalid")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input.BelongsToAccount = sessionCtxData.ActiveAccountID
+
+	// determine relevant webhook ID.
+	webhookID := s.webhookIDFetcher(req)
+	tracing.AttachWebhookIDToSpan(span, webhookID)
+	logger = logger.WithValue(keys.WebhookIDKey, webhookID)
+
+	// fetch the webhook from the database.
+	webhook, err := s.webhookDataManager.GetWebhook(ctx, webhookID, sessionCtxData.ActiveAccountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving webhook for update")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// update the webhook.
+	changes := webhook.Update(input)
+
+	pum := &types.PreUpdateMessage{
+		DataType:                types.WebhookDataType,
+		Webhook:                 webhook,
+		Changes:                 changes,
+		AttributableToUserID:    sessionCtxData.Requester.UserID,
+		AttributableToAccountID: sessionCtxData.ActiveAccountID,
+		RequestID:               audit.RequestIDFromContext(ctx),
+	}
+	if err = s.preUpdatesPublisher.Publish(ctx, pum); err != nil {
+		observability.AcknowledgeError(err, logger, span, "publishing webhook update message")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// encode our response and peace.
+	s.encoderDecoder.RespondWithData(ctx, res, webhook)
+}
+
 // ArchiveHandler returns a handler that archives an webhook.
 func (s *service) ArchiveHandler(res http.ResponseWriter, req *http.Request) {
 	ctx, span := s.tracer.StartSpan(req.Context())
@@ -195,6 +284,7 @@ func (s *service) ArchiveHandler(res http.ResponseWriter, req *http.Request) {
 		RelevantID:              webhookID,
 		AttributableToUserID:    sessionCtxData.Requester.UserID,
 		AttributableToAccountID: sessionCtxData.ActiveAccountID,
+		RequestID:               audit.RequestIDFromContext(ctx),
 	}
 	if err = s.preArchivesPublisher.Publish(ctx, pam); err != nil {
 		observability.AcknowledgeError(err, logger, span, "publishing webhook archive message")
@@ -205,3 +295,182 @@ func (s *service) ArchiveHandler(res http.ResponseWriter, req *http.Request) {
 	// let everybody go home.
 	res.WriteHeader(http.StatusNoContent)
 }
+
+// RotateSecretHandler replaces a webhook's signing secret, keeping the old one valid for a grace period.
+func (s *service) RotateSecretHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	// determine relevant webhook ID.
+	webhookID := s.webhookIDFetcher(req)
+	tracing.AttachWebhookIDToSpan(span, webhookID)
+	logger = logger.WithValue(keys.WebhookIDKey, webhookID)
+
+	newSecret, err := s.secretGenerator.GenerateBase64EncodedString(ctx, signingSecretSize)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "generating webhook signing secret")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	expiresOn := uint64(time.Now().Add(s.signingSecretGracePeriod).Unix())
+
+	err = s.webhookDataManager.RotateWebhookSigningSecret(ctx, webhookID, sessionCtxData.ActiveAccountID, newSecret, expiresOn)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "rotating webhook signing secret")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	audit.Record(ctx, logger, s.auditLogEntryDataManager, &types.AuditLogEntryCreationInput{
+		EventType:        types.WebhookSecretRotationEvent,
+		ActorUserID:      sessionCtxData.Requester.UserID,
+		BelongsToAccount: sessionCtxData.ActiveAccountID,
+		ResourceType:     types.WebhookResourceType,
+		ResourceID:       webhookID,
+	})
+
+	resObj := &types.WebhookSecretRotationResponse{
+		SigningSecret:                  newSecret,
+		PreviousSigningSecretExpiresOn: expiresOn,
+	}
+
+	s.encoderDecoder.RespondWithData(ctx, res, resObj)
+}
+
+// ListDeliveryAttemptsHandler is our webhook delivery attempt list route.
+func (s *service) ListDeliveryAttemptsHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	filter := types.ExtractQueryFilter(req)
+	logger := filter.AttachToLogger(s.logger)
+
+	tracing.AttachRequestToSpan(span, req)
+	tracing.AttachFilterToSpan(span, filter.Page, filter.Limit, string(filter.SortBy))
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	// determine relevant webhook ID.
+	webhookID := s.webhookIDFetcher(req)
+	tracing.AttachWebhookIDToSpan(span, webhookID)
+	logger = logger.WithValue(keys.WebhookIDKey, webhookID)
+
+	// delivery attempts aren't owned by accounts directly, so make sure the webhook is.
+	exists, err := s.webhookDataManager.WebhookExists(ctx, webhookID, sessionCtxData.ActiveAccountID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		observability.AcknowledgeError(err, logger, span, "checking webhook existence")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	} else if !exists || errors.Is(err, sql.ErrNoRows) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	}
+
+	// find the delivery attempts.
+	attempts, err := s.webhookDataManager.GetWebhookDeliveryAttempts(ctx, webhookID, filter)
+	if errors.Is(err, sql.ErrNoRows) {
+		attempts = &types.WebhookDeliveryAttemptList{
+			Attempts: []*types.WebhookDeliveryAttempt{},
+		}
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching webhook delivery attempts")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// encode the response.
+	s.encoderDecoder.RespondWithData(ctx, res, attempts)
+}
+
+// RedeliverHandler queues a past webhook delivery attempt to be sent again.
+func (s *service) RedeliverHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	// determine relevant webhook ID.
+	webhookID := s.webhookIDFetcher(req)
+	tracing.AttachWebhookIDToSpan(span, webhookID)
+	logger = logger.WithValue(keys.WebhookIDKey, webhookID)
+
+	// determine relevant delivery attempt ID.
+	attemptID := s.deliveryAttemptIDFetcher(req)
+	tracing.AttachWebhookDeliveryAttemptIDToSpan(span, attemptID)
+	logger = logger.WithValue(keys.WebhookDeliveryAttemptIDKey, attemptID)
+
+	exists, err := s.webhookDataManager.WebhookExists(ctx, webhookID, sessionCtxData.ActiveAccountID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		observability.AcknowledgeError(err, logger, span, "checking webhook existence")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	} else if !exists || errors.Is(err, sql.ErrNoRows) {
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	}
+
+	attempt, err := s.webhookDataManager.GetWebhookDeliveryAttempt(ctx, attemptID, webhookID)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Debug("No rows found in webhook delivery attempt database")
+		s.encoderDecoder.EncodeNotFoundResponse(ctx, res)
+		return
+	} else if err != nil {
+		observability.AcknowledgeError(err, logger, span, "fetching webhook delivery attempt from database")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// this is deliberately not attributed to a user, so it isn't relayed to their websocket connections.
+	dcm := &types.DataChangeMessage{
+		MessageType:             types.WebhookRedeliveryMessageType,
+		DataType:                types.WebhookDataType,
+		WebhookDeliveryAttempt:  attempt,
+		AttributableToAccountID: sessionCtxData.ActiveAccountID,
+	}
+	if err = s.dataChangesPublisher.Publish(ctx, dcm); err != nil {
+		observability.AcknowledgeError(err, logger, span, "publishing webhook redelivery message")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	res.WriteHeader(http.StatusAccepted)
+}
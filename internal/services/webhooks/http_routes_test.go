@@ -3,6 +3,7 @@
 import (
 	"bytes"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"testing"
@@ -16,6 +17,7 @@
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	mockencoding "gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	mockrandom "gitlab.com/verygoodsoftwarenotvirus/todo/internal/random/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
 	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
@@ -50,9 +52,42 @@ func TestWebhooksService_CreateHandler(T *testing.T) {
 		helper.service.CreateHandler(helper.res, helper.req)
 		assert.Equal(t, http.StatusCreated, helper.res.Code)
 
+		var actual *types.WebhookCreationResponse
+		require.NoError(t, json.NewDecoder(helper.res.Body).Decode(&actual))
+		assert.NotEmpty(t, actual.ID)
+		assert.NotEmpty(t, actual.SigningSecret)
+
 		mock.AssertExpectationsForObjects(t, mockEventProducer)
 	})
 
+	T.Run("with error generating signing secret", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		exampleCreationInput := fakes.BuildFakeWebhookDatabaseCreationInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleCreationInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPost, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		sg := &mockrandom.Generator{}
+		sg.On(
+			"GenerateBase64EncodedString",
+			testutils.ContextMatcher,
+			signingSecretSize,
+		).Return("", errors.New("blah"))
+		helper.service.secretGenerator = sg
+
+		helper.service.CreateHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, sg)
+	})
+
 	T.Run("with error retrieving session context data", func(t *testing.T) {
 		t.Parallel()
 
@@ -351,6 +386,199 @@ func TestWebhooksService_ReadHandler(T *testing.T) {
 	})
 }
 
+func TestWebhooksService_UpdateHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		exampleInput := fakes.BuildFakeWebhookUpdateInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPut, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"GetWebhook",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(helper.exampleWebhook, nil)
+		helper.service.webhookDataManager = wd
+
+		mockEventProducer := &mock2.Publisher{}
+		mockEventProducer.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(testutils.PreUpdateMessageMatcher),
+		).Return(nil)
+		helper.service.preUpdatesPublisher = mockEventProducer
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusOK, helper.res.Code, "expected %d in status response, got %d", http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd, mockEventProducer)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with error decoding request", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"DecodeRequest",
+			testutils.ContextMatcher,
+			testutils.HTTPRequestMatcher,
+			mock.IsType(&types.WebhookUpdateInput{}),
+		).Return(errors.New("blah"))
+
+		encoderDecoder.On(
+			"EncodeErrorResponse",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			mock.IsType(""),
+			http.StatusBadRequest,
+		).Return(nil)
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, encoderDecoder)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		exampleInput := &types.WebhookUpdateInput{}
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPut, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with no such webhook in database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		exampleInput := fakes.BuildFakeWebhookUpdateInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPut, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"GetWebhook",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return((*types.Webhook)(nil), sql.ErrNoRows)
+		helper.service.webhookDataManager = wd
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd)
+	})
+
+	T.Run("with error fetching webhook from database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		exampleInput := fakes.BuildFakeWebhookUpdateInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPut, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"GetWebhook",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return((*types.Webhook)(nil), errors.New("blah"))
+		helper.service.webhookDataManager = wd
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd)
+	})
+
+	T.Run("with error publishing to message queue", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		exampleInput := fakes.BuildFakeWebhookUpdateInput()
+		jsonBytes := helper.service.encoderDecoder.MustEncode(helper.ctx, exampleInput)
+
+		var err error
+		helper.req, err = http.NewRequestWithContext(helper.ctx, http.MethodPut, "https://todo.verygoodsoftwarenotvirus.ru", bytes.NewReader(jsonBytes))
+		require.NoError(t, err)
+		require.NotNil(t, helper.req)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"GetWebhook",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(helper.exampleWebhook, nil)
+		helper.service.webhookDataManager = wd
+
+		mockEventProducer := &mock2.Publisher{}
+		mockEventProducer.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(testutils.PreUpdateMessageMatcher),
+		).Return(errors.New("blah"))
+		helper.service.preUpdatesPublisher = mockEventProducer
+
+		helper.service.UpdateHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd, mockEventProducer)
+	})
+}
+
 func TestWebhooksService_ArchiveHandler(T *testing.T) {
 	T.Parallel()
 
@@ -477,3 +705,438 @@ func TestWebhooksService_ArchiveHandler(T *testing.T) {
 		mock.AssertExpectationsForObjects(t, wd)
 	})
 }
+
+func TestWebhooksService_ListDeliveryAttemptsHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		exampleAttemptList := fakes.BuildFakeWebhookDeliveryAttemptList()
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"WebhookExists",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(true, nil)
+		wd.On(
+			"GetWebhookDeliveryAttempts",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return(exampleAttemptList, nil)
+		helper.service.webhookDataManager = wd
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"RespondWithData",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			mock.IsType(&types.WebhookDeliveryAttemptList{}),
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.ListDeliveryAttemptsHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd, encoderDecoder)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.ListDeliveryAttemptsHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with error checking webhook existence", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"WebhookExists",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(false, errors.New("blah"))
+		helper.service.webhookDataManager = wd
+
+		helper.service.ListDeliveryAttemptsHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd)
+	})
+
+	T.Run("with no such webhook in database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"WebhookExists",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(false, nil)
+		helper.service.webhookDataManager = wd
+
+		helper.service.ListDeliveryAttemptsHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd)
+	})
+
+	T.Run("with no rows returned", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"WebhookExists",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(true, nil)
+		wd.On(
+			"GetWebhookDeliveryAttempts",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return((*types.WebhookDeliveryAttemptList)(nil), sql.ErrNoRows)
+		helper.service.webhookDataManager = wd
+
+		encoderDecoder := mockencoding.NewMockEncoderDecoder()
+		encoderDecoder.On(
+			"RespondWithData",
+			testutils.ContextMatcher,
+			testutils.HTTPResponseWriterMatcher,
+			mock.IsType(&types.WebhookDeliveryAttemptList{}),
+		).Return()
+		helper.service.encoderDecoder = encoderDecoder
+
+		helper.service.ListDeliveryAttemptsHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd, encoderDecoder)
+	})
+
+	T.Run("with error fetching delivery attempts from database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"WebhookExists",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(true, nil)
+		wd.On(
+			"GetWebhookDeliveryAttempts",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return((*types.WebhookDeliveryAttemptList)(nil), errors.New("blah"))
+		helper.service.webhookDataManager = wd
+
+		helper.service.ListDeliveryAttemptsHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd)
+	})
+}
+
+func TestWebhooksService_RedeliverHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"WebhookExists",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(true, nil)
+		wd.On(
+			"GetWebhookDeliveryAttempt",
+			testutils.ContextMatcher,
+			helper.exampleAttempt.ID,
+			helper.exampleWebhook.ID,
+		).Return(helper.exampleAttempt, nil)
+		helper.service.webhookDataManager = wd
+
+		mockEventProducer := &mock2.Publisher{}
+		mockEventProducer.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.WebhookRedeliveryMessageType && msg.WebhookDeliveryAttempt == helper.exampleAttempt
+			}),
+		).Return(nil)
+		helper.service.dataChangesPublisher = mockEventProducer
+
+		helper.service.RedeliverHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusAccepted, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd, mockEventProducer)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.RedeliverHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with error checking webhook existence", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"WebhookExists",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(false, errors.New("blah"))
+		helper.service.webhookDataManager = wd
+
+		helper.service.RedeliverHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd)
+	})
+
+	T.Run("with no such webhook in database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"WebhookExists",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(false, sql.ErrNoRows)
+		helper.service.webhookDataManager = wd
+
+		helper.service.RedeliverHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd)
+	})
+
+	T.Run("with no such delivery attempt in database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"WebhookExists",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(true, nil)
+		wd.On(
+			"GetWebhookDeliveryAttempt",
+			testutils.ContextMatcher,
+			helper.exampleAttempt.ID,
+			helper.exampleWebhook.ID,
+		).Return((*types.WebhookDeliveryAttempt)(nil), sql.ErrNoRows)
+		helper.service.webhookDataManager = wd
+
+		helper.service.RedeliverHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd)
+	})
+
+	T.Run("with error fetching delivery attempt from database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"WebhookExists",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(true, nil)
+		wd.On(
+			"GetWebhookDeliveryAttempt",
+			testutils.ContextMatcher,
+			helper.exampleAttempt.ID,
+			helper.exampleWebhook.ID,
+		).Return((*types.WebhookDeliveryAttempt)(nil), errors.New("blah"))
+		helper.service.webhookDataManager = wd
+
+		helper.service.RedeliverHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd)
+	})
+
+	T.Run("with error publishing to message queue", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"WebhookExists",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+		).Return(true, nil)
+		wd.On(
+			"GetWebhookDeliveryAttempt",
+			testutils.ContextMatcher,
+			helper.exampleAttempt.ID,
+			helper.exampleWebhook.ID,
+		).Return(helper.exampleAttempt, nil)
+		helper.service.webhookDataManager = wd
+
+		mockEventProducer := &mock2.Publisher{}
+		mockEventProducer.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.IsType(&types.DataChangeMessage{}),
+		).Return(errors.New("blah"))
+		helper.service.dataChangesPublisher = mockEventProducer
+
+		helper.service.RedeliverHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd, mockEventProducer)
+	})
+}
+
+func TestWebhooksService_RotateSecretHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"RotateWebhookSigningSecret",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+			mock.AnythingOfType("string"),
+			mock.AnythingOfType("uint64"),
+		).Return(nil)
+		helper.service.webhookDataManager = wd
+
+		helper.service.RotateSecretHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusOK, helper.res.Code)
+
+		var actual *types.WebhookSecretRotationResponse
+		require.NoError(t, json.NewDecoder(helper.res.Body).Decode(&actual))
+		assert.NotEmpty(t, actual.SigningSecret)
+		assert.NotZero(t, actual.PreviousSigningSecretExpiresOn)
+
+		mock.AssertExpectationsForObjects(t, wd)
+	})
+
+	T.Run("with error retrieving session context data", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+		helper.service.sessionContextDataFetcher = testutils.BrokenSessionContextDataFetcher
+
+		helper.service.RotateSecretHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with error generating signing secret", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		sg := &mockrandom.Generator{}
+		sg.On(
+			"GenerateBase64EncodedString",
+			testutils.ContextMatcher,
+			signingSecretSize,
+		).Return("", errors.New("blah"))
+		helper.service.secretGenerator = sg
+
+		helper.service.RotateSecretHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, sg)
+	})
+
+	T.Run("with no such webhook in database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"RotateWebhookSigningSecret",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+			mock.AnythingOfType("string"),
+			mock.AnythingOfType("uint64"),
+		).Return(sql.ErrNoRows)
+		helper.service.webhookDataManager = wd
+
+		helper.service.RotateSecretHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusNotFound, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd)
+	})
+
+	T.Run("with error writing to database", func(t *testing.T) {
+		t.Parallel()
+
+		helper := newTestHelper(t)
+
+		wd := &mocktypes.WebhookDataManager{}
+		wd.On(
+			"RotateWebhookSigningSecret",
+			testutils.ContextMatcher,
+			helper.exampleWebhook.ID,
+			helper.exampleAccount.ID,
+			mock.AnythingOfType("string"),
+			mock.AnythingOfType("uint64"),
+		).Return(errors.New("blah"))
+		helper.service.webhookDataManager = wd
+
+		helper.service.RotateSecretHandler(helper.res, helper.req)
+		assert.Equal(t, http.StatusInternalServerError, helper.res.Code)
+
+		mock.AssertExpectationsForObjects(t, wd)
+	})
+}
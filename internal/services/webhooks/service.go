@@ -3,11 +3,13 @@
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/random"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing"
 	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
@@ -15,6 +17,9 @@
 
 const (
 	serviceName string = "webhooks_service"
+
+	// defaultSigningSecretGracePeriod is how long a rotated signing secret stays valid when no grace period is configured.
+	defaultSigningSecretGracePeriod = 24 * time.Hour
 )
 
 var (
@@ -26,11 +31,17 @@
 	service struct {
 		logger                    logging.Logger
 		webhookDataManager        types.WebhookDataManager
+		auditLogEntryDataManager  types.AuditLogEntryDataManager
 		sessionContextDataFetcher func(*http.Request) (*types.SessionContextData, error)
 		webhookIDFetcher          func(*http.Request) string
+		deliveryAttemptIDFetcher  func(*http.Request) string
 		encoderDecoder            encoding.ServerEncoderDecoder
 		preWritesPublisher        publishers.Publisher
+		preUpdatesPublisher       publishers.Publisher
 		preArchivesPublisher      publishers.Publisher
+		dataChangesPublisher      publishers.Publisher
+		secretGenerator           random.Generator
+		signingSecretGracePeriod  time.Duration
 		tracer                    tracing.Tracer
 	}
 )
@@ -40,6 +51,7 @@ func ProvideWebhooksService(
 	logger logging.Logger,
 	cfg *Config,
 	webhookDataManager types.WebhookDataManager,
+	auditLogEntryDataManager types.AuditLogEntryDataManager,
 	encoder encoding.ServerEncoderDecoder,
 	routeParamManager routing.RouteParamManager,
 	publisherProvider publishers.PublisherProvider,
@@ -49,19 +61,40 @@ func ProvideWebhooksService(
 		return nil, fmt.Errorf("setting up pre-writes producer: %w", err)
 	}
 
+	preUpdatesPublisher, err := publisherProvider.ProviderPublisher(cfg.PreUpdatesTopicName)
+	if err != nil {
+		return nil, fmt.Errorf("setting up pre-updates producer: %w", err)
+	}
+
 	preArchivesPublisher, err := publisherProvider.ProviderPublisher(cfg.PreArchivesTopicName)
 	if err != nil {
 		return nil, fmt.Errorf("setting up pre-archives producer: %w", err)
 	}
 
+	dataChangesPublisher, err := publisherProvider.ProviderPublisher(cfg.DataChangesTopicName)
+	if err != nil {
+		return nil, fmt.Errorf("setting up data changes producer: %w", err)
+	}
+
+	gracePeriod := cfg.SigningSecretGracePeriod
+	if gracePeriod == 0 {
+		gracePeriod = defaultSigningSecretGracePeriod
+	}
+
 	s := &service{
 		logger:                    logging.EnsureLogger(logger).WithName(serviceName),
 		webhookDataManager:        webhookDataManager,
+		auditLogEntryDataManager:  auditLogEntryDataManager,
 		encoderDecoder:            encoder,
 		preWritesPublisher:        preWritesPublisher,
+		preUpdatesPublisher:       preUpdatesPublisher,
 		preArchivesPublisher:      preArchivesPublisher,
+		dataChangesPublisher:      dataChangesPublisher,
+		secretGenerator:           random.NewGenerator(logger),
+		signingSecretGracePeriod:  gracePeriod,
 		sessionContextDataFetcher: authservice.FetchContextFromRequest,
 		webhookIDFetcher:          routeParamManager.BuildRouteParamStringIDFetcher(WebhookIDURIParamKey),
+		deliveryAttemptIDFetcher:  routeParamManager.BuildRouteParamStringIDFetcher(WebhookDeliveryAttemptIDURIParamKey),
 		tracer:                    tracing.NewTracer(serviceName),
 	}
 
@@ -13,17 +13,30 @@
 	mockencoding "gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/random"
 	mockrouting "gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing/mock"
 	mocktypes "gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/mock"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
 )
 
 func buildTestService() *service {
+	auditLogEntryDataManager := &mocktypes.AuditLogEntryDataManager{}
+	auditLogEntryDataManager.On(
+		"CreateAuditLogEntry",
+		testutils.ContextMatcher,
+		mock.MatchedBy(testutils.AuditLogEntryCreationInputMatcher),
+	).Return(nil).Maybe()
+
 	return &service{
-		logger:             logging.NewNoopLogger(),
-		webhookDataManager: &mocktypes.WebhookDataManager{},
-		webhookIDFetcher:   func(req *http.Request) string { return "" },
-		encoderDecoder:     mockencoding.NewMockEncoderDecoder(),
-		tracer:             tracing.NewTracer("test"),
+		logger:                   logging.NewNoopLogger(),
+		webhookDataManager:       &mocktypes.WebhookDataManager{},
+		auditLogEntryDataManager: auditLogEntryDataManager,
+		webhookIDFetcher:         func(req *http.Request) string { return "" },
+		deliveryAttemptIDFetcher: func(req *http.Request) string { return "" },
+		encoderDecoder:           mockencoding.NewMockEncoderDecoder(),
+		secretGenerator:          random.NewGenerator(logging.NewNoopLogger()),
+		signingSecretGracePeriod: defaultSigningSecretGracePeriod,
+		tracer:                   tracing.NewTracer("test"),
 	}
 }
 
@@ -38,20 +51,29 @@ func TestProvideWebhooksService(T *testing.T) {
 			"BuildRouteParamStringIDFetcher",
 			WebhookIDURIParamKey,
 		).Return(func(*http.Request) string { return "" })
+		rpm.On(
+			"BuildRouteParamStringIDFetcher",
+			WebhookDeliveryAttemptIDURIParamKey,
+		).Return(func(*http.Request) string { return "" })
 
 		cfg := &Config{
 			PreWritesTopicName:   "pre-writes",
+			PreUpdatesTopicName:  "pre-updates",
 			PreArchivesTopicName: "pre-archives",
+			DataChangesTopicName: "data-changes",
 		}
 
 		pp := &mock2.ProducerProvider{}
 		pp.On("ProviderPublisher", cfg.PreWritesTopicName).Return(&mock2.Publisher{}, nil)
+		pp.On("ProviderPublisher", cfg.PreUpdatesTopicName).Return(&mock2.Publisher{}, nil)
 		pp.On("ProviderPublisher", cfg.PreArchivesTopicName).Return(&mock2.Publisher{}, nil)
+		pp.On("ProviderPublisher", cfg.DataChangesTopicName).Return(&mock2.Publisher{}, nil)
 
 		actual, err := ProvideWebhooksService(
 			logging.NewNoopLogger(),
 			cfg,
 			&mocktypes.WebhookDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
 			mockencoding.NewMockEncoderDecoder(),
 			rpm,
 			pp,
@@ -68,7 +90,9 @@ func TestProvideWebhooksService(T *testing.T) {
 
 		cfg := &Config{
 			PreWritesTopicName:   "pre-writes",
+			PreUpdatesTopicName:  "pre-updates",
 			PreArchivesTopicName: "pre-archives",
+			DataChangesTopicName: "data-changes",
 		}
 
 		pp := &mock2.ProducerProvider{}
@@ -78,6 +102,37 @@ func TestProvideWebhooksService(T *testing.T) {
 			logging.NewNoopLogger(),
 			cfg,
 			&mocktypes.WebhookDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
+			mockencoding.NewMockEncoderDecoder(),
+			nil,
+			pp,
+		)
+
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+
+		mock.AssertExpectationsForObjects(t, pp)
+	})
+
+	T.Run("with error providing pre-updates publisher", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &Config{
+			PreWritesTopicName:   "pre-writes",
+			PreUpdatesTopicName:  "pre-updates",
+			PreArchivesTopicName: "pre-archives",
+			DataChangesTopicName: "data-changes",
+		}
+
+		pp := &mock2.ProducerProvider{}
+		pp.On("ProviderPublisher", cfg.PreWritesTopicName).Return(&mock2.Publisher{}, nil)
+		pp.On("ProviderPublisher", cfg.PreUpdatesTopicName).Return((*mock2.Publisher)(nil), errors.New("blah"))
+
+		actual, err := ProvideWebhooksService(
+			logging.NewNoopLogger(),
+			cfg,
+			&mocktypes.WebhookDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
 			mockencoding.NewMockEncoderDecoder(),
 			nil,
 			pp,
@@ -94,17 +149,53 @@ func TestProvideWebhooksService(T *testing.T) {
 
 		cfg := &Config{
 			PreWritesTopicName:   "pre-writes",
+			PreUpdatesTopicName:  "pre-updates",
 			PreArchivesTopicName: "pre-archives",
+			DataChangesTopicName: "data-changes",
 		}
 
 		pp := &mock2.ProducerProvider{}
 		pp.On("ProviderPublisher", cfg.PreWritesTopicName).Return(&mock2.Publisher{}, nil)
+		pp.On("ProviderPublisher", cfg.PreUpdatesTopicName).Return(&mock2.Publisher{}, nil)
 		pp.On("ProviderPublisher", cfg.PreArchivesTopicName).Return((*mock2.Publisher)(nil), errors.New("blah"))
 
 		actual, err := ProvideWebhooksService(
 			logging.NewNoopLogger(),
 			cfg,
 			&mocktypes.WebhookDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
+			mockencoding.NewMockEncoderDecoder(),
+			nil,
+			pp,
+		)
+
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+
+		mock.AssertExpectationsForObjects(t, pp)
+	})
+
+	T.Run("with error providing data changes publisher", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &Config{
+			PreWritesTopicName:   "pre-writes",
+			PreUpdatesTopicName:  "pre-updates",
+			PreArchivesTopicName: "pre-archives",
+			DataChangesTopicName: "data-changes",
+		}
+
+		pp := &mock2.ProducerProvider{}
+		pp.On("ProviderPublisher", cfg.PreWritesTopicName).Return(&mock2.Publisher{}, nil)
+		pp.On("ProviderPublisher", cfg.PreUpdatesTopicName).Return(&mock2.Publisher{}, nil)
+		pp.On("ProviderPublisher", cfg.PreArchivesTopicName).Return(&mock2.Publisher{}, nil)
+		pp.On("ProviderPublisher", cfg.DataChangesTopicName).Return((*mock2.Publisher)(nil), errors.New("blah"))
+
+		actual, err := ProvideWebhooksService(
+			logging.NewNoopLogger(),
+			cfg,
+			&mocktypes.WebhookDataManager{},
+			&mocktypes.AuditLogEntryDataManager{},
 			mockencoding.NewMockEncoderDecoder(),
 			nil,
 			pp,
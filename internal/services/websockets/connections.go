@@ -0,0 +1,243 @@
+package websockets
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	// connectionShardCount is how many independently locked shards connections are spread across.
+	connectionShardCount = 64
+	// subscriberSendBufferSize is how many messages can be waiting to be written to a connection before we
+	// consider its consumer too slow to keep, and drop it.
+	subscriberSendBufferSize = 64
+)
+
+type (
+	// subscriber is a websocket connection, along with who opened it, what they've asked to hear about, and which
+	// items they've said they're looking at. Each subscriber has its own writer goroutine, which is the only thing
+	// that writes to its connection.
+	subscriber struct {
+		conn           websocketConnection
+		id             string
+		sessionCtxData *types.SessionContextData
+		filter         *types.DataChangeSubscriptionFilter
+		items          map[string]struct{}
+		outbox         chan []byte
+		done           chan struct{}
+		filterHat      sync.RWMutex
+		itemsHat       sync.Mutex
+		closeOnce      sync.Once
+	}
+
+	// connectionShard holds the subscribers for some subset of users.
+	connectionShard struct {
+		subscribers map[string][]*subscriber
+		hat         sync.RWMutex
+	}
+
+	// connectionRegistry holds every subscriber connected to this process, sharded by user ID so that
+	// connecting and disconnecting don't contend with fan-out to unrelated users.
+	connectionRegistry struct {
+		shards [connectionShardCount]*connectionShard
+	}
+)
+
+func newSubscriber(conn websocketConnection, sessionCtxData *types.SessionContextData) *subscriber {
+	return &subscriber{
+		id:             ksuid.New().String(),
+		conn:           conn,
+		sessionCtxData: sessionCtxData,
+		items:          map[string]struct{}{},
+		outbox:         make(chan []byte, subscriberSendBufferSize),
+		done:           make(chan struct{}),
+	}
+}
+
+func (x *subscriber) setFilter(filter *types.DataChangeSubscriptionFilter) {
+	x.filterHat.Lock()
+	defer x.filterHat.Unlock()
+
+	x.filter = filter
+}
+
+func (x *subscriber) rememberItem(itemID string) {
+	x.itemsHat.Lock()
+	defer x.itemsHat.Unlock()
+
+	x.items[itemID] = struct{}{}
+}
+
+func (x *subscriber) forgetItem(itemID string) {
+	x.itemsHat.Lock()
+	defer x.itemsHat.Unlock()
+
+	delete(x.items, itemID)
+}
+
+// rememberedItems returns the IDs of the items the subscriber has said they're viewing or editing.
+func (x *subscriber) rememberedItems() []string {
+	x.itemsHat.Lock()
+	defer x.itemsHat.Unlock()
+
+	itemIDs := []string{}
+	for itemID := range x.items {
+		itemIDs = append(itemIDs, itemID)
+	}
+
+	return itemIDs
+}
+
+// enqueue hands a message to the subscriber's writer without waiting. It returns false if the subscriber's
+// buffer is full.
+func (x *subscriber) enqueue(payload []byte) bool {
+	select {
+	case x.outbox <- payload:
+		return true
+	default:
+		return false
+	}
+}
+
+// close stops the subscriber's writer and closes its connection, which in turn stops its reader.
+func (x *subscriber) close() {
+	x.closeOnce.Do(func() {
+		close(x.done)
+		_ = x.conn.Close()
+	})
+}
+
+func newConnectionRegistry() *connectionRegistry {
+	r := &connectionRegistry{}
+	for i := range r.shards {
+		r.shards[i] = &connectionShard{subscribers: map[string][]*subscriber{}}
+	}
+
+	return r
+}
+
+func (r *connectionRegistry) shardFor(userID string) *connectionShard {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(userID))
+
+	return r.shards[h.Sum32()%connectionShardCount]
+}
+
+func (r *connectionRegistry) add(sub *subscriber) {
+	userID := sub.sessionCtxData.Requester.UserID
+	shard := r.shardFor(userID)
+
+	shard.hat.Lock()
+	defer shard.hat.Unlock()
+
+	shard.subscribers[userID] = append(shard.subscribers[userID], sub)
+}
+
+// remove removes a subscriber, and reports whether it was there to remove.
+func (r *connectionRegistry) remove(sub *subscriber) bool {
+	userID := sub.sessionCtxData.Requester.UserID
+	shard := r.shardFor(userID)
+
+	shard.hat.Lock()
+	defer shard.hat.Unlock()
+
+	for i, x := range shard.subscribers[userID] {
+		if x == sub {
+			shard.subscribers[userID] = removeConnection(shard.subscribers[userID], i)
+			if len(shard.subscribers[userID]) == 0 {
+				delete(shard.subscribers, userID)
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// forEach calls fn for every subscriber. Only the shard being visited is locked, and fn must not block.
+func (r *connectionRegistry) forEach(fn func(*subscriber)) {
+	for _, shard := range r.shards {
+		shard.hat.RLock()
+		for _, subscribers := range shard.subscribers {
+			for _, sub := range subscribers {
+				fn(sub)
+			}
+		}
+		shard.hat.RUnlock()
+	}
+}
+
+// forUser returns the subscribers for a given user.
+func (r *connectionRegistry) forUser(userID string) []*subscriber {
+	shard := r.shardFor(userID)
+
+	shard.hat.RLock()
+	defer shard.hat.RUnlock()
+
+	return append([]*subscriber{}, shard.subscribers[userID]...)
+}
+
+func removeConnection(s []*subscriber, index int) []*subscriber {
+	s[index] = s[len(s)-1]
+	return s[:len(s)-1]
+}
+
+// register starts serving a new subscriber.
+func (s *service) register(sub *subscriber) {
+	s.connections.add(sub)
+	s.presence.connected(sub.sessionCtxData)
+
+	go s.writeToSubscriber(sub)
+	go s.readFromSubscriber(sub)
+}
+
+// dropSubscriber stops serving a subscriber. It's safe to call more than once.
+func (s *service) dropSubscriber(sub *subscriber) {
+	if s.connections.remove(sub) {
+		s.presence.disconnected(sub.sessionCtxData)
+		s.withdrawItemPresence(sub)
+	}
+
+	sub.close()
+}
+
+// writeToSubscriber writes queued messages and periodic pings to a subscriber's connection until it's dropped.
+func (s *service) writeToSubscriber(sub *subscriber) {
+	logger := s.logger.WithValue(keys.UserIDKey, sub.sessionCtxData.Requester.UserID)
+
+	ticker := time.NewTicker(s.pollDuration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sub.done:
+			return
+		case payload := <-sub.outbox:
+			if err := sub.conn.SetWriteDeadline(time.Now().Add(s.websocketDeadline)); err != nil {
+				logger.Error(err, "setting write deadline")
+				s.dropSubscriber(sub)
+				return
+			}
+
+			if err := sub.conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				logger.Error(err, "writing message to websocket")
+				s.dropSubscriber(sub)
+				return
+			}
+		case <-ticker.C:
+			if err := sub.conn.WriteControl(websocket.PingMessage, []byte("ping"), time.Now().Add(s.pollDuration/2)); err != nil {
+				logger.Error(err, "pinging websocket connection")
+				s.dropSubscriber(sub)
+				return
+			}
+		}
+	}
+}
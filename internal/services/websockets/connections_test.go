@@ -0,0 +1,204 @@
+package websockets
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_connectionRegistry(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		r := newConnectionRegistry()
+		sessionCtxData := buildExampleSessionContextData()
+
+		first := newSubscriber(&mockWebsocketConnection{}, sessionCtxData)
+		second := newSubscriber(&mockWebsocketConnection{}, sessionCtxData)
+		other := newSubscriber(&mockWebsocketConnection{}, buildExampleSessionContextData())
+
+		r.add(first)
+		r.add(second)
+		r.add(other)
+
+		assert.ElementsMatch(t, []*subscriber{first, second}, r.forUser(sessionCtxData.Requester.UserID))
+
+		visited := []*subscriber{}
+		r.forEach(func(sub *subscriber) {
+			visited = append(visited, sub)
+		})
+		assert.ElementsMatch(t, []*subscriber{first, second, other}, visited)
+
+		assert.True(t, r.remove(first))
+		assert.False(t, r.remove(first))
+		assert.Equal(t, []*subscriber{second}, r.forUser(sessionCtxData.Requester.UserID))
+
+		assert.True(t, r.remove(second))
+		assert.Empty(t, r.shardFor(sessionCtxData.Requester.UserID).subscribers[sessionCtxData.Requester.UserID])
+	})
+}
+
+func Test_removeConnection(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		first := &subscriber{}
+		second := &subscriber{}
+		third := &subscriber{}
+
+		actual := removeConnection([]*subscriber{first, second, third}, 0)
+
+		assert.Equal(t, []*subscriber{third, second}, actual)
+	})
+}
+
+func Test_subscriber_close(T *testing.T) {
+	T.Parallel()
+
+	T.Run("only closes once", func(t *testing.T) {
+		t.Parallel()
+
+		mc := &mockWebsocketConnection{}
+		mc.On("Close").Return(nil).Once()
+
+		sub := newSubscriber(mc, buildExampleSessionContextData())
+		sub.close()
+		sub.close()
+
+		mock.AssertExpectationsForObjects(t, mc)
+	})
+}
+
+func Test_writeToSubscriber(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		examplePayload := []byte(t.Name())
+		written := make(chan struct{})
+
+		mc := &mockWebsocketConnection{}
+		mc.On("SetWriteDeadline", mock.AnythingOfType("time.Time")).Return(nil)
+		mc.On("WriteMessage", websocket.TextMessage, examplePayload).Return(nil).Run(func(mock.Arguments) { close(written) })
+		mc.On("Close").Return(nil)
+
+		sub := newSubscriber(mc, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		go s.service.writeToSubscriber(sub)
+		require.True(t, sub.enqueue(examplePayload))
+
+		select {
+		case <-written:
+		case <-time.After(time.Second):
+			t.Fatal("message was never written")
+		}
+
+		s.service.dropSubscriber(sub)
+
+		mock.AssertExpectationsForObjects(t, mc)
+	})
+
+	T.Run("pings connections", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.service.pollDuration = 10 * time.Millisecond
+		pinged := make(chan struct{})
+
+		mc := &mockWebsocketConnection{}
+		mc.On("WriteControl", websocket.PingMessage, []byte("ping"), mock.AnythingOfType("time.Time")).Return(nil).Once().Run(func(mock.Arguments) { close(pinged) })
+		mc.On("WriteControl", websocket.PingMessage, []byte("ping"), mock.AnythingOfType("time.Time")).Return(nil).Maybe()
+		mc.On("Close").Return(nil)
+
+		sub := newSubscriber(mc, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		go s.service.writeToSubscriber(sub)
+
+		select {
+		case <-pinged:
+		case <-time.After(time.Second):
+			t.Fatal("connection was never pinged")
+		}
+
+		s.service.dropSubscriber(sub)
+
+		mock.AssertExpectationsForObjects(t, mc)
+	})
+
+	T.Run("with error writing message", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		examplePayload := []byte(t.Name())
+
+		mc := &mockWebsocketConnection{}
+		mc.On("SetWriteDeadline", mock.AnythingOfType("time.Time")).Return(nil)
+		mc.On("WriteMessage", websocket.TextMessage, examplePayload).Return(errors.New("blah"))
+		mc.On("Close").Return(nil)
+
+		sub := newSubscriber(mc, s.sessionCtxData)
+		s.service.connections.add(sub)
+		s.service.presence.connected(sub.sessionCtxData)
+
+		require.True(t, sub.enqueue(examplePayload))
+		s.service.writeToSubscriber(sub)
+
+		assert.Empty(t, s.service.connections.forUser(s.exampleUser.ID))
+		assert.Zero(t, s.service.presence.connectionsForUser(s.exampleUser.ID))
+
+		mock.AssertExpectationsForObjects(t, mc)
+	})
+
+	T.Run("with error setting write deadline", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		mc := &mockWebsocketConnection{}
+		mc.On("SetWriteDeadline", mock.AnythingOfType("time.Time")).Return(errors.New("blah"))
+		mc.On("Close").Return(nil)
+
+		sub := newSubscriber(mc, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		require.True(t, sub.enqueue([]byte(t.Name())))
+		s.service.writeToSubscriber(sub)
+
+		assert.Empty(t, s.service.connections.forUser(s.exampleUser.ID))
+
+		mock.AssertExpectationsForObjects(t, mc)
+	})
+
+	T.Run("with error pinging connection", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.service.pollDuration = time.Millisecond
+
+		mc := &mockWebsocketConnection{}
+		mc.On("WriteControl", websocket.PingMessage, []byte("ping"), mock.AnythingOfType("time.Time")).Return(errors.New("blah"))
+		mc.On("Close").Return(nil)
+
+		sub := newSubscriber(mc, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		s.service.writeToSubscriber(sub)
+
+		assert.Empty(t, s.service.connections.forUser(s.exampleUser.ID))
+
+		mock.AssertExpectationsForObjects(t, mc)
+	})
+}
@@ -7,11 +7,56 @@
 
 	"github.com/gorilla/websocket"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
+// dataTypeReadPermissions maps data types to the account permission required to hear about changes to them.
+// Data types that aren't listed here are visible to every member of the account.
+var dataTypeReadPermissions = map[string]authorization.Permission{
+	string(types.ItemDataType):         authorization.ReadItemsPermission,
+	string(types.WebhookDataType):      authorization.ReadWebhooksPermission,
+	string(types.ItemPresenceDataType): authorization.ReadItemsPermission,
+}
+
+// wants returns whether a subscriber is allowed to see a given data change, and whether their filter lets it through.
+func (x *subscriber) wants(msg *types.DataChangeMessage) bool {
+	if !canReceive(x.sessionCtxData, msg) {
+		return false
+	}
+
+	x.filterHat.RLock()
+	defer x.filterHat.RUnlock()
+
+	return x.filter.Matches(msg)
+}
+
+// canReceive returns whether someone is allowed to see a given data change. Permissions are those they had
+// when they connected, which is why connections are dropped whenever those permissions change.
+func canReceive(sessionCtxData *types.SessionContextData, msg *types.DataChangeMessage) bool {
+	switch {
+	case msg.DataType == types.NotificationDataType, msg.AttributableToAccountID == "":
+		// notifications are addressed to one person, regardless of who else is in their account.
+		return msg.AttributableToUserID == sessionCtxData.Requester.UserID
+	case msg.DataType == types.ItemPresenceDataType && msg.AttributableToUserID == sessionCtxData.Requester.UserID:
+		// people already know what they're looking at.
+		return false
+	default:
+		perms, isMember := sessionCtxData.AccountPermissions[msg.AttributableToAccountID]
+		if !isMember || perms == nil {
+			return false
+		}
+
+		if permission, ok := dataTypeReadPermissions[string(msg.DataType)]; ok && !perms.HasPermission(permission) {
+			return false
+		}
+
+		return true
+	}
+}
+
 func (s *service) handleDataChange(ctx context.Context, payload []byte) error {
 	_, span := s.tracer.StartSpan(ctx)
 	defer span.End()
@@ -26,57 +71,111 @@ func (s *service) handleDataChange(ctx context.Context, payload []byte) error {
 
 	s.logger.WithValue("msg", msg).Debug("handling data change")
 
-	s.connectionsHat.RLock()
-	defer s.connectionsHat.RUnlock()
-	for userID, connections := range s.connections {
-		if msg.AttributableToUserID != userID {
-			continue
+	// stale connections are dropped first, so they don't hear about anything their owners no longer may.
+	s.dropInvalidatedSubscriptions(msg)
+	s.broadcastToEventStreams(s.replayBuffer.record(msg, payload))
+	s.deliver(msg, payload)
+
+	return nil
+}
+
+// invalidatedBy returns the users whose connections a data change makes stale, because their memberships
+// changed or the sessions they connected with were revoked, along with which of their sessions are affected.
+func invalidatedBy(msg *types.DataChangeMessage) (userIDs []string, invalidates func(*types.SessionContextData) bool) {
+	everySession := func(*types.SessionContextData) bool { return true }
+
+	switch {
+	case msg.DataType == types.UserMembershipDataType && msg.OwnershipTransfer != nil:
+		return []string{msg.OwnershipTransfer.CurrentOwner, msg.OwnershipTransfer.NewOwner}, everySession
+	case msg.DataType == types.UserMembershipDataType && msg.UserMembership != nil:
+		return []string{msg.UserMembership.BelongsToUser}, everySession
+	case msg.DataType == types.UserSessionDataType && msg.UserSessionRevocation != nil:
+		return []string{msg.AttributableToUserID}, func(sessionCtxData *types.SessionContextData) bool {
+			return msg.UserSessionRevocation.Revokes(sessionCtxData.UserSessionID)
 		}
-		logger := s.logger.WithValue(keys.UserIDKey, userID).WithValue("connection_count", len(connections))
-		for i, conn := range connections {
-			logger = logger.WithValue("connection_index", i)
+	default:
+		return nil, nil
+	}
+}
 
-			if err := conn.SetWriteDeadline(time.Now().Add(s.websocketDeadline)); err != nil {
-				observability.AcknowledgeError(err, logger, span, "setting write deadline")
+// dropInvalidatedSubscriptions ends every local websocket and event stream a data change has made stale. Their
+// clients are expected to reconnect, at which point their permissions are checked afresh.
+func (s *service) dropInvalidatedSubscriptions(msg *types.DataChangeMessage) {
+	userIDs, invalidates := invalidatedBy(msg)
+
+	for _, userID := range userIDs {
+		logger := s.logger.WithValue(keys.UserIDKey, userID)
+
+		for _, sub := range s.connections.forUser(userID) {
+			if !invalidates(sub.sessionCtxData) {
 				continue
 			}
 
-			if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
-				logger.Error(err, "writing message to websocket")
-				s.logger.Error(err, "writing message to websocket")
+			logger.Info("dropping websocket consumer whose permissions changed")
+			closeMessage := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "permissions changed")
+			if err := sub.conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(s.websocketDeadline)); err != nil {
+				logger.WithValue("reason", err.Error()).Debug("sending websocket close message")
 			}
+
+			s.dropSubscriber(sub)
 		}
-	}
 
-	return nil
+		s.revokeEventStreams(userID, invalidates)
+	}
 }
 
-func removeConnection(s []websocketConnection, index int) []websocketConnection {
-	s[index] = s[len(s)-1]
-	return s[:len(s)-1]
+// deliver queues a message for every local subscriber who wants it.
+func (s *service) deliver(msg *types.DataChangeMessage, payload []byte) {
+	// subscribers can't be dropped while their shard is being visited, so slow ones are collected for later.
+	slowSubscribers := []*subscriber{}
+	s.connections.forEach(func(sub *subscriber) {
+		if sub.wants(msg) && !sub.enqueue(payload) {
+			slowSubscribers = append(slowSubscribers, sub)
+		}
+	})
+
+	for _, sub := range slowSubscribers {
+		s.logger.WithValue(keys.UserIDKey, sub.sessionCtxData.Requester.UserID).Info("dropping slow websocket consumer")
+		s.dropSubscriber(sub)
+	}
 }
 
-func (s *service) pingConnections() {
-	ticker := time.NewTicker(s.pollDuration)
-
-	s.logger.Debug("pinging websocket connections")
-
-	for range ticker.C {
-		s.connectionsHat.Lock()
-		for userID, connections := range s.connections {
-			l := s.logger.WithValue(keys.UserIDKey, userID).WithValue("connection_count", len(connections))
-			for i, conn := range connections {
-				l = l.WithValue("connection_index", i)
-				if err := conn.WriteControl(websocket.PingMessage, []byte("ping"), time.Now().Add(s.pollDuration/2)); err != nil {
-					l.Error(err, "pinging websocket connection")
-
-					s.connections[userID] = removeConnection(s.connections[userID], i)
-					if len(s.connections[userID]) == 0 {
-						delete(s.connections, userID)
-					}
-				}
+// readFromSubscriber listens for subscription filters and item presence updates sent by a subscriber until their
+// connection goes away, at which point the subscriber is dropped.
+func (s *service) readFromSubscriber(sub *subscriber) {
+	ctx := context.Background()
+	logger := s.logger.WithValue(keys.UserIDKey, sub.sessionCtxData.Requester.UserID)
+
+	for {
+		_, payload, err := sub.conn.ReadMessage()
+		if err != nil {
+			logger.WithValue("reason", err.Error()).Debug("websocket connection closed")
+			s.dropSubscriber(sub)
+			return
+		}
+
+		presenceUpdate := &types.ItemPresenceUpdateMessage{}
+		if err = json.Unmarshal(payload, presenceUpdate); err == nil && presenceUpdate.Presence != nil {
+			if err = presenceUpdate.Presence.ValidateWithContext(ctx); err != nil {
+				logger.WithValue(keys.ValidationErrorKey, err).Debug("invalid item presence update received")
+				continue
 			}
+
+			s.announceItemPresence(ctx, sub, presenceUpdate.Presence)
+			continue
+		}
+
+		filter := &types.DataChangeSubscriptionFilter{}
+		if err = json.Unmarshal(payload, filter); err != nil {
+			logger.WithValue("reason", err.Error()).Debug("invalid subscription filter received")
+			continue
 		}
-		s.connectionsHat.Unlock()
+
+		if err = filter.ValidateWithContext(ctx); err != nil {
+			logger.WithValue(keys.ValidationErrorKey, err).Debug("invalid subscription filter received")
+			continue
+		}
+
+		sub.setFilter(filter)
 	}
 }
@@ -7,7 +7,10 @@
 	"testing"
 	"time"
 
+	mockpublishers "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers/mock"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
 
 	"github.com/stretchr/testify/assert"
 
@@ -33,6 +36,23 @@ func (m *mockWebsocketConnection) WriteControl(messageType int, data []byte, dea
 	return m.Called(messageType, data, deadline).Error(0)
 }
 
+func (m *mockWebsocketConnection) ReadMessage() (messageType int, p []byte, err error) {
+	args := m.Called()
+	return args.Int(0), args.Get(1).([]byte), args.Error(2)
+}
+
+func (m *mockWebsocketConnection) Close() error {
+	return m.Called().Error(0)
+}
+
+// assertDelivered asserts that a given payload, and nothing else, was queued for a subscriber.
+func assertDelivered(t *testing.T, sub *subscriber, expected []byte) {
+	t.Helper()
+
+	require.Len(t, sub.outbox, 1)
+	assert.Equal(t, expected, <-sub.outbox)
+}
+
 func Test_handleDataChange(T *testing.T) {
 	T.Parallel()
 
@@ -46,24 +66,138 @@ func Test_handleDataChange(T *testing.T) {
 		examplePayload, err := json.Marshal(msg)
 		require.NoError(t, err)
 
-		mc := &mockWebsocketConnection{}
-		mc.On(
-			"SetWriteDeadline",
-			mock.MatchedBy(func(x time.Time) bool { return true }),
-		).Return(nil)
-
-		mc.On(
-			"WriteMessage",
-			websocket.TextMessage,
-			examplePayload,
-		).Return(nil)
-
-		s.service.connections = map[string][]websocketConnection{
-			s.exampleUser.ID: {mc},
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		err = s.service.handleDataChange(ctx, examplePayload)
+		require.NoError(t, err)
+
+		assertDelivered(t, sub, examplePayload)
+	})
+
+	T.Run("delivers to account members", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		msg := &types.DataChangeMessage{
+			DataType:                types.ItemDataType,
+			MessageType:             types.CreatedMessageType,
+			Item:                    fakes.BuildFakeItem(),
+			AttributableToUserID:    fakes.BuildFakeUser().ID,
+			AttributableToAccountID: s.exampleAccount.ID,
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		err = s.service.handleDataChange(ctx, examplePayload)
+		require.NoError(t, err)
+
+		assertDelivered(t, sub, examplePayload)
+	})
+
+	T.Run("does not deliver to non-members", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		msg := &types.DataChangeMessage{
+			DataType:                types.ItemDataType,
+			MessageType:             types.CreatedMessageType,
+			Item:                    fakes.BuildFakeItem(),
+			AttributableToUserID:    fakes.BuildFakeUser().ID,
+			AttributableToAccountID: fakes.BuildFakeAccount().ID,
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		err = s.service.handleDataChange(ctx, examplePayload)
+		require.NoError(t, err)
+
+		assert.Empty(t, sub.outbox)
+	})
+
+	T.Run("does not deliver data types members cannot read", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		msg := &types.DataChangeMessage{
+			DataType:                types.WebhookDataType,
+			MessageType:             types.CreatedMessageType,
+			Webhook:                 fakes.BuildFakeWebhook(),
+			AttributableToUserID:    fakes.BuildFakeUser().ID,
+			AttributableToAccountID: s.exampleAccount.ID,
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		err = s.service.handleDataChange(ctx, examplePayload)
+		require.NoError(t, err)
+
+		assert.Empty(t, sub.outbox)
+	})
+
+	T.Run("does not deliver other users' notifications", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		msg := &types.DataChangeMessage{
+			DataType:                types.NotificationDataType,
+			MessageType:             types.CreatedMessageType,
+			AttributableToUserID:    fakes.BuildFakeUser().ID,
+			AttributableToAccountID: s.exampleAccount.ID,
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		err = s.service.handleDataChange(ctx, examplePayload)
+		require.NoError(t, err)
+
+		assert.Empty(t, sub.outbox)
+	})
+
+	T.Run("respects subscription filters", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		msg := &types.DataChangeMessage{
+			DataType:                types.ItemDataType,
+			MessageType:             types.CreatedMessageType,
+			Item:                    fakes.BuildFakeItem(),
+			AttributableToUserID:    s.exampleUser.ID,
+			AttributableToAccountID: s.exampleAccount.ID,
 		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+		sub.setFilter(&types.DataChangeSubscriptionFilter{MessageTypes: []string{types.ArchivedMessageType}})
+		s.service.connections.add(sub)
 
 		err = s.service.handleDataChange(ctx, examplePayload)
 		require.NoError(t, err)
+
+		assert.Empty(t, sub.outbox)
 	})
 
 	T.Run("with invalid JSON", func(t *testing.T) {
@@ -87,27 +221,16 @@ func Test_handleDataChange(T *testing.T) {
 		examplePayload, err := json.Marshal(msg)
 		require.NoError(t, err)
 
-		mc := &mockWebsocketConnection{}
-		mc.On(
-			"SetWriteDeadline",
-			mock.MatchedBy(func(x time.Time) bool { return true }),
-		).Return(nil)
-
-		mc.On(
-			"WriteMessage",
-			websocket.TextMessage,
-			examplePayload,
-		).Return(nil)
-
-		s.service.connections = map[string][]websocketConnection{
-			s.exampleUser.ID: {mc},
-		}
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+		s.service.connections.add(sub)
 
 		err = s.service.handleDataChange(ctx, examplePayload)
 		require.NoError(t, err)
+
+		assert.Empty(t, sub.outbox)
 	})
 
-	T.Run("with error setting write deadline", func(t *testing.T) {
+	T.Run("drops slow consumers", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
@@ -118,117 +241,213 @@ func Test_handleDataChange(T *testing.T) {
 		require.NoError(t, err)
 
 		mc := &mockWebsocketConnection{}
-		mc.On(
-			"SetWriteDeadline",
-			mock.MatchedBy(func(x time.Time) bool { return true }),
-		).Return(errors.New("blah"))
+		mc.On("Close").Return(nil)
 
-		s.service.connections = map[string][]websocketConnection{
-			s.exampleUser.ID: {mc},
+		sub := newSubscriber(mc, s.sessionCtxData)
+		s.service.connections.add(sub)
+		s.service.presence.connected(sub.sessionCtxData)
+
+		// nothing is writing, so the subscriber's buffer fills right up.
+		for i := 0; i < subscriberSendBufferSize; i++ {
+			require.True(t, sub.enqueue(examplePayload))
 		}
 
 		err = s.service.handleDataChange(ctx, examplePayload)
 		require.NoError(t, err)
+
+		assert.Empty(t, s.service.connections.forUser(s.exampleUser.ID))
+		assert.Zero(t, s.service.presence.connectionsForUser(s.exampleUser.ID))
+
+		mock.AssertExpectationsForObjects(t, mc)
 	})
 
-	T.Run("with error writing message", func(t *testing.T) {
+	T.Run("drops subscribers whose memberships change", func(t *testing.T) {
 		t.Parallel()
 
 		ctx := context.Background()
 		s := buildTestHelper(t)
 
-		msg := &types.DataChangeMessage{AttributableToUserID: s.exampleUser.ID}
+		msg := &types.DataChangeMessage{
+			DataType:    types.UserMembershipDataType,
+			MessageType: types.ArchivedMessageType,
+			UserMembership: &types.AccountUserMembership{
+				BelongsToUser:    s.exampleUser.ID,
+				BelongsToAccount: s.exampleAccount.ID,
+			},
+			AttributableToUserID:    fakes.BuildFakeUser().ID,
+			AttributableToAccountID: s.exampleAccount.ID,
+		}
 		examplePayload, err := json.Marshal(msg)
 		require.NoError(t, err)
 
 		mc := &mockWebsocketConnection{}
-		mc.On(
-			"SetWriteDeadline",
-			mock.MatchedBy(func(x time.Time) bool { return true }),
-		).Return(nil)
-
-		mc.On(
-			"WriteMessage",
-			websocket.TextMessage,
-			examplePayload,
-		).Return(errors.New("blah"))
-
-		s.service.connections = map[string][]websocketConnection{
-			s.exampleUser.ID: {mc},
-		}
+		mc.On("WriteControl", websocket.CloseMessage, mock.IsType([]byte{}), mock.IsType(time.Time{})).Return(nil)
+		mc.On("Close").Return(nil)
+
+		sub := newSubscriber(mc, s.sessionCtxData)
+		s.service.connections.add(sub)
+		s.service.presence.connected(sub.sessionCtxData)
+
+		stream := newEventStream(s.sessionCtxData, nil)
+		s.service.addEventStream(stream)
 
 		err = s.service.handleDataChange(ctx, examplePayload)
 		require.NoError(t, err)
-	})
-}
 
-func Test_removeConnection(T *testing.T) {
-	T.Parallel()
+		assert.Empty(t, s.service.connections.forUser(s.exampleUser.ID))
+		assert.Zero(t, s.service.presence.connectionsForUser(s.exampleUser.ID))
+		assert.Empty(t, sub.outbox)
+		assert.Empty(t, stream.events)
 
-	T.Run("standard", func(t *testing.T) {
+		select {
+		case <-stream.revoked:
+		default:
+			t.Fatal("event stream was not revoked")
+		}
+
+		mock.AssertExpectationsForObjects(t, mc)
+	})
+
+	T.Run("drops subscribers whose sessions are revoked", func(t *testing.T) {
 		t.Parallel()
 
-		toCreate := 5
-		connections := []websocketConnection{}
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		revokedSessionCtxData := *s.sessionCtxData
+		revokedSessionCtxData.UserSessionID = "revoked"
+		keptSessionCtxData := *s.sessionCtxData
+		keptSessionCtxData.UserSessionID = "kept"
 
-		for i := 0; i < toCreate; i++ {
-			connections = append(connections, &websocket.Conn{})
+		msg := &types.DataChangeMessage{
+			DataType:              types.UserSessionDataType,
+			MessageType:           types.RevokedMessageType,
+			UserSessionRevocation: &types.UserSessionRevocation{UserSessionID: revokedSessionCtxData.UserSessionID},
+			AttributableToUserID:  s.exampleUser.ID,
 		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		mc := &mockWebsocketConnection{}
+		mc.On("WriteControl", websocket.CloseMessage, mock.IsType([]byte{}), mock.IsType(time.Time{})).Return(nil)
+		mc.On("Close").Return(nil)
+
+		revokedSub := newSubscriber(mc, &revokedSessionCtxData)
+		s.service.connections.add(revokedSub)
+
+		keptSub := newSubscriber(&mockWebsocketConnection{}, &keptSessionCtxData)
+		s.service.connections.add(keptSub)
+
+		err = s.service.handleDataChange(ctx, examplePayload)
+		require.NoError(t, err)
 
-		connections = removeConnection(connections, 1)
+		assert.Equal(t, []*subscriber{keptSub}, s.service.connections.forUser(s.exampleUser.ID))
+		assertDelivered(t, keptSub, examplePayload)
 
-		require.Len(t, connections, toCreate-1)
+		mock.AssertExpectationsForObjects(t, mc)
 	})
+
 }
 
-func Test_pingConnections(T *testing.T) {
+func Test_readFromSubscriber(T *testing.T) {
 	T.Parallel()
 
 	T.Run("standard", func(t *testing.T) {
 		t.Parallel()
 
 		s := buildTestHelper(t)
-		s.service.pollDuration = time.Second / 10
+
+		exampleFilter := &types.DataChangeSubscriptionFilter{DataTypes: []string{string(types.ItemDataType)}}
+		filterPayload, err := json.Marshal(exampleFilter)
+		require.NoError(t, err)
 
 		mc := &mockWebsocketConnection{}
-		mc.On(
-			"WriteControl",
-			websocket.PingMessage,
-			[]byte("ping"),
-			mock.MatchedBy(func(x time.Time) bool { return true }),
-		).Return(nil)
-
-		s.service.connections = map[string][]websocketConnection{
-			s.exampleAccount.ID: {mc},
-		}
+		mc.On("ReadMessage").Return(websocket.TextMessage, filterPayload, nil).Once()
+		mc.On("ReadMessage").Return(0, []byte(nil), errors.New("blah")).Once()
+		mc.On("Close").Return(nil)
+
+		sub := newSubscriber(mc, s.sessionCtxData)
+		s.service.connections.add(sub)
 
-		go s.service.pingConnections()
+		s.service.readFromSubscriber(sub)
 
-		<-time.After(time.Second)
+		assert.Equal(t, exampleFilter, sub.filter)
+		assert.Empty(t, s.service.connections.forUser(s.exampleUser.ID))
+
+		mock.AssertExpectationsForObjects(t, mc)
 	})
 
-	T.Run("with error pinging", func(t *testing.T) {
+	T.Run("with item presence update", func(t *testing.T) {
 		t.Parallel()
 
 		s := buildTestHelper(t)
-		s.service.pollDuration = time.Second / 10
+		exampleItemID := fakes.BuildFakeItem().ID
+
+		presencePayload, err := json.Marshal(&types.ItemPresenceUpdateMessage{
+			Presence: &types.ItemPresenceUpdateInput{ItemID: exampleItemID, Activity: types.ViewingItemPresenceActivity},
+		})
+		require.NoError(t, err)
+
+		invalidPresencePayload, err := json.Marshal(&types.ItemPresenceUpdateMessage{
+			Presence: &types.ItemPresenceUpdateInput{ItemID: exampleItemID, Activity: t.Name()},
+		})
+		require.NoError(t, err)
+
+		publisher := &mockpublishers.Publisher{}
+		publisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.ViewingItemPresenceActivity && msg.ItemPresence.ItemID == exampleItemID
+			}),
+		).Return(nil).Once()
+		publisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.LeftItemPresenceActivity && msg.ItemPresence.ItemID == exampleItemID
+			}),
+		).Return(nil).Once()
+		s.service.itemPresencePublisher = publisher
 
 		mc := &mockWebsocketConnection{}
-		mc.On(
-			"WriteControl",
-			websocket.PingMessage,
-			[]byte("ping"),
-			mock.MatchedBy(func(x time.Time) bool { return true }),
-		).Return(errors.New("blah"))
-
-		s.service.connections = map[string][]websocketConnection{
-			s.exampleAccount.ID: {mc},
-		}
+		mc.On("ReadMessage").Return(websocket.TextMessage, invalidPresencePayload, nil).Once()
+		mc.On("ReadMessage").Return(websocket.TextMessage, presencePayload, nil).Once()
+		mc.On("ReadMessage").Return(0, []byte(nil), errors.New("blah")).Once()
+		mc.On("Close").Return(nil)
+
+		sub := newSubscriber(mc, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		s.service.readFromSubscriber(sub)
+
+		assert.Nil(t, sub.filter)
+
+		mock.AssertExpectationsForObjects(t, mc, publisher)
+	})
+
+	T.Run("ignores invalid filters", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		invalidFilterPayload, err := json.Marshal(&types.DataChangeSubscriptionFilter{DataTypes: []string{t.Name()}})
+		require.NoError(t, err)
+
+		mc := &mockWebsocketConnection{}
+		mc.On("ReadMessage").Return(websocket.TextMessage, []byte(`} not real JSON lol`), nil).Once()
+		mc.On("ReadMessage").Return(websocket.TextMessage, invalidFilterPayload, nil).Once()
+		mc.On("ReadMessage").Return(0, []byte(nil), errors.New("blah")).Once()
+		mc.On("Close").Return(nil)
+
+		sub := newSubscriber(mc, s.sessionCtxData)
+		s.service.connections.add(sub)
 
-		go s.service.pingConnections()
+		s.service.readFromSubscriber(sub)
 
-		<-time.After(time.Second)
+		assert.Nil(t, sub.filter)
+		assert.Empty(t, s.service.connections.forUser(s.exampleUser.ID))
 
-		assert.Empty(t, s.service.connections)
+		mock.AssertExpectationsForObjects(t, mc)
 	})
 }
@@ -0,0 +1,27 @@
+/*
+Package websockets provides HTTP handlers for subscribing to data changes, either by websocket or by server-sent events.
+
+# Running several replicas
+
+Any number of API replicas may serve subscriptions, provided they share a message queue. Each replica:
+
+  - consumes the data changes topic with a broadcast consumer, so that every replica sees every change, regardless
+    of which replicas hold connections that want it. Workers still compete for the same topic, so each change is
+    only written once.
+  - only delivers changes to the connections it holds, so no routing between replicas or sticky sessions are needed
+    for websockets.
+  - checks permissions as they were when a connection was opened, and drops the connections of anyone whose
+    memberships or permissions change, or whose session is revoked, as soon as it hears about it. Clients should
+    reconnect, at which point they're authorized afresh.
+  - drops consumers who fall more than a buffer's worth of messages behind, rather than letting them hold up
+    anyone else. Clients should reconnect and refetch whatever they care about when that happens.
+  - keeps its own replay buffer for server-sent events, so resuming from a Last-Event-ID is only guaranteed to
+    work against the replica that issued it. Clients resuming elsewhere may miss changes.
+  - consumes item presence updates the same way, so every replica knows who is viewing or editing every item, and
+    can answer for it. Each replica expires stale presence on its own.
+  - reports presence metrics (open connections, and the distinct users and accounts they belong to) for itself
+    alone. Sum them across replicas for totals. Counts per user or per account would give metrics one series per
+    user or account, without bound, so instead each replica reports the users and accounts with the most
+    connections to it to service admins, at /api/v1/admin/websockets/presence.
+*/
+package websockets
@@ -0,0 +1,413 @@
+package websockets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	// lastEventIDHeader is the header clients send to resume an event stream where they left off.
+	lastEventIDHeader = "Last-Event-ID"
+	// dataChangeEventName is the event name data changes are sent under in an event stream.
+	dataChangeEventName = "data_change"
+
+	// eventStreamWriteTimeout is how far each event stream pushes back its write deadline before waiting on its next
+	// write. The HTTP server's write timeout covers the whole response, so streams would be cut off without this.
+	eventStreamWriteTimeout = 10 * time.Second
+	// eventStreamFallbackLifetime is how long an event stream stays open when its write deadline can't be pushed
+	// back. We'd rather end those streams ourselves, and let the client resume them, than have them cut off mid-event.
+	eventStreamFallbackLifetime = 9 * time.Second
+	// eventStreamHeartbeatInterval is how often idle event streams get a comment, so proxies don't close them.
+	eventStreamHeartbeatInterval = 3 * time.Second
+	// eventStreamRetryInterval is how long clients should wait before resuming a stream we've ended.
+	eventStreamRetryInterval = 500 * time.Millisecond
+	// eventStreamBufferSize is how many events can be waiting to be written to a stream before it's ended.
+	eventStreamBufferSize = 64
+	// replayBufferSize is how many recent events are kept per account for resuming streams.
+	replayBufferSize = 256
+)
+
+var (
+	// errWriteDeadlineUnsupported indicates a response writer has no way to change its write deadline.
+	errWriteDeadlineUnsupported = errors.New("response writer does not support write deadlines")
+)
+
+type (
+	// writeDeadlineSetter is implemented by the standard library's response writers.
+	writeDeadlineSetter interface {
+		SetWriteDeadline(time.Time) error
+	}
+
+	// responseWriterUnwrapper is implemented by middleware that wraps response writers.
+	responseWriterUnwrapper interface {
+		Unwrap() http.ResponseWriter
+	}
+
+	// bufferedEvent is a data change that's been assigned an event ID.
+	bufferedEvent struct {
+		msg     *types.DataChangeMessage
+		payload []byte
+		id      uint64
+	}
+
+	// replayBuffer is a bounded history of recent data changes for each account, so that event streams can be
+	// resumed. Data changes that don't belong to an account are kept under the ID of the user they belong to.
+	replayBuffer struct {
+		events map[string][]*bufferedEvent
+		size   int
+		lastID uint64
+		hat    sync.Mutex
+	}
+
+	// eventStream is an open Server-Sent Events stream.
+	eventStream struct {
+		sessionCtxData *types.SessionContextData
+		filter         *types.DataChangeSubscriptionFilter
+		events         chan *bufferedEvent
+		overflowed     chan struct{}
+		revoked        chan struct{}
+		overflowOnce   sync.Once
+		revokeOnce     sync.Once
+	}
+)
+
+func newReplayBuffer(size int) *replayBuffer {
+	return &replayBuffer{
+		events: map[string][]*bufferedEvent{},
+		size:   size,
+	}
+}
+
+func replayBufferKey(msg *types.DataChangeMessage) string {
+	if msg.AttributableToAccountID != "" {
+		return msg.AttributableToAccountID
+	}
+
+	return msg.AttributableToUserID
+}
+
+// record assigns a data change an event ID and remembers it. Event IDs are based on the time they're assigned,
+// so that they keep increasing across restarts and are roughly comparable between server instances.
+func (b *replayBuffer) record(msg *types.DataChangeMessage, payload []byte) *bufferedEvent {
+	b.hat.Lock()
+	defer b.hat.Unlock()
+
+	id := uint64(time.Now().UnixNano())
+	if id <= b.lastID {
+		id = b.lastID + 1
+	}
+	b.lastID = id
+
+	event := &bufferedEvent{
+		id:      id,
+		msg:     msg,
+		payload: payload,
+	}
+
+	key := replayBufferKey(msg)
+	b.events[key] = append(b.events[key], event)
+	if len(b.events[key]) > b.size {
+		b.events[key] = b.events[key][len(b.events[key])-b.size:]
+	}
+
+	return event
+}
+
+// since returns every remembered event for the given keys with an ID greater than lastEventID, oldest first.
+func (b *replayBuffer) since(lastEventID uint64, bufferKeys ...string) []*bufferedEvent {
+	b.hat.Lock()
+	defer b.hat.Unlock()
+
+	events := []*bufferedEvent{}
+	for _, key := range bufferKeys {
+		for _, event := range b.events[key] {
+			if event.id > lastEventID {
+				events = append(events, event)
+			}
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].id < events[j].id
+	})
+
+	return events
+}
+
+func newEventStream(sessionCtxData *types.SessionContextData, filter *types.DataChangeSubscriptionFilter) *eventStream {
+	return &eventStream{
+		sessionCtxData: sessionCtxData,
+		filter:         filter,
+		events:         make(chan *bufferedEvent, eventStreamBufferSize),
+		overflowed:     make(chan struct{}),
+		revoked:        make(chan struct{}),
+	}
+}
+
+// bufferKeys returns the replay buffer keys relevant to the stream's owner.
+func (x *eventStream) bufferKeys() []string {
+	bufferKeys := []string{x.sessionCtxData.Requester.UserID}
+	for accountID := range x.sessionCtxData.AccountPermissions {
+		bufferKeys = append(bufferKeys, accountID)
+	}
+
+	return bufferKeys
+}
+
+func (x *eventStream) wants(msg *types.DataChangeMessage) bool {
+	return canReceive(x.sessionCtxData, msg) && x.filter.Matches(msg)
+}
+
+// send queues an event for the stream. Streams that fall too far behind are ended instead of having events
+// dropped, since their clients can resume them from the replay buffer.
+func (x *eventStream) send(event *bufferedEvent) {
+	select {
+	case x.events <- event:
+	default:
+		x.overflowOnce.Do(func() { close(x.overflowed) })
+	}
+}
+
+// revoke ends the stream, because its owner's permissions are no longer what they were when it was opened.
+func (x *eventStream) revoke() {
+	x.revokeOnce.Do(func() { close(x.revoked) })
+}
+
+// revokeEventStreams ends a user's event streams whose sessions are invalidated. They're removed right away, so
+// that nothing else is sent to them while they wind down.
+func (s *service) revokeEventStreams(userID string, invalidates func(*types.SessionContextData) bool) {
+	s.eventStreamsHat.Lock()
+	defer s.eventStreamsHat.Unlock()
+
+	kept := []*eventStream{}
+	for _, stream := range s.eventStreams[userID] {
+		if invalidates(stream.sessionCtxData) {
+			stream.revoke()
+			continue
+		}
+
+		kept = append(kept, stream)
+	}
+
+	if len(kept) == 0 {
+		delete(s.eventStreams, userID)
+	} else {
+		s.eventStreams[userID] = kept
+	}
+}
+
+func (s *service) broadcastToEventStreams(event *bufferedEvent) {
+	s.eventStreamsHat.RLock()
+	defer s.eventStreamsHat.RUnlock()
+
+	for _, streams := range s.eventStreams {
+		for _, stream := range streams {
+			if stream.wants(event.msg) {
+				stream.send(event)
+			}
+		}
+	}
+}
+
+func (s *service) addEventStream(stream *eventStream) {
+	userID := stream.sessionCtxData.Requester.UserID
+
+	s.eventStreamsHat.Lock()
+	defer s.eventStreamsHat.Unlock()
+
+	s.eventStreams[userID] = append(s.eventStreams[userID], stream)
+}
+
+func (s *service) removeEventStream(stream *eventStream) {
+	userID := stream.sessionCtxData.Requester.UserID
+
+	s.eventStreamsHat.Lock()
+	defer s.eventStreamsHat.Unlock()
+
+	for i, x := range s.eventStreams[userID] {
+		if x == stream {
+			s.eventStreams[userID][i] = s.eventStreams[userID][len(s.eventStreams[userID])-1]
+			s.eventStreams[userID] = s.eventStreams[userID][:len(s.eventStreams[userID])-1]
+			break
+		}
+	}
+
+	if len(s.eventStreams[userID]) == 0 {
+		delete(s.eventStreams, userID)
+	}
+}
+
+// writeEvent writes an event in the Server-Sent Events format.
+func writeEvent(res http.ResponseWriter, event *bufferedEvent) error {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "id: %d\nevent: %s\n", event.id, dataChangeEventName)
+	for _, line := range bytes.Split(bytes.TrimSpace(event.payload), []byte("\n")) {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	_, err := res.Write(b.Bytes())
+
+	return err
+}
+
+// extendWriteDeadline pushes a response's write deadline back to the given duration from now, looking through any
+// middleware wrapping it for a writer that can.
+func extendWriteDeadline(res http.ResponseWriter, d time.Duration) error {
+	for {
+		switch w := res.(type) {
+		case writeDeadlineSetter:
+			return w.SetWriteDeadline(time.Now().Add(d))
+		case responseWriterUnwrapper:
+			res = w.Unwrap()
+		default:
+			return errWriteDeadlineUnsupported
+		}
+	}
+}
+
+// EventStreamHandler streams data changes as Server-Sent Events, for clients that can't use websockets.
+func (s *service) EventStreamHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+	logger = sessionCtxData.AttachToLogger(logger)
+
+	filter := types.ExtractDataChangeSubscriptionFilter(req)
+	if filter != nil {
+		if err = filter.ValidateWithContext(ctx); err != nil {
+			logger.WithValue(keys.ValidationErrorKey, err).Debug("invalid subscription filter received")
+			s.encoderDecoder.EncodeErrorResponse(ctx, res, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var lastEventID uint64
+	if rawLastEventID := req.Header.Get(lastEventIDHeader); rawLastEventID != "" {
+		if lastEventID, err = strconv.ParseUint(rawLastEventID, 10, 64); err != nil {
+			logger.WithValue("last_event_id", rawLastEventID).Debug("invalid last event ID received")
+			s.encoderDecoder.EncodeErrorResponse(ctx, res, "invalid Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	flusher, ok := res.(http.Flusher)
+	if !ok {
+		logger.Info("response writer does not support streaming")
+		s.encoderDecoder.EncodeUnspecifiedInternalServerErrorResponse(ctx, res)
+		return
+	}
+
+	// the stream is registered before replaying, so nothing that happens in between is missed.
+	stream := newEventStream(sessionCtxData, filter)
+	s.addEventStream(stream)
+	defer s.removeEventStream(stream)
+
+	var lifetime <-chan time.Time
+
+	canExtendDeadline := extendWriteDeadline(res, eventStreamWriteTimeout) == nil
+	if !canExtendDeadline {
+		logger.Debug("event stream write deadline can't be extended")
+
+		lifetimeTimer := time.NewTimer(eventStreamFallbackLifetime)
+		defer lifetimeTimer.Stop()
+
+		lifetime = lifetimeTimer.C
+	}
+
+	res.Header().Set("Content-Type", "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if _, err = fmt.Fprintf(res, "retry: %d\n\n", eventStreamRetryInterval.Milliseconds()); err != nil {
+		observability.AcknowledgeError(err, logger, span, "writing event stream preamble")
+		return
+	}
+
+	if lastEventID != 0 {
+		for _, event := range s.replayBuffer.since(lastEventID, stream.bufferKeys()...) {
+			if !stream.wants(event.msg) {
+				continue
+			}
+
+			if err = writeEvent(res, event); err != nil {
+				observability.AcknowledgeError(err, logger, span, "replaying event")
+				return
+			}
+
+			lastEventID = event.id
+		}
+	}
+
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(eventStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		if canExtendDeadline {
+			if err = extendWriteDeadline(res, eventStreamWriteTimeout); err != nil {
+				observability.AcknowledgeError(err, logger, span, "extending event stream write deadline")
+				return
+			}
+		}
+
+		select {
+		case <-req.Context().Done():
+			return
+		case <-lifetime:
+			return
+		case <-stream.overflowed:
+			logger.Info("event stream fell behind")
+			return
+		case <-stream.revoked:
+			logger.Info("event stream's permissions changed")
+			return
+		case <-heartbeat.C:
+			if _, err = fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				observability.AcknowledgeError(err, logger, span, "writing heartbeat")
+				return
+			}
+		case event := <-stream.events:
+			// events that were already replayed may also have been queued.
+			if event.id <= lastEventID {
+				continue
+			}
+
+			if err = writeEvent(res, event); err != nil {
+				observability.AcknowledgeError(err, logger, span, "writing event")
+				return
+			}
+
+			lastEventID = event.id
+		}
+
+		flusher.Flush()
+	}
+}
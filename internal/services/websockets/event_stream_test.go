@@ -0,0 +1,300 @@
+package websockets
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func buildExampleItemChange(t *testing.T, accountID string) (*types.DataChangeMessage, []byte) {
+	t.Helper()
+
+	msg := &types.DataChangeMessage{
+		DataType:                types.ItemDataType,
+		MessageType:             types.CreatedMessageType,
+		Item:                    fakes.BuildFakeItem(),
+		AttributableToUserID:    fakes.BuildFakeUser().ID,
+		AttributableToAccountID: accountID,
+	}
+
+	payload, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	return msg, payload
+}
+
+// readEventIDs reads events from a stream until it has seen n of them, and returns their IDs.
+func readEventIDs(t *testing.T, scanner *bufio.Scanner, n int) []string {
+	t.Helper()
+
+	ids := []string{}
+	for len(ids) < n && scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "id: ") {
+			ids = append(ids, strings.TrimPrefix(line, "id: "))
+		}
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return ids
+}
+
+func Test_replayBuffer(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		b := newReplayBuffer(replayBufferSize)
+		exampleAccountID := fakes.BuildFakeAccount().ID
+
+		first := b.record(buildExampleItemChange(t, exampleAccountID))
+		second := b.record(buildExampleItemChange(t, exampleAccountID))
+		third := b.record(buildExampleItemChange(t, fakes.BuildFakeAccount().ID))
+
+		assert.True(t, first.id < second.id)
+		assert.True(t, second.id < third.id)
+
+		assert.Equal(t, []*bufferedEvent{first, second}, b.since(0, exampleAccountID))
+		assert.Equal(t, []*bufferedEvent{second}, b.since(first.id, exampleAccountID))
+		assert.Empty(t, b.since(second.id, exampleAccountID))
+	})
+
+	T.Run("is bounded", func(t *testing.T) {
+		t.Parallel()
+
+		b := newReplayBuffer(2)
+		exampleAccountID := fakes.BuildFakeAccount().ID
+
+		b.record(buildExampleItemChange(t, exampleAccountID))
+		second := b.record(buildExampleItemChange(t, exampleAccountID))
+		third := b.record(buildExampleItemChange(t, exampleAccountID))
+
+		assert.Equal(t, []*bufferedEvent{second, third}, b.since(0, exampleAccountID))
+	})
+
+	T.Run("keeps changes without an account under their user", func(t *testing.T) {
+		t.Parallel()
+
+		b := newReplayBuffer(replayBufferSize)
+
+		msg, payload := buildExampleItemChange(t, "")
+		event := b.record(msg, payload)
+
+		assert.Equal(t, []*bufferedEvent{event}, b.since(0, msg.AttributableToUserID))
+	})
+}
+
+type unwrappableResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w *unwrappableResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+type deadlineRecordingResponseWriter struct {
+	*httptest.ResponseRecorder
+	deadline time.Time
+}
+
+func (w *deadlineRecordingResponseWriter) SetWriteDeadline(deadline time.Time) error {
+	w.deadline = deadline
+	return nil
+}
+
+func Test_extendWriteDeadline(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		res := &deadlineRecordingResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+
+		assert.NoError(t, extendWriteDeadline(res, time.Minute))
+		assert.WithinDuration(t, time.Now().Add(time.Minute), res.deadline, time.Second)
+	})
+
+	T.Run("through middleware", func(t *testing.T) {
+		t.Parallel()
+
+		res := &deadlineRecordingResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+
+		assert.NoError(t, extendWriteDeadline(&unwrappableResponseWriter{ResponseWriter: res}, time.Minute))
+		assert.False(t, res.deadline.IsZero())
+	})
+
+	T.Run("with unsupported response writer", func(t *testing.T) {
+		t.Parallel()
+
+		assert.ErrorIs(t, extendWriteDeadline(httptest.NewRecorder(), time.Minute), errWriteDeadlineUnsupported)
+	})
+}
+
+func TestWebsocketsService_EventStreamHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		helper := buildTestHelper(t)
+
+		ts := httptest.NewServer(http.HandlerFunc(helper.service.EventStreamHandler))
+		t.Cleanup(ts.Close)
+
+		streamCtx, cancel := context.WithCancel(ctx)
+		t.Cleanup(cancel)
+
+		req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = res.Body.Close() })
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+		// wait for the stream to be registered before anything happens.
+		require.Eventually(t, func() bool {
+			helper.service.eventStreamsHat.RLock()
+			defer helper.service.eventStreamsHat.RUnlock()
+
+			return len(helper.service.eventStreams[helper.exampleUser.ID]) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		_, payload := buildExampleItemChange(t, helper.exampleAccount.ID)
+		require.NoError(t, helper.service.handleDataChange(ctx, payload))
+
+		_, irrelevantPayload := buildExampleItemChange(t, fakes.BuildFakeAccount().ID)
+		require.NoError(t, helper.service.handleDataChange(ctx, irrelevantPayload))
+
+		_, otherPayload := buildExampleItemChange(t, helper.exampleAccount.ID)
+		require.NoError(t, helper.service.handleDataChange(ctx, otherPayload))
+
+		expected := []string{}
+		for _, event := range helper.service.replayBuffer.since(0, helper.exampleAccount.ID) {
+			expected = append(expected, fmt.Sprintf("%d", event.id))
+		}
+
+		assert.Equal(t, expected, readEventIDs(t, bufio.NewScanner(res.Body), 2))
+	})
+
+	T.Run("outlives the server's write timeout", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		helper := buildTestHelper(t)
+
+		ts := httptest.NewUnstartedServer(http.HandlerFunc(helper.service.EventStreamHandler))
+		ts.Config.WriteTimeout = 100 * time.Millisecond
+		ts.Start()
+		t.Cleanup(ts.Close)
+
+		streamCtx, cancel := context.WithCancel(ctx)
+		t.Cleanup(cancel)
+
+		req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = res.Body.Close() })
+
+		require.Eventually(t, func() bool {
+			helper.service.eventStreamsHat.RLock()
+			defer helper.service.eventStreamsHat.RUnlock()
+
+			return len(helper.service.eventStreams[helper.exampleUser.ID]) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		time.Sleep(3 * ts.Config.WriteTimeout)
+
+		_, payload := buildExampleItemChange(t, helper.exampleAccount.ID)
+		require.NoError(t, helper.service.handleDataChange(ctx, payload))
+
+		assert.Len(t, readEventIDs(t, bufio.NewScanner(res.Body), 1), 1)
+	})
+
+	T.Run("resumes from last event ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		helper := buildTestHelper(t)
+
+		first := helper.service.replayBuffer.record(buildExampleItemChange(t, helper.exampleAccount.ID))
+		second := helper.service.replayBuffer.record(buildExampleItemChange(t, helper.exampleAccount.ID))
+		helper.service.replayBuffer.record(buildExampleItemChange(t, fakes.BuildFakeAccount().ID))
+		third := helper.service.replayBuffer.record(buildExampleItemChange(t, helper.exampleAccount.ID))
+
+		ts := httptest.NewServer(http.HandlerFunc(helper.service.EventStreamHandler))
+		t.Cleanup(ts.Close)
+
+		streamCtx, cancel := context.WithCancel(ctx)
+		t.Cleanup(cancel)
+
+		req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set(lastEventIDHeader, fmt.Sprintf("%d", first.id))
+
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = res.Body.Close() })
+
+		expected := []string{fmt.Sprintf("%d", second.id), fmt.Sprintf("%d", third.id)}
+
+		assert.Equal(t, expected, readEventIDs(t, bufio.NewScanner(res.Body), 2))
+	})
+
+	T.Run("with error fetching session context", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
+
+		helper.service.sessionContextDataFetcher = func(*http.Request) (*types.SessionContextData, error) {
+			return nil, fmt.Errorf("blah")
+		}
+
+		helper.service.EventStreamHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusUnauthorized, helper.res.Code)
+	})
+
+	T.Run("with invalid filter", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.req.URL.RawQuery = (&types.DataChangeSubscriptionFilter{DataTypes: []string{t.Name()}}).ToValues().Encode()
+
+		helper.service.EventStreamHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+
+	T.Run("with invalid last event ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper(t)
+		helper.req.Header.Set(lastEventIDHeader, t.Name())
+
+		helper.service.EventStreamHandler(helper.res, helper.req)
+
+		assert.Equal(t, http.StatusBadRequest, helper.res.Code)
+	})
+}
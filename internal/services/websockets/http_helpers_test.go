@@ -19,6 +19,7 @@ type websocketsServiceHTTPRoutesTestHelper struct {
 	req            *http.Request
 	res            *httptest.ResponseRecorder
 	service        *service
+	sessionCtxData *types.SessionContextData
 	exampleUser    *types.User
 	exampleAccount *types.Account
 }
@@ -37,6 +38,7 @@ func buildTestHelper(t *testing.T) *websocketsServiceHTTPRoutesTestHelper {
 	sessionCtxData := &types.SessionContextData{
 		Requester: types.RequesterInfo{
 			UserID:                helper.exampleUser.ID,
+			Username:              helper.exampleUser.Username,
 			Reputation:            helper.exampleUser.ServiceAccountStatus,
 			ReputationExplanation: helper.exampleUser.ReputationExplanation,
 			ServicePermissions:    authorization.NewServiceRolePermissionChecker(helper.exampleUser.ServiceRoles...),
@@ -47,6 +49,8 @@ func buildTestHelper(t *testing.T) *websocketsServiceHTTPRoutesTestHelper {
 		},
 	}
 
+	helper.sessionCtxData = sessionCtxData
+
 	helper.service.encoderDecoder = encoding.ProvideServerEncoderDecoder(logging.NewNoopLogger(), encoding.ContentTypeJSON)
 	helper.service.sessionContextDataFetcher = func(*http.Request) (*types.SessionContextData, error) {
 		return sessionCtxData, nil
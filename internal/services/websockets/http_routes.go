@@ -30,6 +30,7 @@ func (s *service) SubscribeHandler(res http.ResponseWriter, req *http.Request) {
 	if err != nil {
 		logger.Error(err, "checking websocket subscription request for cookies")
 		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
 	}
 
 	wsHeader := http.Header{}
@@ -43,13 +44,5 @@ func (s *service) SubscribeHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	s.connectionsHat.Lock()
-	defer s.connectionsHat.Unlock()
-
-	_, ok := s.connections[sessionCtxData.Requester.UserID]
-	if ok {
-		s.connections[sessionCtxData.Requester.UserID] = append(s.connections[sessionCtxData.Requester.UserID], conn)
-	} else {
-		s.connections[sessionCtxData.Requester.UserID] = []websocketConnection{conn}
-	}
+	s.register(newSubscriber(conn, sessionCtxData))
 }
@@ -0,0 +1,318 @@
+package websockets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	// itemPresenceLifetime is how long someone is considered to be viewing or editing an item after they last
+	// said so. Clients are expected to repeat themselves well within this window.
+	itemPresenceLifetime = 30 * time.Second
+	// itemPresenceSweepInterval is how often expired presence is cleared out.
+	itemPresenceSweepInterval = 5 * time.Second
+)
+
+// itemPresenceRegistry keeps track of who is viewing or editing which items, on which connections. Every replica
+// sees every presence update, so every replica's registry ends up with the same contents.
+type itemPresenceRegistry struct {
+	// viewers holds presence by item, then by user, then by connection.
+	viewers map[string]map[string]map[string]*types.ItemPresence
+	hat     sync.Mutex
+}
+
+func newItemPresenceRegistry() *itemPresenceRegistry {
+	return &itemPresenceRegistry{viewers: map[string]map[string]map[string]*types.ItemPresence{}}
+}
+
+func itemPresenceKey(accountID, itemID string) string {
+	return accountID + "/" + itemID
+}
+
+// summarizeItemPresence combines what someone's connections say they're doing with an item: they're editing it
+// if any of their connections are, for as long as the longest lived of them. It returns nil if they have none.
+func summarizeItemPresence(connections map[string]*types.ItemPresence) *types.ItemPresence {
+	var summary *types.ItemPresence
+
+	for _, presence := range connections {
+		if summary == nil {
+			x := *presence
+			x.ConnectionID = ""
+			summary = &x
+
+			continue
+		}
+
+		if presence.Activity == types.EditingItemPresenceActivity {
+			summary.Activity = types.EditingItemPresenceActivity
+		}
+
+		if presence.ExpiresOn > summary.ExpiresOn {
+			summary.ExpiresOn = presence.ExpiresOn
+		}
+	}
+
+	return summary
+}
+
+// itemPresenceChange compares someone's presence before and after an update, and returns what to tell everyone
+// else, if anything. Renewals don't count.
+func itemPresenceChange(before, after *types.ItemPresence) (*types.ItemPresence, bool) {
+	switch {
+	case after == nil && before == nil:
+		return nil, false
+	case after == nil:
+		return &types.ItemPresence{
+			ItemID:           before.ItemID,
+			Activity:         types.LeftItemPresenceActivity,
+			UserID:           before.UserID,
+			Username:         before.Username,
+			BelongsToAccount: before.BelongsToAccount,
+		}, true
+	case before == nil, before.Activity != after.Activity:
+		return after, true
+	default:
+		return nil, false
+	}
+}
+
+// prune removes empty entries for a user's presence on an item.
+func (r *itemPresenceRegistry) prune(key, userID string) {
+	if len(r.viewers[key][userID]) == 0 {
+		delete(r.viewers[key], userID)
+	}
+
+	if len(r.viewers[key]) == 0 {
+		delete(r.viewers, key)
+	}
+}
+
+// update records a presence update for one connection, and returns what changed about its user's presence, if
+// anything did. Someone only leaves an item once none of their connections are viewing or editing it.
+func (r *itemPresenceRegistry) update(presence *types.ItemPresence) (*types.ItemPresence, bool) {
+	key := itemPresenceKey(presence.BelongsToAccount, presence.ItemID)
+
+	r.hat.Lock()
+	defer r.hat.Unlock()
+
+	before := summarizeItemPresence(r.viewers[key][presence.UserID])
+
+	if presence.Activity == types.LeftItemPresenceActivity {
+		delete(r.viewers[key][presence.UserID], presence.ConnectionID)
+		r.prune(key, presence.UserID)
+	} else {
+		if r.viewers[key] == nil {
+			r.viewers[key] = map[string]map[string]*types.ItemPresence{}
+		}
+
+		if r.viewers[key][presence.UserID] == nil {
+			r.viewers[key][presence.UserID] = map[string]*types.ItemPresence{}
+		}
+
+		r.viewers[key][presence.UserID][presence.ConnectionID] = presence
+	}
+
+	return itemPresenceChange(before, summarizeItemPresence(r.viewers[key][presence.UserID]))
+}
+
+// forItem returns who is viewing or editing an item, ordered by user ID.
+func (r *itemPresenceRegistry) forItem(accountID, itemID string, now time.Time) []*types.ItemPresence {
+	r.hat.Lock()
+	defer r.hat.Unlock()
+
+	out := []*types.ItemPresence{}
+	for _, connections := range r.viewers[itemPresenceKey(accountID, itemID)] {
+		current := map[string]*types.ItemPresence{}
+		for connectionID, presence := range connections {
+			if presence.ExpiresOn > uint64(now.Unix()) {
+				current[connectionID] = presence
+			}
+		}
+
+		if summary := summarizeItemPresence(current); summary != nil {
+			out = append(out, summary)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].UserID < out[j].UserID
+	})
+
+	return out
+}
+
+// expire removes presence that hasn't been renewed, and returns what that changed about who is doing what.
+func (r *itemPresenceRegistry) expire(now time.Time) []*types.ItemPresence {
+	r.hat.Lock()
+	defer r.hat.Unlock()
+
+	changes := []*types.ItemPresence{}
+	for key, users := range r.viewers {
+		for userID, connections := range users {
+			before := summarizeItemPresence(connections)
+
+			for connectionID, presence := range connections {
+				if presence.ExpiresOn <= uint64(now.Unix()) {
+					delete(connections, connectionID)
+				}
+			}
+
+			if change, changed := itemPresenceChange(before, summarizeItemPresence(connections)); changed {
+				changes = append(changes, change)
+			}
+
+			r.prune(key, userID)
+		}
+	}
+
+	return changes
+}
+
+func buildItemPresenceMessage(presence *types.ItemPresence) *types.DataChangeMessage {
+	return &types.DataChangeMessage{
+		DataType:                types.ItemPresenceDataType,
+		MessageType:             presence.Activity,
+		ItemPresence:            presence,
+		AttributableToUserID:    presence.UserID,
+		AttributableToAccountID: presence.BelongsToAccount,
+	}
+}
+
+// announceItemPresence publishes what a subscriber says they're doing with an item in their active account.
+func (s *service) announceItemPresence(ctx context.Context, sub *subscriber, input *types.ItemPresenceUpdateInput) {
+	ctx, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	accountID := sub.sessionCtxData.ActiveAccountID
+	logger := s.logger.WithValue(keys.UserIDKey, sub.sessionCtxData.Requester.UserID).
+		WithValue(keys.AccountIDKey, accountID).
+		WithValue(keys.ItemIDKey, input.ItemID)
+
+	if perms, ok := sub.sessionCtxData.AccountPermissions[accountID]; !ok || perms == nil || !perms.HasPermission(authorization.ReadItemsPermission) {
+		logger.Debug("ignoring item presence from user who can't read items")
+		return
+	}
+
+	presence := &types.ItemPresence{
+		ItemID:           input.ItemID,
+		Activity:         input.Activity,
+		UserID:           sub.sessionCtxData.Requester.UserID,
+		Username:         sub.sessionCtxData.Requester.Username,
+		ConnectionID:     sub.id,
+		BelongsToAccount: accountID,
+		ExpiresOn:        uint64(time.Now().Add(itemPresenceLifetime).Unix()),
+	}
+
+	if input.Activity == types.LeftItemPresenceActivity {
+		sub.forgetItem(input.ItemID)
+	} else {
+		sub.rememberItem(input.ItemID)
+	}
+
+	if err := s.itemPresencePublisher.Publish(ctx, buildItemPresenceMessage(presence)); err != nil {
+		observability.AcknowledgeError(err, logger, span, "publishing item presence")
+	}
+}
+
+// withdrawItemPresence announces that a subscriber who has gone away has left every item they were looking at.
+func (s *service) withdrawItemPresence(sub *subscriber) {
+	for _, itemID := range sub.rememberedItems() {
+		s.announceItemPresence(context.Background(), sub, &types.ItemPresenceUpdateInput{
+			ItemID:   itemID,
+			Activity: types.LeftItemPresenceActivity,
+		})
+	}
+}
+
+// handleItemPresenceChange records a presence update from any replica, and tells local subscribers about it if
+// anything changed.
+func (s *service) handleItemPresenceChange(ctx context.Context, payload []byte) error {
+	_, span := s.tracer.StartSpan(ctx)
+	defer span.End()
+
+	var msg *types.DataChangeMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		observability.AcknowledgeError(err, s.logger, span, "unmarshalling item presence message")
+		return err
+	}
+
+	if msg.ItemPresence == nil {
+		return nil
+	}
+
+	if change, changed := s.itemPresence.update(msg.ItemPresence); changed {
+		s.deliverItemPresence(change)
+	}
+
+	return nil
+}
+
+// deliverItemPresence tells local subscribers about a change in who is doing what.
+func (s *service) deliverItemPresence(presence *types.ItemPresence) {
+	msg := buildItemPresenceMessage(presence)
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		s.logger.Error(err, "marshalling item presence")
+		return
+	}
+
+	s.deliver(msg, payload)
+}
+
+// sweepItemPresence clears out expired presence, and tells local subscribers what changed. Every replica sweeps
+// its own registry, so nothing needs to be published.
+func (s *service) sweepItemPresence(now time.Time) {
+	for _, presence := range s.itemPresence.expire(now) {
+		s.deliverItemPresence(presence)
+	}
+}
+
+func (s *service) expireItemPresence() {
+	ticker := time.NewTicker(itemPresenceSweepInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		s.sweepItemPresence(now)
+	}
+}
+
+// ItemPresenceHandler returns who is currently viewing or editing an item.
+func (s *service) ItemPresenceHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+
+	// determine item ID.
+	itemID := s.itemIDFetcher(req)
+	tracing.AttachItemIDToSpan(span, itemID)
+
+	x := &types.ItemPresenceList{
+		Viewers: s.itemPresence.forItem(sessionCtxData.ActiveAccountID, itemID, time.Now()),
+	}
+
+	// encode our response and peace.
+	s.encoderDecoder.RespondWithData(ctx, res, x)
+}
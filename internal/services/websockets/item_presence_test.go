@@ -0,0 +1,480 @@
+package websockets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mockpublishers "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers/mock"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+func buildExampleItemPresence(accountID, activity string, expiresOn time.Time) *types.ItemPresence {
+	exampleUser := fakes.BuildFakeUser()
+
+	return &types.ItemPresence{
+		ItemID:           fakes.BuildFakeItem().ID,
+		Activity:         activity,
+		UserID:           exampleUser.ID,
+		Username:         exampleUser.Username,
+		BelongsToAccount: accountID,
+		ExpiresOn:        uint64(expiresOn.Unix()),
+	}
+}
+
+func buildItemPresencePayload(t *testing.T, presence *types.ItemPresence) []byte {
+	t.Helper()
+
+	payload, err := json.Marshal(buildItemPresenceMessage(presence))
+	require.NoError(t, err)
+
+	return payload
+}
+
+func Test_itemPresenceRegistry(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		r := newItemPresenceRegistry()
+		now := time.Now()
+		exampleAccountID := fakes.BuildFakeAccount().ID
+
+		viewing := buildExampleItemPresence(exampleAccountID, types.ViewingItemPresenceActivity, now.Add(itemPresenceLifetime))
+		change, changed := r.update(viewing)
+		assert.True(t, changed)
+		assert.Equal(t, viewing, change)
+
+		renewed := *viewing
+		renewed.ExpiresOn++
+		_, changed = r.update(&renewed)
+		assert.False(t, changed)
+
+		editing := renewed
+		editing.Activity = types.EditingItemPresenceActivity
+		_, changed = r.update(&editing)
+		assert.True(t, changed)
+
+		assert.Equal(t, []*types.ItemPresence{&editing}, r.forItem(exampleAccountID, viewing.ItemID, now))
+		assert.Empty(t, r.forItem(fakes.BuildFakeAccount().ID, viewing.ItemID, now))
+
+		left := editing
+		left.Activity = types.LeftItemPresenceActivity
+		change, changed = r.update(&left)
+		assert.True(t, changed)
+		assert.Equal(t, &types.ItemPresence{
+			ItemID:           left.ItemID,
+			Activity:         types.LeftItemPresenceActivity,
+			UserID:           left.UserID,
+			Username:         left.Username,
+			BelongsToAccount: left.BelongsToAccount,
+		}, change)
+
+		_, changed = r.update(&left)
+		assert.False(t, changed)
+
+		assert.Empty(t, r.forItem(exampleAccountID, viewing.ItemID, now))
+		assert.Empty(t, r.viewers)
+	})
+
+	T.Run("tracks presence per connection", func(t *testing.T) {
+		t.Parallel()
+
+		r := newItemPresenceRegistry()
+		now := time.Now()
+		exampleAccountID := fakes.BuildFakeAccount().ID
+
+		firstTab := buildExampleItemPresence(exampleAccountID, types.ViewingItemPresenceActivity, now.Add(itemPresenceLifetime))
+		firstTab.ConnectionID = "first"
+		secondTab := *firstTab
+		secondTab.ConnectionID = "second"
+		secondTab.Activity = types.EditingItemPresenceActivity
+
+		_, changed := r.update(firstTab)
+		assert.True(t, changed)
+
+		// someone editing in one tab is editing, whatever their other tabs are doing.
+		change, changed := r.update(&secondTab)
+		assert.True(t, changed)
+		assert.Equal(t, types.EditingItemPresenceActivity, change.Activity)
+		assert.Empty(t, change.ConnectionID)
+
+		leftSecondTab := secondTab
+		leftSecondTab.Activity = types.LeftItemPresenceActivity
+		change, changed = r.update(&leftSecondTab)
+		assert.True(t, changed)
+		assert.Equal(t, types.ViewingItemPresenceActivity, change.Activity)
+
+		require.Len(t, r.forItem(exampleAccountID, firstTab.ItemID, now), 1)
+
+		leftFirstTab := *firstTab
+		leftFirstTab.Activity = types.LeftItemPresenceActivity
+		change, changed = r.update(&leftFirstTab)
+		assert.True(t, changed)
+		assert.Equal(t, types.LeftItemPresenceActivity, change.Activity)
+
+		assert.Empty(t, r.viewers)
+	})
+
+	T.Run("orders viewers by user ID", func(t *testing.T) {
+		t.Parallel()
+
+		r := newItemPresenceRegistry()
+		now := time.Now()
+		exampleAccountID := fakes.BuildFakeAccount().ID
+
+		first := buildExampleItemPresence(exampleAccountID, types.ViewingItemPresenceActivity, now.Add(itemPresenceLifetime))
+		second := buildExampleItemPresence(exampleAccountID, types.EditingItemPresenceActivity, now.Add(itemPresenceLifetime))
+		second.ItemID = first.ItemID
+
+		if second.UserID < first.UserID {
+			first, second = second, first
+		}
+
+		r.update(second)
+		r.update(first)
+
+		assert.Equal(t, []*types.ItemPresence{first, second}, r.forItem(exampleAccountID, first.ItemID, now))
+	})
+
+	T.Run("expires presence", func(t *testing.T) {
+		t.Parallel()
+
+		r := newItemPresenceRegistry()
+		now := time.Now()
+		exampleAccountID := fakes.BuildFakeAccount().ID
+
+		stale := buildExampleItemPresence(exampleAccountID, types.EditingItemPresenceActivity, now)
+		fresh := buildExampleItemPresence(exampleAccountID, types.ViewingItemPresenceActivity, now.Add(itemPresenceLifetime))
+
+		r.update(stale)
+		r.update(fresh)
+
+		assert.Empty(t, r.forItem(exampleAccountID, stale.ItemID, now))
+
+		expected := []*types.ItemPresence{
+			{
+				ItemID:           stale.ItemID,
+				Activity:         types.LeftItemPresenceActivity,
+				UserID:           stale.UserID,
+				Username:         stale.Username,
+				BelongsToAccount: stale.BelongsToAccount,
+			},
+		}
+
+		assert.Equal(t, expected, r.expire(now))
+		assert.Empty(t, r.expire(now))
+		assert.Equal(t, []*types.ItemPresence{fresh}, r.forItem(exampleAccountID, fresh.ItemID, now))
+	})
+}
+
+func Test_announceItemPresence(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+		exampleItemID := fakes.BuildFakeItem().ID
+
+		publisher := &mockpublishers.Publisher{}
+		publisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.DataType == types.ItemPresenceDataType &&
+					msg.MessageType == types.EditingItemPresenceActivity &&
+					msg.ItemPresence.ItemID == exampleItemID &&
+					msg.ItemPresence.UserID == s.exampleUser.ID &&
+					msg.ItemPresence.Username == s.exampleUser.Username &&
+					msg.ItemPresence.ConnectionID != "" &&
+					msg.AttributableToAccountID == s.exampleAccount.ID
+			}),
+		).Return(nil)
+		s.service.itemPresencePublisher = publisher
+
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+
+		s.service.announceItemPresence(ctx, sub, &types.ItemPresenceUpdateInput{
+			ItemID:   exampleItemID,
+			Activity: types.EditingItemPresenceActivity,
+		})
+
+		assert.Equal(t, []string{exampleItemID}, sub.rememberedItems())
+
+		mock.AssertExpectationsForObjects(t, publisher)
+	})
+
+	T.Run("forgets items that have been left", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+		exampleItemID := fakes.BuildFakeItem().ID
+
+		publisher := &mockpublishers.Publisher{}
+		publisher.On("Publish", testutils.ContextMatcher, mock.AnythingOfType("*types.DataChangeMessage")).Return(nil).Twice()
+		s.service.itemPresencePublisher = publisher
+
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+
+		s.service.announceItemPresence(ctx, sub, &types.ItemPresenceUpdateInput{ItemID: exampleItemID, Activity: types.ViewingItemPresenceActivity})
+		s.service.announceItemPresence(ctx, sub, &types.ItemPresenceUpdateInput{ItemID: exampleItemID, Activity: types.LeftItemPresenceActivity})
+
+		assert.Empty(t, sub.rememberedItems())
+
+		mock.AssertExpectationsForObjects(t, publisher)
+	})
+
+	T.Run("without membership in active account", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		publisher := &mockpublishers.Publisher{}
+		s.service.itemPresencePublisher = publisher
+
+		sessionCtxData := buildExampleSessionContextData(s.exampleAccount.ID)
+		sessionCtxData.ActiveAccountID = fakes.BuildFakeAccount().ID
+
+		sub := newSubscriber(&mockWebsocketConnection{}, sessionCtxData)
+
+		s.service.announceItemPresence(ctx, sub, &types.ItemPresenceUpdateInput{
+			ItemID:   fakes.BuildFakeItem().ID,
+			Activity: types.ViewingItemPresenceActivity,
+		})
+
+		assert.Empty(t, sub.rememberedItems())
+
+		mock.AssertExpectationsForObjects(t, publisher)
+	})
+
+	T.Run("with error publishing", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		publisher := &mockpublishers.Publisher{}
+		publisher.On("Publish", testutils.ContextMatcher, mock.AnythingOfType("*types.DataChangeMessage")).Return(errors.New("blah"))
+		s.service.itemPresencePublisher = publisher
+
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+
+		s.service.announceItemPresence(ctx, sub, &types.ItemPresenceUpdateInput{
+			ItemID:   fakes.BuildFakeItem().ID,
+			Activity: types.ViewingItemPresenceActivity,
+		})
+
+		mock.AssertExpectationsForObjects(t, publisher)
+	})
+}
+
+func Test_withdrawItemPresence(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		exampleItemID := fakes.BuildFakeItem().ID
+
+		publisher := &mockpublishers.Publisher{}
+		publisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(msg *types.DataChangeMessage) bool {
+				return msg.MessageType == types.LeftItemPresenceActivity && msg.ItemPresence.ItemID == exampleItemID
+			}),
+		).Return(nil)
+		s.service.itemPresencePublisher = publisher
+
+		mc := &mockWebsocketConnection{}
+		mc.On("Close").Return(nil)
+
+		sub := newSubscriber(mc, s.sessionCtxData)
+		sub.rememberItem(exampleItemID)
+		s.service.connections.add(sub)
+
+		s.service.dropSubscriber(sub)
+
+		assert.Empty(t, sub.rememberedItems())
+
+		mock.AssertExpectationsForObjects(t, publisher, mc)
+	})
+}
+
+func Test_handleItemPresenceChange(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		presence := buildExampleItemPresence(s.exampleAccount.ID, types.EditingItemPresenceActivity, time.Now().Add(itemPresenceLifetime))
+		payload := buildItemPresencePayload(t, presence)
+
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		require.NoError(t, s.service.handleItemPresenceChange(ctx, payload))
+		assertDelivered(t, sub, payload)
+
+		// renewals aren't worth telling anybody about.
+		require.NoError(t, s.service.handleItemPresenceChange(ctx, payload))
+		assert.Empty(t, sub.outbox)
+
+		assert.Equal(t, []*types.ItemPresence{presence}, s.service.itemPresence.forItem(s.exampleAccount.ID, presence.ItemID, time.Now()))
+	})
+
+	T.Run("does not deliver to the user it's about", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		presence := buildExampleItemPresence(s.exampleAccount.ID, types.ViewingItemPresenceActivity, time.Now().Add(itemPresenceLifetime))
+		presence.UserID = s.exampleUser.ID
+
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		require.NoError(t, s.service.handleItemPresenceChange(ctx, buildItemPresencePayload(t, presence)))
+		assert.Empty(t, sub.outbox)
+	})
+
+	T.Run("does not deliver to non-members", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		presence := buildExampleItemPresence(fakes.BuildFakeAccount().ID, types.ViewingItemPresenceActivity, time.Now().Add(itemPresenceLifetime))
+
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		require.NoError(t, s.service.handleItemPresenceChange(ctx, buildItemPresencePayload(t, presence)))
+		assert.Empty(t, sub.outbox)
+	})
+
+	T.Run("without presence", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		payload, err := json.Marshal(&types.DataChangeMessage{DataType: types.ItemPresenceDataType})
+		require.NoError(t, err)
+
+		require.NoError(t, s.service.handleItemPresenceChange(ctx, payload))
+		assert.Empty(t, s.service.itemPresence.viewers)
+	})
+
+	T.Run("with invalid JSON", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		s := buildTestHelper(t)
+
+		assert.Error(t, s.service.handleItemPresenceChange(ctx, []byte(`} not real JSON lol`)))
+	})
+}
+
+func Test_sweepItemPresence(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		now := time.Now()
+
+		presence := buildExampleItemPresence(s.exampleAccount.ID, types.EditingItemPresenceActivity, now)
+		s.service.itemPresence.update(presence)
+
+		sub := newSubscriber(&mockWebsocketConnection{}, s.sessionCtxData)
+		s.service.connections.add(sub)
+
+		s.service.sweepItemPresence(now)
+
+		require.Len(t, sub.outbox, 1)
+
+		var msg *types.DataChangeMessage
+		require.NoError(t, json.Unmarshal(<-sub.outbox, &msg))
+
+		assert.Equal(t, types.LeftItemPresenceActivity, msg.MessageType)
+		assert.Equal(t, presence.ItemID, msg.ItemPresence.ItemID)
+		assert.Equal(t, presence.UserID, msg.ItemPresence.UserID)
+	})
+}
+
+func TestWebsocketsService_ItemPresenceHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+
+		presence := buildExampleItemPresence(s.exampleAccount.ID, types.ViewingItemPresenceActivity, time.Now().Add(itemPresenceLifetime))
+		s.service.itemPresence.update(presence)
+		s.service.itemIDFetcher = func(*http.Request) string {
+			return presence.ItemID
+		}
+
+		s.service.ItemPresenceHandler(s.res, s.req)
+
+		assert.Equal(t, http.StatusOK, s.res.Code)
+
+		var actual *types.ItemPresenceList
+		require.NoError(t, json.NewDecoder(s.res.Body).Decode(&actual))
+
+		assert.Equal(t, &types.ItemPresenceList{Viewers: []*types.ItemPresence{presence}}, actual)
+	})
+
+	T.Run("without viewers", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.service.itemIDFetcher = func(*http.Request) string {
+			return fakes.BuildFakeItem().ID
+		}
+
+		s.service.ItemPresenceHandler(s.res, s.req)
+
+		assert.Equal(t, http.StatusOK, s.res.Code)
+
+		var actual *types.ItemPresenceList
+		require.NoError(t, json.NewDecoder(s.res.Body).Decode(&actual))
+
+		assert.Empty(t, actual.Viewers)
+	})
+
+	T.Run("with error fetching session context", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.service.sessionContextDataFetcher = func(*http.Request) (*types.SessionContextData, error) {
+			return nil, errors.New("blah")
+		}
+
+		s.service.ItemPresenceHandler(s.res, s.req)
+
+		assert.Equal(t, http.StatusUnauthorized, s.res.Code)
+	})
+}
@@ -0,0 +1,285 @@
+package websockets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+const (
+	loadTestConnectionCount = 5000
+	loadTestAccountCount    = 50
+)
+
+var errFakeConnectionClosed = errors.New("connection closed")
+
+// fakeConnection is a websocketConnection cheap enough to have thousands of. Reads block until it's closed, and
+// writes block for as long as it's stalled.
+type fakeConnection struct {
+	closed  chan struct{}
+	stalled chan struct{}
+	written int64
+
+	closeOnce sync.Once
+}
+
+func newFakeConnection() *fakeConnection {
+	return &fakeConnection{closed: make(chan struct{})}
+}
+
+func (c *fakeConnection) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+func (c *fakeConnection) WriteMessage(int, []byte) error {
+	if c.stalled != nil {
+		select {
+		case <-c.stalled:
+		case <-c.closed:
+			return errFakeConnectionClosed
+		}
+	}
+
+	atomic.AddInt64(&c.written, 1)
+
+	return nil
+}
+
+func (c *fakeConnection) WriteControl(int, []byte, time.Time) error {
+	return nil
+}
+
+func (c *fakeConnection) ReadMessage() (messageType int, p []byte, err error) {
+	<-c.closed
+	return 0, nil, errFakeConnectionClosed
+}
+
+func (c *fakeConnection) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+func (c *fakeConnection) messagesWritten() int64 {
+	return atomic.LoadInt64(&c.written)
+}
+
+// connectLoadTestSubscribers registers a subscriber for each of count users, spread evenly across accounts.
+func connectLoadTestSubscribers(s *service, count int, accountIDs []string) []*fakeConnection {
+	conns := make([]*fakeConnection, count)
+	for i := range conns {
+		conns[i] = newFakeConnection()
+		s.register(newSubscriber(conns[i], buildExampleSessionContextData(accountIDs[i%len(accountIDs)])))
+	}
+
+	return conns
+}
+
+func buildLoadTestAccountIDs(count int) []string {
+	accountIDs := make([]string, count)
+	for i := range accountIDs {
+		accountIDs[i] = fakes.BuildFakeAccount().ID
+	}
+
+	return accountIDs
+}
+
+func buildLoadTestPayloads(t testing.TB, accountIDs []string) [][]byte {
+	t.Helper()
+
+	payloads := make([][]byte, len(accountIDs))
+	for i, accountID := range accountIDs {
+		msg := &types.DataChangeMessage{
+			DataType:                types.ItemDataType,
+			MessageType:             types.CreatedMessageType,
+			Item:                    fakes.BuildFakeItem(),
+			AttributableToUserID:    fakes.BuildFakeUser().ID,
+			AttributableToAccountID: accountID,
+		}
+
+		payload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		payloads[i] = payload
+	}
+
+	return payloads
+}
+
+func TestWebsocketsService_Load(T *testing.T) {
+	T.Parallel()
+
+	T.Run("fans out to thousands of connections", func(t *testing.T) {
+		t.Parallel()
+
+		if testing.Short() {
+			t.SkipNow()
+		}
+
+		ctx := context.Background()
+		s := buildTestService()
+		accountIDs := buildLoadTestAccountIDs(loadTestAccountCount)
+
+		conns := connectLoadTestSubscribers(s, loadTestConnectionCount, accountIDs)
+		t.Cleanup(func() {
+			for _, conn := range conns {
+				_ = conn.Close()
+			}
+		})
+
+		assert.Equal(t, loadTestConnectionCount/loadTestAccountCount, s.presence.connectionsForAccount(accountIDs[0]))
+
+		const rounds = 10
+		for i := 0; i < rounds; i++ {
+			for _, payload := range buildLoadTestPayloads(t, accountIDs) {
+				require.NoError(t, s.handleDataChange(ctx, payload))
+			}
+		}
+
+		// every connection hears about every change to its account, and nothing else.
+		require.Eventually(t, func() bool {
+			for _, conn := range conns {
+				if conn.messagesWritten() != rounds {
+					return false
+				}
+			}
+
+			return true
+		}, 10*time.Second, 10*time.Millisecond)
+	})
+
+	T.Run("drops slow consumers without holding anyone else up", func(t *testing.T) {
+		t.Parallel()
+
+		if testing.Short() {
+			t.SkipNow()
+		}
+
+		ctx := context.Background()
+		s := buildTestService()
+		accountIDs := buildLoadTestAccountIDs(loadTestAccountCount)
+
+		conns := connectLoadTestSubscribers(s, loadTestConnectionCount, accountIDs)
+		t.Cleanup(func() {
+			for _, conn := range conns {
+				_ = conn.Close()
+			}
+		})
+
+		slowConn := newFakeConnection()
+		slowConn.stalled = make(chan struct{})
+		slowSubscriber := newSubscriber(slowConn, buildExampleSessionContextData(accountIDs[0]))
+		s.register(slowSubscriber)
+
+		// enough messages to overflow the slow consumer's buffer, even with one stuck in its writer.
+		rounds := subscriberSendBufferSize + 2
+		payloads := buildLoadTestPayloads(t, accountIDs[:1])
+
+		finished := make(chan struct{})
+		go func() {
+			defer close(finished)
+			for i := 0; i < rounds; i++ {
+				assert.NoError(t, s.handleDataChange(ctx, payloads[0]))
+			}
+		}()
+
+		select {
+		case <-finished:
+		case <-time.After(10 * time.Second):
+			t.Fatal("fan-out was held up by a slow consumer")
+		}
+
+		assert.Empty(t, s.connections.forUser(slowSubscriber.sessionCtxData.Requester.UserID))
+		assert.Equal(t, loadTestConnectionCount/loadTestAccountCount, s.presence.connectionsForAccount(accountIDs[0]))
+
+		select {
+		case <-slowConn.closed:
+		default:
+			t.Fatal("slow consumer's connection was not closed")
+		}
+
+		require.Eventually(t, func() bool {
+			for i := 0; i < len(conns); i += len(accountIDs) {
+				if conns[i].messagesWritten() != int64(rounds) {
+					return false
+				}
+			}
+
+			return true
+		}, 10*time.Second, 10*time.Millisecond)
+	})
+
+	T.Run("handles connections coming and going during fan-out", func(t *testing.T) {
+		t.Parallel()
+
+		if testing.Short() {
+			t.SkipNow()
+		}
+
+		ctx := context.Background()
+		s := buildTestService()
+		accountIDs := buildLoadTestAccountIDs(loadTestAccountCount)
+		payloads := buildLoadTestPayloads(t, accountIDs)
+
+		var wg sync.WaitGroup
+		for i := 0; i < loadTestAccountCount; i++ {
+			wg.Add(2)
+
+			go func(accountID string) {
+				defer wg.Done()
+				for _, conn := range connectLoadTestSubscribers(s, loadTestConnectionCount/loadTestAccountCount, []string{accountID}) {
+					_ = conn.Close()
+				}
+			}(accountIDs[i])
+
+			go func(payload []byte) {
+				defer wg.Done()
+				assert.NoError(t, s.handleDataChange(ctx, payload))
+			}(payloads[i])
+		}
+
+		wg.Wait()
+
+		// closed connections are dropped by their readers, so everyone should eventually be gone.
+		require.Eventually(t, func() bool {
+			for _, accountID := range accountIDs {
+				if s.presence.connectionsForAccount(accountID) != 0 {
+					return false
+				}
+			}
+
+			return true
+		}, 10*time.Second, 10*time.Millisecond)
+	})
+}
+
+func BenchmarkWebsocketsService_handleDataChange(b *testing.B) {
+	ctx := context.Background()
+	s := buildTestService()
+	accountIDs := buildLoadTestAccountIDs(loadTestAccountCount)
+	payloads := buildLoadTestPayloads(b, accountIDs)
+
+	conns := connectLoadTestSubscribers(s, loadTestConnectionCount, accountIDs)
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := s.handleDataChange(ctx, payloads[i%len(payloads)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package websockets
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	connectionsCounterName        metrics.CounterName = "websocket_connections"
+	connectionsCounterDescription                     = "number of open websocket connections"
+	usersCounterName              metrics.CounterName = "websocket_connected_users"
+	usersCounterDescription                           = "number of users with at least one open websocket connection"
+	accountsCounterName           metrics.CounterName = "websocket_connected_accounts"
+	accountsCounterDescription                        = "number of accounts with at least one member connected by websocket"
+
+	// presenceReportSize is how many of the most connected users and accounts are included in presence reports.
+	presenceReportSize = 25
+)
+
+// presenceTracker counts the open connections for each user and account. Per-user and per-account counts would
+// make for unbounded metric cardinality, so what's exported as metrics is the number of connections, along with
+// how many distinct users and accounts they belong to. The most connected users and accounts are reported to
+// service admins instead.
+type presenceTracker struct {
+	connectionCounter metrics.UnitCounter
+	userCounter       metrics.UnitCounter
+	accountCounter    metrics.UnitCounter
+	users             map[string]int
+	accounts          map[string]int
+	connections       int
+	hat               sync.RWMutex
+}
+
+func newPresenceTracker(logger logging.Logger, counterProvider metrics.UnitCounterProvider) *presenceTracker {
+	return &presenceTracker{
+		connectionCounter: metrics.EnsureUnitCounter(counterProvider, logger, connectionsCounterName, connectionsCounterDescription),
+		userCounter:       metrics.EnsureUnitCounter(counterProvider, logger, usersCounterName, usersCounterDescription),
+		accountCounter:    metrics.EnsureUnitCounter(counterProvider, logger, accountsCounterName, accountsCounterDescription),
+		users:             map[string]int{},
+		accounts:          map[string]int{},
+	}
+}
+
+// connected counts a new connection towards its user and every account they belong to.
+func (p *presenceTracker) connected(sessionCtxData *types.SessionContextData) {
+	ctx := context.Background()
+
+	p.hat.Lock()
+	defer p.hat.Unlock()
+
+	p.connectionCounter.Increment(ctx)
+	p.connections++
+
+	p.users[sessionCtxData.Requester.UserID]++
+	if p.users[sessionCtxData.Requester.UserID] == 1 {
+		p.userCounter.Increment(ctx)
+	}
+
+	for accountID := range sessionCtxData.AccountPermissions {
+		p.accounts[accountID]++
+		if p.accounts[accountID] == 1 {
+			p.accountCounter.Increment(ctx)
+		}
+	}
+}
+
+// disconnected undoes connected.
+func (p *presenceTracker) disconnected(sessionCtxData *types.SessionContextData) {
+	ctx := context.Background()
+
+	p.hat.Lock()
+	defer p.hat.Unlock()
+
+	p.connectionCounter.Decrement(ctx)
+	p.connections--
+
+	p.users[sessionCtxData.Requester.UserID]--
+	if p.users[sessionCtxData.Requester.UserID] <= 0 {
+		delete(p.users, sessionCtxData.Requester.UserID)
+		p.userCounter.Decrement(ctx)
+	}
+
+	for accountID := range sessionCtxData.AccountPermissions {
+		p.accounts[accountID]--
+		if p.accounts[accountID] <= 0 {
+			delete(p.accounts, accountID)
+			p.accountCounter.Decrement(ctx)
+		}
+	}
+}
+
+// connectionsForUser returns how many connections a user has open to this process.
+func (p *presenceTracker) connectionsForUser(userID string) int {
+	p.hat.RLock()
+	defer p.hat.RUnlock()
+
+	return p.users[userID]
+}
+
+// connectionsForAccount returns how many connections an account's members have open to this process.
+func (p *presenceTracker) connectionsForAccount(accountID string) int {
+	p.hat.RLock()
+	defer p.hat.RUnlock()
+
+	return p.accounts[accountID]
+}
+
+// topConnectionCounts returns the entries with the most connections, most first, and by ID among equals.
+func topConnectionCounts(counts map[string]int, limit int) []*types.WebsocketConnectionCount {
+	out := []*types.WebsocketConnectionCount{}
+	for id, connections := range counts {
+		out = append(out, &types.WebsocketConnectionCount{ID: id, Connections: connections})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Connections != out[j].Connections {
+			return out[i].Connections > out[j].Connections
+		}
+
+		return out[i].ID < out[j].ID
+	})
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+
+	return out
+}
+
+// report summarizes the connections open to this process, including the users and accounts with the most of them.
+func (p *presenceTracker) report(limit int) *types.WebsocketPresenceReport {
+	p.hat.RLock()
+	defer p.hat.RUnlock()
+
+	return &types.WebsocketPresenceReport{
+		Connections: p.connections,
+		Users:       len(p.users),
+		Accounts:    len(p.accounts),
+		TopUsers:    topConnectionCounts(p.users, limit),
+		TopAccounts: topConnectionCounts(p.accounts, limit),
+	}
+}
+
+// PresenceReportHandler reports the websocket connections open to this server instance, and which users and
+// accounts have the most of them.
+func (s *service) PresenceReportHandler(res http.ResponseWriter, req *http.Request) {
+	ctx, span := s.tracer.StartSpan(req.Context())
+	defer span.End()
+
+	logger := s.logger.WithRequest(req)
+	tracing.AttachRequestToSpan(span, req)
+
+	// determine user ID.
+	sessionCtxData, err := s.sessionContextDataFetcher(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "retrieving session context data")
+		s.encoderDecoder.EncodeErrorResponse(ctx, res, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	tracing.AttachSessionContextDataToSpan(span, sessionCtxData)
+
+	// encode our response and peace.
+	s.encoderDecoder.RespondWithData(ctx, res, s.presence.report(presenceReportSize))
+}
@@ -0,0 +1,178 @@
+package websockets
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	mockmetrics "gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics/mock"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+func buildExampleSessionContextData(accountIDs ...string) *types.SessionContextData {
+	sessionCtxData := &types.SessionContextData{
+		Requester:          types.RequesterInfo{UserID: fakes.BuildFakeUser().ID},
+		AccountPermissions: map[string]authorization.AccountRolePermissionsChecker{},
+	}
+
+	for _, accountID := range accountIDs {
+		sessionCtxData.AccountPermissions[accountID] = authorization.NewAccountRolePermissionChecker(authorization.AccountMemberRole.String())
+	}
+
+	return sessionCtxData
+}
+
+func Test_presenceTracker(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		p := newPresenceTracker(logging.NewNoopLogger(), nil)
+		exampleAccountID := fakes.BuildFakeAccount().ID
+
+		first := buildExampleSessionContextData(exampleAccountID)
+		second := buildExampleSessionContextData(exampleAccountID)
+
+		p.connected(first)
+		p.connected(first)
+		p.connected(second)
+
+		assert.Equal(t, 2, p.connectionsForUser(first.Requester.UserID))
+		assert.Equal(t, 1, p.connectionsForUser(second.Requester.UserID))
+		assert.Equal(t, 3, p.connectionsForAccount(exampleAccountID))
+
+		p.disconnected(first)
+		p.disconnected(second)
+
+		assert.Equal(t, 1, p.connectionsForUser(first.Requester.UserID))
+		assert.Zero(t, p.connectionsForUser(second.Requester.UserID))
+		assert.Equal(t, 1, p.connectionsForAccount(exampleAccountID))
+
+		p.disconnected(first)
+
+		assert.Empty(t, p.users)
+		assert.Empty(t, p.accounts)
+	})
+
+	T.Run("counts distinct users and accounts", func(t *testing.T) {
+		t.Parallel()
+
+		connectionCounter := &mockmetrics.UnitCounter{}
+		connectionCounter.On("Increment", testutils.ContextMatcher).Twice()
+		connectionCounter.On("Decrement", testutils.ContextMatcher).Twice()
+
+		userCounter := &mockmetrics.UnitCounter{}
+		userCounter.On("Increment", testutils.ContextMatcher).Once()
+		userCounter.On("Decrement", testutils.ContextMatcher).Once()
+
+		accountCounter := &mockmetrics.UnitCounter{}
+		accountCounter.On("Increment", testutils.ContextMatcher).Twice()
+		accountCounter.On("Decrement", testutils.ContextMatcher).Twice()
+
+		p := newPresenceTracker(logging.NewNoopLogger(), nil)
+		p.connectionCounter = connectionCounter
+		p.userCounter = userCounter
+		p.accountCounter = accountCounter
+
+		sessionCtxData := buildExampleSessionContextData(fakes.BuildFakeAccount().ID, fakes.BuildFakeAccount().ID)
+
+		p.connected(sessionCtxData)
+		p.connected(sessionCtxData)
+		p.disconnected(sessionCtxData)
+		p.disconnected(sessionCtxData)
+
+		mock.AssertExpectationsForObjects(t, connectionCounter, userCounter, accountCounter)
+	})
+}
+
+func Test_presenceTracker_report(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		p := newPresenceTracker(logging.NewNoopLogger(), nil)
+		exampleAccountID := fakes.BuildFakeAccount().ID
+		otherAccountID := fakes.BuildFakeAccount().ID
+
+		busy := buildExampleSessionContextData(exampleAccountID)
+		quiet := buildExampleSessionContextData(exampleAccountID, otherAccountID)
+
+		p.connected(busy)
+		p.connected(busy)
+		p.connected(quiet)
+
+		actual := p.report(presenceReportSize)
+
+		assert.Equal(t, 3, actual.Connections)
+		assert.Equal(t, 2, actual.Users)
+		assert.Equal(t, 2, actual.Accounts)
+		assert.Equal(t, []*types.WebsocketConnectionCount{
+			{ID: busy.Requester.UserID, Connections: 2},
+			{ID: quiet.Requester.UserID, Connections: 1},
+		}, actual.TopUsers)
+		assert.Equal(t, []*types.WebsocketConnectionCount{
+			{ID: exampleAccountID, Connections: 3},
+			{ID: otherAccountID, Connections: 1},
+		}, actual.TopAccounts)
+	})
+
+	T.Run("is bounded", func(t *testing.T) {
+		t.Parallel()
+
+		p := newPresenceTracker(logging.NewNoopLogger(), nil)
+		for i := 0; i < 3; i++ {
+			p.connected(buildExampleSessionContextData(fakes.BuildFakeAccount().ID))
+		}
+
+		actual := p.report(2)
+
+		assert.Equal(t, 3, actual.Users)
+		assert.Len(t, actual.TopUsers, 2)
+		assert.Len(t, actual.TopAccounts, 2)
+	})
+}
+
+func TestWebsocketsService_PresenceReportHandler(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.service.presence.connected(s.sessionCtxData)
+
+		s.service.PresenceReportHandler(s.res, s.req)
+
+		assert.Equal(t, http.StatusOK, s.res.Code)
+
+		var actual *types.WebsocketPresenceReport
+		require.NoError(t, json.NewDecoder(s.res.Body).Decode(&actual))
+
+		assert.Equal(t, 1, actual.Connections)
+		assert.Equal(t, []*types.WebsocketConnectionCount{{ID: s.exampleUser.ID, Connections: 1}}, actual.TopUsers)
+	})
+
+	T.Run("with error fetching session context", func(t *testing.T) {
+		t.Parallel()
+
+		s := buildTestHelper(t)
+		s.service.sessionContextDataFetcher = func(*http.Request) (*types.SessionContextData, error) {
+			return nil, errors.New("blah")
+		}
+
+		s.service.PresenceReportHandler(s.res, s.req)
+
+		assert.Equal(t, http.StatusUnauthorized, s.res.Code)
+	})
+}
@@ -11,15 +11,20 @@
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/consumers"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/metrics"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing"
 	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
+	itemsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/items"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
 const (
-	serviceName          = "websockets_service"
-	dataChangesTopicName = "data_changes"
+	serviceName           = "websockets_service"
+	dataChangesTopicName  = "data_changes"
+	itemPresenceTopicName = "item_presence"
 )
 
 type (
@@ -27,6 +32,8 @@
 		SetWriteDeadline(t time.Time) error
 		WriteMessage(messageType int, data []byte) error
 		WriteControl(messageType int, data []byte, deadline time.Time) error
+		ReadMessage() (messageType int, p []byte, err error)
+		Close() error
 	}
 
 	// service handles websockets.
@@ -34,13 +41,19 @@
 		logger                      logging.Logger
 		encoderDecoder              encoding.ServerEncoderDecoder
 		tracer                      tracing.Tracer
-		connections                 map[string][]websocketConnection
+		connections                 *connectionRegistry
+		presence                    *presenceTracker
+		itemPresence                *itemPresenceRegistry
+		itemPresencePublisher       publishers.Publisher
+		eventStreams                map[string][]*eventStream
+		replayBuffer                *replayBuffer
 		sessionContextDataFetcher   func(*http.Request) (*types.SessionContextData, error)
+		itemIDFetcher               func(*http.Request) string
 		websocketConnectionUpgrader websocket.Upgrader
 		cookieName                  string
 		websocketDeadline           time.Duration
 		pollDuration                time.Duration
-		connectionsHat              sync.RWMutex
+		eventStreamsHat             sync.RWMutex
 	}
 )
 
@@ -51,31 +64,55 @@ func ProvideService(
 	logger logging.Logger,
 	encoder encoding.ServerEncoderDecoder,
 	consumerProvider consumers.ConsumerProvider,
+	counterProvider metrics.UnitCounterProvider,
+	publisherProvider publishers.PublisherProvider,
+	routeParamManager routing.RouteParamManager,
 ) (types.WebsocketDataService, error) {
 	upgrader := websocket.Upgrader{
 		HandshakeTimeout: 10 * time.Second,
 		Error:            buildWebsocketErrorFunc(encoder),
 	}
 
+	logger = logging.EnsureLogger(logger).WithName(serviceName)
+
+	itemPresencePublisher, err := publisherProvider.ProviderPublisher(itemPresenceTopicName)
+	if err != nil {
+		return nil, fmt.Errorf("setting up event publisher: %w", err)
+	}
+
 	svc := &service{
-		logger:                      logging.EnsureLogger(logger).WithName(serviceName),
+		logger:                      logger,
 		sessionContextDataFetcher:   authservice.FetchContextFromRequest,
+		itemIDFetcher:               routeParamManager.BuildRouteParamStringIDFetcher(itemsservice.ItemIDURIParamKey),
 		encoderDecoder:              encoder,
 		websocketConnectionUpgrader: upgrader,
 		cookieName:                  authCfg.Cookies.Name,
-		connections:                 map[string][]websocketConnection{},
+		connections:                 newConnectionRegistry(),
+		presence:                    newPresenceTracker(logger, counterProvider),
+		itemPresence:                newItemPresenceRegistry(),
+		itemPresencePublisher:       itemPresencePublisher,
+		eventStreams:                map[string][]*eventStream{},
+		replayBuffer:                newReplayBuffer(replayBufferSize),
 		websocketDeadline:           5 * time.Second,
 		pollDuration:                10 * time.Second,
 		tracer:                      tracing.NewTracer(serviceName),
 	}
 
-	dataChangesConsumer, err := consumerProvider.ProviderConsumer(ctx, dataChangesTopicName, svc.handleDataChange)
+	// every replica needs to see every data change and presence update, since any of them might hold a connection
+	// that wants it. A group consumer would also share data changes with the workers, who'd each only see some.
+	dataChangesConsumer, err := consumerProvider.ProvideBroadcastConsumer(ctx, dataChangesTopicName, svc.handleDataChange)
 	if err != nil {
-		return nil, fmt.Errorf("setting up event publisher: %w", err)
+		return nil, fmt.Errorf("setting up data changes consumer: %w", err)
+	}
+
+	itemPresenceConsumer, err := consumerProvider.ProvideBroadcastConsumer(ctx, itemPresenceTopicName, svc.handleItemPresenceChange)
+	if err != nil {
+		return nil, fmt.Errorf("setting up item presence consumer: %w", err)
 	}
 
-	go svc.pingConnections()
 	go dataChangesConsumer.Consume(nil, nil)
+	go itemPresenceConsumer.Consume(nil, nil)
+	go svc.expireItemPresence()
 
 	return svc, nil
 }
@@ -6,8 +6,12 @@
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
-	mock2 "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/consumers/mock"
+	mockconsumers "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/consumers/mock"
+	mockpublishers "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers/mock"
+	mockrouting "gitlab.com/verygoodsoftwarenotvirus/todo/internal/routing/mock"
+	itemsservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/items"
 
 	"github.com/stretchr/testify/mock"
 
@@ -25,11 +29,17 @@
 
 func buildTestService() *service {
 	return &service{
-		cookieName:     "testing",
-		logger:         logging.NewNoopLogger(),
-		encoderDecoder: mockencoding.NewMockEncoderDecoder(),
-		tracer:         tracing.NewTracer("test"),
-		connections:    map[string][]websocketConnection{},
+		cookieName:        "testing",
+		logger:            logging.NewNoopLogger(),
+		encoderDecoder:    mockencoding.NewMockEncoderDecoder(),
+		tracer:            tracing.NewTracer("test"),
+		websocketDeadline: time.Second,
+		pollDuration:      time.Second,
+		connections:       newConnectionRegistry(),
+		presence:          newPresenceTracker(logging.NewNoopLogger(), nil),
+		itemPresence:      newItemPresenceRegistry(),
+		eventStreams:      map[string][]*eventStream{},
+		replayBuffer:      newReplayBuffer(replayBufferSize),
 	}
 }
 
@@ -44,16 +54,31 @@ func TestProvideService(T *testing.T) {
 		logger := logging.NewNoopLogger()
 		encoder := encoding.ProvideServerEncoderDecoder(logger, encoding.ContentTypeJSON)
 
-		consumer := &mock2.Consumer{}
+		consumer := &mockconsumers.Consumer{}
 		consumer.On("Consume", chan bool(nil), chan error(nil))
 
-		consumerProvider := &mock2.ConsumerProvider{}
+		consumerProvider := &mockconsumers.ConsumerProvider{}
 		consumerProvider.On(
-			"ProviderConsumer",
+			"ProvideBroadcastConsumer",
 			testutils.ContextMatcher,
 			dataChangesTopicName,
 			mock.Anything,
 		).Return(consumer, nil)
+		consumerProvider.On(
+			"ProvideBroadcastConsumer",
+			testutils.ContextMatcher,
+			itemPresenceTopicName,
+			mock.Anything,
+		).Return(consumer, nil)
+
+		publisherProvider := &mockpublishers.ProducerProvider{}
+		publisherProvider.On("ProviderPublisher", itemPresenceTopicName).Return(&mockpublishers.Publisher{}, nil)
+
+		rpm := mockrouting.NewRouteParamManager()
+		rpm.On(
+			"BuildRouteParamStringIDFetcher",
+			itemsservice.ItemIDURIParamKey,
+		).Return(func(*http.Request) string { return "" })
 
 		actual, err := ProvideService(
 			ctx,
@@ -61,12 +86,18 @@ func TestProvideService(T *testing.T) {
 			logger,
 			encoder,
 			consumerProvider,
+			nil,
+			publisherProvider,
+			rpm,
 		)
 
 		require.NoError(t, err)
 		require.NotNil(t, actual)
 
-		mock.AssertExpectationsForObjects(t, consumerProvider)
+		// joining a consumer group would split data changes between this service and the workers.
+		consumerProvider.AssertNotCalled(t, "ProviderConsumer", mock.Anything, mock.Anything, mock.Anything)
+
+		mock.AssertExpectationsForObjects(t, consumerProvider, publisherProvider, rpm)
 	})
 
 	T.Run("with consumer provider error", func(t *testing.T) {
@@ -77,13 +108,68 @@ func TestProvideService(T *testing.T) {
 		logger := logging.NewNoopLogger()
 		encoder := encoding.ProvideServerEncoderDecoder(logger, encoding.ContentTypeJSON)
 
-		consumerProvider := &mock2.ConsumerProvider{}
+		consumerProvider := &mockconsumers.ConsumerProvider{}
+		consumerProvider.On(
+			"ProvideBroadcastConsumer",
+			testutils.ContextMatcher,
+			dataChangesTopicName,
+			mock.Anything,
+		).Return(&mockconsumers.Consumer{}, errors.New("blah"))
+
+		publisherProvider := &mockpublishers.ProducerProvider{}
+		publisherProvider.On("ProviderPublisher", itemPresenceTopicName).Return(&mockpublishers.Publisher{}, nil)
+
+		rpm := mockrouting.NewRouteParamManager()
+		rpm.On(
+			"BuildRouteParamStringIDFetcher",
+			itemsservice.ItemIDURIParamKey,
+		).Return(func(*http.Request) string { return "" })
+
+		actual, err := ProvideService(
+			ctx,
+			authCfg,
+			logger,
+			encoder,
+			consumerProvider,
+			nil,
+			publisherProvider,
+			rpm,
+		)
+
+		require.Error(t, err)
+		require.Nil(t, actual)
+	})
+
+	T.Run("with item presence consumer provider error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		authCfg := &authservice.Config{}
+		logger := logging.NewNoopLogger()
+		encoder := encoding.ProvideServerEncoderDecoder(logger, encoding.ContentTypeJSON)
+
+		consumerProvider := &mockconsumers.ConsumerProvider{}
 		consumerProvider.On(
-			"ProviderConsumer",
+			"ProvideBroadcastConsumer",
 			testutils.ContextMatcher,
 			dataChangesTopicName,
 			mock.Anything,
-		).Return(&mock2.Consumer{}, errors.New("blah"))
+		).Return(&mockconsumers.Consumer{}, nil)
+		consumerProvider.On(
+			"ProvideBroadcastConsumer",
+			testutils.ContextMatcher,
+			itemPresenceTopicName,
+			mock.Anything,
+		).Return(&mockconsumers.Consumer{}, errors.New("blah"))
+
+		publisherProvider := &mockpublishers.ProducerProvider{}
+		publisherProvider.On("ProviderPublisher", itemPresenceTopicName).Return(&mockpublishers.Publisher{}, nil)
+
+		rpm := mockrouting.NewRouteParamManager()
+		rpm.On(
+			"BuildRouteParamStringIDFetcher",
+			itemsservice.ItemIDURIParamKey,
+		).Return(func(*http.Request) string { return "" })
 
 		actual, err := ProvideService(
 			ctx,
@@ -91,11 +177,42 @@ func TestProvideService(T *testing.T) {
 			logger,
 			encoder,
 			consumerProvider,
+			nil,
+			publisherProvider,
+			rpm,
 		)
 
 		require.Error(t, err)
 		require.Nil(t, actual)
 	})
+
+	T.Run("with publisher provider error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		authCfg := &authservice.Config{}
+		logger := logging.NewNoopLogger()
+		encoder := encoding.ProvideServerEncoderDecoder(logger, encoding.ContentTypeJSON)
+
+		publisherProvider := &mockpublishers.ProducerProvider{}
+		publisherProvider.On("ProviderPublisher", itemPresenceTopicName).Return((*mockpublishers.Publisher)(nil), errors.New("blah"))
+
+		actual, err := ProvideService(
+			ctx,
+			authCfg,
+			logger,
+			encoder,
+			&mockconsumers.ConsumerProvider{},
+			nil,
+			publisherProvider,
+			nil,
+		)
+
+		require.Error(t, err)
+		require.Nil(t, actual)
+
+		mock.AssertExpectationsForObjects(t, publisherProvider)
+	})
 }
 
 func Test_buildWebsocketErrorFunc(T *testing.T) {
@@ -2,33 +2,93 @@
 
 import (
 	"context"
+	"net/http"
+	"sync"
+	"time"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
-// DataChangesWorker writes data from the pending writes topic to the database.
+const (
+	// dataChangesTopicName is the topic data change messages are read from.
+	dataChangesTopicName = "data_changes"
+
+	defaultWebhookAttemptTimeout  = 10 * time.Second
+	defaultWebhookMaxAttempts     = 5
+	defaultWebhookInitialBackoff  = 500 * time.Millisecond
+	defaultWebhookMaximumBackoff  = 30 * time.Second
+	defaultWebhookBackoffExponent = 2
+
+	// defaultWebhookDeliveryConcurrency is how many webhook deliveries are worked on at once.
+	defaultWebhookDeliveryConcurrency = 16
+	// defaultWebhookDeliveryQueueSize is how many webhook deliveries can wait for a free delivery goroutine
+	// before handling messages has to wait too.
+	defaultWebhookDeliveryQueueSize = 1024
+
+	// defaultNotificationDigestWindow is how long notifications are collected before they're emailed.
+	defaultNotificationDigestWindow = 15 * time.Minute
+)
+
+// DataChangesWorker delivers data change messages to the webhooks that care about them,
+// and notifies the users they concern.
 type DataChangesWorker struct {
-	logger  logging.Logger
-	tracer  tracing.Tracer
-	encoder encoding.ClientEncoder
+	logger                logging.Logger
+	tracer                tracing.Tracer
+	encoder               encoding.ClientEncoder
+	dataManager           database.DataManager
+	dataChangesPublisher  publishers.Publisher
+	emailer               email.Emailer
+	emailRenderer         *email.Renderer
+	webhookClient         *http.Client
+	sleepFunc             func(ctx context.Context, d time.Duration) error
+	webhookAttemptTimeout time.Duration
+	webhookMaxAttempts    uint
+	webhookBackoff        time.Duration
+	webhookMaxBackoff     time.Duration
+	webhookDeliveries     chan *webhookDelivery
+	webhookConcurrency    uint
+	digestWindow          time.Duration
+	pendingDigestsMu      sync.Mutex
+	pendingDigests        []*types.Notification
 }
 
 // ProvideDataChangesWorker provides a DataChangesWorker.
-func ProvideDataChangesWorker(logger logging.Logger) *DataChangesWorker {
+func ProvideDataChangesWorker(logger logging.Logger, client *http.Client, dataManager database.DataManager, dataChangesPublisher publishers.Publisher, emailer email.Emailer, emailRenderer *email.Renderer) *DataChangesWorker {
 	name := "post_writes"
 
+	if client == nil {
+		client = &http.Client{}
+	}
+
 	return &DataChangesWorker{
-		logger:  logging.EnsureLogger(logger).WithName(name),
-		tracer:  tracing.NewTracer(name),
-		encoder: encoding.ProvideClientEncoder(logger, encoding.ContentTypeJSON),
+		logger:                logging.EnsureLogger(logger).WithName(name),
+		tracer:                tracing.NewTracer(name),
+		encoder:               encoding.ProvideClientEncoder(logger, encoding.ContentTypeJSON),
+		dataManager:           dataManager,
+		dataChangesPublisher:  dataChangesPublisher,
+		emailer:               emailer,
+		emailRenderer:         emailRenderer,
+		webhookClient:         client,
+		sleepFunc:             sleepWithContext,
+		webhookAttemptTimeout: defaultWebhookAttemptTimeout,
+		webhookMaxAttempts:    defaultWebhookMaxAttempts,
+		webhookBackoff:        defaultWebhookInitialBackoff,
+		webhookMaxBackoff:     defaultWebhookMaximumBackoff,
+		webhookDeliveries:     make(chan *webhookDelivery, defaultWebhookDeliveryQueueSize),
+		webhookConcurrency:    defaultWebhookDeliveryConcurrency,
+		digestWindow:          defaultNotificationDigestWindow,
 	}
 }
 
-// HandleMessage handles a pending write.
+// HandleMessage handles a data change.
 func (w *DataChangesWorker) HandleMessage(ctx context.Context, message []byte) error {
 	ctx, span := w.tracer.StartSpan(ctx)
 	defer span.End()
@@ -40,8 +100,94 @@ func (w *DataChangesWorker) HandleMessage(ctx context.Context, message []byte) e
 	}
 
 	tracing.AttachUserIDToSpan(span, msg.AttributableToUserID)
+	tracing.AttachAccountIDToSpan(span, msg.AttributableToAccountID)
+
+	logger := w.logger.WithValue("data_type", msg.DataType).WithValue(keys.AccountIDKey, msg.AttributableToAccountID)
+	logger.Debug("message received")
+
+	if msg.AttributableToAccountID == "" || w.dataManager == nil {
+		return nil
+	}
+
+	// notifications and session revocations are published for the websockets service, and concern nobody else.
+	if msg.DataType == types.NotificationDataType || msg.DataType == types.UserSessionDataType {
+		return nil
+	}
+
+	// conflicts mean nothing was written, so they're only of interest to whoever is listening by websocket.
+	if msg.MessageType == types.ConflictMessageType {
+		return nil
+	}
+
+	// deliveries are handed off to DeliverWebhooks rather than made here, since retrying them can take the better
+	// part of a minute, and a message that isn't acknowledged by then is claimed and handled again elsewhere.
+	if msg.MessageType == types.WebhookRedeliveryMessageType {
+		if msg.WebhookDeliveryAttempt == nil {
+			observability.AcknowledgeError(errNilDeliveryAttemptProvided, logger, span, "redelivering webhook")
+			return nil
+		}
+
+		delivery := &webhookDelivery{accountID: msg.AttributableToAccountID, attempt: msg.WebhookDeliveryAttempt}
+		if err := w.enqueueWebhookDelivery(ctx, delivery); err != nil {
+			return observability.PrepareError(err, logger, span, "queueing webhook redelivery")
+		}
+
+		return nil
+	}
 
-	w.logger.WithValue("message", message).Info("message received")
+	// anything that can fail the message, and so have it redelivered, has to happen before notifications are
+	// created, or they'd be created again on every redelivery.
+	webhooks, err := w.fetchRelevantWebhooks(ctx, msg)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "fetching relevant webhooks")
+	}
+
+	if err = w.createNotifications(ctx, msg); err != nil {
+		observability.AcknowledgeError(err, logger, span, "creating notifications")
+	}
+
+	for _, webhook := range webhooks {
+		delivery := &webhookDelivery{accountID: msg.AttributableToAccountID, webhook: webhook, msg: msg}
+		// notifications have been created by now, so failing the message would only create them again.
+		if err = w.enqueueWebhookDelivery(ctx, delivery); err != nil {
+			observability.AcknowledgeError(err, logger.WithValue(keys.WebhookIDKey, webhook.ID), span, "queueing webhook delivery")
+		}
+	}
 
 	return nil
 }
+
+// fetchRelevantWebhooks pages through an account's webhooks and returns the ones that match a given message.
+func (w *DataChangesWorker) fetchRelevantWebhooks(ctx context.Context, msg *types.DataChangeMessage) ([]*types.Webhook, error) {
+	ctx, span := w.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := w.logger.WithValue(keys.AccountIDKey, msg.AttributableToAccountID)
+
+	filter := types.DefaultQueryFilter()
+	filter.Limit = types.MaxLimit
+
+	relevant := []*types.Webhook{}
+	for {
+		webhooks, err := w.dataManager.GetWebhooks(ctx, msg.AttributableToAccountID, filter)
+		if err != nil {
+			return nil, observability.PrepareError(err, logger, span, "fetching webhooks for account")
+		}
+
+		for _, webhook := range webhooks.Webhooks {
+			if webhookMatchesMessage(webhook, dataChangesTopicName, msg) {
+				relevant = append(relevant, webhook)
+			}
+		}
+
+		if len(webhooks.Webhooks) < int(filter.Limit) {
+			break
+		}
+
+		filter.Page++
+	}
+
+	logger.WithValue("relevant_webhook_count", len(relevant)).Debug("relevant webhooks fetched")
+
+	return relevant, nil
+}
@@ -2,11 +2,22 @@
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
 )
 
 func TestProvideDataChangesWorker(T *testing.T) {
@@ -15,7 +26,7 @@ func TestProvideDataChangesWorker(T *testing.T) {
 	T.Run("standard", func(t *testing.T) {
 		t.Parallel()
 
-		actual := ProvideDataChangesWorker(logging.NewZerologLogger())
+		actual := ProvideDataChangesWorker(logging.NewZerologLogger(), &http.Client{}, database.BuildMockDatabase(), nil, nil, nil)
 		assert.NotNil(t, actual)
 	})
 }
@@ -26,7 +37,7 @@ func TestDataChangesWorker_HandleMessage(T *testing.T) {
 	T.Run("standard", func(t *testing.T) {
 		t.Parallel()
 
-		actual := ProvideDataChangesWorker(logging.NewZerologLogger())
+		actual := ProvideDataChangesWorker(logging.NewZerologLogger(), &http.Client{}, database.BuildMockDatabase(), nil, nil, nil)
 		assert.NotNil(t, actual)
 
 		ctx := context.Background()
@@ -36,10 +47,229 @@ func TestDataChangesWorker_HandleMessage(T *testing.T) {
 	T.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
 
-		actual := ProvideDataChangesWorker(logging.NewZerologLogger())
+		actual := ProvideDataChangesWorker(logging.NewZerologLogger(), &http.Client{}, database.BuildMockDatabase(), nil, nil, nil)
 		assert.NotNil(t, actual)
 
 		ctx := context.Background()
 		assert.Error(t, actual.HandleMessage(ctx, []byte("} bad JSON lol")))
 	})
+
+	T.Run("with relevant webhook", func(t *testing.T) {
+		t.Parallel()
+
+		var hits int32
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&hits, 1)
+			assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+			res.WriteHeader(http.StatusOK)
+		}))
+		defer ts.Close()
+
+		exampleAccountID := fakes.BuildFakeID()
+		msg := &types.DataChangeMessage{
+			MessageType:             types.CreatedMessageType,
+			DataType:                types.ItemDataType,
+			Item:                    fakes.BuildFakeItem(),
+			AttributableToAccountID: exampleAccountID,
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		relevantWebhook := fakes.BuildFakeWebhook()
+		relevantWebhook.URL = ts.URL
+		relevantWebhook.Events = []string{types.CreatedMessageType}
+		relevantWebhook.DataTypes = []string{string(types.ItemDataType)}
+		relevantWebhook.Topics = nil
+
+		irrelevantWebhook := fakes.BuildFakeWebhook()
+		irrelevantWebhook.URL = ts.URL
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.WebhookDataManager.On(
+			"GetWebhooks",
+			testutils.ContextMatcher,
+			exampleAccountID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return(&types.WebhookList{Webhooks: []*types.Webhook{relevantWebhook, irrelevantWebhook}}, nil)
+		dbManager.WebhookDataManager.On(
+			"CreateWebhookDeliveryAttempt",
+			testutils.ContextMatcher,
+			mock.IsType(&types.WebhookDeliveryAttemptDatabaseCreationInput{}),
+		).Return(&types.WebhookDeliveryAttempt{}, nil)
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), ts.Client(), dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.NoError(t, worker.HandleMessage(ctx, examplePayload))
+		assert.Equal(t, int32(0), atomic.LoadInt32(&hits))
+
+		require.Len(t, worker.webhookDeliveries, 1)
+		worker.performWebhookDelivery(ctx, <-worker.webhookDeliveries)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+
+	T.Run("with error fetching webhooks", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		msg := &types.DataChangeMessage{
+			MessageType:             types.CreatedMessageType,
+			DataType:                types.ItemDataType,
+			AttributableToAccountID: exampleAccountID,
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.WebhookDataManager.On(
+			"GetWebhooks",
+			testutils.ContextMatcher,
+			exampleAccountID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return((*types.WebhookList)(nil), errors.New("blah"))
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.Error(t, worker.HandleMessage(ctx, examplePayload))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+
+	T.Run("ignores session revocations", func(t *testing.T) {
+		t.Parallel()
+
+		msg := &types.DataChangeMessage{
+			MessageType:           types.RevokedMessageType,
+			DataType:              types.UserSessionDataType,
+			UserSessionRevocation: &types.UserSessionRevocation{},
+			AttributableToUserID:  fakes.BuildFakeID(),
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		dbManager := database.BuildMockDatabase()
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.NoError(t, worker.HandleMessage(ctx, examplePayload))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+
+	T.Run("ignores conflicts", func(t *testing.T) {
+		t.Parallel()
+
+		msg := &types.DataChangeMessage{
+			MessageType:             types.ConflictMessageType,
+			DataType:                types.ItemDataType,
+			Item:                    fakes.BuildFakeItem(),
+			AttributableToUserID:    fakes.BuildFakeID(),
+			AttributableToAccountID: fakes.BuildFakeID(),
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		dbManager := database.BuildMockDatabase()
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.NoError(t, worker.HandleMessage(ctx, examplePayload))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+
+	T.Run("with redelivery request", func(t *testing.T) {
+		t.Parallel()
+
+		var hits int32
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&hits, 1)
+			res.WriteHeader(http.StatusOK)
+		}))
+		defer ts.Close()
+
+		exampleAccountID := fakes.BuildFakeID()
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.URL = ts.URL
+		exampleAttempt := fakes.BuildFakeWebhookDeliveryAttempt()
+		exampleAttempt.BelongsToWebhook = exampleWebhook.ID
+
+		msg := &types.DataChangeMessage{
+			MessageType:             types.WebhookRedeliveryMessageType,
+			WebhookDeliveryAttempt:  exampleAttempt,
+			AttributableToAccountID: exampleAccountID,
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.WebhookDataManager.On(
+			"GetWebhook",
+			testutils.ContextMatcher,
+			exampleWebhook.ID,
+			exampleAccountID,
+		).Return(exampleWebhook, nil)
+		dbManager.WebhookDataManager.On(
+			"CreateWebhookDeliveryAttempt",
+			testutils.ContextMatcher,
+			mock.IsType(&types.WebhookDeliveryAttemptDatabaseCreationInput{}),
+		).Return(&types.WebhookDeliveryAttempt{}, nil)
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), ts.Client(), dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.NoError(t, worker.HandleMessage(ctx, examplePayload))
+		assert.Equal(t, int32(0), atomic.LoadInt32(&hits))
+
+		require.Len(t, worker.webhookDeliveries, 1)
+		worker.performWebhookDelivery(ctx, <-worker.webhookDeliveries)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+	T.Run("with redelivery request missing its attempt", func(t *testing.T) {
+		t.Parallel()
+
+		msg := &types.DataChangeMessage{
+			MessageType:             types.WebhookRedeliveryMessageType,
+			AttributableToAccountID: fakes.BuildFakeID(),
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		dbManager := database.BuildMockDatabase()
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.NoError(t, worker.HandleMessage(ctx, examplePayload))
+		assert.Empty(t, worker.webhookDeliveries)
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+
+	T.Run("with full delivery queue and cancelled context", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		exampleAttempt := fakes.BuildFakeWebhookDeliveryAttempt()
+
+		msg := &types.DataChangeMessage{
+			MessageType:             types.WebhookRedeliveryMessageType,
+			WebhookDeliveryAttempt:  exampleAttempt,
+			AttributableToAccountID: exampleAccountID,
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, database.BuildMockDatabase(), nil, nil, nil)
+		worker.webhookDeliveries = make(chan *webhookDelivery)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.Error(t, worker.HandleMessage(ctx, examplePayload))
+	})
 }
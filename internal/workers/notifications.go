@@ -0,0 +1,180 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// buildNotifications determines which users a data change message concerns, and what to tell them.
+func buildNotifications(msg *types.DataChangeMessage) []*types.NotificationDatabaseCreationInput {
+	inputs := []*types.NotificationDatabaseCreationInput{}
+
+	notify := func(userID, title, description string) {
+		// nobody needs to be told about something they did themselves.
+		if userID == "" || userID == msg.AttributableToUserID {
+			return
+		}
+
+		inputs = append(inputs, &types.NotificationDatabaseCreationInput{
+			ID:               ksuid.New().String(),
+			Title:            title,
+			Description:      description,
+			BelongsToAccount: msg.AttributableToAccountID,
+			BelongsToUser:    userID,
+		})
+	}
+
+	switch {
+	case msg.MessageType == types.CreatedMessageType && msg.DataType == types.UserMembershipDataType && msg.UserMembership != nil:
+		notify(
+			msg.UserMembership.BelongsToUser,
+			"added to account",
+			"you were added to an account",
+		)
+	case msg.MessageType == types.OwnershipTransferredMessageType && msg.OwnershipTransfer != nil:
+		notify(
+			msg.OwnershipTransfer.NewOwner,
+			"account ownership received",
+			"you are now the owner of an account",
+		)
+		notify(
+			msg.OwnershipTransfer.CurrentOwner,
+			"account ownership transferred",
+			"an account you owned was transferred to a new owner",
+		)
+	}
+
+	return inputs
+}
+
+// createNotifications creates the notifications a data change message calls for, publishes each of them so the
+// websockets service can push them to their recipient, and queues them for the next digest email.
+func (w *DataChangesWorker) createNotifications(ctx context.Context, msg *types.DataChangeMessage) error {
+	ctx, span := w.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := w.logger.WithValue(keys.AccountIDKey, msg.AttributableToAccountID)
+
+	created := []*types.Notification{}
+
+	for _, input := range buildNotifications(msg) {
+		notification, err := w.dataManager.CreateNotification(ctx, input)
+		if err != nil {
+			return observability.PrepareError(err, logger, span, "creating notification")
+		}
+
+		created = append(created, notification)
+
+		if w.dataChangesPublisher == nil {
+			continue
+		}
+
+		dcm := &types.DataChangeMessage{
+			MessageType:             types.CreatedMessageType,
+			DataType:                types.NotificationDataType,
+			Notification:            notification,
+			AttributableToUserID:    notification.BelongsToUser,
+			AttributableToAccountID: notification.BelongsToAccount,
+		}
+
+		if err = w.dataChangesPublisher.Publish(ctx, dcm); err != nil {
+			return observability.PrepareError(err, logger, span, "publishing notification")
+		}
+	}
+
+	w.queueNotificationDigests(created)
+
+	return nil
+}
+
+// queueNotificationDigests holds on to notifications until the next digest goes out.
+func (w *DataChangesWorker) queueNotificationDigests(notifications []*types.Notification) {
+	if w.emailer == nil || w.emailRenderer == nil || len(notifications) == 0 {
+		return
+	}
+
+	w.pendingDigestsMu.Lock()
+	defer w.pendingDigestsMu.Unlock()
+
+	w.pendingDigests = append(w.pendingDigests, notifications...)
+}
+
+// flushNotificationDigests emails everything that's been queued since the last flush.
+func (w *DataChangesWorker) flushNotificationDigests(ctx context.Context) {
+	w.pendingDigestsMu.Lock()
+	pending := w.pendingDigests
+	w.pendingDigests = nil
+	w.pendingDigestsMu.Unlock()
+
+	w.sendNotificationDigests(ctx, w.logger, pending)
+}
+
+// DigestNotifications emails users a digest of the notifications they received once per digest window, until the
+// context is cancelled. Anything still queued at that point is sent on the way out.
+func (w *DataChangesWorker) DigestNotifications(ctx context.Context) {
+	ticker := time.NewTicker(w.digestWindow)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.flushNotificationDigests(ctx)
+		case <-ctx.Done():
+			w.flushNotificationDigests(context.Background())
+			return
+		}
+	}
+}
+
+// sendNotificationDigests emails each user who received notifications a summary of them. Notifications
+// are already delivered in-app by the time we get here, so failures are only logged.
+func (w *DataChangesWorker) sendNotificationDigests(ctx context.Context, logger logging.Logger, notifications []*types.Notification) {
+	ctx, span := w.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if w.emailer == nil || w.emailRenderer == nil || len(notifications) == 0 {
+		return
+	}
+
+	userIDs := []string{}
+	byUser := map[string][]*types.Notification{}
+
+	for _, n := range notifications {
+		if _, ok := byUser[n.BelongsToUser]; !ok {
+			userIDs = append(userIDs, n.BelongsToUser)
+		}
+
+		byUser[n.BelongsToUser] = append(byUser[n.BelongsToUser], n)
+	}
+
+	for _, userID := range userIDs {
+		l := logger.WithValue(keys.UserIDKey, userID)
+
+		user, err := w.dataManager.GetUser(ctx, userID)
+		if err != nil {
+			observability.AcknowledgeError(err, l, span, "fetching user for notification digest")
+			continue
+		}
+
+		if user.EmailAddress == "" {
+			continue
+		}
+
+		msg, err := w.emailRenderer.BuildNotificationDigestEmail(user, byUser[userID])
+		if err != nil {
+			observability.AcknowledgeError(err, l, span, "rendering notification digest email")
+			continue
+		}
+
+		if err = w.emailer.SendEmail(ctx, msg); err != nil {
+			observability.AcknowledgeError(err, l, span, "sending notification digest email")
+		}
+	}
+}
@@ -0,0 +1,466 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
+	mockemail "gitlab.com/verygoodsoftwarenotvirus/todo/internal/email/mock"
+	mockpublishers "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers/mock"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/frontend"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+func Test_buildNotifications(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with membership creation", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		exampleUserID := fakes.BuildFakeID()
+		msg := &types.DataChangeMessage{
+			MessageType: types.CreatedMessageType,
+			DataType:    types.UserMembershipDataType,
+			UserMembership: &types.AccountUserMembership{
+				BelongsToUser:    exampleUserID,
+				BelongsToAccount: exampleAccountID,
+			},
+			AttributableToUserID:    fakes.BuildFakeID(),
+			AttributableToAccountID: exampleAccountID,
+		}
+
+		actual := buildNotifications(msg)
+		require.Len(t, actual, 1)
+		assert.Equal(t, exampleUserID, actual[0].BelongsToUser)
+		assert.Equal(t, exampleAccountID, actual[0].BelongsToAccount)
+		assert.NoError(t, actual[0].ValidateWithContext(context.Background()))
+	})
+
+	T.Run("with ownership transfer", func(t *testing.T) {
+		t.Parallel()
+
+		exampleInput := fakes.BuildFakeTransferAccountOwnershipInput()
+		msg := &types.DataChangeMessage{
+			MessageType:             types.OwnershipTransferredMessageType,
+			DataType:                types.UserMembershipDataType,
+			OwnershipTransfer:       exampleInput,
+			AttributableToUserID:    fakes.BuildFakeID(),
+			AttributableToAccountID: fakes.BuildFakeID(),
+		}
+
+		actual := buildNotifications(msg)
+		require.Len(t, actual, 2)
+		assert.Equal(t, exampleInput.NewOwner, actual[0].BelongsToUser)
+		assert.Equal(t, exampleInput.CurrentOwner, actual[1].BelongsToUser)
+	})
+
+	T.Run("does not notify the user responsible", func(t *testing.T) {
+		t.Parallel()
+
+		exampleInput := fakes.BuildFakeTransferAccountOwnershipInput()
+		msg := &types.DataChangeMessage{
+			MessageType:             types.OwnershipTransferredMessageType,
+			DataType:                types.UserMembershipDataType,
+			OwnershipTransfer:       exampleInput,
+			AttributableToUserID:    exampleInput.CurrentOwner,
+			AttributableToAccountID: fakes.BuildFakeID(),
+		}
+
+		actual := buildNotifications(msg)
+		require.Len(t, actual, 1)
+		assert.Equal(t, exampleInput.NewOwner, actual[0].BelongsToUser)
+	})
+
+	T.Run("with irrelevant message", func(t *testing.T) {
+		t.Parallel()
+
+		msg := &types.DataChangeMessage{
+			MessageType:             types.CreatedMessageType,
+			DataType:                types.ItemDataType,
+			Item:                    fakes.BuildFakeItem(),
+			AttributableToAccountID: fakes.BuildFakeID(),
+		}
+
+		assert.Empty(t, buildNotifications(msg))
+	})
+}
+
+func TestDataChangesWorker_createNotifications(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleNotification := fakes.BuildFakeNotification()
+		msg := &types.DataChangeMessage{
+			MessageType: types.CreatedMessageType,
+			DataType:    types.UserMembershipDataType,
+			UserMembership: &types.AccountUserMembership{
+				BelongsToUser:    exampleNotification.BelongsToUser,
+				BelongsToAccount: exampleNotification.BelongsToAccount,
+			},
+			AttributableToUserID:    fakes.BuildFakeID(),
+			AttributableToAccountID: exampleNotification.BelongsToAccount,
+		}
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.NotificationDataManager.On(
+			"CreateNotification",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.NotificationDatabaseCreationInput) bool {
+				return input.BelongsToUser == exampleNotification.BelongsToUser
+			}),
+		).Return(exampleNotification, nil)
+
+		publisher := &mockpublishers.Publisher{}
+		publisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(dcm *types.DataChangeMessage) bool {
+				return dcm.DataType == types.NotificationDataType &&
+					dcm.Notification == exampleNotification &&
+					dcm.AttributableToUserID == exampleNotification.BelongsToUser
+			}),
+		).Return(nil)
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, publisher, nil, nil)
+
+		ctx := context.Background()
+		assert.NoError(t, worker.createNotifications(ctx, msg))
+
+		mock.AssertExpectationsForObjects(t, dbManager, publisher)
+	})
+
+	T.Run("with error creating notification", func(t *testing.T) {
+		t.Parallel()
+
+		msg := &types.DataChangeMessage{
+			MessageType:             types.OwnershipTransferredMessageType,
+			DataType:                types.UserMembershipDataType,
+			OwnershipTransfer:       fakes.BuildFakeTransferAccountOwnershipInput(),
+			AttributableToUserID:    fakes.BuildFakeID(),
+			AttributableToAccountID: fakes.BuildFakeID(),
+		}
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.NotificationDataManager.On(
+			"CreateNotification",
+			testutils.ContextMatcher,
+			mock.IsType(&types.NotificationDatabaseCreationInput{}),
+		).Return((*types.Notification)(nil), errors.New("blah"))
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.Error(t, worker.createNotifications(ctx, msg))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+
+	T.Run("with error publishing notification", func(t *testing.T) {
+		t.Parallel()
+
+		exampleNotification := fakes.BuildFakeNotification()
+		msg := &types.DataChangeMessage{
+			MessageType: types.CreatedMessageType,
+			DataType:    types.UserMembershipDataType,
+			UserMembership: &types.AccountUserMembership{
+				BelongsToUser:    exampleNotification.BelongsToUser,
+				BelongsToAccount: exampleNotification.BelongsToAccount,
+			},
+			AttributableToUserID:    fakes.BuildFakeID(),
+			AttributableToAccountID: exampleNotification.BelongsToAccount,
+		}
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.NotificationDataManager.On(
+			"CreateNotification",
+			testutils.ContextMatcher,
+			mock.IsType(&types.NotificationDatabaseCreationInput{}),
+		).Return(exampleNotification, nil)
+
+		publisher := &mockpublishers.Publisher{}
+		publisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.IsType(&types.DataChangeMessage{}),
+		).Return(errors.New("blah"))
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, publisher, nil, nil)
+
+		ctx := context.Background()
+		assert.Error(t, worker.createNotifications(ctx, msg))
+
+		mock.AssertExpectationsForObjects(t, dbManager, publisher)
+	})
+}
+
+func TestDataChangesWorker_HandleMessage_Notifications(T *testing.T) {
+	T.Parallel()
+
+	T.Run("ignores notification messages", func(t *testing.T) {
+		t.Parallel()
+
+		msg := &types.DataChangeMessage{
+			MessageType:             types.CreatedMessageType,
+			DataType:                types.NotificationDataType,
+			Notification:            fakes.BuildFakeNotification(),
+			AttributableToUserID:    fakes.BuildFakeID(),
+			AttributableToAccountID: fakes.BuildFakeID(),
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		dbManager := database.BuildMockDatabase()
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.NoError(t, worker.HandleMessage(ctx, examplePayload))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+
+	T.Run("does not create notifications for messages that will be redelivered", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		msg := &types.DataChangeMessage{
+			MessageType: types.CreatedMessageType,
+			DataType:    types.UserMembershipDataType,
+			UserMembership: &types.AccountUserMembership{
+				BelongsToUser:    fakes.BuildFakeID(),
+				BelongsToAccount: exampleAccountID,
+			},
+			AttributableToUserID:    fakes.BuildFakeID(),
+			AttributableToAccountID: exampleAccountID,
+		}
+		examplePayload, err := json.Marshal(msg)
+		require.NoError(t, err)
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.WebhookDataManager.On(
+			"GetWebhooks",
+			testutils.ContextMatcher,
+			exampleAccountID,
+			mock.IsType(&types.QueryFilter{}),
+		).Return((*types.WebhookList)(nil), errors.New("blah"))
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.Error(t, worker.HandleMessage(ctx, examplePayload))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+		dbManager.NotificationDataManager.AssertNotCalled(t, "CreateNotification", mock.Anything, mock.Anything)
+	})
+}
+
+func buildTestEmailRenderer(t *testing.T) *email.Renderer {
+	t.Helper()
+
+	r, err := email.ProvideRenderer(&email.Config{}, frontend.ProvideLocalizer())
+	require.NoError(t, err)
+
+	return r
+}
+
+func TestDataChangesWorker_sendNotificationDigests(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUser := fakes.BuildFakeUser()
+		exampleNotifications := fakes.BuildFakeNotificationList().Notifications
+		for _, n := range exampleNotifications {
+			n.BelongsToUser = exampleUser.ID
+		}
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.UserDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(exampleUser, nil).Once()
+
+		emailer := email.NewInMemoryEmailer()
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, emailer, buildTestEmailRenderer(t))
+
+		ctx := context.Background()
+		worker.sendNotificationDigests(ctx, logging.NewNoopLogger(), exampleNotifications)
+
+		assert.Len(t, emailer.SentTo(exampleUser.EmailAddress), 1)
+
+		mock.AssertExpectationsForObjects(t, dbManager.UserDataManager)
+	})
+
+	T.Run("with user without email address", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUser := fakes.BuildFakeUser()
+		exampleUser.EmailAddress = ""
+		exampleNotification := fakes.BuildFakeNotification()
+		exampleNotification.BelongsToUser = exampleUser.ID
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.UserDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(exampleUser, nil)
+
+		emailer := email.NewInMemoryEmailer()
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, emailer, buildTestEmailRenderer(t))
+
+		ctx := context.Background()
+		worker.sendNotificationDigests(ctx, logging.NewNoopLogger(), []*types.Notification{exampleNotification})
+
+		assert.Empty(t, emailer.SentMessages())
+
+		mock.AssertExpectationsForObjects(t, dbManager.UserDataManager)
+	})
+
+	T.Run("with error fetching user", func(t *testing.T) {
+		t.Parallel()
+
+		exampleNotification := fakes.BuildFakeNotification()
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.UserDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			exampleNotification.BelongsToUser,
+		).Return((*types.User)(nil), errors.New("blah"))
+
+		emailer := email.NewInMemoryEmailer()
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, emailer, buildTestEmailRenderer(t))
+
+		ctx := context.Background()
+		worker.sendNotificationDigests(ctx, logging.NewNoopLogger(), []*types.Notification{exampleNotification})
+
+		assert.Empty(t, emailer.SentMessages())
+
+		mock.AssertExpectationsForObjects(t, dbManager.UserDataManager)
+	})
+
+	T.Run("with error sending email", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUser := fakes.BuildFakeUser()
+		exampleNotification := fakes.BuildFakeNotification()
+		exampleNotification.BelongsToUser = exampleUser.ID
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.UserDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(exampleUser, nil)
+
+		emailer := &mockemail.Emailer{}
+		emailer.On(
+			"SendEmail",
+			testutils.ContextMatcher,
+			mock.IsType(&email.OutboundMessageDetails{}),
+		).Return(errors.New("blah"))
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, emailer, buildTestEmailRenderer(t))
+
+		ctx := context.Background()
+		worker.sendNotificationDigests(ctx, logging.NewNoopLogger(), []*types.Notification{exampleNotification})
+
+		mock.AssertExpectationsForObjects(t, dbManager.UserDataManager, emailer)
+	})
+}
+
+func TestDataChangesWorker_flushNotificationDigests(T *testing.T) {
+	T.Parallel()
+
+	T.Run("batches notifications from several messages", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUser := fakes.BuildFakeUser()
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.UserDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(exampleUser, nil).Once()
+
+		emailer := email.NewInMemoryEmailer()
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, emailer, buildTestEmailRenderer(t))
+
+		for i := 0; i < 3; i++ {
+			exampleNotification := fakes.BuildFakeNotification()
+			exampleNotification.BelongsToUser = exampleUser.ID
+			worker.queueNotificationDigests([]*types.Notification{exampleNotification})
+		}
+
+		ctx := context.Background()
+		worker.flushNotificationDigests(ctx)
+		worker.flushNotificationDigests(ctx)
+
+		assert.Len(t, emailer.SentTo(exampleUser.EmailAddress), 1)
+
+		mock.AssertExpectationsForObjects(t, dbManager.UserDataManager)
+	})
+
+	T.Run("without emailer", func(t *testing.T) {
+		t.Parallel()
+
+		dbManager := database.BuildMockDatabase()
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, nil, nil)
+
+		worker.queueNotificationDigests([]*types.Notification{fakes.BuildFakeNotification()})
+		worker.flushNotificationDigests(context.Background())
+
+		assert.Empty(t, worker.pendingDigests)
+
+		mock.AssertExpectationsForObjects(t, dbManager.UserDataManager)
+	})
+}
+
+func TestDataChangesWorker_DigestNotifications(T *testing.T) {
+	T.Parallel()
+
+	T.Run("sends queued notifications when cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		exampleUser := fakes.BuildFakeUser()
+		exampleNotification := fakes.BuildFakeNotification()
+		exampleNotification.BelongsToUser = exampleUser.ID
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.UserDataManager.On(
+			"GetUser",
+			testutils.ContextMatcher,
+			exampleUser.ID,
+		).Return(exampleUser, nil).Once()
+
+		emailer := email.NewInMemoryEmailer()
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, emailer, buildTestEmailRenderer(t))
+		worker.queueNotificationDigests([]*types.Notification{exampleNotification})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		worker.DigestNotifications(ctx)
+
+		assert.Len(t, emailer.SentTo(exampleUser.EmailAddress), 1)
+
+		mock.AssertExpectationsForObjects(t, dbManager.UserDataManager)
+	})
+}
@@ -5,6 +5,7 @@
 	"fmt"
 	"net/http"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/audit"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers"
@@ -37,7 +38,7 @@ func ProvidePreArchivesWorker(
 ) (*PreArchivesWorker, error) {
 	const name = "pre_archives"
 
-	itemsIndexManager, err := searchIndexProvider(ctx, logger, client, searchIndexLocation, "items", "name", "description")
+	itemsIndexManager, err := searchIndexProvider(ctx, logger, client, searchIndexLocation, "items", "name", "details")
 	if err != nil {
 		return nil, fmt.Errorf("setting up items search index manager: %w", err)
 	}
@@ -76,13 +77,24 @@ func (w *PreArchivesWorker) HandleMessage(ctx context.Context, message []byte) e
 			return observability.PrepareError(err, w.logger, span, "archiving item")
 		}
 
+		audit.Record(ctx, logger, w.dataManager, &types.AuditLogEntryCreationInput{
+			EventType:        types.ItemArchiveEvent,
+			ActorUserID:      msg.AttributableToUserID,
+			BelongsToAccount: msg.AttributableToAccountID,
+			ResourceType:     types.ItemResourceType,
+			ResourceID:       msg.RelevantID,
+			RequestID:        msg.RequestID,
+		})
+
 		if err := w.itemsIndexManager.Delete(ctx, msg.RelevantID); err != nil {
 			return observability.PrepareError(err, w.logger, span, "removing item from index")
 		}
 
 		if w.postArchivesPublisher != nil {
 			dcm := &types.DataChangeMessage{
+				MessageType:             types.ArchivedMessageType,
 				DataType:                msg.DataType,
+				RelevantID:              msg.RelevantID,
 				AttributableToUserID:    msg.AttributableToUserID,
 				AttributableToAccountID: msg.AttributableToAccountID,
 			}
@@ -96,9 +108,20 @@ func (w *PreArchivesWorker) HandleMessage(ctx context.Context, message []byte) e
 			return observability.PrepareError(err, w.logger, span, "creating item")
 		}
 
+		audit.Record(ctx, logger, w.dataManager, &types.AuditLogEntryCreationInput{
+			EventType:        types.WebhookArchiveEvent,
+			ActorUserID:      msg.AttributableToUserID,
+			BelongsToAccount: msg.AttributableToAccountID,
+			ResourceType:     types.WebhookResourceType,
+			ResourceID:       msg.RelevantID,
+			RequestID:        msg.RequestID,
+		})
+
 		if w.postArchivesPublisher != nil {
 			dcm := &types.DataChangeMessage{
+				MessageType:             types.ArchivedMessageType,
 				DataType:                msg.DataType,
+				RelevantID:              msg.RelevantID,
 				AttributableToUserID:    msg.AttributableToUserID,
 				AttributableToAccountID: msg.AttributableToAccountID,
 			}
@@ -137,6 +137,11 @@ func TestPreArchivesWorker_HandleMessage(T *testing.T) {
 			body.RelevantID,
 			body.AttributableToAccountID,
 		).Return(nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool { return input.EventType == types.ItemArchiveEvent }),
+		).Return(nil)
 
 		postArchivesPublisher := &mockpublishers.Publisher{}
 		postArchivesPublisher.On(
@@ -238,6 +243,11 @@ func TestPreArchivesWorker_HandleMessage(T *testing.T) {
 			body.RelevantID,
 			body.AttributableToAccountID,
 		).Return(nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool { return input.EventType == types.ItemArchiveEvent }),
+		).Return(nil)
 
 		searchIndexManager := &mocksearch.IndexManager{}
 		searchIndexManager.On(
@@ -290,6 +300,11 @@ func TestPreArchivesWorker_HandleMessage(T *testing.T) {
 			body.RelevantID,
 			body.AttributableToAccountID,
 		).Return(nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool { return input.EventType == types.ItemArchiveEvent }),
+		).Return(nil)
 
 		postArchivesPublisher := &mockpublishers.Publisher{}
 		postArchivesPublisher.On(
@@ -347,6 +362,13 @@ func TestPreArchivesWorker_HandleMessage(T *testing.T) {
 			body.RelevantID,
 			body.AttributableToAccountID,
 		).Return(nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.WebhookArchiveEvent
+			}),
+		).Return(nil)
 
 		postArchivesPublisher := &mockpublishers.Publisher{}
 		postArchivesPublisher.On(
@@ -442,6 +464,13 @@ func TestPreArchivesWorker_HandleMessage(T *testing.T) {
 			body.RelevantID,
 			body.AttributableToAccountID,
 		).Return(nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.WebhookArchiveEvent
+			}),
+		).Return(nil)
 
 		postArchivesPublisher := &mockpublishers.Publisher{}
 		postArchivesPublisher.On(
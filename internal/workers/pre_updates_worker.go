@@ -2,15 +2,18 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/audit"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
@@ -38,7 +41,7 @@ func ProvidePreUpdatesWorker(
 ) (*PreUpdatesWorker, error) {
 	const name = "pre_updates"
 
-	itemsIndexManager, err := searchIndexProvider(ctx, logger, client, searchIndexLocation, "items", "name", "description")
+	itemsIndexManager, err := searchIndexProvider(ctx, logger, client, searchIndexLocation, "items", "name", "details")
 	if err != nil {
 		return nil, fmt.Errorf("setting up items search index manager: %w", err)
 	}
@@ -73,16 +76,31 @@ func (w *PreUpdatesWorker) HandleMessage(ctx context.Context, message []byte) er
 
 	switch msg.DataType {
 	case types.ItemDataType:
-		if err := w.dataManager.UpdateItem(ctx, msg.Item); err != nil {
-			return observability.PrepareError(err, logger, span, "creating item")
+		if err := w.dataManager.UpdateItem(ctx, msg.Item); errors.Is(err, database.ErrVersionConflict) {
+			// someone else changed the item since this update was requested, so tell whoever is listening.
+			logger.WithValue(keys.ItemIDKey, msg.Item.ID).Debug("item changed before update could be written")
+			return w.publishConflict(ctx, msg)
+		} else if err != nil {
+			return observability.PrepareError(err, logger, span, "updating item")
 		}
 
+		audit.Record(ctx, logger, w.dataManager, &types.AuditLogEntryCreationInput{
+			EventType:        types.ItemUpdateEvent,
+			ActorUserID:      msg.AttributableToUserID,
+			BelongsToAccount: msg.AttributableToAccountID,
+			ResourceType:     types.ItemResourceType,
+			ResourceID:       msg.Item.ID,
+			RequestID:        msg.RequestID,
+			Changes:          msg.Changes,
+		})
+
 		if err := w.itemsIndexManager.Index(ctx, msg.Item.ID, msg.Item); err != nil {
 			return observability.PrepareError(err, logger, span, "indexing the item")
 		}
 
 		if w.postUpdatesPublisher != nil {
 			dcm := &types.DataChangeMessage{
+				MessageType:             types.UpdatedMessageType,
 				DataType:                msg.DataType,
 				Item:                    msg.Item,
 				AttributableToUserID:    msg.AttributableToUserID,
@@ -93,9 +111,62 @@ func (w *PreUpdatesWorker) HandleMessage(ctx context.Context, message []byte) er
 				return observability.PrepareError(err, logger, span, "publishing data change message")
 			}
 		}
-	case types.UserMembershipDataType, types.WebhookDataType:
+	case types.WebhookDataType:
+		if err := w.dataManager.UpdateWebhook(ctx, msg.Webhook); err != nil {
+			return observability.PrepareError(err, logger, span, "updating webhook")
+		}
+
+		audit.Record(ctx, logger, w.dataManager, &types.AuditLogEntryCreationInput{
+			EventType:        types.WebhookUpdateEvent,
+			ActorUserID:      msg.AttributableToUserID,
+			BelongsToAccount: msg.AttributableToAccountID,
+			ResourceType:     types.WebhookResourceType,
+			ResourceID:       msg.Webhook.ID,
+			RequestID:        msg.RequestID,
+			Changes:          msg.Changes,
+		})
+
+		if w.postUpdatesPublisher != nil {
+			dcm := &types.DataChangeMessage{
+				MessageType:             types.UpdatedMessageType,
+				DataType:                msg.DataType,
+				Webhook:                 msg.Webhook,
+				AttributableToUserID:    msg.AttributableToUserID,
+				AttributableToAccountID: msg.AttributableToAccountID,
+			}
+
+			if err := w.postUpdatesPublisher.Publish(ctx, dcm); err != nil {
+				return observability.PrepareError(err, logger, span, "publishing data change message")
+			}
+		}
+	case types.UserMembershipDataType:
 		break
 	}
 
 	return nil
 }
+
+// publishConflict announces that a pending update was not written, because the data changed after it was requested.
+func (w *PreUpdatesWorker) publishConflict(ctx context.Context, msg *types.PreUpdateMessage) error {
+	ctx, span := w.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if w.postUpdatesPublisher == nil {
+		return nil
+	}
+
+	dcm := &types.DataChangeMessage{
+		MessageType:             types.ConflictMessageType,
+		DataType:                msg.DataType,
+		Item:                    msg.Item,
+		Webhook:                 msg.Webhook,
+		AttributableToUserID:    msg.AttributableToUserID,
+		AttributableToAccountID: msg.AttributableToAccountID,
+	}
+
+	if err := w.postUpdatesPublisher.Publish(ctx, dcm); err != nil {
+		return observability.PrepareError(err, w.logger, span, "publishing conflict message")
+	}
+
+	return nil
+}
@@ -138,6 +138,11 @@ func TestPreUpdatesWorker_HandleMessage(T *testing.T) {
 			testutils.ContextMatcher,
 			body.Item,
 		).Return(nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool { return input.EventType == types.ItemUpdateEvent }),
+		).Return(nil)
 
 		searchIndexManager := &mocksearch.IndexManager{}
 		searchIndexManager.On(
@@ -221,6 +226,114 @@ func TestPreUpdatesWorker_HandleMessage(T *testing.T) {
 		mock.AssertExpectationsForObjects(t, dbManager, postArchivesPublisher)
 	})
 
+	T.Run("with ItemDataType with version conflict", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		client := &http.Client{}
+
+		body := &types.PreUpdateMessage{
+			DataType:                types.ItemDataType,
+			Item:                    fakes.BuildFakeItem(),
+			AttributableToUserID:    fakes.BuildFakeID(),
+			AttributableToAccountID: fakes.BuildFakeID(),
+		}
+		examplePayload, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.ItemDataManager.On(
+			"UpdateItem",
+			testutils.ContextMatcher,
+			body.Item,
+		).Return(database.ErrVersionConflict)
+
+		searchIndexLocation := search.IndexPath(t.Name())
+		searchIndexProvider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
+			return nil, nil
+		}
+
+		postUpdatesPublisher := &mockpublishers.Publisher{}
+		postUpdatesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(message *types.DataChangeMessage) bool {
+				return message.MessageType == types.ConflictMessageType &&
+					message.DataType == types.ItemDataType &&
+					message.Item.ID == body.Item.ID &&
+					message.AttributableToUserID == body.AttributableToUserID &&
+					message.AttributableToAccountID == body.AttributableToAccountID
+			}),
+		).Return(nil)
+
+		worker, err := ProvidePreUpdatesWorker(
+			ctx,
+			logger,
+			client,
+			dbManager,
+			postUpdatesPublisher,
+			searchIndexLocation,
+			searchIndexProvider,
+		)
+		require.NotNil(t, worker)
+		require.NoError(t, err)
+
+		assert.NoError(t, worker.HandleMessage(ctx, examplePayload))
+
+		mock.AssertExpectationsForObjects(t, dbManager, postUpdatesPublisher)
+	})
+
+	T.Run("with ItemDataType with version conflict and error publishing conflict", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		client := &http.Client{}
+
+		body := &types.PreUpdateMessage{
+			DataType: types.ItemDataType,
+			Item:     fakes.BuildFakeItem(),
+		}
+		examplePayload, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.ItemDataManager.On(
+			"UpdateItem",
+			testutils.ContextMatcher,
+			body.Item,
+		).Return(database.ErrVersionConflict)
+
+		searchIndexLocation := search.IndexPath(t.Name())
+		searchIndexProvider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
+			return nil, nil
+		}
+
+		postUpdatesPublisher := &mockpublishers.Publisher{}
+		postUpdatesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(message *types.DataChangeMessage) bool { return message.MessageType == types.ConflictMessageType }),
+		).Return(errors.New("blah"))
+
+		worker, err := ProvidePreUpdatesWorker(
+			ctx,
+			logger,
+			client,
+			dbManager,
+			postUpdatesPublisher,
+			searchIndexLocation,
+			searchIndexProvider,
+		)
+		require.NotNil(t, worker)
+		require.NoError(t, err)
+
+		assert.Error(t, worker.HandleMessage(ctx, examplePayload))
+
+		mock.AssertExpectationsForObjects(t, dbManager, postUpdatesPublisher)
+	})
+
 	T.Run("with ItemDataType and error updating search index", func(t *testing.T) {
 		t.Parallel()
 
@@ -241,6 +354,11 @@ func TestPreUpdatesWorker_HandleMessage(T *testing.T) {
 			testutils.ContextMatcher,
 			body.Item,
 		).Return(nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool { return input.EventType == types.ItemUpdateEvent }),
+		).Return(nil)
 
 		searchIndexManager := &mocksearch.IndexManager{}
 		searchIndexManager.On(
@@ -294,6 +412,11 @@ func TestPreUpdatesWorker_HandleMessage(T *testing.T) {
 			testutils.ContextMatcher,
 			body.Item,
 		).Return(nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool { return input.EventType == types.ItemUpdateEvent }),
+		).Return(nil)
 
 		searchIndexManager := &mocksearch.IndexManager{}
 		searchIndexManager.On(
@@ -376,18 +499,40 @@ func TestPreUpdatesWorker_HandleMessage(T *testing.T) {
 		logger := logging.NewNoopLogger()
 		client := &http.Client{}
 
+		// signing secrets are never serialized, so they won't survive the trip through the queue.
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.SigningSecret = ""
+
 		body := &types.PreUpdateMessage{
 			DataType: types.WebhookDataType,
+			Webhook:  exampleWebhook,
 		}
 		examplePayload, err := json.Marshal(body)
 		require.NoError(t, err)
 
 		dbManager := database.BuildMockDatabase()
+		dbManager.WebhookDataManager.On(
+			"UpdateWebhook",
+			testutils.ContextMatcher,
+			body.Webhook,
+		).Return(nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool { return input.EventType == types.WebhookUpdateEvent }),
+		).Return(nil)
+
 		searchIndexLocation := search.IndexPath(t.Name())
 		searchIndexProvider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
 			return nil, nil
 		}
+
 		postArchivesPublisher := &mockpublishers.Publisher{}
+		postArchivesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(message *types.DataChangeMessage) bool { return true }),
+		).Return(nil)
 
 		worker, err := ProvidePreUpdatesWorker(
 			ctx,
@@ -405,4 +550,112 @@ func TestPreUpdatesWorker_HandleMessage(T *testing.T) {
 
 		mock.AssertExpectationsForObjects(t, dbManager, postArchivesPublisher)
 	})
+
+	T.Run("with WebhookDataType with error updating webhook", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		client := &http.Client{}
+
+		// signing secrets are never serialized, so they won't survive the trip through the queue.
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.SigningSecret = ""
+
+		body := &types.PreUpdateMessage{
+			DataType: types.WebhookDataType,
+			Webhook:  exampleWebhook,
+		}
+		examplePayload, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.WebhookDataManager.On(
+			"UpdateWebhook",
+			testutils.ContextMatcher,
+			body.Webhook,
+		).Return(errors.New("blah"))
+
+		searchIndexLocation := search.IndexPath(t.Name())
+		searchIndexProvider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
+			return nil, nil
+		}
+
+		postArchivesPublisher := &mockpublishers.Publisher{}
+
+		worker, err := ProvidePreUpdatesWorker(
+			ctx,
+			logger,
+			client,
+			dbManager,
+			postArchivesPublisher,
+			searchIndexLocation,
+			searchIndexProvider,
+		)
+		require.NotNil(t, worker)
+		require.NoError(t, err)
+
+		assert.Error(t, worker.HandleMessage(ctx, examplePayload))
+
+		mock.AssertExpectationsForObjects(t, dbManager, postArchivesPublisher)
+	})
+
+	T.Run("with WebhookDataType and error publishing data change event", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		client := &http.Client{}
+
+		// signing secrets are never serialized, so they won't survive the trip through the queue.
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.SigningSecret = ""
+
+		body := &types.PreUpdateMessage{
+			DataType: types.WebhookDataType,
+			Webhook:  exampleWebhook,
+		}
+		examplePayload, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.WebhookDataManager.On(
+			"UpdateWebhook",
+			testutils.ContextMatcher,
+			body.Webhook,
+		).Return(nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool { return input.EventType == types.WebhookUpdateEvent }),
+		).Return(nil)
+
+		searchIndexLocation := search.IndexPath(t.Name())
+		searchIndexProvider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
+			return nil, nil
+		}
+
+		postArchivesPublisher := &mockpublishers.Publisher{}
+		postArchivesPublisher.On(
+			"Publish",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(message *types.DataChangeMessage) bool { return true }),
+		).Return(errors.New("blah"))
+
+		worker, err := ProvidePreUpdatesWorker(
+			ctx,
+			logger,
+			client,
+			dbManager,
+			postArchivesPublisher,
+			searchIndexLocation,
+			searchIndexProvider,
+		)
+		require.NotNil(t, worker)
+		require.NoError(t, err)
+
+		assert.Error(t, worker.HandleMessage(ctx, examplePayload))
+
+		mock.AssertExpectationsForObjects(t, dbManager, postArchivesPublisher)
+	})
 }
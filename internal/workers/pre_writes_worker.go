@@ -7,6 +7,7 @@
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/audit"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers"
@@ -38,7 +39,7 @@ func ProvidePreWritesWorker(
 ) (*PreWritesWorker, error) {
 	const name = "pre_writes"
 
-	itemsIndexManager, err := searchIndexProvider(ctx, logger, client, searchIndexLocation, "items", "name", "description")
+	itemsIndexManager, err := searchIndexProvider(ctx, logger, client, searchIndexLocation, "items", "name", "details")
 	if err != nil {
 		return nil, fmt.Errorf("setting up items search index manager: %w", err)
 	}
@@ -77,12 +78,22 @@ func (w *PreWritesWorker) HandleMessage(ctx context.Context, message []byte) err
 			return observability.PrepareError(err, logger, span, "creating item")
 		}
 
+		audit.Record(ctx, logger, w.dataManager, &types.AuditLogEntryCreationInput{
+			EventType:        types.ItemCreationEvent,
+			ActorUserID:      msg.AttributableToUserID,
+			BelongsToAccount: msg.AttributableToAccountID,
+			ResourceType:     types.ItemResourceType,
+			ResourceID:       item.ID,
+			RequestID:        msg.RequestID,
+		})
+
 		if err = w.itemsIndexManager.Index(ctx, item.ID, item); err != nil {
 			return observability.PrepareError(err, logger, span, "indexing the item")
 		}
 
 		if w.postWritesPublisher != nil {
 			dcm := &types.DataChangeMessage{
+				MessageType:             types.CreatedMessageType,
 				DataType:                msg.DataType,
 				Item:                    item,
 				AttributableToUserID:    msg.AttributableToUserID,
@@ -99,8 +110,18 @@ func (w *PreWritesWorker) HandleMessage(ctx context.Context, message []byte) err
 			return observability.PrepareError(err, logger, span, "creating webhook")
 		}
 
+		audit.Record(ctx, logger, w.dataManager, &types.AuditLogEntryCreationInput{
+			EventType:        types.WebhookCreationEvent,
+			ActorUserID:      msg.AttributableToUserID,
+			BelongsToAccount: msg.AttributableToAccountID,
+			ResourceType:     types.WebhookResourceType,
+			ResourceID:       webhook.ID,
+			RequestID:        msg.RequestID,
+		})
+
 		if w.postWritesPublisher != nil {
 			dcm := &types.DataChangeMessage{
+				MessageType:             types.CreatedMessageType,
 				DataType:                msg.DataType,
 				Webhook:                 webhook,
 				AttributableToUserID:    msg.AttributableToUserID,
@@ -116,9 +137,28 @@ func (w *PreWritesWorker) HandleMessage(ctx context.Context, message []byte) err
 			return observability.PrepareError(err, logger, span, "creating webhook")
 		}
 
+		audit.Record(ctx, logger, w.dataManager, &types.AuditLogEntryCreationInput{
+			EventType:        types.AccountMemberAddedEvent,
+			ActorUserID:      msg.AttributableToUserID,
+			BelongsToAccount: msg.UserMembership.AccountID,
+			ResourceType:     types.UserResourceType,
+			ResourceID:       msg.UserMembership.UserID,
+			RequestID:        msg.RequestID,
+			Changes: []*types.FieldChangeSummary{
+				{FieldName: "roles", NewValue: msg.UserMembership.AccountRoles},
+			},
+		})
+
 		if w.postWritesPublisher != nil {
 			dcm := &types.DataChangeMessage{
-				DataType:                msg.DataType,
+				MessageType: types.CreatedMessageType,
+				DataType:    msg.DataType,
+				UserMembership: &types.AccountUserMembership{
+					ID:               msg.UserMembership.ID,
+					BelongsToUser:    msg.UserMembership.UserID,
+					BelongsToAccount: msg.UserMembership.AccountID,
+					AccountRoles:     msg.UserMembership.AccountRoles,
+				},
 				AttributableToUserID:    msg.AttributableToUserID,
 				AttributableToAccountID: msg.AttributableToAccountID,
 			}
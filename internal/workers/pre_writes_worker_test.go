@@ -138,6 +138,11 @@ func TestPreWritesWorker_HandleMessage(T *testing.T) {
 			testutils.ContextMatcher,
 			body.Item,
 		).Return(expectedItem, nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool { return input.EventType == types.ItemCreationEvent }),
+		).Return(nil)
 
 		searchIndexManager := &mocksearch.IndexManager{}
 		searchIndexManager.On(
@@ -243,6 +248,11 @@ func TestPreWritesWorker_HandleMessage(T *testing.T) {
 			testutils.ContextMatcher,
 			body.Item,
 		).Return(expectedItem, nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool { return input.EventType == types.ItemCreationEvent }),
+		).Return(nil)
 
 		searchIndexManager := &mocksearch.IndexManager{}
 		searchIndexManager.On(
@@ -298,6 +308,11 @@ func TestPreWritesWorker_HandleMessage(T *testing.T) {
 			testutils.ContextMatcher,
 			body.Item,
 		).Return(expectedItem, nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool { return input.EventType == types.ItemCreationEvent }),
+		).Return(nil)
 
 		searchIndexManager := &mocksearch.IndexManager{}
 		searchIndexManager.On(
@@ -358,6 +373,13 @@ func TestPreWritesWorker_HandleMessage(T *testing.T) {
 			testutils.ContextMatcher,
 			body.Webhook,
 		).Return(expectedWebhook, nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.WebhookCreationEvent
+			}),
+		).Return(nil)
 
 		searchIndexLocation := search.IndexPath(t.Name())
 		searchIndexProvider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
@@ -455,6 +477,13 @@ func TestPreWritesWorker_HandleMessage(T *testing.T) {
 			testutils.ContextMatcher,
 			body.Webhook,
 		).Return(expectedWebhook, nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.WebhookCreationEvent
+			}),
+		).Return(nil)
 
 		searchIndexLocation := search.IndexPath(t.Name())
 		searchIndexProvider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
@@ -505,6 +534,13 @@ func TestPreWritesWorker_HandleMessage(T *testing.T) {
 			testutils.ContextMatcher,
 			body.UserMembership,
 		).Return(nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.AccountMemberAddedEvent
+			}),
+		).Return(nil)
 
 		searchIndexLocation := search.IndexPath(t.Name())
 		searchIndexProvider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
@@ -600,6 +636,13 @@ func TestPreWritesWorker_HandleMessage(T *testing.T) {
 			testutils.ContextMatcher,
 			mock.MatchedBy(func(input *types.AddUserToAccountInput) bool { return true }),
 		).Return(nil)
+		dbManager.AuditLogEntryDataManager.On(
+			"CreateAuditLogEntry",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.AuditLogEntryCreationInput) bool {
+				return input.EventType == types.AccountMemberAddedEvent
+			}),
+		).Return(nil)
 
 		searchIndexLocation := search.IndexPath(t.Name())
 		searchIndexProvider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
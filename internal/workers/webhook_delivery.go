@@ -0,0 +1,346 @@
+package workers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	// webhookWildcard matches any value in a webhook's Events, DataTypes, or Topics.
+	webhookWildcard = "*"
+)
+
+var (
+	// errNonRetryableWebhookResponse indicates a webhook endpoint rejected a request in a way retrying won't fix.
+	errNonRetryableWebhookResponse = errors.New("webhook endpoint rejected request")
+	// errRetryableWebhookResponse indicates a webhook endpoint failed in a way that could resolve itself.
+	errRetryableWebhookResponse = errors.New("webhook endpoint failed")
+	// errNilDeliveryAttemptProvided indicates a redelivery was requested without an attempt to redeliver.
+	errNilDeliveryAttemptProvided = errors.New("nil webhook delivery attempt provided")
+)
+
+// webhookDelivery is a delivery waiting on DeliverWebhooks. Either webhook and msg are set, for a new delivery, or
+// attempt is, for a redelivery of it.
+type webhookDelivery struct {
+	webhook   *types.Webhook
+	msg       *types.DataChangeMessage
+	attempt   *types.WebhookDeliveryAttempt
+	accountID string
+}
+
+// webhookMatchesMessage determines whether a given webhook is interested in a given data change message.
+func webhookMatchesMessage(webhook *types.Webhook, topic string, msg *types.DataChangeMessage) bool {
+	if webhook == nil || msg == nil {
+		return false
+	}
+
+	if !containsOrWildcard(webhook.Events, msg.MessageType, false) {
+		return false
+	}
+
+	if !containsOrWildcard(webhook.DataTypes, string(msg.DataType), false) {
+		return false
+	}
+
+	// topics are optional, so a webhook that specifies none is interested in all of them.
+	return containsOrWildcard(webhook.Topics, topic, true)
+}
+
+func containsOrWildcard(values []string, target string, emptyMatches bool) bool {
+	if len(values) == 0 {
+		return emptyMatches
+	}
+
+	for _, v := range values {
+		if v == webhookWildcard || v == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// signWebhookRequest attaches signatures of a payload to a webhook request, so receivers can verify it came from us.
+func signWebhookRequest(req *http.Request, webhook *types.Webhook, payload []byte, now time.Time) {
+	if webhook.SigningSecret == "" {
+		return
+	}
+
+	timestamp := now.Unix()
+	signatures := []string{types.SignWebhookPayload(webhook.SigningSecret, timestamp, payload)}
+
+	// receivers may not have picked up a rotated secret yet, so keep signing with the old one until it expires.
+	if webhook.PreviousSigningSecret != "" && webhook.PreviousSigningSecretExpiresOn != nil && *webhook.PreviousSigningSecretExpiresOn > uint64(timestamp) {
+		signatures = append(signatures, types.SignWebhookPayload(webhook.PreviousSigningSecret, timestamp, payload))
+	}
+
+	req.Header.Set(types.WebhookSignatureTimestampHeaderKey, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(types.WebhookSignatureHeaderKey, strings.Join(signatures, types.WebhookSignatureSeparator))
+}
+
+// sleepWithContext sleeps for a given duration, or until the context is done.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// backoffForAttempt returns how long we should wait before a given (zero-indexed) retry attempt.
+func (w *DataChangesWorker) backoffForAttempt(attempt uint) time.Duration {
+	backoff := w.webhookBackoff
+	for i := uint(0); i < attempt; i++ {
+		backoff *= defaultWebhookBackoffExponent
+		if backoff >= w.webhookMaxBackoff {
+			return w.webhookMaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+// buildWebhookPayload encodes a data change message in the webhook's desired content type.
+func (w *DataChangesWorker) buildWebhookPayload(ctx context.Context, webhook *types.Webhook, msg *types.DataChangeMessage) ([]byte, string, error) {
+	contentType := encoding.ProvideContentType(encoding.Config{ContentType: webhook.ContentType})
+	encoder := encoding.ProvideClientEncoder(w.logger, contentType)
+
+	var b bytes.Buffer
+	if err := encoder.Encode(ctx, &b, msg); err != nil {
+		return nil, "", err
+	}
+
+	return b.Bytes(), encoder.ContentType(), nil
+}
+
+// deliverWebhook sends a data change message to a webhook, retrying with exponential backoff upon failure.
+func (w *DataChangesWorker) deliverWebhook(ctx context.Context, webhook *types.Webhook, msg *types.DataChangeMessage) error {
+	ctx, span := w.tracer.StartCustomSpan(ctx, "deliver_webhook")
+	defer span.End()
+
+	tracing.AttachWebhookIDToSpan(span, webhook.ID)
+	tracing.AttachAccountIDToSpan(span, webhook.BelongsToAccount)
+
+	logger := w.logger.WithValue(keys.WebhookIDKey, webhook.ID).WithValue(keys.URLKey, webhook.URL)
+
+	payload, contentType, err := w.buildWebhookPayload(ctx, webhook, msg)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "encoding webhook payload")
+	}
+
+	if err = w.sendWebhookPayload(ctx, webhook, payload, contentType, 1); err != nil {
+		return observability.PrepareError(err, logger, span, "delivering webhook")
+	}
+
+	return nil
+}
+
+// enqueueWebhookDelivery hands a delivery off to DeliverWebhooks, waiting for room in the queue if need be.
+func (w *DataChangesWorker) enqueueWebhookDelivery(ctx context.Context, delivery *webhookDelivery) error {
+	select {
+	case w.webhookDeliveries <- delivery:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// performWebhookDelivery makes a queued delivery, retries and all.
+func (w *DataChangesWorker) performWebhookDelivery(ctx context.Context, delivery *webhookDelivery) {
+	ctx, span := w.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := w.logger.WithValue(keys.AccountIDKey, delivery.accountID)
+
+	if delivery.attempt != nil {
+		if err := w.redeliverWebhook(ctx, delivery.accountID, delivery.attempt); err != nil {
+			observability.AcknowledgeError(err, logger, span, "redelivering webhook")
+		}
+
+		return
+	}
+
+	if err := w.deliverWebhook(ctx, delivery.webhook, delivery.msg); err != nil {
+		observability.AcknowledgeError(err, logger.WithValue(keys.WebhookIDKey, delivery.webhook.ID), span, "delivering webhook")
+	}
+}
+
+// DeliverWebhooks works through queued webhook deliveries until the context is cancelled. Deliveries still
+// queued at that point are dropped, since the messages they came from have already been acknowledged.
+func (w *DataChangesWorker) DeliverWebhooks(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for i := uint(0); i < w.webhookConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case delivery := <-w.webhookDeliveries:
+					w.performWebhookDelivery(ctx, delivery)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// redeliverWebhook sends the body of a past delivery attempt to its webhook again.
+func (w *DataChangesWorker) redeliverWebhook(ctx context.Context, accountID string, attempt *types.WebhookDeliveryAttempt) error {
+	ctx, span := w.tracer.StartCustomSpan(ctx, "redeliver_webhook")
+	defer span.End()
+
+	if attempt == nil {
+		return errNilDeliveryAttemptProvided
+	}
+
+	tracing.AttachWebhookIDToSpan(span, attempt.BelongsToWebhook)
+	tracing.AttachWebhookDeliveryAttemptIDToSpan(span, attempt.ID)
+
+	logger := w.logger.WithValue(keys.WebhookIDKey, attempt.BelongsToWebhook).WithValue(keys.WebhookDeliveryAttemptIDKey, attempt.ID)
+
+	webhook, err := w.dataManager.GetWebhook(ctx, attempt.BelongsToWebhook, accountID)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "fetching webhook for redelivery")
+	}
+
+	contentType := encoding.ProvideClientEncoder(w.logger, encoding.ProvideContentType(encoding.Config{ContentType: webhook.ContentType})).ContentType()
+
+	if err = w.sendWebhookPayload(ctx, webhook, []byte(attempt.RequestBody), contentType, attempt.AttemptNumber+1); err != nil {
+		return observability.PrepareError(err, logger, span, "redelivering webhook")
+	}
+
+	return nil
+}
+
+// sendWebhookPayload sends an encoded payload to a webhook, retrying with exponential backoff upon failure.
+func (w *DataChangesWorker) sendWebhookPayload(ctx context.Context, webhook *types.Webhook, payload []byte, contentType string, firstAttemptNumber uint8) error {
+	ctx, span := w.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := w.logger.WithValue(keys.WebhookIDKey, webhook.ID)
+
+	var lastErr error
+	for attempt := uint(0); attempt < w.webhookMaxAttempts; attempt++ {
+		if attempt > 0 {
+			if err := w.sleepFunc(ctx, w.backoffForAttempt(attempt-1)); err != nil {
+				return observability.PrepareError(err, logger, span, "waiting to retry webhook delivery")
+			}
+		}
+
+		attemptNumber := firstAttemptNumber + uint8(attempt)
+
+		lastErr = w.attemptWebhookDelivery(ctx, webhook, payload, contentType, attemptNumber)
+		if lastErr == nil {
+			logger.WithValue("attempt", attemptNumber).Debug("webhook delivered")
+			return nil
+		}
+
+		if errors.Is(lastErr, errNonRetryableWebhookResponse) {
+			break
+		}
+	}
+
+	return lastErr
+}
+
+// attemptWebhookDelivery makes a single, time-limited attempt to deliver a payload to a webhook, and records the outcome.
+func (w *DataChangesWorker) attemptWebhookDelivery(ctx context.Context, webhook *types.Webhook, payload []byte, contentType string, attemptNumber uint8) error {
+	ctx, span := w.tracer.StartCustomSpan(ctx, "webhook_delivery_attempt")
+	defer span.End()
+
+	tracing.AttachWebhookIDToSpan(span, webhook.ID)
+	tracing.AttachToSpan(span, "webhook.delivery_attempt", attemptNumber)
+
+	logger := w.logger.WithValue(keys.WebhookIDKey, webhook.ID).WithValue("attempt", attemptNumber)
+
+	start := time.Now()
+	status, err := w.executeWebhookRequest(ctx, webhook, payload, contentType)
+	latency := time.Since(start)
+
+	record := &types.WebhookDeliveryAttemptDatabaseCreationInput{
+		ID:                    ksuid.New().String(),
+		RequestBody:           string(payload),
+		ResponseStatus:        uint16(status),
+		LatencyInMilliseconds: uint64(latency.Milliseconds()),
+		AttemptNumber:         attemptNumber,
+		BelongsToWebhook:      webhook.ID,
+	}
+
+	if err != nil {
+		record.ErrorText = err.Error()
+	}
+
+	if _, recordErr := w.dataManager.CreateWebhookDeliveryAttempt(ctx, record); recordErr != nil {
+		observability.AcknowledgeError(recordErr, logger, span, "recording webhook delivery attempt")
+	}
+
+	return err
+}
+
+// executeWebhookRequest sends a payload to a webhook and returns the response status, if any.
+func (w *DataChangesWorker) executeWebhookRequest(ctx context.Context, webhook *types.Webhook, payload []byte, contentType string) (int, error) {
+	ctx, span := w.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := w.logger.WithValue(keys.WebhookIDKey, webhook.ID)
+
+	ctx, cancel := context.WithTimeout(ctx, w.webhookAttemptTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, webhook.Method, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("%w: building request: %v", errNonRetryableWebhookResponse, err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	signWebhookRequest(req, webhook, payload, time.Now())
+	tracing.AttachRequestToSpan(span, req)
+
+	res, err := w.webhookClient.Do(req)
+	if err != nil {
+		observability.AcknowledgeError(err, logger, span, "executing webhook request")
+		return 0, fmt.Errorf("%w: %v", errRetryableWebhookResponse, err)
+	}
+
+	// drain the body so the underlying connection can be reused.
+	_, _ = io.Copy(io.Discard, res.Body)
+	if closeErr := res.Body.Close(); closeErr != nil {
+		observability.AcknowledgeError(closeErr, logger, span, "closing webhook response body")
+	}
+
+	tracing.AttachResponseToSpan(span, res)
+
+	switch {
+	case res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices:
+		return res.StatusCode, nil
+	case res.StatusCode == http.StatusTooManyRequests, res.StatusCode >= http.StatusInternalServerError:
+		return res.StatusCode, fmt.Errorf("%w: received status %d", errRetryableWebhookResponse, res.StatusCode)
+	default:
+		return res.StatusCode, fmt.Errorf("%w: received status %d", errNonRetryableWebhookResponse, res.StatusCode)
+	}
+}
@@ -0,0 +1,529 @@
+package workers
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+func buildTestDataChangesWorker(t *testing.T, client *http.Client) *DataChangesWorker {
+	t.Helper()
+
+	dbManager := database.BuildMockDatabase()
+	dbManager.WebhookDataManager.On(
+		"CreateWebhookDeliveryAttempt",
+		testutils.ContextMatcher,
+		mock.IsType(&types.WebhookDeliveryAttemptDatabaseCreationInput{}),
+	).Return(&types.WebhookDeliveryAttempt{}, nil)
+
+	worker := ProvideDataChangesWorker(logging.NewNoopLogger(), client, dbManager, nil, nil, nil)
+	worker.sleepFunc = func(context.Context, time.Duration) error { return nil }
+	worker.webhookMaxAttempts = 3
+
+	return worker
+}
+
+func Test_webhookMatchesMessage(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		webhook := fakes.BuildFakeWebhook()
+		webhook.Events = []string{types.CreatedMessageType}
+		webhook.DataTypes = []string{string(types.ItemDataType)}
+		webhook.Topics = []string{dataChangesTopicName}
+
+		msg := &types.DataChangeMessage{MessageType: types.CreatedMessageType, DataType: types.ItemDataType}
+
+		assert.True(t, webhookMatchesMessage(webhook, dataChangesTopicName, msg))
+	})
+
+	T.Run("with wildcards and no topics", func(t *testing.T) {
+		t.Parallel()
+
+		webhook := fakes.BuildFakeWebhook()
+		webhook.Events = []string{webhookWildcard}
+		webhook.DataTypes = []string{webhookWildcard}
+		webhook.Topics = nil
+
+		msg := &types.DataChangeMessage{MessageType: types.ArchivedMessageType, DataType: types.WebhookDataType}
+
+		assert.True(t, webhookMatchesMessage(webhook, dataChangesTopicName, msg))
+	})
+
+	T.Run("with mismatched event", func(t *testing.T) {
+		t.Parallel()
+
+		webhook := fakes.BuildFakeWebhook()
+		webhook.Events = []string{types.UpdatedMessageType}
+		webhook.DataTypes = []string{string(types.ItemDataType)}
+		webhook.Topics = nil
+
+		msg := &types.DataChangeMessage{MessageType: types.CreatedMessageType, DataType: types.ItemDataType}
+
+		assert.False(t, webhookMatchesMessage(webhook, dataChangesTopicName, msg))
+	})
+
+	T.Run("with mismatched data type", func(t *testing.T) {
+		t.Parallel()
+
+		webhook := fakes.BuildFakeWebhook()
+		webhook.Events = []string{types.CreatedMessageType}
+		webhook.DataTypes = []string{string(types.WebhookDataType)}
+		webhook.Topics = nil
+
+		msg := &types.DataChangeMessage{MessageType: types.CreatedMessageType, DataType: types.ItemDataType}
+
+		assert.False(t, webhookMatchesMessage(webhook, dataChangesTopicName, msg))
+	})
+
+	T.Run("with mismatched topic", func(t *testing.T) {
+		t.Parallel()
+
+		webhook := fakes.BuildFakeWebhook()
+		webhook.Events = []string{types.CreatedMessageType}
+		webhook.DataTypes = []string{string(types.ItemDataType)}
+		webhook.Topics = []string{"fake"}
+
+		msg := &types.DataChangeMessage{MessageType: types.CreatedMessageType, DataType: types.ItemDataType}
+
+		assert.False(t, webhookMatchesMessage(webhook, dataChangesTopicName, msg))
+	})
+
+	T.Run("with nil webhook", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, webhookMatchesMessage(nil, dataChangesTopicName, &types.DataChangeMessage{}))
+	})
+}
+
+func Test_signWebhookRequest(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		examplePayload := []byte(`{"things":"stuff"}`)
+		now := time.Now()
+
+		req := httptest.NewRequest(http.MethodPost, exampleWebhook.URL, nil)
+		signWebhookRequest(req, exampleWebhook, examplePayload, now)
+
+		expected := types.SignWebhookPayload(exampleWebhook.SigningSecret, now.Unix(), examplePayload)
+		assert.Equal(t, expected, req.Header.Get(types.WebhookSignatureHeaderKey))
+		assert.Equal(t, strconv.FormatInt(now.Unix(), 10), req.Header.Get(types.WebhookSignatureTimestampHeaderKey))
+	})
+
+	T.Run("with previous secret in grace period", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		expiresOn := uint64(now.Add(time.Hour).Unix())
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.PreviousSigningSecret = "previous"
+		exampleWebhook.PreviousSigningSecretExpiresOn = &expiresOn
+		examplePayload := []byte(`{"things":"stuff"}`)
+
+		req := httptest.NewRequest(http.MethodPost, exampleWebhook.URL, nil)
+		signWebhookRequest(req, exampleWebhook, examplePayload, now)
+
+		expected := strings.Join([]string{
+			types.SignWebhookPayload(exampleWebhook.SigningSecret, now.Unix(), examplePayload),
+			types.SignWebhookPayload(exampleWebhook.PreviousSigningSecret, now.Unix(), examplePayload),
+		}, types.WebhookSignatureSeparator)
+		assert.Equal(t, expected, req.Header.Get(types.WebhookSignatureHeaderKey))
+	})
+
+	T.Run("with expired previous secret", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		expiresOn := uint64(now.Add(-time.Hour).Unix())
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.PreviousSigningSecret = "previous"
+		exampleWebhook.PreviousSigningSecretExpiresOn = &expiresOn
+		examplePayload := []byte(`{"things":"stuff"}`)
+
+		req := httptest.NewRequest(http.MethodPost, exampleWebhook.URL, nil)
+		signWebhookRequest(req, exampleWebhook, examplePayload, now)
+
+		expected := types.SignWebhookPayload(exampleWebhook.SigningSecret, now.Unix(), examplePayload)
+		assert.Equal(t, expected, req.Header.Get(types.WebhookSignatureHeaderKey))
+	})
+
+	T.Run("without signing secret", func(t *testing.T) {
+		t.Parallel()
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.SigningSecret = ""
+
+		req := httptest.NewRequest(http.MethodPost, exampleWebhook.URL, nil)
+		signWebhookRequest(req, exampleWebhook, []byte("{}"), time.Now())
+
+		assert.Empty(t, req.Header.Get(types.WebhookSignatureHeaderKey))
+		assert.Empty(t, req.Header.Get(types.WebhookSignatureTimestampHeaderKey))
+	})
+}
+
+func TestDataChangesWorker_backoffForAttempt(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		worker := buildTestDataChangesWorker(t, &http.Client{})
+		worker.webhookBackoff = time.Second
+		worker.webhookMaxBackoff = 5 * time.Second
+
+		assert.Equal(t, time.Second, worker.backoffForAttempt(0))
+		assert.Equal(t, 2*time.Second, worker.backoffForAttempt(1))
+		assert.Equal(t, 4*time.Second, worker.backoffForAttempt(2))
+		assert.Equal(t, 5*time.Second, worker.backoffForAttempt(3))
+		assert.Equal(t, 5*time.Second, worker.backoffForAttempt(10))
+	})
+}
+
+func TestDataChangesWorker_deliverWebhook(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, exampleWebhook.Method, req.Method)
+			res.WriteHeader(http.StatusAccepted)
+		}))
+		defer ts.Close()
+
+		exampleWebhook.URL = ts.URL
+		worker := buildTestDataChangesWorker(t, ts.Client())
+
+		ctx := context.Background()
+		assert.NoError(t, worker.deliverWebhook(ctx, exampleWebhook, &types.DataChangeMessage{Item: fakes.BuildFakeItem()}))
+	})
+
+	T.Run("with XML content type", func(t *testing.T) {
+		t.Parallel()
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.ContentType = "application/xml"
+		exampleItem := fakes.BuildFakeItem()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "application/xml", req.Header.Get("Content-Type"))
+
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+
+			var msg *types.DataChangeMessage
+			require.NoError(t, xml.Unmarshal(body, &msg))
+			assert.Equal(t, exampleItem.ID, msg.Item.ID)
+
+			res.WriteHeader(http.StatusOK)
+		}))
+		defer ts.Close()
+
+		exampleWebhook.URL = ts.URL
+		worker := buildTestDataChangesWorker(t, ts.Client())
+
+		ctx := context.Background()
+		assert.NoError(t, worker.deliverWebhook(ctx, exampleWebhook, &types.DataChangeMessage{Item: exampleItem}))
+	})
+
+	T.Run("retries server errors", func(t *testing.T) {
+		t.Parallel()
+
+		var hits int32
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(&hits, 1) < 3 {
+				res.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			res.WriteHeader(http.StatusOK)
+		}))
+		defer ts.Close()
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.URL = ts.URL
+		worker := buildTestDataChangesWorker(t, ts.Client())
+
+		ctx := context.Background()
+		assert.NoError(t, worker.deliverWebhook(ctx, exampleWebhook, &types.DataChangeMessage{}))
+		assert.Equal(t, int32(3), atomic.LoadInt32(&hits))
+	})
+
+	T.Run("gives up after max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		var hits int32
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&hits, 1)
+			res.WriteHeader(http.StatusBadGateway)
+		}))
+		defer ts.Close()
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.URL = ts.URL
+		worker := buildTestDataChangesWorker(t, ts.Client())
+
+		ctx := context.Background()
+		assert.Error(t, worker.deliverWebhook(ctx, exampleWebhook, &types.DataChangeMessage{}))
+		assert.Equal(t, int32(worker.webhookMaxAttempts), atomic.LoadInt32(&hits))
+	})
+
+	T.Run("does not retry client errors", func(t *testing.T) {
+		t.Parallel()
+
+		var hits int32
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&hits, 1)
+			res.WriteHeader(http.StatusBadRequest)
+		}))
+		defer ts.Close()
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.URL = ts.URL
+		worker := buildTestDataChangesWorker(t, ts.Client())
+
+		ctx := context.Background()
+		assert.Error(t, worker.deliverWebhook(ctx, exampleWebhook, &types.DataChangeMessage{}))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+	})
+
+	T.Run("with attempt timeout", func(t *testing.T) {
+		t.Parallel()
+
+		done := make(chan struct{})
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			select {
+			case <-done:
+			case <-req.Context().Done():
+			}
+		}))
+		defer ts.Close()
+		defer close(done)
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.URL = ts.URL
+		worker := buildTestDataChangesWorker(t, ts.Client())
+		worker.webhookAttemptTimeout = 10 * time.Millisecond
+		worker.webhookMaxAttempts = 1
+
+		ctx := context.Background()
+		assert.Error(t, worker.deliverWebhook(ctx, exampleWebhook, &types.DataChangeMessage{}))
+	})
+}
+
+func TestDataChangesWorker_attemptWebhookDelivery(T *testing.T) {
+	T.Parallel()
+
+	T.Run("records attempt", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ts.Close()
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.URL = ts.URL
+		examplePayload := []byte(`{"things":"stuff"}`)
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.WebhookDataManager.On(
+			"CreateWebhookDeliveryAttempt",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.WebhookDeliveryAttemptDatabaseCreationInput) bool {
+				return input.BelongsToWebhook == exampleWebhook.ID &&
+					input.RequestBody == string(examplePayload) &&
+					input.ResponseStatus == http.StatusServiceUnavailable &&
+					input.AttemptNumber == 2 &&
+					input.ErrorText != ""
+			}),
+		).Return(&types.WebhookDeliveryAttempt{}, nil)
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), ts.Client(), dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.Error(t, worker.attemptWebhookDelivery(ctx, exampleWebhook, examplePayload, "application/json", 2))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+
+	T.Run("with error recording attempt", func(t *testing.T) {
+		t.Parallel()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.WriteHeader(http.StatusOK)
+		}))
+		defer ts.Close()
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.URL = ts.URL
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.WebhookDataManager.On(
+			"CreateWebhookDeliveryAttempt",
+			testutils.ContextMatcher,
+			mock.IsType(&types.WebhookDeliveryAttemptDatabaseCreationInput{}),
+		).Return((*types.WebhookDeliveryAttempt)(nil), errors.New("blah"))
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), ts.Client(), dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.NoError(t, worker.attemptWebhookDelivery(ctx, exampleWebhook, []byte("{}"), "application/json", 1))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+}
+
+func TestDataChangesWorker_redeliverWebhook(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleAttempt := fakes.BuildFakeWebhookDeliveryAttempt()
+		exampleAttempt.BelongsToWebhook = exampleWebhook.ID
+		exampleAttempt.AttemptNumber = 3
+
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.Equal(t, exampleAttempt.RequestBody, string(body))
+
+			res.WriteHeader(http.StatusOK)
+		}))
+		defer ts.Close()
+
+		exampleWebhook.URL = ts.URL
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.WebhookDataManager.On(
+			"GetWebhook",
+			testutils.ContextMatcher,
+			exampleWebhook.ID,
+			exampleAccountID,
+		).Return(exampleWebhook, nil)
+		dbManager.WebhookDataManager.On(
+			"CreateWebhookDeliveryAttempt",
+			testutils.ContextMatcher,
+			mock.MatchedBy(func(input *types.WebhookDeliveryAttemptDatabaseCreationInput) bool {
+				return input.AttemptNumber == exampleAttempt.AttemptNumber+1
+			}),
+		).Return(&types.WebhookDeliveryAttempt{}, nil)
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), ts.Client(), dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.NoError(t, worker.redeliverWebhook(ctx, exampleAccountID, exampleAttempt))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+
+	T.Run("with nil attempt", func(t *testing.T) {
+		t.Parallel()
+
+		worker := buildTestDataChangesWorker(t, &http.Client{})
+
+		ctx := context.Background()
+		assert.Error(t, worker.redeliverWebhook(ctx, fakes.BuildFakeID(), nil))
+	})
+
+	T.Run("with error fetching webhook", func(t *testing.T) {
+		t.Parallel()
+
+		exampleAccountID := fakes.BuildFakeID()
+		exampleAttempt := fakes.BuildFakeWebhookDeliveryAttempt()
+
+		dbManager := database.BuildMockDatabase()
+		dbManager.WebhookDataManager.On(
+			"GetWebhook",
+			testutils.ContextMatcher,
+			exampleAttempt.BelongsToWebhook,
+			exampleAccountID,
+		).Return((*types.Webhook)(nil), errors.New("blah"))
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), &http.Client{}, dbManager, nil, nil, nil)
+
+		ctx := context.Background()
+		assert.Error(t, worker.redeliverWebhook(ctx, exampleAccountID, exampleAttempt))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+}
+
+func TestDataChangesWorker_DeliverWebhooks(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		var hits int32
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&hits, 1)
+			res.WriteHeader(http.StatusOK)
+		}))
+		defer ts.Close()
+
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleWebhook.URL = ts.URL
+
+		recorded := make(chan struct{})
+		dbManager := database.BuildMockDatabase()
+		dbManager.WebhookDataManager.On(
+			"CreateWebhookDeliveryAttempt",
+			testutils.ContextMatcher,
+			mock.IsType(&types.WebhookDeliveryAttemptDatabaseCreationInput{}),
+		).Return(&types.WebhookDeliveryAttempt{}, nil).Run(func(mock.Arguments) { close(recorded) })
+
+		worker := ProvideDataChangesWorker(logging.NewNoopLogger(), ts.Client(), dbManager, nil, nil, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			worker.DeliverWebhooks(ctx)
+			close(done)
+		}()
+
+		delivery := &webhookDelivery{
+			accountID: exampleWebhook.BelongsToAccount,
+			webhook:   exampleWebhook,
+			msg:       &types.DataChangeMessage{MessageType: types.CreatedMessageType, DataType: types.ItemDataType},
+		}
+		require.NoError(t, worker.enqueueWebhookDelivery(ctx, delivery))
+
+		<-recorded
+		cancel()
+		<-done
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+}
@@ -0,0 +1,114 @@
+package workers
+
+import (
+	"context"
+	"net/http"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/consumers"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
+)
+
+const (
+	// PreWritesTopicName is the topic the pre-writes worker consumes.
+	PreWritesTopicName = "pre_writes"
+	// PreUpdatesTopicName is the topic the pre-updates worker consumes.
+	PreUpdatesTopicName = "pre_updates"
+	// PreArchivesTopicName is the topic the pre-archives worker consumes.
+	PreArchivesTopicName = "pre_archives"
+	// DataChangesTopicName is the topic the data changes worker consumes, and the other workers publish to.
+	DataChangesTopicName = "data_changes"
+
+	// ConsumerGroup is the consumer group workers read durable topics as. The API server
+	// consumes data changes too, so workers need a group of their own to see every message.
+	ConsumerGroup = "workers"
+)
+
+// StartWorkers builds the pre-writes, pre-updates, pre-archives, and data changes workers
+// and starts consuming their topics in the background.
+func StartWorkers(
+	ctx context.Context,
+	logger logging.Logger,
+	client *http.Client,
+	dataManager database.DataManager,
+	consumerProvider consumers.ConsumerProvider,
+	publisherProvider publishers.PublisherProvider,
+	searchIndexLocation search.IndexPath,
+	searchIndexProvider search.IndexManagerProvider,
+	emailer email.Emailer,
+	emailRenderer *email.Renderer,
+) error {
+	// webhook deliveries and digests outlive this call, so they shouldn't hang off of its span.
+	backgroundCtx := ctx
+
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+
+	logger = logging.EnsureLogger(logger)
+
+	// data changes worker
+
+	dataChangesPublisher, err := publisherProvider.ProviderPublisher(DataChangesTopicName)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "providing data changes publisher")
+	}
+
+	dataChangesWorker := ProvideDataChangesWorker(logger, client, dataManager, dataChangesPublisher, emailer, emailRenderer)
+	dataChangesConsumer, err := consumerProvider.ProviderConsumer(ctx, DataChangesTopicName, dataChangesWorker.HandleMessage)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "providing data changes consumer")
+	}
+
+	go dataChangesConsumer.Consume(nil, nil)
+	go dataChangesWorker.DeliverWebhooks(backgroundCtx)
+	go dataChangesWorker.DigestNotifications(backgroundCtx)
+
+	// pre-writes worker
+
+	preWritesWorker, err := ProvidePreWritesWorker(ctx, logger, client, dataManager, dataChangesPublisher, searchIndexLocation, searchIndexProvider)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "providing pre-writes worker")
+	}
+
+	preWritesConsumer, err := consumerProvider.ProviderConsumer(ctx, PreWritesTopicName, preWritesWorker.HandleMessage)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "providing pre-writes consumer")
+	}
+
+	go preWritesConsumer.Consume(nil, nil)
+
+	// pre-updates worker
+
+	preUpdatesWorker, err := ProvidePreUpdatesWorker(ctx, logger, client, dataManager, dataChangesPublisher, searchIndexLocation, searchIndexProvider)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "providing pre-updates worker")
+	}
+
+	preUpdatesConsumer, err := consumerProvider.ProviderConsumer(ctx, PreUpdatesTopicName, preUpdatesWorker.HandleMessage)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "providing pre-updates consumer")
+	}
+
+	go preUpdatesConsumer.Consume(nil, nil)
+
+	// pre-archives worker
+
+	preArchivesWorker, err := ProvidePreArchivesWorker(ctx, logger, client, dataManager, dataChangesPublisher, searchIndexLocation, searchIndexProvider)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "providing pre-archives worker")
+	}
+
+	preArchivesConsumer, err := consumerProvider.ProviderConsumer(ctx, PreArchivesTopicName, preArchivesWorker.HandleMessage)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "providing pre-archives consumer")
+	}
+
+	go preArchivesConsumer.Consume(nil, nil)
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/database"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/email"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/consumers"
+	mockconsumers "gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/consumers/mock"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/messagequeue/publishers"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/search"
+)
+
+func TestStartWorkers(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		dbManager := &database.MockDatabase{}
+		broker := consumers.NewInMemoryBroker(0)
+		searchIndexProvider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
+			return nil, nil
+		}
+
+		err := StartWorkers(
+			ctx,
+			logger,
+			&http.Client{},
+			dbManager,
+			consumers.ProvideInMemoryConsumerProvider(logger, broker),
+			publishers.ProvideInMemoryPublisherProvider(logger, broker),
+			search.IndexPath(t.Name()),
+			searchIndexProvider,
+			email.NewInMemoryEmailer(),
+			nil,
+		)
+		assert.NoError(t, err)
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+
+	T.Run("with error providing consumer", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		dbManager := &database.MockDatabase{}
+		broker := consumers.NewInMemoryBroker(0)
+		searchIndexProvider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
+			return nil, nil
+		}
+
+		consumerProvider := &mockconsumers.ConsumerProvider{}
+		consumerProvider.On(
+			"ProviderConsumer",
+			mock.Anything,
+			DataChangesTopicName,
+			mock.Anything,
+		).Return((*mockconsumers.Consumer)(nil), errors.New("blah"))
+
+		err := StartWorkers(
+			ctx,
+			logger,
+			&http.Client{},
+			dbManager,
+			consumerProvider,
+			publishers.ProvideInMemoryPublisherProvider(logger, broker),
+			search.IndexPath(t.Name()),
+			searchIndexProvider,
+			email.NewInMemoryEmailer(),
+			nil,
+		)
+		assert.Error(t, err)
+
+		mock.AssertExpectationsForObjects(t, dbManager, consumerProvider)
+	})
+
+	T.Run("with error providing search index", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		logger := logging.NewNoopLogger()
+		dbManager := &database.MockDatabase{}
+		broker := consumers.NewInMemoryBroker(0)
+		searchIndexProvider := func(context.Context, logging.Logger, *http.Client, search.IndexPath, search.IndexName, ...string) (search.IndexManager, error) {
+			return nil, errors.New("blah")
+		}
+
+		err := StartWorkers(
+			ctx,
+			logger,
+			&http.Client{},
+			dbManager,
+			consumers.ProvideInMemoryConsumerProvider(logger, broker),
+			publishers.ProvideInMemoryPublisherProvider(logger, broker),
+			search.IndexPath(t.Name()),
+			searchIndexProvider,
+			email.NewInMemoryEmailer(),
+			nil,
+		)
+		assert.Error(t, err)
+
+		mock.AssertExpectationsForObjects(t, dbManager)
+	})
+}
@@ -0,0 +1,186 @@
+package httpclient
+
+import (
+	"context"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// InviteUserToAccount invites a user to join an account.
+func (c *Client) InviteUserToAccount(ctx context.Context, accountID string, input *types.AccountInvitationCreationRequestInput) (*types.AccountInvitation, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	logger := c.logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "validating input")
+	}
+
+	req, err := c.requestBuilder.BuildInviteUserToAccountRequest(ctx, accountID, input)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building invite user to account request")
+	}
+
+	var invitation *types.AccountInvitation
+	if err = c.fetchAndUnmarshal(ctx, req, &invitation); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "inviting user to account")
+	}
+
+	return invitation, nil
+}
+
+// GetPendingAccountInvitations gets a list of the invitations an account has outstanding.
+func (c *Client) GetPendingAccountInvitations(ctx context.Context, accountID string, filter *types.QueryFilter) (*types.AccountInvitationList, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := c.loggerWithFilter(filter).WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	req, err := c.requestBuilder.BuildGetPendingAccountInvitationsRequest(ctx, accountID, filter)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building account invitations list request")
+	}
+
+	var invitations *types.AccountInvitationList
+	if err = c.fetchAndUnmarshal(ctx, req, &invitations); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "retrieving account invitations")
+	}
+
+	return invitations, nil
+}
+
+// RevokeAccountInvitation revokes an invitation an account has outstanding.
+func (c *Client) RevokeAccountInvitation(ctx context.Context, accountID, accountInvitationID string) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" || accountInvitationID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	logger := c.logger.WithValue(keys.AccountIDKey, accountID).WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+
+	req, err := c.requestBuilder.BuildRevokeAccountInvitationRequest(ctx, accountID, accountInvitationID)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building revoke account invitation request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, nil); err != nil {
+		return observability.PrepareError(err, logger, span, "revoking account invitation")
+	}
+
+	return nil
+}
+
+// GetReceivedAccountInvitations gets a list of the invitations awaiting the requester's response.
+func (c *Client) GetReceivedAccountInvitations(ctx context.Context) (*types.AccountInvitationList, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	req, err := c.requestBuilder.BuildGetReceivedAccountInvitationsRequest(ctx)
+	if err != nil {
+		return nil, observability.PrepareError(err, c.logger, span, "building received account invitations request")
+	}
+
+	var invitations *types.AccountInvitationList
+	if err = c.fetchAndUnmarshal(ctx, req, &invitations); err != nil {
+		return nil, observability.PrepareError(err, c.logger, span, "retrieving received account invitations")
+	}
+
+	return invitations, nil
+}
+
+// ClaimAccountInvitation claims an invitation that was sent to an email address, using the token that was sent with it.
+func (c *Client) ClaimAccountInvitation(ctx context.Context, input *types.AccountInvitationClaimInput) (*types.AccountInvitation, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		return nil, observability.PrepareError(err, c.logger, span, "validating input")
+	}
+
+	req, err := c.requestBuilder.BuildClaimAccountInvitationRequest(ctx, input)
+	if err != nil {
+		return nil, observability.PrepareError(err, c.logger, span, "building claim account invitation request")
+	}
+
+	var invitation *types.AccountInvitation
+	if err = c.fetchAndUnmarshal(ctx, req, &invitation); err != nil {
+		return nil, observability.PrepareError(err, c.logger, span, "claiming account invitation")
+	}
+
+	return invitation, nil
+}
+
+// AcceptAccountInvitation accepts an invitation to join an account.
+func (c *Client) AcceptAccountInvitation(ctx context.Context, accountInvitationID string) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountInvitationID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	logger := c.logger.WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+
+	req, err := c.requestBuilder.BuildAcceptAccountInvitationRequest(ctx, accountInvitationID)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building accept account invitation request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, nil); err != nil {
+		return observability.PrepareError(err, logger, span, "accepting account invitation")
+	}
+
+	return nil
+}
+
+// RejectAccountInvitation rejects an invitation to join an account.
+func (c *Client) RejectAccountInvitation(ctx context.Context, accountInvitationID string) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountInvitationID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	logger := c.logger.WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+
+	req, err := c.requestBuilder.BuildRejectAccountInvitationRequest(ctx, accountInvitationID)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building reject account invitation request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, nil); err != nil {
+		return observability.PrepareError(err, logger, span, "rejecting account invitation")
+	}
+
+	return nil
+}
@@ -0,0 +1,354 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func TestAccountInvitations(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(accountInvitationsTestSuite))
+}
+
+type accountInvitationsTestSuite struct {
+	suite.Suite
+
+	ctx                          context.Context
+	exampleAccountInvitation     *types.AccountInvitation
+	exampleAccountInvitationList *types.AccountInvitationList
+}
+
+var _ suite.SetupTestSuite = (*accountInvitationsTestSuite)(nil)
+
+func (s *accountInvitationsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.exampleAccountInvitation = fakes.BuildFakeAccountInvitation()
+	s.exampleAccountInvitationList = fakes.BuildFakeAccountInvitationList()
+}
+
+func (s *accountInvitationsTestSuite) TestClient_InviteUserToAccount() {
+	const expectedPathFormat = "/api/v1/accounts/%s/invitations"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		exampleInput := fakes.BuildFakeAccountInvitationCreationRequestInput()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPathFormat, s.exampleAccountInvitation.DestinationAccount)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleAccountInvitation)
+
+		actual, err := c.InviteUserToAccount(s.ctx, s.exampleAccountInvitation.DestinationAccount, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleAccountInvitation, actual)
+	})
+
+	s.Run("with invalid account ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.InviteUserToAccount(s.ctx, "", fakes.BuildFakeAccountInvitationCreationRequestInput())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with nil input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.InviteUserToAccount(s.ctx, s.exampleAccountInvitation.DestinationAccount, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with invalid input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.InviteUserToAccount(s.ctx, s.exampleAccountInvitation.DestinationAccount, &types.AccountInvitationCreationRequestInput{})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.InviteUserToAccount(s.ctx, s.exampleAccountInvitation.DestinationAccount, fakes.BuildFakeAccountInvitationCreationRequestInput())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.InviteUserToAccount(s.ctx, s.exampleAccountInvitation.DestinationAccount, fakes.BuildFakeAccountInvitationCreationRequestInput())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *accountInvitationsTestSuite) TestClient_GetPendingAccountInvitations() {
+	const expectedPathFormat = "/api/v1/accounts/%s/invitations"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPathFormat, s.exampleAccountInvitation.DestinationAccount)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleAccountInvitationList)
+
+		actual, err := c.GetPendingAccountInvitations(s.ctx, s.exampleAccountInvitation.DestinationAccount, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleAccountInvitationList, actual)
+	})
+
+	s.Run("with invalid account ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.GetPendingAccountInvitations(s.ctx, "", nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.GetPendingAccountInvitations(s.ctx, s.exampleAccountInvitation.DestinationAccount, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.GetPendingAccountInvitations(s.ctx, s.exampleAccountInvitation.DestinationAccount, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *accountInvitationsTestSuite) TestClient_RevokeAccountInvitation() {
+	const expectedPathFormat = "/api/v1/accounts/%s/invitations/%s"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodDelete, "", expectedPathFormat, s.exampleAccountInvitation.DestinationAccount, s.exampleAccountInvitation.ID)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusNoContent)
+
+		err := c.RevokeAccountInvitation(s.ctx, s.exampleAccountInvitation.DestinationAccount, s.exampleAccountInvitation.ID)
+		assert.NoError(t, err)
+	})
+
+	s.Run("with invalid account invitation ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		err := c.RevokeAccountInvitation(s.ctx, s.exampleAccountInvitation.DestinationAccount, "")
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		err := c.RevokeAccountInvitation(s.ctx, s.exampleAccountInvitation.DestinationAccount, s.exampleAccountInvitation.ID)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		err := c.RevokeAccountInvitation(s.ctx, s.exampleAccountInvitation.DestinationAccount, s.exampleAccountInvitation.ID)
+		assert.Error(t, err)
+	})
+}
+
+func (s *accountInvitationsTestSuite) TestClient_GetReceivedAccountInvitations() {
+	const expectedPath = "/api/v1/account_invitations"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodGet, "", expectedPath)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleAccountInvitationList)
+
+		actual, err := c.GetReceivedAccountInvitations(s.ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleAccountInvitationList, actual)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.GetReceivedAccountInvitations(s.ctx)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.GetReceivedAccountInvitations(s.ctx)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *accountInvitationsTestSuite) TestClient_ClaimAccountInvitation() {
+	const expectedPath = "/api/v1/account_invitations/claim"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		exampleInput := &types.AccountInvitationClaimInput{Token: t.Name()}
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleAccountInvitation)
+
+		actual, err := c.ClaimAccountInvitation(s.ctx, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleAccountInvitation, actual)
+	})
+
+	s.Run("with nil input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.ClaimAccountInvitation(s.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with invalid input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.ClaimAccountInvitation(s.ctx, &types.AccountInvitationClaimInput{})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.ClaimAccountInvitation(s.ctx, &types.AccountInvitationClaimInput{Token: t.Name()})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.ClaimAccountInvitation(s.ctx, &types.AccountInvitationClaimInput{Token: t.Name()})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *accountInvitationsTestSuite) TestClient_AcceptAccountInvitation() {
+	const expectedPathFormat = "/api/v1/account_invitations/%s/accept"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodPost, "", expectedPathFormat, s.exampleAccountInvitation.ID)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusAccepted)
+
+		assert.NoError(t, c.AcceptAccountInvitation(s.ctx, s.exampleAccountInvitation.ID))
+	})
+
+	s.Run("with invalid account invitation ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		assert.Error(t, c.AcceptAccountInvitation(s.ctx, ""))
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		assert.Error(t, c.AcceptAccountInvitation(s.ctx, s.exampleAccountInvitation.ID))
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		assert.Error(t, c.AcceptAccountInvitation(s.ctx, s.exampleAccountInvitation.ID))
+	})
+}
+
+func (s *accountInvitationsTestSuite) TestClient_RejectAccountInvitation() {
+	const expectedPathFormat = "/api/v1/account_invitations/%s/reject"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodPost, "", expectedPathFormat, s.exampleAccountInvitation.ID)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusNoContent)
+
+		assert.NoError(t, c.RejectAccountInvitation(s.ctx, s.exampleAccountInvitation.ID))
+	})
+
+	s.Run("with invalid account invitation ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		assert.Error(t, c.RejectAccountInvitation(s.ctx, ""))
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		assert.Error(t, c.RejectAccountInvitation(s.ctx, s.exampleAccountInvitation.ID))
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		assert.Error(t, c.RejectAccountInvitation(s.ctx, s.exampleAccountInvitation.ID))
+	})
+}
@@ -0,0 +1,145 @@
+package httpclient
+
+import (
+	"context"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// GetAccountRole retrieves an account role.
+func (c *Client) GetAccountRole(ctx context.Context, accountID, accountRoleID string) (*types.AccountRole, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" || accountRoleID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := c.logger.WithValue(keys.AccountIDKey, accountID).WithValue(keys.AccountRoleIDKey, accountRoleID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachAccountRoleIDToSpan(span, accountRoleID)
+
+	req, err := c.requestBuilder.BuildGetAccountRoleRequest(ctx, accountID, accountRoleID)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building get account role request")
+	}
+
+	var accountRole *types.AccountRole
+	if err = c.fetchAndUnmarshal(ctx, req, &accountRole); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "retrieving account role")
+	}
+
+	return accountRole, nil
+}
+
+// GetAccountRoles gets a list of an account's roles.
+func (c *Client) GetAccountRoles(ctx context.Context, accountID string, filter *types.QueryFilter) (*types.AccountRoleList, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := c.loggerWithFilter(filter).WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	req, err := c.requestBuilder.BuildGetAccountRolesRequest(ctx, accountID, filter)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building account roles list request")
+	}
+
+	var accountRoles *types.AccountRoleList
+	if err = c.fetchAndUnmarshal(ctx, req, &accountRoles); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "retrieving account roles")
+	}
+
+	return accountRoles, nil
+}
+
+// CreateAccountRole creates an account role.
+func (c *Client) CreateAccountRole(ctx context.Context, accountID string, input *types.AccountRoleCreationInput) (*types.AccountRole, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	logger := c.logger.WithValue(keys.AccountIDKey, accountID).WithValue(keys.NameKey, input.Name)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "validating input")
+	}
+
+	req, err := c.requestBuilder.BuildCreateAccountRoleRequest(ctx, accountID, input)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building create account role request")
+	}
+
+	var accountRole *types.AccountRole
+	if err = c.fetchAndUnmarshal(ctx, req, &accountRole); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "creating account role")
+	}
+
+	return accountRole, nil
+}
+
+// UpdateAccountRole updates an account role.
+func (c *Client) UpdateAccountRole(ctx context.Context, accountRole *types.AccountRole) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountRole == nil {
+		return ErrNilInputProvided
+	}
+
+	logger := c.logger.WithValue(keys.AccountIDKey, accountRole.BelongsToAccount).WithValue(keys.AccountRoleIDKey, accountRole.ID)
+	tracing.AttachAccountIDToSpan(span, accountRole.BelongsToAccount)
+	tracing.AttachAccountRoleIDToSpan(span, accountRole.ID)
+
+	req, err := c.requestBuilder.BuildUpdateAccountRoleRequest(ctx, accountRole)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building update account role request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, &accountRole); err != nil {
+		return observability.PrepareError(err, logger, span, "updating account role %s", accountRole.ID)
+	}
+
+	return nil
+}
+
+// ArchiveAccountRole archives an account role.
+func (c *Client) ArchiveAccountRole(ctx context.Context, accountID, accountRoleID string) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" || accountRoleID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	logger := c.logger.WithValue(keys.AccountIDKey, accountID).WithValue(keys.AccountRoleIDKey, accountRoleID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachAccountRoleIDToSpan(span, accountRoleID)
+
+	req, err := c.requestBuilder.BuildArchiveAccountRoleRequest(ctx, accountID, accountRoleID)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building archive account role request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, nil); err != nil {
+		return observability.PrepareError(err, logger, span, "archiving account role %s", accountRoleID)
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func TestAccountRoles(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(accountRolesTestSuite))
+}
+
+type accountRolesTestSuite struct {
+	suite.Suite
+
+	ctx                    context.Context
+	exampleAccountRole     *types.AccountRole
+	exampleAccountRoleList *types.AccountRoleList
+}
+
+var _ suite.SetupTestSuite = (*accountRolesTestSuite)(nil)
+
+func (s *accountRolesTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.exampleAccountRole = fakes.BuildFakeAccountRole()
+	s.exampleAccountRoleList = fakes.BuildFakeAccountRoleList()
+}
+
+func (s *accountRolesTestSuite) TestClient_GetAccountRole() {
+	const expectedPathFormat = "/api/v1/accounts/%s/roles/%s"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodGet, "", expectedPathFormat, s.exampleAccountRole.BelongsToAccount, s.exampleAccountRole.ID)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleAccountRole)
+
+		actual, err := c.GetAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, s.exampleAccountRole.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleAccountRole, actual)
+	})
+
+	s.Run("with invalid account role ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.GetAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.GetAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, s.exampleAccountRole.ID)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.GetAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, s.exampleAccountRole.ID)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *accountRolesTestSuite) TestClient_GetAccountRoles() {
+	const expectedPathFormat = "/api/v1/accounts/%s/roles"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPathFormat, s.exampleAccountRole.BelongsToAccount)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleAccountRoleList)
+
+		actual, err := c.GetAccountRoles(s.ctx, s.exampleAccountRole.BelongsToAccount, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleAccountRoleList, actual)
+	})
+
+	s.Run("with invalid account ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.GetAccountRoles(s.ctx, "", nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.GetAccountRoles(s.ctx, s.exampleAccountRole.BelongsToAccount, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.GetAccountRoles(s.ctx, s.exampleAccountRole.BelongsToAccount, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *accountRolesTestSuite) TestClient_CreateAccountRole() {
+	const expectedPathFormat = "/api/v1/accounts/%s/roles"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		exampleInput := fakes.BuildFakeAccountRoleCreationInputFromAccountRole(s.exampleAccountRole)
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPathFormat, s.exampleAccountRole.BelongsToAccount)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleAccountRole)
+
+		actual, err := c.CreateAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleAccountRole, actual)
+	})
+
+	s.Run("with invalid account ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.CreateAccountRole(s.ctx, "", fakes.BuildFakeAccountRoleCreationInput())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with nil input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.CreateAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with invalid input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.CreateAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, &types.AccountRoleCreationInput{})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.CreateAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, fakes.BuildFakeAccountRoleCreationInput())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.CreateAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, fakes.BuildFakeAccountRoleCreationInput())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *accountRolesTestSuite) TestClient_UpdateAccountRole() {
+	const expectedPathFormat = "/api/v1/accounts/%s/roles/%s"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(false, http.MethodPut, "", expectedPathFormat, s.exampleAccountRole.BelongsToAccount, s.exampleAccountRole.ID)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleAccountRole)
+
+		err := c.UpdateAccountRole(s.ctx, s.exampleAccountRole)
+		assert.NoError(t, err)
+	})
+
+	s.Run("with nil input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		err := c.UpdateAccountRole(s.ctx, nil)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		err := c.UpdateAccountRole(s.ctx, s.exampleAccountRole)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		err := c.UpdateAccountRole(s.ctx, s.exampleAccountRole)
+		assert.Error(t, err)
+	})
+}
+
+func (s *accountRolesTestSuite) TestClient_ArchiveAccountRole() {
+	const expectedPathFormat = "/api/v1/accounts/%s/roles/%s"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodDelete, "", expectedPathFormat, s.exampleAccountRole.BelongsToAccount, s.exampleAccountRole.ID)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusNoContent)
+
+		err := c.ArchiveAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, s.exampleAccountRole.ID)
+		assert.NoError(t, err)
+	})
+
+	s.Run("with invalid account role ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		err := c.ArchiveAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, "")
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		err := c.ArchiveAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, s.exampleAccountRole.ID)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		err := c.ArchiveAccountRole(s.ctx, s.exampleAccountRole.BelongsToAccount, s.exampleAccountRole.ID)
+		assert.Error(t, err)
+	})
+}
@@ -160,35 +160,6 @@ func (c *Client) ArchiveAccount(ctx context.Context, accountID string) error {
 	return nil
 }
 
-// AddUserToAccount adds a user to an account.
-func (c *Client) AddUserToAccount(ctx context.Context, input *types.AddUserToAccountInput) error {
-	ctx, span := c.tracer.StartSpan(ctx)
-	defer span.End()
-
-	if input == nil {
-		return ErrNilInputProvided
-	}
-
-	logger := c.logger.WithValue(keys.AccountIDKey, input.AccountID).WithValue(keys.UserIDKey, input.UserID)
-	tracing.AttachAccountIDToSpan(span, input.AccountID)
-	tracing.AttachUserIDToSpan(span, input.UserID)
-
-	if err := input.ValidateWithContext(ctx); err != nil {
-		return observability.PrepareError(err, logger, span, "validating input")
-	}
-
-	req, err := c.requestBuilder.BuildAddUserRequest(ctx, input)
-	if err != nil {
-		return observability.PrepareError(err, logger, span, "building add user to account request")
-	}
-
-	if err = c.fetchAndUnmarshal(ctx, req, nil); err != nil {
-		return observability.PrepareError(err, logger, span, "adding user to account")
-	}
-
-	return nil
-}
-
 // MarkAsDefault marks a given account as the default for a given user.
 func (c *Client) MarkAsDefault(ctx context.Context, accountID string) error {
 	ctx, span := c.tracer.StartSpan(ctx)
@@ -307,54 +307,6 @@ func (s *accountsTestSuite) TestClient_ArchiveAccount() {
 	})
 }
 
-func (s *accountsTestSuite) TestClient_AddUserToAccount() {
-	const expectedPathFormat = "/api/v1/accounts/%s/member"
-
-	s.Run("standard", func() {
-		t := s.T()
-
-		exampleInput := fakes.BuildFakeAddUserToAccountInput()
-		spec := newRequestSpec(false, http.MethodPost, "", expectedPathFormat, exampleInput.AccountID)
-		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusOK)
-
-		assert.NoError(t, c.AddUserToAccount(s.ctx, exampleInput))
-	})
-
-	s.Run("with nil input", func() {
-		t := s.T()
-
-		c, _ := buildSimpleTestClient(t)
-
-		assert.Error(t, c.AddUserToAccount(s.ctx, nil))
-	})
-
-	s.Run("with invalid input", func() {
-		t := s.T()
-
-		c, _ := buildSimpleTestClient(t)
-
-		assert.Error(t, c.AddUserToAccount(s.ctx, &types.AddUserToAccountInput{}))
-	})
-
-	s.Run("with error building request", func() {
-		t := s.T()
-
-		c := buildTestClientWithInvalidURL(t)
-		exampleInput := fakes.BuildFakeAddUserToAccountInput()
-
-		assert.Error(t, c.AddUserToAccount(s.ctx, exampleInput))
-	})
-
-	s.Run("with error executing request", func() {
-		t := s.T()
-
-		exampleInput := fakes.BuildFakeAddUserToAccountInput()
-		c, _ := buildTestClientThatWaitsTooLong(t)
-
-		assert.Error(t, c.AddUserToAccount(s.ctx, exampleInput))
-	})
-}
-
 func (s *accountsTestSuite) TestClient_MarkAsDefault() {
 	const expectedPathFormat = "/api/v1/accounts/%s/default"
 
@@ -43,3 +43,47 @@ func (c *Client) UpdateUserReputation(ctx context.Context, input *types.UserRepu
 
 	return nil
 }
+
+// ReindexSearch rebuilds the search index from the database.
+func (c *Client) ReindexSearch(ctx context.Context) (*types.SearchReindexReport, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := c.logger
+
+	req, err := c.requestBuilder.BuildSearchReindexRequest(ctx)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building search reindex request")
+	}
+
+	var report *types.SearchReindexReport
+	if err = c.fetchAndUnmarshal(ctx, req, &report); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "reindexing search")
+	}
+
+	return report, nil
+}
+
+// RevokeAllUserSessions revokes every session belonging to a user.
+func (c *Client) RevokeAllUserSessions(ctx context.Context, userID string) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	logger := c.logger.WithValue(keys.UserIDKey, userID)
+	tracing.AttachUserIDToSpan(span, userID)
+
+	req, err := c.requestBuilder.BuildRevokeAllUserSessionsRequest(ctx, userID)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building revoke all user sessions request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, nil); err != nil {
+		return observability.PrepareError(err, logger, span, "revoking all user sessions")
+	}
+
+	return nil
+}
@@ -104,3 +104,86 @@ func (s *adminTestSuite) TestClient_UpdateUserReputation() {
 		assert.Error(t, c.UpdateUserReputation(s.ctx, exampleInput))
 	})
 }
+
+func (s *adminTestSuite) TestClient_ReindexSearch() {
+	const expectedPath = "/api/v1/admin/search/reindex"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		exampleReport := &types.SearchReindexReport{
+			IndexName:        "items",
+			MissingFromIndex: []string{"missing"},
+			OrphanedInIndex:  []string{"orphaned"},
+			IndexedCount:     1,
+			Compared:         true,
+			Swapped:          true,
+		}
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+		c, _ := buildTestClientWithJSONResponse(t, spec, exampleReport)
+
+		actual, err := c.ReindexSearch(s.ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleReport, actual)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.ReindexSearch(s.ctx)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	s.Run("with bad response from server", func() {
+		t := s.T()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+		c := buildTestClientWithInvalidResponse(t, spec)
+
+		actual, err := c.ReindexSearch(s.ctx)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func (s *adminTestSuite) TestClient_RevokeAllUserSessions() {
+	const expectedPathFormat = "/api/v1/admin/users/%s/sessions"
+
+	exampleUserID := fakes.BuildFakeID()
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodDelete, "", expectedPathFormat, exampleUserID)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusNoContent)
+
+		assert.NoError(t, c.RevokeAllUserSessions(s.ctx, exampleUserID))
+	})
+
+	s.Run("with invalid user ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		assert.Error(t, c.RevokeAllUserSessions(s.ctx, ""))
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		assert.Error(t, c.RevokeAllUserSessions(s.ctx, exampleUserID))
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		assert.Error(t, c.RevokeAllUserSessions(s.ctx, exampleUserID))
+	})
+}
@@ -0,0 +1,55 @@
+package httpclient
+
+import (
+	"context"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// GetAuditLogEntriesForAccount retrieves a list of an account's audit log entries.
+func (c *Client) GetAuditLogEntriesForAccount(ctx context.Context, accountID string, filter *types.AuditLogEntryQueryFilter) (*types.AuditLogEntryList, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := filter.AttachToLogger(c.logger).WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	req, err := c.requestBuilder.BuildGetAuditLogEntriesForAccountRequest(ctx, accountID, filter)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building account audit log request")
+	}
+
+	var entries *types.AuditLogEntryList
+	if err = c.fetchAndUnmarshal(ctx, req, &entries); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "retrieving account audit log entries")
+	}
+
+	return entries, nil
+}
+
+// GetAuditLogEntries retrieves a list of audit log entries for every account.
+func (c *Client) GetAuditLogEntries(ctx context.Context, filter *types.AuditLogEntryQueryFilter) (*types.AuditLogEntryList, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := filter.AttachToLogger(c.logger)
+
+	req, err := c.requestBuilder.BuildGetAuditLogEntriesRequest(ctx, filter)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building audit log request")
+	}
+
+	var entries *types.AuditLogEntryList
+	if err = c.fetchAndUnmarshal(ctx, req, &entries); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "retrieving audit log entries")
+	}
+
+	return entries, nil
+}
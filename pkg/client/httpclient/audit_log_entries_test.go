@@ -0,0 +1,115 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func TestAuditLogEntries(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(auditLogEntriesTestSuite))
+}
+
+type auditLogEntriesTestSuite struct {
+	suite.Suite
+
+	ctx                      context.Context
+	exampleAccountID         string
+	exampleAuditLogEntryList *types.AuditLogEntryList
+}
+
+var _ suite.SetupTestSuite = (*auditLogEntriesTestSuite)(nil)
+
+func (s *auditLogEntriesTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.exampleAccountID = fakes.BuildFakeID()
+	s.exampleAuditLogEntryList = fakes.BuildFakeAuditLogEntryList()
+}
+
+func (s *auditLogEntriesTestSuite) TestClient_GetAuditLogEntriesForAccount() {
+	const expectedPathFormat = "/api/v1/accounts/%s/audit_log"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPathFormat, s.exampleAccountID)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleAuditLogEntryList)
+
+		actual, err := c.GetAuditLogEntriesForAccount(s.ctx, s.exampleAccountID, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleAuditLogEntryList, actual)
+	})
+
+	s.Run("with invalid account ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.GetAuditLogEntriesForAccount(s.ctx, "", nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.GetAuditLogEntriesForAccount(s.ctx, s.exampleAccountID, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.GetAuditLogEntriesForAccount(s.ctx, s.exampleAccountID, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *auditLogEntriesTestSuite) TestClient_GetAuditLogEntries() {
+	const expectedPath = "/api/v1/admin/audit_log"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPath)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleAuditLogEntryList)
+
+		actual, err := c.GetAuditLogEntries(s.ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleAuditLogEntryList, actual)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.GetAuditLogEntries(s.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.GetAuditLogEntries(s.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
@@ -154,38 +154,137 @@ func (c *Client) CycleTwoFactorSecret(ctx context.Context, cookie *http.Cookie,
 	return output, nil
 }
 
-// VerifyTOTPSecret verifies a 2FA secret.
-func (c *Client) VerifyTOTPSecret(ctx context.Context, userID, token string) error {
+// VerifyTOTPSecret verifies a 2FA secret, and returns the user's TOTP recovery codes.
+func (c *Client) VerifyTOTPSecret(ctx context.Context, userID, token string) (*types.TOTPRecoveryCodesResponse, error) {
 	ctx, span := c.tracer.StartSpan(ctx)
 	defer span.End()
 
 	if userID == "" {
-		return ErrInvalidIDProvided
+		return nil, ErrInvalidIDProvided
 	}
 
 	logger := c.logger.WithValue(keys.UserIDKey, userID)
 
 	if _, err := strconv.ParseUint(token, 10, 64); token == "" || err != nil {
-		return observability.PrepareError(err, logger, span, "invalid token provided: %q", token)
+		return nil, observability.PrepareError(err, logger, span, "invalid token provided: %q", token)
 	}
 
 	req, err := c.requestBuilder.BuildVerifyTOTPSecretRequest(ctx, userID, token)
 	if err != nil {
-		return observability.PrepareError(err, logger, span, "building verify two factor secret request")
+		return nil, observability.PrepareError(err, logger, span, "building verify two factor secret request")
 	}
 
 	res, err := c.fetchResponseToRequest(ctx, c.unauthenticatedClient, req)
 	if err != nil {
-		return observability.PrepareError(err, logger, span, "verifying two factor secret")
+		return nil, observability.PrepareError(err, logger, span, "verifying two factor secret")
+	}
+
+	defer c.closeResponseBody(ctx, res)
+
+	if res.StatusCode == http.StatusBadRequest {
+		return nil, ErrInvalidTOTPToken
+	} else if res.StatusCode != http.StatusAccepted {
+		return nil, observability.PrepareError(errInvalidResponseCode, logger, span, "erroneous response code when validating TOTP secret: %d", res.StatusCode)
+	}
+
+	var output *types.TOTPRecoveryCodesResponse
+	if err = c.unmarshalBody(ctx, res, &output); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "loading TOTP recovery codes")
+	}
+
+	return output, nil
+}
+
+// RequestPasswordReset asks the service to send a user a password reset token.
+func (c *Client) RequestPasswordReset(ctx context.Context, input *types.PasswordResetTokenCreationRequestInput) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return ErrNilInputProvided
+	}
+
+	logger := c.logger.WithValue(keys.UsernameKey, input.Username)
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		return observability.PrepareError(err, logger, span, "validating input")
+	}
+
+	req, err := c.requestBuilder.BuildRequestPasswordResetRequest(ctx, input)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building password reset request")
+	}
+
+	res, err := c.fetchResponseToRequest(ctx, c.unauthenticatedClient, req)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "requesting password reset")
+	}
+
+	c.closeResponseBody(ctx, res)
+
+	if res.StatusCode != http.StatusAccepted {
+		return observability.PrepareError(errInvalidResponseCode, logger, span, "invalid response code: %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// RedeemPasswordResetToken sets a user's password with a password reset token.
+func (c *Client) RedeemPasswordResetToken(ctx context.Context, input *types.PasswordResetTokenRedemptionRequestInput) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return ErrNilInputProvided
+	}
+
+	// validating here requires settings knowledge, so we do not do it.
+
+	logger := c.logger
+
+	req, err := c.requestBuilder.BuildRedeemPasswordResetTokenRequest(ctx, input)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building password reset redemption request")
+	}
+
+	res, err := c.fetchResponseToRequest(ctx, c.unauthenticatedClient, req)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "redeeming password reset token")
 	}
 
 	c.closeResponseBody(ctx, res)
 
 	if res.StatusCode == http.StatusBadRequest {
-		return ErrInvalidTOTPToken
+		return ErrInvalidPasswordResetToken
 	} else if res.StatusCode != http.StatusAccepted {
-		return observability.PrepareError(errInvalidResponseCode, logger, span, "erroneous response code when validating TOTP secret: %d", res.StatusCode)
+		return observability.PrepareError(errInvalidResponseCode, logger, span, "invalid response code: %d", res.StatusCode)
 	}
 
 	return nil
 }
+
+// RecoverTOTPSecret trades a TOTP recovery code for a new, unverified 2FA secret.
+func (c *Client) RecoverTOTPSecret(ctx context.Context, input *types.TOTPRecoveryInput) (*types.TOTPRecoveryResponse, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	// validating here requires settings knowledge, so we do not do it.
+
+	logger := c.logger.WithValue(keys.UsernameKey, input.Username)
+
+	req, err := c.requestBuilder.BuildRecoverTOTPSecretRequest(ctx, input)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building TOTP secret recovery request")
+	}
+
+	var output *types.TOTPRecoveryResponse
+	if err = c.fetchAndUnmarshalWithoutAuthentication(ctx, req, &output); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "recovering TOTP secret")
+	}
+
+	return output, nil
+}
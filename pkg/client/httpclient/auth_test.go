@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -338,10 +339,21 @@ func (s *authTestSuite) TestClient_VerifyTOTPSecret() {
 
 		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
 		exampleInput := fakes.BuildFakeTOTPSecretVerificationInputForUser(s.exampleUser)
-		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusAccepted)
+		expected := fakes.BuildFakeTOTPRecoveryCodesResponse()
+
+		ts := httptest.NewTLSServer(http.HandlerFunc(
+			func(res http.ResponseWriter, req *http.Request) {
+				assertRequestQuality(t, req, spec)
 
-		err := c.VerifyTOTPSecret(s.ctx, s.exampleUser.ID, exampleInput.TOTPToken)
+				res.WriteHeader(http.StatusAccepted)
+				require.NoError(t, json.NewEncoder(res).Encode(expected))
+			},
+		))
+		c := buildTestClient(t, ts)
+
+		actual, err := c.VerifyTOTPSecret(s.ctx, s.exampleUser.ID, exampleInput.TOTPToken)
 		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
 	})
 
 	s.Run("with invalid user ID", func() {
@@ -350,8 +362,9 @@ func (s *authTestSuite) TestClient_VerifyTOTPSecret() {
 		exampleInput := fakes.BuildFakeTOTPSecretVerificationInputForUser(s.exampleUser)
 		c, _ := buildSimpleTestClient(t)
 
-		err := c.VerifyTOTPSecret(s.ctx, "", exampleInput.TOTPToken)
+		actual, err := c.VerifyTOTPSecret(s.ctx, "", exampleInput.TOTPToken)
 		assert.Error(t, err)
+		assert.Nil(t, actual)
 	})
 
 	s.Run("with invalid token", func() {
@@ -359,8 +372,9 @@ func (s *authTestSuite) TestClient_VerifyTOTPSecret() {
 
 		c, _ := buildSimpleTestClient(t)
 
-		err := c.VerifyTOTPSecret(s.ctx, s.exampleUser.ID, " doesn't parse lol ")
+		actual, err := c.VerifyTOTPSecret(s.ctx, s.exampleUser.ID, " doesn't parse lol ")
 		assert.Error(t, err)
+		assert.Nil(t, actual)
 	})
 
 	s.Run("with error building request", func() {
@@ -370,8 +384,9 @@ func (s *authTestSuite) TestClient_VerifyTOTPSecret() {
 
 		c := buildTestClientWithInvalidURL(t)
 
-		err := c.VerifyTOTPSecret(s.ctx, s.exampleUser.ID, exampleInput.TOTPToken)
+		actual, err := c.VerifyTOTPSecret(s.ctx, s.exampleUser.ID, exampleInput.TOTPToken)
 		assert.Error(t, err)
+		assert.Nil(t, actual)
 	})
 
 	s.Run("with bad request response", func() {
@@ -381,8 +396,9 @@ func (s *authTestSuite) TestClient_VerifyTOTPSecret() {
 		exampleInput := fakes.BuildFakeTOTPSecretVerificationInputForUser(s.exampleUser)
 		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusBadRequest)
 
-		err := c.VerifyTOTPSecret(s.ctx, s.exampleUser.ID, exampleInput.TOTPToken)
+		actual, err := c.VerifyTOTPSecret(s.ctx, s.exampleUser.ID, exampleInput.TOTPToken)
 		assert.Error(t, err)
+		assert.Nil(t, actual)
 		assert.Equal(t, ErrInvalidTOTPToken, err)
 	})
 
@@ -393,8 +409,9 @@ func (s *authTestSuite) TestClient_VerifyTOTPSecret() {
 		exampleInput := fakes.BuildFakeTOTPSecretVerificationInputForUser(s.exampleUser)
 		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusInternalServerError)
 
-		err := c.VerifyTOTPSecret(s.ctx, s.exampleUser.ID, exampleInput.TOTPToken)
+		actual, err := c.VerifyTOTPSecret(s.ctx, s.exampleUser.ID, exampleInput.TOTPToken)
 		assert.Error(t, err)
+		assert.Nil(t, actual)
 	})
 
 	s.Run("with timeout", func() {
@@ -404,7 +421,194 @@ func (s *authTestSuite) TestClient_VerifyTOTPSecret() {
 		c.unauthenticatedClient.Timeout = time.Millisecond
 		exampleInput := fakes.BuildFakeTOTPSecretVerificationInputForUser(s.exampleUser)
 
-		err := c.VerifyTOTPSecret(s.ctx, s.exampleUser.ID, exampleInput.TOTPToken)
+		actual, err := c.VerifyTOTPSecret(s.ctx, s.exampleUser.ID, exampleInput.TOTPToken)
 		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func (s *authTestSuite) TestClient_RequestPasswordReset() {
+	const expectedPath = "/users/password/reset"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+		exampleInput := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusAccepted)
+
+		assert.NoError(t, c.RequestPasswordReset(s.ctx, exampleInput))
+	})
+
+	s.Run("with nil input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		assert.Error(t, c.RequestPasswordReset(s.ctx, nil))
+	})
+
+	s.Run("with invalid input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		assert.Error(t, c.RequestPasswordReset(s.ctx, &types.PasswordResetTokenCreationRequestInput{}))
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		exampleInput := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+		c := buildTestClientWithInvalidURL(t)
+
+		assert.Error(t, c.RequestPasswordReset(s.ctx, exampleInput))
+	})
+
+	s.Run("with invalid status code response", func() {
+		t := s.T()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+		exampleInput := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusInternalServerError)
+
+		assert.Error(t, c.RequestPasswordReset(s.ctx, exampleInput))
+	})
+
+	s.Run("with timeout", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+		c.unauthenticatedClient.Timeout = time.Millisecond
+		exampleInput := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+
+		assert.Error(t, c.RequestPasswordReset(s.ctx, exampleInput))
+	})
+}
+
+func (s *authTestSuite) TestClient_RedeemPasswordResetToken() {
+	const expectedPath = "/users/password/reset/redeem"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusAccepted)
+
+		assert.NoError(t, c.RedeemPasswordResetToken(s.ctx, exampleInput))
+	})
+
+	s.Run("with nil input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		assert.Error(t, c.RedeemPasswordResetToken(s.ctx, nil))
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		c := buildTestClientWithInvalidURL(t)
+
+		assert.Error(t, c.RedeemPasswordResetToken(s.ctx, exampleInput))
+	})
+
+	s.Run("with bad request response", func() {
+		t := s.T()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusBadRequest)
+
+		err := c.RedeemPasswordResetToken(s.ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Equal(t, ErrInvalidPasswordResetToken, err)
+	})
+
+	s.Run("with otherwise invalid status code response", func() {
+		t := s.T()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusInternalServerError)
+
+		assert.Error(t, c.RedeemPasswordResetToken(s.ctx, exampleInput))
+	})
+
+	s.Run("with timeout", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+		c.unauthenticatedClient.Timeout = time.Millisecond
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+
+		assert.Error(t, c.RedeemPasswordResetToken(s.ctx, exampleInput))
+	})
+}
+
+func (s *authTestSuite) TestClient_RecoverTOTPSecret() {
+	const expectedPath = "/users/totp_secret/recover"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+		exampleInput := fakes.BuildFakeTOTPRecoveryInput()
+		expected := &types.TOTPRecoveryResponse{
+			UserID:          s.exampleUser.ID,
+			TwoFactorSecret: s.exampleUser.TwoFactorSecret,
+		}
+		c, _ := buildTestClientWithJSONResponse(t, spec, expected)
+
+		actual, err := c.RecoverTOTPSecret(s.ctx, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	s.Run("with nil input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.RecoverTOTPSecret(s.ctx, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		exampleInput := fakes.BuildFakeTOTPRecoveryInput()
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.RecoverTOTPSecret(s.ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	s.Run("with unauthorized response", func() {
+		t := s.T()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+		exampleInput := fakes.BuildFakeTOTPRecoveryInput()
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusUnauthorized)
+
+		actual, err := c.RecoverTOTPSecret(s.ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	s.Run("with timeout", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+		exampleInput := fakes.BuildFakeTOTPRecoveryInput()
+
+		actual, err := c.RecoverTOTPSecret(s.ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
 	})
 }
@@ -20,12 +20,21 @@
 	// ErrUnauthorized is a handy error to return when we receive a 401 response.
 	ErrUnauthorized = errors.New("401: not authorized")
 
+	// ErrPreconditionFailed is a handy error to return when we receive a 412 response.
+	ErrPreconditionFailed = errors.New("412: precondition failed")
+
+	// ErrPreconditionRequired is a handy error to return when we receive a 428 response.
+	ErrPreconditionRequired = errors.New("428: precondition required")
+
 	// ErrNoURLProvided is a handy error to return when we expect a *url.URL and don't receive one.
 	ErrNoURLProvided = errors.New("no URL provided")
 
 	// ErrInvalidTOTPToken is an error for when our TOTP validation request goes awry.
 	ErrInvalidTOTPToken = errors.New("invalid TOTP token")
 
+	// ErrInvalidPasswordResetToken is an error for when a password reset token is rejected.
+	ErrInvalidPasswordResetToken = errors.New("invalid password reset token")
+
 	// ErrNilInputProvided indicates nil input was provided in an unacceptable context.
 	ErrNilInputProvided = errors.New("nil input provided")
 
@@ -58,4 +67,13 @@
 
 	// ErrArgumentIsNotPointer indicates we received a non-pointer interface argument.
 	ErrArgumentIsNotPointer = errors.New("value is not a pointer")
+
+	// ErrMissingWebhookSignature indicates a webhook request was not signed.
+	ErrMissingWebhookSignature = errors.New("webhook signature missing")
+
+	// ErrInvalidWebhookSignature indicates a webhook request's signature did not match its content.
+	ErrInvalidWebhookSignature = errors.New("webhook signature invalid")
+
+	// ErrStaleWebhookSignature indicates a webhook request was signed too long ago to be trusted.
+	ErrStaleWebhookSignature = errors.New("webhook signature too old")
 )
@@ -0,0 +1,222 @@
+package httpclient
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	dataChangeEventName             = "data_change"
+	defaultEventStreamRetryInterval = time.Second
+	maxEventStreamLineSize          = 1 << 20
+)
+
+// eventStreamEvent is a single event read from a Server-Sent Events stream.
+type eventStreamEvent struct {
+	id    string
+	name  string
+	data  string
+	retry time.Duration
+}
+
+// readEventStream reads a Server-Sent Events stream until it ends, handing every event to handle. Reading stops
+// early if handle returns false.
+func readEventStream(r io.Reader, handle func(*eventStreamEvent) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxEventStreamLineSize)
+
+	event := &eventStreamEvent{}
+	dataLines := []string{}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			event.data = strings.Join(dataLines, "\n")
+			if !handle(event) {
+				return nil
+			}
+
+			event = &eventStreamEvent{}
+			dataLines = []string{}
+
+			continue
+		}
+
+		// lines that start with a colon are comments, like the heartbeats the server sends.
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+
+		switch field {
+		case "id":
+			event.id = value
+		case "event":
+			event.name = value
+		case "data":
+			dataLines = append(dataLines, value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil {
+				event.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// openDataChangeEventStream requests a data change event stream, resuming after lastEventID if it isn't empty.
+func (c *Client) openDataChangeEventStream(ctx context.Context, client *http.Client, filter *types.DataChangeSubscriptionFilter, lastEventID string) (*http.Response, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := c.logger
+
+	req, err := c.requestBuilder.BuildSubscribeToDataChangeEventsRequest(ctx, filter, lastEventID)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building event stream request")
+	}
+
+	res, err := c.fetchResponseToRequest(ctx, client, req)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "opening event stream")
+	}
+
+	if err = errorFromResponse(res); err != nil {
+		c.closeResponseBody(ctx, res)
+		return nil, observability.PrepareError(err, logger, span, "opening event stream")
+	}
+
+	if res.StatusCode != http.StatusOK {
+		c.closeResponseBody(ctx, res)
+		return nil, observability.PrepareError(fmt.Errorf("unexpected event stream response status: %d", res.StatusCode), logger, span, "opening event stream")
+	}
+
+	return res, nil
+}
+
+// SubscribeToDataChangeEvents subscribes to a Server-Sent Events stream to receive DataChangeMessages, for when
+// websockets aren't an option. Streams that the server ends are resumed where they left off, until stopChan
+// receives a value or the stream is rejected. A nil filter subscribes to every change the user is allowed to see.
+func (c *Client) SubscribeToDataChangeEvents(ctx context.Context, filter *types.DataChangeSubscriptionFilter, stopChan <-chan bool) (chan *types.DataChangeMessage, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if stopChan == nil {
+		stopChan = make(chan bool)
+	}
+
+	logger := c.logger
+
+	if filter != nil {
+		if err := filter.ValidateWithContext(ctx); err != nil {
+			return nil, observability.PrepareError(err, logger, span, "validating subscription filter")
+		}
+	}
+
+	// streams stay open for longer than our usual client timeout allows.
+	streamClient := &http.Client{
+		Transport: c.authedClient.Transport,
+		Jar:       c.authedClient.Jar,
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+
+	res, err := c.openDataChangeEventStream(streamCtx, streamClient, filter, "")
+	if err != nil {
+		cancel()
+		return nil, observability.PrepareError(err, logger, span, "subscribing to data change events")
+	}
+
+	go func() {
+		select {
+		case <-stopChan:
+		case <-streamCtx.Done():
+		}
+
+		cancel()
+	}()
+
+	dataChangeMessages := make(chan *types.DataChangeMessage)
+	go func() {
+		defer cancel()
+
+		lastEventID := ""
+		retryInterval := defaultEventStreamRetryInterval
+
+		handleEvent := func(event *eventStreamEvent) bool {
+			if event.id != "" {
+				lastEventID = event.id
+			}
+
+			if event.retry > 0 {
+				retryInterval = event.retry
+			}
+
+			if event.name != dataChangeEventName || event.data == "" {
+				return true
+			}
+
+			var msg *types.DataChangeMessage
+			if unmarshalErr := c.encoder.Unmarshal(streamCtx, []byte(event.data), &msg); unmarshalErr != nil {
+				observability.AcknowledgeError(unmarshalErr, logger, span, "decoding data change message")
+				return true
+			}
+
+			if msg == nil || !filter.Matches(msg) {
+				return true
+			}
+
+			select {
+			case dataChangeMessages <- msg:
+				return true
+			case <-streamCtx.Done():
+				return false
+			}
+		}
+
+		for {
+			if res != nil {
+				if readErr := readEventStream(res.Body, handleEvent); readErr != nil && streamCtx.Err() == nil {
+					observability.AcknowledgeError(readErr, logger, span, "receiving data change events")
+				}
+
+				c.closeResponseBody(streamCtx, res)
+				res = nil
+			}
+
+			select {
+			case <-streamCtx.Done():
+				return
+			case <-time.After(retryInterval):
+			}
+
+			var openErr error
+			if res, openErr = c.openDataChangeEventStream(streamCtx, streamClient, filter, lastEventID); openErr != nil {
+				observability.AcknowledgeError(openErr, logger, span, "resuming data change events")
+
+				// there's no point in retrying a stream the server won't let us have.
+				if errors.Is(openErr, ErrUnauthorized) || errors.Is(openErr, ErrInvalidRequestInput) {
+					return
+				}
+			}
+		}
+	}()
+
+	return dataChangeMessages, nil
+}
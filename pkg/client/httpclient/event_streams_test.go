@@ -0,0 +1,140 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/client/httpclient/requests"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func Test_readEventStream(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		stream := ": heartbeat\n\nretry: 250\n\nid: 1\nevent: data_change\ndata: {\"a\":\ndata: 1}\n\nid: 2\ndata:\n\n"
+
+		events := []*eventStreamEvent{}
+		err := readEventStream(strings.NewReader(stream), func(event *eventStreamEvent) bool {
+			events = append(events, event)
+			return true
+		})
+		require.NoError(t, err)
+
+		expected := []*eventStreamEvent{
+			{},
+			{retry: 250 * time.Millisecond},
+			{id: "1", name: dataChangeEventName, data: "{\"a\":\n1}"},
+			{id: "2"},
+		}
+
+		assert.Equal(t, expected, events)
+	})
+
+	T.Run("stops when told to", func(t *testing.T) {
+		t.Parallel()
+
+		stream := "id: 1\n\nid: 2\n\n"
+
+		events := []*eventStreamEvent{}
+		err := readEventStream(strings.NewReader(stream), func(event *eventStreamEvent) bool {
+			events = append(events, event)
+			return false
+		})
+		require.NoError(t, err)
+
+		assert.Len(t, events, 1)
+	})
+}
+
+func TestClient_SubscribeToDataChangeEvents(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/websockets/data_changes/events"
+
+	T.Run("resumes streams the server ends", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		exampleMessages := []*types.DataChangeMessage{
+			{DataType: types.ItemDataType, MessageType: types.CreatedMessageType, Item: fakes.BuildFakeItem()},
+			{DataType: types.ItemDataType, MessageType: types.UpdatedMessageType, Item: fakes.BuildFakeItem()},
+		}
+
+		var connections int32
+		ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, expectedPath, req.URL.Path)
+
+			i := int(atomic.AddInt32(&connections, 1)) - 1
+			if i >= len(exampleMessages) {
+				<-req.Context().Done()
+				return
+			}
+
+			if i > 0 {
+				assert.Equal(t, fmt.Sprintf("%d", i), req.Header.Get(requests.LastEventIDHeader))
+			}
+
+			payload, err := json.Marshal(exampleMessages[i])
+			require.NoError(t, err)
+
+			res.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprintf(res, "retry: 10\n\n: heartbeat\n\nid: %d\nevent: %s\ndata: %s\n\n", i+1, dataChangeEventName, payload)
+		}))
+		t.Cleanup(ts.Close)
+
+		c := buildTestClient(t, ts)
+
+		stopChan := make(chan bool, 1)
+		dataChangeMessages, err := c.SubscribeToDataChangeEvents(ctx, nil, stopChan)
+		require.NoError(t, err)
+
+		for _, expected := range exampleMessages {
+			select {
+			case actual := <-dataChangeMessages:
+				assert.Equal(t, expected, actual)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for data change message")
+			}
+		}
+
+		stopChan <- true
+	})
+
+	T.Run("with invalid filter", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.SubscribeToDataChangeEvents(ctx, &types.DataChangeSubscriptionFilter{DataTypes: []string{t.Name()}}, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with unauthorized response", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		spec := newRequestSpec(true, http.MethodGet, "", expectedPath)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusUnauthorized)
+
+		actual, err := c.SubscribeToDataChangeEvents(ctx, nil, nil)
+		assert.Nil(t, actual)
+		assertErrorMatches(t, err, ErrUnauthorized)
+	})
+}
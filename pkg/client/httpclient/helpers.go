@@ -24,6 +24,10 @@ func errorFromResponse(res *http.Response) error {
 		return ErrInvalidRequestInput
 	case http.StatusUnauthorized, http.StatusForbidden:
 		return ErrUnauthorized
+	case http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	case http.StatusPreconditionRequired:
+		return ErrPreconditionRequired
 	case http.StatusInternalServerError:
 		return ErrInternalServerError
 	default:
@@ -29,6 +29,18 @@ func TestErrorFromResponse(T *testing.T) {
 
 		assert.Error(t, errorFromResponse(nil))
 	})
+
+	T.Run("returns error for precondition failure", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, ErrPreconditionFailed, errorFromResponse(&http.Response{StatusCode: http.StatusPreconditionFailed}))
+	})
+
+	T.Run("returns error for missing precondition", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, ErrPreconditionRequired, errorFromResponse(&http.Response{StatusCode: http.StatusPreconditionRequired}))
+	})
 }
 
 func TestArgIsNotPointerOrNil(T *testing.T) {
@@ -35,8 +35,34 @@ func (c *Client) GetItem(ctx context.Context, itemID string) (*types.Item, error
 	return item, nil
 }
 
+// GetItemViewers gets who is currently viewing or editing an item.
+func (c *Client) GetItemViewers(ctx context.Context, itemID string) (*types.ItemPresenceList, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := c.logger
+
+	if itemID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+	logger = logger.WithValue(keys.ItemIDKey, itemID)
+	tracing.AttachItemIDToSpan(span, itemID)
+
+	req, err := c.requestBuilder.BuildGetItemViewersRequest(ctx, itemID)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building get item viewers request")
+	}
+
+	var viewers *types.ItemPresenceList
+	if err = c.fetchAndUnmarshal(ctx, req, &viewers); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "retrieving item viewers")
+	}
+
+	return viewers, nil
+}
+
 // SearchItems searches through a list of items.
-func (c *Client) SearchItems(ctx context.Context, query string, limit uint8) ([]*types.Item, error) {
+func (c *Client) SearchItems(ctx context.Context, query string, limit uint8) ([]*types.ItemSearchResult, error) {
 	ctx, span := c.tracer.StartSpan(ctx)
 	defer span.End()
 
@@ -57,12 +83,12 @@ func (c *Client) SearchItems(ctx context.Context, query string, limit uint8) ([]
 		return nil, observability.PrepareError(err, logger, span, "building search for items request")
 	}
 
-	var items []*types.Item
-	if err = c.fetchAndUnmarshal(ctx, req, &items); err != nil {
+	var results []*types.ItemSearchResult
+	if err = c.fetchAndUnmarshal(ctx, req, &results); err != nil {
 		return nil, observability.PrepareError(err, logger, span, "retrieving items")
 	}
 
-	return items, nil
+	return results, nil
 }
 
 // GetItems retrieves a list of items.
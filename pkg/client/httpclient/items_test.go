@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -86,6 +87,64 @@ func (s *itemsTestSuite) TestClient_GetItem() {
 	})
 }
 
+func (s *itemsTestSuite) TestClient_GetItemViewers() {
+	const expectedPathFormat = "/api/v1/items/%s/viewers"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		exampleViewers := &types.ItemPresenceList{
+			Viewers: []*types.ItemPresence{
+				{
+					ItemID:           s.exampleItem.ID,
+					Activity:         types.EditingItemPresenceActivity,
+					UserID:           fakes.BuildFakeUser().ID,
+					BelongsToAccount: s.exampleItem.BelongsToAccount,
+				},
+			},
+		}
+
+		spec := newRequestSpec(true, http.MethodGet, "", expectedPathFormat, s.exampleItem.ID)
+		c, _ := buildTestClientWithJSONResponse(t, spec, exampleViewers)
+		actual, err := c.GetItemViewers(s.ctx, s.exampleItem.ID)
+
+		require.NotNil(t, actual)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleViewers, actual)
+	})
+
+	s.Run("with invalid item ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+		actual, err := c.GetItemViewers(s.ctx, "")
+
+		require.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+		actual, err := c.GetItemViewers(s.ctx, s.exampleItem.ID)
+
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodGet, "", expectedPathFormat, s.exampleItem.ID)
+		c := buildTestClientWithInvalidResponse(t, spec)
+		actual, err := c.GetItemViewers(s.ctx, s.exampleItem.ID)
+
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
 func (s *itemsTestSuite) TestClient_GetItems() {
 	const expectedPath = "/api/v1/items"
 
@@ -140,14 +199,22 @@ func (s *itemsTestSuite) TestClient_SearchItems() {
 		t := s.T()
 
 		exampleItemList := fakes.BuildFakeItemList()
+		exampleResults := []*types.ItemSearchResult{}
+		for _, item := range exampleItemList.Items {
+			exampleResults = append(exampleResults, &types.ItemSearchResult{
+				Item:       item,
+				Highlights: map[string][]string{"name": {item.Name}},
+				Score:      1,
+			})
+		}
 
 		spec := newRequestSpec(true, http.MethodGet, "limit=20&q=whatever", expectedPath)
-		c, _ := buildTestClientWithJSONResponse(t, spec, exampleItemList.Items)
+		c, _ := buildTestClientWithJSONResponse(t, spec, exampleResults)
 		actual, err := c.SearchItems(s.ctx, exampleQuery, 0)
 
 		require.NotNil(t, actual)
 		assert.NoError(t, err)
-		assert.Equal(t, exampleItemList.Items, actual)
+		assert.Equal(t, exampleResults, actual)
 	})
 
 	s.Run("with empty query", func() {
@@ -260,6 +327,16 @@ func (s *itemsTestSuite) TestClient_UpdateItem() {
 		assert.NoError(t, err)
 	})
 
+	s.Run("with item changed since it was read", func() {
+		t := s.T()
+
+		spec := newRequestSpec(false, http.MethodPut, "", expectedPathFormat, s.exampleItem.ID)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusPreconditionFailed)
+
+		err := c.UpdateItem(s.ctx, s.exampleItem)
+		assert.True(t, errors.Is(err, ErrPreconditionFailed))
+	})
+
 	s.Run("with nil input", func() {
 		t := s.T()
 
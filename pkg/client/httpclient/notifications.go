@@ -0,0 +1,90 @@
+package httpclient
+
+import (
+	"context"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// GetNotifications gets a list of the requesting user's notifications.
+func (c *Client) GetNotifications(ctx context.Context, filter *types.QueryFilter) (*types.NotificationList, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := c.loggerWithFilter(filter)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	req, err := c.requestBuilder.BuildGetNotificationsRequest(ctx, filter)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building notifications list request")
+	}
+
+	var notifications *types.NotificationList
+	if err = c.fetchAndUnmarshal(ctx, req, &notifications); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "retrieving notifications")
+	}
+
+	return notifications, nil
+}
+
+// GetUnreadNotificationCount gets how many of the requesting user's notifications are unread.
+func (c *Client) GetUnreadNotificationCount(ctx context.Context) (uint64, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	req, err := c.requestBuilder.BuildGetUnreadNotificationCountRequest(ctx)
+	if err != nil {
+		return 0, observability.PrepareError(err, c.logger, span, "building unread notification count request")
+	}
+
+	var count *types.NotificationUnreadCount
+	if err = c.fetchAndUnmarshal(ctx, req, &count); err != nil {
+		return 0, observability.PrepareError(err, c.logger, span, "retrieving unread notification count")
+	}
+
+	return count.Count, nil
+}
+
+// MarkNotificationAsSeen marks one of the requesting user's notifications as seen.
+func (c *Client) MarkNotificationAsSeen(ctx context.Context, notificationID string) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if notificationID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	logger := c.logger.WithValue(keys.NotificationIDKey, notificationID)
+	tracing.AttachNotificationIDToSpan(span, notificationID)
+
+	req, err := c.requestBuilder.BuildMarkNotificationAsSeenRequest(ctx, notificationID)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building notification seen request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, nil); err != nil {
+		return observability.PrepareError(err, logger, span, "marking notification as seen")
+	}
+
+	return nil
+}
+
+// MarkAllNotificationsAsSeen marks every one of the requesting user's notifications as seen.
+func (c *Client) MarkAllNotificationsAsSeen(ctx context.Context) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	req, err := c.requestBuilder.BuildMarkAllNotificationsAsSeenRequest(ctx)
+	if err != nil {
+		return observability.PrepareError(err, c.logger, span, "building all notifications seen request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, nil); err != nil {
+		return observability.PrepareError(err, c.logger, span, "marking all notifications as seen")
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func TestNotifications(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(notificationsTestSuite))
+}
+
+type notificationsTestSuite struct {
+	suite.Suite
+
+	ctx                     context.Context
+	exampleNotification     *types.Notification
+	exampleNotificationList *types.NotificationList
+}
+
+var _ suite.SetupTestSuite = (*notificationsTestSuite)(nil)
+
+func (s *notificationsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.exampleNotification = fakes.BuildFakeNotification()
+	s.exampleNotificationList = fakes.BuildFakeNotificationList()
+}
+
+func (s *notificationsTestSuite) TestClient_GetNotifications() {
+	const expectedPath = "/api/v1/notifications"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPath)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleNotificationList)
+
+		actual, err := c.GetNotifications(s.ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleNotificationList, actual)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.GetNotifications(s.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.GetNotifications(s.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *notificationsTestSuite) TestClient_GetUnreadNotificationCount() {
+	const expectedPath = "/api/v1/notifications/unread_count"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		exampleCount := &types.NotificationUnreadCount{Count: 3}
+
+		spec := newRequestSpec(true, http.MethodGet, "", expectedPath)
+		c, _ := buildTestClientWithJSONResponse(t, spec, exampleCount)
+
+		actual, err := c.GetUnreadNotificationCount(s.ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleCount.Count, actual)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.GetUnreadNotificationCount(s.ctx)
+		assert.Zero(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.GetUnreadNotificationCount(s.ctx)
+		assert.Zero(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *notificationsTestSuite) TestClient_MarkNotificationAsSeen() {
+	const expectedPathFormat = "/api/v1/notifications/%s/seen"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodPost, "", expectedPathFormat, s.exampleNotification.ID)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusNoContent)
+
+		err := c.MarkNotificationAsSeen(s.ctx, s.exampleNotification.ID)
+		assert.NoError(t, err)
+	})
+
+	s.Run("with invalid notification ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		err := c.MarkNotificationAsSeen(s.ctx, "")
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		err := c.MarkNotificationAsSeen(s.ctx, s.exampleNotification.ID)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		err := c.MarkNotificationAsSeen(s.ctx, s.exampleNotification.ID)
+		assert.Error(t, err)
+	})
+}
+
+func (s *notificationsTestSuite) TestClient_MarkAllNotificationsAsSeen() {
+	const expectedPath = "/api/v1/notifications/seen"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodPost, "", expectedPath)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusNoContent)
+
+		err := c.MarkAllNotificationsAsSeen(s.ctx)
+		assert.NoError(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		err := c.MarkAllNotificationsAsSeen(s.ctx)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		err := c.MarkAllNotificationsAsSeen(s.ctx)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,155 @@
+package requests
+
+import (
+	"context"
+	"net/http"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	accountInvitationsBasePath         = "invitations"
+	receivedAccountInvitationsBasePath = "account_invitations"
+)
+
+// BuildInviteUserToAccountRequest builds an HTTP request for inviting a user to an account.
+func (b *Builder) BuildInviteUserToAccountRequest(ctx context.Context, accountID string, input *types.AccountInvitationCreationRequestInput) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	logger := b.logger.WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "validating input")
+	}
+
+	uri := b.BuildURL(ctx, nil, accountsBasePath, accountID, accountInvitationsBasePath)
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	return b.buildDataRequest(ctx, http.MethodPost, uri, input)
+}
+
+// BuildGetPendingAccountInvitationsRequest builds an HTTP request for fetching the invitations an account has outstanding.
+func (b *Builder) BuildGetPendingAccountInvitationsRequest(ctx context.Context, accountID string, filter *types.QueryFilter) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := filter.AttachToLogger(b.logger).WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	uri := b.BuildURL(ctx, filter.ToValues(), accountsBasePath, accountID, accountInvitationsBasePath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building account invitations list request")
+	}
+
+	return req, nil
+}
+
+// BuildRevokeAccountInvitationRequest builds an HTTP request for revoking an account invitation.
+func (b *Builder) BuildRevokeAccountInvitationRequest(ctx context.Context, accountID, accountInvitationID string) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" || accountInvitationID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := b.logger.WithValue(keys.AccountIDKey, accountID).WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+
+	uri := b.BuildURL(ctx, nil, accountsBasePath, accountID, accountInvitationsBasePath, accountInvitationID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building revoke account invitation request")
+	}
+
+	return req, nil
+}
+
+// BuildGetReceivedAccountInvitationsRequest builds an HTTP request for fetching the invitations awaiting the requester's response.
+func (b *Builder) BuildGetReceivedAccountInvitationsRequest(ctx context.Context) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	uri := b.BuildURL(ctx, nil, receivedAccountInvitationsBasePath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, b.logger, span, "building received account invitations request")
+	}
+
+	return req, nil
+}
+
+// BuildClaimAccountInvitationRequest builds an HTTP request for claiming an invitation that was sent to an email address.
+func (b *Builder) BuildClaimAccountInvitationRequest(ctx context.Context, input *types.AccountInvitationClaimInput) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		return nil, observability.PrepareError(err, b.logger, span, "validating input")
+	}
+
+	uri := b.BuildURL(ctx, nil, receivedAccountInvitationsBasePath, "claim")
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	return b.buildDataRequest(ctx, http.MethodPost, uri, input)
+}
+
+// BuildAcceptAccountInvitationRequest builds an HTTP request for accepting an account invitation.
+func (b *Builder) BuildAcceptAccountInvitationRequest(ctx context.Context, accountInvitationID string) (*http.Request, error) {
+	return b.buildAccountInvitationResponseRequest(ctx, accountInvitationID, "accept")
+}
+
+// BuildRejectAccountInvitationRequest builds an HTTP request for rejecting an account invitation.
+func (b *Builder) BuildRejectAccountInvitationRequest(ctx context.Context, accountInvitationID string) (*http.Request, error) {
+	return b.buildAccountInvitationResponseRequest(ctx, accountInvitationID, "reject")
+}
+
+// buildAccountInvitationResponseRequest builds an HTTP request for responding to an account invitation.
+func (b *Builder) buildAccountInvitationResponseRequest(ctx context.Context, accountInvitationID, response string) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountInvitationID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := b.logger.WithValue(keys.AccountInvitationIDKey, accountInvitationID)
+	tracing.AttachAccountInvitationIDToSpan(span, accountInvitationID)
+
+	uri := b.BuildURL(ctx, nil, receivedAccountInvitationsBasePath, accountInvitationID, response)
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building account invitation %s request", response)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,318 @@
+package requests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func TestBuilder_BuildInviteUserToAccountRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/accounts/%s/invitations"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccountID := fakes.BuildFakeID()
+		exampleInput := fakes.BuildFakeAccountInvitationCreationRequestInput()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPathFormat, exampleAccountID)
+
+		actual, err := helper.builder.BuildInviteUserToAccountRequest(helper.ctx, exampleAccountID, exampleInput)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid account ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildInviteUserToAccountRequest(helper.ctx, "", fakes.BuildFakeAccountInvitationCreationRequestInput())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildInviteUserToAccountRequest(helper.ctx, fakes.BuildFakeID(), nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildInviteUserToAccountRequest(helper.ctx, fakes.BuildFakeID(), &types.AccountInvitationCreationRequestInput{})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildInviteUserToAccountRequest(helper.ctx, fakes.BuildFakeID(), fakes.BuildFakeAccountInvitationCreationRequestInput())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildGetPendingAccountInvitationsRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/accounts/%s/invitations"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccountID := fakes.BuildFakeID()
+
+		spec := newRequestSpec(false, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPathFormat, exampleAccountID)
+
+		actual, err := helper.builder.BuildGetPendingAccountInvitationsRequest(helper.ctx, exampleAccountID, nil)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid account ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildGetPendingAccountInvitationsRequest(helper.ctx, "", nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildGetPendingAccountInvitationsRequest(helper.ctx, fakes.BuildFakeID(), nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildRevokeAccountInvitationRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/accounts/%s/invitations/%s"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccountInvitation := fakes.BuildFakeAccountInvitation()
+
+		spec := newRequestSpec(true, http.MethodDelete, "", expectedPathFormat, exampleAccountInvitation.DestinationAccount, exampleAccountInvitation.ID)
+
+		actual, err := helper.builder.BuildRevokeAccountInvitationRequest(helper.ctx, exampleAccountInvitation.DestinationAccount, exampleAccountInvitation.ID)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid account invitation ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildRevokeAccountInvitationRequest(helper.ctx, fakes.BuildFakeID(), "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildRevokeAccountInvitationRequest(helper.ctx, fakes.BuildFakeID(), fakes.BuildFakeID())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildGetReceivedAccountInvitationsRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/account_invitations"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		spec := newRequestSpec(true, http.MethodGet, "", expectedPath)
+
+		actual, err := helper.builder.BuildGetReceivedAccountInvitationsRequest(helper.ctx)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildGetReceivedAccountInvitationsRequest(helper.ctx)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildClaimAccountInvitationRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/account_invitations/claim"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleInput := &types.AccountInvitationClaimInput{Token: t.Name()}
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+
+		actual, err := helper.builder.BuildClaimAccountInvitationRequest(helper.ctx, exampleInput)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildClaimAccountInvitationRequest(helper.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildClaimAccountInvitationRequest(helper.ctx, &types.AccountInvitationClaimInput{})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildClaimAccountInvitationRequest(helper.ctx, &types.AccountInvitationClaimInput{Token: t.Name()})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildAcceptAccountInvitationRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/account_invitations/%s/accept"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccountInvitationID := fakes.BuildFakeID()
+
+		spec := newRequestSpec(true, http.MethodPost, "", expectedPathFormat, exampleAccountInvitationID)
+
+		actual, err := helper.builder.BuildAcceptAccountInvitationRequest(helper.ctx, exampleAccountInvitationID)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid account invitation ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildAcceptAccountInvitationRequest(helper.ctx, "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildAcceptAccountInvitationRequest(helper.ctx, fakes.BuildFakeID())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildRejectAccountInvitationRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/account_invitations/%s/reject"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccountInvitationID := fakes.BuildFakeID()
+
+		spec := newRequestSpec(true, http.MethodPost, "", expectedPathFormat, exampleAccountInvitationID)
+
+		actual, err := helper.builder.BuildRejectAccountInvitationRequest(helper.ctx, exampleAccountInvitationID)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid account invitation ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildRejectAccountInvitationRequest(helper.ctx, "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildRejectAccountInvitationRequest(helper.ctx, fakes.BuildFakeID())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,139 @@
+package requests
+
+import (
+	"context"
+	"net/http"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	accountRolesBasePath = "roles"
+)
+
+// BuildGetAccountRoleRequest builds an HTTP request for fetching an account role.
+func (b *Builder) BuildGetAccountRoleRequest(ctx context.Context, accountID, accountRoleID string) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" || accountRoleID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := b.logger.WithValue(keys.AccountIDKey, accountID).WithValue(keys.AccountRoleIDKey, accountRoleID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachAccountRoleIDToSpan(span, accountRoleID)
+
+	uri := b.BuildURL(ctx, nil, accountsBasePath, accountID, accountRolesBasePath, accountRoleID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building account role request")
+	}
+
+	return req, nil
+}
+
+// BuildGetAccountRolesRequest builds an HTTP request for fetching a list of an account's roles.
+func (b *Builder) BuildGetAccountRolesRequest(ctx context.Context, accountID string, filter *types.QueryFilter) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := filter.AttachToLogger(b.logger).WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	uri := b.BuildURL(ctx, filter.ToValues(), accountsBasePath, accountID, accountRolesBasePath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building account roles list request")
+	}
+
+	return req, nil
+}
+
+// BuildCreateAccountRoleRequest builds an HTTP request for creating an account role.
+func (b *Builder) BuildCreateAccountRoleRequest(ctx context.Context, accountID string, input *types.AccountRoleCreationInput) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	logger := b.logger.WithValue(keys.AccountIDKey, accountID).WithValue(keys.NameKey, input.Name)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "validating input")
+	}
+
+	uri := b.BuildURL(ctx, nil, accountsBasePath, accountID, accountRolesBasePath)
+
+	return b.buildDataRequest(ctx, http.MethodPost, uri, input)
+}
+
+// BuildUpdateAccountRoleRequest builds an HTTP request for updating an account role.
+func (b *Builder) BuildUpdateAccountRoleRequest(ctx context.Context, accountRole *types.AccountRole) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountRole == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	logger := b.logger.WithValue(keys.AccountIDKey, accountRole.BelongsToAccount).WithValue(keys.AccountRoleIDKey, accountRole.ID)
+	tracing.AttachAccountIDToSpan(span, accountRole.BelongsToAccount)
+	tracing.AttachAccountRoleIDToSpan(span, accountRole.ID)
+
+	uri := b.BuildURL(ctx, nil, accountsBasePath, accountRole.BelongsToAccount, accountRolesBasePath, accountRole.ID)
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	input := &types.AccountRoleUpdateInput{
+		Name:        accountRole.Name,
+		Description: accountRole.Description,
+		Permissions: accountRole.Permissions,
+	}
+
+	req, err := b.buildDataRequest(ctx, http.MethodPut, uri, input)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building request")
+	}
+
+	return req, nil
+}
+
+// BuildArchiveAccountRoleRequest builds an HTTP request for archiving an account role.
+func (b *Builder) BuildArchiveAccountRoleRequest(ctx context.Context, accountID, accountRoleID string) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" || accountRoleID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := b.logger.WithValue(keys.AccountIDKey, accountID).WithValue(keys.AccountRoleIDKey, accountRoleID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+	tracing.AttachAccountRoleIDToSpan(span, accountRoleID)
+
+	uri := b.BuildURL(ctx, nil, accountsBasePath, accountID, accountRolesBasePath, accountRoleID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building archive account role request")
+	}
+
+	return req, nil
+}
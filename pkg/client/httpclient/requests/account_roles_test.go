@@ -0,0 +1,237 @@
+package requests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func TestBuilder_BuildGetAccountRoleRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/accounts/%s/roles/%s"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+
+		spec := newRequestSpec(false, http.MethodGet, "", expectedPathFormat, exampleAccountRole.BelongsToAccount, exampleAccountRole.ID)
+
+		actual, err := helper.builder.BuildGetAccountRoleRequest(helper.ctx, exampleAccountRole.BelongsToAccount, exampleAccountRole.ID)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid account role ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildGetAccountRoleRequest(helper.ctx, fakes.BuildFakeID(), "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildGetAccountRoleRequest(helper.ctx, fakes.BuildFakeID(), fakes.BuildFakeID())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildGetAccountRolesRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/accounts/%s/roles"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccountID := fakes.BuildFakeID()
+
+		spec := newRequestSpec(false, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPathFormat, exampleAccountID)
+
+		actual, err := helper.builder.BuildGetAccountRolesRequest(helper.ctx, exampleAccountID, nil)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid account ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildGetAccountRolesRequest(helper.ctx, "", nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildGetAccountRolesRequest(helper.ctx, fakes.BuildFakeID(), nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildCreateAccountRoleRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/accounts/%s/roles"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccountID := fakes.BuildFakeID()
+		exampleInput := fakes.BuildFakeAccountRoleCreationInput()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPathFormat, exampleAccountID)
+
+		actual, err := helper.builder.BuildCreateAccountRoleRequest(helper.ctx, exampleAccountID, exampleInput)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid account ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildCreateAccountRoleRequest(helper.ctx, "", fakes.BuildFakeAccountRoleCreationInput())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildCreateAccountRoleRequest(helper.ctx, fakes.BuildFakeID(), nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildCreateAccountRoleRequest(helper.ctx, fakes.BuildFakeID(), &types.AccountRoleCreationInput{})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildCreateAccountRoleRequest(helper.ctx, fakes.BuildFakeID(), fakes.BuildFakeAccountRoleCreationInput())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildUpdateAccountRoleRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/accounts/%s/roles/%s"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+
+		spec := newRequestSpec(false, http.MethodPut, "", expectedPathFormat, exampleAccountRole.BelongsToAccount, exampleAccountRole.ID)
+
+		actual, err := helper.builder.BuildUpdateAccountRoleRequest(helper.ctx, exampleAccountRole)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildUpdateAccountRoleRequest(helper.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildUpdateAccountRoleRequest(helper.ctx, fakes.BuildFakeAccountRole())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildArchiveAccountRoleRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/accounts/%s/roles/%s"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccountRole := fakes.BuildFakeAccountRole()
+
+		spec := newRequestSpec(false, http.MethodDelete, "", expectedPathFormat, exampleAccountRole.BelongsToAccount, exampleAccountRole.ID)
+
+		actual, err := helper.builder.BuildArchiveAccountRoleRequest(helper.ctx, exampleAccountRole.BelongsToAccount, exampleAccountRole.ID)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid account role ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildArchiveAccountRoleRequest(helper.ctx, fakes.BuildFakeID(), "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildArchiveAccountRoleRequest(helper.ctx, fakes.BuildFakeID(), fakes.BuildFakeID())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
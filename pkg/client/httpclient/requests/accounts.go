@@ -5,6 +5,7 @@
 	"fmt"
 	"net/http"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
@@ -120,7 +121,17 @@ func (b *Builder) BuildUpdateAccountRequest(ctx context.Context, account *types.
 	)
 	tracing.AttachRequestURIToSpan(span, uri)
 
-	return b.buildDataRequest(ctx, http.MethodPut, uri, account)
+	req, err := b.buildDataRequest(ctx, http.MethodPut, uri, account)
+	if err != nil {
+		return nil, observability.PrepareError(err, b.logger.WithValue(keys.AccountIDKey, account.ID), span, "building request")
+	}
+
+	// only write the account if it hasn't changed since it was read.
+	if account.Version != 0 {
+		req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(account.Version))
+	}
+
+	return req, nil
 }
 
 // BuildArchiveAccountRequest builds an HTTP request for archiving an account.
@@ -150,27 +161,6 @@ func (b *Builder) BuildArchiveAccountRequest(ctx context.Context, accountID stri
 	return req, nil
 }
 
-// BuildAddUserRequest builds a request that adds a user to an account.
-func (b *Builder) BuildAddUserRequest(ctx context.Context, input *types.AddUserToAccountInput) (*http.Request, error) {
-	ctx, span := b.tracer.StartSpan(ctx)
-	defer span.End()
-
-	if input == nil {
-		return nil, ErrNilInputProvided
-	}
-
-	logger := b.logger.WithValue(keys.UserIDKey, input.UserID)
-
-	if err := input.ValidateWithContext(ctx); err != nil {
-		return nil, observability.PrepareError(err, logger, span, "validating input")
-	}
-
-	uri := b.BuildURL(ctx, nil, accountsBasePath, input.AccountID, "member")
-	tracing.AttachRequestURIToSpan(span, uri)
-
-	return b.buildDataRequest(ctx, http.MethodPost, uri, input)
-}
-
 // BuildMarkAsDefaultRequest builds a request that marks a given account as the default for a given user.
 func (b *Builder) BuildMarkAsDefaultRequest(ctx context.Context, accountID string) (*http.Request, error) {
 	ctx, span := b.tracer.StartSpan(ctx)
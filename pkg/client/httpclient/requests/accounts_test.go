@@ -8,6 +8,7 @@
 
 	"github.com/stretchr/testify/assert"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
 )
@@ -198,6 +199,23 @@ func TestBuilder_BuildUpdateAccountRequest(T *testing.T) {
 
 		actual, err := helper.builder.BuildUpdateAccountRequest(helper.ctx, exampleAccount)
 		assert.NoError(t, err)
+		assert.Equal(t, encoding.ETagForVersion(exampleAccount.Version), actual.Header.Get(encoding.IfMatchHeaderKey))
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("without version", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccount := fakes.BuildFakeAccount()
+		exampleAccount.Version = 0
+
+		spec := newRequestSpec(false, http.MethodPut, "", expectedPathFormat, exampleAccount.ID)
+
+		actual, err := helper.builder.BuildUpdateAccountRequest(helper.ctx, exampleAccount)
+		assert.NoError(t, err)
+		assert.Empty(t, actual.Header.Get(encoding.IfMatchHeaderKey))
 
 		assertRequestQuality(t, actual, spec)
 	})
@@ -267,59 +285,6 @@ func TestBuilder_BuildArchiveAccountRequest(T *testing.T) {
 	})
 }
 
-func TestBuilder_BuildAddUserRequest(T *testing.T) {
-	T.Parallel()
-
-	const expectedPathFormat = "/api/v1/accounts/%s/member"
-
-	T.Run("standard", func(t *testing.T) {
-		t.Parallel()
-
-		helper := buildTestHelper()
-
-		exampleInput := fakes.BuildFakeAddUserToAccountInput()
-		spec := newRequestSpec(false, http.MethodPost, "", expectedPathFormat, exampleInput.AccountID)
-
-		actual, err := helper.builder.BuildAddUserRequest(helper.ctx, exampleInput)
-		assert.NoError(t, err)
-
-		assertRequestQuality(t, actual, spec)
-	})
-
-	T.Run("with nil input", func(t *testing.T) {
-		t.Parallel()
-
-		helper := buildTestHelper()
-
-		actual, err := helper.builder.BuildAddUserRequest(helper.ctx, nil)
-		assert.Nil(t, actual)
-		assert.Error(t, err)
-	})
-
-	T.Run("with invalid input", func(t *testing.T) {
-		t.Parallel()
-
-		helper := buildTestHelper()
-
-		actual, err := helper.builder.BuildAddUserRequest(helper.ctx, &types.AddUserToAccountInput{})
-		assert.Nil(t, actual)
-		assert.Error(t, err)
-	})
-
-	T.Run("with invalid request builder", func(t *testing.T) {
-		t.Parallel()
-
-		helper := buildTestHelper()
-		helper.builder = buildTestRequestBuilderWithInvalidURL()
-
-		exampleInput := fakes.BuildFakeAddUserToAccountInput()
-
-		actual, err := helper.builder.BuildAddUserRequest(helper.ctx, exampleInput)
-		assert.Nil(t, actual)
-		assert.Error(t, err)
-	})
-}
-
 func TestBuilder_BuildMarkAsDefaultRequest(T *testing.T) {
 	T.Parallel()
 
@@ -6,6 +6,7 @@
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
@@ -32,3 +33,41 @@ func (b *Builder) BuildUserReputationUpdateInputRequest(ctx context.Context, inp
 
 	return b.buildDataRequest(ctx, http.MethodPost, uri, input)
 }
+
+// BuildSearchReindexRequest builds a request to rebuild the search index.
+func (b *Builder) BuildSearchReindexRequest(ctx context.Context) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	uri := b.BuildURL(ctx, nil, adminBasePath, "search", "reindex")
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, b.logger, span, "building search reindex request")
+	}
+
+	return req, nil
+}
+
+// BuildRevokeAllUserSessionsRequest builds a request to revoke every session belonging to a user.
+func (b *Builder) BuildRevokeAllUserSessionsRequest(ctx context.Context, userID string) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := b.logger.WithValue(keys.UserIDKey, userID)
+	tracing.AttachUserIDToSpan(span, userID)
+
+	uri := b.BuildURL(ctx, nil, adminBasePath, usersBasePath, userID, userSessionsBasePath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building revoke all user sessions request")
+	}
+
+	return req, nil
+}
@@ -49,3 +49,62 @@ func TestBuilder_BuildUserReputationUpdateInputRequest(T *testing.T) {
 		assert.Nil(t, actual)
 	})
 }
+
+func TestBuilder_BuildSearchReindexRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/admin/search/reindex"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+
+		actual, err := helper.builder.BuildSearchReindexRequest(helper.ctx)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+}
+
+func TestBuilder_BuildRevokeAllUserSessionsRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/admin/users/%s/sessions"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		spec := newRequestSpec(true, http.MethodDelete, "", expectedPathFormat, helper.exampleUser.ID)
+
+		actual, err := helper.builder.BuildRevokeAllUserSessionsRequest(helper.ctx, helper.exampleUser.ID)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid user ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildRevokeAllUserSessionsRequest(helper.ctx, "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildRevokeAllUserSessionsRequest(helper.ctx, helper.exampleUser.ID)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
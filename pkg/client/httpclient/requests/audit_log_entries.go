@@ -0,0 +1,54 @@
+package requests
+
+import (
+	"context"
+	"net/http"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	auditLogBasePath = "audit_log"
+)
+
+// BuildGetAuditLogEntriesForAccountRequest builds an HTTP request for fetching an account's audit log entries.
+func (b *Builder) BuildGetAuditLogEntriesForAccountRequest(ctx context.Context, accountID string, filter *types.AuditLogEntryQueryFilter) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if accountID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := filter.AttachToLogger(b.logger).WithValue(keys.AccountIDKey, accountID)
+	tracing.AttachAccountIDToSpan(span, accountID)
+
+	uri := b.BuildURL(ctx, filter.ToValues(), accountsBasePath, accountID, auditLogBasePath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building account audit log request")
+	}
+
+	return req, nil
+}
+
+// BuildGetAuditLogEntriesRequest builds an HTTP request for fetching audit log entries for every account.
+func (b *Builder) BuildGetAuditLogEntriesRequest(ctx context.Context, filter *types.AuditLogEntryQueryFilter) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := filter.AttachToLogger(b.logger)
+
+	uri := b.BuildURL(ctx, filter.ToValues(), adminBasePath, auditLogBasePath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building audit log request")
+	}
+
+	return req, nil
+}
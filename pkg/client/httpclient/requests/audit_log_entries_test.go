@@ -0,0 +1,98 @@
+package requests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func TestBuilder_BuildGetAuditLogEntriesForAccountRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/accounts/%s/audit_log"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccountID := fakes.BuildFakeID()
+
+		spec := newRequestSpec(false, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPathFormat, exampleAccountID)
+
+		actual, err := helper.builder.BuildGetAuditLogEntriesForAccountRequest(helper.ctx, exampleAccountID, nil)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with filter", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleAccountID := fakes.BuildFakeID()
+		filter := types.DefaultAuditLogEntryQueryFilter()
+		filter.EventType = types.ItemCreationEvent
+
+		spec := newRequestSpec(false, http.MethodGet, "eventType=item_created&includeArchived=false&limit=20&page=1&sortBy=asc", expectedPathFormat, exampleAccountID)
+
+		actual, err := helper.builder.BuildGetAuditLogEntriesForAccountRequest(helper.ctx, exampleAccountID, filter)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid account ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildGetAuditLogEntriesForAccountRequest(helper.ctx, "", nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildGetAuditLogEntriesForAccountRequest(helper.ctx, fakes.BuildFakeID(), nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildGetAuditLogEntriesRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/admin/audit_log"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		spec := newRequestSpec(false, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPath)
+
+		actual, err := helper.builder.BuildGetAuditLogEntriesRequest(helper.ctx, nil)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildGetAuditLogEntriesRequest(helper.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
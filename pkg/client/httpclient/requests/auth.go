@@ -140,3 +140,58 @@ func (b *Builder) BuildVerifyTOTPSecretRequest(ctx context.Context, userID, toke
 		UserID:    userID,
 	})
 }
+
+// BuildRequestPasswordResetRequest builds a request to have a password reset token sent to a user.
+func (b *Builder) BuildRequestPasswordResetRequest(ctx context.Context, input *types.PasswordResetTokenCreationRequestInput) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	logger := b.logger.WithValue(keys.UsernameKey, input.Username)
+	tracing.AttachUsernameToSpan(span, input.Username)
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "validating input")
+	}
+
+	uri := b.buildUnversionedURL(ctx, nil, usersBasePath, "password", "reset")
+
+	return b.buildDataRequest(ctx, http.MethodPost, uri, input)
+}
+
+// BuildRedeemPasswordResetTokenRequest builds a request to set a new password with a password reset token.
+func (b *Builder) BuildRedeemPasswordResetTokenRequest(ctx context.Context, input *types.PasswordResetTokenRedemptionRequestInput) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	// validating here requires settings knowledge, so we do not do it.
+
+	uri := b.buildUnversionedURL(ctx, nil, usersBasePath, "password", "reset", "redeem")
+
+	return b.buildDataRequest(ctx, http.MethodPost, uri, input)
+}
+
+// BuildRecoverTOTPSecretRequest builds a request to trade a TOTP recovery code for a new 2FA secret.
+func (b *Builder) BuildRecoverTOTPSecretRequest(ctx context.Context, input *types.TOTPRecoveryInput) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	tracing.AttachUsernameToSpan(span, input.Username)
+
+	// validating here requires settings knowledge, so we do not do it.
+
+	uri := b.buildUnversionedURL(ctx, nil, usersBasePath, "totp_secret", "recover")
+
+	return b.buildDataRequest(ctx, http.MethodPost, uri, input)
+}
@@ -267,3 +267,136 @@ func TestBuilder_BuildVerifyTOTPSecretRequest(T *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestBuilder_BuildRequestPasswordResetRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/users/password/reset"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleInput := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+
+		actual, err := helper.builder.BuildRequestPasswordResetRequest(helper.ctx, exampleInput)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildRequestPasswordResetRequest(helper.ctx, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildRequestPasswordResetRequest(helper.ctx, &types.PasswordResetTokenCreationRequestInput{})
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+		exampleInput := fakes.BuildFakePasswordResetTokenCreationRequestInput()
+
+		actual, err := helper.builder.BuildRequestPasswordResetRequest(helper.ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestBuilder_BuildRedeemPasswordResetTokenRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/users/password/reset/redeem"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+
+		actual, err := helper.builder.BuildRedeemPasswordResetTokenRequest(helper.ctx, exampleInput)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildRedeemPasswordResetTokenRequest(helper.ctx, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+		exampleInput := fakes.BuildFakePasswordResetTokenRedemptionRequestInput()
+
+		actual, err := helper.builder.BuildRedeemPasswordResetTokenRequest(helper.ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestBuilder_BuildRecoverTOTPSecretRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/users/totp_secret/recover"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleInput := fakes.BuildFakeTOTPRecoveryInput()
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+
+		actual, err := helper.builder.BuildRecoverTOTPSecretRequest(helper.ctx, exampleInput)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildRecoverTOTPSecretRequest(helper.ctx, nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+		exampleInput := fakes.BuildFakeTOTPRecoveryInput()
+
+		actual, err := helper.builder.BuildRecoverTOTPSecretRequest(helper.ctx, exampleInput)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
@@ -6,6 +6,7 @@
 	"net/url"
 	"strconv"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
@@ -45,6 +46,36 @@ func (b *Builder) BuildGetItemRequest(ctx context.Context, itemID string) (*http
 	return req, nil
 }
 
+// BuildGetItemViewersRequest builds an HTTP request for fetching who is viewing or editing an item.
+func (b *Builder) BuildGetItemViewersRequest(ctx context.Context, itemID string) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := b.logger
+
+	if itemID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+	logger = logger.WithValue(keys.ItemIDKey, itemID)
+	tracing.AttachItemIDToSpan(span, itemID)
+
+	uri := b.BuildURL(
+		ctx,
+		nil,
+		itemsBasePath,
+		itemID,
+		"viewers",
+	)
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building item viewers request")
+	}
+
+	return req, nil
+}
+
 // BuildSearchItemsRequest builds an HTTP request for querying items.
 func (b *Builder) BuildSearchItemsRequest(ctx context.Context, query string, limit uint8) (*http.Request, error) {
 	ctx, span := b.tracer.StartSpan(ctx)
@@ -152,6 +183,11 @@ func (b *Builder) BuildUpdateItemRequest(ctx context.Context, item *types.Item)
 		return nil, observability.PrepareError(err, logger, span, "building request")
 	}
 
+	// only write the item if it hasn't changed since it was read.
+	if item.Version != 0 {
+		req.Header.Set(encoding.IfMatchHeaderKey, encoding.ETagForVersion(item.Version))
+	}
+
 	return req, nil
 }
 
@@ -6,6 +6,7 @@
 
 	"github.com/stretchr/testify/assert"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/encoding"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
 )
@@ -54,6 +55,50 @@ func TestBuilder_BuildGetItemRequest(T *testing.T) {
 	})
 }
 
+func TestBuilder_BuildGetItemViewersRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/items/%s/viewers"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		exampleItem := fakes.BuildFakeItem()
+
+		spec := newRequestSpec(true, http.MethodGet, "", expectedPathFormat, exampleItem.ID)
+
+		actual, err := helper.builder.BuildGetItemViewersRequest(helper.ctx, exampleItem.ID)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid item ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildGetItemViewersRequest(helper.ctx, "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		exampleItem := fakes.BuildFakeItem()
+
+		actual, err := helper.builder.BuildGetItemViewersRequest(helper.ctx, exampleItem.ID)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
 func TestBuilder_BuildGetItemsRequest(T *testing.T) {
 	T.Parallel()
 
@@ -192,6 +237,24 @@ func TestBuilder_BuildUpdateItemRequest(T *testing.T) {
 
 		actual, err := helper.builder.BuildUpdateItemRequest(helper.ctx, exampleItem)
 		assert.NoError(t, err)
+		assert.Equal(t, encoding.ETagForVersion(exampleItem.Version), actual.Header.Get(encoding.IfMatchHeaderKey))
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("without version", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		exampleItem := fakes.BuildFakeItem()
+		exampleItem.Version = 0
+
+		spec := newRequestSpec(false, http.MethodPut, "", expectedPathFormat, exampleItem.ID)
+
+		actual, err := helper.builder.BuildUpdateItemRequest(helper.ctx, exampleItem)
+		assert.NoError(t, err)
+		assert.Empty(t, actual.Header.Get(encoding.IfMatchHeaderKey))
 
 		assertRequestQuality(t, actual, spec)
 	})
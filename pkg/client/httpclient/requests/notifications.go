@@ -0,0 +1,87 @@
+package requests
+
+import (
+	"context"
+	"net/http"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	notificationsBasePath        = "notifications"
+	notificationsUnreadCountPath = "unread_count"
+	notificationsSeenPath        = "seen"
+)
+
+// BuildGetNotificationsRequest builds an HTTP request for fetching a list of notifications.
+func (b *Builder) BuildGetNotificationsRequest(ctx context.Context, filter *types.QueryFilter) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := filter.AttachToLogger(b.logger)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	uri := b.BuildURL(ctx, filter.ToValues(), notificationsBasePath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building notifications list request")
+	}
+
+	return req, nil
+}
+
+// BuildGetUnreadNotificationCountRequest builds an HTTP request for fetching how many notifications are unread.
+func (b *Builder) BuildGetUnreadNotificationCountRequest(ctx context.Context) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	uri := b.BuildURL(ctx, nil, notificationsBasePath, notificationsUnreadCountPath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, b.logger, span, "building unread notification count request")
+	}
+
+	return req, nil
+}
+
+// BuildMarkNotificationAsSeenRequest builds an HTTP request for marking a notification as seen.
+func (b *Builder) BuildMarkNotificationAsSeenRequest(ctx context.Context, notificationID string) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if notificationID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := b.logger.WithValue(keys.NotificationIDKey, notificationID)
+	tracing.AttachNotificationIDToSpan(span, notificationID)
+
+	uri := b.BuildURL(ctx, nil, notificationsBasePath, notificationID, notificationsSeenPath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building notification seen request")
+	}
+
+	return req, nil
+}
+
+// BuildMarkAllNotificationsAsSeenRequest builds an HTTP request for marking every notification as seen.
+func (b *Builder) BuildMarkAllNotificationsAsSeenRequest(ctx context.Context) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	uri := b.BuildURL(ctx, nil, notificationsBasePath, notificationsSeenPath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, b.logger, span, "building all notifications seen request")
+	}
+
+	return req, nil
+}
@@ -0,0 +1,141 @@
+package requests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func TestBuilder_BuildGetNotificationsRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/notifications"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		spec := newRequestSpec(false, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPath)
+
+		actual, err := helper.builder.BuildGetNotificationsRequest(helper.ctx, nil)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildGetNotificationsRequest(helper.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildGetUnreadNotificationCountRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/notifications/unread_count"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		spec := newRequestSpec(false, http.MethodGet, "", expectedPath)
+
+		actual, err := helper.builder.BuildGetUnreadNotificationCountRequest(helper.ctx)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildGetUnreadNotificationCountRequest(helper.ctx)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildMarkNotificationAsSeenRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/notifications/%s/seen"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleNotification := fakes.BuildFakeNotification()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPathFormat, exampleNotification.ID)
+
+		actual, err := helper.builder.BuildMarkNotificationAsSeenRequest(helper.ctx, exampleNotification.ID)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid notification ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildMarkNotificationAsSeenRequest(helper.ctx, "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildMarkNotificationAsSeenRequest(helper.ctx, fakes.BuildFakeID())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildMarkAllNotificationsAsSeenRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/notifications/seen"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+
+		actual, err := helper.builder.BuildMarkAllNotificationsAsSeenRequest(helper.ctx)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildMarkAllNotificationsAsSeenRequest(helper.ctx)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,72 @@
+package requests
+
+import (
+	"context"
+	"net/http"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	userSessionsBasePath = "sessions"
+)
+
+// BuildGetUserSessionsRequest builds an HTTP request for fetching the requesting user's sessions.
+func (b *Builder) BuildGetUserSessionsRequest(ctx context.Context, filter *types.QueryFilter) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := filter.AttachToLogger(b.logger)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	uri := b.BuildURL(ctx, filter.ToValues(), usersBasePath, userSessionsBasePath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building user sessions request")
+	}
+
+	return req, nil
+}
+
+// BuildRevokeUserSessionRequest builds an HTTP request for revoking one of the requesting user's sessions.
+func (b *Builder) BuildRevokeUserSessionRequest(ctx context.Context, userSessionID string) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userSessionID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := b.logger.WithValue(keys.UserSessionIDKey, userSessionID)
+	tracing.AttachUserSessionIDToSpan(span, userSessionID)
+
+	uri := b.BuildURL(ctx, nil, usersBasePath, userSessionsBasePath, userSessionID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building revoke user session request")
+	}
+
+	return req, nil
+}
+
+// BuildRevokeOtherUserSessionsRequest builds an HTTP request for revoking every one of the requesting user's sessions
+// other than the one making the request.
+func (b *Builder) BuildRevokeOtherUserSessionsRequest(ctx context.Context) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	uri := b.BuildURL(ctx, nil, usersBasePath, userSessionsBasePath)
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, b.logger, span, "building revoke user sessions request")
+	}
+
+	return req, nil
+}
@@ -0,0 +1,112 @@
+package requests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func TestBuilder_BuildGetUserSessionsRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/users/sessions"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		spec := newRequestSpec(true, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPath)
+
+		actual, err := helper.builder.BuildGetUserSessionsRequest(helper.ctx, nil)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildGetUserSessionsRequest(helper.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildRevokeUserSessionRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/users/sessions/%s"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		spec := newRequestSpec(true, http.MethodDelete, "", expectedPathFormat, exampleUserSession.ID)
+
+		actual, err := helper.builder.BuildRevokeUserSessionRequest(helper.ctx, exampleUserSession.ID)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid user session ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildRevokeUserSessionRequest(helper.ctx, "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+		exampleUserSession := fakes.BuildFakeUserSession()
+
+		actual, err := helper.builder.BuildRevokeUserSessionRequest(helper.ctx, exampleUserSession.ID)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildRevokeOtherUserSessionsRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/users/sessions"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		spec := newRequestSpec(true, http.MethodDelete, "", expectedPath)
+
+		actual, err := helper.builder.BuildRevokeOtherUserSessionsRequest(helper.ctx)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildRevokeOtherUserSessionsRequest(helper.ctx)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
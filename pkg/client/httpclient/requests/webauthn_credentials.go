@@ -0,0 +1,110 @@
+package requests
+
+import (
+	"context"
+	"net/http"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+const (
+	webAuthnBasePath            = "webauthn"
+	webAuthnCredentialsBasePath = "credentials"
+)
+
+// BuildGetWebAuthnCredentialsRequest builds an HTTP request for fetching the requesting user's WebAuthn credentials.
+func (b *Builder) BuildGetWebAuthnCredentialsRequest(ctx context.Context) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	uri := b.BuildURL(ctx, nil, usersBasePath, webAuthnBasePath, webAuthnCredentialsBasePath)
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, b.logger, span, "building WebAuthn credentials request")
+	}
+
+	return req, nil
+}
+
+// BuildBeginWebAuthnRegistrationRequest builds an HTTP request for beginning to register a WebAuthn credential.
+func (b *Builder) BuildBeginWebAuthnRegistrationRequest(ctx context.Context) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	uri := b.BuildURL(ctx, nil, usersBasePath, webAuthnBasePath, "registration", "begin")
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, b.logger, span, "building begin WebAuthn registration request")
+	}
+
+	return req, nil
+}
+
+// BuildFinishWebAuthnRegistrationRequest builds an HTTP request for finishing the registration of a WebAuthn credential.
+func (b *Builder) BuildFinishWebAuthnRegistrationRequest(ctx context.Context, input *types.WebAuthnRegistrationFinishInput) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		return nil, observability.PrepareError(err, b.logger, span, "validating input")
+	}
+
+	uri := b.BuildURL(ctx, nil, usersBasePath, webAuthnBasePath, "registration", "finish")
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	return b.buildDataRequest(ctx, http.MethodPost, uri, input)
+}
+
+// BuildArchiveWebAuthnCredentialRequest builds an HTTP request for removing one of the requesting user's WebAuthn credentials.
+func (b *Builder) BuildArchiveWebAuthnCredentialRequest(ctx context.Context, webAuthnCredentialID string) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webAuthnCredentialID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := b.logger.WithValue(keys.WebAuthnCredentialIDKey, webAuthnCredentialID)
+	tracing.AttachWebAuthnCredentialIDToSpan(span, webAuthnCredentialID)
+
+	uri := b.BuildURL(ctx, nil, usersBasePath, webAuthnBasePath, webAuthnCredentialsBasePath, webAuthnCredentialID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building archive WebAuthn credential request")
+	}
+
+	return req, nil
+}
+
+// BuildBeginWebAuthnLoginRequest builds an HTTP request for beginning to log in with a WebAuthn credential.
+func (b *Builder) BuildBeginWebAuthnLoginRequest(ctx context.Context, input *types.WebAuthnLoginBeginInput) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	tracing.AttachUsernameToSpan(span, input.Username)
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		return nil, observability.PrepareError(err, b.logger, span, "validating input")
+	}
+
+	uri := b.buildUnversionedURL(ctx, nil, usersBasePath, webAuthnBasePath, "login", "begin")
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	return b.buildDataRequest(ctx, http.MethodPost, uri, input)
+}
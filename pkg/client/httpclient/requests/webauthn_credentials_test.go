@@ -0,0 +1,226 @@
+package requests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func TestBuilder_BuildGetWebAuthnCredentialsRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/users/webauthn/credentials"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		spec := newRequestSpec(true, http.MethodGet, "", expectedPath)
+
+		actual, err := helper.builder.BuildGetWebAuthnCredentialsRequest(helper.ctx)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildGetWebAuthnCredentialsRequest(helper.ctx)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildBeginWebAuthnRegistrationRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/users/webauthn/registration/begin"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		spec := newRequestSpec(true, http.MethodPost, "", expectedPath)
+
+		actual, err := helper.builder.BuildBeginWebAuthnRegistrationRequest(helper.ctx)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildBeginWebAuthnRegistrationRequest(helper.ctx)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildFinishWebAuthnRegistrationRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/users/webauthn/registration/finish"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleInput := &types.WebAuthnRegistrationFinishInput{
+			Name:          t.Name(),
+			CeremonyToken: t.Name(),
+			Credential:    json.RawMessage(`{}`),
+		}
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+
+		actual, err := helper.builder.BuildFinishWebAuthnRegistrationRequest(helper.ctx, exampleInput)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildFinishWebAuthnRegistrationRequest(helper.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildFinishWebAuthnRegistrationRequest(helper.ctx, &types.WebAuthnRegistrationFinishInput{})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+		exampleInput := &types.WebAuthnRegistrationFinishInput{
+			Name:          t.Name(),
+			CeremonyToken: t.Name(),
+			Credential:    json.RawMessage(`{}`),
+		}
+
+		actual, err := helper.builder.BuildFinishWebAuthnRegistrationRequest(helper.ctx, exampleInput)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildArchiveWebAuthnCredentialRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/users/webauthn/credentials/%s"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+
+		spec := newRequestSpec(true, http.MethodDelete, "", expectedPathFormat, exampleCredential.ID)
+
+		actual, err := helper.builder.BuildArchiveWebAuthnCredentialRequest(helper.ctx, exampleCredential.ID)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid WebAuthn credential ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildArchiveWebAuthnCredentialRequest(helper.ctx, "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+		exampleCredential := fakes.BuildFakeWebAuthnCredential()
+
+		actual, err := helper.builder.BuildArchiveWebAuthnCredentialRequest(helper.ctx, exampleCredential.ID)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildBeginWebAuthnLoginRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/users/webauthn/login/begin"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleInput := &types.WebAuthnLoginBeginInput{Username: helper.exampleUser.Username}
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+
+		actual, err := helper.builder.BuildBeginWebAuthnLoginRequest(helper.ctx, exampleInput)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildBeginWebAuthnLoginRequest(helper.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildBeginWebAuthnLoginRequest(helper.ctx, &types.WebAuthnLoginBeginInput{})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+		exampleInput := &types.WebAuthnLoginBeginInput{Username: helper.exampleUser.Username}
+
+		actual, err := helper.builder.BuildBeginWebAuthnLoginRequest(helper.ctx, exampleInput)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
@@ -11,7 +11,10 @@
 )
 
 const (
-	webhooksBasePath = "webhooks"
+	webhooksBasePath      = "webhooks"
+	webhookDeliveriesPath = "deliveries"
+	webhookRedeliveryPath = "redeliver"
+	webhookRotationPath   = "rotate_secret"
 )
 
 // BuildGetWebhookRequest builds an HTTP request for fetching a webhook.
@@ -73,6 +76,29 @@ func (b *Builder) BuildCreateWebhookRequest(ctx context.Context, input *types.We
 	return b.buildDataRequest(ctx, http.MethodPost, uri, input)
 }
 
+// BuildUpdateWebhookRequest builds an HTTP request for updating a webhook.
+func (b *Builder) BuildUpdateWebhookRequest(ctx context.Context, webhook *types.Webhook) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webhook == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	logger := b.logger.WithValue(keys.WebhookIDKey, webhook.ID)
+	tracing.AttachWebhookIDToSpan(span, webhook.ID)
+
+	uri := b.BuildURL(ctx, nil, webhooksBasePath, webhook.ID)
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	req, err := b.buildDataRequest(ctx, http.MethodPut, uri, webhook)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building request")
+	}
+
+	return req, nil
+}
+
 // BuildArchiveWebhookRequest builds an HTTP request for archiving a webhook.
 func (b *Builder) BuildArchiveWebhookRequest(ctx context.Context, webhookID string) (*http.Request, error) {
 	ctx, span := b.tracer.StartSpan(ctx)
@@ -94,3 +120,71 @@ func (b *Builder) BuildArchiveWebhookRequest(ctx context.Context, webhookID stri
 
 	return req, nil
 }
+
+// BuildRotateWebhookSigningSecretRequest builds an HTTP request for rotating a webhook's signing secret.
+func (b *Builder) BuildRotateWebhookSigningSecretRequest(ctx context.Context, webhookID string) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webhookID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := b.logger.WithValue(keys.WebhookIDKey, webhookID)
+	tracing.AttachWebhookIDToSpan(span, webhookID)
+
+	uri := b.BuildURL(ctx, nil, webhooksBasePath, webhookID, webhookRotationPath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building webhook secret rotation request")
+	}
+
+	return req, nil
+}
+
+// BuildGetWebhookDeliveryAttemptsRequest builds an HTTP request for fetching a list of a webhook's delivery attempts.
+func (b *Builder) BuildGetWebhookDeliveryAttemptsRequest(ctx context.Context, webhookID string, filter *types.QueryFilter) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webhookID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := filter.AttachToLogger(b.logger).WithValue(keys.WebhookIDKey, webhookID)
+	tracing.AttachWebhookIDToSpan(span, webhookID)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	uri := b.BuildURL(ctx, filter.ToValues(), webhooksBasePath, webhookID, webhookDeliveriesPath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building webhook delivery attempts request")
+	}
+
+	return req, nil
+}
+
+// BuildRedeliverWebhookDeliveryAttemptRequest builds an HTTP request for redelivering a webhook delivery attempt.
+func (b *Builder) BuildRedeliverWebhookDeliveryAttemptRequest(ctx context.Context, webhookID, attemptID string) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webhookID == "" || attemptID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := b.logger.WithValue(keys.WebhookIDKey, webhookID).WithValue(keys.WebhookDeliveryAttemptIDKey, attemptID)
+	tracing.AttachWebhookIDToSpan(span, webhookID)
+	tracing.AttachWebhookDeliveryAttemptIDToSpan(span, attemptID)
+
+	uri := b.BuildURL(ctx, nil, webhooksBasePath, webhookID, webhookDeliveriesPath, attemptID, webhookRedeliveryPath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building webhook redelivery request")
+	}
+
+	return req, nil
+}
@@ -136,6 +136,48 @@ func TestBuilder_BuildCreateWebhookRequest(T *testing.T) {
 	})
 }
 
+func TestBuilder_BuildUpdateWebhookRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/webhooks/%s"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleWebhook := fakes.BuildFakeWebhook()
+
+		spec := newRequestSpec(false, http.MethodPut, "", expectedPathFormat, exampleWebhook.ID)
+
+		actual, err := helper.builder.BuildUpdateWebhookRequest(helper.ctx, exampleWebhook)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with nil input", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildUpdateWebhookRequest(helper.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+		exampleWebhook := fakes.BuildFakeWebhook()
+
+		actual, err := helper.builder.BuildUpdateWebhookRequest(helper.ctx, exampleWebhook)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
 func TestBuilder_BuildArchiveWebhookRequest(T *testing.T) {
 	T.Parallel()
 
@@ -177,3 +219,138 @@ func TestBuilder_BuildArchiveWebhookRequest(T *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestBuilder_BuildRotateWebhookSigningSecretRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/webhooks/%s/rotate_secret"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleWebhook := fakes.BuildFakeWebhook()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPathFormat, exampleWebhook.ID)
+
+		actual, err := helper.builder.BuildRotateWebhookSigningSecretRequest(helper.ctx, exampleWebhook.ID)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid webhook ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildRotateWebhookSigningSecretRequest(helper.ctx, "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildRotateWebhookSigningSecretRequest(helper.ctx, fakes.BuildFakeID())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildGetWebhookDeliveryAttemptsRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/webhooks/%s/deliveries"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleWebhook := fakes.BuildFakeWebhook()
+
+		spec := newRequestSpec(false, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPathFormat, exampleWebhook.ID)
+
+		actual, err := helper.builder.BuildGetWebhookDeliveryAttemptsRequest(helper.ctx, exampleWebhook.ID, nil)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid webhook ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildGetWebhookDeliveryAttemptsRequest(helper.ctx, "", nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+		exampleWebhook := fakes.BuildFakeWebhook()
+
+		actual, err := helper.builder.BuildGetWebhookDeliveryAttemptsRequest(helper.ctx, exampleWebhook.ID, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func TestBuilder_BuildRedeliverWebhookDeliveryAttemptRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPathFormat = "/api/v1/webhooks/%s/deliveries/%s/redeliver"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		exampleWebhook := fakes.BuildFakeWebhook()
+		exampleAttempt := fakes.BuildFakeWebhookDeliveryAttempt()
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPathFormat, exampleWebhook.ID, exampleAttempt.ID)
+
+		actual, err := helper.builder.BuildRedeliverWebhookDeliveryAttemptRequest(helper.ctx, exampleWebhook.ID, exampleAttempt.ID)
+		assert.NoError(t, err)
+
+		assertRequestQuality(t, actual, spec)
+	})
+
+	T.Run("with invalid webhook ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildRedeliverWebhookDeliveryAttemptRequest(helper.ctx, "", fakes.BuildFakeID())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid delivery attempt ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildRedeliverWebhookDeliveryAttemptRequest(helper.ctx, fakes.BuildFakeID(), "")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	T.Run("with invalid request builder", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+		helper.builder = buildTestRequestBuilderWithInvalidURL()
+
+		actual, err := helper.builder.BuildRedeliverWebhookDeliveryAttemptRequest(helper.ctx, fakes.BuildFakeID(), fakes.BuildFakeID())
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
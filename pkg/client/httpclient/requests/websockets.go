@@ -2,12 +2,18 @@
 
 import (
 	"context"
+	"net/http"
 
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
 const (
 	websocketsBasePath = "websockets"
+
+	// LastEventIDHeader is the header event streams are resumed with.
+	LastEventIDHeader = "Last-Event-ID"
 )
 
 // BuildSubscribeToDataChangesWebsocketURL builds a URL for subscribing to a websocket to receive DataChangeMessages.
@@ -24,3 +30,31 @@ func (b *Builder) BuildSubscribeToDataChangesWebsocketURL(ctx context.Context) s
 
 	return uri
 }
+
+// BuildSubscribeToDataChangeEventsRequest builds an HTTP request for streaming DataChangeMessages as Server-Sent
+// Events. A non-empty lastEventID resumes the stream after that event.
+func (b *Builder) BuildSubscribeToDataChangeEventsRequest(ctx context.Context, filter *types.DataChangeSubscriptionFilter, lastEventID string) (*http.Request, error) {
+	ctx, span := b.tracer.StartSpan(ctx)
+	defer span.End()
+
+	uri := b.BuildURL(
+		ctx,
+		filter.ToValues(),
+		websocketsBasePath,
+		"data_changes",
+		"events",
+	)
+	tracing.AttachRequestURIToSpan(span, uri)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, observability.PrepareError(err, b.logger, span, "building data change event stream request")
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+	if lastEventID != "" {
+		req.Header.Set(LastEventIDHeader, lastEventID)
+	}
+
+	return req, nil
+}
@@ -2,9 +2,13 @@
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
 func TestBuilder_BuildSubscribeToNotificationsURL(T *testing.T) {
@@ -24,3 +28,40 @@ func TestBuilder_BuildSubscribeToNotificationsURL(T *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 }
+
+func TestBuilder_BuildSubscribeToDataChangeEventsRequest(T *testing.T) {
+	T.Parallel()
+
+	const expectedPath = "/api/v1/websockets/data_changes/events"
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		actual, err := helper.builder.BuildSubscribeToDataChangeEventsRequest(helper.ctx, nil, "")
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		spec := newRequestSpec(true, http.MethodGet, "", expectedPath)
+		assertRequestQuality(t, actual, spec)
+		assert.Empty(t, actual.Header.Get(LastEventIDHeader))
+	})
+
+	T.Run("with filter and last event ID", func(t *testing.T) {
+		t.Parallel()
+
+		helper := buildTestHelper()
+
+		exampleFilter := &types.DataChangeSubscriptionFilter{DataTypes: []string{string(types.ItemDataType)}}
+		exampleLastEventID := "12345"
+
+		actual, err := helper.builder.BuildSubscribeToDataChangeEventsRequest(helper.ctx, exampleFilter, exampleLastEventID)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		spec := newRequestSpec(true, http.MethodGet, "dataType=item", expectedPath)
+		assertRequestQuality(t, actual, spec)
+		assert.Equal(t, exampleLastEventID, actual.Header.Get(LastEventIDHeader))
+	})
+}
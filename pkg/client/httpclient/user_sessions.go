@@ -0,0 +1,74 @@
+package httpclient
+
+import (
+	"context"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// GetUserSessions retrieves a list of the requesting user's sessions.
+func (c *Client) GetUserSessions(ctx context.Context, filter *types.QueryFilter) (*types.UserSessionList, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := c.loggerWithFilter(filter)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	req, err := c.requestBuilder.BuildGetUserSessionsRequest(ctx, filter)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building user sessions list request")
+	}
+
+	var userSessions *types.UserSessionList
+	if err = c.fetchAndUnmarshal(ctx, req, &userSessions); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "retrieving user sessions")
+	}
+
+	return userSessions, nil
+}
+
+// RevokeUserSession revokes one of the requesting user's sessions.
+func (c *Client) RevokeUserSession(ctx context.Context, userSessionID string) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if userSessionID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	logger := c.logger.WithValue(keys.UserSessionIDKey, userSessionID)
+	tracing.AttachUserSessionIDToSpan(span, userSessionID)
+
+	req, err := c.requestBuilder.BuildRevokeUserSessionRequest(ctx, userSessionID)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building revoke user session request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, nil); err != nil {
+		return observability.PrepareError(err, logger, span, "revoking user session")
+	}
+
+	return nil
+}
+
+// RevokeOtherUserSessions revokes every one of the requesting user's sessions, save the one in use.
+func (c *Client) RevokeOtherUserSessions(ctx context.Context) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := c.logger
+
+	req, err := c.requestBuilder.BuildRevokeOtherUserSessionsRequest(ctx)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building revoke user sessions request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, nil); err != nil {
+		return observability.PrepareError(err, logger, span, "revoking user sessions")
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func TestUserSessions(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(userSessionsTestSuite))
+}
+
+type userSessionsTestSuite struct {
+	suite.Suite
+
+	ctx                    context.Context
+	exampleUserSession     *types.UserSession
+	exampleUserSessionList *types.UserSessionList
+}
+
+var _ suite.SetupTestSuite = (*userSessionsTestSuite)(nil)
+
+func (s *userSessionsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.exampleUserSession = fakes.BuildFakeUserSession()
+	s.exampleUserSessionList = fakes.BuildFakeUserSessionList()
+
+	// session tokens are never transmitted over the wire.
+	for _, userSession := range s.exampleUserSessionList.UserSessions {
+		userSession.Token = ""
+	}
+}
+
+func (s *userSessionsTestSuite) TestClient_GetUserSessions() {
+	const expectedPath = "/api/v1/users/sessions"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPath)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleUserSessionList)
+
+		actual, err := c.GetUserSessions(s.ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleUserSessionList, actual)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.GetUserSessions(s.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.GetUserSessions(s.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *userSessionsTestSuite) TestClient_RevokeUserSession() {
+	const expectedPathFormat = "/api/v1/users/sessions/%s"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodDelete, "", expectedPathFormat, s.exampleUserSession.ID)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusNoContent)
+
+		assert.NoError(t, c.RevokeUserSession(s.ctx, s.exampleUserSession.ID))
+	})
+
+	s.Run("with invalid user session ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		assert.Error(t, c.RevokeUserSession(s.ctx, ""))
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		assert.Error(t, c.RevokeUserSession(s.ctx, s.exampleUserSession.ID))
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		assert.Error(t, c.RevokeUserSession(s.ctx, s.exampleUserSession.ID))
+	})
+}
+
+func (s *userSessionsTestSuite) TestClient_RevokeOtherUserSessions() {
+	const expectedPath = "/api/v1/users/sessions"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodDelete, "", expectedPath)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusNoContent)
+
+		assert.NoError(t, c.RevokeOtherUserSessions(s.ctx))
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		assert.Error(t, c.RevokeOtherUserSessions(s.ctx))
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		assert.Error(t, c.RevokeOtherUserSessions(s.ctx))
+	})
+}
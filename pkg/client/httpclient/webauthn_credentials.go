@@ -0,0 +1,131 @@
+package httpclient
+
+import (
+	"context"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// GetWebAuthnCredentials retrieves the WebAuthn credentials the requesting user has registered.
+func (c *Client) GetWebAuthnCredentials(ctx context.Context) (*types.WebAuthnCredentialList, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := c.logger
+
+	req, err := c.requestBuilder.BuildGetWebAuthnCredentialsRequest(ctx)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building WebAuthn credentials list request")
+	}
+
+	var credentials *types.WebAuthnCredentialList
+	if err = c.fetchAndUnmarshal(ctx, req, &credentials); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "retrieving WebAuthn credentials")
+	}
+
+	return credentials, nil
+}
+
+// BeginWebAuthnRegistration begins registering a new WebAuthn credential for the requesting user.
+func (c *Client) BeginWebAuthnRegistration(ctx context.Context) (*types.WebAuthnRegistrationOptions, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	logger := c.logger
+
+	req, err := c.requestBuilder.BuildBeginWebAuthnRegistrationRequest(ctx)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building begin WebAuthn registration request")
+	}
+
+	var options *types.WebAuthnRegistrationOptions
+	if err = c.fetchAndUnmarshal(ctx, req, &options); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "beginning WebAuthn registration")
+	}
+
+	return options, nil
+}
+
+// FinishWebAuthnRegistration finishes registering a WebAuthn credential for the requesting user.
+func (c *Client) FinishWebAuthnRegistration(ctx context.Context, input *types.WebAuthnRegistrationFinishInput) (*types.WebAuthnCredential, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	logger := c.logger
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "validating input")
+	}
+
+	req, err := c.requestBuilder.BuildFinishWebAuthnRegistrationRequest(ctx, input)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building finish WebAuthn registration request")
+	}
+
+	var credential *types.WebAuthnCredential
+	if err = c.fetchAndUnmarshal(ctx, req, &credential); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "finishing WebAuthn registration")
+	}
+
+	return credential, nil
+}
+
+// ArchiveWebAuthnCredential removes one of the requesting user's WebAuthn credentials.
+func (c *Client) ArchiveWebAuthnCredential(ctx context.Context, webAuthnCredentialID string) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webAuthnCredentialID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	logger := c.logger.WithValue(keys.WebAuthnCredentialIDKey, webAuthnCredentialID)
+	tracing.AttachWebAuthnCredentialIDToSpan(span, webAuthnCredentialID)
+
+	req, err := c.requestBuilder.BuildArchiveWebAuthnCredentialRequest(ctx, webAuthnCredentialID)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building archive WebAuthn credential request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, nil); err != nil {
+		return observability.PrepareError(err, logger, span, "archiving WebAuthn credential")
+	}
+
+	return nil
+}
+
+// BeginWebAuthnLogin begins logging in with a WebAuthn credential. The resulting assertion and ceremony
+// token are meant to be provided to BeginSession alongside the user's password.
+func (c *Client) BeginWebAuthnLogin(ctx context.Context, input *types.WebAuthnLoginBeginInput) (*types.WebAuthnLoginOptions, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if input == nil {
+		return nil, ErrNilInputProvided
+	}
+
+	logger := c.logger.WithValue(keys.UsernameKey, input.Username)
+
+	if err := input.ValidateWithContext(ctx); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "validating input")
+	}
+
+	req, err := c.requestBuilder.BuildBeginWebAuthnLoginRequest(ctx, input)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building begin WebAuthn login request")
+	}
+
+	var options *types.WebAuthnLoginOptions
+	if err = c.fetchAndUnmarshalWithoutAuthentication(ctx, req, &options); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "beginning WebAuthn login")
+	}
+
+	return options, nil
+}
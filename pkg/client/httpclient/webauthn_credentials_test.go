@@ -0,0 +1,284 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func TestWebAuthnCredentials(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(webAuthnCredentialsTestSuite))
+}
+
+type webAuthnCredentialsTestSuite struct {
+	suite.Suite
+
+	ctx                           context.Context
+	exampleUser                   *types.User
+	exampleWebAuthnCredential     *types.WebAuthnCredential
+	exampleWebAuthnCredentialList *types.WebAuthnCredentialList
+}
+
+var _ suite.SetupTestSuite = (*webAuthnCredentialsTestSuite)(nil)
+
+func (s *webAuthnCredentialsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.exampleUser = fakes.BuildFakeUser()
+	s.exampleWebAuthnCredential = fakes.BuildFakeWebAuthnCredential()
+	s.exampleWebAuthnCredentialList = fakes.BuildFakeWebAuthnCredentialList()
+
+	// public keys are never transmitted over the wire.
+	s.exampleWebAuthnCredential.PublicKey = nil
+	for _, credential := range s.exampleWebAuthnCredentialList.WebAuthnCredentials {
+		credential.PublicKey = nil
+	}
+}
+
+func (s *webAuthnCredentialsTestSuite) TestClient_GetWebAuthnCredentials() {
+	const expectedPath = "/api/v1/users/webauthn/credentials"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodGet, "", expectedPath)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleWebAuthnCredentialList)
+
+		actual, err := c.GetWebAuthnCredentials(s.ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleWebAuthnCredentialList, actual)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.GetWebAuthnCredentials(s.ctx)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.GetWebAuthnCredentials(s.ctx)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *webAuthnCredentialsTestSuite) TestClient_BeginWebAuthnRegistration() {
+	const expectedPath = "/api/v1/users/webauthn/registration/begin"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		exampleOptions := &types.WebAuthnRegistrationOptions{CeremonyToken: t.Name()}
+
+		spec := newRequestSpec(true, http.MethodPost, "", expectedPath)
+		c, _ := buildTestClientWithJSONResponse(t, spec, exampleOptions)
+
+		actual, err := c.BeginWebAuthnRegistration(s.ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleOptions, actual)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.BeginWebAuthnRegistration(s.ctx)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.BeginWebAuthnRegistration(s.ctx)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *webAuthnCredentialsTestSuite) TestClient_FinishWebAuthnRegistration() {
+	const expectedPath = "/api/v1/users/webauthn/registration/finish"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		exampleInput := &types.WebAuthnRegistrationFinishInput{
+			Name:          s.exampleWebAuthnCredential.Name,
+			CeremonyToken: t.Name(),
+			Credential:    json.RawMessage(`{}`),
+		}
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleWebAuthnCredential)
+
+		actual, err := c.FinishWebAuthnRegistration(s.ctx, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, s.exampleWebAuthnCredential, actual)
+	})
+
+	s.Run("with nil input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.FinishWebAuthnRegistration(s.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with invalid input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.FinishWebAuthnRegistration(s.ctx, &types.WebAuthnRegistrationFinishInput{})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		exampleInput := &types.WebAuthnRegistrationFinishInput{
+			Name:          s.exampleWebAuthnCredential.Name,
+			CeremonyToken: t.Name(),
+			Credential:    json.RawMessage(`{}`),
+		}
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.FinishWebAuthnRegistration(s.ctx, exampleInput)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		exampleInput := &types.WebAuthnRegistrationFinishInput{
+			Name:          s.exampleWebAuthnCredential.Name,
+			CeremonyToken: t.Name(),
+			Credential:    json.RawMessage(`{}`),
+		}
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.FinishWebAuthnRegistration(s.ctx, exampleInput)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *webAuthnCredentialsTestSuite) TestClient_ArchiveWebAuthnCredential() {
+	const expectedPathFormat = "/api/v1/users/webauthn/credentials/%s"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(true, http.MethodDelete, "", expectedPathFormat, s.exampleWebAuthnCredential.ID)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusNoContent)
+
+		assert.NoError(t, c.ArchiveWebAuthnCredential(s.ctx, s.exampleWebAuthnCredential.ID))
+	})
+
+	s.Run("with invalid WebAuthn credential ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		assert.Error(t, c.ArchiveWebAuthnCredential(s.ctx, ""))
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		assert.Error(t, c.ArchiveWebAuthnCredential(s.ctx, s.exampleWebAuthnCredential.ID))
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		assert.Error(t, c.ArchiveWebAuthnCredential(s.ctx, s.exampleWebAuthnCredential.ID))
+	})
+}
+
+func (s *webAuthnCredentialsTestSuite) TestClient_BeginWebAuthnLogin() {
+	const expectedPath = "/users/webauthn/login/begin"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		exampleInput := &types.WebAuthnLoginBeginInput{Username: s.exampleUser.Username}
+		exampleOptions := &types.WebAuthnLoginOptions{CeremonyToken: t.Name()}
+
+		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
+		c, _ := buildTestClientWithJSONResponse(t, spec, exampleOptions)
+
+		actual, err := c.BeginWebAuthnLogin(s.ctx, exampleInput)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleOptions, actual)
+	})
+
+	s.Run("with nil input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.BeginWebAuthnLogin(s.ctx, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with invalid input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.BeginWebAuthnLogin(s.ctx, &types.WebAuthnLoginBeginInput{})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.BeginWebAuthnLogin(s.ctx, &types.WebAuthnLoginBeginInput{Username: s.exampleUser.Username})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.BeginWebAuthnLogin(s.ctx, &types.WebAuthnLoginBeginInput{Username: s.exampleUser.Username})
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
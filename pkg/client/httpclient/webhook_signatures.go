@@ -0,0 +1,72 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// DefaultWebhookSignatureTolerance is how old a webhook signature may be before VerifyWebhookRequest rejects it.
+const DefaultWebhookSignatureTolerance = 5 * time.Minute
+
+// VerifyWebhookRequest checks that an incoming webhook request was signed with a given secret within a
+// given tolerance. The request body is left readable for whoever handles the request next.
+func VerifyWebhookRequest(req *http.Request, secret string, tolerance time.Duration) error {
+	if req == nil || req.Body == nil {
+		return ErrNilInputProvided
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return fmt.Errorf("reading webhook request body: %w", err)
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return VerifyWebhookSignature(req.Header, body, secret, tolerance)
+}
+
+// VerifyWebhookSignature checks that a webhook payload was signed with a given secret within a given tolerance.
+func VerifyWebhookSignature(header http.Header, body []byte, secret string, tolerance time.Duration) error {
+	return verifyWebhookSignature(header, body, secret, tolerance, time.Now())
+}
+
+func verifyWebhookSignature(header http.Header, body []byte, secret string, tolerance time.Duration, now time.Time) error {
+	if secret == "" {
+		return ErrEmptyInputProvided
+	}
+
+	rawTimestamp := header.Get(types.WebhookSignatureTimestampHeaderKey)
+	rawSignatures := header.Get(types.WebhookSignatureHeaderKey)
+
+	if rawTimestamp == "" || rawSignatures == "" {
+		return ErrMissingWebhookSignature
+	}
+
+	timestamp, err := strconv.ParseInt(rawTimestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: parsing timestamp: %v", ErrInvalidWebhookSignature, err)
+	}
+
+	if age := now.Sub(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+		return ErrStaleWebhookSignature
+	}
+
+	expected := []byte(types.SignWebhookPayload(secret, timestamp, body))
+
+	// while a secret is being rotated, more than one signature is sent, and any of them will do.
+	for _, signature := range strings.Split(rawSignatures, types.WebhookSignatureSeparator) {
+		if hmac.Equal(expected, []byte(strings.TrimSpace(signature))) {
+			return nil
+		}
+	}
+
+	return ErrInvalidWebhookSignature
+}
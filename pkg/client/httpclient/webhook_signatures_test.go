@@ -0,0 +1,132 @@
+package httpclient
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+func buildSignedWebhookHeader(secret string, timestamp int64, body []byte) http.Header {
+	header := http.Header{}
+	header.Set(types.WebhookSignatureTimestampHeaderKey, strconv.FormatInt(timestamp, 10))
+	header.Set(types.WebhookSignatureHeaderKey, types.SignWebhookPayload(secret, timestamp, body))
+
+	return header
+}
+
+func TestVerifyWebhookRequest(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleSecret := "blahblahblah"
+		exampleBody := []byte(`{"things":"stuff"}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(exampleBody))
+		req.Header = buildSignedWebhookHeader(exampleSecret, time.Now().Unix(), exampleBody)
+
+		assert.NoError(t, VerifyWebhookRequest(req, exampleSecret, DefaultWebhookSignatureTolerance))
+
+		actual, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, exampleBody, actual)
+	})
+
+	T.Run("with nil request", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Error(t, VerifyWebhookRequest(nil, "blah", DefaultWebhookSignatureTolerance))
+	})
+}
+
+func Test_verifyWebhookSignature(T *testing.T) {
+	T.Parallel()
+
+	exampleSecret := "blahblahblah"
+	exampleBody := []byte(`{"things":"stuff"}`)
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		header := buildSignedWebhookHeader(exampleSecret, now.Unix(), exampleBody)
+
+		assert.NoError(t, verifyWebhookSignature(header, exampleBody, exampleSecret, time.Minute, now))
+	})
+
+	T.Run("with multiple signatures", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		header := buildSignedWebhookHeader(exampleSecret, now.Unix(), exampleBody)
+		header.Set(types.WebhookSignatureHeaderKey, strings.Join([]string{
+			types.SignWebhookPayload("other", now.Unix(), exampleBody),
+			header.Get(types.WebhookSignatureHeaderKey),
+		}, types.WebhookSignatureSeparator))
+
+		assert.NoError(t, verifyWebhookSignature(header, exampleBody, exampleSecret, time.Minute, now))
+	})
+
+	T.Run("with empty secret", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		header := buildSignedWebhookHeader(exampleSecret, now.Unix(), exampleBody)
+
+		assert.ErrorIs(t, verifyWebhookSignature(header, exampleBody, "", time.Minute, now), ErrEmptyInputProvided)
+	})
+
+	T.Run("with missing headers", func(t *testing.T) {
+		t.Parallel()
+
+		assert.ErrorIs(t, verifyWebhookSignature(http.Header{}, exampleBody, exampleSecret, time.Minute, time.Now()), ErrMissingWebhookSignature)
+	})
+
+	T.Run("with invalid timestamp", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		header := buildSignedWebhookHeader(exampleSecret, now.Unix(), exampleBody)
+		header.Set(types.WebhookSignatureTimestampHeaderKey, "not a number")
+
+		assert.ErrorIs(t, verifyWebhookSignature(header, exampleBody, exampleSecret, time.Minute, now), ErrInvalidWebhookSignature)
+	})
+
+	T.Run("with stale signature", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		header := buildSignedWebhookHeader(exampleSecret, now.Add(-time.Hour).Unix(), exampleBody)
+
+		assert.ErrorIs(t, verifyWebhookSignature(header, exampleBody, exampleSecret, time.Minute, now), ErrStaleWebhookSignature)
+	})
+
+	T.Run("with tampered body", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		header := buildSignedWebhookHeader(exampleSecret, now.Unix(), exampleBody)
+
+		assert.ErrorIs(t, verifyWebhookSignature(header, []byte(`{"things":"other stuff"}`), exampleSecret, time.Minute, now), ErrInvalidWebhookSignature)
+	})
+
+	T.Run("with wrong secret", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		header := buildSignedWebhookHeader(exampleSecret, now.Unix(), exampleBody)
+
+		assert.ErrorIs(t, verifyWebhookSignature(header, exampleBody, "wrong", time.Minute, now), ErrInvalidWebhookSignature)
+	})
+}
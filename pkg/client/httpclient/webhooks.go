@@ -56,34 +56,58 @@ func (c *Client) GetWebhooks(ctx context.Context, filter *types.QueryFilter) (*t
 }
 
 // CreateWebhook creates a webhook.
-func (c *Client) CreateWebhook(ctx context.Context, input *types.WebhookCreationInput) (string, error) {
+func (c *Client) CreateWebhook(ctx context.Context, input *types.WebhookCreationInput) (*types.WebhookCreationResponse, error) {
 	ctx, span := c.tracer.StartSpan(ctx)
 	defer span.End()
 
 	if input == nil {
-		return "", ErrNilInputProvided
+		return nil, ErrNilInputProvided
 	}
 
 	logger := c.logger.WithValue(keys.NameKey, input.Name)
 	logger.Debug("creating webhook")
 
 	if err := input.ValidateWithContext(ctx); err != nil {
-		return "", observability.PrepareError(err, logger, span, "validating input")
+		return nil, observability.PrepareError(err, logger, span, "validating input")
 	}
 
 	req, err := c.requestBuilder.BuildCreateWebhookRequest(ctx, input)
 	if err != nil {
-		return "", observability.PrepareError(err, logger, span, "building create webhook request")
+		return nil, observability.PrepareError(err, logger, span, "building create webhook request")
 	}
 
-	var pwr *types.PreWriteResponse
-	if err = c.fetchAndUnmarshal(ctx, req, &pwr); err != nil {
-		return "", observability.PrepareError(err, logger, span, "creating webhook")
+	var webhookResponse *types.WebhookCreationResponse
+	if err = c.fetchAndUnmarshal(ctx, req, &webhookResponse); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "creating webhook")
 	}
 
 	logger.Debug("webhook created")
 
-	return pwr.ID, nil
+	return webhookResponse, nil
+}
+
+// UpdateWebhook updates a webhook.
+func (c *Client) UpdateWebhook(ctx context.Context, webhook *types.Webhook) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webhook == nil {
+		return ErrNilInputProvided
+	}
+
+	logger := c.logger.WithValue(keys.WebhookIDKey, webhook.ID)
+	tracing.AttachWebhookIDToSpan(span, webhook.ID)
+
+	req, err := c.requestBuilder.BuildUpdateWebhookRequest(ctx, webhook)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building update webhook request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, &webhook); err != nil {
+		return observability.PrepareError(err, logger, span, "updating webhook %s", webhook.ID)
+	}
+
+	return nil
 }
 
 // ArchiveWebhook archives a webhook.
@@ -108,3 +132,77 @@ func (c *Client) ArchiveWebhook(ctx context.Context, webhookID string) error {
 
 	return nil
 }
+
+// RotateWebhookSigningSecret replaces a webhook's signing secret.
+func (c *Client) RotateWebhookSigningSecret(ctx context.Context, webhookID string) (*types.WebhookSecretRotationResponse, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webhookID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := c.logger.WithValue(keys.WebhookIDKey, webhookID)
+
+	req, err := c.requestBuilder.BuildRotateWebhookSigningSecretRequest(ctx, webhookID)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building webhook secret rotation request")
+	}
+
+	var rotationResponse *types.WebhookSecretRotationResponse
+	if err = c.fetchAndUnmarshal(ctx, req, &rotationResponse); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "rotating webhook signing secret")
+	}
+
+	return rotationResponse, nil
+}
+
+// GetWebhookDeliveryAttempts gets a list of a webhook's delivery attempts.
+func (c *Client) GetWebhookDeliveryAttempts(ctx context.Context, webhookID string, filter *types.QueryFilter) (*types.WebhookDeliveryAttemptList, error) {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webhookID == "" {
+		return nil, ErrInvalidIDProvided
+	}
+
+	logger := c.loggerWithFilter(filter).WithValue(keys.WebhookIDKey, webhookID)
+
+	tracing.AttachWebhookIDToSpan(span, webhookID)
+	tracing.AttachQueryFilterToSpan(span, filter)
+
+	req, err := c.requestBuilder.BuildGetWebhookDeliveryAttemptsRequest(ctx, webhookID, filter)
+	if err != nil {
+		return nil, observability.PrepareError(err, logger, span, "building webhook delivery attempts list request")
+	}
+
+	var attempts *types.WebhookDeliveryAttemptList
+	if err = c.fetchAndUnmarshal(ctx, req, &attempts); err != nil {
+		return nil, observability.PrepareError(err, logger, span, "retrieving webhook delivery attempts")
+	}
+
+	return attempts, nil
+}
+
+// RedeliverWebhookDeliveryAttempt requests that a past webhook delivery attempt be sent again.
+func (c *Client) RedeliverWebhookDeliveryAttempt(ctx context.Context, webhookID, attemptID string) error {
+	ctx, span := c.tracer.StartSpan(ctx)
+	defer span.End()
+
+	if webhookID == "" || attemptID == "" {
+		return ErrInvalidIDProvided
+	}
+
+	logger := c.logger.WithValue(keys.WebhookIDKey, webhookID).WithValue(keys.WebhookDeliveryAttemptIDKey, attemptID)
+
+	req, err := c.requestBuilder.BuildRedeliverWebhookDeliveryAttemptRequest(ctx, webhookID, attemptID)
+	if err != nil {
+		return observability.PrepareError(err, logger, span, "building webhook redelivery request")
+	}
+
+	if err = c.fetchAndUnmarshal(ctx, req, nil); err != nil {
+		return observability.PrepareError(err, logger, span, "redelivering webhook")
+	}
+
+	return nil
+}
@@ -31,7 +31,12 @@ type webhooksTestSuite struct {
 func (s *webhooksTestSuite) SetupTest() {
 	s.ctx = context.Background()
 	s.exampleWebhook = fakes.BuildFakeWebhook()
+	s.exampleWebhook.SigningSecret = ""
 	s.exampleWebhookList = fakes.BuildFakeWebhookList()
+
+	for i := 0; i < len(s.exampleWebhookList.Webhooks); i++ {
+		s.exampleWebhookList.Webhooks[i].SigningSecret = ""
+	}
 }
 
 func (s *webhooksTestSuite) TestClient_GetWebhook() {
@@ -125,11 +130,15 @@ func (s *webhooksTestSuite) TestClient_CreateWebhook() {
 		exampleInput.BelongsToAccount = ""
 
 		spec := newRequestSpec(false, http.MethodPost, "", expectedPath)
-		c, _ := buildTestClientWithJSONResponse(t, spec, &types.PreWriteResponse{ID: s.exampleWebhook.ID})
+		exampleResponse := &types.WebhookCreationResponse{
+			ID:            s.exampleWebhook.ID,
+			SigningSecret: fakes.BuildFakeWebhook().SigningSecret,
+		}
+		c, _ := buildTestClientWithJSONResponse(t, spec, exampleResponse)
 
 		actual, err := c.CreateWebhook(s.ctx, exampleInput)
 		assert.NoError(t, err)
-		assert.Equal(t, s.exampleWebhook.ID, actual)
+		assert.Equal(t, exampleResponse, actual)
 	})
 
 	s.Run("with nil input", func() {
@@ -175,6 +184,47 @@ func (s *webhooksTestSuite) TestClient_CreateWebhook() {
 	})
 }
 
+func (s *webhooksTestSuite) TestClient_UpdateWebhook() {
+	const expectedPathFormat = "/api/v1/webhooks/%s"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		spec := newRequestSpec(false, http.MethodPut, "", expectedPathFormat, s.exampleWebhook.ID)
+		c, _ := buildTestClientWithJSONResponse(t, spec, s.exampleWebhook)
+
+		err := c.UpdateWebhook(s.ctx, s.exampleWebhook)
+		assert.NoError(t, err)
+	})
+
+	s.Run("with nil input", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		err := c.UpdateWebhook(s.ctx, nil)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		err := c.UpdateWebhook(s.ctx, s.exampleWebhook)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		err := c.UpdateWebhook(s.ctx, s.exampleWebhook)
+		assert.Error(t, err)
+	})
+}
+
 func (s *webhooksTestSuite) TestClient_ArchiveWebhook() {
 	const expectedPathFormat = "/api/v1/webhooks/%s"
 
@@ -215,3 +265,152 @@ func (s *webhooksTestSuite) TestClient_ArchiveWebhook() {
 		assert.Error(t, err)
 	})
 }
+
+func (s *webhooksTestSuite) TestClient_RotateWebhookSigningSecret() {
+	const expectedPathFormat = "/api/v1/webhooks/%s/rotate_secret"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		exampleResponse := &types.WebhookSecretRotationResponse{
+			SigningSecret:                  fakes.BuildFakeWebhook().SigningSecret,
+			PreviousSigningSecretExpiresOn: 123456789,
+		}
+
+		spec := newRequestSpec(true, http.MethodPost, "", expectedPathFormat, s.exampleWebhook.ID)
+		c, _ := buildTestClientWithJSONResponse(t, spec, exampleResponse)
+
+		actual, err := c.RotateWebhookSigningSecret(s.ctx, s.exampleWebhook.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleResponse, actual)
+	})
+
+	s.Run("with invalid webhook ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.RotateWebhookSigningSecret(s.ctx, "")
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.RotateWebhookSigningSecret(s.ctx, s.exampleWebhook.ID)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.RotateWebhookSigningSecret(s.ctx, s.exampleWebhook.ID)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func (s *webhooksTestSuite) TestClient_GetWebhookDeliveryAttempts() {
+	const expectedPathFormat = "/api/v1/webhooks/%s/deliveries"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		exampleAttemptList := fakes.BuildFakeWebhookDeliveryAttemptList()
+
+		spec := newRequestSpec(false, http.MethodGet, "includeArchived=false&limit=20&page=1&sortBy=asc", expectedPathFormat, s.exampleWebhook.ID)
+		c, _ := buildTestClientWithJSONResponse(t, spec, exampleAttemptList)
+
+		actual, err := c.GetWebhookDeliveryAttempts(s.ctx, s.exampleWebhook.ID, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleAttemptList, actual)
+	})
+
+	s.Run("with invalid webhook ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		actual, err := c.GetWebhookDeliveryAttempts(s.ctx, "", nil)
+		assert.Error(t, err)
+		assert.Nil(t, actual)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		actual, err := c.GetWebhookDeliveryAttempts(s.ctx, s.exampleWebhook.ID, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		actual, err := c.GetWebhookDeliveryAttempts(s.ctx, s.exampleWebhook.ID, nil)
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+	})
+}
+
+func (s *webhooksTestSuite) TestClient_RedeliverWebhookDeliveryAttempt() {
+	const expectedPathFormat = "/api/v1/webhooks/%s/deliveries/%s/redeliver"
+
+	s.Run("standard", func() {
+		t := s.T()
+
+		exampleAttempt := fakes.BuildFakeWebhookDeliveryAttempt()
+
+		spec := newRequestSpec(true, http.MethodPost, "", expectedPathFormat, s.exampleWebhook.ID, exampleAttempt.ID)
+		c, _ := buildTestClientWithStatusCodeResponse(t, spec, http.StatusAccepted)
+
+		err := c.RedeliverWebhookDeliveryAttempt(s.ctx, s.exampleWebhook.ID, exampleAttempt.ID)
+		assert.NoError(t, err)
+	})
+
+	s.Run("with invalid webhook ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		err := c.RedeliverWebhookDeliveryAttempt(s.ctx, "", fakes.BuildFakeID())
+		assert.Error(t, err)
+	})
+
+	s.Run("with invalid delivery attempt ID", func() {
+		t := s.T()
+
+		c, _ := buildSimpleTestClient(t)
+
+		err := c.RedeliverWebhookDeliveryAttempt(s.ctx, s.exampleWebhook.ID, "")
+		assert.Error(t, err)
+	})
+
+	s.Run("with error building request", func() {
+		t := s.T()
+
+		c := buildTestClientWithInvalidURL(t)
+
+		err := c.RedeliverWebhookDeliveryAttempt(s.ctx, s.exampleWebhook.ID, fakes.BuildFakeID())
+		assert.Error(t, err)
+	})
+
+	s.Run("with error executing request", func() {
+		t := s.T()
+
+		c, _ := buildTestClientThatWaitsTooLong(t)
+
+		err := c.RedeliverWebhookDeliveryAttempt(s.ctx, s.exampleWebhook.ID, fakes.BuildFakeID())
+		assert.Error(t, err)
+	})
+}
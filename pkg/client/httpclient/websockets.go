@@ -7,8 +7,9 @@
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 )
 
-// SubscribeToDataChangeNotifications subscribes to a websocket to receive DataChangeMessages.
-func (c *Client) SubscribeToDataChangeNotifications(ctx context.Context, stopChan <-chan bool) (chan *types.DataChangeMessage, error) {
+// SubscribeToDataChangeNotifications subscribes to a websocket to receive DataChangeMessages. A nil filter
+// subscribes to every change the user is allowed to see.
+func (c *Client) SubscribeToDataChangeNotifications(ctx context.Context, filter *types.DataChangeSubscriptionFilter, stopChan <-chan bool) (chan *types.DataChangeMessage, error) {
 	ctx, span := c.tracer.StartSpan(ctx)
 	defer span.End()
 
@@ -17,6 +18,12 @@ func (c *Client) SubscribeToDataChangeNotifications(ctx context.Context, stopCha
 	}
 
 	logger := c.logger
+
+	if filter != nil {
+		if err := filter.ValidateWithContext(ctx); err != nil {
+			return nil, observability.PrepareError(err, logger, span, "validating subscription filter")
+		}
+	}
 	uri := c.requestBuilder.BuildSubscribeToDataChangesWebsocketURL(ctx)
 
 	header, err := c.authHeaderBuilder.BuildRequestHeaders(ctx)
@@ -29,6 +36,12 @@ func (c *Client) SubscribeToDataChangeNotifications(ctx context.Context, stopCha
 		return nil, observability.PrepareError(err, logger, span, "dialing websocket")
 	}
 
+	if filter != nil {
+		if err = conn.WriteJSON(filter); err != nil {
+			return nil, observability.PrepareError(err, logger, span, "sending subscription filter")
+		}
+	}
+
 	dataChangeMessages := make(chan *types.DataChangeMessage)
 	go func() {
 		for {
@@ -48,7 +61,7 @@ func (c *Client) SubscribeToDataChangeNotifications(ctx context.Context, stopCha
 					return
 				}
 
-				if msg != nil {
+				if msg != nil && filter.Matches(msg) {
 					dataChangeMessages <- msg
 				}
 			}
@@ -35,6 +35,7 @@
 		ID                         string                   `json:"id"`
 		Members                    []*AccountUserMembership `json:"members"`
 		CreatedOn                  uint64                   `json:"createdOn"`
+		Version                    uint64                   `json:"version"`
 	}
 
 	// AccountList represents a list of accounts.
@@ -84,19 +85,36 @@
 		ReadHandler(res http.ResponseWriter, req *http.Request)
 		UpdateHandler(res http.ResponseWriter, req *http.Request)
 		ArchiveHandler(res http.ResponseWriter, req *http.Request)
-		AddMemberHandler(res http.ResponseWriter, req *http.Request)
 		RemoveMemberHandler(res http.ResponseWriter, req *http.Request)
 		MarkAsDefaultAccountHandler(res http.ResponseWriter, req *http.Request)
 		ModifyMemberPermissionsHandler(res http.ResponseWriter, req *http.Request)
 		TransferAccountOwnershipHandler(res http.ResponseWriter, req *http.Request)
+		InviteMemberHandler(res http.ResponseWriter, req *http.Request)
+		ListPendingInvitationsHandler(res http.ResponseWriter, req *http.Request)
+		RevokeInvitationHandler(res http.ResponseWriter, req *http.Request)
+		ListReceivedInvitationsHandler(res http.ResponseWriter, req *http.Request)
+		ClaimInvitationHandler(res http.ResponseWriter, req *http.Request)
+		AcceptInvitationHandler(res http.ResponseWriter, req *http.Request)
+		RejectInvitationHandler(res http.ResponseWriter, req *http.Request)
+
+		InviteUserToAccount(ctx context.Context, accountID, inviterID string, input *AccountInvitationCreationRequestInput) (*AccountInvitation, error)
+		ClaimAccountInvitation(ctx context.Context, userID, token string) (*AccountInvitation, error)
+		AcceptAccountInvitation(ctx context.Context, userID, accountInvitationID string) error
+		RejectAccountInvitation(ctx context.Context, userID, accountInvitationID string) error
+		RevokeAccountInvitation(ctx context.Context, accountID, requesterID, accountInvitationID string) error
 	}
 )
 
-// Update merges an AccountUpdateInput with an account.
-func (x *Account) Update(input *AccountUpdateInput) {
+// Update merges an AccountUpdateInput with an account, and returns a summary of what changed.
+func (x *Account) Update(input *AccountUpdateInput) []*FieldChangeSummary {
+	var out []*FieldChangeSummary
+
 	if input.Name != "" && input.Name != x.Name {
+		out = append(out, &FieldChangeSummary{FieldName: "name", OldValue: x.Name, NewValue: input.Name})
 		x.Name = input.Name
 	}
+
+	return out
 }
 
 var _ validation.ValidatableWithContext = (*AccountCreationInput)(nil)
@@ -121,6 +139,7 @@ func (x *AccountUpdateInput) ValidateWithContext(ctx context.Context) error {
 func AccountCreationInputForNewUser(u *User) *AccountCreationInput {
 	return &AccountCreationInput{
 		Name:          fmt.Sprintf("%s_default", u.Username),
+		ContactEmail:  u.EmailAddress,
 		BelongsToUser: u.ID,
 	}
 }
@@ -0,0 +1,136 @@
+package types
+
+import (
+	"context"
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	// PendingAccountInvitationStatus indicates an invitation has yet to be acted upon.
+	PendingAccountInvitationStatus AccountInvitationStatus = "pending"
+	// AcceptedAccountInvitationStatus indicates an invitation was accepted by its recipient.
+	AcceptedAccountInvitationStatus AccountInvitationStatus = "accepted"
+	// RejectedAccountInvitationStatus indicates an invitation was rejected by its recipient.
+	RejectedAccountInvitationStatus AccountInvitationStatus = "rejected"
+	// RevokedAccountInvitationStatus indicates an invitation was revoked by the account that sent it.
+	RevokedAccountInvitationStatus AccountInvitationStatus = "revoked"
+)
+
+var errInvitationRecipientRequired = errors.New("exactly one of email address or username must be provided")
+
+type (
+	// AccountInvitationStatus is the type to use/compare against when checking invitation status.
+	AccountInvitationStatus string
+
+	// AccountInvitation represents an invitation for a user to join an account.
+	AccountInvitation struct {
+		_ struct{}
+
+		LastUpdatedOn      *uint64                 `json:"lastUpdatedOn"`
+		ArchivedOn         *uint64                 `json:"archivedOn"`
+		ToUser             *string                 `json:"toUser"`
+		ID                 string                  `json:"id"`
+		FromUser           string                  `json:"fromUser"`
+		ToEmail            string                  `json:"toEmail"`
+		DestinationAccount string                  `json:"destinationAccount"`
+		Note               string                  `json:"note"`
+		Status             AccountInvitationStatus `json:"status"`
+		AccountRoles       []string                `json:"accountRoles"`
+		ExpiresAt          uint64                  `json:"expiresAt"`
+		CreatedOn          uint64                  `json:"createdOn"`
+	}
+
+	// AccountInvitationList represents a list of account invitations.
+	AccountInvitationList struct {
+		_ struct{}
+
+		AccountInvitations []*AccountInvitation `json:"accountInvitations"`
+		Pagination
+	}
+
+	// AccountInvitationCreationRequestInput represents what a user could set as input for inviting someone to an account.
+	AccountInvitationCreationRequestInput struct {
+		_ struct{}
+
+		ToEmail      string   `json:"toEmail"`
+		ToUsername   string   `json:"toUsername"`
+		Note         string   `json:"note"`
+		AccountRoles []string `json:"accountRoles"`
+	}
+
+	// AccountInvitationClaimInput represents what a user provides to claim an invitation sent to their email address.
+	AccountInvitationClaimInput struct {
+		_ struct{}
+
+		Token string `json:"token"`
+	}
+
+	// AccountInvitationDatabaseCreationInput is used for creating an account invitation. Invitations sent by email
+	// carry the hash of the token that was emailed, since nobody can be presumed to own an address until they use it.
+	AccountInvitationDatabaseCreationInput struct {
+		_ struct{}
+
+		ToUser             *string
+		ID                 string
+		FromUser           string
+		ToEmail            string
+		TokenHash          string
+		DestinationAccount string
+		Note               string
+		AccountRoles       []string
+		ExpiresAt          uint64
+	}
+
+	// AccountInvitationDataManager describes a structure capable of storing account invitations permanently.
+	AccountInvitationDataManager interface {
+		GetAccountInvitation(ctx context.Context, accountInvitationID string) (*AccountInvitation, error)
+		GetPendingAccountInvitationsForAccount(ctx context.Context, accountID string, filter *QueryFilter) (*AccountInvitationList, error)
+		GetPendingAccountInvitationsForUser(ctx context.Context, userID string) (*AccountInvitationList, error)
+		CreateAccountInvitation(ctx context.Context, input *AccountInvitationDatabaseCreationInput) (*AccountInvitation, error)
+		ClaimAccountInvitation(ctx context.Context, tokenHash, userID string) (*AccountInvitation, error)
+		SetAccountInvitationStatus(ctx context.Context, accountInvitationID string, status AccountInvitationStatus) error
+	}
+)
+
+// IsExpired returns whether an AccountInvitation's expiry is before the provided time.
+func (x *AccountInvitation) IsExpired(now uint64) bool {
+	return x.ExpiresAt <= now
+}
+
+var _ validation.ValidatableWithContext = (*AccountInvitationCreationRequestInput)(nil)
+
+// ValidateWithContext validates an AccountInvitationCreationRequestInput.
+func (x *AccountInvitationCreationRequestInput) ValidateWithContext(ctx context.Context) error {
+	if (x.ToEmail == "") == (x.ToUsername == "") {
+		return errInvitationRecipientRequired
+	}
+
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.ToEmail, emailAddressRule),
+		validation.Field(&x.AccountRoles, validation.Required),
+	)
+}
+
+var _ validation.ValidatableWithContext = (*AccountInvitationClaimInput)(nil)
+
+// ValidateWithContext validates an AccountInvitationClaimInput.
+func (x *AccountInvitationClaimInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.Token, validation.Required),
+	)
+}
+
+var _ validation.ValidatableWithContext = (*AccountInvitationDatabaseCreationInput)(nil)
+
+// ValidateWithContext validates an AccountInvitationDatabaseCreationInput.
+func (x *AccountInvitationDatabaseCreationInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.ID, validation.Required),
+		validation.Field(&x.FromUser, validation.Required),
+		validation.Field(&x.DestinationAccount, validation.Required),
+		validation.Field(&x.AccountRoles, validation.Required),
+		validation.Field(&x.ExpiresAt, validation.Required),
+	)
+}
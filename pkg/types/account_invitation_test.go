@@ -0,0 +1,150 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountInvitation_IsExpired(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &AccountInvitation{ExpiresAt: 100}
+
+		assert.False(t, x.IsExpired(99))
+		assert.True(t, x.IsExpired(100))
+		assert.True(t, x.IsExpired(101))
+	})
+}
+
+func TestAccountInvitationCreationRequestInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with email address", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &AccountInvitationCreationRequestInput{
+			ToEmail:      "things@stuff.com",
+			AccountRoles: []string{t.Name()},
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("with username", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &AccountInvitationCreationRequestInput{
+			ToUsername:   t.Name(),
+			AccountRoles: []string{t.Name()},
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("with neither email address nor username", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &AccountInvitationCreationRequestInput{
+			AccountRoles: []string{t.Name()},
+		}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("with both email address and username", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &AccountInvitationCreationRequestInput{
+			ToEmail:      "things@stuff.com",
+			ToUsername:   t.Name(),
+			AccountRoles: []string{t.Name()},
+		}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid email address", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &AccountInvitationCreationRequestInput{
+			ToEmail:      t.Name(),
+			AccountRoles: []string{t.Name()},
+		}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("without roles", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &AccountInvitationCreationRequestInput{
+			ToUsername: t.Name(),
+		}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+}
+
+func TestAccountInvitationClaimInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &AccountInvitationClaimInput{
+			Token: t.Name(),
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("without token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &AccountInvitationClaimInput{}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+}
+
+func TestAccountInvitationDatabaseCreationInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &AccountInvitationDatabaseCreationInput{
+			ID:                 t.Name(),
+			FromUser:           t.Name(),
+			ToEmail:            "things@stuff.com",
+			DestinationAccount: t.Name(),
+			AccountRoles:       []string{t.Name()},
+			ExpiresAt:          123,
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &AccountInvitationDatabaseCreationInput{}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+}
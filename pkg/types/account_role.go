@@ -0,0 +1,127 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+)
+
+var (
+	errInvalidAccountPermission = errors.New("not a valid account permission")
+
+	// accountPermissionRule requires a value be one of the account permissions.
+	accountPermissionRule = validation.By(func(value interface{}) error {
+		if p, ok := value.(string); !ok || !authorization.IsAccountPermission(p) {
+			return errInvalidAccountPermission
+		}
+		return nil
+	})
+)
+
+type (
+	// AccountRole represents a role an account has defined for its members, made up of a set of account permissions.
+	AccountRole struct {
+		_ struct{}
+
+		LastUpdatedOn    *uint64  `json:"lastUpdatedOn"`
+		ArchivedOn       *uint64  `json:"archivedOn"`
+		ID               string   `json:"id"`
+		Name             string   `json:"name"`
+		Description      string   `json:"description"`
+		BelongsToAccount string   `json:"belongsToAccount"`
+		Permissions      []string `json:"permissions"`
+		CreatedOn        uint64   `json:"createdOn"`
+	}
+
+	// AccountRoleList represents a list of account roles.
+	AccountRoleList struct {
+		_ struct{}
+
+		AccountRoles []*AccountRole `json:"accountRoles"`
+		Pagination
+	}
+
+	// AccountRoleCreationInput represents what a user could set as input for creating account roles.
+	AccountRoleCreationInput struct {
+		_ struct{}
+
+		ID               string   `json:"-"`
+		Name             string   `json:"name"`
+		Description      string   `json:"description"`
+		BelongsToAccount string   `json:"-"`
+		Permissions      []string `json:"permissions"`
+	}
+
+	// AccountRoleUpdateInput represents what a user could set as input for updating account roles.
+	AccountRoleUpdateInput struct {
+		_ struct{}
+
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Permissions []string `json:"permissions"`
+	}
+
+	// AccountRoleDataManager describes a structure capable of storing account roles permanently.
+	AccountRoleDataManager interface {
+		GetAccountRole(ctx context.Context, accountRoleID, accountID string) (*AccountRole, error)
+		GetAccountRoles(ctx context.Context, accountID string, filter *QueryFilter) (*AccountRoleList, error)
+		CreateAccountRole(ctx context.Context, input *AccountRoleCreationInput) (*AccountRole, error)
+		UpdateAccountRole(ctx context.Context, updated *AccountRole) error
+		ArchiveAccountRole(ctx context.Context, accountRoleID, accountID string) error
+	}
+
+	// AccountRoleDataService describes a structure capable of serving traffic related to account roles.
+	AccountRoleDataService interface {
+		ListHandler(res http.ResponseWriter, req *http.Request)
+		CreateHandler(res http.ResponseWriter, req *http.Request)
+		ReadHandler(res http.ResponseWriter, req *http.Request)
+		UpdateHandler(res http.ResponseWriter, req *http.Request)
+		ArchiveHandler(res http.ResponseWriter, req *http.Request)
+	}
+)
+
+// Update merges an AccountRoleUpdateInput with an account role, and returns a summary of what changed.
+func (x *AccountRole) Update(input *AccountRoleUpdateInput) []*FieldChangeSummary {
+	var out []*FieldChangeSummary
+
+	if input.Name != "" && input.Name != x.Name {
+		out = append(out, &FieldChangeSummary{FieldName: "name", OldValue: x.Name, NewValue: input.Name})
+		x.Name = input.Name
+	}
+
+	if input.Description != "" && input.Description != x.Description {
+		out = append(out, &FieldChangeSummary{FieldName: "description", OldValue: x.Description, NewValue: input.Description})
+		x.Description = input.Description
+	}
+
+	if len(input.Permissions) > 0 {
+		out = append(out, &FieldChangeSummary{FieldName: "permissions", OldValue: x.Permissions, NewValue: input.Permissions})
+		x.Permissions = input.Permissions
+	}
+
+	return out
+}
+
+var _ validation.ValidatableWithContext = (*AccountRoleCreationInput)(nil)
+
+// ValidateWithContext validates an AccountRoleCreationInput.
+func (x *AccountRoleCreationInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.Name, validation.Required),
+		validation.Field(&x.Permissions, validation.Required, validation.Each(accountPermissionRule)),
+	)
+}
+
+var _ validation.ValidatableWithContext = (*AccountRoleUpdateInput)(nil)
+
+// ValidateWithContext validates an AccountRoleUpdateInput.
+func (x *AccountRoleUpdateInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.Name, validation.Required),
+		validation.Field(&x.Permissions, validation.Each(accountPermissionRule)),
+	)
+}
@@ -0,0 +1,110 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/stretchr/testify/assert"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+)
+
+func TestAccountRole_Update(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &AccountRole{
+			Name:        fake.Word(),
+			Permissions: []string{authorization.ReadItemsPermission.ID()},
+		}
+		input := &AccountRoleUpdateInput{
+			Name:        fake.Word(),
+			Description: fake.Sentence(4),
+			Permissions: []string{authorization.ReadItemsPermission.ID(), authorization.SearchItemsPermission.ID()},
+		}
+
+		changes := x.Update(input)
+		assert.Len(t, changes, 3)
+		assert.Equal(t, input.Name, x.Name)
+		assert.Equal(t, input.Description, x.Description)
+		assert.Equal(t, input.Permissions, x.Permissions)
+	})
+
+	T.Run("without permissions", func(t *testing.T) {
+		t.Parallel()
+
+		expected := []string{authorization.ReadItemsPermission.ID()}
+		x := &AccountRole{
+			Name:        fake.Word(),
+			Permissions: expected,
+		}
+
+		x.Update(&AccountRoleUpdateInput{Name: fake.Word()})
+		assert.Equal(t, expected, x.Permissions)
+	})
+}
+
+func TestAccountRoleCreationInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &AccountRoleCreationInput{
+			Name:        fake.Word(),
+			Permissions: []string{authorization.ReadItemsPermission.ID(), authorization.CreateWebhooksPermission.ID()},
+		}
+
+		assert.NoError(t, x.ValidateWithContext(context.Background()))
+	})
+
+	T.Run("without permissions", func(t *testing.T) {
+		t.Parallel()
+
+		x := &AccountRoleCreationInput{
+			Name: fake.Word(),
+		}
+
+		assert.Error(t, x.ValidateWithContext(context.Background()))
+	})
+
+	T.Run("with service permission", func(t *testing.T) {
+		t.Parallel()
+
+		x := &AccountRoleCreationInput{
+			Name:        fake.Word(),
+			Permissions: []string{authorization.CycleCookieSecretPermission.ID()},
+		}
+
+		assert.Error(t, x.ValidateWithContext(context.Background()))
+	})
+}
+
+func TestAccountRoleUpdateInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &AccountRoleUpdateInput{
+			Name:        fake.Word(),
+			Permissions: []string{authorization.ReadItemsPermission.ID()},
+		}
+
+		assert.NoError(t, x.ValidateWithContext(context.Background()))
+	})
+
+	T.Run("with invalid permission", func(t *testing.T) {
+		t.Parallel()
+
+		x := &AccountRoleUpdateInput{
+			Name:        fake.Word(),
+			Permissions: []string{"fake.permission"},
+		}
+
+		assert.Error(t, x.ValidateWithContext(context.Background()))
+	})
+}
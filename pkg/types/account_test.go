@@ -14,7 +14,10 @@ func TestAccount_Update(T *testing.T) {
 		t.Parallel()
 
 		x := &Account{}
-		x.Update(&AccountUpdateInput{Name: t.Name()})
+		changes := x.Update(&AccountUpdateInput{Name: t.Name()})
+
+		assert.Equal(t, t.Name(), x.Name)
+		assert.Equal(t, []*FieldChangeSummary{{FieldName: "name", OldValue: "", NewValue: t.Name()}}, changes)
 	})
 }
 
@@ -11,6 +11,20 @@
 	// AdminService describes a structure capable of serving traffic related to users.
 	AdminService interface {
 		UserReputationChangeHandler(res http.ResponseWriter, req *http.Request)
+		SearchReindexHandler(res http.ResponseWriter, req *http.Request)
+		RevokeUserSessionsHandler(res http.ResponseWriter, req *http.Request)
+	}
+
+	// SearchReindexReport describes the outcome of rebuilding a search index from the database.
+	SearchReindexReport struct {
+		_ struct{}
+
+		MissingFromIndex []string `json:"missingFromIndex"`
+		OrphanedInIndex  []string `json:"orphanedInIndex"`
+		IndexName        string   `json:"indexName"`
+		IndexedCount     uint64   `json:"indexedCount"`
+		Compared         bool     `json:"compared"`
+		Swapped          bool     `json:"swapped"`
 	}
 
 	// UserReputationUpdateInput represents what an admin User could provide as input for changing statuses.
@@ -2,24 +2,36 @@
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+)
+
+var (
+	errInvalidServicePermission = errors.New("not a valid service permission")
 )
 
 type (
-	// APIClient represents a user-authorized API client.
+	// APIClient represents a user-authorized API client. An API client with no permissions
+	// or admin permissions acts with the full authority of its owner, and an API client with
+	// no accounts can act within any account its owner belongs to.
 	APIClient struct {
 		_ struct{}
 
-		LastUpdatedOn *uint64 `json:"lastUpdatedOn"`
-		ArchivedOn    *uint64 `json:"archivedOn"`
-		Name          string  `json:"name"`
-		ClientID      string  `json:"clientID"`
-		ID            string  `json:"id"`
-		BelongsToUser string  `json:"belongsToUser"`
-		ClientSecret  []byte  `json:"-"`
-		CreatedOn     uint64  `json:"createdOn"`
+		LastUpdatedOn    *uint64  `json:"lastUpdatedOn"`
+		ArchivedOn       *uint64  `json:"archivedOn"`
+		Name             string   `json:"name"`
+		ClientID         string   `json:"clientID"`
+		ID               string   `json:"id"`
+		BelongsToUser    string   `json:"belongsToUser"`
+		Permissions      []string `json:"permissions"`
+		AdminPermissions []string `json:"adminPermissions"`
+		Accounts         []string `json:"accounts"`
+		ClientSecret     []byte   `json:"-"`
+		CreatedOn        uint64   `json:"createdOn"`
 	}
 
 	// APIClientList is a response struct containing a list of API clients.
@@ -35,11 +47,14 @@
 		_ struct{}
 
 		UserLoginInput
-		ID            string `json:"-"`
-		Name          string `json:"clientName"`
-		ClientID      string `json:"-"`
-		BelongsToUser string `json:"-"`
-		ClientSecret  []byte `json:"-"`
+		ID               string   `json:"-"`
+		Name             string   `json:"clientName"`
+		ClientID         string   `json:"-"`
+		BelongsToUser    string   `json:"-"`
+		Permissions      []string `json:"permissions"`
+		AdminPermissions []string `json:"adminPermissions"`
+		Accounts         []string `json:"accounts"`
+		ClientSecret     []byte   `json:"-"`
 	}
 
 	// APIClientCreationResponse is a struct for informing users of what their API client's secret key is.
@@ -78,5 +93,18 @@ func (x *APIClientCreationInput) ValidateWithContext(ctx context.Context, minUse
 
 	return validation.ValidateStructWithContext(ctx, x,
 		validation.Field(&x.Name, validation.Required),
+		validation.Field(&x.Permissions, validation.Each(accountPermissionRule)),
+		validation.Field(&x.AdminPermissions, validation.Each(validation.By(func(value interface{}) error {
+			if p, ok := value.(string); !ok || !authorization.IsServicePermission(p) {
+				return errInvalidServicePermission
+			}
+			return nil
+		}))),
+		validation.Field(&x.Accounts, validation.Each(validation.Required)),
 	)
 }
+
+// IsScoped returns whether an API client is limited to a subset of its owner's permissions.
+func (x *APIClient) IsScoped() bool {
+	return len(x.Permissions) > 0 || len(x.AdminPermissions) > 0
+}
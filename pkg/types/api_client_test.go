@@ -5,6 +5,8 @@
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
 )
 
 func TestAPIClientCreationInput_Validate(T *testing.T) {
@@ -41,4 +43,69 @@ func TestAPIClientCreationInput_Validate(T *testing.T) {
 
 		assert.Error(t, x.ValidateWithContext(ctx, 1, 1))
 	})
+	T.Run("with scopes", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &APIClientCreationInput{
+			UserLoginInput: UserLoginInput{
+				Username:  t.Name(),
+				Password:  t.Name(),
+				TOTPToken: "123456",
+			},
+			Name:             t.Name(),
+			Permissions:      []string{authorization.ReadItemsPermission.ID()},
+			AdminPermissions: []string{authorization.ReadUserPermission.ID()},
+			Accounts:         []string{t.Name()},
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx, 1, 1))
+	})
+
+	T.Run("with invalid permission", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &APIClientCreationInput{
+			UserLoginInput: UserLoginInput{
+				Username:  t.Name(),
+				Password:  t.Name(),
+				TOTPToken: "123456",
+			},
+			Name:        t.Name(),
+			Permissions: []string{authorization.ReadUserPermission.ID()},
+		}
+
+		assert.Error(t, x.ValidateWithContext(ctx, 1, 1))
+	})
+
+	T.Run("with invalid admin permission", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &APIClientCreationInput{
+			UserLoginInput: UserLoginInput{
+				Username:  t.Name(),
+				Password:  t.Name(),
+				TOTPToken: "123456",
+			},
+			Name:             t.Name(),
+			AdminPermissions: []string{authorization.ReadItemsPermission.ID()},
+		}
+
+		assert.Error(t, x.ValidateWithContext(ctx, 1, 1))
+	})
+}
+
+func TestAPIClient_IsScoped(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, (&APIClient{}).IsScoped())
+		assert.False(t, (&APIClient{Accounts: []string{t.Name()}}).IsScoped())
+		assert.True(t, (&APIClient{Permissions: []string{authorization.ReadItemsPermission.ID()}}).IsScoped())
+		assert.True(t, (&APIClient{AdminPermissions: []string{authorization.ReadUserPermission.ID()}}).IsScoped())
+	})
 }
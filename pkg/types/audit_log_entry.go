@@ -0,0 +1,273 @@
+package types
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/keys"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+)
+
+const (
+	// AccountCreationEvent is the event type used to indicate an account was created.
+	AccountCreationEvent = "account_created"
+	// AccountUpdateEvent is the event type used to indicate an account was updated.
+	AccountUpdateEvent = "account_updated"
+	// AccountArchiveEvent is the event type used to indicate an account was archived.
+	AccountArchiveEvent = "account_archived"
+	// AccountOwnershipTransferEvent is the event type used to indicate an account was transferred to a new owner.
+	AccountOwnershipTransferEvent = "account_ownership_transferred"
+	// AccountMemberAddedEvent is the event type used to indicate a user was added to an account.
+	AccountMemberAddedEvent = "account_member_added"
+	// AccountMemberRemovedEvent is the event type used to indicate a user was removed from an account.
+	AccountMemberRemovedEvent = "account_member_removed"
+	// AccountMemberPermissionsModifiedEvent is the event type used to indicate a member's roles were changed.
+	AccountMemberPermissionsModifiedEvent = "account_member_permissions_modified"
+	// AccountInvitationCreationEvent is the event type used to indicate a user was invited to an account.
+	AccountInvitationCreationEvent = "account_invitation_created"
+	// AccountInvitationClaimedEvent is the event type used to indicate an invitation sent by email was claimed by a user.
+	AccountInvitationClaimedEvent = "account_invitation_claimed"
+	// AccountInvitationAcceptedEvent is the event type used to indicate an account invitation was accepted.
+	AccountInvitationAcceptedEvent = "account_invitation_accepted"
+	// AccountInvitationRejectedEvent is the event type used to indicate an account invitation was rejected.
+	AccountInvitationRejectedEvent = "account_invitation_rejected"
+	// AccountInvitationRevokedEvent is the event type used to indicate an account invitation was revoked.
+	AccountInvitationRevokedEvent = "account_invitation_revoked"
+	// AccountRoleCreationEvent is the event type used to indicate an account role was created.
+	AccountRoleCreationEvent = "account_role_created"
+	// AccountRoleUpdateEvent is the event type used to indicate an account role was updated.
+	AccountRoleUpdateEvent = "account_role_updated"
+	// AccountRoleArchiveEvent is the event type used to indicate an account role was archived.
+	AccountRoleArchiveEvent = "account_role_archived"
+	// APIClientCreationEvent is the event type used to indicate an API client was created.
+	APIClientCreationEvent = "api_client_created"
+	// APIClientArchiveEvent is the event type used to indicate an API client was archived.
+	APIClientArchiveEvent = "api_client_archived"
+	// ItemCreationEvent is the event type used to indicate an item was created.
+	ItemCreationEvent = "item_created"
+	// ItemUpdateEvent is the event type used to indicate an item was updated.
+	ItemUpdateEvent = "item_updated"
+	// ItemArchiveEvent is the event type used to indicate an item was archived.
+	ItemArchiveEvent = "item_archived"
+	// OIDCIdentityLinkedEvent is the event type used to indicate an identity provider's subject was linked to a user.
+	OIDCIdentityLinkedEvent = "oidc_identity_linked"
+	// UserCreationEvent is the event type used to indicate a user was created.
+	UserCreationEvent = "user_created"
+	// UserReputationChangeEvent is the event type used to indicate a user's reputation was changed.
+	UserReputationChangeEvent = "user_reputation_changed"
+	// UserPasswordChangeEvent is the event type used to indicate a user changed their password.
+	UserPasswordChangeEvent = "user_password_changed"
+	// UserPasswordResetEvent is the event type used to indicate a user reset their password with a reset token.
+	UserPasswordResetEvent = "user_password_reset"
+	// UserTwoFactorSecretRecoveryEvent is the event type used to indicate a user redeemed a TOTP recovery code.
+	UserTwoFactorSecretRecoveryEvent = "user_two_factor_secret_recovered"
+	// UserTwoFactorSecretChangeEvent is the event type used to indicate a user changed their two factor secret.
+	UserTwoFactorSecretChangeEvent = "user_two_factor_secret_changed"
+	// UserSessionRevokedEvent is the event type used to indicate a user session was revoked.
+	UserSessionRevokedEvent = "user_session_revoked"
+	// UserSessionsRevokedEvent is the event type used to indicate many of a user's sessions were revoked at once.
+	UserSessionsRevokedEvent = "user_sessions_revoked"
+	// UserArchiveEvent is the event type used to indicate a user was archived.
+	UserArchiveEvent = "user_archived"
+	// WebAuthnCredentialCreationEvent is the event type used to indicate a user registered a WebAuthn credential.
+	WebAuthnCredentialCreationEvent = "webauthn_credential_created"
+	// WebAuthnCredentialArchiveEvent is the event type used to indicate a user removed a WebAuthn credential.
+	WebAuthnCredentialArchiveEvent = "webauthn_credential_archived"
+	// WebhookCreationEvent is the event type used to indicate a webhook was created.
+	WebhookCreationEvent = "webhook_created"
+	// WebhookUpdateEvent is the event type used to indicate a webhook was updated.
+	WebhookUpdateEvent = "webhook_updated"
+	// WebhookArchiveEvent is the event type used to indicate a webhook was archived.
+	WebhookArchiveEvent = "webhook_archived"
+	// WebhookSecretRotationEvent is the event type used to indicate a webhook's signing secret was rotated.
+	WebhookSecretRotationEvent = "webhook_secret_rotated"
+
+	// AccountResourceType is the resource type used for account audit log entries.
+	AccountResourceType = "account"
+	// AccountInvitationResourceType is the resource type used for account invitation audit log entries.
+	AccountInvitationResourceType = "account_invitation"
+	// AccountRoleResourceType is the resource type used for account role audit log entries.
+	AccountRoleResourceType = "account_role"
+	// APIClientResourceType is the resource type used for API client audit log entries.
+	APIClientResourceType = "api_client"
+	// ItemResourceType is the resource type used for item audit log entries.
+	ItemResourceType = "item"
+	// OIDCIdentityResourceType is the resource type used for OIDC identity audit log entries.
+	OIDCIdentityResourceType = "oidc_identity"
+	// UserResourceType is the resource type used for user audit log entries.
+	UserResourceType = "user"
+	// UserSessionResourceType is the resource type used for user session audit log entries.
+	UserSessionResourceType = "user_session"
+	// WebAuthnCredentialResourceType is the resource type used for WebAuthn credential audit log entries.
+	WebAuthnCredentialResourceType = "webauthn_credential"
+	// WebhookResourceType is the resource type used for webhook audit log entries.
+	WebhookResourceType = "webhook"
+
+	eventTypeQueryKey    = "eventType"
+	actorUserIDQueryKey  = "actor"
+	resourceTypeQueryKey = "resourceType"
+	resourceIDQueryKey   = "resourceID"
+)
+
+type (
+	// FieldChangeSummary represents a field that changed on a resource, and what it changed from and to.
+	FieldChangeSummary struct {
+		_ struct{}
+
+		OldValue  interface{} `json:"oldValue"`
+		NewValue  interface{} `json:"newValue"`
+		FieldName string      `json:"fieldName"`
+	}
+
+	// AuditLogEntry represents a record of a mutating operation performed by a user.
+	AuditLogEntry struct {
+		_ struct{}
+
+		ID               string                `json:"id"`
+		EventType        string                `json:"eventType"`
+		ActorUserID      string                `json:"actorUserID"`
+		BelongsToAccount string                `json:"belongsToAccount"`
+		ResourceType     string                `json:"resourceType"`
+		ResourceID       string                `json:"resourceID"`
+		RequestID        string                `json:"requestID"`
+		Changes          []*FieldChangeSummary `json:"changes"`
+		CreatedOn        uint64                `json:"createdOn"`
+	}
+
+	// AuditLogEntryList represents a list of audit log entries.
+	AuditLogEntryList struct {
+		_ struct{}
+
+		Entries []*AuditLogEntry `json:"entries"`
+		Pagination
+	}
+
+	// AuditLogEntryCreationInput represents what is needed to record an audit log entry.
+	AuditLogEntryCreationInput struct {
+		_ struct{}
+
+		ID               string                `json:"-"`
+		EventType        string                `json:"eventType"`
+		ActorUserID      string                `json:"actorUserID"`
+		BelongsToAccount string                `json:"belongsToAccount"`
+		ResourceType     string                `json:"resourceType"`
+		ResourceID       string                `json:"resourceID"`
+		RequestID        string                `json:"requestID"`
+		Changes          []*FieldChangeSummary `json:"changes"`
+	}
+
+	// AuditLogEntryQueryFilter represents all the filters a user could apply to an audit log query.
+	AuditLogEntryQueryFilter struct {
+		_ struct{}
+
+		EventType    string `json:"eventType,omitempty"`
+		ActorUserID  string `json:"actor,omitempty"`
+		ResourceType string `json:"resourceType,omitempty"`
+		ResourceID   string `json:"resourceID,omitempty"`
+		QueryFilter
+	}
+
+	// AuditLogEntryDataManager describes a structure capable of storing audit log entries permanently.
+	// Entries are never updated or removed once written.
+	AuditLogEntryDataManager interface {
+		GetAuditLogEntries(ctx context.Context, filter *AuditLogEntryQueryFilter) (*AuditLogEntryList, error)
+		GetAuditLogEntriesForAccount(ctx context.Context, accountID string, filter *AuditLogEntryQueryFilter) (*AuditLogEntryList, error)
+		CreateAuditLogEntry(ctx context.Context, input *AuditLogEntryCreationInput) error
+	}
+
+	// AuditLogEntryDataService describes a structure capable of serving traffic related to audit log entries.
+	AuditLogEntryDataService interface {
+		ListHandler(res http.ResponseWriter, req *http.Request)
+		ListForAccountHandler(res http.ResponseWriter, req *http.Request)
+	}
+)
+
+var _ validation.ValidatableWithContext = (*AuditLogEntryCreationInput)(nil)
+
+// ValidateWithContext validates an AuditLogEntryCreationInput.
+func (x *AuditLogEntryCreationInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.EventType, validation.Required),
+		validation.Field(&x.ResourceType, validation.Required),
+	)
+}
+
+// DefaultAuditLogEntryQueryFilter builds the default audit log query filter.
+func DefaultAuditLogEntryQueryFilter() *AuditLogEntryQueryFilter {
+	return &AuditLogEntryQueryFilter{QueryFilter: *DefaultQueryFilter()}
+}
+
+// AttachToLogger attaches an AuditLogEntryQueryFilter's values to a logging.Logger.
+func (qf *AuditLogEntryQueryFilter) AttachToLogger(logger logging.Logger) logging.Logger {
+	if qf == nil {
+		return logging.EnsureLogger(logger).Clone().WithValue(keys.FilterIsNilKey, true)
+	}
+
+	l := qf.QueryFilter.AttachToLogger(logger)
+
+	if qf.EventType != "" {
+		l = l.WithValue(eventTypeQueryKey, qf.EventType)
+	}
+
+	if qf.ActorUserID != "" {
+		l = l.WithValue(actorUserIDQueryKey, qf.ActorUserID)
+	}
+
+	if qf.ResourceType != "" {
+		l = l.WithValue(resourceTypeQueryKey, qf.ResourceType)
+	}
+
+	if qf.ResourceID != "" {
+		l = l.WithValue(resourceIDQueryKey, qf.ResourceID)
+	}
+
+	return l
+}
+
+// FromParams overrides the AuditLogEntryQueryFilter values with values retrieved from url.Params.
+func (qf *AuditLogEntryQueryFilter) FromParams(params url.Values) {
+	qf.QueryFilter.FromParams(params)
+
+	qf.EventType = params.Get(eventTypeQueryKey)
+	qf.ActorUserID = params.Get(actorUserIDQueryKey)
+	qf.ResourceType = params.Get(resourceTypeQueryKey)
+	qf.ResourceID = params.Get(resourceIDQueryKey)
+}
+
+// ToValues returns a url.Values from an AuditLogEntryQueryFilter.
+func (qf *AuditLogEntryQueryFilter) ToValues() url.Values {
+	if qf == nil {
+		return DefaultAuditLogEntryQueryFilter().ToValues()
+	}
+
+	v := qf.QueryFilter.ToValues()
+
+	if qf.EventType != "" {
+		v.Set(eventTypeQueryKey, qf.EventType)
+	}
+
+	if qf.ActorUserID != "" {
+		v.Set(actorUserIDQueryKey, qf.ActorUserID)
+	}
+
+	if qf.ResourceType != "" {
+		v.Set(resourceTypeQueryKey, qf.ResourceType)
+	}
+
+	if qf.ResourceID != "" {
+		v.Set(resourceIDQueryKey, qf.ResourceID)
+	}
+
+	return v
+}
+
+// ExtractAuditLogEntryQueryFilter can extract an AuditLogEntryQueryFilter from a request.
+func ExtractAuditLogEntryQueryFilter(req *http.Request) *AuditLogEntryQueryFilter {
+	qf := &AuditLogEntryQueryFilter{}
+	qf.FromParams(req.URL.Query())
+
+	return qf
+}
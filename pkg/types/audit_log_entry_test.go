@@ -0,0 +1,138 @@
+package types
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/logging"
+)
+
+func TestAuditLogEntryCreationInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &AuditLogEntryCreationInput{
+			EventType:    ItemCreationEvent,
+			ResourceType: ItemResourceType,
+			ResourceID:   fake.UUID(),
+		}
+
+		assert.NoError(t, x.ValidateWithContext(context.Background()))
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		x := &AuditLogEntryCreationInput{}
+
+		assert.Error(t, x.ValidateWithContext(context.Background()))
+	})
+}
+
+func TestAuditLogEntryQueryFilter_AttachToLogger(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		qf := &AuditLogEntryQueryFilter{
+			EventType:    ItemCreationEvent,
+			ActorUserID:  fake.UUID(),
+			ResourceType: ItemResourceType,
+			ResourceID:   fake.UUID(),
+			QueryFilter:  *DefaultQueryFilter(),
+		}
+
+		assert.NotNil(t, qf.AttachToLogger(logging.NewNoopLogger()))
+	})
+
+	T.Run("with nil", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NotNil(t, (*AuditLogEntryQueryFilter)(nil).AttachToLogger(nil))
+	})
+}
+
+func TestAuditLogEntryQueryFilter_FromParams(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		expected := &AuditLogEntryQueryFilter{
+			EventType:    ItemCreationEvent,
+			ActorUserID:  fake.UUID(),
+			ResourceType: ItemResourceType,
+			ResourceID:   fake.UUID(),
+			QueryFilter: QueryFilter{
+				Page:   2,
+				Limit:  MaxLimit,
+				SortBy: SortDescending,
+			},
+		}
+
+		actual := &AuditLogEntryQueryFilter{}
+		actual.FromParams(expected.ToValues())
+
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func TestAuditLogEntryQueryFilter_ToValues(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		qf := &AuditLogEntryQueryFilter{
+			EventType:   ItemUpdateEvent,
+			QueryFilter: *DefaultQueryFilter(),
+		}
+
+		actual := qf.ToValues()
+
+		assert.Equal(t, ItemUpdateEvent, actual.Get(eventTypeQueryKey))
+		assert.Equal(t, "20", actual.Get(LimitQueryKey))
+		assert.Empty(t, actual.Get(actorUserIDQueryKey))
+	})
+
+	T.Run("with nil", func(t *testing.T) {
+		t.Parallel()
+
+		expected := DefaultAuditLogEntryQueryFilter().ToValues()
+
+		assert.Equal(t, expected, (*AuditLogEntryQueryFilter)(nil).ToValues())
+	})
+}
+
+func TestExtractAuditLogEntryQueryFilter(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleActorID := fake.UUID()
+		params := url.Values{
+			actorUserIDQueryKey:  []string{exampleActorID},
+			resourceTypeQueryKey: []string{WebhookResourceType},
+		}
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://verygoodsoftwarenotvirus.ru", nil)
+		require.NoError(t, err)
+		req.URL.RawQuery = params.Encode()
+
+		actual := ExtractAuditLogEntryQueryFilter(req)
+
+		assert.Equal(t, exampleActorID, actual.ActorUserID)
+		assert.Equal(t, WebhookResourceType, actual.ResourceType)
+		assert.Empty(t, actual.EventType)
+	})
+}
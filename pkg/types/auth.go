@@ -47,6 +47,7 @@ func init() {
 		AccountPermissions map[string]authorization.AccountRolePermissionsChecker `json:"-"`
 		Requester          RequesterInfo                                          `json:"-"`
 		ActiveAccountID    string                                                 `json:"-"`
+		UserSessionID      string                                                 `json:"-"`
 	}
 
 	// RequesterInfo contains data relevant to the user making a request.
@@ -57,6 +58,7 @@ func init() {
 		Reputation            accountStatus                              `json:"-"`
 		ReputationExplanation string                                     `json:"-"`
 		UserID                string                                     `json:"-"`
+		Username              string                                     `json:"-"`
 	}
 
 	// UserStatusResponse is what we encode when the frontend wants to check auth status.
@@ -102,6 +104,16 @@ func init() {
 		CycleCookieSecretHandler(res http.ResponseWriter, req *http.Request)
 		PASETOHandler(res http.ResponseWriter, req *http.Request)
 		ChangeActiveAccountHandler(res http.ResponseWriter, req *http.Request)
+		ListUserSessionsHandler(res http.ResponseWriter, req *http.Request)
+		RevokeUserSessionHandler(res http.ResponseWriter, req *http.Request)
+		RevokeOtherUserSessionsHandler(res http.ResponseWriter, req *http.Request)
+		ListWebAuthnCredentialsHandler(res http.ResponseWriter, req *http.Request)
+		BeginWebAuthnRegistrationHandler(res http.ResponseWriter, req *http.Request)
+		FinishWebAuthnRegistrationHandler(res http.ResponseWriter, req *http.Request)
+		ArchiveWebAuthnCredentialHandler(res http.ResponseWriter, req *http.Request)
+		BeginWebAuthnLoginHandler(res http.ResponseWriter, req *http.Request)
+		BeginOIDCLoginHandler(res http.ResponseWriter, req *http.Request)
+		OIDCCallbackHandler(res http.ResponseWriter, req *http.Request)
 
 		PermissionFilterMiddleware(permissions ...authorization.Permission) func(next http.Handler) http.Handler
 		CookieRequirementMiddleware(next http.Handler) http.Handler
@@ -109,7 +121,7 @@ func init() {
 		AuthorizationMiddleware(next http.Handler) http.Handler
 		ServiceAdminMiddleware(next http.Handler) http.Handler
 
-		AuthenticateUser(ctx context.Context, loginData *UserLoginInput) (*User, *http.Cookie, error)
+		AuthenticateUser(ctx context.Context, req *http.Request, loginData *UserLoginInput) (*User, *http.Cookie, error)
 		LogoutUser(ctx context.Context, sessionCtxData *SessionContextData, req *http.Request, res http.ResponseWriter) error
 	}
 )
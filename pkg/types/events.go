@@ -1,5 +1,22 @@
 package types
 
+const (
+	// CreatedMessageType indicates a piece of data was created.
+	CreatedMessageType = "created"
+	// UpdatedMessageType indicates a piece of data was updated.
+	UpdatedMessageType = "updated"
+	// ArchivedMessageType indicates a piece of data was archived.
+	ArchivedMessageType = "archived"
+	// WebhookRedeliveryMessageType indicates a past webhook delivery attempt should be sent again.
+	WebhookRedeliveryMessageType = "webhook_redelivery"
+	// OwnershipTransferredMessageType indicates an account was handed to a new owner.
+	OwnershipTransferredMessageType = "ownership_transferred"
+	// ConflictMessageType indicates an update was not written because the data had changed since it was read.
+	ConflictMessageType = "conflict"
+	// RevokedMessageType indicates a piece of data can no longer be used.
+	RevokedMessageType = "revoked"
+)
+
 type (
 	dataType string
 
@@ -13,16 +30,20 @@
 		UserMembership          *AddUserToAccountInput        `json:"user_membership"`
 		AttributableToUserID    string                        `json:"attributableToUserID"`
 		AttributableToAccountID string                        `json:"attributeToAccountID"`
+		RequestID               string                        `json:"requestID,omitempty"`
 	}
 
 	// PreUpdateMessage represents an event that asks a worker to update data to the datastore.
 	PreUpdateMessage struct {
 		_ struct{}
 
-		DataType                dataType `json:"dataType"`
-		Item                    *Item    `json:"item,omitempty"`
-		AttributableToUserID    string   `json:"attributableToUserID"`
-		AttributableToAccountID string   `json:"attributeToAccountID"`
+		DataType                dataType              `json:"dataType"`
+		Item                    *Item                 `json:"item,omitempty"`
+		Webhook                 *Webhook              `json:"webhook,omitempty"`
+		Changes                 []*FieldChangeSummary `json:"changes,omitempty"`
+		AttributableToUserID    string                `json:"attributableToUserID"`
+		AttributableToAccountID string                `json:"attributeToAccountID"`
+		RequestID               string                `json:"requestID,omitempty"`
 	}
 
 	// PreArchiveMessage represents an event that asks a worker to archive data to the datastore.
@@ -33,19 +54,26 @@
 		RelevantID              string   `json:"relevantID"`
 		AttributableToUserID    string   `json:"attributableToUserID"`
 		AttributableToAccountID string   `json:"attributeToAccountID"`
+		RequestID               string   `json:"requestID,omitempty"`
 	}
 
 	// DataChangeMessage represents an event that asks a worker to write data to the datastore.
 	DataChangeMessage struct {
 		_ struct{}
 
-		MessageType             string                 `json:"messageType"`
-		DataType                dataType               `json:"dataType"`
-		Item                    *Item                  `json:"item,omitempty"`
-		Webhook                 *Webhook               `json:"webhook,omitempty"`
-		UserMembership          *AccountUserMembership `json:"user_membership"`
-		Context                 map[string]string      `json:"context"`
-		AttributableToUserID    string                 `json:"attributableToUserID"`
-		AttributableToAccountID string                 `json:"attributeToAccountID"`
+		MessageType             string                         `json:"messageType"`
+		DataType                dataType                       `json:"dataType"`
+		Item                    *Item                          `json:"item,omitempty"`
+		Webhook                 *Webhook                       `json:"webhook,omitempty"`
+		WebhookDeliveryAttempt  *WebhookDeliveryAttempt        `json:"webhookDeliveryAttempt,omitempty"`
+		UserMembership          *AccountUserMembership         `json:"user_membership"`
+		OwnershipTransfer       *AccountOwnershipTransferInput `json:"ownershipTransfer,omitempty"`
+		Notification            *Notification                  `json:"notification,omitempty"`
+		ItemPresence            *ItemPresence                  `json:"itemPresence,omitempty"`
+		UserSessionRevocation   *UserSessionRevocation         `json:"userSessionRevocation,omitempty"`
+		Context                 map[string]string              `json:"context" xml:"-"`
+		RelevantID              string                         `json:"relevantID,omitempty"`
+		AttributableToUserID    string                         `json:"attributableToUserID"`
+		AttributableToAccountID string                         `json:"attributeToAccountID"`
 	}
 )
@@ -19,6 +19,7 @@ func BuildFakeAccount() *types.Account {
 		CreatedOn:                  uint64(uint32(fake.Date().Unix())),
 		BelongsToUser:              fake.UUID(),
 		Members:                    BuildFakeAccountUserMembershipList().AccountUserMemberships,
+		Version:                    1,
 	}
 }
 
@@ -29,6 +30,7 @@ func BuildFakeAccountForUser(u *types.User) *types.Account {
 		Name:          u.Username,
 		CreatedOn:     uint64(uint32(fake.Date().Unix())),
 		BelongsToUser: u.ID,
+		Version:       1,
 	}
 }
 
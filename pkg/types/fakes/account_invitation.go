@@ -0,0 +1,72 @@
+package fakes
+
+import (
+	"strings"
+	"time"
+
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// BuildFakeAccountInvitation builds a faked account invitation.
+func BuildFakeAccountInvitation() *types.AccountInvitation {
+	createdOn := uint64(uint32(fake.Date().Unix()))
+	toUser := ksuid.New().String()
+
+	return &types.AccountInvitation{
+		ID:                 ksuid.New().String(),
+		FromUser:           ksuid.New().String(),
+		ToUser:             &toUser,
+		ToEmail:            strings.ToLower(fake.Email()),
+		DestinationAccount: ksuid.New().String(),
+		Note:               fake.Sentence(6),
+		Status:             types.PendingAccountInvitationStatus,
+		AccountRoles:       []string{authorization.AccountMemberRole.String()},
+		ExpiresAt:          createdOn + uint64((7 * 24 * time.Hour).Seconds()),
+		CreatedOn:          createdOn,
+	}
+}
+
+// BuildFakeAccountInvitationList builds a faked AccountInvitationList.
+func BuildFakeAccountInvitationList() *types.AccountInvitationList {
+	var examples []*types.AccountInvitation
+	for i := 0; i < exampleQuantity; i++ {
+		examples = append(examples, BuildFakeAccountInvitation())
+	}
+
+	return &types.AccountInvitationList{
+		Pagination: types.Pagination{
+			Page:          1,
+			Limit:         20,
+			FilteredCount: exampleQuantity / 2,
+			TotalCount:    exampleQuantity,
+		},
+		AccountInvitations: examples,
+	}
+}
+
+// BuildFakeAccountInvitationCreationRequestInput builds a faked AccountInvitationCreationRequestInput.
+func BuildFakeAccountInvitationCreationRequestInput() *types.AccountInvitationCreationRequestInput {
+	return &types.AccountInvitationCreationRequestInput{
+		ToUsername:   fake.Username(),
+		Note:         fake.Sentence(6),
+		AccountRoles: []string{authorization.AccountMemberRole.String()},
+	}
+}
+
+// BuildFakeAccountInvitationDatabaseCreationInputFromAccountInvitation builds a faked AccountInvitationDatabaseCreationInput from an account invitation.
+func BuildFakeAccountInvitationDatabaseCreationInputFromAccountInvitation(invitation *types.AccountInvitation) *types.AccountInvitationDatabaseCreationInput {
+	return &types.AccountInvitationDatabaseCreationInput{
+		ID:                 invitation.ID,
+		FromUser:           invitation.FromUser,
+		ToUser:             invitation.ToUser,
+		ToEmail:            invitation.ToEmail,
+		DestinationAccount: invitation.DestinationAccount,
+		Note:               invitation.Note,
+		AccountRoles:       invitation.AccountRoles,
+		ExpiresAt:          invitation.ExpiresAt,
+	}
+}
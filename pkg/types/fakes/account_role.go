@@ -0,0 +1,74 @@
+package fakes
+
+import (
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// BuildFakeAccountRole builds a faked account role.
+func BuildFakeAccountRole() *types.AccountRole {
+	return &types.AccountRole{
+		ID:          ksuid.New().String(),
+		Name:        fake.Word(),
+		Description: fake.Sentence(6),
+		Permissions: []string{
+			authorization.ReadItemsPermission.ID(),
+			authorization.SearchItemsPermission.ID(),
+		},
+		CreatedOn:        uint64(uint32(fake.Date().Unix())),
+		BelongsToAccount: fake.UUID(),
+	}
+}
+
+// BuildFakeAccountRoleList builds a faked AccountRoleList.
+func BuildFakeAccountRoleList() *types.AccountRoleList {
+	var examples []*types.AccountRole
+	for i := 0; i < exampleQuantity; i++ {
+		examples = append(examples, BuildFakeAccountRole())
+	}
+
+	return &types.AccountRoleList{
+		Pagination: types.Pagination{
+			Page:          1,
+			Limit:         20,
+			FilteredCount: exampleQuantity / 2,
+			TotalCount:    exampleQuantity,
+		},
+		AccountRoles: examples,
+	}
+}
+
+// BuildFakeAccountRoleUpdateInput builds a faked AccountRoleUpdateInput.
+func BuildFakeAccountRoleUpdateInput() *types.AccountRoleUpdateInput {
+	accountRole := BuildFakeAccountRole()
+	return BuildFakeAccountRoleUpdateInputFromAccountRole(accountRole)
+}
+
+// BuildFakeAccountRoleUpdateInputFromAccountRole builds a faked AccountRoleUpdateInput from an account role.
+func BuildFakeAccountRoleUpdateInputFromAccountRole(accountRole *types.AccountRole) *types.AccountRoleUpdateInput {
+	return &types.AccountRoleUpdateInput{
+		Name:        accountRole.Name,
+		Description: accountRole.Description,
+		Permissions: accountRole.Permissions,
+	}
+}
+
+// BuildFakeAccountRoleCreationInput builds a faked AccountRoleCreationInput.
+func BuildFakeAccountRoleCreationInput() *types.AccountRoleCreationInput {
+	accountRole := BuildFakeAccountRole()
+	return BuildFakeAccountRoleCreationInputFromAccountRole(accountRole)
+}
+
+// BuildFakeAccountRoleCreationInputFromAccountRole builds a faked AccountRoleCreationInput from an account role.
+func BuildFakeAccountRoleCreationInputFromAccountRole(accountRole *types.AccountRole) *types.AccountRoleCreationInput {
+	return &types.AccountRoleCreationInput{
+		ID:               accountRole.ID,
+		Name:             accountRole.Name,
+		Description:      accountRole.Description,
+		Permissions:      accountRole.Permissions,
+		BelongsToAccount: accountRole.BelongsToAccount,
+	}
+}
@@ -73,9 +73,12 @@ func BuildFakeAPIClientCreationInputFromClient(client *types.APIClient) *types.A
 			Password:  fake.Password(true, true, true, true, true, 32),
 			TOTPToken: fmt.Sprintf("0%s", fake.Zip()),
 		},
-		Name:          client.Name,
-		ClientID:      client.ClientID,
-		ClientSecret:  client.ClientSecret,
-		BelongsToUser: client.BelongsToUser,
+		Name:             client.Name,
+		ClientID:         client.ClientID,
+		ClientSecret:     client.ClientSecret,
+		BelongsToUser:    client.BelongsToUser,
+		Permissions:      client.Permissions,
+		AdminPermissions: client.AdminPermissions,
+		Accounts:         client.Accounts,
 	}
 }
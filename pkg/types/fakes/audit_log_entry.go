@@ -0,0 +1,66 @@
+package fakes
+
+import (
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// BuildFakeAuditLogEntry builds a faked audit log entry.
+func BuildFakeAuditLogEntry() *types.AuditLogEntry {
+	return &types.AuditLogEntry{
+		ID:               ksuid.New().String(),
+		EventType:        types.ItemUpdateEvent,
+		ActorUserID:      fake.UUID(),
+		BelongsToAccount: fake.UUID(),
+		ResourceType:     types.ItemResourceType,
+		ResourceID:       fake.UUID(),
+		RequestID:        fake.UUID(),
+		Changes: []*types.FieldChangeSummary{
+			{
+				FieldName: "name",
+				OldValue:  fake.Word(),
+				NewValue:  fake.Word(),
+			},
+		},
+		CreatedOn: uint64(uint32(fake.Date().Unix())),
+	}
+}
+
+// BuildFakeAuditLogEntryList builds a faked AuditLogEntryList.
+func BuildFakeAuditLogEntryList() *types.AuditLogEntryList {
+	var examples []*types.AuditLogEntry
+	for i := 0; i < exampleQuantity; i++ {
+		examples = append(examples, BuildFakeAuditLogEntry())
+	}
+
+	return &types.AuditLogEntryList{
+		Pagination: types.Pagination{
+			Page:          1,
+			Limit:         20,
+			FilteredCount: exampleQuantity / 2,
+			TotalCount:    exampleQuantity,
+		},
+		Entries: examples,
+	}
+}
+
+// BuildFakeAuditLogEntryCreationInput builds a faked AuditLogEntryCreationInput.
+func BuildFakeAuditLogEntryCreationInput() *types.AuditLogEntryCreationInput {
+	return BuildFakeAuditLogEntryCreationInputFromAuditLogEntry(BuildFakeAuditLogEntry())
+}
+
+// BuildFakeAuditLogEntryCreationInputFromAuditLogEntry builds a faked AuditLogEntryCreationInput from an audit log entry.
+func BuildFakeAuditLogEntryCreationInputFromAuditLogEntry(entry *types.AuditLogEntry) *types.AuditLogEntryCreationInput {
+	return &types.AuditLogEntryCreationInput{
+		ID:               entry.ID,
+		EventType:        entry.EventType,
+		ActorUserID:      entry.ActorUserID,
+		BelongsToAccount: entry.BelongsToAccount,
+		ResourceType:     entry.ResourceType,
+		ResourceID:       entry.ResourceID,
+		RequestID:        entry.RequestID,
+		Changes:          entry.Changes,
+	}
+}
@@ -15,6 +15,7 @@ func BuildFakeItem() *types.Item {
 		Details:          fake.Word(),
 		CreatedOn:        uint64(uint32(fake.Date().Unix())),
 		BelongsToAccount: fake.UUID(),
+		Version:          1,
 	}
 }
 
@@ -0,0 +1,55 @@
+package fakes
+
+import (
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// BuildFakeNotification builds a faked notification.
+func BuildFakeNotification() *types.Notification {
+	return &types.Notification{
+		ID:               ksuid.New().String(),
+		Title:            fake.Sentence(3),
+		Description:      fake.Sentence(8),
+		CreatedOn:        uint64(uint32(fake.Date().Unix())),
+		BelongsToAccount: fake.UUID(),
+		BelongsToUser:    fake.UUID(),
+	}
+}
+
+// BuildFakeNotificationList builds a faked NotificationList.
+func BuildFakeNotificationList() *types.NotificationList {
+	var examples []*types.Notification
+	for i := 0; i < exampleQuantity; i++ {
+		examples = append(examples, BuildFakeNotification())
+	}
+
+	return &types.NotificationList{
+		Pagination: types.Pagination{
+			Page:          1,
+			Limit:         20,
+			FilteredCount: exampleQuantity / 2,
+			TotalCount:    exampleQuantity,
+		},
+		Notifications: examples,
+	}
+}
+
+// BuildFakeNotificationDatabaseCreationInput builds a faked NotificationDatabaseCreationInput.
+func BuildFakeNotificationDatabaseCreationInput() *types.NotificationDatabaseCreationInput {
+	notification := BuildFakeNotification()
+	return BuildFakeNotificationDatabaseCreationInputFromNotification(notification)
+}
+
+// BuildFakeNotificationDatabaseCreationInputFromNotification builds a faked NotificationDatabaseCreationInput from a notification.
+func BuildFakeNotificationDatabaseCreationInputFromNotification(notification *types.Notification) *types.NotificationDatabaseCreationInput {
+	return &types.NotificationDatabaseCreationInput{
+		ID:               notification.ID,
+		Title:            notification.Title,
+		Description:      notification.Description,
+		BelongsToAccount: notification.BelongsToAccount,
+		BelongsToUser:    notification.BelongsToUser,
+	}
+}
@@ -0,0 +1,29 @@
+package fakes
+
+import (
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// BuildFakeOIDCIdentity builds a faked OIDC identity.
+func BuildFakeOIDCIdentity() *types.OIDCIdentity {
+	return &types.OIDCIdentity{
+		ID:            ksuid.New().String(),
+		Issuer:        fake.URL(),
+		Subject:       fake.UUID(),
+		BelongsToUser: ksuid.New().String(),
+		CreatedOn:     uint64(uint32(fake.Date().Unix())),
+	}
+}
+
+// BuildFakeOIDCIdentityDatabaseCreationInputFromOIDCIdentity builds a faked OIDCIdentityDatabaseCreationInput from an OIDC identity.
+func BuildFakeOIDCIdentityDatabaseCreationInputFromOIDCIdentity(x *types.OIDCIdentity) *types.OIDCIdentityDatabaseCreationInput {
+	return &types.OIDCIdentityDatabaseCreationInput{
+		ID:            x.ID,
+		Issuer:        x.Issuer,
+		Subject:       x.Subject,
+		BelongsToUser: x.BelongsToUser,
+	}
+}
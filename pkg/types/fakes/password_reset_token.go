@@ -0,0 +1,48 @@
+package fakes
+
+import (
+	"time"
+
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// BuildFakePasswordResetToken builds a faked password reset token.
+func BuildFakePasswordResetToken() *types.PasswordResetToken {
+	createdOn := uint64(uint32(fake.Date().Unix()))
+
+	return &types.PasswordResetToken{
+		ID:            ksuid.New().String(),
+		HashedToken:   fake.UUID(),
+		BelongsToUser: ksuid.New().String(),
+		ExpiresAt:     createdOn + uint64((30 * time.Minute).Seconds()),
+		CreatedOn:     createdOn,
+	}
+}
+
+// BuildFakePasswordResetTokenDatabaseCreationInputFromPasswordResetToken builds a faked PasswordResetTokenDatabaseCreationInput from a password reset token.
+func BuildFakePasswordResetTokenDatabaseCreationInputFromPasswordResetToken(token *types.PasswordResetToken) *types.PasswordResetTokenDatabaseCreationInput {
+	return &types.PasswordResetTokenDatabaseCreationInput{
+		ID:            token.ID,
+		HashedToken:   token.HashedToken,
+		BelongsToUser: token.BelongsToUser,
+		ExpiresAt:     token.ExpiresAt,
+	}
+}
+
+// BuildFakePasswordResetTokenCreationRequestInput builds a faked PasswordResetTokenCreationRequestInput.
+func BuildFakePasswordResetTokenCreationRequestInput() *types.PasswordResetTokenCreationRequestInput {
+	return &types.PasswordResetTokenCreationRequestInput{
+		Username: fake.Username(),
+	}
+}
+
+// BuildFakePasswordResetTokenRedemptionRequestInput builds a faked PasswordResetTokenRedemptionRequestInput.
+func BuildFakePasswordResetTokenRedemptionRequestInput() *types.PasswordResetTokenRedemptionRequestInput {
+	return &types.PasswordResetTokenRedemptionRequestInput{
+		Token:       fake.UUID(),
+		NewPassword: fake.Password(true, true, true, true, true, 32),
+	}
+}
@@ -0,0 +1,26 @@
+package fakes
+
+import (
+	fake "github.com/brianvoe/gofakeit/v5"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// BuildFakeTOTPRecoveryInput builds a faked TOTPRecoveryInput.
+func BuildFakeTOTPRecoveryInput() *types.TOTPRecoveryInput {
+	return &types.TOTPRecoveryInput{
+		Username:     fake.Username(),
+		Password:     fake.Password(true, true, true, true, true, 32),
+		RecoveryCode: fake.Password(true, false, true, false, false, 10),
+	}
+}
+
+// BuildFakeTOTPRecoveryCodesResponse builds a faked TOTPRecoveryCodesResponse.
+func BuildFakeTOTPRecoveryCodesResponse() *types.TOTPRecoveryCodesResponse {
+	var codes []string
+	for i := 0; i < exampleQuantity; i++ {
+		codes = append(codes, fake.Password(true, false, true, false, false, 10))
+	}
+
+	return &types.TOTPRecoveryCodesResponse{RecoveryCodes: codes}
+}
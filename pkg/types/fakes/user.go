@@ -17,8 +17,9 @@
 // BuildFakeUser builds a faked User.
 func BuildFakeUser() *types.User {
 	return &types.User{
-		ID:       ksuid.New().String(),
-		Username: fake.Password(true, true, true, false, false, 32),
+		ID:           ksuid.New().String(),
+		Username:     fake.Password(true, true, true, false, false, 32),
+		EmailAddress: fake.Email(),
 		// HashedPassword: "",
 		// Salt:           []byte(fakes.Word()),
 		ServiceAccountStatus:      types.GoodStandingAccountStatus,
@@ -61,8 +62,9 @@ func BuildFakeUserCreationInput() *types.UserRegistrationInput {
 	exampleUser := BuildFakeUser()
 
 	return &types.UserRegistrationInput{
-		Username: exampleUser.Username,
-		Password: fake.Password(true, true, true, true, true, 32),
+		Username:     exampleUser.Username,
+		Password:     fake.Password(true, true, true, true, true, 32),
+		EmailAddress: exampleUser.EmailAddress,
 	}
 }
 
@@ -81,8 +83,9 @@ func BuildTestUserCreationConfig() *types.TestUserCreationConfig {
 // BuildFakeUserRegistrationInputFromUser builds a faked UserRegistrationInput.
 func BuildFakeUserRegistrationInputFromUser(user *types.User) *types.UserRegistrationInput {
 	return &types.UserRegistrationInput{
-		Username: user.Username,
-		Password: fake.Password(true, true, true, true, true, 32),
+		Username:     user.Username,
+		Password:     fake.Password(true, true, true, true, true, 32),
+		EmailAddress: user.EmailAddress,
 	}
 }
 
@@ -91,6 +94,7 @@ func BuildFakeUserDataStoreCreationInputFromUser(user *types.User) *types.UserDa
 	return &types.UserDataStoreCreationInput{
 		ID:              user.ID,
 		Username:        user.Username,
+		EmailAddress:    user.EmailAddress,
 		HashedPassword:  user.HashedPassword,
 		TwoFactorSecret: user.TwoFactorSecret,
 	}
@@ -108,8 +112,9 @@ func BuildFakeUserReputationUpdateInputFromUser(user *types.User) *types.UserRep
 // BuildFakeUserRegistrationInput builds a faked UserLoginInput.
 func BuildFakeUserRegistrationInput() *types.UserRegistrationInput {
 	return &types.UserRegistrationInput{
-		Username: fake.Username(),
-		Password: fake.Password(true, true, true, true, true, 32),
+		Username:     fake.Username(),
+		Password:     fake.Password(true, true, true, true, true, 32),
+		EmailAddress: fake.Email(),
 	}
 }
 
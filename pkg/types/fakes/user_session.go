@@ -0,0 +1,64 @@
+package fakes
+
+import (
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// BuildFakeUserSession builds a faked user session.
+func BuildFakeUserSession() *types.UserSession {
+	createdOn := uint64(uint32(fake.Date().Unix()))
+
+	return &types.UserSession{
+		ID:              ksuid.New().String(),
+		Token:           fake.UUID(),
+		BelongsToUser:   ksuid.New().String(),
+		ActiveAccount:   ksuid.New().String(),
+		IPAddress:       fake.IPv4Address(),
+		UserAgent:       fake.UserAgent(),
+		Browser:         fake.RandomString([]string{"Chrome", "Firefox", "Safari"}),
+		BrowserVersion:  fake.AppVersion(),
+		OperatingSystem: fake.RandomString([]string{"Linux x86_64", "Windows 10", "Mac OS X 10_15_7"}),
+		Platform:        fake.RandomString([]string{"X11", "Windows", "Macintosh"}),
+		Mobile:          fake.Bool(),
+		CreatedOn:       createdOn,
+		LastSeenOn:      createdOn,
+	}
+}
+
+// BuildFakeUserSessionList builds a faked UserSessionList.
+func BuildFakeUserSessionList() *types.UserSessionList {
+	var examples []*types.UserSession
+	for i := 0; i < exampleQuantity; i++ {
+		examples = append(examples, BuildFakeUserSession())
+	}
+
+	return &types.UserSessionList{
+		Pagination: types.Pagination{
+			Page:          1,
+			Limit:         20,
+			FilteredCount: exampleQuantity / 2,
+			TotalCount:    exampleQuantity,
+		},
+		UserSessions: examples,
+	}
+}
+
+// BuildFakeUserSessionDatabaseCreationInputFromUserSession builds a faked UserSessionDatabaseCreationInput from a user session.
+func BuildFakeUserSessionDatabaseCreationInputFromUserSession(x *types.UserSession) *types.UserSessionDatabaseCreationInput {
+	return &types.UserSessionDatabaseCreationInput{
+		ID:              x.ID,
+		Token:           x.Token,
+		BelongsToUser:   x.BelongsToUser,
+		ActiveAccount:   x.ActiveAccount,
+		IPAddress:       x.IPAddress,
+		UserAgent:       x.UserAgent,
+		Browser:         x.Browser,
+		BrowserVersion:  x.BrowserVersion,
+		OperatingSystem: x.OperatingSystem,
+		Platform:        x.Platform,
+		Mobile:          x.Mobile,
+	}
+}
@@ -0,0 +1,53 @@
+package fakes
+
+import (
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/segmentio/ksuid"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+// BuildFakeWebAuthnCredential builds a faked WebAuthn credential.
+func BuildFakeWebAuthnCredential() *types.WebAuthnCredential {
+	return &types.WebAuthnCredential{
+		ID:              ksuid.New().String(),
+		Name:            fake.Word(),
+		BelongsToUser:   ksuid.New().String(),
+		AttestationType: "none",
+		CredentialID:    []byte(fake.UUID()),
+		PublicKey:       []byte(fake.UUID()),
+		AAGUID:          make([]byte, 16),
+		SignCount:       uint32(fake.Uint16()),
+		CreatedOn:       uint64(uint32(fake.Date().Unix())),
+	}
+}
+
+// BuildFakeWebAuthnCredentialList builds a faked WebAuthnCredentialList.
+func BuildFakeWebAuthnCredentialList() *types.WebAuthnCredentialList {
+	var examples []*types.WebAuthnCredential
+	for i := 0; i < exampleQuantity; i++ {
+		examples = append(examples, BuildFakeWebAuthnCredential())
+	}
+
+	return &types.WebAuthnCredentialList{
+		Pagination: types.Pagination{
+			FilteredCount: exampleQuantity,
+			TotalCount:    exampleQuantity,
+		},
+		WebAuthnCredentials: examples,
+	}
+}
+
+// BuildFakeWebAuthnCredentialDatabaseCreationInputFromWebAuthnCredential builds a faked WebAuthnCredentialDatabaseCreationInput from a WebAuthn credential.
+func BuildFakeWebAuthnCredentialDatabaseCreationInputFromWebAuthnCredential(x *types.WebAuthnCredential) *types.WebAuthnCredentialDatabaseCreationInput {
+	return &types.WebAuthnCredentialDatabaseCreationInput{
+		ID:              x.ID,
+		Name:            x.Name,
+		BelongsToUser:   x.BelongsToUser,
+		AttestationType: x.AttestationType,
+		CredentialID:    x.CredentialID,
+		PublicKey:       x.PublicKey,
+		AAGUID:          x.AAGUID,
+		SignCount:       x.SignCount,
+	}
+}
@@ -23,6 +23,7 @@ func BuildFakeWebhook() *types.Webhook {
 		CreatedOn:        uint64(uint32(fake.Date().Unix())),
 		ArchivedOn:       nil,
 		BelongsToAccount: fake.UUID(),
+		SigningSecret:    fake.Password(true, true, true, false, false, 32),
 	}
 }
 
@@ -83,5 +84,70 @@ func BuildFakeWebhookDatabaseCreationInputFromWebhook(webhook *types.Webhook) *t
 		DataTypes:        webhook.DataTypes,
 		Topics:           webhook.Topics,
 		BelongsToAccount: webhook.BelongsToAccount,
+		SigningSecret:    webhook.SigningSecret,
+	}
+}
+
+// BuildFakeWebhookUpdateInput builds a faked WebhookUpdateInput.
+func BuildFakeWebhookUpdateInput() *types.WebhookUpdateInput {
+	webhook := BuildFakeWebhook()
+	return BuildFakeWebhookUpdateInputFromWebhook(webhook)
+}
+
+// BuildFakeWebhookUpdateInputFromWebhook builds a faked WebhookUpdateInput from a webhook.
+func BuildFakeWebhookUpdateInputFromWebhook(webhook *types.Webhook) *types.WebhookUpdateInput {
+	return &types.WebhookUpdateInput{
+		Name:             webhook.Name,
+		ContentType:      webhook.ContentType,
+		URL:              webhook.URL,
+		Method:           webhook.Method,
+		Events:           webhook.Events,
+		DataTypes:        webhook.DataTypes,
+		Topics:           webhook.Topics,
+		BelongsToAccount: webhook.BelongsToAccount,
+	}
+}
+
+// BuildFakeWebhookDeliveryAttempt builds a faked WebhookDeliveryAttempt.
+func BuildFakeWebhookDeliveryAttempt() *types.WebhookDeliveryAttempt {
+	return &types.WebhookDeliveryAttempt{
+		ID:                    ksuid.New().String(),
+		RequestBody:           fake.Sentence(10),
+		ResponseStatus:        http.StatusOK,
+		LatencyInMilliseconds: uint64(fake.Uint16()),
+		AttemptNumber:         1,
+		BelongsToWebhook:      ksuid.New().String(),
+		CreatedOn:             uint64(uint32(fake.Date().Unix())),
+	}
+}
+
+// BuildFakeWebhookDeliveryAttemptList builds a faked WebhookDeliveryAttemptList.
+func BuildFakeWebhookDeliveryAttemptList() *types.WebhookDeliveryAttemptList {
+	var examples []*types.WebhookDeliveryAttempt
+	for i := 0; i < exampleQuantity; i++ {
+		examples = append(examples, BuildFakeWebhookDeliveryAttempt())
+	}
+
+	return &types.WebhookDeliveryAttemptList{
+		Pagination: types.Pagination{
+			Page:          1,
+			Limit:         20,
+			FilteredCount: exampleQuantity / 2,
+			TotalCount:    exampleQuantity,
+		},
+		Attempts: examples,
+	}
+}
+
+// BuildFakeWebhookDeliveryAttemptDatabaseCreationInputFromWebhookDeliveryAttempt builds a faked WebhookDeliveryAttemptDatabaseCreationInput.
+func BuildFakeWebhookDeliveryAttemptDatabaseCreationInputFromWebhookDeliveryAttempt(attempt *types.WebhookDeliveryAttempt) *types.WebhookDeliveryAttemptDatabaseCreationInput {
+	return &types.WebhookDeliveryAttemptDatabaseCreationInput{
+		ID:                    attempt.ID,
+		RequestBody:           attempt.RequestBody,
+		ErrorText:             attempt.ErrorText,
+		BelongsToWebhook:      attempt.BelongsToWebhook,
+		LatencyInMilliseconds: attempt.LatencyInMilliseconds,
+		ResponseStatus:        attempt.ResponseStatus,
+		AttemptNumber:         attempt.AttemptNumber,
 	}
 }
@@ -16,6 +16,7 @@
 func init() {
 	gob.Register(new(Item))
 	gob.Register(new(ItemList))
+	gob.Register(new(ItemSearchResult))
 	gob.Register(new(ItemCreationInput))
 	gob.Register(new(ItemUpdateInput))
 }
@@ -32,6 +33,7 @@ func init() {
 		ID               string  `json:"id"`
 		BelongsToAccount string  `json:"belongsToAccount"`
 		CreatedOn        uint64  `json:"createdOn"`
+		Version          uint64  `json:"version"`
 	}
 
 	// ItemList represents a list of items.
@@ -42,6 +44,15 @@ func init() {
 		Pagination
 	}
 
+	// ItemSearchResult represents an item matched by a search query.
+	ItemSearchResult struct {
+		_ struct{}
+
+		*Item
+		Highlights map[string][]string `json:"highlights,omitempty"`
+		Score      float64             `json:"score"`
+	}
+
 	// ItemCreationInput represents what a user could set as input for creating items.
 	ItemCreationInput struct {
 		_ struct{}
@@ -77,7 +88,10 @@ func init() {
 		GetItem(ctx context.Context, itemID, accountID string) (*Item, error)
 		GetTotalItemCount(ctx context.Context) (uint64, error)
 		GetItems(ctx context.Context, accountID string, filter *QueryFilter) (*ItemList, error)
+		GetItemsForAdmin(ctx context.Context, filter *QueryFilter) (*ItemList, error)
 		GetItemsWithIDs(ctx context.Context, accountID string, limit uint8, ids []string) ([]*Item, error)
+		SearchItemIDs(ctx context.Context, query, accountID string) ([]string, error)
+		SearchItemIDsForAdmin(ctx context.Context, query string) ([]string, error)
 		CreateItem(ctx context.Context, input *ItemDatabaseCreationInput) (*Item, error)
 		UpdateItem(ctx context.Context, updated *Item) error
 		ArchiveItem(ctx context.Context, itemID, accountID string) error
@@ -94,15 +108,21 @@ func init() {
 	}
 )
 
-// Update merges an ItemUpdateInput with an item.
-func (x *Item) Update(input *ItemUpdateInput) {
+// Update merges an ItemUpdateInput with an item, and returns a summary of what changed.
+func (x *Item) Update(input *ItemUpdateInput) []*FieldChangeSummary {
+	var out []*FieldChangeSummary
+
 	if input.Name != "" && input.Name != x.Name {
+		out = append(out, &FieldChangeSummary{FieldName: "name", OldValue: x.Name, NewValue: input.Name})
 		x.Name = input.Name
 	}
 
 	if input.Details != "" && input.Details != x.Details {
+		out = append(out, &FieldChangeSummary{FieldName: "details", OldValue: x.Details, NewValue: input.Details})
 		x.Details = input.Details
 	}
+
+	return out
 }
 
 var _ validation.ValidatableWithContext = (*ItemCreationInput)(nil)
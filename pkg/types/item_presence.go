@@ -0,0 +1,80 @@
+package types
+
+import (
+	"context"
+	"encoding/gob"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	// ItemPresenceDataType indicates an event is about who is viewing or editing an item.
+	ItemPresenceDataType dataType = "item_presence"
+
+	// ViewingItemPresenceActivity indicates someone is looking at an item.
+	ViewingItemPresenceActivity = "viewing"
+	// EditingItemPresenceActivity indicates someone is editing an item.
+	EditingItemPresenceActivity = "editing"
+	// LeftItemPresenceActivity indicates someone has stopped viewing or editing an item.
+	LeftItemPresenceActivity = "left"
+)
+
+func init() {
+	gob.Register(new(ItemPresence))
+	gob.Register(new(ItemPresenceList))
+	gob.Register(new(ItemPresenceUpdateInput))
+}
+
+type (
+	// ItemPresence represents someone viewing or editing an item. Presence expires unless it is renewed. Someone
+	// may have an item open on more than one connection, so presence is published per connection, and what's
+	// reported to anyone else is what all of their connections amount to, without a connection ID.
+	ItemPresence struct {
+		_ struct{}
+
+		ItemID           string `json:"itemID"`
+		Activity         string `json:"activity"`
+		UserID           string `json:"userID"`
+		Username         string `json:"username"`
+		ConnectionID     string `json:"connectionID,omitempty"`
+		BelongsToAccount string `json:"belongsToAccount"`
+		ExpiresOn        uint64 `json:"expiresOn"`
+	}
+
+	// ItemPresenceList represents everyone currently viewing or editing an item.
+	ItemPresenceList struct {
+		_ struct{}
+
+		Viewers []*ItemPresence `json:"viewers"`
+	}
+
+	// ItemPresenceUpdateInput represents what a user sends to announce what they're doing with an item.
+	ItemPresenceUpdateInput struct {
+		_ struct{}
+
+		ItemID   string `json:"itemID"`
+		Activity string `json:"activity"`
+	}
+
+	// ItemPresenceUpdateMessage is how an ItemPresenceUpdateInput is sent over a websocket. Anything else sent over
+	// a websocket is treated as a DataChangeSubscriptionFilter.
+	ItemPresenceUpdateMessage struct {
+		_ struct{}
+
+		Presence *ItemPresenceUpdateInput `json:"presence"`
+	}
+)
+
+var _ validation.ValidatableWithContext = (*ItemPresenceUpdateInput)(nil)
+
+// ValidateWithContext validates an ItemPresenceUpdateInput.
+func (x *ItemPresenceUpdateInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.ItemID, validation.Required),
+		validation.Field(&x.Activity, validation.Required, validation.In(
+			ViewingItemPresenceActivity,
+			EditingItemPresenceActivity,
+			LeftItemPresenceActivity,
+		)),
+	)
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemPresenceUpdateInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &ItemPresenceUpdateInput{
+			ItemID:   fake.UUID(),
+			Activity: EditingItemPresenceActivity,
+		}
+
+		assert.NoError(t, x.ValidateWithContext(context.Background()))
+	})
+
+	T.Run("with missing item ID", func(t *testing.T) {
+		t.Parallel()
+
+		x := &ItemPresenceUpdateInput{
+			Activity: ViewingItemPresenceActivity,
+		}
+
+		assert.Error(t, x.ValidateWithContext(context.Background()))
+	})
+
+	T.Run("with invalid activity", func(t *testing.T) {
+		t.Parallel()
+
+		x := &ItemPresenceUpdateInput{
+			ItemID:   fake.UUID(),
+			Activity: t.Name(),
+		}
+
+		assert.Error(t, x.ValidateWithContext(context.Background()))
+	})
+}
@@ -8,6 +8,44 @@
 	"github.com/stretchr/testify/assert"
 )
 
+func TestItem_Update(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &Item{
+			Name:    fake.Word(),
+			Details: fake.Sentence(5),
+		}
+		expected := *x
+		input := &ItemUpdateInput{
+			Name:    fake.UUID(),
+			Details: fake.UUID(),
+		}
+
+		changes := x.Update(input)
+
+		assert.Equal(t, input.Name, x.Name)
+		assert.Equal(t, input.Details, x.Details)
+		assert.Equal(t, []*FieldChangeSummary{
+			{FieldName: "name", OldValue: expected.Name, NewValue: input.Name},
+			{FieldName: "details", OldValue: expected.Details, NewValue: input.Details},
+		}, changes)
+	})
+
+	T.Run("without changes", func(t *testing.T) {
+		t.Parallel()
+
+		x := &Item{
+			Name:    fake.Word(),
+			Details: fake.Sentence(5),
+		}
+
+		assert.Empty(t, x.Update(&ItemUpdateInput{Name: x.Name}))
+	})
+}
+
 func TestItemCreationInput_Validate(T *testing.T) {
 	T.Parallel()
 
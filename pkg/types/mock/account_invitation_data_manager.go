@@ -0,0 +1,51 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var _ types.AccountInvitationDataManager = (*AccountInvitationDataManager)(nil)
+
+// AccountInvitationDataManager is a mocked types.AccountInvitationDataManager for testing.
+type AccountInvitationDataManager struct {
+	mock.Mock
+}
+
+// GetAccountInvitation is a mock function.
+func (m *AccountInvitationDataManager) GetAccountInvitation(ctx context.Context, accountInvitationID string) (*types.AccountInvitation, error) {
+	args := m.Called(ctx, accountInvitationID)
+	return args.Get(0).(*types.AccountInvitation), args.Error(1)
+}
+
+// GetPendingAccountInvitationsForAccount is a mock function.
+func (m *AccountInvitationDataManager) GetPendingAccountInvitationsForAccount(ctx context.Context, accountID string, filter *types.QueryFilter) (*types.AccountInvitationList, error) {
+	args := m.Called(ctx, accountID, filter)
+	return args.Get(0).(*types.AccountInvitationList), args.Error(1)
+}
+
+// GetPendingAccountInvitationsForUser is a mock function.
+func (m *AccountInvitationDataManager) GetPendingAccountInvitationsForUser(ctx context.Context, userID string) (*types.AccountInvitationList, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(*types.AccountInvitationList), args.Error(1)
+}
+
+// CreateAccountInvitation is a mock function.
+func (m *AccountInvitationDataManager) CreateAccountInvitation(ctx context.Context, input *types.AccountInvitationDatabaseCreationInput) (*types.AccountInvitation, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*types.AccountInvitation), args.Error(1)
+}
+
+// ClaimAccountInvitation is a mock function.
+func (m *AccountInvitationDataManager) ClaimAccountInvitation(ctx context.Context, tokenHash, userID string) (*types.AccountInvitation, error) {
+	args := m.Called(ctx, tokenHash, userID)
+	return args.Get(0).(*types.AccountInvitation), args.Error(1)
+}
+
+// SetAccountInvitationStatus is a mock function.
+func (m *AccountInvitationDataManager) SetAccountInvitationStatus(ctx context.Context, accountInvitationID string, status types.AccountInvitationStatus) error {
+	return m.Called(ctx, accountInvitationID, status).Error(0)
+}
@@ -0,0 +1,44 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var _ types.AccountRoleDataManager = (*AccountRoleDataManager)(nil)
+
+// AccountRoleDataManager is a mocked types.AccountRoleDataManager for testing.
+type AccountRoleDataManager struct {
+	mock.Mock
+}
+
+// GetAccountRole is a mock function.
+func (m *AccountRoleDataManager) GetAccountRole(ctx context.Context, accountRoleID, accountID string) (*types.AccountRole, error) {
+	args := m.Called(ctx, accountRoleID, accountID)
+	return args.Get(0).(*types.AccountRole), args.Error(1)
+}
+
+// GetAccountRoles is a mock function.
+func (m *AccountRoleDataManager) GetAccountRoles(ctx context.Context, accountID string, filter *types.QueryFilter) (*types.AccountRoleList, error) {
+	args := m.Called(ctx, accountID, filter)
+	return args.Get(0).(*types.AccountRoleList), args.Error(1)
+}
+
+// CreateAccountRole is a mock function.
+func (m *AccountRoleDataManager) CreateAccountRole(ctx context.Context, input *types.AccountRoleCreationInput) (*types.AccountRole, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*types.AccountRole), args.Error(1)
+}
+
+// UpdateAccountRole is a mock function.
+func (m *AccountRoleDataManager) UpdateAccountRole(ctx context.Context, updated *types.AccountRole) error {
+	return m.Called(ctx, updated).Error(0)
+}
+
+// ArchiveAccountRole is a mock function.
+func (m *AccountRoleDataManager) ArchiveAccountRole(ctx context.Context, accountRoleID, accountID string) error {
+	return m.Called(ctx, accountRoleID, accountID).Error(0)
+}
@@ -0,0 +1,125 @@
+package mock
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var _ types.AccountDataService = (*AccountsService)(nil)
+
+// AccountsService is a mock types.AccountDataService.
+type AccountsService struct {
+	mock.Mock
+}
+
+// ListHandler satisfies our interface contract.
+func (m *AccountsService) ListHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// CreateHandler satisfies our interface contract.
+func (m *AccountsService) CreateHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// ReadHandler satisfies our interface contract.
+func (m *AccountsService) ReadHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// UpdateHandler satisfies our interface contract.
+func (m *AccountsService) UpdateHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// ArchiveHandler satisfies our interface contract.
+func (m *AccountsService) ArchiveHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// RemoveMemberHandler satisfies our interface contract.
+func (m *AccountsService) RemoveMemberHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// MarkAsDefaultAccountHandler satisfies our interface contract.
+func (m *AccountsService) MarkAsDefaultAccountHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// ModifyMemberPermissionsHandler satisfies our interface contract.
+func (m *AccountsService) ModifyMemberPermissionsHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// TransferAccountOwnershipHandler satisfies our interface contract.
+func (m *AccountsService) TransferAccountOwnershipHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// InviteMemberHandler satisfies our interface contract.
+func (m *AccountsService) InviteMemberHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// ListPendingInvitationsHandler satisfies our interface contract.
+func (m *AccountsService) ListPendingInvitationsHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// RevokeInvitationHandler satisfies our interface contract.
+func (m *AccountsService) RevokeInvitationHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// ListReceivedInvitationsHandler satisfies our interface contract.
+func (m *AccountsService) ListReceivedInvitationsHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// ClaimInvitationHandler satisfies our interface contract.
+func (m *AccountsService) ClaimInvitationHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// AcceptInvitationHandler satisfies our interface contract.
+func (m *AccountsService) AcceptInvitationHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// RejectInvitationHandler satisfies our interface contract.
+func (m *AccountsService) RejectInvitationHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// InviteUserToAccount satisfies our interface contract.
+func (m *AccountsService) InviteUserToAccount(ctx context.Context, accountID, inviterID string, input *types.AccountInvitationCreationRequestInput) (*types.AccountInvitation, error) {
+	args := m.Called(ctx, accountID, inviterID, input)
+
+	return args.Get(0).(*types.AccountInvitation), args.Error(1)
+}
+
+// ClaimAccountInvitation satisfies our interface contract.
+func (m *AccountsService) ClaimAccountInvitation(ctx context.Context, userID, token string) (*types.AccountInvitation, error) {
+	args := m.Called(ctx, userID, token)
+	return args.Get(0).(*types.AccountInvitation), args.Error(1)
+}
+
+// AcceptAccountInvitation satisfies our interface contract.
+func (m *AccountsService) AcceptAccountInvitation(ctx context.Context, userID, accountInvitationID string) error {
+	return m.Called(ctx, userID, accountInvitationID).Error(0)
+}
+
+// RejectAccountInvitation satisfies our interface contract.
+func (m *AccountsService) RejectAccountInvitation(ctx context.Context, userID, accountInvitationID string) error {
+	return m.Called(ctx, userID, accountInvitationID).Error(0)
+}
+
+// RevokeAccountInvitation satisfies our interface contract.
+func (m *AccountsService) RevokeAccountInvitation(ctx context.Context, accountID, requesterID, accountInvitationID string) error {
+	return m.Called(ctx, accountID, requesterID, accountInvitationID).Error(0)
+}
@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var _ types.AuditLogEntryDataManager = (*AuditLogEntryDataManager)(nil)
+
+// AuditLogEntryDataManager is a mocked types.AuditLogEntryDataManager for testing.
+type AuditLogEntryDataManager struct {
+	mock.Mock
+}
+
+// GetAuditLogEntries is a mock function.
+func (m *AuditLogEntryDataManager) GetAuditLogEntries(ctx context.Context, filter *types.AuditLogEntryQueryFilter) (*types.AuditLogEntryList, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(*types.AuditLogEntryList), args.Error(1)
+}
+
+// GetAuditLogEntriesForAccount is a mock function.
+func (m *AuditLogEntryDataManager) GetAuditLogEntriesForAccount(ctx context.Context, accountID string, filter *types.AuditLogEntryQueryFilter) (*types.AuditLogEntryList, error) {
+	args := m.Called(ctx, accountID, filter)
+	return args.Get(0).(*types.AuditLogEntryList), args.Error(1)
+}
+
+// CreateAuditLogEntry is a mock function.
+func (m *AuditLogEntryDataManager) CreateAuditLogEntry(ctx context.Context, input *types.AuditLogEntryCreationInput) error {
+	return m.Called(ctx, input).Error(0)
+}
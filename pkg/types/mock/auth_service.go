@@ -87,9 +87,59 @@ func (m *AuthService) ChangeActiveAccountInputMiddleware(next http.Handler) http
 	return m.Called(next).Get(0).(http.Handler)
 }
 
+// ListUserSessionsHandler satisfies our interface contract.
+func (m *AuthService) ListUserSessionsHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(req, res)
+}
+
+// RevokeUserSessionHandler satisfies our interface contract.
+func (m *AuthService) RevokeUserSessionHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(req, res)
+}
+
+// RevokeOtherUserSessionsHandler satisfies our interface contract.
+func (m *AuthService) RevokeOtherUserSessionsHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(req, res)
+}
+
+// ListWebAuthnCredentialsHandler satisfies our interface contract.
+func (m *AuthService) ListWebAuthnCredentialsHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(req, res)
+}
+
+// BeginWebAuthnRegistrationHandler satisfies our interface contract.
+func (m *AuthService) BeginWebAuthnRegistrationHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(req, res)
+}
+
+// FinishWebAuthnRegistrationHandler satisfies our interface contract.
+func (m *AuthService) FinishWebAuthnRegistrationHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(req, res)
+}
+
+// ArchiveWebAuthnCredentialHandler satisfies our interface contract.
+func (m *AuthService) ArchiveWebAuthnCredentialHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(req, res)
+}
+
+// BeginWebAuthnLoginHandler satisfies our interface contract.
+func (m *AuthService) BeginWebAuthnLoginHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(req, res)
+}
+
+// BeginOIDCLoginHandler satisfies our interface contract.
+func (m *AuthService) BeginOIDCLoginHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(req, res)
+}
+
+// OIDCCallbackHandler satisfies our interface contract.
+func (m *AuthService) OIDCCallbackHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(req, res)
+}
+
 // AuthenticateUser satisfies our interface contract.
-func (m *AuthService) AuthenticateUser(ctx context.Context, loginData *types.UserLoginInput) (*types.User, *http.Cookie, error) {
-	returnValues := m.Called(ctx, loginData)
+func (m *AuthService) AuthenticateUser(ctx context.Context, req *http.Request, loginData *types.UserLoginInput) (*types.User, *http.Cookie, error) {
+	returnValues := m.Called(ctx, req, loginData)
 
 	return returnValues.Get(0).(*types.User), returnValues.Get(1).(*http.Cookie), returnValues.Error(2)
 }
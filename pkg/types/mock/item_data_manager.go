@@ -39,12 +39,30 @@ func (m *ItemDataManager) GetItems(ctx context.Context, accountID string, filter
 	return args.Get(0).(*types.ItemList), args.Error(1)
 }
 
+// GetItemsForAdmin is a mock function.
+func (m *ItemDataManager) GetItemsForAdmin(ctx context.Context, filter *types.QueryFilter) (*types.ItemList, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(*types.ItemList), args.Error(1)
+}
+
 // GetItemsWithIDs is a mock function.
 func (m *ItemDataManager) GetItemsWithIDs(ctx context.Context, accountID string, limit uint8, ids []string) ([]*types.Item, error) {
 	args := m.Called(ctx, accountID, limit, ids)
 	return args.Get(0).([]*types.Item), args.Error(1)
 }
 
+// SearchItemIDs is a mock function.
+func (m *ItemDataManager) SearchItemIDs(ctx context.Context, query, accountID string) ([]string, error) {
+	args := m.Called(ctx, query, accountID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// SearchItemIDsForAdmin is a mock function.
+func (m *ItemDataManager) SearchItemIDsForAdmin(ctx context.Context, query string) ([]string, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]string), args.Error(1)
+}
+
 // CreateItem is a mock function.
 func (m *ItemDataManager) CreateItem(ctx context.Context, input *types.ItemDatabaseCreationInput) (*types.Item, error) {
 	args := m.Called(ctx, input)
@@ -0,0 +1,44 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var _ types.NotificationDataManager = (*NotificationDataManager)(nil)
+
+// NotificationDataManager is a mocked types.NotificationDataManager for testing.
+type NotificationDataManager struct {
+	mock.Mock
+}
+
+// GetNotifications is a mock function.
+func (m *NotificationDataManager) GetNotifications(ctx context.Context, userID string, filter *types.QueryFilter) (*types.NotificationList, error) {
+	args := m.Called(ctx, userID, filter)
+	return args.Get(0).(*types.NotificationList), args.Error(1)
+}
+
+// GetUnreadNotificationCount is a mock function.
+func (m *NotificationDataManager) GetUnreadNotificationCount(ctx context.Context, userID string) (uint64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+// CreateNotification is a mock function.
+func (m *NotificationDataManager) CreateNotification(ctx context.Context, input *types.NotificationDatabaseCreationInput) (*types.Notification, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*types.Notification), args.Error(1)
+}
+
+// MarkNotificationAsSeen is a mock function.
+func (m *NotificationDataManager) MarkNotificationAsSeen(ctx context.Context, notificationID, userID string) error {
+	return m.Called(ctx, notificationID, userID).Error(0)
+}
+
+// MarkAllNotificationsAsSeen is a mock function.
+func (m *NotificationDataManager) MarkAllNotificationsAsSeen(ctx context.Context, userID string) error {
+	return m.Called(ctx, userID).Error(0)
+}
@@ -0,0 +1,28 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var _ types.OIDCIdentityDataManager = (*OIDCIdentityDataManager)(nil)
+
+// OIDCIdentityDataManager is a mocked types.OIDCIdentityDataManager for testing.
+type OIDCIdentityDataManager struct {
+	mock.Mock
+}
+
+// GetOIDCIdentity is a mock function.
+func (m *OIDCIdentityDataManager) GetOIDCIdentity(ctx context.Context, issuer, subject string) (*types.OIDCIdentity, error) {
+	args := m.Called(ctx, issuer, subject)
+	return args.Get(0).(*types.OIDCIdentity), args.Error(1)
+}
+
+// CreateOIDCIdentity is a mock function.
+func (m *OIDCIdentityDataManager) CreateOIDCIdentity(ctx context.Context, input *types.OIDCIdentityDatabaseCreationInput) (*types.OIDCIdentity, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*types.OIDCIdentity), args.Error(1)
+}
@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var _ types.PasswordResetTokenDataManager = (*PasswordResetTokenDataManager)(nil)
+
+// PasswordResetTokenDataManager is a mocked types.PasswordResetTokenDataManager for testing.
+type PasswordResetTokenDataManager struct {
+	mock.Mock
+}
+
+// GetPasswordResetTokenByToken is a mock function.
+func (m *PasswordResetTokenDataManager) GetPasswordResetTokenByToken(ctx context.Context, hashedToken string) (*types.PasswordResetToken, error) {
+	args := m.Called(ctx, hashedToken)
+	return args.Get(0).(*types.PasswordResetToken), args.Error(1)
+}
+
+// CreatePasswordResetToken is a mock function.
+func (m *PasswordResetTokenDataManager) CreatePasswordResetToken(ctx context.Context, input *types.PasswordResetTokenDatabaseCreationInput) (*types.PasswordResetToken, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*types.PasswordResetToken), args.Error(1)
+}
+
+// RedeemPasswordResetToken is a mock function.
+func (m *PasswordResetTokenDataManager) RedeemPasswordResetToken(ctx context.Context, passwordResetTokenID string) error {
+	return m.Called(ctx, passwordResetTokenID).Error(0)
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var _ types.TOTPRecoveryCodeDataManager = (*TOTPRecoveryCodeDataManager)(nil)
+
+// TOTPRecoveryCodeDataManager is a mocked types.TOTPRecoveryCodeDataManager for testing.
+type TOTPRecoveryCodeDataManager struct {
+	mock.Mock
+}
+
+// CreateTOTPRecoveryCodes is a mock function.
+func (m *TOTPRecoveryCodeDataManager) CreateTOTPRecoveryCodes(ctx context.Context, userID string, input []*types.TOTPRecoveryCodeDatabaseCreationInput) error {
+	return m.Called(ctx, userID, input).Error(0)
+}
+
+// RedeemTOTPRecoveryCode is a mock function.
+func (m *TOTPRecoveryCodeDataManager) RedeemTOTPRecoveryCode(ctx context.Context, userID, hashedCode string) error {
+	return m.Called(ctx, userID, hashedCode).Error(0)
+}
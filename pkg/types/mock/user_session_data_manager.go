@@ -0,0 +1,48 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var _ types.UserSessionDataManager = (*UserSessionDataManager)(nil)
+
+// UserSessionDataManager is a mocked types.UserSessionDataManager for testing.
+type UserSessionDataManager struct {
+	mock.Mock
+}
+
+// GetUserSessionsForUser is a mock function.
+func (m *UserSessionDataManager) GetUserSessionsForUser(ctx context.Context, userID string, filter *types.QueryFilter) (*types.UserSessionList, error) {
+	args := m.Called(ctx, userID, filter)
+	return args.Get(0).(*types.UserSessionList), args.Error(1)
+}
+
+// CreateUserSession is a mock function.
+func (m *UserSessionDataManager) CreateUserSession(ctx context.Context, input *types.UserSessionDatabaseCreationInput) (*types.UserSession, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*types.UserSession), args.Error(1)
+}
+
+// UpdateUserSessionToken is a mock function.
+func (m *UserSessionDataManager) UpdateUserSessionToken(ctx context.Context, userSessionID, token, activeAccountID string) error {
+	return m.Called(ctx, userSessionID, token, activeAccountID).Error(0)
+}
+
+// MarkUserSessionAsSeen is a mock function.
+func (m *UserSessionDataManager) MarkUserSessionAsSeen(ctx context.Context, userSessionID string) error {
+	return m.Called(ctx, userSessionID).Error(0)
+}
+
+// RevokeUserSession is a mock function.
+func (m *UserSessionDataManager) RevokeUserSession(ctx context.Context, userSessionID, userID string) error {
+	return m.Called(ctx, userSessionID, userID).Error(0)
+}
+
+// RevokeUserSessionsForUser is a mock function.
+func (m *UserSessionDataManager) RevokeUserSessionsForUser(ctx context.Context, userID, exceptUserSessionID string) error {
+	return m.Called(ctx, userID, exceptUserSessionID).Error(0)
+}
@@ -84,6 +84,21 @@ func (m *UsersService) ArchiveHandler(res http.ResponseWriter, req *http.Request
 	m.Called(res, req)
 }
 
+// RequestPasswordResetHandler satisfies our interface contract.
+func (m *UsersService) RequestPasswordResetHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// RedeemPasswordResetHandler satisfies our interface contract.
+func (m *UsersService) RedeemPasswordResetHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
+// TOTPRecoveryHandler satisfies our interface contract.
+func (m *UsersService) TOTPRecoveryHandler(res http.ResponseWriter, req *http.Request) {
+	m.Called(res, req)
+}
+
 // RegisterUser satisfies our interface contract.
 func (m *UsersService) RegisterUser(ctx context.Context, registrationInput *types.UserRegistrationInput) (*types.UserCreationResponse, error) {
 	returnValues := m.Called(ctx, registrationInput)
@@ -92,6 +107,25 @@ func (m *UsersService) RegisterUser(ctx context.Context, registrationInput *type
 }
 
 // VerifyUserTwoFactorSecret satisfies our interface contract.
-func (m *UsersService) VerifyUserTwoFactorSecret(ctx context.Context, input *types.TOTPSecretVerificationInput) error {
+func (m *UsersService) VerifyUserTwoFactorSecret(ctx context.Context, input *types.TOTPSecretVerificationInput) (*types.TOTPRecoveryCodesResponse, error) {
+	returnValues := m.Called(ctx, input)
+
+	return returnValues.Get(0).(*types.TOTPRecoveryCodesResponse), returnValues.Error(1)
+}
+
+// RequestPasswordReset satisfies our interface contract.
+func (m *UsersService) RequestPasswordReset(ctx context.Context, input *types.PasswordResetTokenCreationRequestInput) error {
 	return m.Called(ctx, input).Error(0)
 }
+
+// RedeemPasswordResetToken satisfies our interface contract.
+func (m *UsersService) RedeemPasswordResetToken(ctx context.Context, input *types.PasswordResetTokenRedemptionRequestInput) error {
+	return m.Called(ctx, input).Error(0)
+}
+
+// RecoverTwoFactorSecret satisfies our interface contract.
+func (m *UsersService) RecoverTwoFactorSecret(ctx context.Context, input *types.TOTPRecoveryInput) (*types.TOTPRecoveryResponse, error) {
+	returnValues := m.Called(ctx, input)
+
+	return returnValues.Get(0).(*types.TOTPRecoveryResponse), returnValues.Error(1)
+}
@@ -0,0 +1,38 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+var _ types.WebAuthnCredentialDataManager = (*WebAuthnCredentialDataManager)(nil)
+
+// WebAuthnCredentialDataManager is a mocked types.WebAuthnCredentialDataManager for testing.
+type WebAuthnCredentialDataManager struct {
+	mock.Mock
+}
+
+// GetWebAuthnCredentialsForUser is a mock function.
+func (m *WebAuthnCredentialDataManager) GetWebAuthnCredentialsForUser(ctx context.Context, userID string) (*types.WebAuthnCredentialList, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(*types.WebAuthnCredentialList), args.Error(1)
+}
+
+// CreateWebAuthnCredential is a mock function.
+func (m *WebAuthnCredentialDataManager) CreateWebAuthnCredential(ctx context.Context, input *types.WebAuthnCredentialDatabaseCreationInput) (*types.WebAuthnCredential, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*types.WebAuthnCredential), args.Error(1)
+}
+
+// MarkWebAuthnCredentialAsUsed is a mock function.
+func (m *WebAuthnCredentialDataManager) MarkWebAuthnCredentialAsUsed(ctx context.Context, webAuthnCredentialID string, signCount uint32) error {
+	return m.Called(ctx, webAuthnCredentialID, signCount).Error(0)
+}
+
+// ArchiveWebAuthnCredential is a mock function.
+func (m *WebAuthnCredentialDataManager) ArchiveWebAuthnCredential(ctx context.Context, webAuthnCredentialID, userID string) error {
+	return m.Called(ctx, webAuthnCredentialID, userID).Error(0)
+}
@@ -59,3 +59,26 @@ func (m *WebhookDataManager) UpdateWebhook(ctx context.Context, updated *types.W
 func (m *WebhookDataManager) ArchiveWebhook(ctx context.Context, webhookID, accountID string) error {
 	return m.Called(ctx, webhookID, accountID).Error(0)
 }
+
+// RotateWebhookSigningSecret satisfies our WebhookDataManager interface.
+func (m *WebhookDataManager) RotateWebhookSigningSecret(ctx context.Context, webhookID, accountID, newSecret string, previousSecretExpiresOn uint64) error {
+	return m.Called(ctx, webhookID, accountID, newSecret, previousSecretExpiresOn).Error(0)
+}
+
+// GetWebhookDeliveryAttempt satisfies our WebhookDataManager interface.
+func (m *WebhookDataManager) GetWebhookDeliveryAttempt(ctx context.Context, attemptID, webhookID string) (*types.WebhookDeliveryAttempt, error) {
+	args := m.Called(ctx, attemptID, webhookID)
+	return args.Get(0).(*types.WebhookDeliveryAttempt), args.Error(1)
+}
+
+// GetWebhookDeliveryAttempts satisfies our WebhookDataManager interface.
+func (m *WebhookDataManager) GetWebhookDeliveryAttempts(ctx context.Context, webhookID string, filter *types.QueryFilter) (*types.WebhookDeliveryAttemptList, error) {
+	args := m.Called(ctx, webhookID, filter)
+	return args.Get(0).(*types.WebhookDeliveryAttemptList), args.Error(1)
+}
+
+// CreateWebhookDeliveryAttempt satisfies our WebhookDataManager interface.
+func (m *WebhookDataManager) CreateWebhookDeliveryAttempt(ctx context.Context, input *types.WebhookDeliveryAttemptDatabaseCreationInput) (*types.WebhookDeliveryAttempt, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*types.WebhookDeliveryAttempt), args.Error(1)
+}
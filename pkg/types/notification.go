@@ -0,0 +1,94 @@
+package types
+
+import (
+	"context"
+	"encoding/gob"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	// NotificationDataType indicates an event is notification-related.
+	NotificationDataType dataType = "notification"
+)
+
+func init() {
+	gob.Register(new(Notification))
+	gob.Register(new(NotificationList))
+	gob.Register(new(NotificationUnreadCount))
+}
+
+type (
+	// Notification represents a notification.
+	Notification struct {
+		_ struct{}
+
+		ArchivedOn       *uint64 `json:"archivedOn"`
+		LastUpdatedOn    *uint64 `json:"lastUpdatedOn"`
+		SeenOn           *uint64 `json:"seenOn"`
+		ID               string  `json:"id"`
+		Title            string  `json:"title"`
+		Description      string  `json:"description"`
+		BelongsToAccount string  `json:"belongsToAccount"`
+		BelongsToUser    string  `json:"belongsToUser"`
+		CreatedOn        uint64  `json:"createdOn"`
+	}
+
+	// NotificationList represents a list of notifications.
+	NotificationList struct {
+		_ struct{}
+
+		Notifications []*Notification `json:"notifications"`
+		Pagination
+	}
+
+	// NotificationUnreadCount represents how many of a user's notifications they have yet to see.
+	NotificationUnreadCount struct {
+		_ struct{}
+
+		Count uint64 `json:"count"`
+	}
+
+	// NotificationDatabaseCreationInput represents what a worker could set as input for creating notifications.
+	NotificationDatabaseCreationInput struct {
+		_ struct{}
+
+		ID               string `json:"id"`
+		Title            string `json:"title"`
+		Description      string `json:"description"`
+		BelongsToAccount string `json:"belongsToAccount"`
+		BelongsToUser    string `json:"belongsToUser"`
+	}
+
+	// NotificationDataManager describes a structure capable of storing notifications permanently.
+	NotificationDataManager interface {
+		GetNotifications(ctx context.Context, userID string, filter *QueryFilter) (*NotificationList, error)
+		GetUnreadNotificationCount(ctx context.Context, userID string) (uint64, error)
+		CreateNotification(ctx context.Context, input *NotificationDatabaseCreationInput) (*Notification, error)
+		MarkNotificationAsSeen(ctx context.Context, notificationID, userID string) error
+		MarkAllNotificationsAsSeen(ctx context.Context, userID string) error
+	}
+
+	// NotificationDataService describes a structure capable of serving traffic related to notifications.
+	NotificationDataService interface {
+		ListHandler(res http.ResponseWriter, req *http.Request)
+		UnreadCountHandler(res http.ResponseWriter, req *http.Request)
+		MarkAsSeenHandler(res http.ResponseWriter, req *http.Request)
+		MarkAllAsSeenHandler(res http.ResponseWriter, req *http.Request)
+	}
+)
+
+var _ validation.ValidatableWithContext = (*NotificationDatabaseCreationInput)(nil)
+
+// ValidateWithContext validates a NotificationDatabaseCreationInput.
+func (x *NotificationDatabaseCreationInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(
+		ctx,
+		x,
+		validation.Field(&x.ID, validation.Required),
+		validation.Field(&x.Title, validation.Required),
+		validation.Field(&x.BelongsToAccount, validation.Required),
+		validation.Field(&x.BelongsToUser, validation.Required),
+	)
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationDatabaseCreationInput_Validate(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &NotificationDatabaseCreationInput{
+			ID:               fake.UUID(),
+			Title:            fake.Word(),
+			Description:      fake.Word(),
+			BelongsToAccount: fake.UUID(),
+			BelongsToUser:    fake.UUID(),
+		}
+
+		actual := x.ValidateWithContext(context.Background())
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with invalid structure", func(t *testing.T) {
+		t.Parallel()
+
+		x := &NotificationDatabaseCreationInput{}
+
+		actual := x.ValidateWithContext(context.Background())
+		assert.Error(t, actual)
+	})
+}
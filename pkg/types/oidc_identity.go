@@ -0,0 +1,49 @@
+package types
+
+import (
+	"context"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// OIDCIdentity represents the link between a subject at an OpenID Connect identity provider and a user.
+	OIDCIdentity struct {
+		_ struct{}
+
+		ArchivedOn    *uint64 `json:"archivedOn"`
+		ID            string  `json:"id"`
+		Issuer        string  `json:"issuer"`
+		Subject       string  `json:"subject"`
+		BelongsToUser string  `json:"belongsToUser"`
+		CreatedOn     uint64  `json:"createdOn"`
+	}
+
+	// OIDCIdentityDatabaseCreationInput is used for linking an identity provider's subject to a user.
+	OIDCIdentityDatabaseCreationInput struct {
+		_ struct{}
+
+		ID            string
+		Issuer        string
+		Subject       string
+		BelongsToUser string
+	}
+
+	// OIDCIdentityDataManager describes a structure capable of storing OIDC identities permanently.
+	OIDCIdentityDataManager interface {
+		GetOIDCIdentity(ctx context.Context, issuer, subject string) (*OIDCIdentity, error)
+		CreateOIDCIdentity(ctx context.Context, input *OIDCIdentityDatabaseCreationInput) (*OIDCIdentity, error)
+	}
+)
+
+var _ validation.ValidatableWithContext = (*OIDCIdentityDatabaseCreationInput)(nil)
+
+// ValidateWithContext validates an OIDCIdentityDatabaseCreationInput.
+func (x *OIDCIdentityDatabaseCreationInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.ID, validation.Required),
+		validation.Field(&x.Issuer, validation.Required),
+		validation.Field(&x.Subject, validation.Required),
+		validation.Field(&x.BelongsToUser, validation.Required),
+	)
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCIdentityDatabaseCreationInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &OIDCIdentityDatabaseCreationInput{
+			ID:            t.Name(),
+			Issuer:        t.Name(),
+			Subject:       t.Name(),
+			BelongsToUser: t.Name(),
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid structure", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &OIDCIdentityDatabaseCreationInput{}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+}
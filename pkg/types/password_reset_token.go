@@ -0,0 +1,88 @@
+package types
+
+import (
+	"context"
+	"math"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// PasswordResetToken represents a single-use token a user can redeem to set a new password.
+	PasswordResetToken struct {
+		_ struct{}
+
+		RedeemedOn    *uint64 `json:"redeemedOn"`
+		ID            string  `json:"id"`
+		HashedToken   string  `json:"-"`
+		BelongsToUser string  `json:"belongsToUser"`
+		ExpiresAt     uint64  `json:"expiresAt"`
+		CreatedOn     uint64  `json:"createdOn"`
+	}
+
+	// PasswordResetTokenCreationRequestInput represents what a user could set as input for requesting a password reset.
+	PasswordResetTokenCreationRequestInput struct {
+		_ struct{}
+
+		Username string `json:"username"`
+	}
+
+	// PasswordResetTokenDatabaseCreationInput is used for creating a password reset token.
+	PasswordResetTokenDatabaseCreationInput struct {
+		_ struct{}
+
+		ID            string
+		HashedToken   string
+		BelongsToUser string
+		ExpiresAt     uint64
+	}
+
+	// PasswordResetTokenRedemptionRequestInput represents what a user could set as input for redeeming a password reset token.
+	PasswordResetTokenRedemptionRequestInput struct {
+		_ struct{}
+
+		Token       string `json:"token"`
+		NewPassword string `json:"newPassword"`
+	}
+
+	// PasswordResetTokenDataManager describes a structure capable of storing password reset tokens permanently.
+	PasswordResetTokenDataManager interface {
+		GetPasswordResetTokenByToken(ctx context.Context, hashedToken string) (*PasswordResetToken, error)
+		CreatePasswordResetToken(ctx context.Context, input *PasswordResetTokenDatabaseCreationInput) (*PasswordResetToken, error)
+		RedeemPasswordResetToken(ctx context.Context, passwordResetTokenID string) error
+	}
+)
+
+// IsExpired returns whether a PasswordResetToken's expiry is before the provided time.
+func (x *PasswordResetToken) IsExpired(now uint64) bool {
+	return x.ExpiresAt <= now
+}
+
+var _ validation.ValidatableWithContext = (*PasswordResetTokenCreationRequestInput)(nil)
+
+// ValidateWithContext validates a PasswordResetTokenCreationRequestInput.
+func (x *PasswordResetTokenCreationRequestInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.Username, validation.Required),
+	)
+}
+
+var _ validation.ValidatableWithContext = (*PasswordResetTokenDatabaseCreationInput)(nil)
+
+// ValidateWithContext validates a PasswordResetTokenDatabaseCreationInput.
+func (x *PasswordResetTokenDatabaseCreationInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.ID, validation.Required),
+		validation.Field(&x.HashedToken, validation.Required),
+		validation.Field(&x.BelongsToUser, validation.Required),
+		validation.Field(&x.ExpiresAt, validation.Required),
+	)
+}
+
+// ValidateWithContext validates a PasswordResetTokenRedemptionRequestInput.
+func (x *PasswordResetTokenRedemptionRequestInput) ValidateWithContext(ctx context.Context, minPasswordLength uint8) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.Token, validation.Required),
+		validation.Field(&x.NewPassword, validation.Required, validation.Length(int(minPasswordLength), math.MaxInt8)),
+	)
+}
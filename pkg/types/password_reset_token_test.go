@@ -0,0 +1,101 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordResetToken_IsExpired(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &PasswordResetToken{ExpiresAt: 100}
+
+		assert.False(t, x.IsExpired(99))
+		assert.True(t, x.IsExpired(100))
+		assert.True(t, x.IsExpired(101))
+	})
+}
+
+func TestPasswordResetTokenCreationRequestInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &PasswordResetTokenCreationRequestInput{
+			Username: t.Name(),
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &PasswordResetTokenCreationRequestInput{}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+}
+
+func TestPasswordResetTokenDatabaseCreationInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &PasswordResetTokenDatabaseCreationInput{
+			ID:            t.Name(),
+			HashedToken:   t.Name(),
+			BelongsToUser: t.Name(),
+			ExpiresAt:     123,
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &PasswordResetTokenDatabaseCreationInput{}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+}
+
+func TestPasswordResetTokenRedemptionRequestInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &PasswordResetTokenRedemptionRequestInput{
+			Token:       t.Name(),
+			NewPassword: t.Name(),
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx, 1))
+	})
+
+	T.Run("with password that is too short", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &PasswordResetTokenRedemptionRequestInput{
+			Token:       t.Name(),
+			NewPassword: "a",
+		}
+
+		assert.Error(t, x.ValidateWithContext(ctx, 8))
+	})
+}
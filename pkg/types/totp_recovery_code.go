@@ -0,0 +1,59 @@
+package types
+
+import (
+	"context"
+	"math"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// TOTPRecoveryCodesResponse is what we provide to a user once they've verified their 2FA secret.
+	// The codes are only ever shown once, and each can be redeemed a single time.
+	TOTPRecoveryCodesResponse struct {
+		_ struct{}
+
+		RecoveryCodes []string `json:"recoveryCodes"`
+	}
+
+	// TOTPRecoveryInput represents what a user who has lost their 2FA device provides to enroll a new one.
+	TOTPRecoveryInput struct {
+		_ struct{}
+
+		Username     string `json:"username"`
+		Password     string `json:"password"`
+		RecoveryCode string `json:"recoveryCode"`
+	}
+
+	// TOTPRecoveryResponse is what we provide to a user who has redeemed a recovery code, so that they can enroll a new device.
+	TOTPRecoveryResponse struct {
+		_ struct{}
+
+		UserID          string `json:"userID"`
+		TwoFactorQRCode string `json:"qrCode"`
+		TwoFactorSecret string `json:"twoFactorSecret"`
+	}
+
+	// TOTPRecoveryCodeDatabaseCreationInput is used for creating a TOTP recovery code.
+	TOTPRecoveryCodeDatabaseCreationInput struct {
+		_ struct{}
+
+		ID         string
+		HashedCode string
+	}
+
+	// TOTPRecoveryCodeDataManager describes a structure capable of storing TOTP recovery codes permanently.
+	TOTPRecoveryCodeDataManager interface {
+		CreateTOTPRecoveryCodes(ctx context.Context, userID string, input []*TOTPRecoveryCodeDatabaseCreationInput) error
+		RedeemTOTPRecoveryCode(ctx context.Context, userID, hashedCode string) error
+	}
+)
+
+// ValidateWithContext validates a TOTPRecoveryInput.
+func (x *TOTPRecoveryInput) ValidateWithContext(ctx context.Context, minUsernameLength, minPasswordLength uint8) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.Username, validation.Required, validation.Length(int(minUsernameLength), math.MaxInt8)),
+		validation.Field(&x.Password, validation.Required, validation.Length(int(minPasswordLength), math.MaxInt8)),
+		validation.Field(&x.RecoveryCode, validation.Required),
+	)
+}
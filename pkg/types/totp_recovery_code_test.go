@@ -0,0 +1,37 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTPRecoveryInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &TOTPRecoveryInput{
+			Username:     t.Name(),
+			Password:     t.Name(),
+			RecoveryCode: t.Name(),
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx, 1, 1))
+	})
+
+	T.Run("without recovery code", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &TOTPRecoveryInput{
+			Username: t.Name(),
+			Password: t.Name(),
+		}
+
+		assert.Error(t, x.ValidateWithContext(ctx, 1, 1))
+	})
+}
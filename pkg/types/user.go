@@ -2,8 +2,11 @@
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"math"
 	"net/http"
+	"net/mail"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -23,6 +26,17 @@
 
 var (
 	totpTokenLengthRule = validation.Length(validTOTPTokenLength, validTOTPTokenLength)
+	emailAddressRule    = validation.By(func(value interface{}) error {
+		if s, ok := value.(string); ok && s != "" {
+			if _, err := mail.ParseAddress(s); err != nil {
+				return errInvalidEmailAddress
+			}
+		}
+
+		return nil
+	})
+
+	errInvalidEmailAddress = errors.New("must be a valid email address")
 )
 
 type (
@@ -40,6 +54,7 @@
 		ServiceAccountStatus      accountStatus `json:"reputation"`
 		ReputationExplanation     string        `json:"reputationExplanation"`
 		Username                  string        `json:"username"`
+		EmailAddress              string        `json:"emailAddress"`
 		TwoFactorSecret           string        `json:"-"`
 		HashedPassword            string        `json:"-"`
 		ID                        string        `json:"id"`
@@ -71,8 +86,9 @@
 	UserRegistrationInput struct {
 		_ struct{}
 
-		Username string `json:"username"`
-		Password string `json:"password"`
+		Username     string `json:"username"`
+		Password     string `json:"password"`
+		EmailAddress string `json:"emailAddress"`
 	}
 
 	// UserDataStoreCreationInput is used by the User creation route to communicate with the data store.
@@ -81,6 +97,7 @@
 
 		ID              string `json:"-"`
 		Username        string `json:"-"`
+		EmailAddress    string `json:"-"`
 		HashedPassword  string `json:"-"`
 		TwoFactorSecret string `json:"-"`
 	}
@@ -103,9 +120,11 @@
 	UserLoginInput struct {
 		_ struct{}
 
-		Username  string `json:"username"`
-		Password  string `json:"password"`
-		TOTPToken string `json:"totpToken"`
+		Username              string          `json:"username"`
+		Password              string          `json:"password"`
+		TOTPToken             string          `json:"totpToken"`
+		WebAuthnCeremonyToken string          `json:"webAuthnCeremonyToken,omitempty"`
+		WebAuthnAssertion     json.RawMessage `json:"webAuthnAssertion,omitempty"`
 	}
 
 	// PasswordUpdateInput represents input a User would provide when updating their passwords.
@@ -175,9 +194,15 @@
 		UpdatePasswordHandler(res http.ResponseWriter, req *http.Request)
 		AvatarUploadHandler(res http.ResponseWriter, req *http.Request)
 		ArchiveHandler(res http.ResponseWriter, req *http.Request)
+		RequestPasswordResetHandler(res http.ResponseWriter, req *http.Request)
+		RedeemPasswordResetHandler(res http.ResponseWriter, req *http.Request)
+		TOTPRecoveryHandler(res http.ResponseWriter, req *http.Request)
 
 		RegisterUser(ctx context.Context, registrationInput *UserRegistrationInput) (*UserCreationResponse, error)
-		VerifyUserTwoFactorSecret(ctx context.Context, input *TOTPSecretVerificationInput) error
+		VerifyUserTwoFactorSecret(ctx context.Context, input *TOTPSecretVerificationInput) (*TOTPRecoveryCodesResponse, error)
+		RequestPasswordReset(ctx context.Context, input *PasswordResetTokenCreationRequestInput) error
+		RedeemPasswordResetToken(ctx context.Context, input *PasswordResetTokenRedemptionRequestInput) error
+		RecoverTwoFactorSecret(ctx context.Context, input *TOTPRecoveryInput) (*TOTPRecoveryResponse, error)
 	}
 )
 
@@ -219,15 +244,20 @@ func (i *UserRegistrationInput) ValidateWithContext(ctx context.Context, minUser
 	return validation.ValidateStructWithContext(ctx, i,
 		validation.Field(&i.Username, validation.Required, validation.Length(int(minUsernameLength), math.MaxInt8)),
 		validation.Field(&i.Password, validation.Required, validation.Length(int(minPasswordLength), math.MaxInt8)),
+		validation.Field(&i.EmailAddress, emailAddressRule),
 	)
 }
 
-// ValidateWithContext ensures our provided UserLoginInput meets expectations.
+// ValidateWithContext ensures our provided UserLoginInput meets expectations. A TOTP token is only
+// required when the second factor isn't a WebAuthn assertion.
 func (i *UserLoginInput) ValidateWithContext(ctx context.Context, minUsernameLength, minPasswordLength uint8) error {
+	usingWebAuthn := len(i.WebAuthnAssertion) > 0
+
 	return validation.ValidateStructWithContext(ctx, i,
 		validation.Field(&i.Username, validation.Required, validation.Length(int(minUsernameLength), math.MaxInt8)),
 		validation.Field(&i.Password, validation.Required, validation.Length(int(minPasswordLength), math.MaxInt8)),
-		validation.Field(&i.TOTPToken, validation.Required, totpTokenLengthRule),
+		validation.Field(&i.TOTPToken, validation.When(!usingWebAuthn, validation.Required), totpTokenLengthRule),
+		validation.Field(&i.WebAuthnCeremonyToken, validation.When(usingWebAuthn, validation.Required)),
 	)
 }
 
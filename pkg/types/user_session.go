@@ -0,0 +1,127 @@
+package types
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	useragent "github.com/mssola/user_agent"
+)
+
+const (
+	// UserSessionDataType indicates an event is related to a user session.
+	UserSessionDataType dataType = "user_session"
+)
+
+type (
+	// UserSession represents a cookie-backed session a user has established with the service.
+	UserSession struct {
+		_ struct{}
+
+		ArchivedOn      *uint64 `json:"archivedOn"`
+		ID              string  `json:"id"`
+		Token           string  `json:"-"`
+		BelongsToUser   string  `json:"belongsToUser"`
+		ActiveAccount   string  `json:"activeAccount"`
+		IPAddress       string  `json:"ipAddress"`
+		UserAgent       string  `json:"userAgent"`
+		Browser         string  `json:"browser"`
+		BrowserVersion  string  `json:"browserVersion"`
+		OperatingSystem string  `json:"operatingSystem"`
+		Platform        string  `json:"platform"`
+		CreatedOn       uint64  `json:"createdOn"`
+		LastSeenOn      uint64  `json:"lastSeenOn"`
+		Mobile          bool    `json:"mobile"`
+		Current         bool    `json:"current"`
+	}
+
+	// UserSessionList represents a list of user sessions.
+	UserSessionList struct {
+		_ struct{}
+
+		UserSessions []*UserSession `json:"userSessions"`
+		Pagination
+	}
+
+	// UserSessionRevocation describes which of a user's sessions were revoked. A revocation that names no session
+	// revoked every session the user had, save for the one it says was kept, if any.
+	UserSessionRevocation struct {
+		_ struct{}
+
+		UserSessionID     string `json:"userSessionID,omitempty"`
+		KeptUserSessionID string `json:"keptUserSessionID,omitempty"`
+	}
+
+	// UserSessionDatabaseCreationInput is used for recording a new user session.
+	UserSessionDatabaseCreationInput struct {
+		_ struct{}
+
+		ID              string
+		Token           string
+		BelongsToUser   string
+		ActiveAccount   string
+		IPAddress       string
+		UserAgent       string
+		Browser         string
+		BrowserVersion  string
+		OperatingSystem string
+		Platform        string
+		Mobile          bool
+	}
+
+	// UserSessionDataManager describes a structure capable of storing user session metadata permanently.
+	UserSessionDataManager interface {
+		GetUserSessionsForUser(ctx context.Context, userID string, filter *QueryFilter) (*UserSessionList, error)
+		CreateUserSession(ctx context.Context, input *UserSessionDatabaseCreationInput) (*UserSession, error)
+		UpdateUserSessionToken(ctx context.Context, userSessionID, token, activeAccountID string) error
+		MarkUserSessionAsSeen(ctx context.Context, userSessionID string) error
+		RevokeUserSession(ctx context.Context, userSessionID, userID string) error
+		RevokeUserSessionsForUser(ctx context.Context, userID, exceptUserSessionID string) error
+	}
+)
+
+// BuildUserSessionDatabaseCreationInput builds a UserSessionDatabaseCreationInput from the request that began a session.
+func BuildUserSessionDatabaseCreationInput(req *http.Request, userSessionID, token, userID, activeAccountID string) *UserSessionDatabaseCreationInput {
+	ua := useragent.New(req.UserAgent())
+	browser, browserVersion := ua.Browser()
+
+	ipAddress := req.RemoteAddr
+	if host, _, err := net.SplitHostPort(ipAddress); err == nil {
+		ipAddress = host
+	}
+
+	return &UserSessionDatabaseCreationInput{
+		ID:              userSessionID,
+		Token:           token,
+		BelongsToUser:   userID,
+		ActiveAccount:   activeAccountID,
+		IPAddress:       ipAddress,
+		UserAgent:       req.UserAgent(),
+		Browser:         browser,
+		BrowserVersion:  browserVersion,
+		OperatingSystem: ua.OS(),
+		Platform:        ua.Platform(),
+		Mobile:          ua.Mobile(),
+	}
+}
+
+var _ validation.ValidatableWithContext = (*UserSessionDatabaseCreationInput)(nil)
+
+// ValidateWithContext validates a UserSessionDatabaseCreationInput.
+func (x *UserSessionDatabaseCreationInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.ID, validation.Required),
+		validation.Field(&x.Token, validation.Required),
+		validation.Field(&x.BelongsToUser, validation.Required),
+	)
+}
+
+// Revokes returns whether the revocation ended a given session.
+func (x *UserSessionRevocation) Revokes(userSessionID string) bool {
+	if x.UserSessionID != "" {
+		return userSessionID == x.UserSessionID
+	}
+
+	return x.KeptUserSessionID == "" || userSessionID != x.KeptUserSessionID
+}
@@ -0,0 +1,102 @@
+package types
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildUserSessionDatabaseCreationInput(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/90.0.4430.93 Safari/537.36")
+
+		actual := BuildUserSessionDatabaseCreationInput(req, "session", "token", "user", "account")
+
+		assert.Equal(t, "session", actual.ID)
+		assert.Equal(t, "token", actual.Token)
+		assert.Equal(t, "user", actual.BelongsToUser)
+		assert.Equal(t, "account", actual.ActiveAccount)
+		assert.Equal(t, "192.0.2.1", actual.IPAddress)
+		assert.Equal(t, "Chrome", actual.Browser)
+		assert.Equal(t, "90.0.4430.93", actual.BrowserVersion)
+		assert.Equal(t, "Linux x86_64", actual.OperatingSystem)
+		assert.False(t, actual.Mobile)
+	})
+
+	T.Run("without port in remote address", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = "192.0.2.1"
+
+		actual := BuildUserSessionDatabaseCreationInput(req, "session", "token", "user", "account")
+
+		assert.Equal(t, "192.0.2.1", actual.IPAddress)
+	})
+}
+
+func TestUserSessionDatabaseCreationInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &UserSessionDatabaseCreationInput{
+			ID:            t.Name(),
+			Token:         t.Name(),
+			BelongsToUser: t.Name(),
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid structure", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &UserSessionDatabaseCreationInput{}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+}
+
+func TestUserSessionRevocation_Revokes(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with one session", func(t *testing.T) {
+		t.Parallel()
+
+		x := &UserSessionRevocation{UserSessionID: "one"}
+
+		assert.True(t, x.Revokes("one"))
+		assert.False(t, x.Revokes("two"))
+	})
+
+	T.Run("with every session", func(t *testing.T) {
+		t.Parallel()
+
+		x := &UserSessionRevocation{}
+
+		assert.True(t, x.Revokes("one"))
+		assert.True(t, x.Revokes(""))
+	})
+
+	T.Run("with every other session", func(t *testing.T) {
+		t.Parallel()
+
+		x := &UserSessionRevocation{KeptUserSessionID: "one"}
+
+		assert.False(t, x.Revokes("one"))
+		assert.True(t, x.Revokes("two"))
+	})
+}
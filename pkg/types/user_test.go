@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -114,6 +115,32 @@ func TestUserCreationInput_ValidateWithContext(T *testing.T) {
 
 		assert.NoError(t, x.ValidateWithContext(ctx, 1, 1))
 	})
+
+	T.Run("with email address", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &UserRegistrationInput{
+			Username:     t.Name(),
+			Password:     t.Name(),
+			EmailAddress: "things@stuff.com",
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx, 1, 1))
+	})
+
+	T.Run("with invalid email address", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &UserRegistrationInput{
+			Username:     t.Name(),
+			Password:     t.Name(),
+			EmailAddress: t.Name(),
+		}
+
+		assert.Error(t, x.ValidateWithContext(ctx, 1, 1))
+	})
 }
 
 func TestUserLoginInput_ValidateWithContext(T *testing.T) {
@@ -131,4 +158,43 @@ func TestUserLoginInput_ValidateWithContext(T *testing.T) {
 
 		assert.NoError(t, x.ValidateWithContext(ctx, 1, 1))
 	})
+
+	T.Run("with WebAuthn assertion instead of TOTP token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &UserLoginInput{
+			Username:              t.Name(),
+			Password:              t.Name(),
+			WebAuthnCeremonyToken: t.Name(),
+			WebAuthnAssertion:     json.RawMessage(`{}`),
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx, 1, 1))
+	})
+
+	T.Run("without any second factor", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &UserLoginInput{
+			Username: t.Name(),
+			Password: t.Name(),
+		}
+
+		assert.Error(t, x.ValidateWithContext(ctx, 1, 1))
+	})
+
+	T.Run("with WebAuthn assertion but no ceremony token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &UserLoginInput{
+			Username:          t.Name(),
+			Password:          t.Name(),
+			WebAuthnAssertion: json.RawMessage(`{}`),
+		}
+
+		assert.Error(t, x.ValidateWithContext(ctx, 1, 1))
+	})
 }
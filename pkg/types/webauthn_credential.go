@@ -0,0 +1,125 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/duo-labs/webauthn/protocol"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// WebAuthnCredential represents a hardware key or passkey a user has registered as a second factor.
+	WebAuthnCredential struct {
+		_ struct{}
+
+		ArchivedOn      *uint64 `json:"archivedOn"`
+		LastUsedOn      *uint64 `json:"lastUsedOn"`
+		ID              string  `json:"id"`
+		Name            string  `json:"name"`
+		BelongsToUser   string  `json:"belongsToUser"`
+		AttestationType string  `json:"attestationType"`
+		CredentialID    []byte  `json:"credentialID"`
+		PublicKey       []byte  `json:"-"`
+		AAGUID          []byte  `json:"aaguid"`
+		CreatedOn       uint64  `json:"createdOn"`
+		SignCount       uint32  `json:"signCount"`
+	}
+
+	// WebAuthnCredentialList represents a list of WebAuthn credentials.
+	WebAuthnCredentialList struct {
+		_ struct{}
+
+		WebAuthnCredentials []*WebAuthnCredential `json:"webAuthnCredentials"`
+		Pagination
+	}
+
+	// WebAuthnCredentialDatabaseCreationInput is used for recording a newly registered WebAuthn credential.
+	WebAuthnCredentialDatabaseCreationInput struct {
+		_ struct{}
+
+		ID              string
+		Name            string
+		BelongsToUser   string
+		AttestationType string
+		CredentialID    []byte
+		PublicKey       []byte
+		AAGUID          []byte
+		SignCount       uint32
+	}
+
+	// WebAuthnRegistrationOptions is what we respond with when a user begins registering a WebAuthn credential.
+	// The options are meant to be handed to navigator.credentials.create, and the ceremony token returned with the result.
+	WebAuthnRegistrationOptions struct {
+		_ struct{}
+
+		Options       *protocol.CredentialCreation `json:"options"`
+		CeremonyToken string                       `json:"ceremonyToken"`
+	}
+
+	// WebAuthnRegistrationFinishInput represents what a user provides to finish registering a WebAuthn credential.
+	WebAuthnRegistrationFinishInput struct {
+		_ struct{}
+
+		Name          string          `json:"name"`
+		CeremonyToken string          `json:"ceremonyToken"`
+		Credential    json.RawMessage `json:"credential"`
+	}
+
+	// WebAuthnLoginBeginInput represents what a user provides to begin logging in with a WebAuthn credential.
+	WebAuthnLoginBeginInput struct {
+		_ struct{}
+
+		Username string `json:"username"`
+	}
+
+	// WebAuthnLoginOptions is what we respond with when a user begins logging in with a WebAuthn credential.
+	// The options are meant to be handed to navigator.credentials.get, and the ceremony token returned with the result.
+	WebAuthnLoginOptions struct {
+		_ struct{}
+
+		Options       *protocol.CredentialAssertion `json:"options"`
+		CeremonyToken string                        `json:"ceremonyToken"`
+	}
+
+	// WebAuthnCredentialDataManager describes a structure capable of storing WebAuthn credentials permanently.
+	WebAuthnCredentialDataManager interface {
+		GetWebAuthnCredentialsForUser(ctx context.Context, userID string) (*WebAuthnCredentialList, error)
+		CreateWebAuthnCredential(ctx context.Context, input *WebAuthnCredentialDatabaseCreationInput) (*WebAuthnCredential, error)
+		MarkWebAuthnCredentialAsUsed(ctx context.Context, webAuthnCredentialID string, signCount uint32) error
+		ArchiveWebAuthnCredential(ctx context.Context, webAuthnCredentialID, userID string) error
+	}
+)
+
+var _ validation.ValidatableWithContext = (*WebAuthnCredentialDatabaseCreationInput)(nil)
+
+// ValidateWithContext validates a WebAuthnCredentialDatabaseCreationInput.
+func (x *WebAuthnCredentialDatabaseCreationInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.ID, validation.Required),
+		validation.Field(&x.Name, validation.Required),
+		validation.Field(&x.BelongsToUser, validation.Required),
+		validation.Field(&x.CredentialID, validation.Required),
+		validation.Field(&x.PublicKey, validation.Required),
+	)
+}
+
+var _ validation.ValidatableWithContext = (*WebAuthnRegistrationFinishInput)(nil)
+
+// ValidateWithContext validates a WebAuthnRegistrationFinishInput.
+func (x *WebAuthnRegistrationFinishInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.Name, validation.Required),
+		validation.Field(&x.CeremonyToken, validation.Required),
+		validation.Field(&x.Credential, validation.Required),
+	)
+}
+
+var _ validation.ValidatableWithContext = (*WebAuthnLoginBeginInput)(nil)
+
+// ValidateWithContext validates a WebAuthnLoginBeginInput.
+func (x *WebAuthnLoginBeginInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.Username, validation.Required),
+	)
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebAuthnCredentialDatabaseCreationInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &WebAuthnCredentialDatabaseCreationInput{
+			ID:            t.Name(),
+			Name:          t.Name(),
+			BelongsToUser: t.Name(),
+			CredentialID:  []byte(t.Name()),
+			PublicKey:     []byte(t.Name()),
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid structure", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &WebAuthnCredentialDatabaseCreationInput{}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+}
+
+func TestWebAuthnRegistrationFinishInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &WebAuthnRegistrationFinishInput{
+			Name:          t.Name(),
+			CeremonyToken: t.Name(),
+			Credential:    json.RawMessage(`{}`),
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid structure", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &WebAuthnRegistrationFinishInput{}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+}
+
+func TestWebAuthnLoginBeginInput_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &WebAuthnLoginBeginInput{
+			Username: t.Name(),
+		}
+
+		assert.NoError(t, x.ValidateWithContext(ctx))
+	})
+
+	T.Run("with invalid structure", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		x := &WebAuthnLoginBeginInput{}
+
+		assert.Error(t, x.ValidateWithContext(ctx))
+	})
+}
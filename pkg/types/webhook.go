@@ -2,6 +2,10 @@
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
 	"net/http"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -10,6 +14,13 @@
 const (
 	// WebhookDataType indicates an event is webhook-related.
 	WebhookDataType dataType = "webhook"
+
+	// WebhookSignatureHeaderKey is the header webhook payload signatures are sent in.
+	WebhookSignatureHeaderKey = "X-Todo-Signature"
+	// WebhookSignatureTimestampHeaderKey is the header the timestamp covered by a webhook payload signature is sent in.
+	WebhookSignatureTimestampHeaderKey = "X-Todo-Signature-Timestamp"
+	// WebhookSignatureSeparator separates signatures when more than one is valid for a payload.
+	WebhookSignatureSeparator = ","
 )
 
 type (
@@ -17,18 +28,37 @@
 	Webhook struct {
 		_ struct{}
 
-		LastUpdatedOn    *uint64  `json:"lastUpdatedOn"`
-		ArchivedOn       *uint64  `json:"archivedOn"`
-		Name             string   `json:"name"`
-		URL              string   `json:"url"`
-		Method           string   `json:"method"`
-		ContentType      string   `json:"contentType"`
-		ID               string   `json:"id"`
-		BelongsToAccount string   `json:"belongsToAccount"`
-		Events           []string `json:"events"`
-		DataTypes        []string `json:"dataTypes"`
-		Topics           []string `json:"topics"`
-		CreatedOn        uint64   `json:"createdOn"`
+		LastUpdatedOn                  *uint64  `json:"lastUpdatedOn"`
+		ArchivedOn                     *uint64  `json:"archivedOn"`
+		PreviousSigningSecretExpiresOn *uint64  `json:"-" xml:"-"`
+		Name                           string   `json:"name"`
+		URL                            string   `json:"url"`
+		Method                         string   `json:"method"`
+		ContentType                    string   `json:"contentType"`
+		ID                             string   `json:"id"`
+		BelongsToAccount               string   `json:"belongsToAccount"`
+		SigningSecret                  string   `json:"-" xml:"-"`
+		PreviousSigningSecret          string   `json:"-" xml:"-"`
+		Events                         []string `json:"events"`
+		DataTypes                      []string `json:"dataTypes"`
+		Topics                         []string `json:"topics"`
+		CreatedOn                      uint64   `json:"createdOn"`
+	}
+
+	// WebhookCreationResponse is a struct for informing users of what their webhook's signing secret is.
+	WebhookCreationResponse struct {
+		_ struct{}
+
+		ID            string `json:"id"`
+		SigningSecret string `json:"signingSecret"`
+	}
+
+	// WebhookSecretRotationResponse is a struct for informing users of what their webhook's new signing secret is.
+	WebhookSecretRotationResponse struct {
+		_ struct{}
+
+		SigningSecret                  string `json:"signingSecret"`
+		PreviousSigningSecretExpiresOn uint64 `json:"previousSigningSecretExpiresOn"`
 	}
 
 	// WebhookCreationInput represents what a User could set as input for creating a webhook.
@@ -56,6 +86,21 @@
 		URL              string   `json:"url"`
 		Method           string   `json:"method"`
 		BelongsToAccount string   `json:"belongsToAccount"`
+		SigningSecret    string   `json:"signingSecret"`
+		Events           []string `json:"events"`
+		DataTypes        []string `json:"dataTypes"`
+		Topics           []string `json:"topics"`
+	}
+
+	// WebhookUpdateInput represents what a User could set as input for updating a webhook.
+	WebhookUpdateInput struct {
+		_ struct{}
+
+		Name             string   `json:"name"`
+		ContentType      string   `json:"contentType"`
+		URL              string   `json:"url"`
+		Method           string   `json:"method"`
+		BelongsToAccount string   `json:"-"`
 		Events           []string `json:"events"`
 		DataTypes        []string `json:"dataTypes"`
 		Topics           []string `json:"topics"`
@@ -69,6 +114,41 @@
 		Pagination
 	}
 
+	// WebhookDeliveryAttempt represents a single attempt to deliver a payload to a webhook.
+	WebhookDeliveryAttempt struct {
+		_ struct{}
+
+		ID                    string `json:"id"`
+		RequestBody           string `json:"requestBody"`
+		ErrorText             string `json:"errorText"`
+		BelongsToWebhook      string `json:"belongsToWebhook"`
+		CreatedOn             uint64 `json:"createdOn"`
+		LatencyInMilliseconds uint64 `json:"latencyInMilliseconds"`
+		ResponseStatus        uint16 `json:"responseStatus"`
+		AttemptNumber         uint8  `json:"attemptNumber"`
+	}
+
+	// WebhookDeliveryAttemptList represents a list of webhook delivery attempts.
+	WebhookDeliveryAttemptList struct {
+		_ struct{}
+
+		Attempts []*WebhookDeliveryAttempt `json:"attempts"`
+		Pagination
+	}
+
+	// WebhookDeliveryAttemptDatabaseCreationInput represents what a worker could set as input for recording a delivery attempt.
+	WebhookDeliveryAttemptDatabaseCreationInput struct {
+		_ struct{}
+
+		ID                    string `json:"id"`
+		RequestBody           string `json:"requestBody"`
+		ErrorText             string `json:"errorText"`
+		BelongsToWebhook      string `json:"belongsToWebhook"`
+		LatencyInMilliseconds uint64 `json:"latencyInMilliseconds"`
+		ResponseStatus        uint16 `json:"responseStatus"`
+		AttemptNumber         uint8  `json:"attemptNumber"`
+	}
+
 	// WebhookDataManager describes a structure capable of storing webhooks.
 	WebhookDataManager interface {
 		WebhookExists(ctx context.Context, webhookID, accountID string) (bool, error)
@@ -76,7 +156,12 @@
 		GetAllWebhooksCount(ctx context.Context) (uint64, error)
 		GetWebhooks(ctx context.Context, accountID string, filter *QueryFilter) (*WebhookList, error)
 		CreateWebhook(ctx context.Context, input *WebhookDatabaseCreationInput) (*Webhook, error)
+		UpdateWebhook(ctx context.Context, updated *Webhook) error
 		ArchiveWebhook(ctx context.Context, webhookID, accountID string) error
+		RotateWebhookSigningSecret(ctx context.Context, webhookID, accountID, newSecret string, previousSecretExpiresOn uint64) error
+		GetWebhookDeliveryAttempt(ctx context.Context, attemptID, webhookID string) (*WebhookDeliveryAttempt, error)
+		GetWebhookDeliveryAttempts(ctx context.Context, webhookID string, filter *QueryFilter) (*WebhookDeliveryAttemptList, error)
+		CreateWebhookDeliveryAttempt(ctx context.Context, input *WebhookDeliveryAttemptDatabaseCreationInput) (*WebhookDeliveryAttempt, error)
 	}
 
 	// WebhookDataService describes a structure capable of serving traffic related to webhooks.
@@ -84,10 +169,57 @@
 		ListHandler(res http.ResponseWriter, req *http.Request)
 		CreateHandler(res http.ResponseWriter, req *http.Request)
 		ReadHandler(res http.ResponseWriter, req *http.Request)
+		UpdateHandler(res http.ResponseWriter, req *http.Request)
 		ArchiveHandler(res http.ResponseWriter, req *http.Request)
+		RotateSecretHandler(res http.ResponseWriter, req *http.Request)
+		ListDeliveryAttemptsHandler(res http.ResponseWriter, req *http.Request)
+		RedeliverHandler(res http.ResponseWriter, req *http.Request)
 	}
 )
 
+// Update merges a WebhookUpdateInput with a webhook, and returns a summary of what changed.
+func (w *Webhook) Update(input *WebhookUpdateInput) []*FieldChangeSummary {
+	var out []*FieldChangeSummary
+
+	if input.Name != "" && input.Name != w.Name {
+		out = append(out, &FieldChangeSummary{FieldName: "name", OldValue: w.Name, NewValue: input.Name})
+		w.Name = input.Name
+	}
+
+	if input.ContentType != "" && input.ContentType != w.ContentType {
+		out = append(out, &FieldChangeSummary{FieldName: "contentType", OldValue: w.ContentType, NewValue: input.ContentType})
+		w.ContentType = input.ContentType
+	}
+
+	if input.URL != "" && input.URL != w.URL {
+		out = append(out, &FieldChangeSummary{FieldName: "url", OldValue: w.URL, NewValue: input.URL})
+		w.URL = input.URL
+	}
+
+	if input.Method != "" && input.Method != w.Method {
+		out = append(out, &FieldChangeSummary{FieldName: "method", OldValue: w.Method, NewValue: input.Method})
+		w.Method = input.Method
+	}
+
+	if len(input.Events) > 0 {
+		out = append(out, &FieldChangeSummary{FieldName: "events", OldValue: w.Events, NewValue: input.Events})
+		w.Events = input.Events
+	}
+
+	if len(input.DataTypes) > 0 {
+		out = append(out, &FieldChangeSummary{FieldName: "dataTypes", OldValue: w.DataTypes, NewValue: input.DataTypes})
+		w.DataTypes = input.DataTypes
+	}
+
+	// topics are optional, so nil means "leave them alone" while an empty slice clears them.
+	if input.Topics != nil {
+		out = append(out, &FieldChangeSummary{FieldName: "topics", OldValue: w.Topics, NewValue: input.Topics})
+		w.Topics = input.Topics
+	}
+
+	return out
+}
+
 var _ validation.ValidatableWithContext = (*WebhookCreationInput)(nil)
 
 // ValidateWithContext validates a WebhookCreationInput.
@@ -130,5 +262,40 @@ func (w *WebhookDatabaseCreationInput) ValidateWithContext(ctx context.Context)
 		validation.Field(&w.Events, validation.Required),
 		validation.Field(&w.DataTypes, validation.Required),
 		validation.Field(&w.BelongsToAccount, validation.Required),
+		validation.Field(&w.SigningSecret, validation.Required),
+	)
+}
+
+var _ validation.ValidatableWithContext = (*WebhookUpdateInput)(nil)
+
+// ValidateWithContext validates a WebhookUpdateInput.
+func (w *WebhookUpdateInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, w,
+		validation.Field(&w.Name, validation.Required),
+		validation.Field(&w.URL, &urlValidator{}),
+		validation.Field(&w.Method, validation.In(http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete)),
+		validation.Field(&w.ContentType, validation.In("application/json", "application/xml")),
 	)
 }
+
+var _ validation.ValidatableWithContext = (*WebhookDeliveryAttemptDatabaseCreationInput)(nil)
+
+// ValidateWithContext validates a WebhookDeliveryAttemptDatabaseCreationInput.
+func (w *WebhookDeliveryAttemptDatabaseCreationInput) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, w,
+		validation.Field(&w.ID, validation.Required),
+		validation.Field(&w.BelongsToWebhook, validation.Required),
+		validation.Field(&w.AttemptNumber, validation.Required),
+	)
+}
+
+// SignWebhookPayload produces the signature a webhook receiver should expect for a given payload, timestamp, and secret.
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+
+	// hash.Hash.Write never returns an error.
+	_, _ = mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	_, _ = mac.Write(payload)
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
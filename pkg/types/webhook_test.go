@@ -78,3 +78,113 @@ func TestWebhookCreationInput_Validate(T *testing.T) {
 		assert.Error(t, exampleInput.ValidateWithContext(context.Background()))
 	})
 }
+
+func TestWebhook_Update(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &Webhook{
+			Name:        "whatever",
+			ContentType: "application/xml",
+			URL:         "https://blah.verygoodsoftwarenotvirus.ru",
+			Method:      http.MethodPatch,
+			Events:      []string{"more_things"},
+			DataTypes:   []string{"new_stuff"},
+			Topics:      []string{"blah-blah"},
+		}
+
+		input := &WebhookUpdateInput{
+			Name:        "something else",
+			ContentType: "application/json",
+			URL:         "https://blah.verygoodsoftwarenotvirus.ru/other",
+			Method:      http.MethodPost,
+			Events:      []string{"fewer_things"},
+			DataTypes:   []string{"old_stuff"},
+			Topics:      []string{},
+		}
+
+		changes := x.Update(input)
+
+		assert.Len(t, changes, 7)
+		assert.Equal(t, input.Name, x.Name)
+		assert.Equal(t, input.ContentType, x.ContentType)
+		assert.Equal(t, input.URL, x.URL)
+		assert.Equal(t, input.Method, x.Method)
+		assert.Equal(t, input.Events, x.Events)
+		assert.Equal(t, input.DataTypes, x.DataTypes)
+		assert.Empty(t, x.Topics)
+	})
+
+	T.Run("with empty input", func(t *testing.T) {
+		t.Parallel()
+
+		x := &Webhook{
+			Name:   "whatever",
+			Events: []string{"more_things"},
+			Topics: []string{"blah-blah"},
+		}
+		expected := *x
+
+		changes := x.Update(&WebhookUpdateInput{})
+
+		assert.Empty(t, changes)
+		assert.Equal(t, expected.Name, x.Name)
+		assert.Equal(t, expected.Events, x.Events)
+		assert.Equal(t, expected.Topics, x.Topics)
+	})
+}
+
+func TestWebhookUpdateInput_Validate(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &WebhookUpdateInput{
+			Name:        "whatever",
+			ContentType: "application/xml",
+			Method:      http.MethodPatch,
+		}
+
+		assert.Nil(t, x.ValidateWithContext(context.Background()))
+	})
+
+	T.Run("with empty name", func(t *testing.T) {
+		t.Parallel()
+
+		x := &WebhookUpdateInput{}
+
+		assert.Error(t, x.ValidateWithContext(context.Background()))
+	})
+
+	T.Run("bad method", func(t *testing.T) {
+		t.Parallel()
+
+		x := &WebhookUpdateInput{
+			Name:   "whatever",
+			Method: "balogna",
+		}
+
+		assert.Error(t, x.ValidateWithContext(context.Background()))
+	})
+}
+
+func TestSignWebhookPayload(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		exampleSecret := "blahblahblah"
+		examplePayload := []byte(`{"things":"stuff"}`)
+
+		expected := SignWebhookPayload(exampleSecret, 123, examplePayload)
+
+		assert.NotEmpty(t, expected)
+		assert.Equal(t, expected, SignWebhookPayload(exampleSecret, 123, examplePayload))
+		assert.NotEqual(t, expected, SignWebhookPayload(exampleSecret, 124, examplePayload))
+		assert.NotEqual(t, expected, SignWebhookPayload("different", 123, examplePayload))
+	})
+}
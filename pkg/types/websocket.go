@@ -1,12 +1,170 @@
 package types
 
 import (
+	"context"
 	"net/http"
+	"net/url"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 type (
 	// WebsocketDataService describes a structure capable of serving traffic related to notifications.
 	WebsocketDataService interface {
 		SubscribeHandler(res http.ResponseWriter, req *http.Request)
+		EventStreamHandler(res http.ResponseWriter, req *http.Request)
+		ItemPresenceHandler(res http.ResponseWriter, req *http.Request)
+		PresenceReportHandler(res http.ResponseWriter, req *http.Request)
+	}
+
+	// WebsocketConnectionCount is how many websocket connections a user or account has open.
+	WebsocketConnectionCount struct {
+		_ struct{}
+
+		ID          string `json:"id"`
+		Connections int    `json:"connections"`
 	}
+
+	// WebsocketPresenceReport describes the websocket connections open to one server instance, along with the
+	// users and accounts that have the most of them.
+	WebsocketPresenceReport struct {
+		_ struct{}
+
+		TopUsers    []*WebsocketConnectionCount `json:"topUsers"`
+		TopAccounts []*WebsocketConnectionCount `json:"topAccounts"`
+		Connections int                         `json:"connections"`
+		Users       int                         `json:"users"`
+		Accounts    int                         `json:"accounts"`
+	}
+
+	// DataChangeSubscriptionFilter is what a subscriber sends to narrow down which DataChangeMessages they receive.
+	// Empty fields don't filter anything, so the zero value matches every message.
+	DataChangeSubscriptionFilter struct {
+		_ struct{}
+
+		DataTypes    []string `json:"dataTypes"`
+		MessageTypes []string `json:"messageTypes"`
+		ItemIDs      []string `json:"itemIDs"`
+	}
+)
+
+const (
+	dataTypeSubscriptionQueryKey    = "dataType"
+	messageTypeSubscriptionQueryKey = "messageType"
+	itemIDSubscriptionQueryKey      = "itemID"
 )
+
+var _ validation.ValidatableWithContext = (*DataChangeSubscriptionFilter)(nil)
+
+// ValidateWithContext validates a DataChangeSubscriptionFilter.
+func (x *DataChangeSubscriptionFilter) ValidateWithContext(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, x,
+		validation.Field(&x.DataTypes, validation.Each(validation.In(
+			string(ItemDataType),
+			string(WebhookDataType),
+			string(UserMembershipDataType),
+			string(NotificationDataType),
+			string(ItemPresenceDataType),
+			string(UserSessionDataType),
+		))),
+		validation.Field(&x.MessageTypes, validation.Each(validation.In(
+			CreatedMessageType,
+			UpdatedMessageType,
+			ArchivedMessageType,
+			WebhookRedeliveryMessageType,
+			OwnershipTransferredMessageType,
+			ConflictMessageType,
+			RevokedMessageType,
+			ViewingItemPresenceActivity,
+			EditingItemPresenceActivity,
+			LeftItemPresenceActivity,
+		))),
+		validation.Field(&x.ItemIDs, validation.Each(validation.Required)),
+	)
+}
+
+// Matches returns whether a given DataChangeMessage passes the filter.
+func (x *DataChangeSubscriptionFilter) Matches(msg *DataChangeMessage) bool {
+	if x == nil {
+		return true
+	}
+
+	if len(x.DataTypes) > 0 && !containsString(x.DataTypes, string(msg.DataType)) {
+		return false
+	}
+
+	if len(x.MessageTypes) > 0 && !containsString(x.MessageTypes, msg.MessageType) {
+		return false
+	}
+
+	if len(x.ItemIDs) > 0 {
+		if msg.DataType != ItemDataType && msg.DataType != ItemPresenceDataType {
+			return false
+		}
+
+		itemID := msg.RelevantID
+		switch {
+		case msg.Item != nil:
+			itemID = msg.Item.ID
+		case msg.ItemPresence != nil:
+			itemID = msg.ItemPresence.ItemID
+		}
+
+		if !containsString(x.ItemIDs, itemID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ToValues returns a url.Values from a DataChangeSubscriptionFilter.
+func (x *DataChangeSubscriptionFilter) ToValues() url.Values {
+	v := url.Values{}
+
+	if x == nil {
+		return v
+	}
+
+	for _, dataType := range x.DataTypes {
+		v.Add(dataTypeSubscriptionQueryKey, dataType)
+	}
+
+	for _, messageType := range x.MessageTypes {
+		v.Add(messageTypeSubscriptionQueryKey, messageType)
+	}
+
+	for _, itemID := range x.ItemIDs {
+		v.Add(itemIDSubscriptionQueryKey, itemID)
+	}
+
+	return v
+}
+
+// ExtractDataChangeSubscriptionFilter can extract a DataChangeSubscriptionFilter from a request. It returns nil
+// if the request doesn't filter anything.
+func ExtractDataChangeSubscriptionFilter(req *http.Request) *DataChangeSubscriptionFilter {
+	params := req.URL.Query()
+
+	x := &DataChangeSubscriptionFilter{
+		DataTypes:    params[dataTypeSubscriptionQueryKey],
+		MessageTypes: params[messageTypeSubscriptionQueryKey],
+		ItemIDs:      params[itemIDSubscriptionQueryKey],
+	}
+
+	if len(x.DataTypes) == 0 && len(x.MessageTypes) == 0 && len(x.ItemIDs) == 0 {
+		return nil
+	}
+
+	return x
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	fake "github.com/brianvoe/gofakeit/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataChangeSubscriptionFilter_ValidateWithContext(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &DataChangeSubscriptionFilter{
+			DataTypes:    []string{string(ItemDataType)},
+			MessageTypes: []string{CreatedMessageType, UpdatedMessageType},
+			ItemIDs:      []string{fake.UUID()},
+		}
+
+		actual := x.ValidateWithContext(context.Background())
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with empty filter", func(t *testing.T) {
+		t.Parallel()
+
+		x := &DataChangeSubscriptionFilter{}
+
+		actual := x.ValidateWithContext(context.Background())
+		assert.Nil(t, actual)
+	})
+
+	T.Run("with invalid data type", func(t *testing.T) {
+		t.Parallel()
+
+		x := &DataChangeSubscriptionFilter{
+			DataTypes: []string{fake.Word()},
+		}
+
+		actual := x.ValidateWithContext(context.Background())
+		assert.Error(t, actual)
+	})
+
+	T.Run("with invalid message type", func(t *testing.T) {
+		t.Parallel()
+
+		x := &DataChangeSubscriptionFilter{
+			MessageTypes: []string{fake.Word()},
+		}
+
+		actual := x.ValidateWithContext(context.Background())
+		assert.Error(t, actual)
+	})
+}
+
+func TestDataChangeSubscriptionFilter_Matches(T *testing.T) {
+	T.Parallel()
+
+	T.Run("with nil filter", func(t *testing.T) {
+		t.Parallel()
+
+		var x *DataChangeSubscriptionFilter
+
+		assert.True(t, x.Matches(&DataChangeMessage{DataType: WebhookDataType}))
+	})
+
+	T.Run("with data types", func(t *testing.T) {
+		t.Parallel()
+
+		x := &DataChangeSubscriptionFilter{DataTypes: []string{string(ItemDataType)}}
+
+		assert.True(t, x.Matches(&DataChangeMessage{DataType: ItemDataType}))
+		assert.False(t, x.Matches(&DataChangeMessage{DataType: WebhookDataType}))
+	})
+
+	T.Run("with message types", func(t *testing.T) {
+		t.Parallel()
+
+		x := &DataChangeSubscriptionFilter{MessageTypes: []string{ArchivedMessageType}}
+
+		assert.True(t, x.Matches(&DataChangeMessage{MessageType: ArchivedMessageType}))
+		assert.False(t, x.Matches(&DataChangeMessage{MessageType: CreatedMessageType}))
+	})
+
+	T.Run("with item IDs", func(t *testing.T) {
+		t.Parallel()
+
+		exampleItemID := fake.UUID()
+		x := &DataChangeSubscriptionFilter{ItemIDs: []string{exampleItemID}}
+
+		assert.True(t, x.Matches(&DataChangeMessage{DataType: ItemDataType, Item: &Item{ID: exampleItemID}}))
+		assert.True(t, x.Matches(&DataChangeMessage{DataType: ItemDataType, RelevantID: exampleItemID}))
+		assert.False(t, x.Matches(&DataChangeMessage{DataType: ItemDataType, Item: &Item{ID: fake.UUID()}}))
+		assert.False(t, x.Matches(&DataChangeMessage{DataType: WebhookDataType, RelevantID: exampleItemID}))
+		assert.True(t, x.Matches(&DataChangeMessage{DataType: ItemPresenceDataType, ItemPresence: &ItemPresence{ItemID: exampleItemID}}))
+		assert.False(t, x.Matches(&DataChangeMessage{DataType: ItemPresenceDataType, ItemPresence: &ItemPresence{ItemID: fake.UUID()}}))
+	})
+}
+
+func TestDataChangeSubscriptionFilter_ToValues(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		x := &DataChangeSubscriptionFilter{
+			DataTypes:    []string{string(ItemDataType)},
+			MessageTypes: []string{CreatedMessageType, UpdatedMessageType},
+			ItemIDs:      []string{fake.UUID()},
+		}
+
+		expected := url.Values{
+			dataTypeSubscriptionQueryKey:    x.DataTypes,
+			messageTypeSubscriptionQueryKey: x.MessageTypes,
+			itemIDSubscriptionQueryKey:      x.ItemIDs,
+		}
+
+		assert.Equal(t, expected, x.ToValues())
+	})
+
+	T.Run("with nil filter", func(t *testing.T) {
+		t.Parallel()
+
+		var x *DataChangeSubscriptionFilter
+
+		assert.Empty(t, x.ToValues())
+	})
+}
+
+func TestExtractDataChangeSubscriptionFilter(T *testing.T) {
+	T.Parallel()
+
+	T.Run("standard", func(t *testing.T) {
+		t.Parallel()
+
+		expected := &DataChangeSubscriptionFilter{
+			DataTypes:    []string{string(ItemDataType)},
+			MessageTypes: []string{CreatedMessageType},
+			ItemIDs:      []string{fake.UUID(), fake.UUID()},
+		}
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://verygoodsoftwarenotvirus.ru", nil)
+		require.NoError(t, err)
+		req.URL.RawQuery = expected.ToValues().Encode()
+
+		assert.Equal(t, expected, ExtractDataChangeSubscriptionFilter(req))
+	})
+
+	T.Run("without filter values", func(t *testing.T) {
+		t.Parallel()
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://verygoodsoftwarenotvirus.ru", nil)
+		require.NoError(t, err)
+
+		assert.Nil(t, ExtractDataChangeSubscriptionFilter(req))
+	})
+}
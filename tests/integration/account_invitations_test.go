@@ -0,0 +1,140 @@
+package integration
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func findAccountInvitation(invitations []*types.AccountInvitation, id string) *types.AccountInvitation {
+	for _, invitation := range invitations {
+		if invitation.ID == id {
+			return invitation
+		}
+	}
+
+	return nil
+}
+
+func (s *TestSuite) TestAccountInvitations_Accepting() {
+	s.runForEachClientExcept("should be possible to invite a user to an account and have them accept", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			account, err := testClients.main.CreateAccount(ctx, &types.AccountCreationInput{Name: fakes.BuildFakeAccount().Name})
+			requireNotNilAndNoProblems(t, account, err)
+
+			u, _, c, _ := createUserAndClientForTest(ctx, t)
+
+			invitation, err := testClients.main.InviteUserToAccount(ctx, account.ID, &types.AccountInvitationCreationRequestInput{
+				ToUsername:   u.Username,
+				Note:         t.Name(),
+				AccountRoles: []string{authorization.AccountMemberRole.String()},
+			})
+			requireNotNilAndNoProblems(t, invitation, err)
+			assert.Equal(t, types.PendingAccountInvitationStatus, invitation.Status)
+
+			// the invitee shouldn't have access until they accept.
+			require.Error(t, c.SwitchActiveAccount(ctx, account.ID))
+
+			pending, err := testClients.main.GetPendingAccountInvitations(ctx, account.ID, nil)
+			requireNotNilAndNoProblems(t, pending, err)
+			assert.NotNil(t, findAccountInvitation(pending.AccountInvitations, invitation.ID))
+
+			received, err := c.GetReceivedAccountInvitations(ctx)
+			requireNotNilAndNoProblems(t, received, err)
+			assert.NotNil(t, findAccountInvitation(received.AccountInvitations, invitation.ID))
+
+			require.NoError(t, c.AcceptAccountInvitation(ctx, invitation.ID))
+
+			checkFunc := func() bool {
+				return assert.NoError(t, c.SwitchActiveAccount(ctx, account.ID))
+			}
+			assert.Eventually(t, checkFunc, creationTimeout, waitPeriod)
+
+			// accepted invitations are no longer pending.
+			received, err = c.GetReceivedAccountInvitations(ctx)
+			requireNotNilAndNoProblems(t, received, err)
+			assert.Nil(t, findAccountInvitation(received.AccountInvitations, invitation.ID))
+
+			// Clean up.
+			require.NoError(t, testClients.admin.ArchiveUser(ctx, u.ID))
+		}
+	})
+}
+
+func (s *TestSuite) TestAccountInvitations_Rejecting() {
+	s.runForEachClientExcept("should be possible for a user to reject an invitation", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			account, err := testClients.main.CreateAccount(ctx, &types.AccountCreationInput{Name: fakes.BuildFakeAccount().Name})
+			requireNotNilAndNoProblems(t, account, err)
+
+			u, _, c, _ := createUserAndClientForTest(ctx, t)
+
+			invitation, err := testClients.main.InviteUserToAccount(ctx, account.ID, &types.AccountInvitationCreationRequestInput{
+				ToUsername:   u.Username,
+				AccountRoles: []string{authorization.AccountMemberRole.String()},
+			})
+			requireNotNilAndNoProblems(t, invitation, err)
+
+			require.NoError(t, c.RejectAccountInvitation(ctx, invitation.ID))
+
+			// rejected invitations can't subsequently be accepted.
+			require.Error(t, c.AcceptAccountInvitation(ctx, invitation.ID))
+			require.Error(t, c.SwitchActiveAccount(ctx, account.ID))
+
+			pending, err := testClients.main.GetPendingAccountInvitations(ctx, account.ID, nil)
+			requireNotNilAndNoProblems(t, pending, err)
+			assert.Nil(t, findAccountInvitation(pending.AccountInvitations, invitation.ID))
+
+			// Clean up.
+			require.NoError(t, testClients.admin.ArchiveUser(ctx, u.ID))
+		}
+	})
+}
+
+func (s *TestSuite) TestAccountInvitations_Revoking() {
+	s.runForEachClientExcept("should be possible to revoke a pending invitation", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			account, err := testClients.main.CreateAccount(ctx, &types.AccountCreationInput{Name: fakes.BuildFakeAccount().Name})
+			requireNotNilAndNoProblems(t, account, err)
+
+			u, _, c, _ := createUserAndClientForTest(ctx, t)
+
+			invitation, err := testClients.main.InviteUserToAccount(ctx, account.ID, &types.AccountInvitationCreationRequestInput{
+				ToUsername:   u.Username,
+				AccountRoles: []string{authorization.AccountMemberRole.String()},
+			})
+			requireNotNilAndNoProblems(t, invitation, err)
+
+			require.NoError(t, testClients.main.RevokeAccountInvitation(ctx, account.ID, invitation.ID))
+
+			// revoked invitations can't subsequently be accepted.
+			require.Error(t, c.AcceptAccountInvitation(ctx, invitation.ID))
+
+			received, err := c.GetReceivedAccountInvitations(ctx)
+			requireNotNilAndNoProblems(t, received, err)
+			assert.Nil(t, findAccountInvitation(received.AccountInvitations, invitation.ID))
+
+			// Clean up.
+			require.NoError(t, testClients.admin.ArchiveUser(ctx, u.ID))
+		}
+	})
+}
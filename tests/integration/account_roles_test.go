@@ -0,0 +1,114 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func checkAccountRoleEquality(t *testing.T, expected, actual *types.AccountRole) {
+	t.Helper()
+
+	assert.NotZero(t, actual.ID)
+	assert.Equal(t, expected.Name, actual.Name, "expected Name for account role %s to be %v, but it was %v ", expected.ID, expected.Name, actual.Name)
+	assert.Equal(t, expected.Description, actual.Description, "expected Description for account role %s to be %v, but it was %v ", expected.ID, expected.Description, actual.Description)
+	assert.ElementsMatch(t, expected.Permissions, actual.Permissions, "expected Permissions for account role %s to be %v, but it was %v ", expected.ID, expected.Permissions, actual.Permissions)
+	assert.NotZero(t, actual.CreatedOn)
+}
+
+func (s *TestSuite) TestAccountRoles_CompleteLifecycle() {
+	s.runForEachClientExcept("should be possible to create, read, update, and archive account roles", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			currentStatus, statusErr := testClients.main.UserStatus(s.ctx)
+			requireNotNilAndNoProblems(t, currentStatus, statusErr)
+
+			exampleAccountRole := fakes.BuildFakeAccountRole()
+			exampleAccountRoleInput := fakes.BuildFakeAccountRoleCreationInputFromAccountRole(exampleAccountRole)
+			createdAccountRole, err := testClients.main.CreateAccountRole(ctx, currentStatus.ActiveAccount, exampleAccountRoleInput)
+			requireNotNilAndNoProblems(t, createdAccountRole, err)
+			checkAccountRoleEquality(t, exampleAccountRole, createdAccountRole)
+
+			actual, err := testClients.main.GetAccountRole(ctx, currentStatus.ActiveAccount, createdAccountRole.ID)
+			requireNotNilAndNoProblems(t, actual, err)
+			checkAccountRoleEquality(t, exampleAccountRole, actual)
+
+			accountRoles, err := testClients.main.GetAccountRoles(ctx, currentStatus.ActiveAccount, nil)
+			requireNotNilAndNoProblems(t, accountRoles, err)
+			assert.NotEmpty(t, accountRoles.AccountRoles)
+
+			newAccountRole := fakes.BuildFakeAccountRole()
+			actual.Update(fakes.BuildFakeAccountRoleUpdateInputFromAccountRole(newAccountRole))
+			require.NoError(t, testClients.main.UpdateAccountRole(ctx, actual))
+
+			actual, err = testClients.main.GetAccountRole(ctx, currentStatus.ActiveAccount, createdAccountRole.ID)
+			requireNotNilAndNoProblems(t, actual, err)
+			checkAccountRoleEquality(t, newAccountRole, actual)
+			assert.NotNil(t, actual.LastUpdatedOn)
+
+			require.NoError(t, testClients.main.ArchiveAccountRole(ctx, currentStatus.ActiveAccount, createdAccountRole.ID))
+
+			actual, err = testClients.main.GetAccountRole(ctx, currentStatus.ActiveAccount, createdAccountRole.ID)
+			assert.Nil(t, actual)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func (s *TestSuite) TestAccountRoles_AssigningToMembers() {
+	s.runForCookieClient("should grant members the permissions of custom roles assigned to them", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			account, err := testClients.main.CreateAccount(ctx, &types.AccountCreationInput{Name: fakes.BuildFakeAccount().Name})
+			requireNotNilAndNoProblems(t, account, err)
+
+			u, _, c, _ := createUserAndClientForTest(ctx, t)
+
+			inviteUserToAccountAndAccept(ctx, t, testClients.main, c, account.ID, u.Username, authorization.AccountMemberRole.String())
+			require.NoError(t, c.SwitchActiveAccount(ctx, account.ID))
+
+			// members can't see an account's roles by default.
+			accountRoles, err := c.GetAccountRoles(ctx, account.ID, nil)
+			assert.Nil(t, accountRoles)
+			assert.Error(t, err)
+
+			createdAccountRole, err := testClients.main.CreateAccountRole(ctx, account.ID, &types.AccountRoleCreationInput{
+				Name:        t.Name(),
+				Permissions: []string{authorization.ReadAccountRolesPermission.ID()},
+			})
+			requireNotNilAndNoProblems(t, createdAccountRole, err)
+
+			require.NoError(t, testClients.main.ModifyMemberPermissions(ctx, account.ID, u.ID, &types.ModifyUserPermissionsInput{
+				Reason:   t.Name(),
+				NewRoles: []string{authorization.AccountMemberRole.String(), createdAccountRole.ID},
+			}))
+
+			// custom roles from other accounts can't be assigned.
+			assert.Error(t, testClients.main.ModifyMemberPermissions(ctx, account.ID, u.ID, &types.ModifyUserPermissionsInput{
+				Reason:   t.Name(),
+				NewRoles: []string{fakes.BuildFakeID()},
+			}))
+
+			accountRoles, err = c.GetAccountRoles(ctx, account.ID, nil)
+			requireNotNilAndNoProblems(t, accountRoles, err)
+			require.Len(t, accountRoles.AccountRoles, 1)
+			assert.Equal(t, createdAccountRole.ID, accountRoles.AccountRoles[0].ID)
+
+			assert.NoError(t, testClients.main.ArchiveAccount(ctx, account.ID))
+		}
+	})
+}
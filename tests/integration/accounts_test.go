@@ -243,15 +243,16 @@ func (s *TestSuite) TestAccounts_ChangingMemberships() {
 			t.Logf("switched main test client active account to %s, creating webhook", account.ID)
 
 			stopChan := make(chan bool, 1)
-			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, stopChan)
+			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, nil, stopChan)
 			require.NotNil(t, notificationsChan)
 			require.NoError(t, err)
 
 			// Create webhook.
 			exampleWebhook := fakes.BuildFakeWebhook()
 			exampleWebhookInput := fakes.BuildFakeWebhookCreationInputFromWebhook(exampleWebhook)
-			createdWebhookID, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
+			webhookCreationResponse, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
 			require.NoError(t, err)
+			createdWebhookID := webhookCreationResponse.ID
 
 			n := <-notificationsChan
 			assert.Equal(t, n.DataType, types.WebhookDataType)
@@ -290,12 +291,7 @@ func (s *TestSuite) TestAccounts_ChangingMemberships() {
 			// add them to the account
 			for i := 0; i < userCount; i++ {
 				t.Logf("adding user %q to account %s", users[i].ID, account.ID)
-				require.NoError(t, testClients.main.AddUserToAccount(ctx, &types.AddUserToAccountInput{
-					UserID:       users[i].ID,
-					AccountID:    account.ID,
-					Reason:       t.Name(),
-					AccountRoles: []string{authorization.AccountAdminRole.String()},
-				}))
+				inviteUserToAccountAndAccept(ctx, t, testClients.main, clients[i], account.ID, users[i].Username, authorization.AccountAdminRole.String())
 				t.Logf("added user %q to account %s", users[i].ID, account.ID)
 
 				n := <-notificationsChan
@@ -377,8 +373,9 @@ func (s *TestSuite) TestAccounts_ChangingMemberships() {
 			// Create webhook.
 			exampleWebhook := fakes.BuildFakeWebhook()
 			exampleWebhookInput := fakes.BuildFakeWebhookCreationInputFromWebhook(exampleWebhook)
-			createdWebhookID, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
+			webhookCreationResponse, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
 			require.NoError(t, err)
+			createdWebhookID := webhookCreationResponse.ID
 
 			var createdWebhook *types.Webhook
 			checkFunc := func() bool {
@@ -419,12 +416,7 @@ func (s *TestSuite) TestAccounts_ChangingMemberships() {
 			// add them to the account
 			for i := 0; i < userCount; i++ {
 				t.Logf("adding user %q to account %s", users[i].ID, account.ID)
-				require.NoError(t, testClients.main.AddUserToAccount(ctx, &types.AddUserToAccountInput{
-					UserID:       users[i].ID,
-					AccountID:    account.ID,
-					Reason:       t.Name(),
-					AccountRoles: []string{authorization.AccountAdminRole.String()},
-				}))
+				inviteUserToAccountAndAccept(ctx, t, testClients.main, clients[i], account.ID, users[i].Username, authorization.AccountAdminRole.String())
 				t.Logf("added user %q to account %s", users[i].ID, account.ID)
 
 				t.Logf("setting user %q's client to account %s", users[i].ID, account.ID)
@@ -504,15 +496,16 @@ func (s *TestSuite) TestAccounts_OwnershipTransfer() {
 
 			// create a webhook
 			stopChan := make(chan bool, 1)
-			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, stopChan)
+			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, nil, stopChan)
 			require.NotNil(t, notificationsChan)
 			require.NoError(t, err)
 
 			// Create webhook.
 			exampleWebhook := fakes.BuildFakeWebhook()
 			exampleWebhookInput := fakes.BuildFakeWebhookCreationInputFromWebhook(exampleWebhook)
-			createdWebhookID, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
+			webhookCreationResponse, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
 			require.NoError(t, err)
+			createdWebhookID := webhookCreationResponse.ID
 
 			n := <-notificationsChan
 			assert.Equal(t, n.DataType, types.WebhookDataType)
@@ -586,8 +579,9 @@ func (s *TestSuite) TestAccounts_OwnershipTransfer() {
 			// create a webhook
 			exampleWebhook := fakes.BuildFakeWebhook()
 			exampleWebhookInput := fakes.BuildFakeWebhookCreationInputFromWebhook(exampleWebhook)
-			createdWebhookID, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
+			webhookCreationResponse, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
 			require.NoError(t, err)
+			createdWebhookID := webhookCreationResponse.ID
 
 			var createdWebhook *types.Webhook
 			checkFunc := func() bool {
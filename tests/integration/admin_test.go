@@ -72,3 +72,17 @@ func (s *TestSuite) TestAdmin_BanningUsers() {
 		}
 	})
 }
+
+func (s *TestSuite) TestAdmin_ReindexingSearch() {
+	s.runForEachClientExcept("should be possible to reindex search", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			report, err := testClients.admin.ReindexSearch(ctx)
+			requireNotNilAndNoProblems(t, report, err)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
+)
+
+func findAuditLogEntry(t *testing.T, entries []*types.AuditLogEntry, eventType, resourceID string) *types.AuditLogEntry {
+	t.Helper()
+
+	for _, entry := range entries {
+		if entry.EventType == eventType && entry.ResourceID == resourceID {
+			return entry
+		}
+	}
+
+	return nil
+}
+
+func (s *TestSuite) TestAuditLog_RecordsMutations() {
+	s.runForEachClientExcept("should record mutating operations in the account audit log", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			currentStatus, statusErr := testClients.main.UserStatus(s.ctx)
+			requireNotNilAndNoProblems(t, currentStatus, statusErr)
+
+			exampleAccountRole := fakes.BuildFakeAccountRole()
+			exampleAccountRoleInput := fakes.BuildFakeAccountRoleCreationInputFromAccountRole(exampleAccountRole)
+			createdAccountRole, err := testClients.main.CreateAccountRole(ctx, currentStatus.ActiveAccount, exampleAccountRoleInput)
+			requireNotNilAndNoProblems(t, createdAccountRole, err)
+
+			newAccountRole := fakes.BuildFakeAccountRole()
+			createdAccountRole.Update(fakes.BuildFakeAccountRoleUpdateInputFromAccountRole(newAccountRole))
+			require.NoError(t, testClients.main.UpdateAccountRole(ctx, createdAccountRole))
+
+			filter := types.DefaultAuditLogEntryQueryFilter()
+			filter.ResourceType = types.AccountRoleResourceType
+			filter.ResourceID = createdAccountRole.ID
+
+			entries, err := testClients.main.GetAuditLogEntriesForAccount(ctx, currentStatus.ActiveAccount, filter)
+			requireNotNilAndNoProblems(t, entries, err)
+
+			creationEntry := findAuditLogEntry(t, entries.Entries, types.AccountRoleCreationEvent, createdAccountRole.ID)
+			require.NotNil(t, creationEntry)
+			assert.Equal(t, currentStatus.ActiveAccount, creationEntry.BelongsToAccount)
+			assert.NotEmpty(t, creationEntry.ActorUserID)
+			assert.NotEmpty(t, creationEntry.RequestID)
+
+			updateEntry := findAuditLogEntry(t, entries.Entries, types.AccountRoleUpdateEvent, createdAccountRole.ID)
+			require.NotNil(t, updateEntry)
+			assert.NotEmpty(t, updateEntry.Changes)
+
+			adminEntries, err := testClients.admin.GetAuditLogEntries(ctx, filter)
+			requireNotNilAndNoProblems(t, adminEntries, err)
+			assert.NotNil(t, findAuditLogEntry(t, adminEntries.Entries, types.AccountRoleCreationEvent, createdAccountRole.ID))
+
+			require.NoError(t, testClients.main.ArchiveAccountRole(ctx, currentStatus.ActiveAccount, createdAccountRole.ID))
+		}
+	})
+}
+
+func (s *TestSuite) TestAuditLog_RequiresServicePermissionForSystemWideLog() {
+	s.runForEachClientExcept("should not allow regular users to read the system-wide audit log", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			entries, err := testClients.main.GetAuditLogEntries(ctx, nil)
+			assert.Nil(t, entries)
+			assert.Error(t, err)
+		}
+	})
+}
@@ -14,6 +14,7 @@
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
 	authservice "gitlab.com/verygoodsoftwarenotvirus/todo/internal/services/authentication"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/client/httpclient"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
 )
@@ -292,7 +293,8 @@ func (s *TestSuite) TestTOTPSecretChanging() {
 		secretVerificationToken, err := totp.GenerateCode(r.TwoFactorSecret, time.Now().UTC())
 		requireNotNilAndNoProblems(t, secretVerificationToken, err)
 
-		assert.NoError(t, testClient.VerifyTOTPSecret(ctx, testUser.ID, secretVerificationToken))
+		_, err = testClient.VerifyTOTPSecret(ctx, testUser.ID, secretVerificationToken)
+		assert.NoError(t, err)
 
 		// logout.
 		assert.NoError(t, testClient.EndSession(ctx))
@@ -329,7 +331,10 @@ func (s *TestSuite) TestTOTPTokenValidation() {
 		token, err := totp.GenerateCode(user.TwoFactorSecret, time.Now().UTC())
 		requireNotNilAndNoProblems(t, token, err)
 
-		assert.NoError(t, testClient.VerifyTOTPSecret(ctx, user.CreatedUserID, token))
+		recoveryCodes, err := testClient.VerifyTOTPSecret(ctx, user.CreatedUserID, token)
+		assert.NoError(t, err)
+		require.NotNil(t, recoveryCodes)
+		assert.NotEmpty(t, recoveryCodes.RecoveryCodes)
 	})
 
 	s.Run("should not be possible to validate an invalid TOTP", func() {
@@ -346,6 +351,112 @@ func (s *TestSuite) TestTOTPTokenValidation() {
 		assert.NotNil(t, user)
 		require.NoError(t, err)
 
-		assert.Error(t, testClient.VerifyTOTPSecret(ctx, user.CreatedUserID, "NOTREAL"))
+		_, err = testClient.VerifyTOTPSecret(ctx, user.CreatedUserID, "NOTREAL")
+		assert.Error(t, err)
+	})
+}
+
+func (s *TestSuite) TestTOTPSecretRecovery() {
+	s.Run("should be possible to recover a lost TOTP secret with a recovery code", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(context.Background(), t.Name())
+		defer span.End()
+
+		testClient := buildSimpleClient(t)
+
+		// create user.
+		userInput := fakes.BuildFakeUserCreationInput()
+		user, err := testClient.CreateUser(ctx, userInput)
+		requireNotNilAndNoProblems(t, user, err)
+
+		token, err := totp.GenerateCode(user.TwoFactorSecret, time.Now().UTC())
+		requireNotNilAndNoProblems(t, token, err)
+
+		recoveryCodes, err := testClient.VerifyTOTPSecret(ctx, user.CreatedUserID, token)
+		requireNotNilAndNoProblems(t, recoveryCodes, err)
+		require.NotEmpty(t, recoveryCodes.RecoveryCodes)
+
+		recoveryInput := &types.TOTPRecoveryInput{
+			Username:     userInput.Username,
+			Password:     userInput.Password,
+			RecoveryCode: recoveryCodes.RecoveryCodes[0],
+		}
+
+		recovered, err := testClient.RecoverTOTPSecret(ctx, recoveryInput)
+		requireNotNilAndNoProblems(t, recovered, err)
+		assert.Equal(t, user.CreatedUserID, recovered.UserID)
+		assert.NotEqual(t, user.TwoFactorSecret, recovered.TwoFactorSecret)
+
+		// recovery codes are single use.
+		_, err = testClient.RecoverTOTPSecret(ctx, recoveryInput)
+		assert.Error(t, err)
+
+		newToken, err := totp.GenerateCode(recovered.TwoFactorSecret, time.Now().UTC())
+		requireNotNilAndNoProblems(t, newToken, err)
+
+		_, err = testClient.VerifyTOTPSecret(ctx, user.CreatedUserID, newToken)
+		require.NoError(t, err)
+
+		cookie, err := testClient.BeginSession(ctx, &types.UserLoginInput{
+			Username:  userInput.Username,
+			Password:  userInput.Password,
+			TOTPToken: newToken,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, cookie)
+	})
+
+	s.Run("should not be possible to recover a TOTP secret with an invalid recovery code", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(context.Background(), t.Name())
+		defer span.End()
+
+		testUser, _, testClient, _ := createUserAndClientForTest(ctx, t)
+
+		recovered, err := testClient.RecoverTOTPSecret(ctx, &types.TOTPRecoveryInput{
+			Username:     testUser.Username,
+			Password:     testUser.HashedPassword,
+			RecoveryCode: "NOTREALATALL",
+		})
+		assert.Error(t, err)
+		assert.Nil(t, recovered)
+	})
+}
+
+func (s *TestSuite) TestPasswordReset() {
+	s.Run("should be possible to request a password reset", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(context.Background(), t.Name())
+		defer span.End()
+
+		testUser, _, testClient, _ := createUserAndClientForTest(ctx, t)
+
+		assert.NoError(t, testClient.RequestPasswordReset(ctx, &types.PasswordResetTokenCreationRequestInput{Username: testUser.Username}))
+	})
+
+	s.Run("requesting a password reset for a nonexistent user looks the same as for a real one", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(context.Background(), t.Name())
+		defer span.End()
+
+		testClient := buildSimpleClient(t)
+
+		assert.NoError(t, testClient.RequestPasswordReset(ctx, fakes.BuildFakePasswordResetTokenCreationRequestInput()))
+	})
+
+	s.Run("should not be possible to redeem a bogus password reset token", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(context.Background(), t.Name())
+		defer span.End()
+
+		testClient := buildSimpleClient(t)
+
+		err := testClient.RedeemPasswordResetToken(ctx, fakes.BuildFakePasswordResetTokenRedemptionRequestInput())
+		assert.ErrorIs(t, err, httpclient.ErrInvalidPasswordResetToken)
 	})
 }
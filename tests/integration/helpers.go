@@ -60,6 +60,19 @@ func createUserAndClientForTest(ctx context.Context, t *testing.T) (user *types.
 	return user, cookie, cookieClient, pasetoClient
 }
 
+func inviteUserToAccountAndAccept(ctx context.Context, t *testing.T, inviterClient, inviteeClient *httpclient.Client, accountID, username string, accountRoles ...string) {
+	t.Helper()
+
+	invitation, err := inviterClient.InviteUserToAccount(ctx, accountID, &types.AccountInvitationCreationRequestInput{
+		ToUsername:   username,
+		Note:         t.Name(),
+		AccountRoles: accountRoles,
+	})
+	requireNotNilAndNoProblems(t, invitation, err)
+
+	require.NoError(t, inviteeClient.AcceptAccountInvitation(ctx, invitation.ID))
+}
+
 func initializeCookiePoweredClient(cookie *http.Cookie) (*httpclient.Client, error) {
 	if parsedURLToUse == nil {
 		panic("url not set!")
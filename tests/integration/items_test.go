@@ -9,6 +9,7 @@
 	"github.com/stretchr/testify/require"
 
 	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/client/httpclient"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
 	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types/fakes"
 )
@@ -36,7 +37,7 @@ func (s *TestSuite) TestItems_CompleteLifecycle() {
 			defer span.End()
 
 			stopChan := make(chan bool, 1)
-			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, stopChan)
+			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, nil, stopChan)
 			require.NotNil(t, notificationsChan)
 			require.NoError(t, err)
 
@@ -62,6 +63,36 @@ func (s *TestSuite) TestItems_CompleteLifecycle() {
 		}
 	})
 
+	s.runForCookieClient("should be streamable as server-sent events", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			stopChan := make(chan bool, 1)
+			eventsChan, err := testClients.main.SubscribeToDataChangeEvents(ctx, &types.DataChangeSubscriptionFilter{DataTypes: []string{string(types.ItemDataType)}}, stopChan)
+			require.NotNil(t, eventsChan)
+			require.NoError(t, err)
+
+			// Create item.
+			exampleItem := fakes.BuildFakeItem()
+			exampleItemInput := fakes.BuildFakeItemCreationInputFromItem(exampleItem)
+			createdItemID, err := testClients.main.CreateItem(ctx, exampleItemInput)
+			require.NoError(t, err)
+
+			n := <-eventsChan
+			assert.Equal(t, n.DataType, types.ItemDataType)
+			require.NotNil(t, n.Item)
+			checkItemEquality(t, exampleItem, n.Item)
+
+			// Clean up item.
+			assert.NoError(t, testClients.main.ArchiveItem(ctx, createdItemID))
+
+			stopChan <- true
+		}
+	})
+
 	s.runForPASETOClient("should be creatable", func(testClients *testClientWrapper) func() {
 		return func() {
 			t := s.T()
@@ -100,7 +131,7 @@ func (s *TestSuite) TestItems_Listing() {
 			defer span.End()
 
 			stopChan := make(chan bool, 1)
-			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, stopChan)
+			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, nil, stopChan)
 			require.NotNil(t, notificationsChan)
 			require.NoError(t, err)
 
@@ -200,7 +231,7 @@ func (s *TestSuite) TestItems_Searching() {
 			defer span.End()
 
 			stopChan := make(chan bool, 1)
-			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, stopChan)
+			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, nil, stopChan)
 			require.NotNil(t, notificationsChan)
 			require.NoError(t, err)
 
@@ -343,7 +374,7 @@ func (s *TestSuite) TestItems_Updating() {
 			defer span.End()
 
 			stopChan := make(chan bool, 1)
-			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, stopChan)
+			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, nil, stopChan)
 			require.NotNil(t, notificationsChan)
 			require.NoError(t, err)
 
@@ -424,6 +455,56 @@ func (s *TestSuite) TestItems_Updating() {
 	})
 }
 
+func (s *TestSuite) TestItems_Updating_Returns412ForStaleItem() {
+	s.runForCookieClient("it should refuse to update an item that has changed since it was read", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			stopChan := make(chan bool, 1)
+			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, nil, stopChan)
+			require.NotNil(t, notificationsChan)
+			require.NoError(t, err)
+
+			// Create item.
+			exampleItem := fakes.BuildFakeItem()
+			exampleItemInput := fakes.BuildFakeItemCreationInputFromItem(exampleItem)
+			createdItemID, err := testClients.main.CreateItem(ctx, exampleItemInput)
+			require.NoError(t, err)
+
+			n := <-notificationsChan
+			assert.Equal(t, n.DataType, types.ItemDataType)
+
+			createdItem, err := testClients.main.GetItem(ctx, createdItemID)
+			requireNotNilAndNoProblems(t, createdItem, err)
+			staleItem := *createdItem
+
+			// change item
+			createdItem.Update(convertItemToItemUpdateInput(fakes.BuildFakeItem()))
+			assert.NoError(t, testClients.main.UpdateItem(ctx, createdItem))
+
+			n = <-notificationsChan
+			assert.Equal(t, n.MessageType, types.UpdatedMessageType)
+
+			// change item from the copy read before the last change
+			staleItem.Update(convertItemToItemUpdateInput(fakes.BuildFakeItem()))
+			assert.ErrorIs(t, testClients.main.UpdateItem(ctx, &staleItem), httpclient.ErrPreconditionFailed)
+
+			actual, err := testClients.main.GetItem(ctx, createdItemID)
+			requireNotNilAndNoProblems(t, actual, err)
+
+			// assert the stale change was not written
+			checkItemEquality(t, createdItem, actual)
+			assert.Equal(t, createdItem.Version, actual.Version)
+
+			// clean up item
+			assert.NoError(t, testClients.main.ArchiveItem(ctx, createdItem.ID))
+		}
+	})
+}
+
 func (s *TestSuite) TestItems_Archiving_Returns404ForNonexistentItem() {
 	s.runForEachClientExcept("it should return an error when trying to delete something that does not exist", func(testClients *testClientWrapper) func() {
 		return func() {
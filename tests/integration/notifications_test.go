@@ -0,0 +1,55 @@
+package integration
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/authorization"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+)
+
+func (s *TestSuite) TestNotifications_AddingMember() {
+	s.runForCookieClient("should notify users added to an account", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			currentStatus, statusErr := testClients.main.UserStatus(s.ctx)
+			requireNotNilAndNoProblems(t, currentStatus, statusErr)
+
+			u, _, c, _ := createUserAndClientForTest(ctx, t)
+
+			stopChan := make(chan bool, 1)
+			notificationsChan, err := c.SubscribeToDataChangeNotifications(ctx, nil, stopChan)
+			require.NotNil(t, notificationsChan)
+			require.NoError(t, err)
+
+			inviteUserToAccountAndAccept(ctx, t, testClients.main, c, currentStatus.ActiveAccount, u.Username, authorization.AccountMemberRole.String())
+
+			n := <-notificationsChan
+			assert.Equal(t, types.NotificationDataType, n.DataType)
+			require.NotNil(t, n.Notification)
+			assert.Equal(t, u.ID, n.Notification.BelongsToUser)
+
+			unreadCount, err := c.GetUnreadNotificationCount(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), unreadCount)
+
+			notifications, err := c.GetNotifications(ctx, nil)
+			requireNotNilAndNoProblems(t, notifications, err)
+			require.Len(t, notifications.Notifications, 1)
+			assert.Equal(t, n.Notification.ID, notifications.Notifications[0].ID)
+
+			require.NoError(t, c.MarkNotificationAsSeen(ctx, n.Notification.ID))
+
+			unreadCount, err = c.GetUnreadNotificationCount(ctx)
+			require.NoError(t, err)
+			assert.Zero(t, unreadCount)
+
+			require.NoError(t, c.MarkAllNotificationsAsSeen(ctx))
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package integration
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+func (s *TestSuite) TestUserSessions_Listing() {
+	s.Run("should be able to list your own sessions", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+		defer span.End()
+
+		user, _, userClient, _ := createUserAndClientForTest(ctx, t)
+
+		secondCookie, err := testutils.GetLoginCookie(ctx, urlToUse, user)
+		require.NoError(t, err)
+
+		secondClient, err := initializeCookiePoweredClient(secondCookie)
+		require.NoError(t, err)
+
+		userSessions, err := secondClient.GetUserSessions(ctx, nil)
+		requireNotNilAndNoProblems(t, userSessions, err)
+		assert.GreaterOrEqual(t, len(userSessions.UserSessions), 2)
+
+		var currentSessions uint
+		for _, userSession := range userSessions.UserSessions {
+			assert.Equal(t, user.ID, userSession.BelongsToUser)
+			if userSession.Current {
+				currentSessions++
+			}
+		}
+		assert.Equal(t, uint(1), currentSessions)
+
+		// Clean up.
+		assert.NoError(t, userClient.EndSession(ctx))
+		assert.NoError(t, secondClient.EndSession(ctx))
+	})
+}
+
+func (s *TestSuite) TestUserSessions_Revoking() {
+	s.Run("should be able to revoke a single session", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+		defer span.End()
+
+		user, _, userClient, _ := createUserAndClientForTest(ctx, t)
+
+		secondCookie, err := testutils.GetLoginCookie(ctx, urlToUse, user)
+		require.NoError(t, err)
+
+		secondClient, err := initializeCookiePoweredClient(secondCookie)
+		require.NoError(t, err)
+
+		userSessions, err := secondClient.GetUserSessions(ctx, nil)
+		requireNotNilAndNoProblems(t, userSessions, err)
+
+		var currentSessionID string
+		for _, userSession := range userSessions.UserSessions {
+			if userSession.Current {
+				currentSessionID = userSession.ID
+			}
+		}
+		require.NotEmpty(t, currentSessionID)
+
+		assert.NoError(t, userClient.RevokeUserSession(ctx, currentSessionID))
+
+		_, err = secondClient.GetUserSessions(ctx, nil)
+		assert.Error(t, err)
+
+		_, err = userClient.GetUserSessions(ctx, nil)
+		assert.NoError(t, err)
+	})
+
+	s.Run("should be able to revoke every other session", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+		defer span.End()
+
+		user, _, userClient, _ := createUserAndClientForTest(ctx, t)
+
+		secondCookie, err := testutils.GetLoginCookie(ctx, urlToUse, user)
+		require.NoError(t, err)
+
+		secondClient, err := initializeCookiePoweredClient(secondCookie)
+		require.NoError(t, err)
+
+		assert.NoError(t, secondClient.RevokeOtherUserSessions(ctx))
+
+		_, err = userClient.GetUserSessions(ctx, nil)
+		assert.Error(t, err)
+
+		userSessions, err := secondClient.GetUserSessions(ctx, nil)
+		requireNotNilAndNoProblems(t, userSessions, err)
+		require.Len(t, userSessions.UserSessions, 1)
+		assert.True(t, userSessions.UserSessions[0].Current)
+	})
+}
+
+func (s *TestSuite) TestAdmin_RevokingUserSessions() {
+	s.runForEachClientExcept("should be possible to revoke every session a user has", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			user, _, userClient, _ := createUserAndClientForTest(ctx, t)
+
+			_, err := userClient.GetUserSessions(ctx, nil)
+			require.NoError(t, err)
+
+			assert.NoError(t, testClients.admin.RevokeAllUserSessions(ctx, user.ID))
+
+			_, err = userClient.GetUserSessions(ctx, nil)
+			assert.Error(t, err)
+
+			// Clean up.
+			assert.NoError(t, testClients.admin.ArchiveUser(ctx, user.ID))
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/verygoodsoftwarenotvirus/todo/internal/observability/tracing"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/client/httpclient"
+	"gitlab.com/verygoodsoftwarenotvirus/todo/pkg/types"
+	testutils "gitlab.com/verygoodsoftwarenotvirus/todo/tests/utils"
+)
+
+// webAuthnOrigin is the relying party origin the integration test server is configured with.
+const webAuthnOrigin = "http://localhost:8888"
+
+func registerWebAuthnCredentialForTest(ctx context.Context, t *testing.T, userClient *httpclient.Client) (*testutils.SoftwareAuthenticator, *types.WebAuthnCredential) {
+	t.Helper()
+
+	options, err := userClient.BeginWebAuthnRegistration(ctx)
+	requireNotNilAndNoProblems(t, options, err)
+
+	authenticator := testutils.NewSoftwareAuthenticator(webAuthnOrigin)
+	credential, err := authenticator.CreateCredential(options.Options)
+	require.NoError(t, err)
+
+	created, err := userClient.FinishWebAuthnRegistration(ctx, &types.WebAuthnRegistrationFinishInput{
+		Name:          t.Name(),
+		CeremonyToken: options.CeremonyToken,
+		Credential:    credential,
+	})
+	requireNotNilAndNoProblems(t, created, err)
+
+	return authenticator, created
+}
+
+func (s *TestSuite) TestWebAuthn_Registering() {
+	s.Run("should be able to register and list WebAuthn credentials", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+		defer span.End()
+
+		user, _, userClient, _ := createUserAndClientForTest(ctx, t)
+
+		_, created := registerWebAuthnCredentialForTest(ctx, t, userClient)
+		assert.Equal(t, user.ID, created.BelongsToUser)
+		assert.Equal(t, t.Name(), created.Name)
+
+		credentials, err := userClient.GetWebAuthnCredentials(ctx)
+		requireNotNilAndNoProblems(t, credentials, err)
+		require.Len(t, credentials.WebAuthnCredentials, 1)
+		assert.Equal(t, created.ID, credentials.WebAuthnCredentials[0].ID)
+
+		// Clean up.
+		assert.NoError(t, userClient.ArchiveWebAuthnCredential(ctx, created.ID))
+	})
+}
+
+func (s *TestSuite) TestWebAuthn_Archiving() {
+	s.Run("should be able to archive a WebAuthn credential", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+		defer span.End()
+
+		_, _, userClient, _ := createUserAndClientForTest(ctx, t)
+
+		_, created := registerWebAuthnCredentialForTest(ctx, t, userClient)
+
+		assert.NoError(t, userClient.ArchiveWebAuthnCredential(ctx, created.ID))
+
+		credentials, err := userClient.GetWebAuthnCredentials(ctx)
+		requireNotNilAndNoProblems(t, credentials, err)
+		assert.Empty(t, credentials.WebAuthnCredentials)
+	})
+
+	s.Run("should not be able to archive another user's WebAuthn credential", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+		defer span.End()
+
+		_, _, userClient, _ := createUserAndClientForTest(ctx, t)
+		_, _, otherClient, _ := createUserAndClientForTest(ctx, t)
+
+		_, created := registerWebAuthnCredentialForTest(ctx, t, userClient)
+
+		assert.Error(t, otherClient.ArchiveWebAuthnCredential(ctx, created.ID))
+	})
+}
+
+func (s *TestSuite) TestWebAuthn_Login() {
+	s.Run("should be able to log in with a WebAuthn credential in place of a TOTP token", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+		defer span.End()
+
+		user, _, userClient, _ := createUserAndClientForTest(ctx, t)
+
+		authenticator, _ := registerWebAuthnCredentialForTest(ctx, t, userClient)
+
+		c := buildSimpleClient(t)
+
+		options, err := c.BeginWebAuthnLogin(ctx, &types.WebAuthnLoginBeginInput{Username: user.Username})
+		requireNotNilAndNoProblems(t, options, err)
+
+		assertion, err := authenticator.GetAssertion(options.Options)
+		require.NoError(t, err)
+
+		cookie, err := c.BeginSession(ctx, &types.UserLoginInput{
+			Username:              user.Username,
+			Password:              user.HashedPassword,
+			WebAuthnCeremonyToken: options.CeremonyToken,
+			WebAuthnAssertion:     assertion,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, cookie)
+	})
+
+	s.Run("should not be able to log in with a bad password and a valid WebAuthn credential", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+		defer span.End()
+
+		user, _, userClient, _ := createUserAndClientForTest(ctx, t)
+
+		authenticator, _ := registerWebAuthnCredentialForTest(ctx, t, userClient)
+
+		c := buildSimpleClient(t)
+
+		options, err := c.BeginWebAuthnLogin(ctx, &types.WebAuthnLoginBeginInput{Username: user.Username})
+		requireNotNilAndNoProblems(t, options, err)
+
+		assertion, err := authenticator.GetAssertion(options.Options)
+		require.NoError(t, err)
+
+		cookie, err := c.BeginSession(ctx, &types.UserLoginInput{
+			Username:              user.Username,
+			Password:              "definitely-not-the-password",
+			WebAuthnCeremonyToken: options.CeremonyToken,
+			WebAuthnAssertion:     assertion,
+		})
+		assert.Error(t, err)
+		assert.Nil(t, cookie)
+	})
+
+	s.Run("should not be able to replay a WebAuthn assertion", func() {
+		t := s.T()
+
+		ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+		defer span.End()
+
+		user, _, userClient, _ := createUserAndClientForTest(ctx, t)
+
+		authenticator, _ := registerWebAuthnCredentialForTest(ctx, t, userClient)
+
+		c := buildSimpleClient(t)
+
+		options, err := c.BeginWebAuthnLogin(ctx, &types.WebAuthnLoginBeginInput{Username: user.Username})
+		requireNotNilAndNoProblems(t, options, err)
+
+		assertion, err := authenticator.GetAssertion(options.Options)
+		require.NoError(t, err)
+
+		loginInput := &types.UserLoginInput{
+			Username:              user.Username,
+			Password:              user.HashedPassword,
+			WebAuthnCeremonyToken: options.CeremonyToken,
+			WebAuthnAssertion:     assertion,
+		}
+
+		cookie, err := c.BeginSession(ctx, loginInput)
+		assert.NoError(t, err)
+		assert.NotNil(t, cookie)
+
+		cookie, err = c.BeginSession(ctx, loginInput)
+		assert.Error(t, err)
+		assert.Nil(t, cookie)
+	})
+}
@@ -31,15 +31,16 @@ func (s *TestSuite) TestWebhooks_Creating() {
 			defer span.End()
 
 			stopChan := make(chan bool, 1)
-			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, stopChan)
+			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, nil, stopChan)
 			require.NotNil(t, notificationsChan)
 			require.NoError(t, err)
 
 			// Create webhook.
 			exampleWebhook := fakes.BuildFakeWebhook()
 			exampleWebhookInput := fakes.BuildFakeWebhookCreationInputFromWebhook(exampleWebhook)
-			createdWebhookID, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
+			webhookCreationResponse, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
 			require.NoError(t, err)
+			createdWebhookID := webhookCreationResponse.ID
 
 			n := <-notificationsChan
 			assert.Equal(t, n.DataType, types.WebhookDataType)
@@ -71,8 +72,9 @@ func (s *TestSuite) TestWebhooks_Creating() {
 			// Create webhook.
 			exampleWebhook := fakes.BuildFakeWebhook()
 			exampleWebhookInput := fakes.BuildFakeWebhookCreationInputFromWebhook(exampleWebhook)
-			createdWebhookID, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
+			webhookCreationResponse, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
 			require.NoError(t, err)
+			createdWebhookID := webhookCreationResponse.ID
 
 			var createdWebhook *types.Webhook
 			checkFunc := func() bool {
@@ -94,6 +96,98 @@ func (s *TestSuite) TestWebhooks_Creating() {
 	})
 }
 
+func (s *TestSuite) TestWebhooks_Updating_Returns404ForNonexistentWebhook() {
+	s.runForEachClientExcept("it should return an error when trying to update something that does not exist", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			exampleWebhook := fakes.BuildFakeWebhook()
+			exampleWebhook.ID = nonexistentID
+
+			assert.Error(t, testClients.main.UpdateWebhook(ctx, exampleWebhook))
+		}
+	})
+}
+
+func (s *TestSuite) TestWebhooks_Updating() {
+	s.runForEachClientExcept("it should be possible to update a webhook", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			// Create webhook.
+			exampleWebhook := fakes.BuildFakeWebhook()
+			exampleWebhookInput := fakes.BuildFakeWebhookCreationInputFromWebhook(exampleWebhook)
+			webhookCreationResponse, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
+			require.NoError(t, err)
+
+			var createdWebhook *types.Webhook
+			checkFunc := func() bool {
+				createdWebhook, err = testClients.main.GetWebhook(ctx, webhookCreationResponse.ID)
+				return assert.NotNil(t, createdWebhook) && assert.NoError(t, err)
+			}
+			assert.Eventually(t, checkFunc, creationTimeout, waitPeriod)
+
+			// Change webhook.
+			updatedWebhook := fakes.BuildFakeWebhook()
+			createdWebhook.Update(fakes.BuildFakeWebhookUpdateInputFromWebhook(updatedWebhook))
+			assert.NoError(t, testClients.main.UpdateWebhook(ctx, createdWebhook))
+
+			var actual *types.Webhook
+			checkFunc = func() bool {
+				actual, err = testClients.main.GetWebhook(ctx, createdWebhook.ID)
+				return assert.NoError(t, err) && assert.NotNil(t, actual) && assert.NotNil(t, actual.LastUpdatedOn)
+			}
+			assert.Eventually(t, checkFunc, creationTimeout, waitPeriod)
+
+			// assert webhook equality
+			checkWebhookEquality(t, updatedWebhook, actual)
+
+			// Clean up.
+			assert.NoError(t, testClients.main.ArchiveWebhook(ctx, createdWebhook.ID))
+		}
+	})
+}
+
+func (s *TestSuite) TestWebhooks_RotatingSigningSecret() {
+	s.runForEachClientExcept("should be able to rotate a webhook's signing secret", func(testClients *testClientWrapper) func() {
+		return func() {
+			t := s.T()
+
+			ctx, span := tracing.StartCustomSpan(s.ctx, t.Name())
+			defer span.End()
+
+			// Create webhook.
+			exampleWebhook := fakes.BuildFakeWebhook()
+			exampleWebhookInput := fakes.BuildFakeWebhookCreationInputFromWebhook(exampleWebhook)
+			webhookCreationResponse, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
+			require.NoError(t, err)
+			require.NotEmpty(t, webhookCreationResponse.SigningSecret)
+
+			var createdWebhook *types.Webhook
+			checkFunc := func() bool {
+				createdWebhook, err = testClients.main.GetWebhook(ctx, webhookCreationResponse.ID)
+				return assert.NotNil(t, createdWebhook) && assert.NoError(t, err)
+			}
+			assert.Eventually(t, checkFunc, creationTimeout, waitPeriod)
+
+			// Rotate secret.
+			rotationResponse, err := testClients.main.RotateWebhookSigningSecret(ctx, createdWebhook.ID)
+			requireNotNilAndNoProblems(t, rotationResponse, err)
+			assert.NotEqual(t, webhookCreationResponse.SigningSecret, rotationResponse.SigningSecret)
+			assert.NotZero(t, rotationResponse.PreviousSigningSecretExpiresOn)
+
+			// Clean up.
+			assert.NoError(t, testClients.main.ArchiveWebhook(ctx, createdWebhook.ID))
+		}
+	})
+}
+
 func (s *TestSuite) TestWebhooks_Reading_Returns404ForNonexistentWebhook() {
 	s.runForEachClientExcept("should fail to read non-existent webhook", func(testClients *testClientWrapper) func() {
 		return func() {
@@ -118,7 +212,7 @@ func (s *TestSuite) TestWebhooks_Listing() {
 			defer span.End()
 
 			stopChan := make(chan bool, 1)
-			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, stopChan)
+			notificationsChan, err := testClients.main.SubscribeToDataChangeNotifications(ctx, nil, stopChan)
 			require.NotNil(t, notificationsChan)
 			require.NoError(t, err)
 
@@ -128,8 +222,9 @@ func (s *TestSuite) TestWebhooks_Listing() {
 				// Create webhook.
 				exampleWebhook := fakes.BuildFakeWebhook()
 				exampleWebhookInput := fakes.BuildFakeWebhookCreationInputFromWebhook(exampleWebhook)
-				createdWebhookID, webhookCreationErr := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
+				webhookCreationResponse, webhookCreationErr := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
 				require.NoError(t, webhookCreationErr)
+				createdWebhookID := webhookCreationResponse.ID
 
 				n := <-notificationsChan
 				assert.Equal(t, n.DataType, types.WebhookDataType)
@@ -167,8 +262,9 @@ func (s *TestSuite) TestWebhooks_Listing() {
 				// Create webhook.
 				exampleWebhook := fakes.BuildFakeWebhook()
 				exampleWebhookInput := fakes.BuildFakeWebhookCreationInputFromWebhook(exampleWebhook)
-				createdWebhookID, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
+				webhookCreationResponse, err := testClients.main.CreateWebhook(ctx, exampleWebhookInput)
 				require.NoError(t, err)
+				createdWebhookID := webhookCreationResponse.ID
 
 				var createdWebhook *types.Webhook
 				checkFunc := func() bool {
@@ -38,3 +38,6 @@ func PreUpdateMessageMatcher(*types.PreUpdateMessage) bool { return true }
 
 // PreArchiveMessageMatcher matches the types.PreArchiveMessage type.
 func PreArchiveMessageMatcher(*types.PreArchiveMessage) bool { return true }
+
+// AuditLogEntryCreationInputMatcher matches the types.AuditLogEntryCreationInput type.
+func AuditLogEntryCreationInputMatcher(*types.AuditLogEntryCreationInput) bool { return true }
@@ -0,0 +1,286 @@
+package testutils
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	mockOIDCKeyID       = "mock_oidc_key"
+	mockOIDCKeySize     = 2048
+	mockOIDCTokenExpiry = time.Hour
+)
+
+// MockOIDCIdentity is who a MockOIDCProvider says is signing in.
+type MockOIDCIdentity struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+}
+
+type mockOIDCAuthorization struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	identity      MockOIDCIdentity
+}
+
+// MockOIDCProvider is a bare-bones, in-memory OpenID Connect identity provider for tests. It serves discovery,
+// JWKS, authorization, and token endpoints, approves every authorization request for its current identity,
+// and insists on S256 PKCE.
+type MockOIDCProvider struct {
+	server         *httptest.Server
+	signer         jose.Signer
+	publicKey      jose.JSONWebKey
+	authorizations map[string]*mockOIDCAuthorization
+	identity       MockOIDCIdentity
+	authMu         sync.Mutex
+	identityMu     sync.RWMutex
+}
+
+// NewMockOIDCProvider starts a MockOIDCProvider that signs people in as a given identity. Callers are
+// responsible for calling Close.
+func NewMockOIDCProvider(identity MockOIDCIdentity) (*MockOIDCProvider, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, mockOIDCKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("generating signing key: %w", err)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.RS256,
+		Key:       jose.JSONWebKey{Key: privateKey, KeyID: mockOIDCKeyID},
+	}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return nil, fmt.Errorf("building signer: %w", err)
+	}
+
+	p := &MockOIDCProvider{
+		signer:         signer,
+		publicKey:      jose.JSONWebKey{Key: &privateKey.PublicKey, KeyID: mockOIDCKeyID, Algorithm: string(jose.RS256), Use: "sig"},
+		authorizations: map[string]*mockOIDCAuthorization{},
+		identity:       identity,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discoveryHandler)
+	mux.HandleFunc("/jwks", p.jwksHandler)
+	mux.HandleFunc("/authorize", p.authorizeHandler)
+	mux.HandleFunc("/token", p.tokenHandler)
+
+	p.server = httptest.NewServer(mux)
+
+	return p, nil
+}
+
+// IssuerURL returns the provider's issuer URL.
+func (p *MockOIDCProvider) IssuerURL() string {
+	return p.server.URL
+}
+
+// Close shuts the provider down.
+func (p *MockOIDCProvider) Close() {
+	p.server.Close()
+}
+
+// SetIdentity changes who the provider signs people in as.
+func (p *MockOIDCProvider) SetIdentity(identity MockOIDCIdentity) {
+	p.identityMu.Lock()
+	defer p.identityMu.Unlock()
+
+	p.identity = identity
+}
+
+// Authorize visits an authorization URL the way a browser would, and returns the URL the provider
+// redirected back to, complete with an authorization code and state.
+func (p *MockOIDCProvider) Authorize(authCodeURL string) (*url.URL, error) {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	res, err := client.Get(authCodeURL)
+	if err != nil {
+		return nil, fmt.Errorf("requesting authorization: %w", err)
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusFound {
+		return nil, fmt.Errorf("unexpected authorization response status: %d", res.StatusCode)
+	}
+
+	return res.Location()
+}
+
+func (p *MockOIDCProvider) discoveryHandler(res http.ResponseWriter, _ *http.Request) {
+	writeMockOIDCJSON(res, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.server.URL,
+		"authorization_endpoint":                p.server.URL + "/authorize",
+		"token_endpoint":                        p.server.URL + "/token",
+		"jwks_uri":                              p.server.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{string(jose.RS256)},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *MockOIDCProvider) jwksHandler(res http.ResponseWriter, _ *http.Request) {
+	writeMockOIDCJSON(res, http.StatusOK, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{p.publicKey}})
+}
+
+func (p *MockOIDCProvider) authorizeHandler(res http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	if query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(res, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.String() == "" {
+		http.Error(res, "invalid redirect URI", http.StatusBadRequest)
+		return
+	}
+
+	code, err := randomMockOIDCValue()
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	p.identityMu.RLock()
+	identity := p.identity
+	p.identityMu.RUnlock()
+
+	p.authMu.Lock()
+	p.authorizations[code] = &mockOIDCAuthorization{
+		clientID:      query.Get("client_id"),
+		redirectURI:   redirectURI.String(),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		identity:      identity,
+	}
+	p.authMu.Unlock()
+
+	values := redirectURI.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirectURI.RawQuery = values.Encode()
+
+	http.Redirect(res, req, redirectURI.String(), http.StatusFound)
+}
+
+func (p *MockOIDCProvider) tokenHandler(res http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		writeMockOIDCTokenError(res, "invalid_request")
+		return
+	}
+
+	clientID, _, ok := req.BasicAuth()
+	if !ok {
+		clientID = req.PostForm.Get("client_id")
+	}
+
+	code := req.PostForm.Get("code")
+
+	// authorization codes are single use.
+	p.authMu.Lock()
+	authorization, found := p.authorizations[code]
+	delete(p.authorizations, code)
+	p.authMu.Unlock()
+
+	if req.PostForm.Get("grant_type") != "authorization_code" || !found {
+		writeMockOIDCTokenError(res, "invalid_grant")
+		return
+	}
+
+	if authorization.clientID != clientID || authorization.redirectURI != req.PostForm.Get("redirect_uri") {
+		writeMockOIDCTokenError(res, "invalid_grant")
+		return
+	}
+
+	verifierHash := sha256.Sum256([]byte(req.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(verifierHash[:]) != authorization.codeChallenge {
+		writeMockOIDCTokenError(res, "invalid_grant")
+		return
+	}
+
+	idToken, err := p.signIDToken(authorization)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	accessToken, err := randomMockOIDCValue()
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeMockOIDCJSON(res, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   int(mockOIDCTokenExpiry.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+func (p *MockOIDCProvider) signIDToken(authorization *mockOIDCAuthorization) (string, error) {
+	now := time.Now()
+
+	claims := map[string]interface{}{
+		"iss":   p.server.URL,
+		"sub":   authorization.identity.Subject,
+		"aud":   authorization.clientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(mockOIDCTokenExpiry).Unix(),
+		"nonce": authorization.nonce,
+	}
+
+	if authorization.identity.Email != "" {
+		claims["email"] = authorization.identity.Email
+		claims["email_verified"] = authorization.identity.EmailVerified
+	}
+
+	if authorization.identity.PreferredUsername != "" {
+		claims["preferred_username"] = authorization.identity.PreferredUsername
+	}
+
+	return jwt.Signed(p.signer).Claims(claims).CompactSerialize()
+}
+
+func randomMockOIDCValue() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("reading from secure random source")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func writeMockOIDCJSON(res http.ResponseWriter, status int, body interface{}) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	_ = json.NewEncoder(res).Encode(body)
+}
+
+func writeMockOIDCTokenError(res http.ResponseWriter, code string) {
+	writeMockOIDCJSON(res, http.StatusBadRequest, map[string]string{"error": code})
+}
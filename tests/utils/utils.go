@@ -59,7 +59,7 @@ func CreateServiceUser(ctx context.Context, address, username string) (*types.Us
 		return nil, fmt.Errorf("generating totp code: %w", tokenErr)
 	}
 
-	if validationErr := c.VerifyTOTPSecret(ctx, ucr.CreatedUserID, token); validationErr != nil {
+	if _, validationErr := c.VerifyTOTPSecret(ctx, ucr.CreatedUserID, token); validationErr != nil {
 		return nil, fmt.Errorf("verifying totp code: %w", validationErr)
 	}
 
@@ -0,0 +1,191 @@
+package testutils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	webAuthnFlagUserPresent            = 0x01
+	webAuthnFlagUserVerified           = 0x04
+	webAuthnFlagAttestedCredentialData = 0x40
+
+	webAuthnCredentialIDLength = 32
+)
+
+type softwareCredential struct {
+	id         []byte
+	userHandle []byte
+	privateKey *ecdsa.PrivateKey
+	signCount  uint32
+}
+
+// SoftwareAuthenticator is a bare-bones, in-memory WebAuthn authenticator for tests. It produces the
+// same JSON a browser would hand back from navigator.credentials.create and navigator.credentials.get,
+// using ES256 credentials and "none" attestation.
+type SoftwareAuthenticator struct {
+	origin      string
+	credentials []*softwareCredential
+}
+
+// NewSoftwareAuthenticator builds a SoftwareAuthenticator that claims to be running on a given origin.
+func NewSoftwareAuthenticator(origin string) *SoftwareAuthenticator {
+	return &SoftwareAuthenticator{origin: origin}
+}
+
+func (a *SoftwareAuthenticator) buildClientDataJSON(ceremonyType protocol.CeremonyType, challenge []byte) ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"type":      string(ceremonyType),
+		"challenge": base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":    a.origin,
+	})
+}
+
+func buildAuthenticatorData(rpID string, flags byte, signCount uint32) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+
+	authData := append([]byte{}, rpIDHash[:]...)
+	authData = append(authData, flags)
+
+	counter := make([]byte, 4)
+	binary.BigEndian.PutUint32(counter, signCount)
+
+	return append(authData, counter...)
+}
+
+func encodeCOSEPublicKey(key *ecdsa.PublicKey) ([]byte, error) {
+	x, y := make([]byte, 32), make([]byte, 32)
+	key.X.FillBytes(x)
+	key.Y.FillBytes(y)
+
+	return cbor.Marshal(map[int]interface{}{
+		1:  2,  // key type: EC2
+		3:  -7, // algorithm: ES256
+		-1: 1,  // curve: P-256
+		-2: x,
+		-3: y,
+	})
+}
+
+// CreateCredential creates a new credential in response to registration options, and returns what a browser
+// would return from navigator.credentials.create.
+func (a *SoftwareAuthenticator) CreateCredential(options *protocol.CredentialCreation) (json.RawMessage, error) {
+	if options == nil {
+		return nil, errors.New("nil registration options provided")
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	credential := &softwareCredential{
+		id:         make([]byte, webAuthnCredentialIDLength),
+		userHandle: options.Response.User.ID,
+		privateKey: privateKey,
+	}
+
+	if _, err = rand.Read(credential.id); err != nil {
+		return nil, err
+	}
+
+	publicKey, err := encodeCOSEPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialIDLength := make([]byte, 2)
+	binary.BigEndian.PutUint16(credentialIDLength, uint16(len(credential.id)))
+
+	authData := buildAuthenticatorData(options.Response.RelyingParty.ID, webAuthnFlagUserPresent|webAuthnFlagUserVerified|webAuthnFlagAttestedCredentialData, credential.signCount)
+	authData = append(authData, make([]byte, 16)...) // AAGUID
+	authData = append(authData, credentialIDLength...)
+	authData = append(authData, credential.id...)
+	authData = append(authData, publicKey...)
+
+	attestationObject, err := cbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": authData,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataJSON, err := a.buildClientDataJSON(protocol.CreateCeremony, options.Response.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	a.credentials = append(a.credentials, credential)
+
+	return json.Marshal(map[string]interface{}{
+		"id":    base64.RawURLEncoding.EncodeToString(credential.id),
+		"rawId": base64.RawURLEncoding.EncodeToString(credential.id),
+		"type":  string(protocol.PublicKeyCredentialType),
+		"response": map[string]string{
+			"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientDataJSON),
+			"attestationObject": base64.RawURLEncoding.EncodeToString(attestationObject),
+		},
+	})
+}
+
+// GetAssertion signs the challenge in a set of login options with a credential they allow, and returns
+// what a browser would return from navigator.credentials.get.
+func (a *SoftwareAuthenticator) GetAssertion(options *protocol.CredentialAssertion) (json.RawMessage, error) {
+	if options == nil {
+		return nil, errors.New("nil login options provided")
+	}
+
+	var credential *softwareCredential
+
+	for _, c := range a.credentials {
+		for _, allowed := range options.Response.AllowedCredentials {
+			if string(allowed.CredentialID) == string(c.id) {
+				credential = c
+				break
+			}
+		}
+	}
+
+	if credential == nil {
+		return nil, errors.New("no allowed credential found")
+	}
+
+	credential.signCount++
+	authData := buildAuthenticatorData(options.Response.RelyingPartyID, webAuthnFlagUserPresent|webAuthnFlagUserVerified, credential.signCount)
+
+	clientDataJSON, err := a.buildClientDataJSON(protocol.AssertCeremony, options.Response.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signedData := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+
+	signature, err := ecdsa.SignASN1(rand.Reader, credential.privateKey, signedData[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"id":    base64.RawURLEncoding.EncodeToString(credential.id),
+		"rawId": base64.RawURLEncoding.EncodeToString(credential.id),
+		"type":  string(protocol.PublicKeyCredentialType),
+		"response": map[string]string{
+			"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientDataJSON),
+			"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+			"signature":         base64.RawURLEncoding.EncodeToString(signature),
+			"userHandle":        base64.RawURLEncoding.EncodeToString(credential.userHandle),
+		},
+	})
+}